@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"os"
 	"path"
 	"reflect"
 
@@ -57,6 +58,7 @@ func NewDebugCommand() *cobra.Command {
 	meta.AddCommand(encodeBackupMetaCommand())
 	meta.AddCommand(setPDConfigCommand())
 	meta.AddCommand(searchStreamBackupCommand())
+	meta.AddCommand(newVerifyCommand())
 	meta.Hidden = true
 
 	return meta
@@ -499,3 +501,44 @@ func searchStreamBackupCommand() *cobra.Command {
 
 	return searchBackupCMD
 }
+
+func newVerifyCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "verify",
+		Short: "verify the backup data thoroughly without a cluster",
+		Long: "verify the backup data thoroughly without a cluster. " +
+			"it reads every file of the full backup or the log backup, checks the files against the metadata, " +
+			"decodes the KVs and recomputes the checksums of the tables, then emits a report in JSON.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithCancel(GetDefaultContext())
+			defer cancel()
+
+			var cfg task.VerifyConfig
+			if err := cfg.ParseFromFlags(cmd.Flags()); err != nil {
+				return errors.Trace(err)
+			}
+			report, err := task.RunVerify(ctx, &cfg)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			reportJSON, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(cfg.Output) > 0 {
+				if err = os.WriteFile(cfg.Output, reportJSON, 0o644); err != nil {
+					return errors.Trace(err)
+				}
+			} else {
+				cmd.Println(string(reportJSON))
+			}
+			if !report.Passed {
+				return errors.Annotatef(berrors.ErrBackupVerifyFailed, "found %d issues", len(report.Issues))
+			}
+			return nil
+		},
+	}
+	task.DefineVerifyFlags(command.Flags())
+	return command
+}
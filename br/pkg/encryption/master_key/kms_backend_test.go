@@ -5,9 +5,15 @@ package encryption
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/tidb/br/pkg/kms"
 	"github.com/stretchr/testify/require"
 )
 
@@ -111,3 +117,48 @@ func TestKmsBackendDecryptErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestKmsBackendWithVault(t *testing.T) {
+	ctx := context.Background()
+	// a stand-in of Vault Transit, whose ciphertext is the base64 encoded plaintext with the prefix `vault:v1:`.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Ciphertext string `json:"ciphertext"`
+		}
+		require.Equal(t, "/v1/transit/decrypt/test-key", r.URL.Path)
+		require.Equal(t, "test-token", r.Header.Get("X-Vault-Token"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		resp := map[string]any{"data": map[string]string{"plaintext": strings.TrimPrefix(req.Ciphertext, "vault:v1:")}}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+	t.Setenv(kms.VaultTokenEnv, "test-token")
+
+	dataKey := make([]byte, 32)
+	_, err := rand.Read(dataKey)
+	require.NoError(t, err)
+	memBackend, err := NewMemAesGcmBackend(dataKey)
+	require.NoError(t, err)
+	iv, err := NewIVGcm()
+	require.NoError(t, err)
+	plaintext := []byte("log backup data")
+	content, err := memBackend.EncryptContent(ctx, plaintext, iv)
+	require.NoError(t, err)
+	content.Metadata[MetadataKeyKmsVendor] = []byte(kms.EncryptionVendorNameVault)
+	content.Metadata[MetadataKeyKmsCiphertextKey] = []byte("vault:v1:" + base64.StdEncoding.EncodeToString(dataKey))
+
+	backend, err := CreateBackend(&encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Kms{
+			Kms: &encryptionpb.MasterKeyKms{
+				Vendor:   "vault",
+				KeyId:    "transit/test-key",
+				Endpoint: server.URL,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer backend.Close()
+	decrypted, err := backend.Decrypt(ctx, content)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+}
@@ -55,23 +55,13 @@ func createCloudBackend(config *encryptionpb.MasterKeyKms) (Backend, error) {
 		zap.String("Vendor", config.GetVendor()))
 
 	switch config.Vendor {
-	case StorageVendorNameAWS:
-		kmsProvider, err := kms.NewAwsKms(config)
-		if err != nil {
-			return nil, errors.Annotate(err, "new AWS KMS")
-		}
-		return NewKmsBackend(kmsProvider)
-
 	case StorageVendorNameAzure:
 		return nil, errors.Errorf("not implemented Azure KMS")
-	case StorageVendorNameGCP:
-		kmsProvider, err := kms.NewGcpKms(config)
+	default:
+		kmsProvider, err := kms.NewProvider(config)
 		if err != nil {
-			return nil, errors.Annotate(err, "new GCP KMS")
+			return nil, errors.Annotatef(err, "new %s KMS", config.Vendor)
 		}
 		return NewKmsBackend(kmsProvider)
-
-	default:
-		return nil, errors.Errorf("vendor not found: %s", config.Vendor)
 	}
 }
@@ -18,6 +18,12 @@ const (
 	EncryptionVendorNameAwsKms = "AWS"
 )
 
+func init() {
+	RegisterProvider(EncryptionVendorNameAwsKms, func(config *encryptionpb.MasterKeyKms) (Provider, error) {
+		return NewAwsKms(config)
+	})
+}
+
 type AwsKms struct {
 	client       *kms.KMS
 	currentKeyID string
@@ -22,6 +22,12 @@ const (
 	StorageVendorNameGcp = "gcp"
 )
 
+func init() {
+	RegisterProvider(StorageVendorNameGcp, func(config *encryptionpb.MasterKeyKms) (Provider, error) {
+		return NewGcpKms(config)
+	})
+}
+
 type GcpKms struct {
 	config *encryptionpb.MasterKeyKms
 	// the location prefix of key id,
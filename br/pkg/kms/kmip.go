@@ -0,0 +1,296 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+)
+
+const (
+	// EncryptionVendorNameKMIP is the vendor of the key management servers speaking KMIP.
+	EncryptionVendorNameKMIP = "kmip"
+
+	// KMIPCAPathEnv, KMIPCertPathEnv and KMIPKeyPathEnv are the environment variables of the PEM files used to
+	// establish the TLS connection to the KMIP server, they are read from the environment because the master key
+	// config doesn't carry credentials. The client certificate is optional if the server doesn't require it.
+	KMIPCAPathEnv   = "KMIP_CA_PATH"
+	KMIPCertPathEnv = "KMIP_CERT_PATH"
+	KMIPKeyPathEnv  = "KMIP_KEY_PATH"
+
+	kmipRequestTimeout = 30 * time.Second
+	// kmipMaxMessageSize limits the size of the response, the response of decrypting a data key is tiny.
+	kmipMaxMessageSize = 1 << 20
+)
+
+// The tags, types and enumerations of KMIP 1.2 used by the Decrypt operation.
+const (
+	kmipTagBatchCount           = 0x42000D
+	kmipTagBatchItem            = 0x42000F
+	kmipTagData                 = 0x4200C2
+	kmipTagOperation            = 0x42005C
+	kmipTagProtocolVersion      = 0x420069
+	kmipTagProtocolVersionMajor = 0x42006A
+	kmipTagProtocolVersionMinor = 0x42006B
+	kmipTagRequestHeader        = 0x420077
+	kmipTagRequestMessage       = 0x420078
+	kmipTagRequestPayload       = 0x420079
+	kmipTagResponseMessage      = 0x42007B
+	kmipTagResponsePayload      = 0x42007C
+	kmipTagResultMessage        = 0x42007D
+	kmipTagResultReason         = 0x42007E
+	kmipTagResultStatus         = 0x42007F
+	kmipTagUniqueIdentifier     = 0x420094
+
+	kmipTypeStructure   = 0x01
+	kmipTypeInteger     = 0x02
+	kmipTypeEnumeration = 0x05
+	kmipTypeTextString  = 0x07
+	kmipTypeByteString  = 0x08
+
+	kmipOperationDecrypt = 0x1F
+
+	kmipResultStatusSuccess = 0x00
+
+	kmipResultReasonItemNotFound       = 0x01
+	kmipResultReasonCryptographicError = 0x0A
+	kmipResultReasonPermissionDenied   = 0x0C
+)
+
+func init() {
+	RegisterProvider(EncryptionVendorNameKMIP, func(config *encryptionpb.MasterKeyKms) (Provider, error) {
+		return NewKMIPKms(config)
+	})
+}
+
+// KMIPKms decrypts the data keys by the Decrypt operation of a KMIP server, the data key is the ciphertext of the
+// Encrypt operation with the default cryptographic parameters of the key.
+// TiKV doesn't support KMIP, so it can only be used by BR itself, such as restoring the log backup, but not by the
+// backup tasks executed by TiKV.
+type KMIPKms struct {
+	endpoint string
+	keyID    string
+	tls      *tls.Config
+}
+
+// NewKMIPKms creates the KMIP KMS by the master key config, where the key id is the unique identifier of the key
+// and the endpoint is the `host:port` of the KMIP server.
+func NewKMIPKms(config *encryptionpb.MasterKeyKms) (*KMIPKms, error) {
+	if len(config.KeyId) == 0 {
+		return nil, errors.New("missing KMIP key id")
+	}
+	if len(config.Endpoint) == 0 {
+		return nil, errors.New("missing KMIP endpoint")
+	}
+	host, _, err := net.SplitHostPort(config.Endpoint)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid KMIP endpoint %s, should be {host}:{port}", config.Endpoint)
+	}
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if caPath := os.Getenv(KMIPCAPathEnv); len(caPath) > 0 {
+		caPEM, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to read the CA of KMIP")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificate found in %s", caPath)
+		}
+	}
+	certPath, keyPath := os.Getenv(KMIPCertPathEnv), os.Getenv(KMIPKeyPathEnv)
+	if len(certPath) > 0 || len(keyPath) > 0 {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load the client certificate of KMIP")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return &KMIPKms{
+		endpoint: config.Endpoint,
+		keyID:    config.KeyId,
+		tls:      tlsConfig,
+	}, nil
+}
+
+func (k *KMIPKms) Name() string {
+	return EncryptionVendorNameKMIP
+}
+
+// DecryptDataKey decrypts the data key by the Decrypt operation, a connection is used for each data key because
+// the data keys are only decrypted a few times in a task.
+func (k *KMIPKms) DecryptDataKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, kmipRequestTimeout)
+	defer cancel()
+	dialer := &tls.Dialer{Config: k.tls}
+	conn, err := dialer.DialContext(ctx, "tcp", k.endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to connect to KMIP server")
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	request := encodeKMIPStructure(kmipTagRequestMessage,
+		encodeKMIPStructure(kmipTagRequestHeader,
+			encodeKMIPStructure(kmipTagProtocolVersion,
+				encodeKMIPInteger(kmipTagProtocolVersionMajor, kmipTypeInteger, 1),
+				encodeKMIPInteger(kmipTagProtocolVersionMinor, kmipTypeInteger, 2),
+			),
+			encodeKMIPInteger(kmipTagBatchCount, kmipTypeInteger, 1),
+		),
+		encodeKMIPStructure(kmipTagBatchItem,
+			encodeKMIPInteger(kmipTagOperation, kmipTypeEnumeration, kmipOperationDecrypt),
+			encodeKMIPStructure(kmipTagRequestPayload,
+				encodeKMIP(kmipTagUniqueIdentifier, kmipTypeTextString, []byte(k.keyID)),
+				encodeKMIP(kmipTagData, kmipTypeByteString, dataKey),
+			),
+		),
+	)
+	if _, err := conn.Write(request); err != nil {
+		return nil, errors.Annotate(err, "kmip decrypt request failed")
+	}
+	response, err := readKMIPMessage(conn)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read kmip decrypt response")
+	}
+
+	batchItem := response.child(kmipTagBatchItem)
+	if response.tag != kmipTagResponseMessage || batchItem == nil {
+		return nil, errors.New("kmip decrypt response corrupted")
+	}
+	if status := batchItem.child(kmipTagResultStatus); status == nil || status.integer() != kmipResultStatusSuccess {
+		var reason uint32
+		if r := batchItem.child(kmipTagResultReason); r != nil {
+			reason = r.integer()
+		}
+		var msg string
+		if m := batchItem.child(kmipTagResultMessage); m != nil {
+			msg = string(m.value)
+		}
+		return nil, classifyKMIPError(reason, msg)
+	}
+	data := batchItem.child(kmipTagResponsePayload).child(kmipTagData)
+	if data == nil {
+		return nil, errors.New("kmip decrypt response corrupted")
+	}
+	return data.value, nil
+}
+
+func classifyKMIPError(reason uint32, msg string) error {
+	err := errors.Errorf("kmip returns reason 0x%02X: %s", reason, msg)
+	switch reason {
+	case kmipResultReasonItemNotFound, kmipResultReasonCryptographicError, kmipResultReasonPermissionDenied:
+		return errors.Annotate(err, "wrong master key")
+	default:
+		return errors.Annotate(err, "KMS error")
+	}
+}
+
+func (*KMIPKms) Close() {}
+
+// kmipItem is a decoded TTLV item, the children are only decoded for the structures.
+type kmipItem struct {
+	tag      uint32
+	typ      byte
+	value    []byte
+	children []*kmipItem
+}
+
+func (i *kmipItem) child(tag uint32) *kmipItem {
+	if i == nil {
+		return nil
+	}
+	for _, c := range i.children {
+		if c.tag == tag {
+			return c
+		}
+	}
+	return nil
+}
+
+func (i *kmipItem) integer() uint32 {
+	if len(i.value) < 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(i.value)
+}
+
+// encodeKMIP encodes an item in TTLV, the value is padded to a multiple of 8 bytes.
+func encodeKMIP(tag uint32, typ byte, value []byte) []byte {
+	buf := make([]byte, 8, 8+(len(value)+7)/8*8)
+	buf[0], buf[1], buf[2] = byte(tag>>16), byte(tag>>8), byte(tag)
+	buf[3] = typ
+	binary.BigEndian.PutUint32(buf[4:], uint32(len(value)))
+	buf = append(buf, value...)
+	return append(buf, make([]byte, cap(buf)-len(buf))...)
+}
+
+func encodeKMIPInteger(tag uint32, typ byte, v uint32) []byte {
+	return encodeKMIP(tag, typ, binary.BigEndian.AppendUint32(nil, v))
+}
+
+func encodeKMIPStructure(tag uint32, children ...[]byte) []byte {
+	return encodeKMIP(tag, kmipTypeStructure, bytes.Join(children, nil))
+}
+
+func readKMIPMessage(r io.Reader) (*kmipItem, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Trace(err)
+	}
+	length := binary.BigEndian.Uint32(header[4:])
+	if length > kmipMaxMessageSize {
+		return nil, errors.Errorf("kmip message too large: %d", length)
+	}
+	msg := make([]byte, 8+length)
+	copy(msg, header)
+	if _, err := io.ReadFull(r, msg[8:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	item, _, err := decodeKMIP(msg)
+	return item, err
+}
+
+// decodeKMIP decodes the first item of the buffer and returns the rest.
+func decodeKMIP(buf []byte) (*kmipItem, []byte, error) {
+	if len(buf) < 8 {
+		return nil, nil, errors.New("kmip item truncated")
+	}
+	item := &kmipItem{
+		tag: uint32(buf[0])<<16 | uint32(buf[1])<<8 | uint32(buf[2]),
+		typ: buf[3],
+	}
+	length := int(binary.BigEndian.Uint32(buf[4:]))
+	padded := (length + 7) / 8 * 8
+	if len(buf) < 8+padded {
+		return nil, nil, errors.Errorf("kmip item 0x%06X truncated", item.tag)
+	}
+	item.value = buf[8 : 8+length]
+	if item.typ == kmipTypeStructure {
+		for rest := item.value; len(rest) > 0; {
+			var (
+				c   *kmipItem
+				err error
+			)
+			if c, rest, err = decodeKMIP(rest); err != nil {
+				return nil, nil, err
+			}
+			item.children = append(item.children, c)
+		}
+	}
+	return item, buf[8+padded:], nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/stretchr/testify/require"
+)
+
+const testKMIPKeyID = "test-key"
+
+// newKMIPServer starts a stand-in of the KMIP server and returns its address and the path of its CA. The ciphertext
+// of the key `test-key` is the plaintext with the prefix `kmip:`.
+func newKMIPServer(t *testing.T) (string, string) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: priv}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			serveKMIPConn(conn)
+		}
+	}()
+	return listener.Addr().String(), caPath
+}
+
+func serveKMIPConn(conn net.Conn) {
+	defer conn.Close()
+	request, err := readKMIPMessage(conn)
+	if err != nil {
+		return
+	}
+	payload := request.child(kmipTagBatchItem).child(kmipTagRequestPayload)
+	ciphertext := payload.child(kmipTagData).value
+	var result [][]byte
+	switch {
+	case string(payload.child(kmipTagUniqueIdentifier).value) != testKMIPKeyID:
+		result = [][]byte{
+			encodeKMIPInteger(kmipTagResultStatus, kmipTypeEnumeration, 1),
+			encodeKMIPInteger(kmipTagResultReason, kmipTypeEnumeration, kmipResultReasonItemNotFound),
+			encodeKMIP(kmipTagResultMessage, kmipTypeTextString, []byte("object not found")),
+		}
+	case !bytes.HasPrefix(ciphertext, []byte("kmip:")):
+		result = [][]byte{
+			encodeKMIPInteger(kmipTagResultStatus, kmipTypeEnumeration, 1),
+			encodeKMIPInteger(kmipTagResultReason, kmipTypeEnumeration, kmipResultReasonCryptographicError),
+		}
+	default:
+		result = [][]byte{
+			encodeKMIPInteger(kmipTagResultStatus, kmipTypeEnumeration, kmipResultStatusSuccess),
+			encodeKMIPStructure(kmipTagResponsePayload,
+				encodeKMIP(kmipTagUniqueIdentifier, kmipTypeTextString, []byte(testKMIPKeyID)),
+				encodeKMIP(kmipTagData, kmipTypeByteString, bytes.TrimPrefix(ciphertext, []byte("kmip:"))),
+			),
+		}
+	}
+	batchItem := append([][]byte{encodeKMIPInteger(kmipTagOperation, kmipTypeEnumeration, kmipOperationDecrypt)}, result...)
+	_, _ = conn.Write(encodeKMIPStructure(kmipTagResponseMessage,
+		encodeKMIPStructure(kmipTagBatchItem, batchItem...),
+	))
+}
+
+func TestKMIPKmsDecryptDataKey(t *testing.T) {
+	ctx := context.Background()
+	addr, caPath := newKMIPServer(t)
+	t.Setenv(KMIPCAPathEnv, caPath)
+	t.Setenv(KMIPCertPathEnv, "")
+	t.Setenv(KMIPKeyPathEnv, "")
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	provider, err := NewProvider(&encryptionpb.MasterKeyKms{Vendor: "KMIP", KeyId: testKMIPKeyID, Endpoint: addr})
+	require.NoError(t, err)
+	defer provider.Close()
+	require.Equal(t, EncryptionVendorNameKMIP, provider.Name())
+	plaintext, err := provider.DecryptDataKey(ctx, append([]byte("kmip:"), dataKey...))
+	require.NoError(t, err)
+	require.Equal(t, dataKey, plaintext)
+
+	_, err = provider.DecryptDataKey(ctx, dataKey)
+	require.ErrorContains(t, err, "wrong master key")
+
+	provider, err = NewKMIPKms(&encryptionpb.MasterKeyKms{Vendor: EncryptionVendorNameKMIP, KeyId: "other-key", Endpoint: addr})
+	require.NoError(t, err)
+	_, err = provider.DecryptDataKey(ctx, dataKey)
+	require.ErrorContains(t, err, "object not found")
+
+	// the certificate of the server isn't trusted without the CA.
+	t.Setenv(KMIPCAPathEnv, "")
+	provider, err = NewKMIPKms(&encryptionpb.MasterKeyKms{Vendor: EncryptionVendorNameKMIP, KeyId: testKMIPKeyID, Endpoint: addr})
+	require.NoError(t, err)
+	_, err = provider.DecryptDataKey(ctx, dataKey)
+	require.ErrorContains(t, err, "failed to connect to KMIP server")
+}
+
+func TestNewKMIPKms(t *testing.T) {
+	t.Setenv(KMIPCAPathEnv, "")
+	t.Setenv(KMIPCertPathEnv, "")
+	t.Setenv(KMIPKeyPathEnv, "")
+	_, err := NewKMIPKms(&encryptionpb.MasterKeyKms{Endpoint: "127.0.0.1:5696"})
+	require.ErrorContains(t, err, "missing KMIP key id")
+	_, err = NewKMIPKms(&encryptionpb.MasterKeyKms{KeyId: testKMIPKeyID})
+	require.ErrorContains(t, err, "missing KMIP endpoint")
+	_, err = NewKMIPKms(&encryptionpb.MasterKeyKms{KeyId: testKMIPKeyID, Endpoint: "127.0.0.1"})
+	require.ErrorContains(t, err, "invalid KMIP endpoint")
+	t.Setenv(KMIPCertPathEnv, filepath.Join(t.TempDir(), "client.pem"))
+	_, err = NewKMIPKms(&encryptionpb.MasterKeyKms{KeyId: testKMIPKeyID, Endpoint: "127.0.0.1:5696"})
+	require.ErrorContains(t, err, "failed to load the client certificate of KMIP")
+}
@@ -2,7 +2,14 @@
 
 package kms
 
-import "context"
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+)
 
 // Provider is an interface for key management service providers
 // implement encrypt data key in future if needed
@@ -11,3 +18,34 @@ type Provider interface {
 	Name() string
 	Close()
 }
+
+// ProviderFactory creates the provider by the master key config.
+type ProviderFactory func(config *encryptionpb.MasterKeyKms) (Provider, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]ProviderFactory)
+)
+
+// RegisterProvider registers the factory of the KMS vendor, so that the master keys of the vendor can be used.
+// The vendor is case-insensitive, it panics if the vendor has been registered.
+func RegisterProvider(vendor string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	vendor = strings.ToLower(vendor)
+	if _, ok := providers[vendor]; ok {
+		panic("KMS vendor " + vendor + " has been registered")
+	}
+	providers[vendor] = factory
+}
+
+// NewProvider creates the provider of the vendor in the master key config.
+func NewProvider(config *encryptionpb.MasterKeyKms) (Provider, error) {
+	providersMu.RLock()
+	factory, ok := providers[strings.ToLower(config.Vendor)]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("vendor not found: %s", config.Vendor)
+	}
+	return factory(config)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+)
+
+const (
+	// EncryptionVendorNameVault is the vendor of HashiCorp Vault Transit secrets engine.
+	EncryptionVendorNameVault = "vault"
+
+	// VaultAddrEnv, VaultTokenEnv and VaultNamespaceEnv are the environment variables used by the Vault CLI,
+	// the token and the namespace are read from them because the master key config doesn't carry credentials
+	// of Vault.
+	VaultAddrEnv      = "VAULT_ADDR"
+	VaultTokenEnv     = "VAULT_TOKEN"
+	VaultNamespaceEnv = "VAULT_NAMESPACE"
+
+	vaultRequestTimeout = 30 * time.Second
+)
+
+func init() {
+	RegisterProvider(EncryptionVendorNameVault, func(config *encryptionpb.MasterKeyKms) (Provider, error) {
+		return NewVaultKms(config)
+	})
+}
+
+// VaultKms decrypts the data keys by the transit secrets engine of HashiCorp Vault.
+// TiKV doesn't support Vault, so it can only be used by BR itself, such as restoring the log backup, but not by the
+// backup tasks executed by TiKV.
+type VaultKms struct {
+	client    *http.Client
+	address   string
+	mount     string
+	keyName   string
+	token     string
+	namespace string
+}
+
+// NewVaultKms creates the Vault KMS by the master key config, where the key id is in the form of
+// `{mount path}/{key name}` and the endpoint is the address of Vault.
+func NewVaultKms(config *encryptionpb.MasterKeyKms) (*VaultKms, error) {
+	idx := strings.LastIndex(config.KeyId, "/")
+	if idx <= 0 || idx == len(config.KeyId)-1 {
+		return nil, errors.Errorf("invalid Vault key id %s, should be {mount path}/{key name}", config.KeyId)
+	}
+	address := config.Endpoint
+	if len(address) == 0 {
+		address = os.Getenv(VaultAddrEnv)
+	}
+	if len(address) == 0 {
+		return nil, errors.Errorf("missing Vault address, please set the endpoint or %s", VaultAddrEnv)
+	}
+	token := os.Getenv(VaultTokenEnv)
+	if len(token) == 0 {
+		return nil, errors.Errorf("missing Vault token, please set %s", VaultTokenEnv)
+	}
+	return &VaultKms{
+		client:    &http.Client{Timeout: vaultRequestTimeout},
+		address:   strings.TrimSuffix(address, "/"),
+		mount:     strings.Trim(config.KeyId[:idx], "/"),
+		keyName:   config.KeyId[idx+1:],
+		token:     token,
+		namespace: os.Getenv(VaultNamespaceEnv),
+	}, nil
+}
+
+func (v *VaultKms) Name() string {
+	return EncryptionVendorNameVault
+}
+
+type vaultDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type vaultDecryptResponse struct {
+	Data struct {
+		Plaintext string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// DecryptDataKey decrypts the data key, which is the ciphertext returned by Vault like `vault:v1:...`.
+func (v *VaultKms) DecryptDataKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	body, err := json.Marshal(vaultDecryptRequest{Ciphertext: string(dataKey)})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	u := fmt.Sprintf("%s/v1/%s/decrypt/%s", v.address, v.mount, url.PathEscape(v.keyName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", v.token)
+	if len(v.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "vault decrypt request failed")
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read vault decrypt response")
+	}
+
+	var result vaultDecryptResponse
+	// the body of some errors isn't JSON, e.g. the ones of proxies.
+	_ = json.Unmarshal(respBody, &result)
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyVaultError(resp.StatusCode, result.Errors)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(result.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Annotate(err, "vault decrypt response corrupted")
+	}
+	return plaintext, nil
+}
+
+func classifyVaultError(statusCode int, errs []string) error {
+	err := errors.Errorf("vault returns %d: %s", statusCode, strings.Join(errs, "; "))
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound:
+		return errors.Annotate(err, "wrong master key")
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return errors.Annotate(err, "API unavailable")
+	default:
+		return errors.Annotate(err, "KMS error")
+	}
+}
+
+func (v *VaultKms) Close() {
+	v.client.CloseIdleConnections()
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVaultToken       = "test-token"
+	testVaultDecryptPath = "/v1/transit/decrypt/test-key"
+)
+
+// newVaultTransitServer returns a stand-in of Vault Transit, whose ciphertext is the base64 encoded plaintext with
+// the prefix `vault:v1:`.
+func newVaultTransitServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply := func(code int, body any) {
+			w.WriteHeader(code)
+			require.NoError(t, json.NewEncoder(w).Encode(body))
+		}
+		if r.Header.Get("X-Vault-Token") != testVaultToken {
+			reply(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != testVaultDecryptPath {
+			reply(http.StatusNotFound, map[string]any{"errors": []string{"no handler for route"}})
+			return
+		}
+		var req vaultDecryptRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		plaintext, ok := strings.CutPrefix(req.Ciphertext, "vault:v1:")
+		if !ok {
+			reply(http.StatusBadRequest, map[string]any{"errors": []string{"invalid ciphertext: no prefix"}})
+			return
+		}
+		reply(http.StatusOK, map[string]any{"data": map[string]string{"plaintext": plaintext}})
+	}))
+}
+
+func TestVaultKmsDecryptDataKey(t *testing.T) {
+	ctx := context.Background()
+	server := newVaultTransitServer(t)
+	defer server.Close()
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	ciphertext := []byte("vault:v1:" + base64.StdEncoding.EncodeToString(dataKey))
+
+	t.Setenv(VaultTokenEnv, testVaultToken)
+	provider, err := NewProvider(&encryptionpb.MasterKeyKms{
+		Vendor:   "Vault",
+		KeyId:    "transit/test-key",
+		Endpoint: server.URL + "/",
+	})
+	require.NoError(t, err)
+	defer provider.Close()
+	require.Equal(t, EncryptionVendorNameVault, provider.Name())
+	plaintext, err := provider.DecryptDataKey(ctx, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, plaintext)
+
+	_, err = provider.DecryptDataKey(ctx, dataKey)
+	require.ErrorContains(t, err, "wrong master key")
+	require.ErrorContains(t, err, "invalid ciphertext")
+
+	// the address is read from the environment variable if the endpoint isn't set.
+	t.Setenv(VaultAddrEnv, server.URL)
+	provider, err = NewVaultKms(&encryptionpb.MasterKeyKms{Vendor: EncryptionVendorNameVault, KeyId: "transit/other-key"})
+	require.NoError(t, err)
+	_, err = provider.DecryptDataKey(ctx, ciphertext)
+	require.ErrorContains(t, err, "no handler for route")
+
+	t.Setenv(VaultTokenEnv, "wrong-token")
+	provider, err = NewVaultKms(&encryptionpb.MasterKeyKms{Vendor: EncryptionVendorNameVault, KeyId: "transit/test-key"})
+	require.NoError(t, err)
+	_, err = provider.DecryptDataKey(ctx, ciphertext)
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestNewVaultKms(t *testing.T) {
+	t.Setenv(VaultAddrEnv, "")
+	t.Setenv(VaultTokenEnv, "")
+	_, err := NewVaultKms(&encryptionpb.MasterKeyKms{KeyId: "test-key", Endpoint: "http://127.0.0.1:8200"})
+	require.ErrorContains(t, err, "invalid Vault key id")
+	_, err = NewVaultKms(&encryptionpb.MasterKeyKms{KeyId: "transit/", Endpoint: "http://127.0.0.1:8200"})
+	require.ErrorContains(t, err, "invalid Vault key id")
+	_, err = NewVaultKms(&encryptionpb.MasterKeyKms{KeyId: "transit/test-key"})
+	require.ErrorContains(t, err, "missing Vault address")
+	_, err = NewVaultKms(&encryptionpb.MasterKeyKms{KeyId: "transit/test-key", Endpoint: "http://127.0.0.1:8200"})
+	require.ErrorContains(t, err, "missing Vault token")
+
+	_, err = NewProvider(&encryptionpb.MasterKeyKms{Vendor: "unknown"})
+	require.ErrorContains(t, err, "vendor not found: unknown")
+	require.Panics(t, func() {
+		RegisterProvider("VAULT", func(*encryptionpb.MasterKeyKms) (Provider, error) { return nil, nil })
+	})
+}
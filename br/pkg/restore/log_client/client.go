@@ -66,6 +66,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	tidbutil "github.com/pingcap/tidb/pkg/util"
 	"github.com/tikv/client-go/v2/config"
 	kvutil "github.com/tikv/client-go/v2/util"
@@ -1065,6 +1066,8 @@ type GetIDMapConfig struct {
 	CipherInfo              *backuppb.CipherInfo
 	// generated at full restore step that contains all the table ids that need to restore
 	PiTRTableTracker *utils.PiTRIdTracker
+	// generated at full restore step that contains the new names of the renamed databases and tables
+	PiTRRenameMap *stream.PiTRRenameMap
 }
 
 const UnsafePITRLogRestoreStartBeforeAnyUpstreamUserDDL = "UNSAFE_PITR_LOG_RESTORE_START_BEFORE_ANY_UPSTREAM_USER_DDL"
@@ -1095,6 +1098,8 @@ func (rc *LogClient) generateDBReplacesFromFullBackupStorage(
 	}
 	for _, t := range filteredFullBackupTables {
 		dbName, _ := utils.GetSysDBCIStrName(t.DB.Name)
+		// the renamed databases and tables have been created with the new names.
+		dbName = ast.NewCIStr(cfg.PiTRRenameMap.DBName(t.DB.ID, dbName.O))
 		newDBInfo, exist := rc.dom.InfoSchema().SchemaByName(dbName)
 		if !exist {
 			log.Info("db does not exist", zap.String("dbName", dbName.String()))
@@ -1111,14 +1116,15 @@ func (rc *LogClient) generateDBReplacesFromFullBackupStorage(
 			// If the db is empty, skip it.
 			continue
 		}
-		newTableInfo, err := restore.GetTableSchema(rc.GetDomain(), dbName, t.Info.Name)
+		tableName := ast.NewCIStr(cfg.PiTRRenameMap.TableName(t.Info.ID, t.Info.Name.O))
+		newTableInfo, err := restore.GetTableSchema(rc.GetDomain(), dbName, tableName)
 		if err != nil {
-			log.Info("table doesn't exist", zap.String("tableName", dbName.String()+"."+t.Info.Name.String()))
+			log.Info("table doesn't exist", zap.String("tableName", dbName.String()+"."+tableName.String()))
 			continue
 		}
 
 		dbReplace.TableMap[t.Info.ID] = &stream.TableReplace{
-			Name:         newTableInfo.Name.O,
+			Name:         t.Info.Name.O,
 			TableID:      newTableInfo.ID,
 			PartitionMap: restoreutils.GetPartitionIDMap(newTableInfo, t.Info),
 			IndexMap:     restoreutils.GetIndexIDMap(newTableInfo, t.Info),
@@ -103,6 +103,14 @@ type WithMigrationsBuilder struct {
 	restoredTS   uint64
 }
 
+// NewWithMigrationsBuilder creates the builder for the log restored in [startTS, restoredTS].
+func NewWithMigrationsBuilder(startTS, restoredTS uint64) *WithMigrationsBuilder {
+	return &WithMigrationsBuilder{
+		startTS:    startTS,
+		restoredTS: restoredTS,
+	}
+}
+
 func (builder *WithMigrationsBuilder) SetShiftStartTS(ts uint64) {
 	builder.shiftStartTS = ts
 }
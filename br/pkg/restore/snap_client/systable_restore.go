@@ -61,6 +61,7 @@ var sysPrivilegeTableMap = map[string]string{
 	"role_edges":    "(to_user = '%s' and to_host = '%%')", // since v3.0.0
 	"global_priv":   "(user = '%s' and host = '%%')",       // since v3.0.8
 	"global_grants": "(user = '%s' and host = '%%')",       // since v5.0.3
+	"procs_priv":    "(user = '%s' and host = '%%')",
 }
 
 var unRecoverableTable = map[string]map[string]struct{}{
@@ -111,6 +112,13 @@ var unRecoverableTable = map[string]map[string]struct{}{
 		// TiDB internal timers.
 		"tidb_timers": {},
 
+		// execution history of the scheduled events.
+		"tidb_event_history": {},
+
+		// changed groups of the materialized views, the IDs of the views are
+		// changed after restore.
+		"tidb_mview_log": {},
+
 		// gc info don't need to recover.
 		"gc_delete_range":       {},
 		"gc_delete_range_done":  {},
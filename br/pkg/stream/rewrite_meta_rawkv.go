@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"go.uber.org/zap"
 )
 
@@ -43,6 +44,8 @@ type TableReplace struct {
 	PartitionMap map[UpstreamID]DownstreamID
 	IndexMap     map[UpstreamID]DownstreamID
 	FilteredOut  bool
+	// TargetName is the new name of the table in down-stream cluster if it is renamed at restore.
+	TargetName string
 }
 
 // DBReplace specifies database information mapping from up-stream cluster to down-stream cluster.
@@ -51,6 +54,8 @@ type DBReplace struct {
 	DbID        DownstreamID
 	TableMap    map[UpstreamID]*TableReplace
 	FilteredOut bool
+	// TargetName is the new name of the database in down-stream cluster if it is renamed at restore.
+	TargetName string
 }
 
 // SchemasReplace specifies schemas information mapping from up-stream cluster to down-stream cluster.
@@ -159,6 +164,9 @@ func (sr *SchemasReplace) rewriteDBInfo(value []byte) ([]byte, error) {
 	}
 
 	dbInfo.ID = dbMap.DbID
+	if len(dbMap.TargetName) > 0 {
+		dbInfo.Name = ast.NewCIStr(dbMap.TargetName)
+	}
 	newValue, err := json.Marshal(dbInfo)
 	if err != nil {
 		return nil, err
@@ -272,6 +280,9 @@ func (sr *SchemasReplace) rewriteTableInfo(value []byte, dbID int64) ([]byte, er
 
 	// update table ID and partition ID.
 	tableInfo.ID = tableReplace.TableID
+	if len(tableReplace.TargetName) > 0 {
+		tableInfo.Name = ast.NewCIStr(tableReplace.TargetName)
+	}
 	partitions := tableInfo.GetPartitionInfo()
 	if partitions != nil {
 		for i, tbl := range partitions.Definitions {
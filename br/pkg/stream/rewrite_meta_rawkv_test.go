@@ -121,6 +121,16 @@ func TestRewriteDBInfo(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, DBInfo.ID, sr.DbReplaceMap[dbID].DbID)
 	require.Equal(t, newId, sr.DbReplaceMap[dbID].DbID)
+	require.Equal(t, dbName, DBInfo.Name.O)
+
+	// rewrite the name if the db is renamed.
+	sr.DbReplaceMap[dbID].TargetName = "Db2"
+	newValue, err = sr.rewriteDBInfo(value)
+	require.Nil(t, err)
+	err = json.Unmarshal(newValue, &DBInfo)
+	require.Nil(t, err)
+	require.Equal(t, newId, DBInfo.ID)
+	require.Equal(t, ast.NewCIStr("Db2"), DBInfo.Name)
 }
 
 func TestRewriteKeyForTable(t *testing.T) {
@@ -242,6 +252,16 @@ func TestRewriteTableInfo(t *testing.T) {
 	require.Equal(t, tableInfo.ID, sr.DbReplaceMap[dbId].TableMap[tableID].TableID)
 	require.Equal(t, newID, sr.DbReplaceMap[dbId].TableMap[tableID].TableID)
 	require.EqualValues(t, tableCount, 2)
+	require.Equal(t, tableName, tableInfo.Name.O)
+
+	// rewrite the name if the table is renamed.
+	sr.DbReplaceMap[dbId].TableMap[tableID].TargetName = "t1_bak"
+	newValue, err = sr.rewriteTableInfo(value, dbId)
+	require.Nil(t, err)
+	err = json.Unmarshal(newValue, &tableInfo)
+	require.Nil(t, err)
+	require.Equal(t, newID, tableInfo.ID)
+	require.Equal(t, ast.NewCIStr("t1_bak"), tableInfo.Name)
 }
 
 func TestRewriteTableInfoForPartitionTable(t *testing.T) {
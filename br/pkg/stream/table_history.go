@@ -80,6 +80,13 @@ func (info *LogBackupTableHistoryManager) GetTableHistory() map[int64][2]TableLo
 	return info.tableNameHistory
 }
 
+// GetTableLocation returns the current location of the table or partition at the end of the log backup,
+// it returns false if the table is not changed during the log backup.
+func (info *LogBackupTableHistoryManager) GetTableLocation(tableID int64) (TableLocationInfo, bool) {
+	history, exists := info.tableNameHistory[tableID]
+	return history[1], exists
+}
+
 func (info *LogBackupTableHistoryManager) GetDBNameByID(dbId int64) (string, bool) {
 	name, ok := info.dbIdToName[dbId]
 	return name, ok
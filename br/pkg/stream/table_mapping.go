@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/pingcap/errors"
 	backuppb "github.com/pingcap/kvproto/pkg/brpb"
@@ -466,6 +467,46 @@ func (tm *TableMappingManager) ApplyFilterToDBReplaceMap(tracker *utils.PiTRIdTr
 	}
 }
 
+// ApplyRenameToDBReplaceMap sets the target names of the databases and tables renamed by the rules. It should be
+// called after the filter is applied, because the target database is decided by the tables restored into it.
+// The target names are not saved in the id map, so it needs to be called again after loading the saved id map.
+func (tm *TableMappingManager) ApplyRenameToDBReplaceMap(rules RenameRules) error {
+	for _, dbReplace := range tm.DBReplaceMap {
+		dbReplace.TargetName = ""
+		if dbReplace.FilteredOut {
+			continue
+		}
+
+		// the database is restored into the target database of its tables, or renamed by the rule of the database
+		// if no table is restored.
+		targetDB, dbRenamed := rules.RenameDB(dbReplace.Name)
+		targetDecided := dbRenamed
+		for _, tableReplace := range dbReplace.TableMap {
+			tableReplace.TargetName = ""
+			if tableReplace.FilteredOut || len(tableReplace.Name) == 0 {
+				continue
+			}
+			newDBName, newTableName, renamed := rules.RenameTable(dbReplace.Name, tableReplace.Name)
+			if !targetDecided {
+				targetDB, dbRenamed, targetDecided = newDBName, renamed, true
+			} else if !strings.EqualFold(newDBName, targetDB) {
+				return errors.Annotatef(berrors.ErrInvalidArgument,
+					"tables of database %s are restored into different databases %s and %s, "+
+						"consider to add a rename rule for the database or exclude the table %s by the filter",
+					dbReplace.Name, targetDB, newDBName, tableReplace.Name)
+			}
+			if renamed {
+				tableReplace.TargetName = newTableName
+			}
+		}
+
+		if dbRenamed {
+			dbReplace.TargetName = targetDB
+		}
+	}
+	return nil
+}
+
 // ToProto produces schemas id maps from up-stream to down-stream.
 func (tm *TableMappingManager) ToProto() []*backuppb.PitrDBMap {
 	dbMaps := make([]*backuppb.PitrDBMap, 0, len(tm.DBReplaceMap))
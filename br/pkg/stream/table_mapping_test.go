@@ -676,6 +676,145 @@ func TestFilterDBReplaceMap(t *testing.T) {
 	}
 }
 
+func TestApplyRenameToDBReplaceMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     map[UpstreamID]*DBReplace
+		rules       []string
+		expected    map[UpstreamID]*DBReplace
+		expectedErr string
+	}{
+		{
+			name: "rename table into another database",
+			initial: map[UpstreamID]*DBReplace{
+				1: {
+					Name: "prod",
+					DbID: 1000,
+					TableMap: map[UpstreamID]*TableReplace{
+						10: {TableID: 1010, Name: "orders"},
+						11: {TableID: 1011, Name: "users", FilteredOut: true},
+					},
+				},
+				2: {
+					Name: "test",
+					DbID: 2000,
+					TableMap: map[UpstreamID]*TableReplace{
+						20: {TableID: 2020, Name: "orders"},
+					},
+				},
+			},
+			rules: []string{"prod.orders=recovery.orders_0930"},
+			expected: map[UpstreamID]*DBReplace{
+				1: {
+					Name:       "prod",
+					DbID:       1000,
+					TargetName: "recovery",
+					TableMap: map[UpstreamID]*TableReplace{
+						10: {TableID: 1010, Name: "orders", TargetName: "orders_0930"},
+						11: {TableID: 1011, Name: "users", FilteredOut: true},
+					},
+				},
+				2: {
+					Name: "test",
+					DbID: 2000,
+					TableMap: map[UpstreamID]*TableReplace{
+						20: {TableID: 2020, Name: "orders"},
+					},
+				},
+			},
+		},
+		{
+			name: "rename database",
+			initial: map[UpstreamID]*DBReplace{
+				1: {
+					Name: "prod",
+					DbID: 1000,
+					TableMap: map[UpstreamID]*TableReplace{
+						10: {TableID: 1010, Name: "orders"},
+						11: {TableID: 1011, Name: "users"},
+					},
+				},
+				2: {
+					Name:     "prod2",
+					DbID:     2000,
+					TableMap: map[UpstreamID]*TableReplace{},
+				},
+				3: {
+					Name:        "prod3",
+					DbID:        3000,
+					TableMap:    map[UpstreamID]*TableReplace{},
+					FilteredOut: true,
+				},
+			},
+			rules: []string{"prod.orders=recovery.orders_0930", "prod=recovery", "prod2=recovery2", "prod3=recovery3"},
+			expected: map[UpstreamID]*DBReplace{
+				1: {
+					Name:       "prod",
+					DbID:       1000,
+					TargetName: "recovery",
+					TableMap: map[UpstreamID]*TableReplace{
+						10: {TableID: 1010, Name: "orders", TargetName: "orders_0930"},
+						11: {TableID: 1011, Name: "users", TargetName: "users"},
+					},
+				},
+				2: {
+					Name:       "prod2",
+					DbID:       2000,
+					TargetName: "recovery2",
+					TableMap:   map[UpstreamID]*TableReplace{},
+				},
+				3: {
+					Name:        "prod3",
+					DbID:        3000,
+					TableMap:    map[UpstreamID]*TableReplace{},
+					FilteredOut: true,
+				},
+			},
+		},
+		{
+			name: "tables restored into different databases",
+			initial: map[UpstreamID]*DBReplace{
+				1: {
+					Name: "prod",
+					DbID: 1000,
+					TableMap: map[UpstreamID]*TableReplace{
+						10: {TableID: 1010, Name: "orders"},
+						11: {TableID: 1011, Name: "users"},
+					},
+				},
+			},
+			rules:       []string{"prod.orders=recovery.orders_0930"},
+			expectedErr: "tables of database prod are restored into different databases",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := NewTableMappingManager()
+			tm.DBReplaceMap = tt.initial
+			rules, err := ParseRenameRules(tt.rules)
+			require.NoError(t, err)
+
+			err = tm.ApplyRenameToDBReplaceMap(rules)
+			if len(tt.expectedErr) > 0 {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tm.DBReplaceMap)
+
+			// the target names are reset when applying again
+			require.NoError(t, tm.ApplyRenameToDBReplaceMap(nil))
+			for _, dbReplace := range tm.DBReplaceMap {
+				require.Empty(t, dbReplace.TargetName)
+				for _, tableReplace := range dbReplace.TableMap {
+					require.Empty(t, tableReplace.TargetName)
+				}
+			}
+		})
+	}
+}
+
 func TestReplaceTemporaryIDs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,209 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+// RenameRule restores a database, or a table if FromTable is not empty, with a new name.
+// The source names are the names at the point of restore.
+type RenameRule struct {
+	FromDB    string
+	FromTable string
+	ToDB      string
+	ToTable   string
+}
+
+func (r *RenameRule) String() string {
+	if len(r.FromTable) == 0 {
+		return fmt.Sprintf("%s=%s", utils.EncloseName(r.FromDB), utils.EncloseName(r.ToDB))
+	}
+	return fmt.Sprintf("%s=%s",
+		utils.EncloseDBAndTable(r.FromDB, r.FromTable), utils.EncloseDBAndTable(r.ToDB, r.ToTable))
+}
+
+// RenameRules are the rename rules of point-in-time restore. A table is renamed by the rule of the table first,
+// then by the rule of the database it belongs to.
+type RenameRules []RenameRule
+
+// ParseRenameRules parses the rename rules in the form of `db.tbl=new_db.new_tbl` or `db=new_db`.
+// The names can be quoted by backquotes if they contain '.' or '='.
+func ParseRenameRules(args []string) (RenameRules, error) {
+	rules := make(RenameRules, 0, len(args))
+	sources := make(map[string]struct{}, len(args))
+	for _, arg := range args {
+		from, rest, err := parseRenameNames(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) == 0 || rest[0] != '=' {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "invalid rename rule %q, expect `from=to`", arg)
+		}
+		to, rest, err := parseRenameNames(rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) > 0 || len(from) != len(to) {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"invalid rename rule %q, expect `db.tbl=new_db.new_tbl` or `db=new_db`", arg)
+		}
+		rule := RenameRule{FromDB: from[0], ToDB: to[0]}
+		if len(from) == 2 {
+			rule.FromTable, rule.ToTable = from[1], to[1]
+		}
+		if utils.IsSysDB(strings.ToLower(rule.FromDB)) || utils.IsSysDB(strings.ToLower(rule.ToDB)) {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"invalid rename rule %q, system databases can't be renamed", arg)
+		}
+		source := strings.ToLower(utils.EncloseDBAndTable(rule.FromDB, rule.FromTable))
+		if _, ok := sources[source]; ok {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "duplicated rename rule for %s", source)
+		}
+		sources[source] = struct{}{}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseRenameNames parses `db` or `db.tbl` from the beginning of s, and returns the rest of s.
+func parseRenameNames(s string) (names []string, rest string, err error) {
+	for {
+		var name string
+		if strings.HasPrefix(s, "`") {
+			var sb strings.Builder
+			i := 1
+			for ; i < len(s); i++ {
+				if s[i] != '`' {
+					sb.WriteByte(s[i])
+					continue
+				}
+				if i+1 < len(s) && s[i+1] == '`' {
+					sb.WriteByte('`')
+					i++
+					continue
+				}
+				break
+			}
+			if i >= len(s) {
+				return nil, "", errors.Annotatef(berrors.ErrInvalidArgument, "unclosed quoted name in %q", s)
+			}
+			name, s = sb.String(), s[i+1:]
+		} else {
+			end := strings.IndexAny(s, ".=")
+			if end < 0 {
+				end = len(s)
+			}
+			name, s = strings.TrimSpace(s[:end]), s[end:]
+		}
+		if len(name) == 0 {
+			return nil, "", errors.Annotatef(berrors.ErrInvalidArgument, "empty name is not allowed in rename rule")
+		}
+		names = append(names, name)
+		if len(names) > 2 {
+			return nil, "", errors.Annotatef(berrors.ErrInvalidArgument, "too many names in rename rule")
+		}
+		if !strings.HasPrefix(s, ".") {
+			return names, s, nil
+		}
+		s = s[1:]
+	}
+}
+
+// TableFilter returns the filter that matches all the sources of the rules.
+func (rs RenameRules) TableFilter() (filter.Filter, error) {
+	patterns := make([]string, 0, len(rs))
+	for _, r := range rs {
+		pattern := utils.EncloseName(r.FromDB) + ".*"
+		if len(r.FromTable) > 0 {
+			pattern = utils.EncloseDBAndTable(r.FromDB, r.FromTable)
+		}
+		patterns = append(patterns, pattern)
+	}
+	f, err := filter.Parse(patterns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return filter.CaseInsensitive(f), nil
+}
+
+// RenameDB returns the new name of the database renamed by the rule of the database.
+func (rs RenameRules) RenameDB(dbName string) (string, bool) {
+	for _, r := range rs {
+		if len(r.FromTable) == 0 && strings.EqualFold(r.FromDB, dbName) {
+			return r.ToDB, true
+		}
+	}
+	return dbName, false
+}
+
+// RenameTable returns the new names of the database and the table. The table is renamed if there is a rule of the
+// table, otherwise it's restored with the same name into the database renamed by the rule of the database.
+func (rs RenameRules) RenameTable(dbName, tableName string) (newDBName, newTableName string, renamed bool) {
+	for _, r := range rs {
+		if len(r.FromTable) > 0 && strings.EqualFold(r.FromDB, dbName) && strings.EqualFold(r.FromTable, tableName) {
+			return r.ToDB, r.ToTable, true
+		}
+	}
+	newDBName, renamed = rs.RenameDB(dbName)
+	return newDBName, tableName, renamed
+}
+
+// PiTRRenameMap is the rename rules resolved at the snapshot restore step of point-in-time restore, it records the
+// new names of the databases and tables by their upstream IDs.
+type PiTRRenameMap struct {
+	DBNames    map[UpstreamID]string
+	TableNames map[UpstreamID]string
+}
+
+func NewPiTRRenameMap() *PiTRRenameMap {
+	return &PiTRRenameMap{
+		DBNames:    make(map[UpstreamID]string),
+		TableNames: make(map[UpstreamID]string),
+	}
+}
+
+// DBName returns the name of the database in the downstream cluster.
+func (m *PiTRRenameMap) DBName(dbID UpstreamID, name string) string {
+	if m == nil {
+		return name
+	}
+	if newName, ok := m.DBNames[dbID]; ok {
+		return newName
+	}
+	return name
+}
+
+// TableName returns the name of the table in the downstream cluster.
+func (m *PiTRRenameMap) TableName(tableID UpstreamID, name string) string {
+	if m == nil {
+		return name
+	}
+	if newName, ok := m.TableNames[tableID]; ok {
+		return newName
+	}
+	return name
+}
+
+// IsEmpty returns whether no database or table is renamed.
+func (m *PiTRRenameMap) IsEmpty() bool {
+	return m == nil || (len(m.DBNames) == 0 && len(m.TableNames) == 0)
+}
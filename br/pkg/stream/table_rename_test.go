@@ -0,0 +1,80 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRenameRules(t *testing.T) {
+	rules, err := ParseRenameRules([]string{
+		"prod.orders=recovery.orders_0930",
+		"prod2=recovery2",
+		"`a.b`.`c=d`=`e``f`.g",
+	})
+	require.NoError(t, err)
+	require.Equal(t, RenameRules{
+		{FromDB: "prod", FromTable: "orders", ToDB: "recovery", ToTable: "orders_0930"},
+		{FromDB: "prod2", ToDB: "recovery2"},
+		{FromDB: "a.b", FromTable: "c=d", ToDB: "e`f", ToTable: "g"},
+	}, rules)
+	require.Equal(t, "`a.b`.`c=d`=`e``f`.`g`", rules[2].String())
+
+	for _, invalid := range []string{
+		"prod.orders",
+		"prod.orders=recovery",
+		"prod=recovery.orders",
+		"prod.orders=recovery.orders.x",
+		"prod.=recovery.orders",
+		"`prod.orders=recovery.orders",
+		"prod.orders=recovery.orders=x",
+		"mysql.user=recovery.user",
+		"prod=sys",
+	} {
+		_, err := ParseRenameRules([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+	_, err = ParseRenameRules([]string{"prod.orders=r.o1", "PROD.Orders=r.o2"})
+	require.ErrorContains(t, err, "duplicated rename rule")
+}
+
+func TestRenameRules(t *testing.T) {
+	rules, err := ParseRenameRules([]string{"prod.orders=recovery.orders_0930", "prod=recovery", "db1=db2"})
+	require.NoError(t, err)
+
+	db, tbl, renamed := rules.RenameTable("Prod", "ORDERS")
+	require.True(t, renamed)
+	require.Equal(t, "recovery", db)
+	require.Equal(t, "orders_0930", tbl)
+	db, tbl, renamed = rules.RenameTable("prod", "users")
+	require.True(t, renamed)
+	require.Equal(t, "recovery", db)
+	require.Equal(t, "users", tbl)
+	db, tbl, renamed = rules.RenameTable("test", "orders")
+	require.False(t, renamed)
+	require.Equal(t, "test", db)
+	require.Equal(t, "orders", tbl)
+
+	f, err := rules.TableFilter()
+	require.NoError(t, err)
+	require.True(t, f.MatchTable("prod", "orders"))
+	require.True(t, f.MatchTable("PROD", "users"))
+	require.True(t, f.MatchTable("db1", "t"))
+	require.True(t, f.MatchSchema("db1"))
+	require.False(t, f.MatchTable("test", "orders"))
+	require.False(t, f.MatchSchema("db2"))
+
+	var renameMap *PiTRRenameMap
+	require.True(t, renameMap.IsEmpty())
+	require.Equal(t, "db", renameMap.DBName(1, "db"))
+	renameMap = NewPiTRRenameMap()
+	renameMap.DBNames[1] = "recovery"
+	renameMap.TableNames[10] = "orders_0930"
+	require.False(t, renameMap.IsEmpty())
+	require.Equal(t, "recovery", renameMap.DBName(1, "prod"))
+	require.Equal(t, "test", renameMap.DBName(2, "test"))
+	require.Equal(t, "orders_0930", renameMap.TableName(10, "orders"))
+	require.Equal(t, "users", renameMap.TableName(11, "users"))
+}
@@ -217,6 +217,9 @@ func (cfg *BackupConfig) ParseFromFlags(flags *pflag.FlagSet, skipCommonConfig b
 			return errors.Trace(err)
 		}
 	}
+	if err = checkMasterKeysForTiKV(cfg.MasterKeyConfig.MasterKeys); err != nil {
+		return errors.Trace(err)
+	}
 
 	cfg.RemoveSchedulers, err = flags.GetBool(flagRemoveSchedulers)
 	if err != nil {
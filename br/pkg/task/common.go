@@ -355,7 +355,12 @@ func DefineCommonFlags(flags *pflag.FlagSet) {
 	flags.String(flagMasterKeyConfig, "", "Master key config for point in time restore "+
 		"examples: \"local:///path/to/master/key/file,"+
 		"aws-kms:///{key-id}?AWS_ACCESS_KEY_ID={access-key}&AWS_SECRET_ACCESS_KEY={secret-key}&REGION={region},"+
-		"gcp-kms:///projects/{project-id}/locations/{location}/keyRings/{keyring}/cryptoKeys/{key-name}?AUTH=specified&CREDENTIALS={credentials}\"")
+		"gcp-kms:///projects/{project-id}/locations/{location}/keyRings/{keyring}/cryptoKeys/{key-name}?AUTH=specified&CREDENTIALS={credentials},"+
+		"vault-kms:///{transit-mount-path}/{key-name}?ENDPOINT={vault-address},"+
+		"kmip:///{key-unique-id}?ENDPOINT={host}:{port}\", "+
+		"the token of Vault is read from the environment variable VAULT_TOKEN, the TLS files of KMIP are read from "+
+		"KMIP_CA_PATH, KMIP_CERT_PATH and KMIP_KEY_PATH, vault-kms and kmip are not supported by TiKV, "+
+		"so they can only be used for restore")
 	_ = flags.MarkHidden(flagMetadataDownloadBatchSize)
 
 	storage.DefineFlags(flags)
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
 )
 
 const (
@@ -16,6 +18,8 @@ const (
 	SchemeAWS   = "aws-kms"
 	SchemeAzure = "azure-kms"
 	SchemeGCP   = "gcp-kms"
+	SchemeVault = "vault-kms"
+	SchemeKMIP  = "kmip"
 
 	AWSVendor      = "aws"
 	AWSRegion      = "REGION"
@@ -31,12 +35,20 @@ const (
 
 	GCPVendor      = "gcp"
 	GCPCredentials = "CREDENTIALS"
+
+	VaultVendor   = "vault"
+	VaultEndpoint = "ENDPOINT"
+
+	KMIPVendor   = "kmip"
+	KMIPEndpoint = "ENDPOINT"
 )
 
 var (
 	awsRegex   = regexp.MustCompile(`^/([^/]+)$`)
 	azureRegex = regexp.MustCompile(`^/(.+)$`)
 	gcpRegex   = regexp.MustCompile(`^/projects/([^/]+)/locations/([^/]+)/keyRings/([^/]+)/cryptoKeys/([^/]+)/?$`)
+	vaultRegex = regexp.MustCompile(`^/(.+)/([^/]+)$`)
+	kmipRegex  = regexp.MustCompile(`^/([^/]+)$`)
 )
 
 func validateAndParseMasterKeyString(keyString string) (encryptionpb.MasterKey, error) {
@@ -54,11 +66,36 @@ func validateAndParseMasterKeyString(keyString string) (encryptionpb.MasterKey,
 		return parseAzureKmsConfig(u)
 	case SchemeGCP:
 		return parseGcpKmsConfig(u)
+	case SchemeVault:
+		return parseVaultKmsConfig(u)
+	case SchemeKMIP:
+		return parseKMIPConfig(u)
 	default:
 		return encryptionpb.MasterKey{}, errors.Errorf("unsupported master key type: %s", u.Scheme)
 	}
 }
 
+// checkMasterKeysForTiKV checks whether the master keys can be used by TiKV, which encrypts the files of the backup
+// tasks executed by it. TiKV only implements the KMS of AWS, Azure and GCP, the Vault KMS and KMIP are only
+// implemented by BR, so they can't be used for backup and log backup.
+func checkMasterKeysForTiKV(keys []*encryptionpb.MasterKey) error {
+	for _, key := range keys {
+		kms := key.GetKms()
+		if kms == nil {
+			continue
+		}
+		switch strings.ToLower(kms.Vendor) {
+		case VaultVendor:
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"master key type %s is not supported by TiKV, it can only be used for restore", SchemeVault)
+		case KMIPVendor:
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"master key type %s is not supported by TiKV, it can only be used for restore", SchemeKMIP)
+		}
+	}
+	return nil
+}
+
 func parseLocalDiskConfig(u *url.URL) (encryptionpb.MasterKey, error) {
 	if len(u.Host) > 0 {
 		return encryptionpb.MasterKey{}, errors.New("local master key path must be absolute")
@@ -169,3 +206,41 @@ func parseGcpKmsConfig(u *url.URL) (encryptionpb.MasterKey, error) {
 		},
 	}, nil
 }
+
+func parseVaultKmsConfig(u *url.URL) (encryptionpb.MasterKey, error) {
+	matches := vaultRegex.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return encryptionpb.MasterKey{}, errors.New("invalid Vault KMS path format")
+	}
+
+	mount, keyName := matches[1], matches[2]
+	return encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Kms{
+			Kms: &encryptionpb.MasterKeyKms{
+				Vendor:   VaultVendor,
+				KeyId:    fmt.Sprintf("%s/%s", mount, keyName),
+				Endpoint: u.Query().Get(VaultEndpoint), // Optional, can read from env
+			},
+		},
+	}, nil
+}
+
+func parseKMIPConfig(u *url.URL) (encryptionpb.MasterKey, error) {
+	matches := kmipRegex.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return encryptionpb.MasterKey{}, errors.New("invalid KMIP key ID format")
+	}
+	endpoint := u.Query().Get(KMIPEndpoint)
+	if len(endpoint) == 0 {
+		return encryptionpb.MasterKey{}, errors.New("missing KMIP endpoint")
+	}
+	return encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Kms{
+			Kms: &encryptionpb.MasterKeyKms{
+				Vendor:   KMIPVendor,
+				KeyId:    matches[1],
+				Endpoint: endpoint,
+			},
+		},
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -194,3 +195,103 @@ func TestParseGcpKmsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVaultKmsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    encryptionpb.MasterKey
+		expectError bool
+	}{
+		{
+			name:  "Valid Vault config",
+			input: "vault-kms:///transit/key-name?ENDPOINT=https://vault.example.com:8200",
+			expected: encryptionpb.MasterKey{
+				Backend: &encryptionpb.MasterKey_Kms{
+					Kms: &encryptionpb.MasterKeyKms{
+						Vendor:   "vault",
+						KeyId:    "transit/key-name",
+						Endpoint: "https://vault.example.com:8200",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:  "Nested mount path without endpoint",
+			input: "vault-kms:///team/transit/key-name",
+			expected: encryptionpb.MasterKey{
+				Backend: &encryptionpb.MasterKey_Kms{
+					Kms: &encryptionpb.MasterKeyKms{
+						Vendor: "vault",
+						KeyId:  "team/transit/key-name",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "Missing mount path",
+			input:       "vault-kms:///key-name",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(tt.input)
+			result, err := parseVaultKmsConfig(u)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseKMIPConfig(t *testing.T) {
+	u, err := url.Parse("kmip:///key-unique-id?ENDPOINT=kmip.example.com:5696")
+	assert.NoError(t, err)
+	result, err := parseKMIPConfig(u)
+	assert.NoError(t, err)
+	assert.Equal(t, encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Kms{
+			Kms: &encryptionpb.MasterKeyKms{
+				Vendor:   "kmip",
+				KeyId:    "key-unique-id",
+				Endpoint: "kmip.example.com:5696",
+			},
+		},
+	}, result)
+
+	u, err = url.Parse("kmip:///key-unique-id")
+	assert.NoError(t, err)
+	_, err = parseKMIPConfig(u)
+	assert.ErrorContains(t, err, "missing KMIP endpoint")
+	u, err = url.Parse("kmip:///a/b?ENDPOINT=kmip.example.com:5696")
+	assert.NoError(t, err)
+	_, err = parseKMIPConfig(u)
+	assert.ErrorContains(t, err, "invalid KMIP key ID format")
+}
+
+func TestCheckMasterKeysForTiKV(t *testing.T) {
+	awsKey, err := validateAndParseMasterKeyString("aws-kms:///key-id?REGION=us-west-2")
+	assert.NoError(t, err)
+	vaultKey, err := validateAndParseMasterKeyString("vault-kms:///transit/key-name")
+	assert.NoError(t, err)
+
+	assert.NoError(t, checkMasterKeysForTiKV(nil))
+	assert.NoError(t, checkMasterKeysForTiKV([]*encryptionpb.MasterKey{&awsKey}))
+	err = checkMasterKeysForTiKV([]*encryptionpb.MasterKey{&awsKey, &vaultKey})
+	assert.ErrorIs(t, err, berrors.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "vault-kms is not supported by TiKV")
+
+	kmipKey, err := validateAndParseMasterKeyString("kmip:///key-unique-id?ENDPOINT=kmip.example.com:5696")
+	assert.NoError(t, err)
+	err = checkMasterKeysForTiKV([]*encryptionpb.MasterKey{&kmipKey})
+	assert.ErrorIs(t, err, berrors.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "kmip is not supported by TiKV")
+}
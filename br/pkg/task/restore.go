@@ -49,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/engine"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/tikv/client-go/v2/tikv"
@@ -97,6 +98,8 @@ const (
 	FlagStreamRestoreTS = "restored-ts"
 	// FlagStreamFullBackupStorage is used for log restore, represents the full backup storage.
 	FlagStreamFullBackupStorage = "full-backup-storage"
+	// FlagPiTRRename is used for log restore, represents the rules to restore databases and tables with new names.
+	FlagPiTRRename = "rename"
 	// FlagPiTRBatchCount and FlagPiTRBatchSize are used for restore log with batch method.
 	FlagPiTRBatchCount  = "pitr-batch-count"
 	FlagPiTRBatchSize   = "pitr-batch-size"
@@ -267,6 +270,11 @@ type RestoreConfig struct {
 	PitrBatchCount  uint32                      `json:"pitr-batch-count" toml:"pitr-batch-count"`
 	PitrBatchSize   uint32                      `json:"pitr-batch-size" toml:"pitr-batch-size"`
 	PitrConcurrency uint32                      `json:"-" toml:"-"`
+	// RenameRules restores the databases and tables with new names at point-in-time restore.
+	RenameRules stream.RenameRules `json:"-" toml:"-"`
+	// PiTRRenameMap is the rename rules resolved by the upstream IDs during snapshot restore.
+	PiTRRenameMap     *stream.PiTRRenameMap `json:"-" toml:"-"`
+	pitrRenameTargets []filter.Table        `json:"-" toml:"-"`
 
 	UseCheckpoint                 bool                            `json:"use-checkpoint" toml:"use-checkpoint"`
 	CheckpointStorage             string                          `json:"checkpoint-storage" toml:"checkpoint-storage"`
@@ -327,6 +335,10 @@ func DefineStreamRestoreFlags(command *cobra.Command) {
 		"support TSO or datetime, e.g. '400036290571534337' or '2018-05-11 01:42:23+0800'")
 	command.Flags().String(FlagStreamFullBackupStorage, "", "specify the backup full storage. "+
 		"fill it if want restore full backup before restore log.")
+	command.Flags().StringArray(FlagPiTRRename, nil, "restore the database or table with a new name, "+
+		"the names are the names at the point of restore, can be specified multiple times.\n"+
+		"e.g. 'prod.orders=recovery.orders_0930' or 'prod=recovery'. "+
+		"the tables are filtered by the rules if no filter is specified, and it requires the full backup storage.")
 	command.Flags().Uint32(FlagPiTRBatchCount, defaultPiTRBatchCount, "specify the batch count to restore log.")
 	command.Flags().Uint32(FlagPiTRBatchSize, defaultPiTRBatchSize, "specify the batch size to retore log.")
 	command.Flags().Uint32(FlagPiTRConcurrency, defaultPiTRConcurrency, "specify the concurrency to restore log.")
@@ -358,6 +370,27 @@ func (cfg *RestoreConfig) ParseStreamRestoreFlags(flags *pflag.FlagSet) error {
 			FlagStreamStartTS, FlagStreamFullBackupStorage)
 	}
 
+	renames, err := flags.GetStringArray(FlagPiTRRename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.RenameRules, err = stream.ParseRenameRules(renames); err != nil {
+		return errors.Trace(err)
+	}
+	if len(cfg.RenameRules) > 0 {
+		// the renamed tables are created at the snapshot restore step, so the full backup is required.
+		if len(cfg.FullBackupStorage) == 0 {
+			return errors.Annotatef(berrors.ErrInvalidArgument, "%v requires %v",
+				FlagPiTRRename, FlagStreamFullBackupStorage)
+		}
+		if !cfg.ExplicitFilter {
+			if cfg.TableFilter, err = cfg.RenameRules.TableFilter(); err != nil {
+				return errors.Trace(err)
+			}
+			cfg.ExplicitFilter = true
+		}
+	}
+
 	if cfg.PitrBatchCount, err = flags.GetUint32(FlagPiTRBatchCount); err != nil {
 		return errors.Trace(err)
 	}
@@ -1047,6 +1080,12 @@ func runSnapshotRestore(c context.Context, mgr *conn.Mgr, g glue.Glue, cmdName s
 			return errors.Trace(err)
 		}
 	}
+	// the renamed tables are restored along with the existing tables, make sure they won't be overwritten.
+	if len(cfg.pitrRenameTargets) > 0 && checkpointFirstRun && cfg.CheckRequirements {
+		if err := checkPiTRRenameTargetsNotExist(ctx, mgr, cfg.pitrRenameTargets); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if client.IsFullClusterRestore() && client.HasBackedUpSysDB() {
 		if err = snapclient.CheckSysTableCompatibility(mgr.GetDomain(), tables); err != nil {
@@ -1765,6 +1804,11 @@ func AdjustTablesToRestoreAndCreateTableTracker(
 	}
 
 	log.Info("pitr table tracker", zap.String("map", piTRIdTracker.String()))
+
+	// restore the tables with new names if specified
+	if len(cfg.RenameRules) > 0 {
+		return resolvePiTRRenames(logBackupTableHistory, snapshotDBMap, cfg, tableMap, dbMap)
+	}
 	return nil
 }
 
@@ -0,0 +1,180 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/conn"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"go.uber.org/zap"
+)
+
+// resolvePiTRRenames resolves the rename rules by the names of the databases and tables at the point of restore,
+// and replaces the databases and tables to restore at the snapshot restore step with the renamed ones.
+// The tables of one database must be restored into the same database, because the database id is rewritten as a
+// whole during log restore.
+func resolvePiTRRenames(
+	history *stream.LogBackupTableHistoryManager,
+	snapshotDBMap map[int64]*metautil.Database,
+	cfg *RestoreConfig,
+	tableMap map[int64]*metautil.Table,
+	dbMap map[int64]*metautil.Database,
+) error {
+	renameMap := stream.NewPiTRRenameMap()
+	targets := make([]filter.Table, 0)
+	for dbID, tableIDs := range cfg.PiTRTableTracker.DBIdToTableId {
+		dbName, exists := getDBNameFromIDInBackup(dbID, snapshotDBMap, history)
+		if !exists {
+			continue
+		}
+		targetDB, dbRenamed := cfg.RenameRules.RenameDB(dbName)
+		targetDecided := dbRenamed
+		for tableID := range tableIDs {
+			tableName, err := getTableNameAtRestoredTS(dbID, tableID, snapshotDBMap, history, cfg.RenameRules)
+			if err != nil {
+				return err
+			}
+			if len(tableName) == 0 {
+				continue
+			}
+			newDBName, newTableName, renamed := cfg.RenameRules.RenameTable(dbName, tableName)
+			if !targetDecided {
+				targetDB, dbRenamed, targetDecided = newDBName, renamed, true
+			} else if !strings.EqualFold(newDBName, targetDB) {
+				return errors.Annotatef(berrors.ErrInvalidArgument,
+					"tables of database %s are restored into different databases %s and %s, "+
+						"consider to add a rename rule for the database or exclude the table %s by the filter",
+					dbName, targetDB, newDBName, tableName)
+			}
+			if renamed {
+				renameMap.TableNames[tableID] = newTableName
+				targets = append(targets, filter.Table{Schema: newDBName, Name: newTableName})
+			}
+		}
+		if dbRenamed {
+			renameMap.DBNames[dbID] = targetDB
+			if _, exists := snapshotDBMap[dbID]; !exists {
+				// the database is created during log backup, it will be created by the log restore.
+				targets = append(targets, filter.Table{Schema: targetDB})
+			}
+		}
+	}
+	// the databases without any table to restore
+	for dbID, db := range dbMap {
+		if _, exists := renameMap.DBNames[dbID]; exists {
+			continue
+		}
+		if targetDB, renamed := cfg.RenameRules.RenameDB(db.Info.Name.O); renamed {
+			renameMap.DBNames[dbID] = targetDB
+		}
+	}
+
+	// create the renamed databases before log restore even if none of their tables is in the full backup.
+	renamedDBInfos := make(map[int64]*metautil.Database, len(renameMap.DBNames))
+	for dbID, targetDB := range renameMap.DBNames {
+		db, exists := snapshotDBMap[dbID]
+		if !exists {
+			continue
+		}
+		dbInfo := db.Info.Clone()
+		dbInfo.Name = ast.NewCIStr(targetDB)
+		renamedDBInfos[dbID] = &metautil.Database{Info: dbInfo, Tables: db.Tables}
+		dbMap[dbID] = renamedDBInfos[dbID]
+	}
+	for tableID, table := range tableMap {
+		renamedDB, dbRenamed := renamedDBInfos[table.DB.ID]
+		targetTable, tableRenamed := renameMap.TableNames[tableID]
+		if !dbRenamed && !tableRenamed {
+			continue
+		}
+		renamedTable := *table
+		if dbRenamed {
+			renamedTable.DB = renamedDB.Info
+		}
+		if tableRenamed {
+			renamedTable.Info = table.Info.Clone()
+			renamedTable.Info.Name = ast.NewCIStr(targetTable)
+		}
+		tableMap[tableID] = &renamedTable
+	}
+
+	cfg.PiTRRenameMap = renameMap
+	cfg.pitrRenameTargets = targets
+	log.Info("resolved pitr rename rules",
+		zap.Any("databases", renameMap.DBNames), zap.Any("tables", renameMap.TableNames))
+	return nil
+}
+
+// getTableNameAtRestoredTS returns the name of the table at the point of restore. It returns an empty name if the
+// table isn't restored into the database, or it is a partition.
+func getTableNameAtRestoredTS(
+	dbID, tableID int64,
+	snapshotDBMap map[int64]*metautil.Database,
+	history *stream.LogBackupTableHistoryManager,
+	rules stream.RenameRules,
+) (string, error) {
+	if locations, exists := history.GetTableHistory()[tableID]; exists {
+		start, end := locations[0], locations[1]
+		if !start.IsPartition && !end.IsPartition && start.DbID != end.DbID {
+			// the table is renamed into another database during log backup, the table id map of both databases
+			// contains the table, so it can't be renamed at restore.
+			for _, location := range locations {
+				dbName, exists := getDBNameFromIDInBackup(location.DbID, snapshotDBMap, history)
+				if _, _, renamed := rules.RenameTable(dbName, location.TableName); exists && renamed {
+					return "", errors.Annotatef(berrors.ErrInvalidArgument,
+						"table %s is moved from database %d to database %d during log backup, "+
+							"which is not supported to restore with a new name", location.TableName, start.DbID, end.DbID)
+				}
+			}
+		}
+		if end.IsPartition || end.DbID != dbID {
+			return "", nil
+		}
+		return end.TableName, nil
+	}
+	if db, exists := snapshotDBMap[dbID]; exists {
+		for _, table := range db.Tables {
+			if table.Info != nil && table.Info.ID == tableID {
+				return table.Info.Name.O, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// checkPiTRRenameTargetsNotExist checks that the renamed tables, and the renamed databases created during log
+// backup, don't exist in the cluster, so the restore won't touch the existing ones.
+func checkPiTRRenameTargetsNotExist(ctx context.Context, mgr *conn.Mgr, targets []filter.Table) error {
+	is := mgr.GetDomain().InfoSchema()
+	existed := make([]string, 0)
+	for _, target := range targets {
+		if len(target.Name) == 0 {
+			if _, exists := is.SchemaByName(ast.NewCIStr(target.Schema)); exists {
+				existed = append(existed, utils.EncloseName(target.Schema))
+			}
+			continue
+		}
+		_, err := is.TableByName(ctx, ast.NewCIStr(target.Schema), ast.NewCIStr(target.Name))
+		if err == nil {
+			existed = append(existed, utils.EncloseDBAndTable(target.Schema, target.Name))
+		} else if !infoschema.ErrTableNotExists.Equal(err) && !infoschema.ErrDatabaseNotExists.Equal(err) {
+			return errors.Trace(err)
+		}
+	}
+	if len(existed) > 0 {
+		return errors.Annotate(berrors.ErrTablesAlreadyExisted,
+			fmt.Sprintf("the restore targets already exist: %s", strings.Join(existed, ", ")))
+	}
+	return nil
+}
@@ -868,6 +868,88 @@ func TestAdjustTablesToRestoreAndCreateTableTracker(t *testing.T) {
 	}
 }
 
+func TestAdjustTablesToRestoreWithRename(t *testing.T) {
+	prod := &model.DBInfo{ID: 1, Name: ast.NewCIStr("prod")}
+	test := &model.DBInfo{ID: 2, Name: ast.NewCIStr("test")}
+	orders := &metautil.Table{DB: prod, Info: &model.TableInfo{ID: 11, Name: ast.NewCIStr("orders_tmp")}}
+	users := &metautil.Table{DB: prod, Info: &model.TableInfo{ID: 12, Name: ast.NewCIStr("users")}}
+	other := &metautil.Table{DB: test, Info: &model.TableInfo{ID: 21, Name: ast.NewCIStr("other")}}
+	snapshotDBMap := map[int64]*metautil.Database{
+		1: {Info: prod, Tables: []*metautil.Table{orders, users}},
+		2: {Info: test, Tables: []*metautil.Table{other}},
+	}
+
+	adjust := func(renames []string, history *stream.LogBackupTableHistoryManager) (
+		*task.RestoreConfig, map[int64]*metautil.Table, map[int64]*metautil.Database, error) {
+		rules, err := stream.ParseRenameRules(renames)
+		require.NoError(t, err)
+		testFilter, err := rules.TableFilter()
+		require.NoError(t, err)
+		cfg := &task.RestoreConfig{
+			Config:      task.Config{TableFilter: testFilter},
+			RenameRules: rules,
+		}
+		tableMap := make(map[int64]*metautil.Table)
+		dbMap := make(map[int64]*metautil.Database)
+		for dbID, db := range snapshotDBMap {
+			for _, table := range db.Tables {
+				if testFilter.MatchTable(db.Info.Name.O, table.Info.Name.O) {
+					tableMap[table.Info.ID] = table
+					dbMap[dbID] = db
+				}
+			}
+		}
+		err = task.AdjustTablesToRestoreAndCreateTableTracker(history, cfg, snapshotDBMap, tableMap, dbMap)
+		return cfg, tableMap, dbMap, err
+	}
+
+	// the table is renamed to `prod`.`orders` during log backup, and a table is created in `prod`.
+	history := stream.NewTableHistoryManager()
+	history.AddTableHistory(11, "orders_tmp", 1)
+	history.AddTableHistory(11, "orders", 1)
+	history.AddTableHistory(13, "orders", 1)
+	history.AddTableHistory(13, "orders_new", 1)
+	cfg, tableMap, dbMap, err := adjust([]string{"prod.orders=recovery.orders_0930"}, history)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "recovery"}, cfg.PiTRRenameMap.DBNames)
+	require.Equal(t, map[int64]string{11: "orders_0930"}, cfg.PiTRRenameMap.TableNames)
+	require.True(t, cfg.PiTRTableTracker.ContainsTableId(1, 11))
+	require.False(t, cfg.PiTRTableTracker.ContainsTableId(1, 13))
+	require.Len(t, tableMap, 1)
+	require.Equal(t, "recovery", tableMap[11].DB.Name.O)
+	require.Equal(t, "orders_0930", tableMap[11].Info.Name.O)
+	require.Equal(t, int64(11), tableMap[11].Info.ID)
+	require.Len(t, dbMap, 1)
+	require.Equal(t, "recovery", dbMap[1].Info.Name.O)
+	// the tables in the backup are not changed
+	require.Equal(t, "prod", orders.DB.Name.O)
+	require.Equal(t, "orders_tmp", orders.Info.Name.O)
+	require.Equal(t, "prod", snapshotDBMap[1].Info.Name.O)
+
+	// rename the database, the table created during log backup is restored into the renamed database.
+	cfg, tableMap, dbMap, err = adjust([]string{"prod=recovery", "prod.orders=recovery.orders_0930"}, history)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "recovery"}, cfg.PiTRRenameMap.DBNames)
+	require.Equal(t, map[int64]string{11: "orders_0930", 12: "users", 13: "orders_new"}, cfg.PiTRRenameMap.TableNames)
+	require.True(t, cfg.PiTRTableTracker.ContainsTableId(1, 13))
+	require.Len(t, tableMap, 2)
+	require.Equal(t, "recovery", tableMap[12].DB.Name.O)
+	require.Equal(t, "users", tableMap[12].Info.Name.O)
+	require.Equal(t, "recovery", dbMap[1].Info.Name.O)
+
+	// the tables of one database can't be restored into different databases.
+	history = stream.NewTableHistoryManager()
+	_, _, _, err = adjust([]string{"prod.orders_tmp=recovery.orders", "prod.users=recovery2.users"}, history)
+	require.ErrorContains(t, err, "are restored into different databases")
+
+	// the table moved to another database during log backup can't be renamed.
+	history = stream.NewTableHistoryManager()
+	history.AddTableHistory(21, "other", 2)
+	history.AddTableHistory(21, "other", 1)
+	_, _, _, err = adjust([]string{"prod.other=recovery.other"}, history)
+	require.ErrorContains(t, err, "is moved from database 2 to database 1")
+}
+
 func TestSortKeyRanges(t *testing.T) {
 	makeKeyRange := func(start, end int64) [2]kv.Key {
 		return [2]kv.Key{
@@ -286,7 +286,7 @@ func (cfg *StreamConfig) ParseStreamStartFromFlags(flags *pflag.FlagSet) error {
 		cfg.SafePointTTL = utils.DefaultStreamStartSafePointTTL
 	}
 
-	return nil
+	return checkMasterKeysForTiKV(cfg.MasterKeyConfig.MasterKeys)
 }
 
 // ParseStreamPauseFromFlags parse parameters for `stream pause`
@@ -2153,6 +2153,7 @@ func buildAndSaveIDMapIfNeeded(ctx context.Context, client *logclient.LogClient,
 	dbReplaces, err := client.GetBaseIDMap(ctx, &logclient.GetIDMapConfig{
 		LoadSavedIDMap:          saved,
 		PiTRTableTracker:        cfg.PiTRTableTracker,
+		PiTRRenameMap:           cfg.PiTRRenameMap,
 		FullBackupStorageConfig: fullBackupStorageConfig,
 		CipherInfo:              &cfg.Config.CipherInfo,
 	})
@@ -2180,6 +2181,12 @@ func buildAndSaveIDMapIfNeeded(ctx context.Context, client *logclient.LogClient,
 			return errors.Trace(err)
 		}
 	}
+	// the new names are not saved in the id map, apply them every time.
+	if len(cfg.RenameRules) > 0 {
+		if err = tableMappingManager.ApplyRenameToDBReplaceMap(cfg.RenameRules); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,175 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/encryption"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/verify"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagVerifyOutput = "output"
+
+	defaultVerifyConcurrency = 8
+)
+
+// VerifyConfig is the configuration specific for verifying backups.
+type VerifyConfig struct {
+	Config
+
+	// FullBackupStorage is the full backup verified together with the log backup in Storage.
+	FullBackupStorage string `json:"full-backup-storage" toml:"full-backup-storage"`
+	StartTS           uint64 `json:"start-ts" toml:"start-ts"`
+	RestoreTS         uint64 `json:"restore-ts" toml:"restore-ts"`
+	// Output is the path of the report file, the report is printed if it's empty.
+	Output string `json:"output" toml:"output"`
+}
+
+// DefineVerifyFlags defines the flags for verifying backups.
+func DefineVerifyFlags(flags *pflag.FlagSet) {
+	flags.String(FlagStreamStartTS, "", "the start of the point-in-time restore window to verify, "+
+		"it's the start of the log backup by default.\n"+
+		"support TSO or datetime, e.g. '400036290571534337' or '2018-05-11 01:42:23+0800'")
+	flags.String(FlagStreamRestoreTS, "", "the end of the point-in-time restore window to verify, "+
+		"it's the global checkpoint of the log backup by default.\n"+
+		"support TSO or datetime, e.g. '400036290571534337' or '2018-05-11 01:42:23+0800'")
+	flags.String(FlagStreamFullBackupStorage, "", "specify the full backup storage. "+
+		"fill it if want to verify the full backup together with the log backup, "+
+		"the point-in-time restore window starts from the full backup then.")
+	flags.Uint32(flagConcurrency, defaultVerifyConcurrency, "the number of files verified concurrently")
+	flags.String(flagVerifyOutput, "", "the path of the report file, the report is printed if it's empty")
+}
+
+// ParseFromFlags parses the verify-related flags from the flag set.
+func (cfg *VerifyConfig) ParseFromFlags(flags *pflag.FlagSet) error {
+	err := cfg.Config.ParseFromFlags(flags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tsString, err := flags.GetString(FlagStreamStartTS)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.StartTS, err = ParseTSString(tsString, true); err != nil {
+		return errors.Trace(err)
+	}
+	tsString, err = flags.GetString(FlagStreamRestoreTS)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.RestoreTS, err = ParseTSString(tsString, true); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.FullBackupStorage, err = flags.GetString(FlagStreamFullBackupStorage); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.StartTS > 0 && len(cfg.FullBackupStorage) > 0 {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "%v and %v are mutually exclusive",
+			FlagStreamStartTS, FlagStreamFullBackupStorage)
+	}
+	if cfg.Concurrency, err = flags.GetUint32(flagConcurrency); err != nil {
+		return errors.Trace(err)
+	}
+	cfg.Output, err = flags.GetString(flagVerifyOutput)
+	return errors.Trace(err)
+}
+
+// RunVerify verifies the full backup or the log backup in the storage without a cluster, and returns the report.
+// If the storage is a log backup, the full backup in FullBackupStorage is verified too, and the point-in-time
+// restore window starts from it.
+func RunVerify(c context.Context, cfg *VerifyConfig) (*verify.Report, error) {
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+
+	_, s, err := GetStorage(ctx, cfg.Storage, &cfg.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backupMeta, err := readVerifiedBackupMeta(ctx, s, &cfg.CipherInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	verifier := verify.NewVerifier(uint(cfg.Concurrency))
+	// endVersion > 0 represents that the storage has been used for `br backup`
+	if backupMeta.GetEndVersion() > 0 {
+		if len(cfg.FullBackupStorage) > 0 || cfg.StartTS > 0 || cfg.RestoreTS > 0 {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "%v, %v and %v are only for log backup",
+				FlagStreamFullBackupStorage, FlagStreamStartTS, FlagStreamRestoreTS)
+		}
+		if err = verifier.VerifySnapshot(ctx, s, backupMeta, &cfg.CipherInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return verifier.Report(), nil
+	}
+
+	logInfo, err := getLogInfoFromStorage(ctx, s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	window := verify.LogWindow{
+		LogMinTS:   logInfo.logMinTS,
+		LogMaxTS:   logInfo.logMaxTS,
+		StartTS:    cfg.StartTS,
+		RestoredTS: cfg.RestoreTS,
+	}
+	if len(cfg.FullBackupStorage) > 0 {
+		_, fullStorage, err := GetStorage(ctx, cfg.FullBackupStorage, &cfg.Config)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fullBackupMeta, err := readVerifiedBackupMeta(ctx, fullStorage, &cfg.CipherInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fullBackupMeta.GetEndVersion() == 0 {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "%v is not a full backup",
+				FlagStreamFullBackupStorage)
+		}
+		if err = verifier.VerifySnapshot(ctx, fullStorage, fullBackupMeta, &cfg.CipherInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+		window.StartTS = fullBackupMeta.GetEndVersion()
+	}
+	if window.StartTS == 0 {
+		window.StartTS = logInfo.logMinTS
+	}
+	if window.RestoredTS == 0 {
+		window.RestoredTS = logInfo.logMaxTS
+	}
+	encryptionManager, err := encryption.NewManager(&cfg.LogBackupCipherInfo, &cfg.MasterKeyConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = verifier.VerifyLog(ctx, s, window, encryptionManager); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return verifier.Report(), nil
+}
+
+func readVerifiedBackupMeta(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	cipher *backuppb.CipherInfo,
+) (*backuppb.BackupMeta, error) {
+	metaData, err := s.ReadFile(ctx, metautil.MetaFile)
+	if err != nil {
+		return nil, errors.Annotate(err, "load backupmeta failed")
+	}
+	decryptedMetaData, err := metautil.DecryptFullBackupMetaIfNeeded(metaData, cipher)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backupMeta := &backuppb.BackupMeta{}
+	if err = backupMeta.Unmarshal(decryptedMetaData); err != nil {
+		return nil, errors.Annotate(err, "parse backupmeta failed")
+	}
+	return backupMeta, nil
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/encryption"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	logclient "github.com/pingcap/tidb/br/pkg/restore/log_client"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils/consts"
+	"github.com/pingcap/tidb/br/pkg/utils/iter"
+	"github.com/pingcap/tidb/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// LogWindow is the point-in-time restore window claimed for the log backup.
+type LogWindow struct {
+	// LogMinTS and LogMaxTS are the range of the log backup, which is from the start or the truncated point of the
+	// log backup to its global checkpoint.
+	LogMinTS uint64
+	LogMaxTS uint64
+	// StartTS and RestoredTS are the window to verify.
+	StartTS    uint64
+	RestoredTS uint64
+}
+
+// VerifyLog verifies that the log backup can restore the cluster to any point of the window. It checks the window
+// is covered by the log backup, and verifies all the data files and compacted SSTs needed to restore the window.
+func (v *Verifier) VerifyLog(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	window LogWindow,
+	encryptionManager *encryption.Manager,
+) error {
+	if window.StartTS > window.RestoredTS {
+		return errors.Annotatef(berrors.ErrInvalidArgument,
+			"start ts %d is greater than restored ts %d", window.StartTS, window.RestoredTS)
+	}
+	result := &LogResult{
+		LogMinTS:   window.LogMinTS,
+		LogMaxTS:   window.LogMaxTS,
+		StartTS:    window.StartTS,
+		RestoredTS: window.RestoredTS,
+	}
+	if window.StartTS < window.LogMinTS {
+		v.addIssue(IssueLogGap, "", "", "the log before %d is unavailable, but the window starts from %d",
+			window.LogMinTS, window.StartTS)
+	}
+	if window.RestoredTS > window.LogMaxTS {
+		v.addIssue(IssueLogGap, "", "", "the log after the global checkpoint %d is unavailable, but the window ends at %d",
+			window.LogMaxTS, window.RestoredTS)
+	}
+
+	migs, err := stream.MigrationExtension(s).Load(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var truncatedTo uint64
+	for _, mig := range migs.ListAll() {
+		truncatedTo = max(truncatedTo, mig.TruncatedTo)
+	}
+	if truncatedTo > window.StartTS {
+		v.addIssue(IssueLogGap, "", "", "the log before %d has been truncated, but the window starts from %d",
+			truncatedTo, window.StartTS)
+	}
+
+	lm, err := logclient.CreateLogFileManager(ctx, logclient.LogFileManagerInit{
+		StartTS:                   window.StartTS,
+		RestoreTS:                 window.RestoredTS,
+		Storage:                   s,
+		MigrationsBuilder:         logclient.NewWithMigrationsBuilder(window.StartTS, window.RestoredTS),
+		MetadataDownloadBatchSize: v.concurrency,
+		EncryptionManager:         encryptionManager,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer lm.Close()
+	lm.BuildMigrations(migs.ListAll())
+	result.ShiftStartTS = lm.ShiftTS()
+
+	// collect the data files by the physical files, so that each physical file is read only once.
+	paths := make([]string, 0)
+	filesOfPath := make(map[string][]*backuppb.DataFileInfo)
+	addFile := func(file *backuppb.DataFileInfo) {
+		if _, ok := filesOfPath[file.Path]; !ok {
+			paths = append(paths, file.Path)
+		}
+		filesOfPath[file.Path] = append(filesOfPath[file.Path], file)
+		result.DataFiles += 1
+		result.Entries += file.NumberOfEntries
+		result.Size += file.Length
+	}
+	ddlFiles, err := lm.LoadDDLFiles(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, file := range ddlFiles {
+		addFile(file)
+	}
+	dmlFiles, err := lm.LoadDMLFiles(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for err, file := range iter.AsSeq(ctx, dmlFiles) {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		addFile(file.DataFileInfo)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer decoder.Close()
+	pool := util.NewWorkerPool(v.concurrency, "verify log backup")
+	eg, ectx := errgroup.WithContext(ctx)
+	for _, path := range paths {
+		files := filesOfPath[path]
+		pool.ApplyOnErrorGroup(eg, func() error {
+			return v.verifyLogDataFiles(ectx, s, path, files, decoder, encryptionManager)
+		})
+	}
+	for err, ssts := range iter.AsSeq(ctx, lm.GetCompactionIter(ctx)) {
+		if err != nil {
+			_ = eg.Wait()
+			return errors.Trace(err)
+		}
+		files := ssts.GetSSTs()
+		result.CompactedSSTs += len(files)
+		result.Size += metautil.ArchiveSize(files)
+		check := newCompactedKVChecker(ssts.TableID())
+		pool.ApplyOnErrorGroup(eg, func() error {
+			_, _, err := v.verifySSTs(ectx, s, files, nil, "", check)
+			return errors.Trace(err)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return errors.Trace(err)
+	}
+
+	v.mu.Lock()
+	v.report.Log = result
+	v.mu.Unlock()
+	return nil
+}
+
+// verifyLogDataFiles verifies the data files stored in the same physical file of the log backup.
+func (v *Verifier) verifyLogDataFiles(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	path string,
+	files []*backuppb.DataFileInfo,
+	decoder *zstd.Decoder,
+	encryptionManager *encryption.Manager,
+) error {
+	data, ok, err := v.readFile(ctx, s, path, 0, nil, "")
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	for _, file := range files {
+		content := data
+		if file.RangeLength > 0 {
+			end := file.RangeOffset + file.RangeLength
+			if end > uint64(len(data)) {
+				v.addIssue(IssueSizeMismatch, path, "",
+					"the size is %d, but the file at [%d, %d) is recorded", len(data), file.RangeOffset, end)
+				continue
+			}
+			content = data[file.RangeOffset:end]
+		} else if file.Length > 0 && uint64(len(data)) != file.Length {
+			v.addIssue(IssueSizeMismatch, path, "", "the size is %d, but %d is recorded", len(data), file.Length)
+			continue
+		}
+		v.verifyLogDataFile(ctx, file, content, decoder, encryptionManager)
+	}
+	return nil
+}
+
+// verifyLogDataFile decodes the KV events of a data file of the log backup.
+func (v *Verifier) verifyLogDataFile(
+	ctx context.Context,
+	file *backuppb.DataFileInfo,
+	content []byte,
+	decoder *zstd.Decoder,
+	encryptionManager *encryption.Manager,
+) {
+	path := file.Path
+	if file.RangeLength > 0 {
+		path = fmt.Sprintf("%s:%d", file.Path, file.RangeOffset)
+	}
+	var err error
+	if info := file.FileEncryptionInfo; info != nil {
+		if len(info.Checksum) > 0 {
+			if checksum := sha256.Sum256(content); !bytes.Equal(checksum[:], info.Checksum) {
+				v.addIssue(IssueSha256Mismatch, path, "",
+					"the sha256 of encrypted content is %x, but %x is recorded", checksum[:], info.Checksum)
+				return
+			}
+		}
+		if encryptionManager == nil {
+			v.addIssue(IssueCorruptedFile, path, "", "the file is encrypted, but no encryption key is provided")
+			return
+		}
+		if content, err = encryptionManager.Decrypt(ctx, content, info); err != nil {
+			v.addIssue(IssueCorruptedFile, path, "", "failed to decrypt: %s", err)
+			return
+		}
+	}
+	switch file.CompressionType {
+	case backuppb.CompressionType_UNKNOWN:
+	case backuppb.CompressionType_ZSTD:
+		if content, err = decoder.DecodeAll(content, nil); err != nil {
+			v.addIssue(IssueCorruptedFile, path, "", "failed to decompress: %s", err)
+			return
+		}
+	default:
+		v.addIssue(IssueCorruptedFile, path, "", "unsupported compression type %s", file.CompressionType)
+		return
+	}
+	if len(file.Sha256) > 0 {
+		if checksum := sha256.Sum256(content); !bytes.Equal(checksum[:], file.Sha256) {
+			v.addIssue(IssueSha256Mismatch, path, "", "the sha256 is %x, but %x is recorded", checksum[:], file.Sha256)
+			return
+		}
+	}
+
+	var (
+		entries  int64
+		failed   int
+		firstErr error
+	)
+	events := stream.NewEventIterator(content)
+	for events.Valid() {
+		events.Next()
+		if err := events.GetError(); err != nil {
+			v.addIssue(IssueCorruptedFile, path, "", "failed to read the events: %s", err)
+			return
+		}
+		entries++
+		_, _, err := decodeMVCCKey(events.Key())
+		if err == nil && file.Cf == consts.WriteCF {
+			err = new(stream.RawWriteCFValue).ParseFrom(events.Value())
+		}
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if failed > 0 {
+		v.addIssue(IssueDecodeFailed, path, "", "%d KVs can't be decoded, the first error: %s", failed, firstErr)
+	}
+	if entries != file.NumberOfEntries {
+		v.addIssue(IssueEntriesMismatch, path, "",
+			"the file has %d entries, but %d is recorded", entries, file.NumberOfEntries)
+	}
+}
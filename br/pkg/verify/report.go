@@ -0,0 +1,89 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package verify
+
+import (
+	"github.com/pingcap/tidb/pkg/lightning/verification"
+)
+
+// IssueKind is the kind of the problem found in the backup.
+type IssueKind string
+
+const (
+	// IssueMissingFile means the file recorded in the metadata doesn't exist.
+	IssueMissingFile IssueKind = "missing-file"
+	// IssueSizeMismatch means the size of the file differs from the metadata.
+	IssueSizeMismatch IssueKind = "size-mismatch"
+	// IssueSha256Mismatch means the sha256 of the file differs from the metadata.
+	IssueSha256Mismatch IssueKind = "sha256-mismatch"
+	// IssueCorruptedFile means the file can't be decrypted, decompressed or opened.
+	IssueCorruptedFile IssueKind = "corrupted-file"
+	// IssueDecodeFailed means some KVs of the file can't be decoded.
+	IssueDecodeFailed IssueKind = "decode-failed"
+	// IssueChecksumMismatch means the checksum recomputed from the KVs differs from the recorded one.
+	IssueChecksumMismatch IssueKind = "checksum-mismatch"
+	// IssueEntriesMismatch means the number of entries of a log file differs from the metadata.
+	IssueEntriesMismatch IssueKind = "entries-mismatch"
+	// IssueLogGap means the log backup can't cover the claimed point-in-time restore window.
+	IssueLogGap IssueKind = "log-gap"
+)
+
+// Issue is a problem found in the backup.
+type Issue struct {
+	Kind    IssueKind `json:"kind"`
+	Path    string    `json:"path,omitempty"`
+	Table   string    `json:"table,omitempty"`
+	Message string    `json:"message"`
+}
+
+// Checksum is the checksum of a collection of KVs, it's the same as the one of `ADMIN CHECKSUM TABLE`.
+type Checksum struct {
+	Crc64Xor   uint64 `json:"crc64xor"`
+	TotalKvs   uint64 `json:"total_kvs"`
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+func checksumOf(c *verification.KVChecksum) Checksum {
+	return Checksum{Crc64Xor: c.Sum(), TotalKvs: c.SumKVS(), TotalBytes: c.SumSize()}
+}
+
+// TableResult is the verification result of a table in the snapshot backup.
+type TableResult struct {
+	Database string `json:"database"`
+	Table    string `json:"table"`
+	ID       int64  `json:"id"`
+	Files    int    `json:"files"`
+	// Expected is the checksum recorded in the backupmeta, it's nil if the backup is taken without checksum.
+	Expected *Checksum `json:"expected,omitempty"`
+	Actual   Checksum  `json:"actual"`
+	Passed   bool      `json:"passed"`
+}
+
+// SnapshotResult is the verification result of a snapshot backup.
+type SnapshotResult struct {
+	BackupTS uint64         `json:"backup_ts"`
+	Files    int            `json:"files"`
+	Size     uint64         `json:"size"`
+	Tables   []*TableResult `json:"tables"`
+}
+
+// LogResult is the verification result of a log backup.
+type LogResult struct {
+	LogMinTS      uint64 `json:"log_min_ts"`
+	LogMaxTS      uint64 `json:"log_max_ts"`
+	StartTS       uint64 `json:"start_ts"`
+	RestoredTS    uint64 `json:"restored_ts"`
+	ShiftStartTS  uint64 `json:"shift_start_ts"`
+	DataFiles     int    `json:"data_files"`
+	Entries       int64  `json:"entries"`
+	CompactedSSTs int    `json:"compacted_ssts"`
+	Size          uint64 `json:"size"`
+}
+
+// Report is the machine-readable result of the verification.
+type Report struct {
+	Passed   bool            `json:"passed"`
+	Snapshot *SnapshotResult `json:"snapshot,omitempty"`
+	Log      *LogResult      `json:"log,omitempty"`
+	Issues   []Issue         `json:"issues"`
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/br/pkg/utils/consts"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/verification"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/redact"
+)
+
+// dataKeyPrefix is the prefix added by TiKV to the keys in the SST files.
+var dataKeyPrefix = []byte{'z'}
+
+// kvChecker checks that the KV can be decoded, the key is the raw key without MVCC timestamp.
+type kvChecker func(key, value []byte) error
+
+// newTableKVChecker returns the checker that decodes the KVs by the schema of the table.
+func newTableKVChecker(info *model.TableInfo) kvChecker {
+	physicalIDs := map[int64]struct{}{info.ID: {}}
+	if pi := info.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			physicalIDs[def.ID] = struct{}{}
+		}
+	}
+	indexIDs := make(map[int64]struct{}, len(info.Indices))
+	for _, index := range info.Indices {
+		indexIDs[index.ID] = struct{}{}
+	}
+	cols := make(map[int64]*types.FieldType, len(info.Columns))
+	for _, col := range info.Columns {
+		cols[col.ID] = &col.FieldType
+	}
+	return func(key, value []byte) (err error) {
+		// the decoders may panic on the corrupted data.
+		defer func() {
+			if r := recover(); r != nil {
+				err = util.GetRecoverError(r)
+			}
+		}()
+		tableID, indexID, isRecord, err := tablecodec.DecodeKeyHead(key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := physicalIDs[tableID]; !ok {
+			return errors.Errorf("the key belongs to table %d instead of table %d", tableID, info.ID)
+		}
+		if isRecord {
+			if _, _, err = tablecodec.DecodeRecordKey(key); err != nil {
+				return errors.Trace(err)
+			}
+			_, err = tablecodec.DecodeRowToDatumMap(value, cols, time.UTC)
+			return errors.Annotate(err, "failed to decode row")
+		}
+		if _, ok := indexIDs[indexID&tablecodec.IndexIDMask]; !ok {
+			return errors.Errorf("index %d doesn't exist in table %d", indexID, info.ID)
+		}
+		_, _, _, err = tablecodec.DecodeIndexKey(key)
+		return errors.Trace(err)
+	}
+}
+
+// newCompactedKVChecker returns the checker of the KVs compacted from the log backup. The schema of the table may
+// change in the log backup, so only the keys are decoded.
+func newCompactedKVChecker(tableID int64) kvChecker {
+	return func(key, _ []byte) error {
+		if tableID == 0 && !bytes.HasPrefix(key, tablecodec.TablePrefix()) {
+			return nil
+		}
+		keyTableID, _, isRecord, err := tablecodec.DecodeKeyHead(key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if tableID != 0 && keyTableID != tableID {
+			return errors.Errorf("the key belongs to table %d instead of table %d", keyTableID, tableID)
+		}
+		if isRecord {
+			_, _, err = tablecodec.DecodeRecordKey(key)
+		} else {
+			_, _, _, err = tablecodec.DecodeIndexKey(key)
+		}
+		return errors.Trace(err)
+	}
+}
+
+// decodeMVCCKey decodes the key in the form of `{mem-comparable encoded key}{bit-wise reversed ts}`.
+func decodeMVCCKey(key []byte) (rawKey []byte, ts uint64, err error) {
+	if len(key) < 8 {
+		return nil, 0, errors.Annotatef(berrors.ErrInvalidArgument, "the key is too short, len: %d", len(key))
+	}
+	if _, ts, err = codec.DecodeUintDesc(key[len(key)-8:]); err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	rest, rawKey, err := codec.DecodeBytes(key[:len(key)-8], nil)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	if len(rest) > 0 {
+		return nil, 0, errors.Annotatef(berrors.ErrInvalidArgument, "%d unexpected bytes in the key", len(rest))
+	}
+	return rawKey, ts, nil
+}
+
+type sstFile struct {
+	meta   *backuppb.File
+	reader *sstable.Reader
+}
+
+func openSST(data []byte) (*sstable.Reader, error) {
+	readable, err := sstable.NewSimpleReadable(vfs.NewMemFile(data))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reader, err := sstable.NewReader(readable, sstable.ReaderOptions{})
+	return reader, errors.Trace(err)
+}
+
+// verifySSTs verifies the SST files of a key range, where the values of the write CF files may be stored in the
+// default CF files. It returns the checksum of the KVs, and false if some of the files can't be read or decoded.
+func (v *Verifier) verifySSTs(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	files []*backuppb.File,
+	cipher *backuppb.CipherInfo,
+	table string,
+	check kvChecker,
+) (*verification.KVChecksum, bool, error) {
+	var writes, defaults []*sstFile
+	defer func() {
+		for _, f := range append(writes, defaults...) {
+			_ = f.reader.Close()
+		}
+	}()
+	ok := true
+	for _, file := range files {
+		data, readOK, err := v.readFile(ctx, s, file.Name, file.Size_, file.Sha256, table)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		if !readOK {
+			ok = false
+			continue
+		}
+		if len(file.CipherIv) > 0 && cipher != nil && utils.IsEffectiveEncryptionMethod(cipher.CipherType) {
+			if data, err = utils.Decrypt(data, cipher, file.CipherIv); err != nil {
+				v.addIssue(IssueCorruptedFile, file.Name, table, "failed to decrypt: %s", err)
+				ok = false
+				continue
+			}
+		}
+		reader, err := openSST(data)
+		if err != nil {
+			v.addIssue(IssueCorruptedFile, file.Name, table, "failed to open the sst: %s", err)
+			ok = false
+			continue
+		}
+		if file.Cf == consts.DefaultCF {
+			defaults = append(defaults, &sstFile{meta: file, reader: reader})
+		} else {
+			writes = append(writes, &sstFile{meta: file, reader: reader})
+		}
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	checksum := verification.NewKVChecksum()
+	for _, w := range writes {
+		if !v.scanWriteSST(w, defaults, checksum, table, check) {
+			ok = false
+		}
+	}
+	return checksum, ok, nil
+}
+
+// scanWriteSST decodes the KVs committed in the write CF file, and updates the checksum by them.
+func (v *Verifier) scanWriteSST(
+	w *sstFile,
+	defaults []*sstFile,
+	checksum *verification.KVChecksum,
+	table string,
+	check kvChecker,
+) bool {
+	iters := make([]sstable.Iterator, 0, len(defaults)+1)
+	defer func() {
+		for _, it := range iters {
+			_ = it.Close()
+		}
+	}()
+	for _, f := range append([]*sstFile{w}, defaults...) {
+		it, err := f.reader.NewIter(nil, nil)
+		if err != nil {
+			v.addIssue(IssueCorruptedFile, f.meta.Name, table, "failed to read the sst: %s", err)
+			return false
+		}
+		iters = append(iters, it)
+	}
+	writeIter, defaultIters := iters[0], iters[1:]
+
+	// getDefaultValue returns the value of the key in the default CF files.
+	getDefaultValue := func(key []byte) ([]byte, error) {
+		for _, it := range defaultIters {
+			k, lv := it.SeekGE(key, 0)
+			if k == nil || !bytes.Equal(k.UserKey, key) {
+				continue
+			}
+			value, _, err := lv.Value(nil)
+			return value, errors.Trace(err)
+		}
+		return nil, errors.Errorf("the value isn't found in the default cf")
+	}
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for k, lv := writeIter.First(); k != nil; k, lv = writeIter.Next() {
+		err := func() error {
+			rawKey, _, err := decodeMVCCKey(bytes.TrimPrefix(k.UserKey, dataKeyPrefix))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			value, _, err := lv.Value(nil)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			write := new(stream.RawWriteCFValue)
+			if err = write.ParseFrom(value); err != nil {
+				return errors.Trace(err)
+			}
+			if write.GetWriteType() != stream.WriteTypePut {
+				return nil
+			}
+			value = write.GetShortValue()
+			if !write.HasShortValue() {
+				defaultKey := codec.EncodeUintDesc(slices.Clone(k.UserKey[:len(k.UserKey)-8]), write.GetStartTs())
+				if value, err = getDefaultValue(defaultKey); err != nil {
+					return errors.Annotatef(err, "key %s", redact.Key(rawKey))
+				}
+			}
+			checksum.UpdateOne(common.KvPair{Key: rawKey, Val: value})
+			return errors.Annotatef(check(rawKey, value), "key %s", redact.Key(rawKey))
+		}()
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if err := writeIter.Error(); err != nil {
+		v.addIssue(IssueCorruptedFile, w.meta.Name, table, "failed to read the sst: %s", err)
+		return false
+	}
+	if failed > 0 {
+		v.addIssue(IssueDecodeFailed, w.meta.Name, table,
+			"%d KVs can't be decoded, the first error: %s", failed, firstErr)
+		return false
+	}
+	return true
+}
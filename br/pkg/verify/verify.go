@@ -0,0 +1,231 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/pkg/lightning/verification"
+	"github.com/pingcap/tidb/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Verifier verifies the backup data thoroughly without a cluster. It reads every file of the backup, checks the
+// files and decodes the KVs against the metadata, and collects the problems into the report.
+type Verifier struct {
+	concurrency uint
+
+	mu     sync.Mutex
+	report Report
+}
+
+// NewVerifier creates a verifier which reads and decodes the files with the concurrency.
+func NewVerifier(concurrency uint) *Verifier {
+	return &Verifier{
+		concurrency: max(concurrency, 1),
+		report:      Report{Issues: make([]Issue, 0)},
+	}
+}
+
+func (v *Verifier) addIssue(kind IssueKind, path, table, format string, args ...any) {
+	issue := Issue{Kind: kind, Path: path, Table: table, Message: fmt.Sprintf(format, args...)}
+	log.Warn("found issue in backup", zap.String("kind", string(kind)), zap.String("path", path),
+		zap.String("table", table), zap.String("message", issue.Message))
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.report.Issues = append(v.report.Issues, issue)
+}
+
+// Report returns the report of the verification.
+func (v *Verifier) Report() *Report {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.report.Passed = len(v.report.Issues) == 0
+	return &v.report
+}
+
+// readFile reads the file and checks its size and sha256 if they are recorded. It returns false if the file is
+// missing or mismatches the metadata.
+func (v *Verifier) readFile(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	path string,
+	size uint64,
+	sha []byte,
+	table string,
+) ([]byte, bool, error) {
+	data, err := s.ReadFile(ctx, path)
+	if err != nil {
+		exists, existErr := s.FileExists(ctx, path)
+		if existErr == nil && !exists {
+			v.addIssue(IssueMissingFile, path, table, "the file doesn't exist")
+			return nil, false, nil
+		}
+		return nil, false, errors.Annotatef(err, "failed to read file %s", path)
+	}
+	if size > 0 && uint64(len(data)) != size {
+		v.addIssue(IssueSizeMismatch, path, table, "the size is %d, but %d is recorded", len(data), size)
+		return nil, false, nil
+	}
+	if len(sha) > 0 {
+		if checksum := sha256.Sum256(data); !bytes.Equal(checksum[:], sha) {
+			v.addIssue(IssueSha256Mismatch, path, table, "the sha256 is %x, but %x is recorded", checksum[:], sha)
+			return nil, false, nil
+		}
+	}
+	return data, true, nil
+}
+
+// groupFilesByRange groups the files by their key ranges, the write CF file and the default CF file of a region
+// share the same key range.
+func groupFilesByRange(files []*backuppb.File) [][]*backuppb.File {
+	groups := make([][]*backuppb.File, 0, len(files))
+	index := make(map[[2]string]int, len(files))
+	for _, file := range files {
+		key := [2]string{string(file.StartKey), string(file.EndKey)}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], file)
+	}
+	return groups
+}
+
+func recordedChecksum(files []*backuppb.File) Checksum {
+	var c Checksum
+	for _, file := range files {
+		c.Crc64Xor ^= file.Crc64Xor
+		c.TotalKvs += file.TotalKvs
+		c.TotalBytes += file.TotalBytes
+	}
+	return c
+}
+
+type tableState struct {
+	table    *metautil.Table
+	result   *TableResult
+	checksum *verification.KVChecksum
+	failed   bool
+}
+
+// VerifySnapshot verifies the files of the snapshot backup, and recomputes the checksums of the tables.
+func (v *Verifier) VerifySnapshot(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	backupMeta *backuppb.BackupMeta,
+	cipher *backuppb.CipherInfo,
+) error {
+	if backupMeta.IsRawKv || backupMeta.IsTxnKv {
+		return errors.Annotate(berrors.ErrInvalidArgument, "verifying raw kv or txn kv backup is not supported")
+	}
+	reader := metautil.NewMetaReader(backupMeta, s, cipher)
+	dbs, err := metautil.LoadBackupTables(ctx, reader, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := &SnapshotResult{BackupTS: backupMeta.EndVersion, Tables: make([]*TableResult, 0)}
+	states := make([]*tableState, 0)
+	for _, db := range dbs {
+		for _, table := range db.Tables {
+			if table.Info == nil {
+				// empty database.
+				continue
+			}
+			state := &tableState{
+				table: table,
+				result: &TableResult{
+					Database: db.Info.Name.O,
+					Table:    table.Info.Name.O,
+					ID:       table.Info.ID,
+				},
+				checksum: verification.NewKVChecksum(),
+			}
+			expected := Checksum{Crc64Xor: table.Crc64Xor, TotalKvs: table.TotalKvs, TotalBytes: table.TotalBytes}
+			if expected == (Checksum{}) {
+				stats := table.CalculateChecksumStatsOnFiles()
+				expected = Checksum{Crc64Xor: stats.Crc64Xor, TotalKvs: stats.TotalKvs, TotalBytes: stats.TotalBytes}
+			}
+			if expected != (Checksum{}) {
+				state.result.Expected = &expected
+			}
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].result.Database != states[j].result.Database {
+			return states[i].result.Database < states[j].result.Database
+		}
+		return states[i].result.Table < states[j].result.Table
+	})
+
+	pool := util.NewWorkerPool(v.concurrency, "verify snapshot backup")
+	eg, ectx := errgroup.WithContext(ctx)
+	for _, state := range states {
+		name := utils.EncloseDBAndTable(state.result.Database, state.result.Table)
+		check := newTableKVChecker(state.table.Info)
+		for _, files := range state.table.FilesOfPhysicals {
+			state.result.Files += len(files)
+			result.Files += len(files)
+			result.Size += metautil.ArchiveSize(files)
+			for _, group := range groupFilesByRange(files) {
+				pool.ApplyOnErrorGroup(eg, func() error {
+					checksum, ok, err := v.verifySSTs(ectx, s, group, cipher, name, check)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					if ok {
+						recorded := recordedChecksum(group)
+						actual := checksumOf(checksum)
+						if recorded != (Checksum{}) && recorded != actual {
+							v.addIssue(IssueChecksumMismatch, group[0].Name, name,
+								"the checksum of the files is %+v, but %+v is recorded", actual, recorded)
+							ok = false
+						}
+					}
+					v.mu.Lock()
+					defer v.mu.Unlock()
+					if ok {
+						state.checksum.Add(checksum)
+					} else {
+						state.failed = true
+					}
+					return nil
+				})
+			}
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, state := range states {
+		state.result.Actual = checksumOf(state.checksum)
+		if !state.failed && state.result.Expected != nil && *state.result.Expected != state.result.Actual {
+			v.addIssue(IssueChecksumMismatch, "", utils.EncloseDBAndTable(state.result.Database, state.result.Table),
+				"the checksum of the table is %+v, but %+v is recorded", state.result.Actual, *state.result.Expected)
+			state.failed = true
+		}
+		state.result.Passed = !state.failed
+		result.Tables = append(result.Tables, state.result)
+	}
+	v.mu.Lock()
+	v.report.Snapshot = result
+	v.mu.Unlock()
+	return nil
+}
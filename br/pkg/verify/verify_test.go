@@ -0,0 +1,431 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package verify_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils/consts"
+	"github.com/pingcap/tidb/br/pkg/verify"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/verification"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/rowcodec"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tableID = int64(100)
+	// startTS and commitTS are realistic TSOs, so that they are encoded like the ones of TiKV.
+	startTS  = uint64(404411537129996288)
+	commitTS = startTS + 1
+	backupTS = commitTS + 1
+)
+
+type kvPair struct {
+	key   []byte
+	value []byte
+}
+
+func mockTableInfo() *model.TableInfo {
+	return &model.TableInfo{
+		ID:   tableID,
+		Name: ast.NewCIStr("t"),
+		Columns: []*model.ColumnInfo{
+			{ID: 1, Name: ast.NewCIStr("a"), Offset: 0, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLonglong)},
+			{ID: 2, Name: ast.NewCIStr("b"), Offset: 1, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+		},
+		Indices: []*model.IndexInfo{{
+			ID:      1,
+			Name:    ast.NewCIStr("idx"),
+			Columns: []*model.IndexColumn{{Name: ast.NewCIStr("a"), Offset: 0, Length: types.UnspecifiedLength}},
+			State:   model.StatePublic,
+		}},
+		State: model.StatePublic,
+	}
+}
+
+// mockTableKVs returns the raw KVs of the table, some of the rows are too long to be stored in the write CF.
+func mockTableKVs(t *testing.T, rows int, corruptRow bool) []kvPair {
+	kvs := make([]kvPair, 0, rows*2)
+	for i := range rows {
+		handle := int64(i + 1)
+		row, err := tablecodec.EncodeRow(time.UTC,
+			[]types.Datum{types.NewIntDatum(handle), types.NewStringDatum(strings.Repeat("x", i*100))},
+			[]int64{1, 2}, nil, nil, nil, &rowcodec.Encoder{})
+		require.NoError(t, err)
+		if corruptRow && i == 0 {
+			row = []byte{rowcodec.CodecVer, 0xff}
+		}
+		kvs = append(kvs, kvPair{key: tablecodec.EncodeRowKeyWithHandle(tableID, kv.IntHandle(handle)), value: row})
+
+		values, err := codec.EncodeKey(time.UTC, nil, types.NewIntDatum(handle), types.NewIntDatum(handle))
+		require.NoError(t, err)
+		kvs = append(kvs, kvPair{key: tablecodec.EncodeIndexSeekKey(tableID, 1, values), value: []byte{'0'}})
+	}
+	return kvs
+}
+
+func writeCFValue(value []byte) []byte {
+	data := append([]byte{stream.WriteTypePut}, codec.EncodeUvarint(nil, startTS)...)
+	if len(value) <= 255 {
+		data = append(data, 'v', byte(len(value)))
+		data = append(data, value...)
+	}
+	return data
+}
+
+func mvccKey(key []byte, ts uint64) []byte {
+	return codec.EncodeUintDesc(append([]byte{'z'}, codec.EncodeBytes(nil, key)...), ts)
+}
+
+func buildSST(t *testing.T, kvs []kvPair) []byte {
+	slices.SortFunc(kvs, func(a, b kvPair) int { return bytes.Compare(a.key, b.key) })
+	fs := vfs.NewMem()
+	f, err := fs.Create("sst")
+	require.NoError(t, err)
+	w := sstable.NewWriter(objstorageprovider.NewFileWritable(f), sstable.WriterOptions{})
+	for _, pair := range kvs {
+		require.NoError(t, w.Set(pair.key, pair.value))
+	}
+	require.NoError(t, w.Close())
+	f, err = fs.Open("sst")
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return data
+}
+
+func writeFile(t *testing.T, s storage.ExternalStorage, name, cf string, data []byte) *backuppb.File {
+	require.NoError(t, s.WriteFile(context.Background(), name, data))
+	sha := sha256.Sum256(data)
+	return &backuppb.File{
+		Name:     name,
+		Cf:       cf,
+		Size_:    uint64(len(data)),
+		Sha256:   sha[:],
+		StartKey: tablecodec.EncodeTablePrefix(tableID),
+		EndKey:   tablecodec.EncodeTablePrefix(tableID + 1),
+	}
+}
+
+// writeSnapshotBackup writes a snapshot backup of a table in the format of TiKV, and returns its backupmeta.
+func writeSnapshotBackup(t *testing.T, s storage.ExternalStorage, corruptRow bool) *backuppb.BackupMeta {
+	checksum := verification.NewKVChecksum()
+	var writes, defaults []kvPair
+	for _, pair := range mockTableKVs(t, 5, corruptRow) {
+		checksum.UpdateOne(common.KvPair{Key: pair.key, Val: pair.value})
+		writes = append(writes, kvPair{key: mvccKey(pair.key, commitTS), value: writeCFValue(pair.value)})
+		if len(pair.value) > 255 {
+			defaults = append(defaults, kvPair{key: mvccKey(pair.key, startTS), value: pair.value})
+		}
+	}
+	writeCFFile := writeFile(t, s, "1_write.sst", consts.WriteCF, buildSST(t, writes))
+	writeCFFile.Crc64Xor, writeCFFile.TotalKvs, writeCFFile.TotalBytes = checksum.Sum(), checksum.SumKVS(), checksum.SumSize()
+	defaultCFFile := writeFile(t, s, "1_default.sst", consts.DefaultCF, buildSST(t, defaults))
+
+	dbInfo, err := json.Marshal(&model.DBInfo{ID: 1, Name: ast.NewCIStr("test")})
+	require.NoError(t, err)
+	tableInfo, err := json.Marshal(mockTableInfo())
+	require.NoError(t, err)
+	meta := &backuppb.BackupMeta{
+		StartVersion: backupTS,
+		EndVersion:   backupTS,
+		Files:        []*backuppb.File{writeCFFile, defaultCFFile},
+		Schemas: []*backuppb.Schema{{
+			Db:         dbInfo,
+			Table:      tableInfo,
+			Crc64Xor:   checksum.Sum(),
+			TotalKvs:   checksum.SumKVS(),
+			TotalBytes: checksum.SumSize(),
+		}},
+	}
+	data, err := meta.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(context.Background(), metautil.MetaFile, data))
+	return meta
+}
+
+func issueKinds(report *verify.Report) []verify.IssueKind {
+	kinds := make([]verify.IssueKind, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		kinds = append(kinds, issue.Kind)
+	}
+	return kinds
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	ctx := context.Background()
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	meta := writeSnapshotBackup(t, s, false)
+
+	v := verify.NewVerifier(2)
+	require.NoError(t, v.VerifySnapshot(ctx, s, meta, nil))
+	report := v.Report()
+	require.True(t, report.Passed, "%+v", report.Issues)
+	require.Nil(t, report.Log)
+	require.Equal(t, backupTS, report.Snapshot.BackupTS)
+	require.Equal(t, 2, report.Snapshot.Files)
+	require.Len(t, report.Snapshot.Tables, 1)
+	table := report.Snapshot.Tables[0]
+	require.Equal(t, "test", table.Database)
+	require.Equal(t, "t", table.Table)
+	require.True(t, table.Passed)
+	require.Equal(t, *table.Expected, table.Actual)
+	require.Equal(t, uint64(10), table.Actual.TotalKvs)
+
+	// the report is machine-readable.
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"passed":true`)
+	require.Contains(t, string(data), `"issues":[]`)
+}
+
+func TestVerifySnapshotIssues(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name       string
+		corruptRow bool
+		modify     func(t *testing.T, s storage.ExternalStorage, meta *backuppb.BackupMeta)
+		expected   []verify.IssueKind
+	}{
+		{
+			name: "missing file",
+			modify: func(t *testing.T, s storage.ExternalStorage, _ *backuppb.BackupMeta) {
+				require.NoError(t, s.DeleteFile(ctx, "1_default.sst"))
+			},
+			expected: []verify.IssueKind{verify.IssueMissingFile},
+		},
+		{
+			name: "size mismatch",
+			modify: func(t *testing.T, s storage.ExternalStorage, _ *backuppb.BackupMeta) {
+				data, err := s.ReadFile(ctx, "1_write.sst")
+				require.NoError(t, err)
+				require.NoError(t, s.WriteFile(ctx, "1_write.sst", append(data, 0)))
+			},
+			expected: []verify.IssueKind{verify.IssueSizeMismatch},
+		},
+		{
+			name: "sha256 mismatch",
+			modify: func(t *testing.T, s storage.ExternalStorage, _ *backuppb.BackupMeta) {
+				data, err := s.ReadFile(ctx, "1_write.sst")
+				require.NoError(t, err)
+				data[0] ^= 0xff
+				require.NoError(t, s.WriteFile(ctx, "1_write.sst", data))
+			},
+			expected: []verify.IssueKind{verify.IssueSha256Mismatch},
+		},
+		{
+			name: "corrupted file",
+			modify: func(t *testing.T, s storage.ExternalStorage, meta *backuppb.BackupMeta) {
+				data := []byte("not a sst file")
+				sha := sha256.Sum256(data)
+				require.NoError(t, s.WriteFile(ctx, "1_write.sst", data))
+				meta.Files[0].Size_, meta.Files[0].Sha256 = uint64(len(data)), sha[:]
+			},
+			expected: []verify.IssueKind{verify.IssueCorruptedFile},
+		},
+		{
+			name:       "undecodable row",
+			corruptRow: true,
+			modify:     func(*testing.T, storage.ExternalStorage, *backuppb.BackupMeta) {},
+			expected:   []verify.IssueKind{verify.IssueDecodeFailed},
+		},
+		{
+			name: "file checksum mismatch",
+			modify: func(_ *testing.T, _ storage.ExternalStorage, meta *backuppb.BackupMeta) {
+				meta.Files[0].TotalKvs++
+			},
+			expected: []verify.IssueKind{verify.IssueChecksumMismatch},
+		},
+		{
+			name: "table checksum mismatch",
+			modify: func(_ *testing.T, _ storage.ExternalStorage, meta *backuppb.BackupMeta) {
+				meta.Schemas[0].Crc64Xor++
+			},
+			expected: []verify.IssueKind{verify.IssueChecksumMismatch},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := storage.NewLocalStorage(t.TempDir())
+			require.NoError(t, err)
+			meta := writeSnapshotBackup(t, s, c.corruptRow)
+			c.modify(t, s, meta)
+
+			v := verify.NewVerifier(2)
+			require.NoError(t, v.VerifySnapshot(ctx, s, meta, nil))
+			report := v.Report()
+			require.False(t, report.Passed)
+			require.Equal(t, c.expected, issueKinds(report))
+			require.False(t, report.Snapshot.Tables[0].Passed)
+		})
+	}
+}
+
+func TestVerifySnapshotOnS3(t *testing.T) {
+	ctx := context.Background()
+	backend := s3mem.New()
+	faker := gofakes3.New(backend)
+	ts := httptest.NewServer(faker.Server())
+	defer ts.Close()
+	require.NoError(t, backend.CreateBucket("test-bucket"))
+	s, err := storage.NewFromURL(ctx, fmt.Sprintf(
+		"s3://test-bucket/backup?region=us-east-1&endpoint=%s&access-key=xxxxxx&secret-access-key=xxxxxx&force-path-style=true",
+		ts.URL))
+	require.NoError(t, err)
+	meta := writeSnapshotBackup(t, s, false)
+
+	v := verify.NewVerifier(2)
+	require.NoError(t, v.VerifySnapshot(ctx, s, meta, nil))
+	report := v.Report()
+	require.True(t, report.Passed, "%+v", report.Issues)
+	require.True(t, report.Snapshot.Tables[0].Passed)
+
+	require.NoError(t, s.DeleteFile(ctx, "1_write.sst"))
+	v = verify.NewVerifier(2)
+	require.NoError(t, v.VerifySnapshot(ctx, s, meta, nil))
+	require.Equal(t, []verify.IssueKind{verify.IssueMissingFile}, issueKinds(v.Report()))
+}
+
+// writeLogBackup writes a log backup which has a data file of the write CF, and returns the data file.
+func writeLogBackup(t *testing.T, s storage.ExternalStorage, modify func(*backuppb.DataFileInfo)) []byte {
+	ctx := context.Background()
+	var content []byte
+	entries := int64(0)
+	for _, pair := range mockTableKVs(t, 3, false) {
+		key := codec.EncodeUintDesc(codec.EncodeBytes(nil, pair.key), commitTS)
+		content = append(content, stream.EncodeKVEntry(key, writeCFValue(pair.value))...)
+		entries++
+	}
+	sha := sha256.Sum256(content)
+	file := &backuppb.DataFileInfo{
+		Sha256:                sha[:],
+		NumberOfEntries:       entries,
+		MinTs:                 commitTS,
+		MaxTs:                 commitTS,
+		ResolvedTs:            commitTS,
+		Cf:                    consts.WriteCF,
+		TableId:               tableID,
+		Length:                uint64(len(content)),
+		RangeOffset:           0,
+		RangeLength:           uint64(len(content)),
+		MinBeginTsInDefaultCf: startTS,
+	}
+	if modify != nil {
+		modify(file)
+	}
+	dataPath := "v1/20260101/00/1/1.log"
+	require.NoError(t, s.WriteFile(ctx, dataPath, content))
+	meta := &backuppb.Metadata{
+		StoreId:     1,
+		MinTs:       commitTS,
+		MaxTs:       commitTS,
+		ResolvedTs:  commitTS,
+		MetaVersion: backuppb.MetaVersion_V2,
+		FileGroups: []*backuppb.DataFileGroup{{
+			Path:          dataPath,
+			DataFilesInfo: []*backuppb.DataFileInfo{file},
+			MinTs:         commitTS,
+			MaxTs:         commitTS,
+			MinResolvedTs: commitTS,
+			Length:        uint64(len(content)),
+		}},
+	}
+	data, err := meta.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(ctx, path.Join(stream.GetStreamBackupMetaPrefix(), "1.meta"), data))
+	return content
+}
+
+func TestVerifyLog(t *testing.T) {
+	ctx := context.Background()
+	window := verify.LogWindow{LogMinTS: startTS, LogMaxTS: backupTS, StartTS: startTS, RestoredTS: backupTS}
+	cases := []struct {
+		name     string
+		window   verify.LogWindow
+		modify   func(*backuppb.DataFileInfo)
+		corrupt  bool
+		expected []verify.IssueKind
+	}{
+		{
+			name:     "passed",
+			window:   window,
+			expected: []verify.IssueKind{},
+		},
+		{
+			name:     "window after the global checkpoint",
+			window:   verify.LogWindow{LogMinTS: startTS, LogMaxTS: backupTS, StartTS: startTS, RestoredTS: backupTS + 1},
+			expected: []verify.IssueKind{verify.IssueLogGap},
+		},
+		{
+			name:     "window before the start of the log",
+			window:   verify.LogWindow{LogMinTS: startTS, LogMaxTS: backupTS, StartTS: startTS - 1, RestoredTS: backupTS},
+			expected: []verify.IssueKind{verify.IssueLogGap},
+		},
+		{
+			name:     "entries mismatch",
+			window:   window,
+			modify:   func(file *backuppb.DataFileInfo) { file.NumberOfEntries++ },
+			expected: []verify.IssueKind{verify.IssueEntriesMismatch},
+		},
+		{
+			name:     "sha256 mismatch",
+			window:   window,
+			corrupt:  true,
+			expected: []verify.IssueKind{verify.IssueSha256Mismatch},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := storage.NewLocalStorage(t.TempDir())
+			require.NoError(t, err)
+			content := writeLogBackup(t, s, c.modify)
+			if c.corrupt {
+				content[len(content)-1] ^= 0xff
+				require.NoError(t, s.WriteFile(ctx, "v1/20260101/00/1/1.log", content))
+			}
+
+			v := verify.NewVerifier(2)
+			require.NoError(t, v.VerifyLog(ctx, s, c.window, nil))
+			report := v.Report()
+			require.Equal(t, len(c.expected) == 0, report.Passed, "%+v", report.Issues)
+			require.Equal(t, c.expected, issueKinds(report))
+			require.Equal(t, 1, report.Log.DataFiles)
+		})
+	}
+
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	writeLogBackup(t, s, nil)
+	v := verify.NewVerifier(2)
+	require.Error(t, v.VerifyLog(ctx, s, verify.LogWindow{StartTS: backupTS, RestoredTS: startTS}, nil))
+}
@@ -76,6 +76,10 @@ const (
 	flagTransactionalConsistency = "transactional-consistency"
 	flagCompress                 = "compress"
 	flagCsvOutputDialect         = "csv-output-dialect"
+	flagIncrementalFrom          = "incremental-from"
+	flagIncrementalColumn        = "incremental-column"
+	flagParquetRowGroupSize      = "parquet-row-group-size"
+	flagParquetInvalidDateAsNull = "parquet-invalid-date-as-null"
 
 	// FlagHelp represents the help flag
 	FlagHelp = "help"
@@ -179,6 +183,11 @@ type Config struct {
 	Tables              DatabaseTables
 	CollationCompatible string
 	CsvOutputDialect    CSVDialect
+	IncrementalFrom     string
+	IncrementalColumn   string
+	ParquetRowGroupSize uint64
+	// ParquetInvalidDateAsNull writes the zero or invalid dates, which can't be represented in parquet, as NULL.
+	ParquetInvalidDateAsNull bool
 
 	Labels        prometheus.Labels       `json:"-"`
 	PromFactory   promutil.Factory        `json:"-"`
@@ -241,6 +250,7 @@ func DefaultConfig() *Config {
 		PromFactory:              promutil.NewDefaultFactory(),
 		PromRegistry:             promutil.NewDefaultRegistry(),
 		TransactionalConsistency: true,
+		ParquetRowGroupSize:      DefaultParquetRowGroupSize,
 	}
 }
 
@@ -330,7 +340,7 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 		"If not specified, dumpling will dump table without inner-concurrency which could be relatively slow. default unlimited")
 	flags.String(flagWhere, "", "Dump only selected records")
 	flags.Bool(flagEscapeBackslash, true, "use backslash to escape special characters")
-	flags.String(flagFiletype, "", "The type of export file (sql/csv)")
+	flags.String(flagFiletype, "", "The type of export file (sql/csv/parquet)")
 	flags.Bool(flagNoHeader, false, "whether not to dump CSV table header")
 	flags.BoolP(flagNoSchemas, "m", false, "Do not dump table schemas with the data")
 	flags.BoolP(flagNoData, "d", false, "Do not dump table data")
@@ -357,6 +367,10 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 	_ = flags.MarkHidden(flagTransactionalConsistency)
 	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'snappy', 'zstd', 'no-compression' now")
 	flags.String(flagCsvOutputDialect, "", "The dialect of output CSV file, support 'snowflake', 'redshift', 'bigquery' now")
+	flags.String(flagIncrementalFrom, "", "Only dump the rows changed since a previous dump, given by its output directory or its snapshot TSO. Valid only when consistency=snapshot")
+	flags.String(flagIncrementalColumn, "", "The update-time column used to find the changed rows in incremental dump. Tables without it are compared between the two snapshots by scanning the whole table instead")
+	flags.String(flagParquetRowGroupSize, "128MiB", "The approximate size of row groups in output parquet file")
+	flags.Bool(flagParquetInvalidDateAsNull, false, "Write the zero or invalid dates as NULL in output parquet file, otherwise the dump fails on them")
 }
 
 // ParseFromFlags parses dumpling's export.Config from flags
@@ -602,6 +616,29 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 		return errors.Trace(err)
 	}
 
+	conf.IncrementalFrom, err = flags.GetString(flagIncrementalFrom)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.IncrementalColumn, err = flags.GetString(flagIncrementalColumn)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	rowGroupSizeStr, err := flags.GetString(flagParquetRowGroupSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rowGroupSize, err := units.RAMInBytes(rowGroupSizeStr)
+	if err != nil || rowGroupSize <= 0 {
+		return errors.Errorf("failed to parse parquet row group size (--%s '%s')", flagParquetRowGroupSize, rowGroupSizeStr)
+	}
+	conf.ParquetRowGroupSize = uint64(rowGroupSize)
+	conf.ParquetInvalidDateAsNull, err = flags.GetBool(flagParquetInvalidDateAsNull)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	for k, v := range params {
 		conf.SessionParams[k] = v
 	}
@@ -720,6 +757,8 @@ const (
 	UnspecifiedSize = 0
 	// DefaultStatementSize is the default statement size
 	DefaultStatementSize = 1000000
+	// DefaultParquetRowGroupSize is the default row group size of the output parquet files
+	DefaultParquetRowGroupSize = 128 * units.MiB
 	// TiDBMemQuotaQueryName is the session variable TiDBMemQuotaQuery's name in TiDB
 	TiDBMemQuotaQueryName = "tidb_mem_quota_query"
 	// DefaultTableFilter is the default exclude table filter. It will exclude all system databases
@@ -776,6 +815,22 @@ func validateSpecifiedSQL(conf *Config) error {
 	return nil
 }
 
+func validateIncremental(conf *Config) error {
+	if conf.IncrementalFrom == "" {
+		if conf.IncrementalColumn != "" {
+			return errors.Errorf("--%s requires --%s", flagIncrementalColumn, flagIncrementalFrom)
+		}
+		return nil
+	}
+	if conf.SQL != "" {
+		return errors.Errorf("can't specify both --sql and --%s at the same time", flagIncrementalFrom)
+	}
+	if conf.NoData {
+		return errors.Errorf("can't specify both --no-data and --%s at the same time", flagIncrementalFrom)
+	}
+	return nil
+}
+
 func adjustFileFormat(conf *Config) error {
 	conf.FileType = strings.ToLower(conf.FileType)
 	switch conf.FileType {
@@ -789,7 +844,7 @@ func adjustFileFormat(conf *Config) error {
 		if conf.SQL != "" {
 			return errors.Errorf("unsupported config.FileType '%s' when we specify --sql, please unset --filetype or set it to 'csv'", conf.FileType)
 		}
-	case FileFormatCSVString:
+	case FileFormatCSVString, FileFormatParquetString:
 	default:
 		return errors.Errorf("unknown config.FileType '%s'", conf.FileType)
 	}
@@ -67,6 +67,7 @@ type Dumper struct {
 	selectTiDBTableRegionFunc     func(tctx *tcontext.Context, conn *BaseConn, meta TableMeta) (pkFields []string, pkVals [][]string, err error)
 	totalTables                   int64
 	charsetAndDefaultCollationMap map[string]string
+	incremental                   *incrementalRange
 
 	speedRecorder *SpeedRecorder
 }
@@ -108,6 +109,7 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 	err = adjustConfig(conf,
 		buildTLSConfig,
 		validateSpecifiedSQL,
+		validateIncremental,
 		adjustFileFormat)
 	if err != nil {
 		return nil, err
@@ -134,6 +136,7 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 		validateResolveAutoConsistency,
 		tidbSetPDClientForGC,
 		tidbGetSnapshot,
+		resolveIncrementalRange,
 		tidbStartGCSavepointUpdateService,
 
 		setSessionParam)
@@ -333,6 +336,11 @@ func (d *Dumper) Dump() (dumpErr error) {
 	}
 	summary.CollectSuccessUnit("dump cost", countTotalTask(writers), time.Since(tableDataStartTime))
 
+	if d.incremental != nil {
+		if err := writeIncrementalManifest(tctx, d.extStore, d.incremental); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	summary.SetSuccessStatus(true)
 	m.recordFinishTime(time.Now())
 	return nil
@@ -623,6 +631,9 @@ func (d *Dumper) dumpTableData(tctx *tcontext.Context, conn *BaseConn, meta Tabl
 	c := estimateCount(tctx, meta.DatabaseName(), meta.TableName(), conn, fieldName, conf)
 	AddCounter(d.metrics.estimateTotalRowsCounter, float64(c))
 
+	if d.incremental != nil {
+		return d.incrementalDumpTable(tctx, conn, meta, taskChan)
+	}
 	if conf.Rows == UnspecifiedSize {
 		return d.sequentialDumpTable(tctx, conn, meta, taskChan)
 	}
@@ -1513,6 +1524,10 @@ func tidbStartGCSavepointUpdateService(d *Dumper) error {
 		if err != nil {
 			return err
 		}
+		// incremental dump reads the start snapshot too, so GC must be blocked from it.
+		if d.incremental != nil {
+			snapshotTS = d.incremental.startTS
+		}
 		go updateServiceSafePoint(tctx, d.tidbPDClientForGC, defaultDumpGCSafePointTTL, snapshotTS)
 	} else if si.ServerType == version.ServerTypeTiDB {
 		tctx.L().Warn("If the amount of data to dump is large, criteria: (data more than 60GB or dumped time more than 10 minutes)\n" +
@@ -0,0 +1,548 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/version"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// incrementalManifestPath is the manifest of an incremental dump, which chains the dump to the previous ones. It's
+// read by the next incremental dump to find its start snapshot, and by Lightning to apply the dump.
+const incrementalManifestPath = "incremental.json"
+
+// incrementalRange is the snapshot range of an incremental dump. Rows changed in
+// (startTS, endTS] are dumped, together with the rows deleted in the range.
+type incrementalRange struct {
+	// from is the previous dump directory or the start TSO given by the user.
+	from    string
+	startTS uint64
+	endTS   uint64
+	// chain is the snapshots of the dumps which this dump is chained to, see incrementalManifest.Chain.
+	chain []uint64
+}
+
+// incrementalManifest is the content of incrementalManifestPath.
+type incrementalManifest struct {
+	From    string `json:"from"`
+	StartTS uint64 `json:"start-ts"`
+	EndTS   uint64 `json:"end-ts"`
+	// Chain is the snapshots of the dumps from the first full dump to this dump, the dumps should be applied in this
+	// order. The first snapshot is the start TSO if the dump is started from a TSO given by the user.
+	Chain []uint64 `json:"chain"`
+}
+
+// resolveIncrementalRange is an initialization step of Dumper.
+func resolveIncrementalRange(d *Dumper) error {
+	tctx, conf, pool := d.tctx, d.conf, d.dbHandle
+	if conf.IncrementalFrom == "" {
+		return nil
+	}
+	if conf.ServerInfo.ServerType != version.ServerTypeTiDB || conf.Consistency != ConsistencyTypeSnapshot {
+		return errors.Errorf("incremental dump is only supported for TiDB with consistency snapshot, resolved consistency: %s", conf.Consistency)
+	}
+
+	var (
+		startTS uint64
+		chain   []uint64
+		err     error
+	)
+	if startTS, err = strconv.ParseUint(conf.IncrementalFrom, 10, 64); err != nil {
+		startTS, chain, err = readPreviousDumpSnapshot(tctx, pool, conf)
+		if err != nil {
+			return err
+		}
+	} else {
+		chain = []uint64{startTS}
+	}
+	endTS, err := parseSnapshotToTSO(pool, conf.Snapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if startTS >= endTS {
+		return errors.Errorf("the start snapshot %d of incremental dump should be less than the dump snapshot %d", startTS, endTS)
+	}
+
+	// fail fast if the start snapshot has been garbage collected.
+	conn, err := openSnapshotConn(tctx, pool, startTS)
+	if err != nil {
+		return err
+	}
+	discardConn(conn)
+
+	d.incremental = &incrementalRange{
+		from:    conf.IncrementalFrom,
+		startTS: startTS,
+		endTS:   endTS,
+		chain:   append(chain, endTS),
+	}
+	tctx.L().Info("dump incrementally",
+		zap.String("from", conf.IncrementalFrom),
+		zap.Uint64("startTS", startTS),
+		zap.Uint64("endTS", endTS))
+	return nil
+}
+
+// readPreviousDumpSnapshot reads the snapshot of the previous dump and the chain of it. The manifest is read if the
+// previous dump is an incremental dump, otherwise the snapshot is read from the metadata of the full dump.
+func readPreviousDumpSnapshot(tctx *tcontext.Context, pool *sql.DB, conf *Config) (uint64, []uint64, error) {
+	b, err := storage.ParseBackend(conf.IncrementalFrom, &conf.BackendOptions)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	s, err := storage.New(tctx, b, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	defer s.Close()
+
+	exists, err := s.FileExists(tctx, incrementalManifestPath)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	if exists {
+		data, err := s.ReadFile(tctx, incrementalManifestPath)
+		if err != nil {
+			return 0, nil, errors.Annotatef(err, "failed to read manifest of the previous dump %s", conf.IncrementalFrom)
+		}
+		var manifest incrementalManifest
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			return 0, nil, errors.Annotatef(err, "failed to parse manifest of the previous dump %s", conf.IncrementalFrom)
+		}
+		return manifest.EndTS, manifest.Chain, nil
+	}
+
+	data, err := s.ReadFile(tctx, metadataPath)
+	if err != nil {
+		return 0, nil, errors.Annotatef(err, "failed to read metadata of the previous dump %s", conf.IncrementalFrom)
+	}
+	pos, err := parseMetadataPos(string(data))
+	if err != nil {
+		return 0, nil, errors.Annotatef(err, "previous dump %s", conf.IncrementalFrom)
+	}
+	ts, err := parseSnapshotToTSO(pool, pos)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	return ts, []uint64{ts}, nil
+}
+
+// writeIncrementalManifest writes the manifest of the incremental dump. It's written after all the data are dumped,
+// so a failed dump can't be chained by the next incremental dump.
+func writeIncrementalManifest(tctx *tcontext.Context, s storage.ExternalStorage, r *incrementalRange) error {
+	data, err := json.Marshal(&incrementalManifest{
+		From:    r.from,
+		StartTS: r.startTS,
+		EndTS:   r.endTS,
+		Chain:   r.chain,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fileWriter, tearDown, err := buildFileWriter(tctx, s, incrementalManifestPath, storage.NoCompression)
+	if err != nil {
+		return err
+	}
+	err = writeBytes(tctx, fileWriter, data)
+	tearDownErr := tearDown(tctx)
+	if err == nil {
+		return tearDownErr
+	}
+	return err
+}
+
+// openSnapshotConn opens a connection which reads at the given snapshot. The
+// connection should be released by discardConn to keep the pool clean.
+func openSnapshotConn(tctx *tcontext.Context, pool *sql.DB, ts uint64) (*sql.Conn, error) {
+	conn, err := pool.Conn(tctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	query := fmt.Sprintf("SET SESSION %s = '%d'", snapshotVar, ts)
+	if _, err = conn.ExecContext(tctx, query); err != nil {
+		discardConn(conn)
+		return nil, errors.Annotatef(err, "sql: %s", query)
+	}
+	return conn, nil
+}
+
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		// return an `ErrBadConn` to close the connection instead of putting it back to the pool.
+		return driver.ErrBadConn
+	})
+}
+
+// incrementalDumpTable dumps the rows deleted from the table since the start snapshot,
+// and the rows inserted or updated since then. Deleted rows are found by comparing the
+// primary keys between the two snapshots. Changed rows are selected by the incremental
+// column if the table has it, otherwise found by comparing the whole rows.
+//
+// Note that finding the deleted rows scans the primary keys of the whole table at both
+// snapshots, and comparing the whole rows scans the whole table at both snapshots. So
+// the cost of an incremental dump is proportional to the table size rather than the
+// changes, it only saves the cost of writing and importing the unchanged rows.
+func (d *Dumper) incrementalDumpTable(tctx *tcontext.Context, conn *BaseConn, meta TableMeta, taskChan chan<- Task) error {
+	conf, inc := d.conf, d.incremental
+	db, tbl := meta.DatabaseName(), meta.TableName()
+	handleCols, handleTps, err := GetPrimaryKeyAndColumnTypes(tctx, conn, meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(handleCols) == 0 {
+		return errors.Errorf("incremental dump requires a primary key, but table `%s`.`%s` has none", db, tbl)
+	}
+	quotedCols := make([]string, 0, len(handleCols))
+	for _, col := range handleCols {
+		quotedCols = append(quotedCols, wrapBackTicks(escapeString(col)))
+	}
+	handleField := strings.Join(quotedCols, ",")
+	orderByClause := buildOrderByClauseString(handleCols)
+	where := buildWhereCondition(conf, "")
+
+	deletesQuery := buildSelectQuery(db, tbl, handleField, "", where, orderByClause)
+	deletes := newSnapshotDiffData(d.dbHandle, inc.startTS, deletesQuery, handleTps, len(handleCols), true)
+	if ctxDone := d.sendTaskToChan(tctx, NewTaskTableDeletes(meta, deletes, handleCols, handleTps), taskChan); ctxDone {
+		return tctx.Err()
+	}
+
+	var data TableDataIR
+	if hasColumn(meta, conf.IncrementalColumn) {
+		cond := fmt.Sprintf("%s >= TIDB_PARSE_TSO(%d)", wrapBackTicks(escapeString(conf.IncrementalColumn)), inc.startTS)
+		query := buildSelectQuery(db, tbl, meta.SelectedField(), "", buildWhereCondition(conf, cond), orderByClause)
+		data = newTableData(query, meta.SelectedLen(), false)
+	} else {
+		tctx.L().Warn("table has no incremental column, compare the whole rows between the snapshots",
+			zap.String("database", db), zap.String("table", tbl), zap.String("incremental column", conf.IncrementalColumn))
+		fields, colLen := handleField, len(handleCols)
+		switch selectedField := meta.SelectedField(); selectedField {
+		case "":
+		case "*":
+			fields += "," + wrapBackTicks(escapeString(tbl)) + ".*"
+			colLen += meta.SelectedLen()
+		default:
+			fields += "," + selectedField
+			colLen += meta.SelectedLen()
+		}
+		query := buildSelectQuery(db, tbl, fields, "", where, orderByClause)
+		data = newSnapshotDiffData(d.dbHandle, inc.startTS, query, handleTps, colLen, false)
+	}
+	if ctxDone := d.sendTaskToChan(tctx, d.newTaskTableData(meta, data, 0, 1), taskChan); ctxDone {
+		return tctx.Err()
+	}
+	return nil
+}
+
+func hasColumn(meta TableMeta, column string) bool {
+	if column == "" {
+		return false
+	}
+	for _, name := range meta.ColumnNames() {
+		if strings.EqualFold(name, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotDiffData is a TableDataIR that compares the query results between the start
+// snapshot and the dump snapshot. The query should select the handle columns first and
+// order the rows by them.
+type snapshotDiffData struct {
+	pool      *sql.DB
+	startTS   uint64
+	query     string
+	handleTps []string
+	colLen    int
+	// deleted means returning the handles of the rows deleted since the start snapshot,
+	// otherwise returning the rows inserted or updated since then without the handles.
+	deleted bool
+
+	startConn *sql.Conn
+	startRows *sql.Rows
+	endRows   *sql.Rows
+	SQLRowIter
+}
+
+func newSnapshotDiffData(pool *sql.DB, startTS uint64, query string, handleTps []string, colLen int, deleted bool) *snapshotDiffData { // revive:disable-line:flag-parameter
+	return &snapshotDiffData{
+		pool:      pool,
+		startTS:   startTS,
+		query:     query,
+		handleTps: handleTps,
+		colLen:    colLen,
+		deleted:   deleted,
+	}
+}
+
+func (td *snapshotDiffData) Start(tctx *tcontext.Context, conn *sql.Conn) error {
+	tctx.L().Debug("try to start snapshotDiffData", zap.String("query", td.query), zap.Uint64("startTS", td.startTS))
+	td.SQLRowIter = nil
+	endRows, err := conn.QueryContext(tctx, td.query)
+	if err != nil {
+		return errors.Annotatef(err, "sql: %s", td.query)
+	}
+	startConn, err := openSnapshotConn(tctx, td.pool, td.startTS)
+	if err != nil {
+		_ = endRows.Close()
+		return err
+	}
+	startRows, err := startConn.QueryContext(tctx, td.query)
+	if err != nil {
+		_ = endRows.Close()
+		discardConn(startConn)
+		return errors.Annotatef(err, "sql: %s, snapshot: %d", td.query, td.startTS)
+	}
+	td.startConn, td.startRows, td.endRows = startConn, startRows, endRows
+	return nil
+}
+
+func (td *snapshotDiffData) Rows() SQLRowIter {
+	if td.SQLRowIter == nil {
+		td.SQLRowIter = newSnapshotDiffIter(td.startRows, td.endRows, td.handleTps, td.colLen, td.deleted)
+	}
+	return td.SQLRowIter
+}
+
+func (td *snapshotDiffData) Close() error {
+	var err error
+	if td.SQLRowIter != nil {
+		// will close the rows internally
+		err = td.SQLRowIter.Close()
+	} else if td.startRows != nil {
+		err = multierr.Append(td.startRows.Close(), td.endRows.Close())
+	}
+	if td.startConn != nil {
+		discardConn(td.startConn)
+		td.startConn = nil
+	}
+	td.startRows, td.endRows = nil, nil
+	return err
+}
+
+func (*snapshotDiffData) RawRows() *sql.Rows {
+	return nil
+}
+
+// diffSide is the result set of one snapshot in snapshotDiffIter.
+type diffSide struct {
+	rows  *sql.Rows
+	args  []any
+	raw   []sql.RawBytes
+	row   [][]byte
+	prev  [][]byte
+	valid bool
+}
+
+func newDiffSide(rows *sql.Rows, colLen int) *diffSide {
+	s := &diffSide{
+		rows: rows,
+		args: make([]any, colLen),
+		raw:  make([]sql.RawBytes, colLen),
+		row:  make([][]byte, colLen),
+		prev: make([][]byte, colLen),
+	}
+	for i := range s.args {
+		s.args[i] = &s.raw[i]
+	}
+	return s
+}
+
+// next reads the next row and checks it's ordered by the handle as we expect.
+func (s *diffSide) next(isIntHandle []bool) error {
+	hadRow := s.valid
+	s.valid = false
+	if !s.rows.Next() {
+		return errors.Trace(s.rows.Err())
+	}
+	if err := s.rows.Scan(s.args...); err != nil {
+		return errors.Trace(err)
+	}
+	s.prev, s.row = s.row, s.prev
+	for i, raw := range s.raw {
+		if raw == nil {
+			s.row[i] = nil
+			continue
+		}
+		if s.row[i] == nil {
+			s.row[i] = make([]byte, 0, len(raw))
+		}
+		s.row[i] = append(s.row[i][:0], raw...)
+	}
+	if hadRow && compareHandles(s.prev, s.row, isIntHandle) >= 0 {
+		return errors.New("rows are not ordered by the primary key as expected, " +
+			"the primary key may use a non-binary collation, please specify --incremental-column instead")
+	}
+	s.valid = true
+	return nil
+}
+
+// snapshotDiffIter implements the SQLRowIter interface by merging the rows of the start
+// snapshot and the end snapshot, which are both ordered by the handle.
+type snapshotDiffIter struct {
+	start, end  *diffSide
+	isIntHandle []bool
+	deleted     bool
+	// cur is the row to decode, and outputFrom is the first column of it to output.
+	cur        [][]byte
+	outputFrom int
+	args       []any
+	hasNext    bool
+	err        error
+
+	advanceStart, advanceEnd bool
+}
+
+func newSnapshotDiffIter(startRows, endRows *sql.Rows, handleTps []string, colLen int, deleted bool) *snapshotDiffIter { // revive:disable-line:flag-parameter
+	iter := &snapshotDiffIter{
+		start:        newDiffSide(startRows, colLen),
+		end:          newDiffSide(endRows, colLen),
+		isIntHandle:  make([]bool, len(handleTps)),
+		deleted:      deleted,
+		advanceStart: true,
+		advanceEnd:   true,
+	}
+	for i, tp := range handleTps {
+		iter.isIntHandle[i] = strings.Contains(tp, "INT")
+	}
+	if !deleted {
+		iter.outputFrom = len(handleTps)
+	}
+	iter.args = make([]any, colLen-iter.outputFrom)
+	iter.Next()
+	return iter
+}
+
+func (iter *snapshotDiffIter) Close() error {
+	return multierr.Append(iter.start.rows.Close(), iter.end.rows.Close())
+}
+
+func (iter *snapshotDiffIter) Decode(row RowReceiver) error {
+	row.BindAddress(iter.args)
+	for i, arg := range iter.args {
+		p, ok := arg.(*sql.RawBytes)
+		if !ok {
+			return errors.Errorf("unexpected row receiver %T", arg)
+		}
+		*p = iter.cur[iter.outputFrom+i]
+	}
+	return nil
+}
+
+func (iter *snapshotDiffIter) Error() error {
+	return iter.err
+}
+
+func (iter *snapshotDiffIter) Next() {
+	iter.hasNext = false
+	for iter.err == nil {
+		if iter.advanceStart {
+			iter.advanceStart = false
+			if iter.err = iter.start.next(iter.isIntHandle); iter.err != nil {
+				return
+			}
+		}
+		if iter.advanceEnd {
+			iter.advanceEnd = false
+			if iter.err = iter.end.next(iter.isIntHandle); iter.err != nil {
+				return
+			}
+		}
+
+		var cmp int
+		switch {
+		case !iter.start.valid && !iter.end.valid:
+			return
+		case !iter.start.valid:
+			cmp = 1
+		case !iter.end.valid:
+			cmp = -1
+		default:
+			cmp = compareHandles(iter.start.row, iter.end.row, iter.isIntHandle)
+		}
+		switch {
+		case cmp < 0:
+			// the row only exists in the start snapshot
+			iter.advanceStart = true
+			if iter.deleted {
+				iter.cur, iter.hasNext = iter.start.row, true
+				return
+			}
+		case cmp > 0:
+			// the row only exists in the end snapshot
+			iter.advanceEnd = true
+			if !iter.deleted {
+				iter.cur, iter.hasNext = iter.end.row, true
+				return
+			}
+		default:
+			iter.advanceStart, iter.advanceEnd = true, true
+			if !iter.deleted && !rowsEqual(iter.start.row, iter.end.row) {
+				iter.cur, iter.hasNext = iter.end.row, true
+				return
+			}
+		}
+	}
+}
+
+func (iter *snapshotDiffIter) HasNext() bool {
+	return iter.hasNext
+}
+
+// compareHandles compares the leading handle columns of two rows, integer columns
+// are compared by value and the others are compared by bytes.
+func compareHandles(a, b [][]byte, isIntHandle []bool) int {
+	for i, isInt := range isIntHandle {
+		var cmp int
+		if isInt {
+			cmp = compareIntBytes(a[i], b[i])
+		} else {
+			cmp = bytes.Compare(a[i], b[i])
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareIntBytes compares two integers in decimal text without leading zeros.
+func compareIntBytes(a, b []byte) int {
+	negA := len(a) > 0 && a[0] == '-'
+	negB := len(b) > 0 && b[0] == '-'
+	if negA != negB {
+		if negA {
+			return -1
+		}
+		return 1
+	}
+	cmp := len(a) - len(b)
+	if cmp == 0 {
+		cmp = bytes.Compare(a, b)
+	}
+	if negA {
+		return -cmp
+	}
+	return cmp
+}
+
+func rowsEqual(a, b [][]byte) bool {
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/stretchr/testify/require"
+)
+
+func readSnapshotDiff(t *testing.T, data *snapshotDiffData, colTypes []string) ([]string, error) {
+	iter := data.Rows()
+	row := MakeRowReceiver(colTypes)
+	var (
+		res []string
+		bf  bytes.Buffer
+	)
+	for iter.HasNext() {
+		require.NoError(t, iter.Decode(row))
+		bf.Reset()
+		row.WriteToBuffer(&bf, true)
+		res = append(res, bf.String())
+		iter.Next()
+	}
+	return res, iter.Error()
+}
+
+func mockSnapshotDiff(t *testing.T, query string, cols []string, startData, endData [][]driver.Value, deleted bool) (*snapshotDiffData, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	tctx := tcontext.Background().WithLogger(appLogger)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+
+	endRows := sqlmock.NewRows(cols)
+	for _, row := range endData {
+		endRows.AddRow(row...)
+	}
+	startRows := sqlmock.NewRows(cols)
+	for _, row := range startData {
+		startRows.AddRow(row...)
+	}
+	mock.ExpectQuery(query).WillReturnRows(endRows)
+	mock.ExpectExec("SET SESSION tidb_snapshot = '100'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(query).WillReturnRows(startRows)
+
+	data := newSnapshotDiffData(db, 100, query, []string{"BIGINT", "VARCHAR"}, len(cols), deleted)
+	require.NoError(t, data.Start(tctx, conn))
+	return data, mock
+}
+
+func TestSnapshotDiffData(t *testing.T) {
+	const query = "SELECT `id`,`k`,`t`.* FROM `test`.`t` ORDER BY `id`,`k`"
+	cols := []string{"id", "k", "id", "k", "v"}
+	startData := [][]driver.Value{
+		{"-10", "a", "-10", "a", "deleted"},
+		{"-2", "a", "-2", "a", "same"},
+		{"3", "a", "3", "a", nil},
+		{"3", "b", "3", "b", "old"},
+		{"20", "a", "20", "a", "deleted"},
+		{"100", "a", "100", "a", ""},
+	}
+	endData := [][]driver.Value{
+		{"-2", "a", "-2", "a", "same"},
+		{"-1", "a", "-1", "a", "inserted"},
+		{"3", "a", "3", "a", ""},
+		{"3", "b", "3", "b", "new"},
+		{"3", "c", "3", "c", "inserted"},
+		{"100", "a", "100", "a", ""},
+		{"1000", "a", "1000", "a", "inserted"},
+	}
+
+	data, mock := mockSnapshotDiff(t, query, cols, startData, endData, false)
+	rows, err := readSnapshotDiff(t, data, []string{"BIGINT", "VARCHAR", "VARCHAR"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"(-1,'a','inserted')",
+		"(3,'a','')",
+		"(3,'b','new')",
+		"(3,'c','inserted')",
+		"(1000,'a','inserted')",
+	}, rows)
+	require.NoError(t, data.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	const handleQuery = "SELECT `id`,`k` FROM `test`.`t` ORDER BY `id`,`k`"
+	handleCols := []string{"id", "k"}
+	handles := func(rows [][]driver.Value) [][]driver.Value {
+		res := make([][]driver.Value, 0, len(rows))
+		for _, row := range rows {
+			res = append(res, row[:2])
+		}
+		return res
+	}
+	data, mock = mockSnapshotDiff(t, handleQuery, handleCols, handles(startData), handles(endData), true)
+	rows, err = readSnapshotDiff(t, data, []string{"BIGINT", "VARCHAR"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"(-10,'a')", "(20,'a')"}, rows)
+	require.NoError(t, data.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the rows are ordered by a non-binary collation
+	data, mock = mockSnapshotDiff(t, handleQuery, handleCols,
+		[][]driver.Value{{"1", "a"}, {"1", "B"}},
+		[][]driver.Value{{"1", "a"}, {"1", "b"}, {"1", "C"}}, true)
+	_, err = readSnapshotDiff(t, data, []string{"BIGINT", "VARCHAR"})
+	require.ErrorContains(t, err, "rows are not ordered by the primary key as expected")
+	require.NoError(t, data.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompareIntBytes(t *testing.T) {
+	nums := []string{"-18446744073709551615", "-100", "-99", "-1", "0", "1", "9", "10", "18446744073709551615"}
+	for i := range nums {
+		for j := range nums {
+			cmp := compareIntBytes([]byte(nums[i]), []byte(nums[j]))
+			switch {
+			case i < j:
+				require.Less(t, cmp, 0, "%s %s", nums[i], nums[j])
+			case i > j:
+				require.Greater(t, cmp, 0, "%s %s", nums[i], nums[j])
+			default:
+				require.Zero(t, cmp)
+			}
+		}
+	}
+}
+
+func TestIncrementalManifest(t *testing.T) {
+	tctx := tcontext.Background().WithLogger(appLogger)
+	dir := t.TempDir()
+	s, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	// the previous dump is a full dump, whose snapshot is read from the metadata
+	require.NoError(t, s.WriteFile(tctx, metadataPath, []byte("SHOW MASTER STATUS:\n"+
+		"\tLog: tidb-binlog\n"+
+		"\tPos: 420633273211289601\n"+
+		"\tGTID:\n\n")))
+	conf := defaultConfigForTest(t)
+	conf.IncrementalFrom = dir
+	startTS, chain, err := readPreviousDumpSnapshot(tctx, nil, conf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(420633273211289601), startTS)
+	require.Equal(t, []uint64{420633273211289601}, chain)
+
+	// the previous dump is an incremental dump, whose snapshot and chain are read from the manifest
+	require.NoError(t, writeIncrementalManifest(tctx, s, &incrementalRange{
+		from:    "s3://bucket/full",
+		startTS: 420633273211289601,
+		endTS:   420633329401856001,
+		chain:   []uint64{420633273211289601, 420633329401856001},
+	}))
+	data, err := s.ReadFile(tctx, incrementalManifestPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"from":"s3://bucket/full","start-ts":420633273211289601,"end-ts":420633329401856001,`+
+		`"chain":[420633273211289601,420633329401856001]}`, string(data))
+	startTS, chain, err = readPreviousDumpSnapshot(tctx, nil, conf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(420633329401856001), startTS)
+	require.Equal(t, []uint64{420633273211289601, 420633329401856001}, chain)
+}
@@ -28,6 +28,9 @@ type TableMeta interface {
 	ColumnCount() uint
 	ColumnTypes() []string
 	ColumnNames() []string
+	// ColumnDecimalSizes returns the precision and scale of DECIMAL columns, and the fractional seconds precision
+	// of time columns as both. The sizes are zero if they are unknown.
+	ColumnDecimalSizes() [][2]int64
 	SelectedField() string
 	SelectedLen() int
 	SpecialComments() StringIter
@@ -283,6 +283,16 @@ func (tm *tableMeta) ColumnNames() []string {
 	return colNames
 }
 
+func (tm *tableMeta) ColumnDecimalSizes() [][2]int64 {
+	sizes := make([][2]int64, len(tm.colTypes))
+	for i, ct := range tm.colTypes {
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			sizes[i] = [2]int64{precision, scale}
+		}
+	}
+	return sizes
+}
+
 func (tm *tableMeta) DatabaseName() string {
 	return tm.database
 }
@@ -239,6 +239,23 @@ func (m *globalMetadata) writeGlobalMetaData() error {
 	return err
 }
 
+// parseMetadataPos extracts the position of the first master status recorded in
+// the metadata file, which is the snapshot of the dump for TiDB.
+func parseMetadataPos(metadata string) (string, error) {
+	inMasterStatus := false
+	for _, line := range strings.Split(metadata, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			inMasterStatus = strings.HasPrefix(line, "SHOW MASTER STATUS:") ||
+				strings.HasPrefix(line, "SHOW BINARY LOG STATUS:")
+			continue
+		}
+		if pos, ok := strings.CutPrefix(line, "\tPos: "); ok && inMasterStatus {
+			return strings.TrimSpace(pos), nil
+		}
+	}
+	return "", errors.New("can't find the snapshot position in metadata")
+}
+
 func getValidStr(str []string, idx int) string {
 	if idx < len(str) {
 		return str[idx]
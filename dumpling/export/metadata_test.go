@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/tidb/br/pkg/storage"
@@ -289,6 +290,38 @@ func TestTiDBSnapshotMetaData(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestIncrementalMetaData(t *testing.T) {
+	m := newGlobalMetadata(tcontext.Background(), createStorage(t), "420633329401856001")
+	m.buffer.WriteString("SHOW MASTER STATUS:\n" +
+		"\tLog: tidb-binlog\n" +
+		"\tPos: 420633329401856001\n" +
+		"\tGTID:\n\n")
+	m.afterConnBuffer.WriteString("SHOW MASTER STATUS: /* AFTER CONNECTION POOL ESTABLISHED */\n" +
+		"\tLog: tidb-binlog\n" +
+		"\tPos: 420633329401856002\n" +
+		"\tGTID:\n\n")
+	m.recordFinishTime(time.Now())
+
+	// the position of this dump is the start of the next incremental dump.
+	pos, err := parseMetadataPos(m.String())
+	require.NoError(t, err)
+	require.Equal(t, "420633329401856001", pos)
+
+	pos, err = parseMetadataPos("Started dump at: 2026-01-02 15:04:05\n" +
+		"SHOW BINARY LOG STATUS:\n" +
+		"\tLog: tidb-binlog\n" +
+		"\tPos: 2026-01-02 15:04:05\n" +
+		"\tGTID:\n\n")
+	require.NoError(t, err)
+	require.Equal(t, "2026-01-02 15:04:05", pos)
+
+	_, err = parseMetadataPos("Started dump at: 2026-01-02 15:04:05\n" +
+		"SHOW SLAVE STATUS:\n" +
+		"\tPos: 7502\n" +
+		"Finished dump at: 2026-01-02 15:04:05\n")
+	require.ErrorContains(t, err, "can't find the snapshot position in metadata")
+}
+
 func TestNoPrivilege(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -0,0 +1,266 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+const (
+	// maxParquetInt64DecimalDigits is the max digits of the DECIMAL values which are written as INT64.
+	maxParquetInt64DecimalDigits = 18
+	parquetDateLayout            = "2006-01-02"
+	parquetDatetimeLayout        = "2006-01-02 15:04:05.999999"
+)
+
+// parquetColumnType is how the values of a column are converted before written to the parquet files.
+type parquetColumnType int
+
+const (
+	parquetString parquetColumnType = iota
+	parquetInt64
+	parquetUint64
+	parquetFloat
+	parquetDouble
+	parquetDecimalInt64
+	parquetDecimalBytes
+	parquetDate
+	parquetTimestampMillis
+	parquetTimestampMicros
+)
+
+// parquetColumn describes a column in the parquet files.
+type parquetColumn struct {
+	tp     parquetColumnType
+	schema string
+	// scale and length are the scale and the byte length of the DECIMAL columns.
+	scale  int
+	length int
+}
+
+// newParquetColumns maps the column types of the table to parquet types:
+//
+//	integer              -> INT64, UNSIGNED BIGINT is annotated as UINT_64
+//	DECIMAL(p,s)         -> DECIMAL(p,s) stored as INT64 or FIXED_LEN_BYTE_ARRAY
+//	DATE                 -> DATE
+//	DATETIME/TIMESTAMP   -> TIMESTAMP in MILLIS or MICROS by the fsp, which isn't adjusted to UTC
+//	JSON                 -> JSON
+//	ENUM                 -> ENUM
+//	binary/BLOB/BIT      -> BYTE_ARRAY
+//	others               -> UTF8 string
+//
+// The TIMESTAMP columns are dumped in the session time zone, so they are written as local time like DATETIME.
+func newParquetColumns(meta TableMeta) []parquetColumn {
+	colTypes, colNames, sizes := meta.ColumnTypes(), meta.ColumnNames(), meta.ColumnDecimalSizes()
+	cols := make([]parquetColumn, len(colTypes))
+	names := make(map[string]struct{}, len(colTypes))
+	for i, colType := range colTypes {
+		var size [2]int64
+		if i < len(sizes) {
+			size = sizes[i]
+		}
+		colName := ""
+		if i < len(colNames) {
+			colName = colNames[i]
+		}
+		name := parquetColumnName(colName, i, names)
+		cols[i] = newParquetColumn(colType, size)
+		cols[i].schema = fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", name, cols[i].schema)
+	}
+	return cols
+}
+
+func newParquetColumn(colType string, size [2]int64) parquetColumn {
+	precision, scale := size[0], size[1]
+	switch colType {
+	case "UNSIGNED BIGINT":
+		return parquetColumn{tp: parquetUint64, schema: "type=INT64, convertedtype=UINT_64"}
+	case "FLOAT":
+		return parquetColumn{tp: parquetFloat, schema: "type=FLOAT"}
+	case "DOUBLE":
+		return parquetColumn{tp: parquetDouble, schema: "type=DOUBLE"}
+	case "DECIMAL":
+		if precision <= 0 || scale < 0 || scale > precision {
+			break
+		}
+		// the precision of the unsigned DECIMAL columns reported by the driver is one less than the real one,
+		// so one more digit is reserved for the values.
+		schema := fmt.Sprintf("convertedtype=DECIMAL, precision=%d, scale=%d", precision, scale)
+		if precision+1 <= maxParquetInt64DecimalDigits {
+			return parquetColumn{tp: parquetDecimalInt64, schema: "type=INT64, " + schema, scale: int(scale)}
+		}
+		length := parquetDecimalLength(int(precision) + 1)
+		return parquetColumn{
+			tp:     parquetDecimalBytes,
+			schema: fmt.Sprintf("type=FIXED_LEN_BYTE_ARRAY, length=%d, %s", length, schema),
+			scale:  int(scale),
+			length: length,
+		}
+	case "DATE":
+		return parquetColumn{tp: parquetDate, schema: "type=INT32, convertedtype=DATE"}
+	case "DATETIME", "TIMESTAMP":
+		// the fsp is returned as the scale of the time columns.
+		if scale > 3 {
+			return parquetColumn{tp: parquetTimestampMicros,
+				schema: "type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=false, logicaltype.unit=MICROS"}
+		}
+		return parquetColumn{tp: parquetTimestampMillis,
+			schema: "type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=false, logicaltype.unit=MILLIS"}
+	case "JSON":
+		return parquetColumn{tp: parquetString, schema: "type=BYTE_ARRAY, convertedtype=JSON"}
+	case "ENUM":
+		return parquetColumn{tp: parquetString, schema: "type=BYTE_ARRAY, convertedtype=ENUM"}
+	default:
+		if _, ok := dataTypeInt[colType]; ok || colType == "YEAR" {
+			return parquetColumn{tp: parquetInt64, schema: "type=INT64"}
+		}
+		if _, ok := dataTypeBin[colType]; ok {
+			return parquetColumn{tp: parquetString, schema: "type=BYTE_ARRAY"}
+		}
+	}
+	return parquetColumn{tp: parquetString, schema: "type=BYTE_ARRAY, convertedtype=UTF8"}
+}
+
+// parquetDecimalLength returns the minimal byte length of FIXED_LEN_BYTE_ARRAY to store the decimals with the
+// given precision in two's complement.
+func parquetDecimalLength(precision int) int {
+	return int(math.Ceil((float64(precision)*math.Log2(10) + 1) / 8))
+}
+
+// parquetColumnName returns a unique parquet column name for the table column. The characters which are not
+// allowed in the schema definition of the parquet writer are replaced with '_'.
+func parquetColumnName(colName string, idx int, names map[string]struct{}) string {
+	var sb strings.Builder
+	for _, r := range colName {
+		if r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	name := sb.String()
+	if name == "" {
+		name = fmt.Sprintf("col_%d", idx)
+	}
+	// the parquet reader of lightning matches the columns case-insensitively.
+	for i := 1; ; i++ {
+		if _, ok := names[strings.ToLower(name)]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", sb.String(), i)
+	}
+	names[strings.ToLower(name)] = struct{}{}
+	return name
+}
+
+// parquetCompressionCodec maps the compress type of dumpling to the parquet compression codec. The parquet files
+// are compressed by pages, so they are not compressed again as a whole.
+func parquetCompressionCodec(compressType storage.CompressType) parquet.CompressionCodec {
+	switch compressType {
+	case storage.Gzip:
+		return parquet.CompressionCodec_GZIP
+	case storage.Snappy:
+		return parquet.CompressionCodec_SNAPPY
+	case storage.Zstd:
+		return parquet.CompressionCodec_ZSTD
+	default:
+		return parquet.CompressionCodec_UNCOMPRESSED
+	}
+}
+
+// errInvalidParquetDate is returned by toParquetValue for the zero or invalid dates which can't be represented in
+// parquet, such as '0000-00-00'.
+var errInvalidParquetDate = errors.New("zero or invalid date can't be represented in parquet")
+
+// toParquetValue converts the value of the column in text protocol to the value accepted by the parquet writer.
+// It returns a nil value for NULL, and errInvalidParquetDate for the zero or invalid dates.
+func (c *parquetColumn) toParquetValue(raw []byte) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	s := string(raw)
+	switch c.tp {
+	case parquetInt64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return v, errors.Trace(err)
+	case parquetUint64:
+		v, err := strconv.ParseUint(s, 10, 64)
+		// the UINT_64 values are stored in INT64 with the same bits.
+		return int64(v), errors.Trace(err)
+	case parquetFloat:
+		v, err := strconv.ParseFloat(s, 32)
+		return float32(v), errors.Trace(err)
+	case parquetDouble:
+		v, err := strconv.ParseFloat(s, 64)
+		return v, errors.Trace(err)
+	case parquetDecimalInt64:
+		v, err := parseUnscaledDecimal(s, c.scale)
+		if err != nil {
+			return nil, err
+		}
+		if !v.IsInt64() {
+			return nil, errors.Errorf("decimal %s overflows the parquet column", s)
+		}
+		return v.Int64(), nil
+	case parquetDecimalBytes:
+		v, err := parseUnscaledDecimal(s, c.scale)
+		if err != nil {
+			return nil, err
+		}
+		return decimalToFixedBytes(s, v, c.length)
+	case parquetDate:
+		t, err := time.Parse(parquetDateLayout, s)
+		if err != nil {
+			return nil, errors.Annotatef(errInvalidParquetDate, "date '%s'", s)
+		}
+		return int32(t.Unix() / 86400), nil
+	case parquetTimestampMillis, parquetTimestampMicros:
+		t, err := time.Parse(parquetDatetimeLayout, s)
+		if err != nil {
+			return nil, errors.Annotatef(errInvalidParquetDate, "datetime '%s'", s)
+		}
+		if c.tp == parquetTimestampMillis {
+			return t.UnixMilli(), nil
+		}
+		return t.UnixMicro(), nil
+	default:
+		return s, nil
+	}
+}
+
+// parseUnscaledDecimal parses the decimal in text as an integer multiplied by 10^scale.
+func parseUnscaledDecimal(s string, scale int) (*big.Int, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > scale {
+		fracPart = fracPart[:scale]
+	} else {
+		fracPart += strings.Repeat("0", scale-len(fracPart))
+	}
+	v, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid decimal %s", s)
+	}
+	return v, nil
+}
+
+// decimalToFixedBytes encodes the unscaled decimal in big-endian two's complement with the given length.
+func decimalToFixedBytes(s string, v *big.Int, length int) (string, error) {
+	if v.BitLen() >= length*8 {
+		return "", errors.Errorf("decimal %s overflows the parquet column", s)
+	}
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), uint(length*8)))
+	}
+	b := make([]byte, length)
+	v.FillBytes(b)
+	return string(b), nil
+}
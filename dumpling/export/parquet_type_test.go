@@ -0,0 +1,93 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParquetDecimalLength(t *testing.T) {
+	cases := map[int]int{
+		1:  1,
+		2:  1,
+		3:  2,
+		9:  4,
+		10: 5,
+		18: 8,
+		19: 9,
+		38: 16,
+		66: 28,
+	}
+	for precision, length := range cases {
+		require.Equal(t, length, parquetDecimalLength(precision), precision)
+	}
+}
+
+func TestParquetColumnName(t *testing.T) {
+	names := make(map[string]struct{})
+	require.Equal(t, "a", parquetColumnName("a", 0, names))
+	require.Equal(t, "A_1", parquetColumnName("A", 1, names))
+	require.Equal(t, "a_b", parquetColumnName("a b", 2, names))
+	require.Equal(t, "a_b_1", parquetColumnName("a,b", 3, names))
+	require.Equal(t, "col_4", parquetColumnName("", 4, names))
+	require.Equal(t, "__a", parquetColumnName("列名a", 5, names))
+}
+
+func TestParquetColumnValue(t *testing.T) {
+	decimal := newParquetColumn("DECIMAL", [2]int64{40, 3})
+	require.Equal(t, parquetDecimalBytes, decimal.tp)
+	require.Equal(t, 18, decimal.length)
+	for _, s := range []string{"0.000", "1.5", "-1.500", "-0.001", ".25", "9999999999999999999999999999999999999.999", "-9999999999999999999999999999999999999.999"} {
+		v, err := decimal.toParquetValue([]byte(s))
+		require.NoError(t, err)
+		b := []byte(v.(string))
+		require.Len(t, b, decimal.length)
+		// decode the big-endian two's complement
+		unscaled := new(big.Int).SetBytes(b)
+		if b[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		expected, err := parseUnscaledDecimal(s, 3)
+		require.NoError(t, err)
+		require.Equal(t, expected.String(), unscaled.String(), s)
+	}
+	_, err := decimal.toParquetValue([]byte("1e100"))
+	require.ErrorContains(t, err, "invalid decimal")
+
+	decimal = newParquetColumn("DECIMAL", [2]int64{10, 2})
+	require.Equal(t, parquetDecimalInt64, decimal.tp)
+	v, err := decimal.toParquetValue([]byte("-0.5"))
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), v)
+	// the unknown precision is written as string
+	require.Equal(t, parquetString, newParquetColumn("DECIMAL", [2]int64{}).tp)
+
+	uint64Col := newParquetColumn("UNSIGNED BIGINT", [2]int64{})
+	v, err = uint64Col.toParquetValue([]byte("9223372036854775808"))
+	require.NoError(t, err)
+	require.Equal(t, int64(-9223372036854775808), v)
+	intCol := newParquetColumn("INT", [2]int64{})
+	_, err = intCol.toParquetValue([]byte("abc"))
+	require.Error(t, err)
+
+	datetime := newParquetColumn("TIMESTAMP", [2]int64{3, 3})
+	require.Equal(t, parquetTimestampMillis, datetime.tp)
+	v, err = datetime.toParquetValue([]byte("2000-01-01 00:00:00.5"))
+	require.NoError(t, err)
+	require.Equal(t, int64(946684800500), v)
+	for _, zero := range []string{"0000-00-00 00:00:00", "2000-00-01 00:00:00"} {
+		_, err = datetime.toParquetValue([]byte(zero))
+		require.ErrorIs(t, err, errInvalidParquetDate)
+	}
+	date := newParquetColumn("DATE", [2]int64{})
+	_, err = date.toParquetValue([]byte("0000-00-00"))
+	require.ErrorIs(t, err, errInvalidParquetDate)
+
+	float := newParquetColumn("FLOAT", [2]int64{})
+	v, err = float.toParquetValue(nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
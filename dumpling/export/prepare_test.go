@@ -281,6 +281,10 @@ func TestConfigValidation(t *testing.T) {
 	conf.FileType = FileFormatCSVString
 	require.NoError(t, adjustFileFormat(conf))
 
+	conf.FileType = "PARQUET"
+	require.NoError(t, adjustFileFormat(conf))
+	require.Equal(t, FileFormatParquetString, conf.FileType)
+
 	conf.FileType = ""
 	require.NoError(t, adjustFileFormat(conf))
 	require.Equal(t, FileFormatCSVString, conf.FileType)
@@ -297,6 +301,24 @@ func TestConfigValidation(t *testing.T) {
 	require.EqualError(t, adjustFileFormat(conf), "unknown config.FileType 'rand_str'")
 }
 
+func TestValidateIncremental(t *testing.T) {
+	conf := defaultConfigForTest(t)
+	require.NoError(t, validateIncremental(conf))
+
+	conf.IncrementalColumn = "updated_at"
+	require.EqualError(t, validateIncremental(conf), "--incremental-column requires --incremental-from")
+
+	conf.IncrementalFrom = "420633273211289601"
+	require.NoError(t, validateIncremental(conf))
+
+	conf.SQL = "select * from t"
+	require.EqualError(t, validateIncremental(conf), "can't specify both --sql and --incremental-from at the same time")
+
+	conf.SQL = ""
+	conf.NoData = true
+	require.EqualError(t, validateIncremental(conf), "can't specify both --no-data and --incremental-from at the same time")
+}
+
 func TestValidateResolveAutoConsistency(t *testing.T) {
 	conf1 := defaultConfigForTest(t)
 	d := &Dumper{conf: conf1}
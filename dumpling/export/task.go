@@ -60,6 +60,15 @@ type TaskTableData struct {
 	TotalChunks int
 }
 
+// TaskTableDeletes is a dumping task of the rows deleted from a table since the previous dump
+type TaskTableDeletes struct {
+	Task
+	Meta          TableMeta
+	Data          TableDataIR
+	HandleColumns []string
+	HandleTypes   []string
+}
+
 // NewTaskDatabaseMeta returns a new dumping database metadata task
 func NewTaskDatabaseMeta(dbName, createSQL string) *TaskDatabaseMeta {
 	return &TaskDatabaseMeta{
@@ -114,6 +123,16 @@ func NewTaskTableData(meta TableMeta, data TableDataIR, currentChunk, totalChunk
 	}
 }
 
+// NewTaskTableDeletes returns a new dumping table deletes task
+func NewTaskTableDeletes(meta TableMeta, data TableDataIR, handleCols, handleTps []string) *TaskTableDeletes {
+	return &TaskTableDeletes{
+		Meta:          meta,
+		Data:          data,
+		HandleColumns: handleCols,
+		HandleTypes:   handleTps,
+	}
+}
+
 // Brief implements task.Brief
 func (t *TaskDatabaseMeta) Brief() string {
 	return fmt.Sprintf("meta of dababase '%s'", t.DatabaseName)
@@ -145,3 +164,8 @@ func (t *TaskTableData) Brief() string {
 	idx, total := t.ChunkIndex, t.TotalChunks
 	return fmt.Sprintf("data of table '%s'.'%s'(%d/%d)", db, tbl, idx, total)
 }
+
+// Brief implements task.Brief
+func (t *TaskTableDeletes) Brief() string {
+	return fmt.Sprintf("deletes of table '%s'.'%s'", t.Meta.DatabaseName(), t.Meta.TableName())
+}
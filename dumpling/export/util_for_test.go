@@ -66,6 +66,7 @@ type mockTableIR struct {
 	specCmt          []string
 	colTypes         []string
 	colNames         []string
+	colDecimalSizes  [][2]int64
 	escapeBackSlash  bool
 	hasImplicitRowID bool
 	rowErr           error
@@ -121,6 +122,10 @@ func (m *mockTableIR) ColumnNames() []string {
 	return m.colNames
 }
 
+func (m *mockTableIR) ColumnDecimalSizes() [][2]int64 {
+	return m.colDecimalSizes
+}
+
 func (m *mockTableIR) SelectedField() string {
 	return m.selectedField
 }
@@ -58,6 +58,8 @@ func NewWriter(
 		sw.fileFmt = FileFormatSQLText
 	case FileFormatCSVString:
 		sw.fileFmt = FileFormatCSV
+	case FileFormatParquetString:
+		sw.fileFmt = FileFormatParquet
 	}
 	return sw
 }
@@ -120,6 +122,8 @@ func (w *Writer) handleTask(task Task) error {
 			w.finishTableCallBack(task)
 		}
 		return nil
+	case *TaskTableDeletes:
+		return w.WriteTableDeletes(t.Meta, t.Data, t.HandleColumns, t.HandleTypes)
 	default:
 		w.tctx.L().Warn("unsupported writer task type", zap.String("type", fmt.Sprintf("%T", t)))
 		return nil
@@ -229,6 +233,51 @@ func (w *Writer) WriteTableData(meta TableMeta, ir TableDataIR, currentChunk int
 	}, newRebuildConnBackOffer(canRebuildConn(conf.Consistency, conf.TransactionalConsistency)))
 }
 
+// WriteTableDeletes writes the deleted rows of a table to a file with retry
+func (w *Writer) WriteTableDeletes(meta TableMeta, ir TableDataIR, handleCols, handleTps []string) error {
+	tctx, conf, conn := w.tctx, w.conf, w.conn
+	fileName, err := (&outputFileNamer{DB: meta.DatabaseName(), Table: meta.TableName()}).render(conf.OutputFileTemplate, outputFileTemplateDeletes)
+	if err != nil {
+		return err
+	}
+	fileName += ".sql"
+	retryTime := 0
+	return utils.WithRetry(tctx, func() (err error) {
+		defer func() {
+			if err != nil {
+				IncCounter(w.metrics.errorCount)
+			}
+		}()
+		retryTime++
+		if retryTime > 1 {
+			conn, err = w.rebuildConnFn(conn, true)
+			w.conn = conn
+			if err != nil {
+				return
+			}
+		}
+		err = ir.Start(tctx, conn)
+		if err != nil {
+			tctx.L().Warn("failed to start table deletes", zap.Error(err))
+			return
+		}
+		defer func() {
+			_ = ir.Close()
+		}()
+		fileWriter, tearDown := buildInterceptFileWriter(tctx, w.extStorage, fileName, conf.CompressType)
+		n, err := WriteDeletes(tctx, conf, meta, ir, handleCols, handleTps, fileWriter)
+		tearDownErr := tearDown(tctx)
+		if err != nil {
+			return err
+		}
+		tctx.L().Debug("finish dumping table deletes",
+			zap.String("database", meta.DatabaseName()),
+			zap.String("table", meta.TableName()),
+			zap.Uint64("total rows", n))
+		return tearDownErr
+	}, newRebuildConnBackOffer(canRebuildConn(conf.Consistency, conf.TransactionalConsistency)))
+}
+
 func (w *Writer) tryToWriteTableData(tctx *tcontext.Context, meta TableMeta, ir TableDataIR, curChkIdx int) error {
 	conf, format := w.conf, w.fileFmt
 	namer := newOutputFileNamer(meta, curChkIdx, conf.Rows != UnspecifiedSize, conf.FileSize != UnspecifiedSize)
@@ -237,9 +286,14 @@ func (w *Writer) tryToWriteTableData(tctx *tcontext.Context, meta TableMeta, ir
 		return err
 	}
 
+	compressType := conf.CompressType
+	if format == FileFormatParquet {
+		// the parquet files are compressed by pages with the codec mapped from --compress.
+		compressType = storage.NoCompression
+	}
 	somethingIsWritten := false
 	for {
-		fileWriter, tearDown := buildInterceptFileWriter(tctx, w.extStorage, fileName, conf.CompressType)
+		fileWriter, tearDown := buildInterceptFileWriter(tctx, w.extStorage, fileName, compressType)
 		n, err := format.WriteInsert(tctx, conf, meta, ir, fileWriter, w.metrics)
 		tearDownErr := tearDown(tctx)
 		if err != nil {
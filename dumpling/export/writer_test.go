@@ -12,10 +12,14 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/br/pkg/version"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/pkg/util/promutil"
 	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func TestWriteDatabaseMeta(t *testing.T) {
@@ -153,6 +157,60 @@ func TestWriteTableData(t *testing.T) {
 	require.ErrorContains(t, err, "injected error: fail to close data file")
 }
 
+func TestWriteTableDeletes(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+
+	writer := createTestWriter(config, t)
+
+	data := [][]driver.Value{
+		{"1", "a"},
+		{"3", "b'c"},
+		{"5", "d"},
+	}
+	colTypes := []string{"INT", "VARCHAR"}
+	specCmts := []string{
+		"/*!40101 SET NAMES binary*/;",
+	}
+	tableIR := newMockTableIR("test", "employee", data, specCmts, colTypes)
+	err := writer.WriteTableDeletes(tableIR, tableIR, []string{"id", "name"}, colTypes)
+	require.NoError(t, err)
+
+	p := path.Join(dir, "test.employee-deletes.sql")
+	bytes, err := os.ReadFile(p)
+	require.NoError(t, err)
+	expected := "/*!40101 SET NAMES binary*/;\n" +
+		"DELETE FROM `employee` WHERE (`id`,`name`) IN (\n" +
+		"(1,'a'),\n" +
+		"(3,'b\\'c'),\n" +
+		"(5,'d'));\n"
+	require.Equal(t, expected, string(bytes))
+
+	// split into several statements by the statement size
+	config.StatementSize = 20
+	tableIR = newMockTableIR("test", "employee", data, specCmts, colTypes)
+	err = writer.WriteTableDeletes(tableIR, tableIR, []string{"id", "name"}, colTypes)
+	require.NoError(t, err)
+	bytes, err = os.ReadFile(p)
+	require.NoError(t, err)
+	expected = "/*!40101 SET NAMES binary*/;\n" +
+		"DELETE FROM `employee` WHERE (`id`,`name`) IN (\n" +
+		"(1,'a'));\n" +
+		"DELETE FROM `employee` WHERE (`id`,`name`) IN (\n" +
+		"(3,'b\\'c'));\n" +
+		"DELETE FROM `employee` WHERE (`id`,`name`) IN (\n" +
+		"(5,'d'));\n"
+	require.Equal(t, expected, string(bytes))
+
+	// no file is written if nothing is deleted
+	tableIR = newMockTableIR("test", "empty", nil, specCmts, colTypes)
+	err = writer.WriteTableDeletes(tableIR, tableIR, []string{"id", "name"}, colTypes)
+	require.NoError(t, err)
+	_, err = os.Stat(path.Join(dir, "test.empty-deletes.sql"))
+	require.True(t, os.IsNotExist(err))
+}
+
 func TestWriteTableDataWithFileSize(t *testing.T) {
 	dir := t.TempDir()
 	config := defaultConfigForTest(t)
@@ -252,6 +310,125 @@ func TestWriteTableDataWithFileSizeAndRows(t *testing.T) {
 	}
 }
 
+func TestWriteTableDataInParquet(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+	config.CompressType = storage.Zstd
+	config.FileSize = 60
+	config.ParquetInvalidDateAsNull = true
+
+	writer := createTestWriter(config, t)
+	require.Equal(t, FileFormatParquet, writer.fileFmt)
+
+	data := [][]driver.Value{
+		{"1", "18446744073709551615", "-12.50", "-123456789012345678901.12345", "2024-02-29 12:34:56.123456", "2024-02-29", `{"a": 1}`, "x", "\x00\x01", "bob"},
+		{"2", "0", "0.00", "0.00000", "1970-01-01 00:00:00", "0000-00-00", nil, nil, nil, nil},
+		{"3", "42", "3.14", "1.00001", "1969-12-31 23:59:59.999999", "1969-12-31", "[]", "y", "", "alice"},
+	}
+	colTypes := []string{"INT", "UNSIGNED BIGINT", "DECIMAL", "DECIMAL", "DATETIME", "DATE", "JSON", "ENUM", "BINARY", "VARCHAR"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "u", "d1", "d2", "dt", "day", "j", "e", "b", "name col"}
+	tableIR.colDecimalSizes = [][2]int64{{}, {}, {5, 2}, {30, 5}, {6, 6}, {}, {}, {}, {}, {}}
+	require.NoError(t, writer.WriteTableData(tableIR, tableIR, 0))
+
+	// each file is ended after the estimated size reaches the file size limit
+	files := []string{"test.employee.000000000.parquet", "test.employee.000000001.parquet"}
+	columns := make([][]any, len(colTypes))
+	for _, name := range files {
+		pf, err := local.NewLocalFileReader(path.Join(dir, name))
+		require.NoError(t, err)
+		pr, err := reader.NewParquetColumnReader(pf, 1)
+		require.NoError(t, err)
+		schema := pr.Footer.Schema[1:]
+		require.Len(t, schema, len(colTypes))
+		require.Equal(t, "name_col", pr.SchemaHandler.Infos[10].ExName)
+		require.Equal(t, parquet.ConvertedType_UINT_64, schema[1].GetConvertedType())
+		require.Equal(t, parquet.Type_INT64, schema[2].GetType())
+		require.Equal(t, int32(2), schema[2].GetScale())
+		require.Equal(t, parquet.Type_FIXED_LEN_BYTE_ARRAY, schema[3].GetType())
+		require.Equal(t, int32(30), schema[3].GetPrecision())
+		require.Equal(t, int32(5), schema[3].GetScale())
+		require.Equal(t, parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()}, *schema[4].GetLogicalType().GetTIMESTAMP().GetUnit())
+		require.False(t, schema[4].GetLogicalType().GetTIMESTAMP().GetIsAdjustedToUTC())
+		require.Equal(t, parquet.ConvertedType_DATE, schema[5].GetConvertedType())
+		require.Equal(t, parquet.ConvertedType_JSON, schema[6].GetConvertedType())
+		require.Equal(t, parquet.ConvertedType_ENUM, schema[7].GetConvertedType())
+		require.Nil(t, schema[8].ConvertedType)
+		require.Equal(t, parquet.ConvertedType_UTF8, schema[9].GetConvertedType())
+		require.Equal(t, parquet.CompressionCodec_ZSTD, pr.Footer.RowGroups[0].Columns[0].MetaData.Codec)
+
+		num := pr.GetNumRows()
+		for i := range columns {
+			values, _, _, err := pr.ReadColumnByIndex(int64(i), num)
+			require.NoError(t, err)
+			columns[i] = append(columns[i], values...)
+		}
+		pr.ReadStop()
+		require.NoError(t, pf.Close())
+	}
+	fixedBytes := func(s string) string {
+		v, err := parseUnscaledDecimal(s, 5)
+		require.NoError(t, err)
+		b, err := decimalToFixedBytes(s, v, 13)
+		require.NoError(t, err)
+		return b
+	}
+	require.Equal(t, [][]any{
+		{int64(1), int64(2), int64(3)},
+		{int64(-1), int64(0), int64(42)},
+		{int64(-1250), int64(0), int64(314)},
+		{fixedBytes("-123456789012345678901.12345"), fixedBytes("0"), fixedBytes("1.00001")},
+		{int64(1709210096123456), int64(0), int64(-1)},
+		{int32(19782), nil, int32(-1)},
+		{`{"a": 1}`, nil, "[]"},
+		{"x", nil, "y"},
+		{"\x00\x01", nil, ""},
+		{"bob", nil, "alice"},
+	}, columns)
+}
+
+func TestWriteTableDataInParquetWithZeroDate(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+
+	data := [][]driver.Value{
+		{"1", "2024-02-29"},
+		{"2", "0000-00-00"},
+	}
+	colTypes := []string{"INT", "DATE"}
+	newTableIR := func() *mockTableIR {
+		tableIR := newMockTableIR("test", "zero_date", data, nil, colTypes)
+		tableIR.colNames = []string{"id", "day"}
+		return tableIR
+	}
+
+	// the zero date fails the dump by default
+	writer := createTestWriter(config, t)
+	tableIR := newTableIR()
+	err := writer.WriteTableData(tableIR, tableIR, 0)
+	require.ErrorIs(t, err, errInvalidParquetDate)
+	require.ErrorContains(t, err, flagParquetInvalidDateAsNull)
+
+	config.ParquetInvalidDateAsNull = true
+	writer = createTestWriter(config, t)
+	tableIR = newTableIR()
+	require.NoError(t, writer.WriteTableData(tableIR, tableIR, 0))
+
+	pf, err := local.NewLocalFileReader(path.Join(dir, "test.zero_date.000000000.parquet"))
+	require.NoError(t, err)
+	defer pf.Close()
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	values, _, _, err := pr.ReadColumnByIndex(1, pr.GetNumRows())
+	require.NoError(t, err)
+	require.Equal(t, []any{int32(19782), nil}, values)
+}
+
 func TestWriteTableDataWithStatementSize(t *testing.T) {
 	dir := t.TempDir()
 	config := defaultConfigForTest(t)
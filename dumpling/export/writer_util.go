@@ -5,6 +5,7 @@ package export
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/dumpling/log"
 	"github.com/prometheus/client_golang/prometheus"
+	pqtwriter "github.com/xitongsys/parquet-go/writer"
 	"go.uber.org/zap"
 )
 
@@ -417,6 +419,237 @@ func WriteInsertInCsv(
 	return counter, wp.Error()
 }
 
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet format. Each call writes a
+// complete parquet file, which is ended when the estimated size of the written rows reaches the file size limit.
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+	if meta.SelectedField() == "" || meta.ColumnCount() == 0 {
+		return 0, errors.Errorf("can't dump table %s.%s without columns in parquet format",
+			meta.DatabaseName(), meta.TableName())
+	}
+
+	cols := newParquetColumns(meta)
+	schema := make([]string, len(cols))
+	for i := range cols {
+		schema[i] = cols[i].schema
+	}
+	fw := &parquetFileWriter{tctx: pCtx, w: w}
+	pw, err := pqtwriter.NewCSVWriterFromWriter(schema, fw, 1)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	pw.CompressionType = parquetCompressionCodec(cfg.CompressType)
+	pw.RowGroupSize = int64(cfg.ParquetRowGroupSize)
+	if cfg.FileSize != UnspecifiedSize {
+		pw.RowGroupSize = min(pw.RowGroupSize, int64(cfg.FileSize))
+	}
+
+	var (
+		row         = &parquetRowReceiver{raws: make([]sql.RawBytes, len(cols))}
+		counter     uint64
+		lastCounter uint64
+		lastWritten uint64
+		fileSize    uint64
+		// invalidDates is the number of zero or invalid dates written as NULL.
+		invalidDates uint64
+	)
+
+	defer func() {
+		if invalidDates > 0 {
+			pCtx.L().Warn("zero or invalid dates are written as NULL in parquet file",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("count", invalidDates))
+		}
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", lastWritten),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(lastWritten))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", fw.written))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, fw.written)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+	// updateMetrics updates the metrics after some data is flushed to the file.
+	updateMetrics := func() {
+		AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+		AddGauge(metrics.finishedSizeGauge, float64(fw.written-lastWritten))
+		lastCounter, lastWritten = counter, fw.written
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		// the parquet writer keeps the records until the row group is flushed, so they can't be reused.
+		rec := make([]any, len(cols))
+		for i := range cols {
+			if rec[i], err = cols[i].toParquetValue(row.raws[i]); err != nil {
+				if errors.Cause(err) == errInvalidParquetDate && cfg.ParquetInvalidDateAsNull {
+					rec[i] = nil
+					invalidDates++
+				} else {
+					if errors.Cause(err) == errInvalidParquetDate {
+						err = errors.Annotatef(err, "use --%s to write them as NULL", flagParquetInvalidDateAsNull)
+					}
+					return counter, errors.Annotatef(err, "column %d of %s.%s", i, meta.DatabaseName(), meta.TableName())
+				}
+			}
+			fileSize += uint64(len(row.raws[i]))
+		}
+		if err = pw.Write(rec); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		if fw.written > lastWritten {
+			if err = pCtx.Err(); err != nil {
+				return counter, err
+			}
+			updateMetrics()
+		}
+
+		fileRowIter.Next()
+		if cfg.FileSize != UnspecifiedSize && fileSize >= cfg.FileSize {
+			break
+		}
+	}
+
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	updateMetrics()
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, nil
+}
+
+// parquetRowReceiver receives the values of a row in text protocol.
+type parquetRowReceiver struct {
+	bound bool
+	raws  []sql.RawBytes
+}
+
+// BindAddress implements RowReceiver.BindAddress
+func (r *parquetRowReceiver) BindAddress(args []any) {
+	if r.bound {
+		return
+	}
+	r.bound = true
+	for i := range args {
+		args[i] = &r.raws[i]
+	}
+}
+
+// parquetFileWriter adapts storage.ExternalFileWriter to io.Writer for the parquet writer.
+type parquetFileWriter struct {
+	tctx    *tcontext.Context
+	w       storage.ExternalFileWriter
+	written uint64
+}
+
+// Write implements io.Writer.
+func (p *parquetFileWriter) Write(b []byte) (int, error) {
+	if err := writeBytes(p.tctx, p.w, b); err != nil {
+		return 0, err
+	}
+	p.written += uint64(len(b))
+	return len(b), nil
+}
+
+// WriteDeletes writes the handles of the deleted rows in TableDataIR to a storage.ExternalFileWriter
+// as DELETE statements, which should be executed before importing the changed rows.
+func WriteDeletes(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	handleCols, handleTps []string,
+	w storage.ExternalFileWriter,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+
+	bf := pool.Get().(*bytes.Buffer)
+	defer func() {
+		bf.Reset()
+		pool.Put(bf)
+	}()
+
+	specCmtIter := meta.SpecialComments()
+	for specCmtIter.HasNext() {
+		bf.WriteString(specCmtIter.Next())
+		bf.WriteByte('\n')
+	}
+
+	quotedCols := make([]string, 0, len(handleCols))
+	for _, col := range handleCols {
+		quotedCols = append(quotedCols, wrapBackTicks(escapeString(col)))
+	}
+	deleteStatementPrefix := fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (\n",
+		wrapBackTicks(escapeString(meta.TableName())), strings.Join(quotedCols, ","))
+	row := MakeRowReceiver(handleTps)
+
+	for fileRowIter.HasNext() {
+		bf.WriteString(deleteStatementPrefix)
+		statementSize := uint64(len(deleteStatementPrefix))
+		for fileRowIter.HasNext() {
+			lastBfSize := bf.Len()
+			if err = fileRowIter.Decode(row); err != nil {
+				return n, errors.Trace(err)
+			}
+			row.WriteToBuffer(bf, cfg.EscapeBackslash)
+			n++
+			statementSize += uint64(bf.Len()-lastBfSize) + 2
+			fileRowIter.Next()
+			shouldSwitch := cfg.StatementSize != UnspecifiedSize && statementSize >= cfg.StatementSize
+			if fileRowIter.HasNext() && !shouldSwitch {
+				bf.WriteString(",\n")
+			} else {
+				bf.WriteString(");\n")
+			}
+			if bf.Len() >= lengthLimit {
+				if err = writeBytes(pCtx, w, bf.Bytes()); err != nil {
+					return n, err
+				}
+				bf.Reset()
+			}
+			if shouldSwitch {
+				break
+			}
+		}
+	}
+	if err = fileRowIter.Error(); err != nil {
+		return n, errors.Trace(err)
+	}
+	if bf.Len() > 0 {
+		err = writeBytes(pCtx, w, bf.Bytes())
+	}
+	return n, err
+}
+
 func write(tctx *tcontext.Context, writer storage.ExternalFileWriter, str string) error {
 	_, err := writer.Write(tctx, []byte(str))
 	if err != nil {
@@ -607,7 +840,7 @@ func compressFileSuffix(compressType storage.CompressType) string {
 	}
 }
 
-// FileFormat is the format that output to file. Currently we support SQL text and CSV file format.
+// FileFormat is the format that output to file. Currently we support SQL text, CSV and Parquet file format.
 type FileFormat int32
 
 const (
@@ -617,6 +850,8 @@ const (
 	FileFormatSQLText
 	// FileFormatCSV indicates the given file type is csv type
 	FileFormatCSV
+	// FileFormatParquet indicates the given file type is parquet type
+	FileFormatParquet
 )
 
 const (
@@ -624,6 +859,8 @@ const (
 	FileFormatSQLTextString = "sql"
 	// FileFormatCSVString indicates the string/suffix of csv type file
 	FileFormatCSVString = "csv"
+	// FileFormatParquetString indicates the string/suffix of parquet type file
+	FileFormatParquetString = "parquet"
 )
 
 // String implement Stringer.String method.
@@ -633,6 +870,8 @@ func (f FileFormat) String() string {
 		return strings.ToUpper(FileFormatSQLTextString)
 	case FileFormatCSV:
 		return strings.ToUpper(FileFormatCSVString)
+	case FileFormatParquet:
+		return strings.ToUpper(FileFormatParquetString)
 	default:
 		return "unknown"
 	}
@@ -640,20 +879,23 @@ func (f FileFormat) String() string {
 
 // Extension returns the extension for specific format.
 //
-//	text -> "sql"
-//	csv  -> "csv"
+//	text    -> "sql"
+//	csv     -> "csv"
+//	parquet -> "parquet"
 func (f FileFormat) Extension() string {
 	switch f {
 	case FileFormatSQLText:
 		return FileFormatSQLTextString
 	case FileFormatCSV:
 		return FileFormatCSVString
+	case FileFormatParquet:
+		return FileFormatParquetString
 	default:
 		return "unknown_format"
 	}
 }
 
-// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv type
+// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv/parquet type
 func (f FileFormat) WriteInsert(
 	pCtx *tcontext.Context,
 	cfg *Config,
@@ -667,6 +909,8 @@ func (f FileFormat) WriteInsert(
 		return WriteInsert(pCtx, cfg, meta, tblIR, w, metrics)
 	case FileFormatCSV:
 		return WriteInsertInCsv(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatParquet:
+		return WriteInsertInParquet(pCtx, cfg, meta, tblIR, w, metrics)
 	default:
 		return 0, errors.Errorf("unknown file format")
 	}
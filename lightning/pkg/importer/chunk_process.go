@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -62,9 +64,10 @@ func newChunkProcessor(
 	chunk *checkpoints.ChunkCheckpoint,
 	ioWorkers *worker.Pool,
 	store storage.ExternalStorage,
+	dbName string,
 	tableInfo *model.TableInfo,
 ) (*chunkProcessor, error) {
-	parser, err := openParser(ctx, cfg, chunk, ioWorkers, store, tableInfo)
+	parser, err := openParser(ctx, cfg, chunk, ioWorkers, store, dbName, tableInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +84,7 @@ func openParser(
 	chunk *checkpoints.ChunkCheckpoint,
 	ioWorkers *worker.Pool,
 	store storage.ExternalStorage,
+	dbName string,
 	tblInfo *model.TableInfo,
 ) (mydump.Parser, error) {
 	blockBufSize := int64(cfg.Mydumper.ReadBlockSize)
@@ -111,6 +115,20 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeJSONL:
+		jsonlConfig, err := newJSONLConfig(cfg, dbName, tblInfo)
+		if err != nil {
+			return nil, err
+		}
+		parser, err = mydump.NewJSONLParser(ctx, jsonlConfig, reader, blockBufSize, ioWorkers)
+		if err != nil {
+			return nil, err
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
@@ -134,6 +152,36 @@ func openParser(
 	return parser, nil
 }
 
+// newJSONLConfig builds the JSON Lines config from the columns of the target table like IMPORT INTO does, so the
+// keys which don't appear in the first row aren't dropped. The columns are read from the first row if the table is
+// unknown.
+func newJSONLConfig(cfg *config.Config, dbName string, tblInfo *model.TableInfo) (*mydump.JSONLConfig, error) {
+	if tblInfo == nil {
+		return nil, nil
+	}
+	jsonlPaths, err := cfg.Mydumper.JSONLPaths.GetJSONLPaths(dbName, tblInfo.Name.O, cfg.Mydumper.CaseSensitive)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	columns := make([]string, 0, len(tblInfo.Columns))
+	for _, col := range tblInfo.Columns {
+		if col.Hidden || col.IsGenerated() {
+			continue
+		}
+		columns = append(columns, col.Name.O)
+	}
+	for name := range jsonlPaths.Paths {
+		if !slices.ContainsFunc(columns, func(col string) bool { return strings.EqualFold(col, name) }) {
+			return nil, common.ErrInvalidConfig.GenWithStack(
+				"'%s' in `mydumper.jsonl-paths` is not a column of table `%s`.`%s`", name, dbName, tblInfo.Name.O)
+		}
+	}
+	return &mydump.JSONLConfig{
+		Columns: columns,
+		Paths:   jsonlPaths.Paths,
+	}, nil
+}
+
 func getColumnNames(tableInfo *model.TableInfo, permutation []int) []string {
 	colIndexes := make([]int, 0, len(permutation))
 	for i := 0; i < len(permutation); i++ {
@@ -86,7 +86,7 @@ func (s *chunkRestoreSuite) SetupTest() {
 	}
 
 	var err error
-	s.cr, err = newChunkProcessor(context.Background(), 1, s.cfg, &chunk, w, s.store, nil)
+	s.cr, err = newChunkProcessor(context.Background(), 1, s.cfg, &chunk, w, s.store, "", nil)
 	require.NoError(s.T(), err)
 }
 
@@ -768,7 +768,7 @@ func TestCompressChunkRestore(t *testing.T) {
 	cfg.App.TableConcurrency = 2
 	cfg.Mydumper.CSV.Header = false
 
-	cr, err := newChunkProcessor(ctx, 1, cfg, &chunk, w, store, nil)
+	cr, err := newChunkProcessor(ctx, 1, cfg, &chunk, w, store, "", nil)
 	require.NoError(t, err)
 	var (
 		id, lastID int
@@ -800,7 +800,7 @@ func TestCompressChunkRestore(t *testing.T) {
 			RowIDMax:     100,
 		},
 	}
-	cr, err = newChunkProcessor(ctx, 1, cfg, &chunk, w, store, nil)
+	cr, err = newChunkProcessor(ctx, 1, cfg, &chunk, w, store, "", nil)
 	require.NoError(t, err)
 	for id = lastID; id < 300; {
 		err = cr.parser.ReadRow()
@@ -846,3 +846,46 @@ func TestGetColumnsNames(t *testing.T) {
 	require.Equal(t, []string{"c", "_tidb_rowid", "a"}, getColumnNames(tableInfo, []int{2, -1, 0, 1}))
 	require.Equal(t, []string{"_tidb_rowid", "b"}, getColumnNames(tableInfo, []int{-1, 1, -1, 0}))
 }
+
+func TestOpenJSONLParser(t *testing.T) {
+	p := parser.New()
+	node, err := p.ParseOneStmt(`CREATE TABLE t (id INT, name VARCHAR(20), tag VARCHAR(20), v INT AS (id + 1))`, "", "")
+	require.NoError(t, err)
+	core, err := ddl.BuildTableInfoFromAST(metabuild.NewContext(), node.(*ast.CreateTableStmt))
+	require.NoError(t, err)
+	core.State = model.StatePublic
+
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	// the key "tag" only appears in the second row.
+	content := "{\"id\": 1, \"user\": {\"name\": \"alice\"}}\n{\"id\": 2, \"user\": {\"name\": \"bob\"}, \"tag\": \"x\"}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db.t.1.jsonl"), []byte(content), 0o644))
+	fileMeta := mydump.SourceFileMeta{Path: "db.t.1.jsonl", Type: mydump.SourceTypeJSONL, FileSize: int64(len(content))}
+	chunk := checkpoints.ChunkCheckpoint{
+		Key:      checkpoints.ChunkCheckpointKey{Path: fileMeta.Path},
+		FileMeta: fileMeta,
+		Chunk:    mydump.Chunk{EndOffset: fileMeta.FileSize, RowIDMax: 2},
+	}
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.Mydumper.JSONLPaths = config.AllJSONLPaths{{DB: "db", Table: "t", Paths: map[string]string{"name": "$.user.name"}}}
+
+	jsonlParser, err := openParser(ctx, cfg, &chunk, nil, store, "db", core)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "name", "tag"}, jsonlParser.Columns())
+	require.NoError(t, jsonlParser.ReadRow())
+	row := jsonlParser.LastRow().Row
+	require.Equal(t, "alice", row[1].GetString())
+	require.True(t, row[2].IsNull())
+	require.NoError(t, jsonlParser.ReadRow())
+	row = jsonlParser.LastRow().Row
+	require.Equal(t, "bob", row[1].GetString())
+	require.Equal(t, "x", row[2].GetString())
+	require.NoError(t, jsonlParser.Close())
+
+	// the path must be for a column of the table.
+	cfg.Mydumper.JSONLPaths[0].Paths = map[string]string{"unknown": "$.user.name"}
+	_, err = openParser(ctx, cfg, &chunk, nil, store, "db", core)
+	require.ErrorContains(t, err, "'unknown' in `mydumper.jsonl-paths` is not a column of table `db`.`t`")
+}
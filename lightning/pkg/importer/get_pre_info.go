@@ -493,6 +493,20 @@ func (p *PreImportInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONL:
+		jsonlConfig, err := p.jsonlConfigOfFile(ctx, dataFileMeta)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		parser, err = mydump.NewJSONLParser(ctx, jsonlConfig, reader, blockBufSize, p.ioWorkers)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -514,6 +528,34 @@ func (p *PreImportInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 	return parser.Columns(), rows, nil
 }
 
+// jsonlConfigOfFile builds the JSON Lines config from the target table of the data file. It returns nil if the
+// target table doesn't exist, then the columns are read from the first row.
+func (p *PreImportInfoGetterImpl) jsonlConfigOfFile(ctx context.Context, dataFileMeta mydump.SourceFileMeta) (*mydump.JSONLConfig, error) {
+	for dbName, mdTableMetaMap := range p.mdDBTableMetaMap {
+		for _, mdTableMeta := range mdTableMetaMap {
+			for _, dataFile := range mdTableMeta.DataFiles {
+				if dataFile.FileMeta.Path != dataFileMeta.Path {
+					continue
+				}
+				dbInfos, err := p.GetAllTableStructures(ctx)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				dbInfo, ok := dbInfos[dbName]
+				if !ok {
+					return nil, nil
+				}
+				tableInfo, ok := dbInfo.Tables[mdTableMeta.Name]
+				if !ok {
+					return nil, nil
+				}
+				return newJSONLConfig(p.cfg, dbName, tableInfo.Core)
+			}
+		}
+	}
+	return nil, nil
+}
+
 // EstimateSourceDataSize estimates the datasize to generate during the import as well as some other sub-informaiton.
 // It implements the PreImportInfoGetter interface.
 // It has a cache mechanism.  The estimated size will only calculated once.
@@ -663,6 +705,20 @@ func (p *PreImportInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONL:
+		jsonlConfig, err := newJSONLConfig(p.cfg, dbName, tableInfo)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
+		parser, err = mydump.NewJSONLParser(ctx, jsonlConfig, reader, blockBufSize, p.ioWorkers)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -334,6 +334,9 @@ func NewImportControllerWithPauser(
 	if err := verifyCheckpoint(cfg, taskCp); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkIncrementalDump(ctx, cfg, p); err != nil {
+		return nil, errors.Trace(err)
+	}
 	// reuse task id to reuse task meta correctly.
 	if taskCp != nil {
 		cfg.TaskID = taskCp.TaskID
@@ -1810,6 +1813,30 @@ func (rc *Controller) cleanCheckpoints(ctx context.Context) error {
 	return nil
 }
 
+// checkIncrementalDump checks the config for the incremental dump of Dumpling, which contains the rows changed since
+// the previous dump and the deletes files of the rows deleted since then. The deletes are applied by SQL and the changed
+// rows should replace the existing ones, so only the TiDB backend with conflict.strategy = "replace" is supported.
+func checkIncrementalDump(ctx context.Context, cfg *config.Config, p *ControllerParam) error {
+	incremental := false
+	if p.DumpFileStorage != nil {
+		exists, err := p.DumpFileStorage.FileExists(ctx, mydump.IncrementalManifestFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		incremental = exists
+	}
+	for _, dbMeta := range p.DBMetas {
+		for _, tblMeta := range dbMeta.Tables {
+			incremental = incremental || len(tblMeta.DeleteFiles) > 0
+		}
+	}
+	if incremental && (!isTiDBBackend(cfg) || cfg.Conflict.Strategy != config.ReplaceOnDup) {
+		return common.ErrInvalidConfig.GenWithStack(
+			`the data source is an incremental dump of dumpling, which requires tikv-importer.backend = "tidb" and conflict.strategy = "replace"`)
+	}
+	return nil
+}
+
 func isLocalBackend(cfg *config.Config) bool {
 	return cfg.TikvImporter.Backend == config.BackendLocal
 }
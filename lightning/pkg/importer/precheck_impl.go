@@ -1024,7 +1024,9 @@ func (ci *schemaCheckItem) SchemaIsValid(ctx context.Context, tableInfo *mydump.
 	// get columns name from data file.
 	dataFileMeta := dataFile.FileMeta
 
-	if tp := dataFileMeta.Type; tp != mydump.SourceTypeCSV && tp != mydump.SourceTypeSQL && tp != mydump.SourceTypeParquet {
+	switch dataFileMeta.Type {
+	case mydump.SourceTypeCSV, mydump.SourceTypeSQL, mydump.SourceTypeParquet, mydump.SourceTypeJSONL, mydump.SourceTypeAvro:
+	default:
 		msgs = append(msgs, fmt.Sprintf("file '%s' with unknown source type '%s'", dataFileMeta.Path, dataFileMeta.Type.String()))
 		return msgs, nil
 	}
@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/lightning/worker"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
@@ -261,7 +262,15 @@ func (tr *TableImporter) importTable(
 		}
 	}
 
-	// 4. Restore engines (if still needed)
+	// 4. Apply the deletes of the incremental dump before importing the changed rows. The DELETE statements are
+	// idempotent, so they are executed again when resuming from the checkpoint.
+	if len(tr.tableMeta.DeleteFiles) > 0 && cp.Status < checkpoints.CheckpointStatusAllWritten {
+		if err := tr.applyDeletes(ctx, rc); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+
+	// 5. Restore engines (if still needed)
 	err := tr.importEngines(ctx, rc, cp)
 	if err != nil {
 		return false, errors.Trace(err)
@@ -272,7 +281,7 @@ func (tr *TableImporter) importTable(
 		return false, errors.Trace(err)
 	}
 
-	// 5. Post-process. With the last parameter set to false, we can allow delay analyze execute latter
+	// 6. Post-process. With the last parameter set to false, we can allow delay analyze execute latter
 	return tr.postProcess(ctx, rc, cp, false /* force-analyze */, metaMgr)
 }
 
@@ -780,7 +789,7 @@ ChunkLoop:
 			setError(err)
 			break
 		}
-		cr, err := newChunkProcessor(ctx, chunkIndex, rc.cfg, chunk, rc.ioWorkers, rc.store, tr.tableInfo.Core)
+		cr, err := newChunkProcessor(ctx, chunkIndex, rc.cfg, chunk, rc.ioWorkers, rc.store, tr.dbInfo.Name, tr.tableInfo.Core)
 		if err != nil {
 			setError(err)
 			break
@@ -1403,6 +1412,30 @@ func (tr *TableImporter) compareChecksum(remoteChecksum *local.RemoteChecksum, l
 	return nil
 }
 
+// applyDeletes deletes the rows which are deleted since the previous dump, the rows are recorded in the deletes files
+// of the incremental dump of Dumpling.
+func (tr *TableImporter) applyDeletes(ctx context.Context, rc *Controller) error {
+	task := tr.logger.Begin(zap.InfoLevel, "apply deletes")
+	p := parser.New()
+	p.SetSQLMode(rc.cfg.TiDB.SQLMode)
+	stmts, err := tr.tableMeta.GetDeleteStatements(ctx, rc.store, p)
+	if err != nil {
+		task.End(zap.ErrorLevel, err)
+		return err
+	}
+	exec := common.SQLWithRetry{
+		DB:     rc.db,
+		Logger: tr.logger,
+	}
+	for _, stmt := range stmts {
+		if err = exec.Exec(ctx, "apply deletes", stmt); err != nil {
+			break
+		}
+	}
+	task.End(zap.ErrorLevel, err, zap.Int("statements", len(stmts)))
+	return err
+}
+
 func (tr *TableImporter) analyzeTable(ctx context.Context, db *sql.DB) error {
 	task := tr.logger.Begin(zap.InfoLevel, "analyze")
 	exec := common.SQLWithRetry{
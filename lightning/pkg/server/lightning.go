@@ -93,6 +93,7 @@ type Lightning struct {
 	cancelLock sync.Mutex
 	curTask    *config.Config
 	cancel     context.CancelFunc // for per task context, which maybe different from lightning context
+	watcher    *watcher           // only set in the watch mode
 
 	taskCanceled bool
 }
@@ -250,6 +251,7 @@ func (l *Lightning) goServe(statusAddr string, realAddrWriter io.Writer) error {
 	mux.Handle("/tasks/", httpHandleWrapper(handleTasks.ServeHTTP))
 	mux.HandleFunc("/progress/task", httpHandleWrapper(handleProgressTask))
 	mux.HandleFunc("/progress/table", httpHandleWrapper(handleProgressTable))
+	mux.HandleFunc("/progress/watch", httpHandleWrapper(l.handleProgressWatch))
 	mux.HandleFunc("/pause", httpHandleWrapper(handlePause))
 	mux.HandleFunc("/resume", httpHandleWrapper(handleResume))
 	mux.HandleFunc("/loglevel", httpHandleWrapper(handleLogLevel))
@@ -356,11 +358,11 @@ func (l *Lightning) RunOnceWithOptions(taskCtx context.Context, taskCfg *config.
 	if err := taskCfg.Adjust(taskCtx); err != nil {
 		return err
 	}
-	r, err := rand.Int(rand.Reader, big.NewInt(1<<63-1))
+	taskID, err := newTaskID()
 	if err != nil {
 		return err
 	}
-	taskCfg.TaskID = r.Int64()
+	taskCfg.TaskID = taskID
 
 	failpoint.Inject("SetTaskID", func(val failpoint.Value) {
 		taskCfg.TaskID = int64(val.(int))
@@ -396,9 +398,20 @@ func (l *Lightning) RunOnceWithOptions(taskCtx context.Context, taskCfg *config.
 		})
 	}
 
+	if taskCfg.Watch.Enable {
+		return l.runWatch(taskCtx, taskCfg, o)
+	}
 	return l.run(taskCtx, taskCfg, o)
 }
 
+func newTaskID() (int64, error) {
+	r, err := rand.Int(rand.Reader, big.NewInt(1<<63-1))
+	if err != nil {
+		return 0, err
+	}
+	return r.Int64(), nil
+}
+
 var (
 	taskRunNotifyKey   = "taskRunNotifyKey"
 	taskCfgRecorderKey = "taskCfgRecorderKey"
@@ -800,6 +813,10 @@ func (l *Lightning) handlePostTask(w http.ResponseWriter, req *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "invalid task configuration", err)
 		return
 	}
+	if cfg.Watch.Enable {
+		writeJSONError(w, http.StatusBadRequest, "watch mode is not supported in server mode", nil)
+		return
+	}
 
 	l.taskCfgs.Push(cfg)
 	w.WriteHeader(http.StatusOK)
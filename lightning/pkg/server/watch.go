@@ -0,0 +1,446 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"go.uber.org/zap"
+)
+
+const (
+	watchStatusPolling   = "polling"
+	watchStatusImporting = "importing"
+	watchStatusStopped   = "stopped"
+)
+
+// WatchBatchProgress is the progress of a batch imported by the watch mode.
+type WatchBatchProgress struct {
+	ID        int64     `json:"id"`
+	Files     int       `json:"files"`
+	Bytes     int64     `json:"bytes"`
+	StartTime time.Time `json:"start-time"`
+}
+
+// WatchProgress is the progress of the watch mode.
+type WatchProgress struct {
+	Status          string `json:"status"`
+	FinishedBatches int    `json:"finished-batches"`
+	IngestedFiles   int    `json:"ingested-files"`
+	IngestedBytes   int64  `json:"ingested-bytes"`
+	// PendingFiles and PendingBytes are the data files found by the last poll
+	// but not ingested yet.
+	PendingFiles int                 `json:"pending-files"`
+	PendingBytes int64               `json:"pending-bytes"`
+	CurrentBatch *WatchBatchProgress `json:"current-batch,omitempty"`
+	LastPollTime time.Time           `json:"last-poll-time"`
+	LastError    string              `json:"last-error,omitempty"`
+}
+
+// batchStorage only exposes the files of a batch to the lightning task, so the
+// files arriving during the import are left to the following batches.
+type batchStorage struct {
+	storage.ExternalStorage
+	files map[string]struct{}
+}
+
+// WalkDir implements storage.ExternalStorage.WalkDir.
+func (s *batchStorage) WalkDir(ctx context.Context, opt *storage.WalkOption, fn func(path string, size int64) error) error {
+	return s.ExternalStorage.WalkDir(ctx, opt, func(path string, size int64) error {
+		if _, ok := s.files[path]; !ok {
+			return nil
+		}
+		return fn(path, size)
+	})
+}
+
+// watchSourceFile is a data file found in the data source.
+type watchSourceFile struct {
+	size int64
+	// schemaFiles are the schema files of the database and the table of the
+	// data file, they are imported with the data file.
+	schemaFiles []string
+}
+
+// importBatchFunc imports the files visible in the storage as a lightning task.
+type importBatchFunc func(ctx context.Context, cfg *config.Config, store storage.ExternalStorage) error
+
+// watcher keeps polling the data source, and imports the data files not
+// ingested yet in micro-batches.
+type watcher struct {
+	cfg      *config.Config
+	store    storage.ExternalStorage
+	cpdb     checkpoints.WatchCheckpointsDB
+	importFn importBatchFunc
+	logger   log.Logger
+
+	// ingested is the data files recorded as finished in cpdb.
+	ingested map[string]struct{}
+	// unfinished is the files of the batch which is interrupted last time.
+	unfinished  []checkpoints.WatchedFile
+	lastBatchID int64
+	// lastSizes is the sizes of the data files not ingested in the last poll. A
+	// data file may be still being written, so it's imported only if its size
+	// doesn't change between two polls.
+	lastSizes map[string]int64
+
+	mu       sync.Mutex
+	progress WatchProgress
+}
+
+func newWatcher(
+	ctx context.Context,
+	cfg *config.Config,
+	store storage.ExternalStorage,
+	cpdb checkpoints.WatchCheckpointsDB,
+	importFn importBatchFunc,
+	logger log.Logger,
+) (*watcher, error) {
+	files, err := cpdb.Files(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &watcher{
+		cfg:       cfg,
+		store:     store,
+		cpdb:      cpdb,
+		importFn:  importFn,
+		logger:    logger,
+		ingested:  make(map[string]struct{}, len(files)),
+		lastSizes: make(map[string]int64),
+		progress:  WatchProgress{Status: watchStatusPolling},
+	}
+	finishedBatches := make(map[int64]struct{})
+	for _, file := range files {
+		w.lastBatchID = max(w.lastBatchID, file.BatchID)
+		if !file.Finished {
+			w.unfinished = append(w.unfinished, file)
+			continue
+		}
+		w.ingested[file.Path] = struct{}{}
+		finishedBatches[file.BatchID] = struct{}{}
+		w.progress.IngestedFiles++
+		w.progress.IngestedBytes += file.Size
+	}
+	w.progress.FinishedBatches = len(finishedBatches)
+	return w, nil
+}
+
+// Progress returns the progress of the watch mode.
+func (w *watcher) Progress() WatchProgress {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	progress := w.progress
+	if progress.CurrentBatch != nil {
+		batch := *progress.CurrentBatch
+		progress.CurrentBatch = &batch
+	}
+	return progress
+}
+
+func (w *watcher) updateProgress(fn func(progress *WatchProgress)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fn(&w.progress)
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	defer w.updateProgress(func(progress *WatchProgress) {
+		progress.Status = watchStatusStopped
+		progress.CurrentBatch = nil
+	})
+
+	ticker := time.NewTicker(w.cfg.Watch.PollInterval.Duration)
+	defer ticker.Stop()
+	for {
+		if err := w.poll(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll scans the data source once and imports the data files ready to import.
+func (w *watcher) poll(ctx context.Context) error {
+	sources, err := w.scan(ctx)
+	if err != nil {
+		if common.IsContextCanceledError(err) {
+			return err
+		}
+		// the data source may be unavailable temporarily, or a schema file may
+		// arrive later than the data files, so we just retry in the next poll.
+		w.logger.Warn("failed to scan the data source, will retry in the next poll", log.ShortError(err))
+		w.updateProgress(func(progress *WatchProgress) {
+			progress.LastError = err.Error()
+		})
+		return nil
+	}
+
+	if len(w.unfinished) > 0 {
+		// the batch must be resumed with the same files, otherwise the
+		// checkpoints of it don't match the data files.
+		w.logger.Info("resume the unfinished batch", zap.Int64("batchID", w.lastBatchID), zap.Int("files", len(w.unfinished)))
+		if err := w.importBatch(ctx, w.lastBatchID, w.unfinished, sources); err != nil {
+			return err
+		}
+		w.unfinished = nil
+	}
+
+	var (
+		candidates []checkpoints.WatchedFile
+		lastSizes  = make(map[string]int64, len(sources))
+	)
+	for path, source := range sources {
+		if _, ok := w.ingested[path]; ok {
+			continue
+		}
+		lastSizes[path] = source.size
+		if size, ok := w.lastSizes[path]; ok && size == source.size {
+			candidates = append(candidates, checkpoints.WatchedFile{Path: path, Size: source.size})
+		}
+	}
+	w.lastSizes = lastSizes
+	slices.SortFunc(candidates, func(a, b checkpoints.WatchedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	for len(candidates) > 0 {
+		n, size := 0, int64(0)
+		for n < len(candidates) && n < w.cfg.Watch.BatchFiles {
+			if n > 0 && size+candidates[n].Size > int64(w.cfg.Watch.BatchSize) {
+				break
+			}
+			size += candidates[n].Size
+			n++
+		}
+		if err := w.importBatch(ctx, w.lastBatchID+1, candidates[:n], sources); err != nil {
+			return err
+		}
+		candidates = candidates[n:]
+	}
+	return nil
+}
+
+// scan returns the data files in the data source by their paths.
+func (w *watcher) scan(ctx context.Context) (map[string]watchSourceFile, error) {
+	mdl, err := mydump.NewLoaderWithStore(
+		ctx, mydump.NewLoaderCfg(w.cfg), w.store,
+		mydump.WithScanFileConcurrency(w.cfg.App.RegionConcurrency*2),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	sources := make(map[string]watchSourceFile)
+	var pendingFiles int
+	var pendingBytes int64
+	for _, db := range mdl.GetDatabases() {
+		for _, tbl := range db.Tables {
+			var schemaFiles []string
+			for _, schemaFile := range []mydump.FileInfo{db.SchemaFile, tbl.SchemaFile} {
+				if len(schemaFile.FileMeta.Path) > 0 {
+					schemaFiles = append(schemaFiles, schemaFile.FileMeta.Path)
+				}
+			}
+			for _, dataFile := range tbl.DataFiles {
+				sources[dataFile.FileMeta.Path] = watchSourceFile{
+					size:        dataFile.FileMeta.FileSize,
+					schemaFiles: schemaFiles,
+				}
+				if _, ok := w.ingested[dataFile.FileMeta.Path]; !ok {
+					pendingFiles++
+					pendingBytes += dataFile.FileMeta.FileSize
+				}
+			}
+		}
+	}
+	w.updateProgress(func(progress *WatchProgress) {
+		progress.PendingFiles = pendingFiles
+		progress.PendingBytes = pendingBytes
+		progress.LastPollTime = time.Now()
+		progress.LastError = ""
+	})
+	return sources, nil
+}
+
+// importBatch imports the files as a batch, a new batch is recorded in the
+// checkpoints before importing, so that it can be resumed after restarting.
+func (w *watcher) importBatch(
+	ctx context.Context,
+	batchID int64,
+	files []checkpoints.WatchedFile,
+	sources map[string]watchSourceFile,
+) error {
+	visible := make(map[string]struct{}, len(files))
+	var size int64
+	for _, file := range files {
+		source, ok := sources[file.Path]
+		if !ok {
+			return errors.Errorf("data file %s of batch %d is not found in the data source", file.Path, batchID)
+		}
+		visible[file.Path] = struct{}{}
+		for _, schemaFile := range source.schemaFiles {
+			visible[schemaFile] = struct{}{}
+		}
+		size += file.Size
+	}
+
+	if batchID > w.lastBatchID {
+		if err := w.cpdb.StartBatch(ctx, batchID, files); err != nil {
+			return errors.Trace(err)
+		}
+		w.lastBatchID = batchID
+	}
+
+	logger := w.logger.With(zap.Int64("batchID", batchID), zap.Int("files", len(files)), zap.Int64("size", size))
+	w.updateProgress(func(progress *WatchProgress) {
+		progress.Status = watchStatusImporting
+		progress.CurrentBatch = &WatchBatchProgress{
+			ID:        batchID,
+			Files:     len(files),
+			Bytes:     size,
+			StartTime: time.Now(),
+		}
+	})
+
+	task := logger.Begin(zap.InfoLevel, "import watch batch")
+	// every batch is an individual lightning task.
+	batchCfg := *w.cfg
+	taskID, err := newTaskID()
+	if err == nil {
+		batchCfg.TaskID = taskID
+		err = w.importFn(ctx, &batchCfg, &batchStorage{ExternalStorage: w.store, files: visible})
+	}
+	task.End(zap.ErrorLevel, err)
+	if err != nil {
+		w.updateProgress(func(progress *WatchProgress) {
+			progress.LastError = err.Error()
+		})
+		return errors.Annotatef(err, "import watch batch %d", batchID)
+	}
+	if err := w.cpdb.FinishBatch(ctx, batchID); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, file := range files {
+		w.ingested[file.Path] = struct{}{}
+		delete(w.lastSizes, file.Path)
+	}
+	w.updateProgress(func(progress *WatchProgress) {
+		progress.Status = watchStatusPolling
+		progress.CurrentBatch = nil
+		progress.FinishedBatches++
+		progress.IngestedFiles += len(files)
+		progress.IngestedBytes += size
+		progress.PendingFiles = max(progress.PendingFiles-len(files), 0)
+		progress.PendingBytes = max(progress.PendingBytes-size, 0)
+	})
+	return nil
+}
+
+// runWatch runs the watch mode until the context is canceled or a batch fails
+// to import.
+func (l *Lightning) runWatch(taskCtx context.Context, taskCfg *config.Config, o *options) error {
+	ctx, cancel := context.WithCancel(taskCtx)
+	defer cancel()
+	// Stop only cancels the running task, so we also need to exit on the
+	// shutdown of lightning between two batches.
+	stop := context.AfterFunc(l.ctx, cancel)
+	defer stop()
+
+	if err := taskCfg.TiDB.Security.BuildTLSConfig(); err != nil {
+		return common.ErrInvalidTLSConfig.Wrap(err)
+	}
+
+	store := o.dumpFileStorage
+	if store == nil {
+		u, err := storage.ParseBackend(taskCfg.Mydumper.SourceDir, nil)
+		if err != nil {
+			return common.NormalizeError(err)
+		}
+		store, err = storage.New(ctx, u, &storage.ExternalStorageOptions{})
+		if err != nil {
+			return common.NormalizeError(err)
+		}
+		defer store.Close()
+	}
+
+	cpdb, err := checkpoints.OpenWatchCheckpointsDB(ctx, taskCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer cpdb.Close()
+
+	importFn := func(ctx context.Context, cfg *config.Config, store storage.ExternalStorage) error {
+		batchOpts := *o
+		batchOpts.dumpFileStorage = store
+		return l.run(ctx, cfg, &batchOpts)
+	}
+	w, err := newWatcher(ctx, taskCfg, store, cpdb, importFn, o.logger)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	l.cancelLock.Lock()
+	l.watcher = w
+	l.cancelLock.Unlock()
+
+	o.logger.Info("start watching the data source",
+		zap.String("source", taskCfg.Mydumper.SourceDir),
+		zap.Duration("pollInterval", taskCfg.Watch.PollInterval.Duration))
+	return errors.Trace(w.run(ctx))
+}
+
+func (l *Lightning) handleProgressWatch(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "only GET is allowed", nil)
+		return
+	}
+
+	l.cancelLock.Lock()
+	watcher := l.watcher
+	l.cancelLock.Unlock()
+	if watcher == nil {
+		writeJSONError(w, http.StatusNotFound, "watch mode not enabled", nil)
+		return
+	}
+
+	res, err := json.Marshal(watcher.Progress())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "cannot marshal watch progress", err)
+		return
+	}
+	writeBytesCompressed(w, req, res)
+}
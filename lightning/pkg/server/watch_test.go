@@ -0,0 +1,172 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/stretchr/testify/require"
+)
+
+type watchTestImporter struct {
+	batches [][]string
+	taskIDs map[int64]struct{}
+	err     error
+}
+
+func (i *watchTestImporter) importBatch(ctx context.Context, cfg *config.Config, store storage.ExternalStorage) error {
+	var files []string
+	err := store.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	i.batches = append(i.batches, files)
+	i.taskIDs[cfg.TaskID] = struct{}{}
+	return i.err
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	writeFile("db-schema-create.sql", "CREATE DATABASE db;")
+	writeFile("db.t-schema.sql", "CREATE TABLE t (a int);")
+	writeFile("db.t.1.csv", "1\n")
+
+	cfg := config.NewConfig()
+	cfg.Mydumper.SourceDir = dir
+	cfg.Mydumper.DefaultFileRules = true
+	cfg.App.RegionConcurrency = 2
+	cfg.Checkpoint.Driver = config.CheckpointDriverFile
+	cfg.Checkpoint.DSN = filepath.Join(t.TempDir(), "cp.pb")
+	cfg.Watch.Enable = true
+	cfg.Watch.BatchFiles = 2
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	cpdb, err := checkpoints.OpenWatchCheckpointsDB(ctx, cfg)
+	require.NoError(t, err)
+
+	importer := &watchTestImporter{taskIDs: make(map[int64]struct{})}
+	w, err := newWatcher(ctx, cfg, store, cpdb, importer.importBatch, log.L())
+	require.NoError(t, err)
+
+	// the files are imported only if the size is stable between two polls.
+	require.NoError(t, w.poll(ctx))
+	require.Empty(t, importer.batches)
+	progress := w.Progress()
+	require.Equal(t, watchStatusPolling, progress.Status)
+	require.Equal(t, 1, progress.PendingFiles)
+	require.EqualValues(t, 2, progress.PendingBytes)
+
+	writeFile("db.t.2.csv", "2\n")
+	require.NoError(t, w.poll(ctx))
+	require.Equal(t, [][]string{{"db-schema-create.sql", "db.t-schema.sql", "db.t.1.csv"}}, importer.batches)
+	progress = w.Progress()
+	require.Equal(t, 1, progress.FinishedBatches)
+	require.Equal(t, 1, progress.IngestedFiles)
+	require.Equal(t, 1, progress.PendingFiles)
+
+	// a file still being written is left to the next poll.
+	writeFile("db.t.3.csv", "3\n")
+	writeFile("db.t.4.csv", "4\n")
+	writeFile("db.t.5.csv", "5\n")
+	require.NoError(t, w.poll(ctx))
+	require.Len(t, importer.batches, 2)
+	require.Equal(t, []string{"db-schema-create.sql", "db.t-schema.sql", "db.t.2.csv"}, importer.batches[1])
+	writeFile("db.t.5.csv", "5\n55\n")
+	writeFile("db.t.6.csv", "6\n")
+	require.NoError(t, w.poll(ctx))
+	require.Len(t, importer.batches, 3)
+	require.Equal(t, []string{"db-schema-create.sql", "db.t-schema.sql", "db.t.3.csv", "db.t.4.csv"}, importer.batches[2])
+
+	// the failed batch is resumed with the same files after restarting.
+	importer.err = errors.New("mock import error")
+	require.ErrorContains(t, w.poll(ctx), "import watch batch 4: mock import error")
+	require.Equal(t, []string{"db-schema-create.sql", "db.t-schema.sql", "db.t.5.csv", "db.t.6.csv"}, importer.batches[3])
+	require.Equal(t, "mock import error", w.Progress().LastError)
+	require.NoError(t, cpdb.Close())
+
+	writeFile("db.t.7.csv", "7\n")
+	cpdb, err = checkpoints.OpenWatchCheckpointsDB(ctx, cfg)
+	require.NoError(t, err)
+	defer cpdb.Close()
+	importer.err = nil
+	w, err = newWatcher(ctx, cfg, store, cpdb, importer.importBatch, log.L())
+	require.NoError(t, err)
+	progress = w.Progress()
+	require.Equal(t, 3, progress.FinishedBatches)
+	require.Equal(t, 4, progress.IngestedFiles)
+	require.EqualValues(t, 8, progress.IngestedBytes)
+	require.NoError(t, w.poll(ctx))
+	require.Len(t, importer.batches, 5)
+	require.Equal(t, importer.batches[3], importer.batches[4])
+	require.NoError(t, w.poll(ctx))
+	require.Len(t, importer.batches, 6)
+	require.Equal(t, []string{"db-schema-create.sql", "db.t-schema.sql", "db.t.7.csv"}, importer.batches[5])
+	require.Len(t, importer.taskIDs, 6)
+
+	files, err := cpdb.Files(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 7)
+	for _, file := range files {
+		require.True(t, file.Finished)
+	}
+	require.Equal(t, int64(5), files[len(files)-1].BatchID)
+	progress = w.Progress()
+	require.Equal(t, 5, progress.FinishedBatches)
+	require.Equal(t, 7, progress.IngestedFiles)
+	require.Zero(t, progress.PendingFiles)
+}
+
+func TestHandleProgressWatch(t *testing.T) {
+	l := &Lightning{}
+	resp := httptest.NewRecorder()
+	l.handleProgressWatch(resp, httptest.NewRequest(http.MethodGet, "/progress/watch", nil))
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	l.watcher = &watcher{progress: WatchProgress{
+		Status:        watchStatusImporting,
+		IngestedFiles: 3,
+		CurrentBatch:  &WatchBatchProgress{ID: 2, Files: 1},
+	}}
+	resp = httptest.NewRecorder()
+	l.handleProgressWatch(resp, httptest.NewRequest(http.MethodGet, "/progress/watch", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	var progress WatchProgress
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &progress))
+	require.Equal(t, watchStatusImporting, progress.Status)
+	require.Equal(t, 3, progress.IngestedFiles)
+	require.Equal(t, int64(2), progress.CurrentBatch.ID)
+
+	resp = httptest.NewRecorder()
+	l.handleProgressWatch(resp, httptest.NewRequest(http.MethodPost, "/progress/watch", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
@@ -52,6 +52,8 @@ const (
 	SourceManual = "manual"
 	// SourceHistory indicate the binding is created from statement summary by plan digest
 	SourceHistory = "history"
+	// SourceGenerated indicates the binding is generated by the plan generator of the binding plan evolution.
+	SourceGenerated = "generated"
 )
 
 // Binding stores the basic bind hint info.
@@ -33,6 +33,10 @@ import (
 // PlanDigestFunc is used to get the plan digest of this SQL.
 var PlanDigestFunc func(sctx sessionctx.Context, stmt ast.StmtNode) (planDigest string, err error)
 
+// GenPlanFunc is used to generate the plan of this SQL, and returns its plan digest, plan text and the hints to
+// reproduce the plan.
+var GenPlanFunc func(sctx sessionctx.Context, stmt ast.StmtNode) (planDigest, plan, planHints string, err error)
+
 // BindingPlanInfo contains the binding info and its corresponding plan execution info, which is used by
 // "SHOW PLAN FOR <SQL>" to help users understand the historical plans for a specific SQL.
 type BindingPlanInfo struct {
@@ -70,7 +74,7 @@ type bindingAuto struct {
 func newBindingAuto(sPool util.DestroyableSessionPool) BindingPlanEvolution {
 	return &bindingAuto{
 		sPool:              sPool,
-		planGenerator:      new(knobBasedPlanGenerator),
+		planGenerator:      &knobBasedPlanGenerator{sPool: sPool},
 		ruleBasedPredictor: new(ruleBasedPlanPerfPredictor),
 		llmPredictor:       new(llmBasedPlanPerfPredictor),
 	}
@@ -91,7 +95,20 @@ func (ba *bindingAuto) ShowPlansForSQL(currentDB, sqlOrDigest, charset, collatio
 		return nil, err
 	}
 
-	planCandidates := append(historicalPlans, generatedPlans...)
+	planCandidates := historicalPlans
+	for _, genPlan := range generatedPlans {
+		if slices.ContainsFunc(historicalPlans, func(p *BindingPlanInfo) bool {
+			return p.PlanDigest == genPlan.PlanDigest
+		}) {
+			continue // this plan has been bound already.
+		}
+		pInfo, err := ba.getPlanExecInfo(genPlan.PlanDigest)
+		if err != nil {
+			bindingLogger().Error("get plan execution info failed", zap.String("plan_digest", genPlan.PlanDigest), zap.Error(err))
+		}
+		genPlan.fillExecInfo(pInfo)
+		planCandidates = append(planCandidates, genPlan)
+	}
 	ok, err := ba.fillRecommendation(planCandidates, ba.ruleBasedPredictor, "rule-based")
 	if err != nil || ok { // error or hit any rule
 		return planCandidates, err
@@ -138,6 +155,7 @@ func (ba *bindingAuto) getHistoricalPlanInfo(currentDB, sqlOrDigest, charset, co
 				bindingLogger().Error("get plan digest failed",
 					zap.String("bind_sql", binding.BindSQL), zap.Error(err))
 			}
+			binding.PlanDigest = planDigest
 		}
 
 		pInfo, err := ba.getPlanExecInfo(planDigest)
@@ -146,22 +164,28 @@ func (ba *bindingAuto) getHistoricalPlanInfo(currentDB, sqlOrDigest, charset, co
 			continue
 		}
 		autoBinding := &BindingPlanInfo{Binding: binding}
-		if pInfo != nil && pInfo.ExecCount > 0 { // pInfo could be nil when stmt_stats' data is incomplete.
-			autoBinding.Plan = pInfo.Plan
-			autoBinding.ExecTimes = pInfo.ExecCount
-			autoBinding.AvgLatency = float64(pInfo.TotalTime) / float64(pInfo.ExecCount)
-			autoBinding.AvgScanRows = float64(pInfo.ProcessedKeys) / float64(pInfo.ExecCount)
-			autoBinding.AvgReturnedRows = float64(pInfo.ResultRows) / float64(pInfo.ExecCount)
-			if autoBinding.AvgReturnedRows > 0 {
-				autoBinding.LatencyPerReturnRow = autoBinding.AvgLatency / autoBinding.AvgReturnedRows
-				autoBinding.ScanRowsPerReturnRow = autoBinding.AvgScanRows / autoBinding.AvgReturnedRows
-			}
-		}
+		autoBinding.fillExecInfo(pInfo)
 		bindingPlans = append(bindingPlans, autoBinding)
 	}
 	return bindingPlans, nil
 }
 
+// fillExecInfo fills the execution info of this plan from the statement stats.
+func (p *BindingPlanInfo) fillExecInfo(pInfo *planExecInfo) {
+	if pInfo == nil || pInfo.ExecCount == 0 { // pInfo could be nil when stmt_stats' data is incomplete.
+		return
+	}
+	p.Plan = pInfo.Plan
+	p.ExecTimes = pInfo.ExecCount
+	p.AvgLatency = float64(pInfo.TotalTime) / float64(pInfo.ExecCount)
+	p.AvgScanRows = float64(pInfo.ProcessedKeys) / float64(pInfo.ExecCount)
+	p.AvgReturnedRows = float64(pInfo.ResultRows) / float64(pInfo.ExecCount)
+	if p.AvgReturnedRows > 0 {
+		p.LatencyPerReturnRow = p.AvgLatency / p.AvgReturnedRows
+		p.ScanRowsPerReturnRow = p.AvgScanRows / p.AvgReturnedRows
+	}
+}
+
 func (*bindingAuto) fillRecommendation(plans []*BindingPlanInfo, predictor PlanPerfPredictor, name string) (bool, error) {
 	if len(plans) == 0 {
 		return false, nil
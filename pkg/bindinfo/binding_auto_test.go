@@ -15,10 +15,15 @@
 package bindinfo_test
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/bindinfo"
+	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,29 +33,38 @@ func TestShowPlanForSQLBasic(t *testing.T) {
 	tk.MustExec("use test")
 
 	tk.MustExec(`create table t (a int, b int, c varchar(10), key(a))`)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b=1"`).Rows()) == 0)
+	// the bindings below have no hints, which distinguishes them from the generated plans.
+	showBindingPlans := func(showSQL string) (rows [][]any) {
+		for _, row := range tk.MustQuery(showSQL).Rows() {
+			if row[1] == "" {
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	}
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b=1"`)) == 0)
 	tk.MustExec(`create global binding using select a from t where b=1`)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b=1"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "SELECT a FROM t WHERE b=1"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "SELECT a FROM t WHERE b= 1"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "     SELECT  a FROM test.t WHERE b= 1"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "23109784b802bcef5398dd81d3b1c5b79200c257c101a5b9f90758206f3d09ed"`).Rows()) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b=1"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "SELECT a FROM t WHERE b=1"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "SELECT a FROM t WHERE b= 1"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "     SELECT  a FROM test.t WHERE b= 1"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "23109784b802bcef5398dd81d3b1c5b79200c257c101a5b9f90758206f3d09ed"`)) == 1)
 
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b in (1, 2, 3)"`).Rows()) == 0)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b in (1, 2, 3)"`)) == 0)
 	tk.MustExec(`create global binding using select a from t where b in (1, 2, 3)`)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b in (1, 2, 3)"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b in (1, 2)"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where b in (1)"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "SELECT a from t WHere b in (1)"`).Rows()) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b in (1, 2, 3)"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b in (1, 2)"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where b in (1)"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "SELECT a from t WHere b in (1)"`)) == 1)
 
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where c = ''"`).Rows()) == 0)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where c = ''"`)) == 0)
 	tk.MustExec(`create global binding using select a from t where c = ''`)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where c = ''"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where c = '123'"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where c = '\"'"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for "select a from t where c = '              '"`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for 'select a from t where c = ""'`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`show plan for 'select a from t where c = "\'"'`).Rows()) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where c = ''"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where c = '123'"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where c = '\"'"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for "select a from t where c = '              '"`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for 'select a from t where c = ""'`)) == 1)
+	require.True(t, len(showBindingPlans(`show plan for 'select a from t where c = "\'"'`)) == 1)
 
 	tk.MustExecToErr("show plan for 'xxx'", "")
 	tk.MustExecToErr("show plan for 'SELECT A FROM'", "")
@@ -82,3 +96,74 @@ func TestIsSimplePointPlan(t *testing.T) {
         HashJoin    root    1       plus(test.t.a, 1)->Column#3     0       time:173µs, open:24.9µs, close:8.92µs, loops:1, Concurrency:OFF                         380 Bytes       N/A
         └─Point_Get_5   root    1       table:t, handle:2               0       time:143.2µs, open:1.71µs, close:5.92µs, loops:1, Get:{num_rpc:1, total_time:40µs}      N/A             N/A`))
 }
+
+func TestShowPlanForSQLGeneratedPlans(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t1 (a int, b int, c int, key(a), key(b))`)
+	tk.MustExec(`create table t2 (a int, b int, c int, key(a))`)
+
+	sql := "select * from t1, t2 where t1.a = t2.a and t1.b = 1"
+	rows := tk.MustQuery(fmt.Sprintf("show plan for '%s'", sql)).Rows()
+	require.Greater(t, len(rows), 1)
+	planDigests := make(map[string]struct{}, len(rows))
+	var plans []string
+	for _, row := range rows {
+		require.Equal(t, "select * from ( `test` . `t1` ) join `test` . `t2` where `t1` . `a` = `t2` . `a` and `t1` . `b` = ?", row[0])
+		require.NotEmpty(t, row[1]) // binding hints
+		require.NotEmpty(t, row[2]) // plan
+		planDigest := row[3].(string)
+		require.NotContains(t, planDigests, planDigest)
+		planDigests[planDigest] = struct{}{}
+		plans = append(plans, row[2].(string))
+	}
+	for _, op := range []string{"HashJoin", "MergeJoin", "IndexJoin", "IndexLookUp", "TableFullScan"} {
+		require.True(t, slices.ContainsFunc(plans, func(plan string) bool {
+			return strings.Contains(plan, op)
+		}), op)
+	}
+
+	// the hints of the generated plans can reproduce the plans.
+	for _, row := range rows {
+		hintedSQL := strings.Replace(sql, "select", fmt.Sprintf("select /*+ %s */", row[1]), 1)
+		tk.MustExec(hintedSQL)
+		tk.MustQuery(`show warnings`).Check(testkit.Rows())
+	}
+
+	// the plan of the bound SQL won't be generated again.
+	tk.MustExec("create global binding using select /*+ use_index(t1, b) */ * from t1 where b = 1")
+	rows = tk.MustQuery("show plan for 'select * from t1 where b = 1'").Rows()
+	require.Contains(t, rows[0][1], "use_index(@`sel_1` `test`.`t1` `b`)")
+	for _, row := range rows[1:] {
+		require.NotEqual(t, rows[0][3], row[3])
+	}
+
+	// plans can't be generated by the SQL digest only.
+	_, sqlDigest := parser.NormalizeDigest("select * from t1 where a = 1")
+	require.Len(t, tk.MustQuery(fmt.Sprintf("show plan for '%s'", sqlDigest.String())).Rows(), 0)
+	// knobs adjusted by SET_VAR are restored, so the same plans are generated again.
+	require.Equal(t, rows, tk.MustQuery("show plan for 'select * from t1 where b = 1'").Rows())
+}
+
+func TestShowPlanForSQLKnobsLimit(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	cols := make([]string, 0, 60)
+	keys := make([]string, 0, 60)
+	for i := range 60 {
+		cols = append(cols, fmt.Sprintf("c%d int", i))
+		keys = append(keys, fmt.Sprintf("key(c%d)", i))
+	}
+	tk.MustExec(fmt.Sprintf("create table t (%s, %s)", strings.Join(cols, ", "), strings.Join(keys, ", ")))
+
+	// each index is a knob, but the number of optimizations is limited.
+	var knobs []string
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/bindinfo/genPlanWithKnob", func(knob string) {
+		knobs = append(knobs, knob)
+	})
+	require.NotEmpty(t, tk.MustQuery("show plan for 'select * from t where c0 = 1'").Rows())
+	require.Len(t, knobs, 64)
+	require.Equal(t, "", knobs[0])
+}
@@ -15,7 +15,21 @@
 package bindinfo
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"go.uber.org/zap"
 )
 
 // PlanGenerator is used to generate new Plan Candidates for this specified query.
@@ -24,12 +38,246 @@ type PlanGenerator interface {
 }
 
 // knobBasedPlanGenerator generates new plan candidates via adjusting knobs like cost factors, hints, etc.
+// Each knob is applied to the query alone, and plans with the same plan digest are only returned once.
 type knobBasedPlanGenerator struct {
+	sPool util.DestroyableSessionPool
 }
 
-func (*knobBasedPlanGenerator) Generate(string, string) (plans []*BindingPlanInfo, err error) {
-	// TODO: implement this
-	return nil, nil
+// maxPlanGenerationKnobs limits the number of knobs tried for a statement, since each knob costs a full
+// optimization of the statement.
+const maxPlanGenerationKnobs = 64
+
+// knobVariables are the optimizer variables adjusted by the SET_VAR hint to generate plan candidates.
+var knobVariables = []struct {
+	name   string
+	values []string
+}{
+	{vardef.TiDBOptPreferRangeScan, []string{"ON", "OFF"}},
+	{vardef.TiDBOptAggPushDown, []string{"ON", "OFF"}},
+	{vardef.TiDBOptEnableCorrelationAdjustment, []string{"ON", "OFF"}},
+	{vardef.TiDBEnableIndexMerge, []string{"ON", "OFF"}},
+	{vardef.TiDBOptObjective, []string{vardef.OptObjectiveModerate, vardef.OptObjectiveDeterminate}},
+	// penalize some operators to let the optimizer try other ones.
+	{vardef.TiDBOptTableFullScanCostFactor, []string{"100"}},
+	{vardef.TiDBOptIndexLookupCostFactor, []string{"100"}},
+	{vardef.TiDBOptSortCostFactor, []string{"100"}},
+}
+
+func (g *knobBasedPlanGenerator) Generate(defaultSchema, sql string) (plans []*BindingPlanInfo, err error) {
+	sql = strings.TrimSpace(sql)
+	if len(sql) == 64 && !strings.Contains(sql, " ") {
+		// only the SQL digest is specified, but the SQL text is required to generate plans.
+		return nil, nil
+	}
+	err = callWithSCtx(g.sPool, false, func(sctx sessionctx.Context) error {
+		plans, err = g.generate(sctx, defaultSchema, sql)
+		return err
+	})
+	return
+}
+
+func (*knobBasedPlanGenerator) generate(sctx sessionctx.Context, defaultSchema, sql string) (plans []*BindingPlanInfo, err error) {
+	vars := sctx.GetSessionVars()
+	defer func(originalBaseline bool, originalDB string) {
+		vars.UsePlanBaselines = originalBaseline
+		vars.CurrentDB = originalDB
+	}(vars.UsePlanBaselines, vars.CurrentDB)
+	vars.UsePlanBaselines = false
+
+	p := utilparser.GetParser()
+	defer utilparser.DestroyParser(p)
+	p.SetSQLMode(vars.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	charset, collation := vars.GetCharsetInfo()
+
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return nil, errors.NewNoStackErrorf("failed to parse the SQL: %v", err)
+	}
+	if hasParam(stmt) {
+		// the plan of SQL with '?' can't be generated.
+		return nil, nil
+	}
+	db := utilparser.GetDefaultDB(stmt, defaultSchema)
+	vars.CurrentDB = db
+	originalSQL, sqlDigest := NormalizeStmtForBinding(stmt, db, false)
+	originalHints, err := hint.CollectHint(stmt).Restore()
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[string]struct{})
+	// the first knob is empty to generate the default plan.
+	knobs := append([]string{""}, enumerateKnobs(sctx, stmt, db)...)
+	if len(knobs) > maxPlanGenerationKnobs {
+		bindingLogger().Info("too many knobs to generate plans, only the first ones are tried", zap.String("sql", sql),
+			zap.Int("knobs", len(knobs)), zap.Int("limit", maxPlanGenerationKnobs))
+		knobs = knobs[:maxPlanGenerationKnobs]
+	}
+	for _, knob := range knobs {
+		failpoint.InjectCall("genPlanWithKnob", knob)
+		planDigest, plan, bindSQL, err := genPlanWithKnob(sctx, p, sql, db, originalHints, knob)
+		if err != nil {
+			bindingLogger().Warn("generate plan with knob failed", zap.String("sql", sql),
+				zap.String("knob", knob), zap.Error(err))
+			continue
+		}
+		if _, ok := visited[planDigest]; ok || bindSQL == "" {
+			continue
+		}
+		visited[planDigest] = struct{}{}
+
+		binding := &Binding{
+			OriginalSQL: originalSQL,
+			Db:          db,
+			BindSQL:     bindSQL,
+			Status:      StatusEnabled,
+			Charset:     charset,
+			Collation:   collation,
+			Source:      SourceGenerated,
+			SQLDigest:   sqlDigest,
+			PlanDigest:  planDigest,
+		}
+		if err := prepareHints(sctx, binding); err != nil {
+			bindingLogger().Warn("prepare hints for the generated binding failed",
+				zap.String("bind_sql", bindSQL), zap.Error(err))
+			continue
+		}
+		plans = append(plans, &BindingPlanInfo{Binding: binding, Plan: plan})
+	}
+	return plans, nil
+}
+
+// genPlanWithKnob optimizes the SQL with the knob hint and returns the plan and the binding SQL to reproduce it.
+func genPlanWithKnob(sctx sessionctx.Context, p *parser.Parser, sql, db, originalHints, knob string) (planDigest, plan, bindSQL string, err error) {
+	charset, collation := sctx.GetSessionVars().GetCharsetInfo()
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return "", "", "", err
+	}
+	if knob != "" {
+		// GenerateBindingSQL replaces the hints in the SQL, so the original hints are kept here.
+		hintedSQL := GenerateBindingSQL(stmt, joinHints(originalHints, knob), db)
+		if stmt, err = p.ParseOneStmt(hintedSQL, charset, collation); err != nil {
+			return "", "", "", err
+		}
+	}
+	planDigest, plan, planHints, err := GenPlanFunc(sctx, stmt)
+	if err != nil {
+		return "", "", "", err
+	}
+	if strings.HasPrefix(knob, "set_var") {
+		// the effect of some variables like tidb_opt_agg_push_down can't be expressed by the plan hints.
+		planHints = joinHints(planHints, knob)
+	}
+	if stmt, err = p.ParseOneStmt(sql, charset, collation); err != nil {
+		return "", "", "", err
+	}
+	if planHints == "" {
+		// plans like PointGet can't be reproduced by hints, bind the SQL itself.
+		return planDigest, plan, RestoreDBForBinding(stmt, db), nil
+	}
+	return planDigest, plan, GenerateBindingSQL(stmt, planHints, db), nil
+}
+
+func joinHints(hints ...string) string {
+	nonEmpty := make([]string, 0, len(hints))
+	for _, h := range hints {
+		if h != "" {
+			nonEmpty = append(nonEmpty, h)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+// enumerateKnobs returns the knobs that may change the plan of this statement, each knob is a hint string.
+func enumerateKnobs(sctx sessionctx.Context, stmt ast.StmtNode, db string) []string {
+	var knobs []string
+	for _, v := range knobVariables {
+		for _, val := range v.values {
+			knobs = append(knobs, fmt.Sprintf("set_var(%s='%s')", v.name, val))
+		}
+	}
+
+	// only tables in the outermost query block are adjusted, since the hints are put there.
+	tables := collectQueryBlockTables(stmt)
+	is := sctx.GetDomainInfoSchema()
+	for _, tbl := range tables {
+		schema, name := tbl.tableName.Schema, tbl.tableName.Name
+		if schema.L == "" {
+			schema = ast.NewCIStr(db)
+		}
+		tblInfo, err := is.TableInfoByName(schema, name)
+		if err != nil {
+			continue
+		}
+		// access paths: table scan and each visible index.
+		knobs = append(knobs, fmt.Sprintf("%s(%s)", hint.HintUseIndex, tbl.hintName))
+		for _, idx := range tblInfo.Indices {
+			if idx.State != model.StatePublic || idx.Invisible {
+				continue
+			}
+			knobs = append(knobs, fmt.Sprintf("%s(%s, %s)", hint.HintUseIndex, tbl.hintName, idx.Name.O))
+		}
+		// join methods and join orders.
+		if len(tables) > 1 {
+			for _, joinHint := range []string{hint.HintHJ, hint.HintSMJ, hint.HintINLJ, hint.HintLeading} {
+				knobs = append(knobs, fmt.Sprintf("%s(%s)", joinHint, tbl.hintName))
+			}
+		}
+	}
+	return knobs
+}
+
+type queryBlockTable struct {
+	tableName *ast.TableName
+	hintName  string
+}
+
+// collectQueryBlockTables collects the tables in the FROM clause of the outermost query block.
+func collectQueryBlockTables(stmt ast.StmtNode) []queryBlockTable {
+	var from *ast.TableRefsClause
+	switch x := stmt.(type) {
+	case *ast.SelectStmt:
+		from = x.From
+	case *ast.UpdateStmt:
+		from = x.TableRefs
+	case *ast.DeleteStmt:
+		from = x.TableRefs
+	case *ast.InsertStmt:
+		if sel, ok := x.Select.(*ast.SelectStmt); ok {
+			from = sel.From
+		}
+	}
+	if from == nil || from.TableRefs == nil {
+		return nil
+	}
+
+	var tables []queryBlockTable
+	var collect func(node ast.ResultSetNode)
+	collect = func(node ast.ResultSetNode) {
+		switch x := node.(type) {
+		case *ast.Join:
+			collect(x.Left)
+			if x.Right != nil {
+				collect(x.Right)
+			}
+		case *ast.TableSource:
+			tn, ok := x.Source.(*ast.TableName)
+			if !ok {
+				return
+			}
+			hintName := tn.Name.O
+			if x.AsName.L != "" {
+				hintName = x.AsName.O
+			} else if tn.Schema.L != "" {
+				hintName = tn.Schema.O + "." + tn.Name.O
+			}
+			tables = append(tables, queryBlockTable{tableName: tn, hintName: hintName})
+		}
+	}
+	collect(from.TableRefs)
+	return tables
 }
 
 // PlanPerfPredictor is used to score these plan candidates, returns their scores and gives some explanations.
@@ -889,12 +889,12 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: ast.NewCIStr(op.StrValue),
 			}
-		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval, ast.TableOptionTTLAction, ast.TableOptionTTLWhere:
 			if ttlOptionsHandled {
 				continue
 			}
 
-			ttlInfo, ttlEnable, ttlJobInterval, err := getTTLInfoInOptions(options)
+			ttlInfo, ttlEnable, ttlJobInterval, ttlAction, ttlCondition, err := getTTLInfoInOptions(options)
 			if err != nil {
 				return err
 			}
@@ -907,6 +907,12 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 				if ttlJobInterval != nil {
 					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 				}
+				if ttlAction != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+				}
+				if ttlCondition != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_WHERE"))
+				}
 			}
 
 			tbInfo.TTLInfo = ttlInfo
@@ -0,0 +1,451 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// maxEventIntervalDays is the max interval of a recurring event, it's far
+// beyond the range of DATETIME.
+const maxEventIntervalDays = 100 * 365
+
+// CreateEvent implements the DDL interface.
+func (e *executor) CreateEvent(ctx sessionctx.Context, stmt *ast.CreateEventStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.EventName.Schema)
+	}
+	event, err := buildEventInfo(ctx, schema, stmt)
+	if err != nil {
+		return err
+	}
+	past, err := isEventInThePast(ctx, event)
+	if err != nil {
+		return err
+	}
+	if past {
+		if !event.Preserve {
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrEventCannotCreateInThePast)
+			return nil
+		}
+		event.Status = ast.EventStatusDisable
+		ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrEventExecTimeInThePast)
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      event.Name.L,
+		Type:           model.ActionCreateEvent,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(ctx, job, &model.EventArgs{Event: event})
+	if meta.ErrEventExists.Equal(err) && stmt.IfNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// AlterEvent implements the DDL interface.
+func (e *executor) AlterEvent(ctx sessionctx.Context, stmt *ast.AlterEventStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.EventName.Schema)
+	}
+	snapshot := e.store.GetSnapshot(kv.MaxVersion)
+	origin, err := meta.NewReader(snapshot).GetEvent(schema.ID, stmt.EventName.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if origin == nil {
+		return meta.ErrEventNotExists.GenWithStackByArgs(stmt.EventName.Name)
+	}
+
+	event := origin.Clone()
+	if stmt.NewName != nil {
+		if stmt.NewName.Schema.L != stmt.EventName.Schema.L {
+			return dbterror.ErrNotSupportedYet.GenWithStackByArgs("moving an event to another schema")
+		}
+		if stmt.NewName.Name.L == stmt.EventName.Name.L {
+			return dbterror.ErrEventSameName
+		}
+		if err = checkTooLongTable(stmt.NewName.Name); err != nil {
+			return err
+		}
+		event.Name = stmt.NewName.Name
+	}
+	if stmt.Definer != nil {
+		event.Definer = stmt.Definer
+	}
+	// Like CREATE EVENT, the new schedule and body work with the session
+	// variables of ALTER EVENT.
+	vars := ctx.GetSessionVars()
+	if stmt.Schedule != nil {
+		event.TimeZone, _ = vars.GetSystemVar(vardef.TimeZone)
+		if err = buildEventSchedule(ctx, event, stmt.Schedule); err != nil {
+			return err
+		}
+	}
+	switch stmt.Completion {
+	case ast.EventCompletionPreserve:
+		event.Preserve = true
+	case ast.EventCompletionNotPreserve:
+		event.Preserve = false
+	}
+	if stmt.Status != ast.EventStatusUnspecified {
+		event.Status = stmt.Status
+	}
+	if stmt.Comment != nil {
+		event.Comment = *stmt.Comment
+	}
+	if stmt.Body != nil {
+		event.SQLMode = vars.SQLMode
+		event.Charset, _ = vars.GetSystemVar(vardef.CharacterSetClient)
+		event.Collate, _ = vars.GetSystemVar(vardef.CollationConnection)
+		if err = buildEventBody(ctx, schema, event, stmt.Body); err != nil {
+			return err
+		}
+	}
+	if stmt.Schedule != nil {
+		past, err := isEventInThePast(ctx, event)
+		if err != nil {
+			return err
+		}
+		if past {
+			if !event.Preserve {
+				ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrEventCannotAlterInThePast)
+				return nil
+			}
+			event.Status = ast.EventStatusDisable
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrEventExecTimeInThePast)
+		}
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      origin.Name.L,
+		Type:           model.ActionAlterEvent,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	return e.doDDLJob2(ctx, job, &model.EventArgs{Event: event, OldName: origin.Name})
+}
+
+// DropEvent implements the DDL interface.
+func (e *executor) DropEvent(ctx sessionctx.Context, stmt *ast.DropEventStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.EventName.Schema)
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      stmt.EventName.Name.L,
+		Type:           model.ActionDropEvent,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	args := &model.EventArgs{Event: &model.EventInfo{Name: stmt.EventName.Name}}
+	err := e.doDDLJob2(ctx, job, args)
+	if meta.ErrEventNotExists.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// buildEventInfo builds the event info from the CREATE EVENT statement, the
+// schedule is evaluated and the event body is validated as well.
+func buildEventInfo(sctx sessionctx.Context, schema *model.DBInfo, stmt *ast.CreateEventStmt) (*model.EventInfo, error) {
+	name := stmt.EventName.Name
+	if err := checkTooLongTable(name); err != nil {
+		return nil, err
+	}
+	vars := sctx.GetSessionVars()
+	event := &model.EventInfo{
+		Name:     name,
+		Definer:  stmt.Definer,
+		Status:   ast.EventStatusEnable,
+		Preserve: stmt.Completion == ast.EventCompletionPreserve,
+		SQLMode:  vars.SQLMode,
+	}
+	if stmt.Status != ast.EventStatusUnspecified {
+		event.Status = stmt.Status
+	}
+	if stmt.Comment != nil {
+		event.Comment = *stmt.Comment
+	}
+	event.TimeZone, _ = vars.GetSystemVar(vardef.TimeZone)
+	event.Charset, _ = vars.GetSystemVar(vardef.CharacterSetClient)
+	event.Collate, _ = vars.GetSystemVar(vardef.CollationConnection)
+	if err := buildEventSchedule(sctx, event, stmt.Schedule); err != nil {
+		return nil, err
+	}
+	if err := buildEventBody(sctx, schema, event, stmt.Body); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// buildEventSchedule evaluates the ON SCHEDULE clause in the session and sets
+// the schedule of the event.
+func buildEventSchedule(sctx sessionctx.Context, event *model.EventInfo, schedule *ast.EventSchedule) error {
+	event.ExecuteAt, event.Starts, event.Ends = time.Time{}, time.Time{}, time.Time{}
+	event.IntervalValue, event.IntervalField, event.Interval = "", 0, model.EventInterval{}
+	if schedule.At != nil {
+		at, err := evalEventTime(sctx, schedule.At)
+		if err != nil {
+			return err
+		}
+		event.ExecuteAt = at
+		return nil
+	}
+
+	if strings.Contains(schedule.Unit.String(), "MICROSECOND") {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("MICROSECOND")
+	}
+	v, err := expression.EvalSimpleAst(sctx.GetExprCtx(), schedule.Every)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v.IsNull() {
+		return dbterror.ErrEventIntervalNotPositiveOrTooBig
+	}
+	value, err := v.ToString()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	years, months, days, nanos, _, err := types.ParseDurationValue(schedule.Unit.String(), value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	months += years * 12
+	switch {
+	case months != 0:
+		if months < 0 || days != 0 || nanos != 0 || months > maxEventIntervalDays/30 {
+			return dbterror.ErrEventIntervalNotPositiveOrTooBig
+		}
+		event.Interval.Months = months
+	default:
+		if days < 0 || nanos < 0 || days+nanos == 0 || days > maxEventIntervalDays {
+			return dbterror.ErrEventIntervalNotPositiveOrTooBig
+		}
+		event.Interval.Duration = time.Duration(days)*24*time.Hour + time.Duration(nanos)
+	}
+	event.IntervalValue, event.IntervalField = value, schedule.Unit
+
+	// The event starts at once if STARTS is not specified.
+	if schedule.Starts != nil {
+		event.Starts, err = evalEventTime(sctx, schedule.Starts)
+	} else {
+		event.Starts, err = sctx.GetExprCtx().GetEvalCtx().CurrentTime()
+		event.Starts = event.Starts.UTC().Truncate(time.Second)
+	}
+	if err != nil {
+		return err
+	}
+	if schedule.Ends != nil {
+		if event.Ends, err = evalEventTime(sctx, schedule.Ends); err != nil {
+			return err
+		}
+		if event.Ends.Before(event.Starts) {
+			return dbterror.ErrEventEndsBeforeStarts
+		}
+	}
+	return nil
+}
+
+// evalEventTime evaluates the datetime expression in the time zone of the
+// session, the result is in UTC.
+func evalEventTime(sctx sessionctx.Context, expr ast.ExprNode) (time.Time, error) {
+	v, err := expression.EvalSimpleAst(sctx.GetExprCtx(), expr)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if v.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, "NULL")
+	}
+	tp := types.NewFieldType(mysql.TypeDatetime)
+	v, err = v.ConvertTo(sctx.GetSessionVars().StmtCtx.TypeCtx(), tp)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	t := v.GetMysqlTime()
+	if t.IsZero() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, t.String())
+	}
+	gt, err := t.AdjustedGoTime(sctx.GetSessionVars().Location())
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return gt.UTC(), nil
+}
+
+// isEventInThePast checks whether the event will never be executed, that's
+// either the execution time or the end of a recurring event is in the past.
+func isEventInThePast(sctx sessionctx.Context, event *model.EventInfo) (bool, error) {
+	now, err := sctx.GetExprCtx().GetEvalCtx().CurrentTime()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	now = now.Truncate(time.Second)
+	if !event.IsRecurring() {
+		return event.ExecuteAt.Before(now), nil
+	}
+	return !event.Ends.IsZero() && event.Ends.Before(now), nil
+}
+
+// buildEventBody validates the body of the event and sets its restored text.
+func buildEventBody(sctx sessionctx.Context, schema *model.DBInfo, event *model.EventInfo, body ast.StmtNode) error {
+	checker := &routineChecker{
+		isEvent: true,
+		buildType: func(tp *types.FieldType, colName string) error {
+			return buildRoutineVarType(NewMetaBuildContextWithSctx(sctx), schema, tp, colName)
+		},
+	}
+	checker.pushScope()
+	if err := checker.check(body); err != nil {
+		return err
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore the body despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := body.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+	event.Body = sb.String()
+	return nil
+}
+
+func onCreateEvent(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetEventArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	event := args.Event
+	event.Created = model.TSConvert2Time(job.StartTS).UTC()
+	event.LastAltered = event.Created
+	if err = jobCtx.metaMut.CreateEvent(job.SchemaID, event); err != nil {
+		if meta.ErrEventExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+	return ver, nil
+}
+
+func onAlterEvent(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetEventArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	origin, err := jobCtx.metaMut.GetEvent(job.SchemaID, args.OldName)
+	if err == nil && origin == nil {
+		err = meta.ErrEventNotExists.GenWithStackByArgs(args.OldName)
+	}
+	if err != nil {
+		if meta.ErrEventNotExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+
+	event := args.Event
+	event.ID, event.Created = origin.ID, origin.Created
+	event.LastAltered = model.TSConvert2Time(job.StartTS).UTC()
+	if event.Name.L == origin.Name.L {
+		err = jobCtx.metaMut.UpdateEvent(job.SchemaID, event)
+	} else {
+		err = jobCtx.metaMut.CreateEvent(job.SchemaID, event)
+		if err == nil {
+			err = jobCtx.metaMut.DropEvent(job.SchemaID, origin.Name)
+		}
+	}
+	if err != nil {
+		if meta.ErrEventExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+	return ver, nil
+}
+
+func onDropEvent(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetEventArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if err = jobCtx.metaMut.DropEvent(job.SchemaID, args.Event.Name); err != nil {
+		if meta.ErrEventNotExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	return ver, nil
+}
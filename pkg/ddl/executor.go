@@ -133,6 +133,16 @@ type Executor interface {
 	AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error
 	AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error
 	DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error
+	CreateRoutine(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error
+	DropRoutine(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	CreateEvent(ctx sessionctx.Context, stmt *ast.CreateEventStmt) error
+	AlterEvent(ctx sessionctx.Context, stmt *ast.AlterEventStmt) error
+	DropEvent(ctx sessionctx.Context, stmt *ast.DropEventStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
+	DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error
+	RefreshMaterializedView(ctx sessionctx.Context, ident ast.Ident, refreshTS uint64) error
 	FlashbackCluster(ctx sessionctx.Context, flashbackTS uint64) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
@@ -1027,6 +1037,9 @@ func (e *executor) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (
 	if err = checkTableInfoValidWithStmt(metaBuildCtx, tbInfo, s); err != nil {
 		return err
 	}
+	if err = checkTTLConditionPushDown(ctx, schema.Name, tbInfo); err != nil {
+		return err
+	}
 	if err = checkTableForeignKeysValid(ctx, is, schema.Name.L, tbInfo); err != nil {
 		return err
 	}
@@ -1099,6 +1112,8 @@ func (e *executor) createTableWithInfoJob(
 		actionType = model.ActionCreateView
 	case tbInfo.Sequence != nil:
 		actionType = model.ActionCreateSequence
+	case tbInfo.MaterializedView != nil:
+		actionType = model.ActionCreateMaterializedView
 	default:
 		actionType = model.ActionCreateTable
 	}
@@ -1670,6 +1685,7 @@ func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 		}
 		// TODO: Only allow REMOVE PARTITIONING as a single ALTER TABLE statement?
 	}
+	validSpecs = resolveExchangePartitionValidation(validSpecs)
 
 	// Verify whether the algorithm is supported.
 	for _, spec := range validSpecs {
@@ -1690,6 +1706,34 @@ func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 	return validSpecs, nil
 }
 
+// resolveExchangePartitionValidation applies the WITH VALIDATION and WITHOUT
+// VALIDATION specs to the EXCHANGE PARTITION spec of the same statement and
+// removes them, the last one takes effect. They are kept if there is no
+// EXCHANGE PARTITION spec.
+func resolveExchangePartitionValidation(specs []*ast.AlterTableSpec) []*ast.AlterTableSpec {
+	var exchange *ast.AlterTableSpec
+	for _, spec := range specs {
+		if spec.Tp == ast.AlterTableExchangePartition {
+			exchange = spec
+		}
+	}
+	if exchange == nil {
+		return specs
+	}
+	validSpecs := specs[:0]
+	for _, spec := range specs {
+		switch spec.Tp {
+		case ast.AlterTableWithValidation:
+			exchange.WithValidation = true
+		case ast.AlterTableWithoutValidation:
+			exchange.WithValidation = false
+		default:
+			validSpecs = append(validSpecs, spec)
+		}
+	}
+	return validSpecs
+}
+
 func isMultiSchemaChanges(specs []*ast.AlterTableSpec) bool {
 	if len(specs) > 1 {
 		return true
@@ -1715,6 +1759,9 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
 	}
+	if err = checkAlterTableWithMView(is, tb.Meta(), validSpecs); err != nil {
+		return err
+	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		if len(validSpecs) != 1 {
 			return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
@@ -1760,13 +1807,16 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 		case ast.AlterTableCheckPartitions:
 			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
 		case ast.AlterTableRebuildPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
+			err = e.RebuildPartitions(sctx, ident, spec)
 		case ast.AlterTableOptimizePartition:
 			err = errors.Trace(dbterror.ErrUnsupportedOptimizePartition)
 		case ast.AlterTableRemovePartitioning:
 			err = e.RemovePartitioning(sctx, ident, spec)
 		case ast.AlterTableRepairPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRepairPartition)
+			// The partitions are repaired by rebuilding them, the indexes are
+			// regenerated from the rows so the inconsistent index entries are
+			// fixed.
+			err = e.RebuildPartitions(sctx, ident, spec)
 		case ast.AlterTableDropColumn:
 			err = e.DropColumn(sctx, ident, spec)
 		case ast.AlterTableDropIndex:
@@ -1886,19 +1936,21 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 				case ast.TableOptionEngineAttribute:
 					err = dbterror.ErrUnsupportedEngineAttribute
 				case ast.TableOptionRowFormat:
-				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval, ast.TableOptionTTLAction, ast.TableOptionTTLWhere:
 					var ttlInfo *model.TTLInfo
 					var ttlEnable *bool
 					var ttlJobInterval *string
+					var ttlAction *model.TTLAction
+					var ttlCondition *string
 
 					if ttlOptionsHandled {
 						continue
 					}
-					ttlInfo, ttlEnable, ttlJobInterval, err = getTTLInfoInOptions(spec.Options)
+					ttlInfo, ttlEnable, ttlJobInterval, ttlAction, ttlCondition, err = getTTLInfoInOptions(spec.Options)
 					if err != nil {
 						return err
 					}
-					err = e.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
+					err = e.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval, ttlAction, ttlCondition)
 
 					ttlOptionsHandled = true
 				default:
@@ -2504,20 +2556,80 @@ func (e *executor) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident,
 	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
 		return errors.Trace(err)
 	}
+	return e.doReorganizePartitions(ctx, schema, meta, partNames, partInfo)
+}
+
+// RebuildPartitions rebuilds partitions by reorganizing them into the same
+// definitions, so the rows and the indexes of the partitions are rewritten by
+// the reorg backfill. For RANGE and LIST partitioning the partitions between
+// the specified ones are rebuilt too, and all the partitions are rebuilt for
+// HASH and KEY partitioning since the rows are routed by the number of
+// partitions. REPAIR PARTITION is executed by it as well.
+func (e *executor) RebuildPartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := e.getSchemaAndTableByIdent(ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	firstPartIdx, lastPartIdx := len(pi.Definitions)-1, 0
+	for _, name := range spec.PartitionNames {
+		partIdx := pi.FindPartitionDefinitionByName(name.L)
+		if partIdx == -1 {
+			return errors.Trace(dbterror.ErrWrongPartitionName)
+		}
+		firstPartIdx = min(firstPartIdx, partIdx)
+		lastPartIdx = max(lastPartIdx, partIdx)
+	}
+	switch pi.Type {
+	case ast.PartitionTypeRange, ast.PartitionTypeList:
+		if spec.OnAllPartitions {
+			firstPartIdx, lastPartIdx = 0, len(pi.Definitions)-1
+		}
+	case ast.PartitionTypeHash, ast.PartitionTypeKey:
+		firstPartIdx, lastPartIdx = 0, len(pi.Definitions)-1
+	default:
+		return errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
+	}
+
+	partNames := make([]string, 0, lastPartIdx-firstPartIdx+1)
+	partInfo := &model.PartitionInfo{
+		Type:    pi.Type,
+		Expr:    pi.Expr,
+		Columns: pi.Columns,
+		Enable:  pi.Enable,
+	}
+	for i := firstPartIdx; i <= lastPartIdx; i++ {
+		def := pi.Definitions[i].Clone()
+		// The ID is allocated when the job is submitted.
+		def.ID = 0
+		partNames = append(partNames, def.Name.L)
+		partInfo.Definitions = append(partInfo.Definitions, def)
+	}
+	partInfo.Num = uint64(len(partInfo.Definitions))
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+	return e.doReorganizePartitions(ctx, schema, meta, partNames, partInfo)
+}
 
+func (e *executor) doReorganizePartitions(ctx sessionctx.Context, schema *model.DBInfo, meta *model.TableInfo, partNames []string, partInfo *model.PartitionInfo) error {
 	job := &model.Job{
 		Version:        model.GetJobVerInUse(),
 		SchemaID:       schema.ID,
 		TableID:        meta.ID,
 		SchemaName:     schema.Name.L,
-		TableName:      t.Meta().Name.L,
+		TableName:      meta.Name.L,
 		Type:           model.ActionReorganizePartition,
 		BinlogInfo:     &model.HistoryInfo{},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 		SQLMode:        ctx.GetSessionVars().SQLMode,
 	}
-	err = initJobReorgMetaFromVariables(job, ctx)
-	if err != nil {
+	if err := initJobReorgMetaFromVariables(job, ctx); err != nil {
 		return errors.Trace(err)
 	}
 	args := &model.TablePartitionArgs{
@@ -2526,7 +2638,7 @@ func (e *executor) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident,
 	}
 
 	// No preSplitAndScatter here, it will be done by the worker in onReorganizePartition instead.
-	err = e.doDDLJob2(ctx, job, args)
+	err := e.doDDLJob2(ctx, job, args)
 	failpoint.InjectCall("afterReorganizePartition")
 	if err == nil {
 		ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("The statistics of related partitions will be outdated after reorganizing partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
@@ -3359,6 +3471,9 @@ func (e *executor) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *a
 	if oldColName.L == newColName.L {
 		return nil
 	}
+	if err = checkRenameColumnWithTTLConfig(tbl.Meta(), oldCol.Name, newColName); err != nil {
+		return err
+	}
 	if newColName.L == model.ExtraHandleName.L {
 		return dbterror.ErrWrongColumnName.GenWithStackByArgs(newColName.L)
 	}
@@ -3700,7 +3815,7 @@ func (e *executor) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast
 // When `ttlInfo` is nil, and `ttlCronJobSchedule` is not, it will use the original `.TTLInfo` in the table info and modify the
 // `.JobInterval`. If the `.TTLInfo` in the table info is empty, this function will return an error.
 // When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`.
-func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string) error {
+func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, ttlAction *model.TTLAction, ttlCondition *string) error {
 	is := e.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -3718,6 +3833,10 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 
 	var job *model.Job
 	if ttlInfo != nil {
+		if ttlCondition == nil && tblInfo.TTLInfo != nil {
+			// keep the original TTL_WHERE predicate, it should still be valid
+			ttlInfo.Condition = tblInfo.TTLInfo.Condition
+		}
 		tblInfo.TTLInfo = ttlInfo
 		err = checkTTLInfoValid(ident.Schema, tblInfo, is)
 		if err != nil {
@@ -3731,7 +3850,25 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 			if ttlCronJobSchedule != nil {
 				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 			}
+			if ttlAction != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+			}
+			if ttlCondition != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_WHERE"))
+			}
+		}
+		if err = checkTTLActionValid(ident.Schema, tblInfo, ttlAction, is); err != nil {
+			return err
 		}
+		if ttlCondition != nil {
+			tblInfo.TTLInfo.Condition = *ttlCondition
+			if err = checkTTLConditionValid(tblInfo); err != nil {
+				return err
+			}
+		}
+	}
+	if err = checkTTLConditionPushDown(ctx, ident.Schema, tblInfo); err != nil {
+		return err
 	}
 
 	job = &model.Job{
@@ -3749,6 +3886,8 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 		TTLInfo:            ttlInfo,
 		TTLEnable:          ttlEnable,
 		TTLCronJobSchedule: ttlCronJobSchedule,
+		TTLAction:          ttlAction,
+		TTLCondition:       ttlCondition,
 	}
 	err = e.doDDLJob2(ctx, job, args)
 	return errors.Trace(err)
@@ -4170,6 +4309,12 @@ func (e *executor) dropTableObject(
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
+			if tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "BASE TABLE")
+			}
+			if err = checkTableHasMViews(tableInfo.Meta(), "DROP TABLE"); err != nil {
+				return err
+			}
 
 			tempTableType := tableInfo.Meta().TempTableType
 			if config.CheckTableBeforeDrop && tempTableType == model.TempTableNone {
@@ -4270,6 +4415,12 @@ func (e *executor) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tblInfo.IsView() || tblInfo.IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tblInfo.Name.O)
 	}
+	if tblInfo.IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema.Name, tblInfo.Name, "BASE TABLE")
+	}
+	if err = checkTableHasMViews(tblInfo, "TRUNCATE TABLE"); err != nil {
+		return err
+	}
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
@@ -4350,6 +4501,10 @@ func (e *executor) renameTable(ctx sessionctx.Context, oldIdent, newIdent ast.Id
 		if err = dbutil.CheckTableModeIsNormal(tbl.Meta().Name, tbl.Meta().Mode); err != nil {
 			return err
 		}
+		// The triggers must be in the same schema with their table.
+		if len(tbl.Meta().Triggers) > 0 && schemas[0].ID != schemas[1].ID {
+			return dbterror.ErrTrgInWrongSchema
+		}
 	}
 
 	job := &model.Job{
@@ -4400,6 +4555,9 @@ func (e *executor) renameTables(ctx sessionctx.Context, oldIdents, newIdents []a
 			if err = dbutil.CheckTableModeIsNormal(t.Meta().Name, t.Meta().Mode); err != nil {
 				return err
 			}
+			if len(t.Meta().Triggers) > 0 && schemas[0].ID != schemas[1].ID {
+				return dbterror.ErrTrgInWrongSchema
+			}
 		}
 
 		infos = append(infos, &model.RenameTableArgs{
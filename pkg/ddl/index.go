@@ -121,9 +121,6 @@ func buildIndexColumns(ctx *metabuild.Context, columns []*model.ColumnInfo, inde
 			}
 		}
 		if col.FieldType.IsArray() {
-			if mvIndex {
-				return nil, false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("more than one multi-valued key part per index")
-			}
 			mvIndex = true
 		}
 		indexColLen := ip.Length
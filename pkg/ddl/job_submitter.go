@@ -415,7 +415,8 @@ func getRequiredGIDCount(jobWs []*JobWrapper) int {
 			continue
 		}
 		switch jobW.Type {
-		case model.ActionCreateView, model.ActionCreateSequence, model.ActionCreateTable:
+		case model.ActionCreateView, model.ActionCreateSequence, model.ActionCreateTable,
+			model.ActionCreateMaterializedView:
 			args := jobW.JobArgs.(*model.CreateTableArgs)
 			count += idCountForTable(args.TableInfo)
 		case model.ActionCreateTables:
@@ -423,7 +424,7 @@ func getRequiredGIDCount(jobWs []*JobWrapper) int {
 			for _, tblArgs := range args.Tables {
 				count += idCountForTable(tblArgs.TableInfo)
 			}
-		case model.ActionCreateSchema, model.ActionCreateResourceGroup:
+		case model.ActionCreateSchema, model.ActionCreateResourceGroup, model.ActionCreateEvent:
 			count++
 		case model.ActionAlterTablePartitioning:
 			args := jobW.JobArgs.(*model.TablePartitionArgs)
@@ -450,7 +451,8 @@ func assignGIDsForJobs(jobWs []*JobWrapper, ids []int64) {
 	alloc := &gidAllocator{ids: ids}
 	for _, jobW := range jobWs {
 		switch jobW.Type {
-		case model.ActionCreateView, model.ActionCreateSequence, model.ActionCreateTable:
+		case model.ActionCreateView, model.ActionCreateSequence, model.ActionCreateTable,
+			model.ActionCreateMaterializedView:
 			args := jobW.JobArgs.(*model.CreateTableArgs)
 			if !jobW.IDAllocated {
 				alloc.assignIDsForTable(args.TableInfo)
@@ -474,6 +476,11 @@ func assignGIDsForJobs(jobWs []*JobWrapper, ids []int64) {
 				args := jobW.JobArgs.(*model.ResourceGroupArgs)
 				args.RGInfo.ID = alloc.next()
 			}
+		case model.ActionCreateEvent:
+			if !jobW.IDAllocated {
+				args := jobW.JobArgs.(*model.EventArgs)
+				args.Event.ID = alloc.next()
+			}
 		case model.ActionAlterTablePartitioning:
 			if !jobW.IDAllocated {
 				args := jobW.JobArgs.(*model.TablePartitionArgs)
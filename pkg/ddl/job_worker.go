@@ -347,7 +347,7 @@ func JobNeedGC(job *model.Job) bool {
 			return false
 		}
 		switch job.Type {
-		case model.ActionDropSchema, model.ActionDropTable,
+		case model.ActionDropSchema, model.ActionDropTable, model.ActionDropMaterializedView,
 			model.ActionTruncateTable,
 			model.ActionDropPrimaryKey,
 			model.ActionDropTablePartition, model.ActionTruncateTablePartition,
@@ -937,7 +937,8 @@ func (w *worker) runOneJobStep(
 		ver, err = onRepairTable(jobCtx, job)
 	case model.ActionCreateView:
 		ver, err = onCreateView(jobCtx, job)
-	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence:
+	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence,
+		model.ActionDropMaterializedView:
 		ver, err = w.onDropTableOrView(jobCtx, job)
 	case model.ActionDropTablePartition:
 		ver, err = w.onDropTablePartition(jobCtx, job)
@@ -1025,6 +1026,24 @@ func (w *worker) runOneJobStep(
 		ver, err = onAlterResourceGroup(jobCtx, job)
 	case model.ActionDropResourceGroup:
 		ver, err = onDropResourceGroup(jobCtx, job)
+	case model.ActionCreateRoutine:
+		ver, err = onCreateRoutine(jobCtx, job)
+	case model.ActionDropRoutine:
+		ver, err = onDropRoutine(jobCtx, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(jobCtx, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(jobCtx, job)
+	case model.ActionCreateEvent:
+		ver, err = onCreateEvent(jobCtx, job)
+	case model.ActionAlterEvent:
+		ver, err = onAlterEvent(jobCtx, job)
+	case model.ActionDropEvent:
+		ver, err = onDropEvent(jobCtx, job)
+	case model.ActionCreateMaterializedView:
+		ver, err = w.onCreateMaterializedView(jobCtx, job)
+	case model.ActionRefreshMaterializedView:
+		ver, err = onRefreshMaterializedView(jobCtx, job)
 	case model.ActionAlterCacheTable:
 		ver, err = onAlterCacheTable(jobCtx, job)
 	case model.ActionAlterNoCacheTable:
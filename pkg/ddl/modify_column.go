@@ -946,6 +946,9 @@ func GetModifiableColumnJob(
 		if t.Meta().TTLInfo.ColumnName.L == originalColName.L && !types.IsTypeTime(newCol.ColumnInfo.FieldType.GetType()) {
 			return nil, errors.Trace(dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(newCol.ColumnInfo.Name.O))
 		}
+		if err = checkRenameColumnWithTTLConfig(t.Meta(), originalColName, newCol.Name); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	var newAutoRandBits uint64
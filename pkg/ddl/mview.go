@@ -0,0 +1,547 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl/notifier"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// mviewGroupIndexName is the name of the index built on the GROUP BY columns
+// of an incrementally refreshed materialized view.
+const mviewGroupIndexName = "mview_group"
+
+// mviewNonDeterministicFuncs are the functions whose results may change
+// without any change of the base table, a view calling them can't be refreshed
+// incrementally.
+var mviewNonDeterministicFuncs = map[string]struct{}{
+	ast.Now: {}, ast.CurrentTimestamp: {}, ast.Curdate: {}, ast.CurrentDate: {}, ast.Curtime: {},
+	ast.CurrentTime: {}, ast.Sysdate: {}, ast.UTCDate: {}, ast.UTCTime: {}, ast.UTCTimestamp: {},
+	ast.LocalTime: {}, ast.LocalTimestamp: {}, ast.UnixTimestamp: {}, ast.Rand: {}, ast.UUID: {},
+	ast.UUIDShort: {}, ast.Sleep: {}, ast.ConnectionID: {}, ast.CurrentUser: {}, ast.User: {},
+	ast.Database: {}, ast.GetVar: {}, ast.SetVar: {}, ast.NextVal: {}, ast.LastVal: {}, ast.SetVal: {},
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (e *executor) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.ViewName.Schema)
+	}
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("creating a materialized view in a system database")
+	}
+	if len(stmt.SchemaCols) != len(stmt.SchemaTypes) {
+		return dbterror.ErrViewWrongList
+	}
+
+	vars := ctx.GetSessionVars()
+	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := stmt.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+	mv := &model.MaterializedViewInfo{
+		Definition:    sb.String(),
+		RefreshMethod: stmt.RefreshMethod,
+		SQLMode:       vars.SQLMode,
+	}
+	mv.TimeZone, _ = vars.GetSystemVar(vardef.TimeZone)
+	mv.Charset, _ = vars.GetSystemVar(vardef.CharacterSetClient)
+	mv.Collate, _ = vars.GetSystemVar(vardef.CollationConnection)
+
+	var involvingRef []model.InvolvingSchemaInfo
+	base, groupBy := analyzeIncrementalMView(is, stmt)
+	if base != nil {
+		mv.BaseTableID, mv.GroupBy = base.Meta().ID, groupBy
+		dbInfo, _ := infoschema.SchemaByTable(is, base.Meta())
+		involvingRef = append(involvingRef, model.InvolvingSchemaInfo{
+			Database: dbInfo.Name.L,
+			Table:    base.Meta().Name.L,
+		})
+	} else if stmt.RefreshMethod == ast.MViewRefreshFast {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+			"REFRESH FAST on a materialized view which isn't an aggregation over a single table grouped by columns")
+	}
+
+	tbInfo, err := buildMViewTableInfo(ctx, stmt, mv)
+	if err != nil {
+		return err
+	}
+	tbInfo.MaterializedView = mv
+
+	onExist := OnExistError
+	if stmt.IfNotExists {
+		onExist = OnExistIgnore
+	}
+	return e.CreateTableWithInfo(ctx, schema.Name, tbInfo, involvingRef, WithOnExist(onExist))
+}
+
+// buildMViewTableInfo builds the table which stores the result of the
+// materialized view, the columns are typed after the output of the SELECT
+// statement.
+func buildMViewTableInfo(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt, mv *model.MaterializedViewInfo) (*model.TableInfo, error) {
+	cols := make([]*table.Column, len(stmt.SchemaCols))
+	for i, name := range stmt.SchemaCols {
+		cols[i] = table.ToColumn(&model.ColumnInfo{
+			Name:      name,
+			Offset:    i,
+			State:     model.StatePublic,
+			FieldType: *buildMViewColumnType(stmt.SchemaTypes[i]),
+		})
+	}
+
+	var constraints []*ast.Constraint
+	if len(mv.GroupBy) > 0 {
+		cons := &ast.Constraint{Tp: ast.ConstraintIndex, Name: mviewGroupIndexName}
+		for _, gb := range mv.GroupBy {
+			cons.Keys = append(cons.Keys, &ast.IndexPartSpecification{Column: &ast.ColumnName{Name: gb.ViewColumn}, Length: types.UnspecifiedLength})
+		}
+		for _, col := range cols {
+			// The index speeds up the incremental refresh, skip it if some
+			// columns can't be indexed.
+			if types.IsTypeBlob(col.GetType()) || col.GetType() == mysql.TypeJSON {
+				if slices.ContainsFunc(mv.GroupBy, func(gb model.MViewGroupByColumn) bool { return gb.ViewColumn.L == col.Name.L }) {
+					cons = nil
+					break
+				}
+			}
+		}
+		if cons != nil {
+			constraints = append(constraints, cons)
+		}
+	}
+
+	tblCharset, tblCollate := "", ""
+	if v, ok := ctx.GetSessionVars().GetSystemVar(vardef.CharacterSetConnection); ok {
+		tblCharset = v
+	}
+	if v, ok := ctx.GetSessionVars().GetSystemVar(vardef.CollationConnection); ok {
+		tblCollate = v
+	}
+	tbInfo, err := BuildTableInfo(NewMetaBuildContextWithSctx(ctx), stmt.ViewName.Name, cols, constraints, tblCharset, tblCollate)
+	if dbterror.ErrTooLongKey.Equal(err) && len(constraints) > 0 {
+		tbInfo, err = BuildTableInfo(NewMetaBuildContextWithSctx(ctx), stmt.ViewName.Name, cols, nil, tblCharset, tblCollate)
+	}
+	return tbInfo, err
+}
+
+// buildMViewColumnType converts the type of an output column of the SELECT
+// statement to the type of a table column.
+func buildMViewColumnType(origin *types.FieldType) *types.FieldType {
+	tp := origin.Clone()
+	tp.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag))
+	switch tp.GetType() {
+	case mysql.TypeNull:
+		tp = types.NewFieldType(mysql.TypeString)
+		tp.SetFlen(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		return tp
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString:
+		maxLen := 1
+		if cs, err := charset.GetCharsetInfo(tp.GetCharset()); err == nil {
+			maxLen = cs.Maxlen
+		}
+		switch {
+		case tp.GetFlen() == types.UnspecifiedLength || tp.GetFlen()*maxLen > mysql.MaxFieldVarCharLength:
+			tp.SetType(mysql.TypeLongBlob)
+			tp.SetFlen(types.UnspecifiedLength)
+		case tp.GetType() == mysql.TypeVarString || tp.GetFlen() > mysql.MaxFieldCharLength:
+			tp.SetType(mysql.TypeVarchar)
+		}
+		return tp
+	case mysql.TypeNewDecimal:
+		if tp.GetFlen() == types.UnspecifiedLength || tp.GetFlen() > mysql.MaxDecimalWidth {
+			tp.SetFlen(mysql.MaxDecimalWidth)
+		}
+		if tp.GetDecimal() == types.UnspecifiedLength || tp.GetDecimal() > mysql.MaxDecimalScale {
+			tp.SetDecimal(mysql.MaxDecimalScale)
+		}
+		return tp
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return tp
+}
+
+// analyzeIncrementalMView checks whether the materialized view is an
+// aggregation over a single table grouped by plain columns. If so, it returns
+// the base table and the mapping of the GROUP BY columns.
+func analyzeIncrementalMView(is infoschema.InfoSchema, stmt *ast.CreateMaterializedViewStmt) (table.Table, []model.MViewGroupByColumn) {
+	sel, ok := stmt.Select.(*ast.SelectStmt)
+	if !ok || sel.With != nil || sel.Distinct || sel.Limit != nil || len(sel.WindowSpecs) > 0 ||
+		sel.From == nil || sel.From.TableRefs.Right != nil || sel.GroupBy == nil || sel.GroupBy.Rollup {
+		return nil, nil
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		return nil, nil
+	}
+	if util.IsMemOrSysDB(tn.Schema.L) {
+		return nil, nil
+	}
+	base, err := is.TableByName(context.Background(), tn.Schema, tn.Name)
+	if err != nil {
+		return nil, nil
+	}
+	baseInfo := base.Meta()
+	if !baseInfo.IsBaseTable() || baseInfo.IsMaterializedView() || baseInfo.TempTableType != model.TempTableNone {
+		return nil, nil
+	}
+	checker := &mviewDeterministicChecker{}
+	sel.Accept(checker)
+	if checker.nonDeterministic {
+		return nil, nil
+	}
+
+	groupBy := make([]model.MViewGroupByColumn, 0, len(sel.GroupBy.Items))
+	for _, item := range sel.GroupBy.Items {
+		col, ok := item.Expr.(*ast.ColumnNameExpr)
+		if !ok || model.FindColumnInfo(baseInfo.Columns, col.Name.Name.L) == nil {
+			return nil, nil
+		}
+		// The GROUP BY column must be output as is, so the changed groups can be
+		// located in the view.
+		idx := slices.IndexFunc(sel.Fields.Fields, func(f *ast.SelectField) bool {
+			c, ok := f.Expr.(*ast.ColumnNameExpr)
+			return ok && c.Name.Name.L == col.Name.Name.L
+		})
+		if idx < 0 || idx >= len(stmt.SchemaCols) {
+			return nil, nil
+		}
+		groupBy = append(groupBy, model.MViewGroupByColumn{
+			BaseColumn: model.FindColumnInfo(baseInfo.Columns, col.Name.Name.L).Name,
+			ViewColumn: stmt.SchemaCols[idx],
+		})
+	}
+	return base, groupBy
+}
+
+// mviewDeterministicChecker checks whether the definition of a materialized
+// view only depends on the rows of the base table.
+type mviewDeterministicChecker struct {
+	nonDeterministic bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *mviewDeterministicChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.WindowFuncExpr, *ast.VariableExpr, *ast.DefaultExpr:
+		c.nonDeterministic = true
+	case *ast.FuncCallExpr:
+		if _, ok := mviewNonDeterministicFuncs[x.FnName.L]; ok {
+			c.nonDeterministic = true
+		}
+	}
+	return in, c.nonDeterministic
+}
+
+// Leave implements ast.Visitor interface.
+func (c *mviewDeterministicChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// DropMaterializedView implements the DDL interface.
+func (e *executor) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	is := e.infoCache.GetLatest()
+	ident := ast.Ident{Schema: stmt.ViewName.Schema, Name: stmt.ViewName.Name}
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return e.handleMViewNotExists(ctx, stmt.IfExists, ident)
+	}
+	tbl, err := is.TableByName(e.ctx, ident.Schema, ident.Name)
+	if infoschema.ErrTableNotExists.Equal(err) {
+		return e.handleMViewNotExists(ctx, stmt.IfExists, ident)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "MATERIALIZED VIEW")
+	}
+
+	var involvingSchemas []model.InvolvingSchemaInfo
+	if baseID := tbl.Meta().MaterializedView.BaseTableID; baseID != 0 {
+		if base, ok := is.TableInfoByID(baseID); ok {
+			if dbInfo, ok := is.SchemaByID(base.DBID); ok {
+				involvingSchemas = []model.InvolvingSchemaInfo{
+					{Database: schema.Name.L, Table: tbl.Meta().Name.L},
+					{Database: dbInfo.Name.L, Table: base.Name.L},
+				}
+			}
+		}
+	}
+	job := &model.Job{
+		Version:             model.GetJobVerInUse(),
+		SchemaID:            schema.ID,
+		TableID:             tbl.Meta().ID,
+		SchemaName:          schema.Name.L,
+		SchemaState:         schema.State,
+		TableName:           tbl.Meta().Name.L,
+		Type:                model.ActionDropMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      ctx.GetSessionVars().CDCWriteSource,
+		InvolvingSchemaInfo: involvingSchemas,
+		SQLMode:             ctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(ctx, job, &model.DropTableArgs{})
+	if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableNotExists.Equal(err) {
+		return e.handleMViewNotExists(ctx, stmt.IfExists, ident)
+	}
+	return errors.Trace(err)
+}
+
+func (*executor) handleMViewNotExists(ctx sessionctx.Context, ifExists bool, ident ast.Ident) error {
+	err := infoschema.ErrTableDropExists.FastGenByArgs(ident.String())
+	if ifExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// RefreshMaterializedView implements the DDL interface. The result of the view
+// is refreshed by the caller, it only records the snapshot TS reflected by the
+// result.
+func (e *executor) RefreshMaterializedView(ctx sessionctx.Context, ident ast.Ident, refreshTS uint64) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	tbl, err := is.TableByName(e.ctx, ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "MATERIALIZED VIEW")
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		TableID:        tbl.Meta().ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tbl.Meta().Name.L,
+		Type:           model.ActionRefreshMaterializedView,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	return errors.Trace(e.doDDLJob2(ctx, job, &model.RefreshMaterializedViewArgs{RefreshTS: refreshTS}))
+}
+
+// getMViewBaseTable gets the base table of the materialized view from the
+// meta, it returns nil if the base table doesn't exist anymore.
+func getMViewBaseTable(jobCtx *jobContext, mv *model.MaterializedViewInfo) (*schemaIDAndTableInfo, error) {
+	if mv == nil || mv.BaseTableID == 0 {
+		return nil, nil
+	}
+	info, ok := jobCtx.infoCache.GetLatest().TableInfoByID(mv.BaseTableID)
+	if !ok {
+		return nil, nil
+	}
+	base, err := jobCtx.metaMut.GetTable(info.DBID, mv.BaseTableID)
+	if err != nil || base == nil {
+		return nil, errors.Trace(err)
+	}
+	return &schemaIDAndTableInfo{schemaID: info.DBID, tblInfo: base}, nil
+}
+
+func (w *worker) onCreateMaterializedView(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetCreateTableArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	jobCtx.jobArgs = args
+
+	mv := args.TableInfo.MaterializedView
+	base, err := getMViewBaseTable(jobCtx, mv)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if mv.BaseTableID != 0 && base == nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTableNotExists.GenWithStackByArgs(job.SchemaName, fmt.Sprintf("(Table ID %d)", mv.BaseTableID))
+	}
+
+	tbInfo, err := createTable(jobCtx, job, args)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	var multiInfos []schemaIDAndTableInfo
+	if base != nil {
+		base.tblInfo.MaterializedViews = append(base.tblInfo.MaterializedViews, tbInfo.ID)
+		if err = updateTable(jobCtx.metaMut, base.schemaID, base.tblInfo, true); err != nil {
+			return ver, errors.Trace(err)
+		}
+		multiInfos = append(multiInfos, *base)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job, multiInfos...)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	createTableEvent := notifier.NewCreateTableEvent(tbInfo)
+	err = asyncNotifyEvent(jobCtx, createTableEvent, job, noSubJob, w.sess)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tbInfo)
+	return ver, nil
+}
+
+// unlinkMViewFromBaseTable removes the materialized view from its base table
+// when it's being dropped, the base table is returned to be updated.
+func unlinkMViewFromBaseTable(jobCtx *jobContext, tblInfo *model.TableInfo) ([]schemaIDAndTableInfo, error) {
+	base, err := getMViewBaseTable(jobCtx, tblInfo.MaterializedView)
+	if err != nil || base == nil {
+		return nil, errors.Trace(err)
+	}
+	base.tblInfo.MaterializedViews = slices.DeleteFunc(base.tblInfo.MaterializedViews, func(id int64) bool {
+		return id == tblInfo.ID
+	})
+	return []schemaIDAndTableInfo{*base}, nil
+}
+
+func onRefreshMaterializedView(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetRefreshMaterializedViewArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if !tblInfo.IsMaterializedView() {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+	// Refreshes may finish out of order, keep the latest one.
+	tblInfo.MaterializedView.LastRefreshTS = max(tblInfo.MaterializedView.LastRefreshTS, args.RefreshTS)
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// checkTableHasMViews returns an error if the table is the base table of
+// materialized views, it's used to reject the DDLs which change the data
+// without logging the changes for the views.
+func checkTableHasMViews(tblInfo *model.TableInfo, op string) error {
+	if len(tblInfo.MaterializedViews) > 0 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(op + " on a table referenced by materialized views")
+	}
+	return nil
+}
+
+// checkColumnInMViewGroupBy returns an error if the column is a GROUP BY
+// column of an incrementally refreshed materialized view on the table, the
+// changes of the table can't be logged for the view without the column.
+func checkColumnInMViewGroupBy(is infoschema.InfoSchema, tblInfo *model.TableInfo, colName ast.CIStr, op string) error {
+	for _, id := range tblInfo.MaterializedViews {
+		mvInfo, ok := is.TableInfoByID(id)
+		if !ok || !mvInfo.IsMaterializedView() || !mvInfo.MaterializedView.IsIncremental() {
+			continue
+		}
+		for _, gb := range mvInfo.MaterializedView.GroupBy {
+			if gb.BaseColumn.L == colName.L {
+				return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("%s on column '%s' referenced by the GROUP BY of materialized view '%s'", op, colName.O, mvInfo.Name.O))
+			}
+		}
+	}
+	return nil
+}
+
+// checkAlterTableWithMView checks the ALTER TABLE statement on a materialized
+// view or on a base table of materialized views. Only the indexes and the
+// TiFlash replica of a materialized view can be altered since its columns are
+// decided by the definition.
+func checkAlterTableWithMView(is infoschema.InfoSchema, tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) error {
+	for _, spec := range specs {
+		var err error
+		switch spec.Tp {
+		case ast.AlterTableDropColumn:
+			err = checkColumnInMViewGroupBy(is, tblInfo, spec.OldColumnName.Name, "DROP COLUMN")
+		case ast.AlterTableRenameColumn:
+			err = checkColumnInMViewGroupBy(is, tblInfo, spec.OldColumnName.Name, "RENAME COLUMN")
+		case ast.AlterTableChangeColumn:
+			if spec.OldColumnName.Name.L != spec.NewColumns[0].Name.Name.L {
+				err = checkColumnInMViewGroupBy(is, tblInfo, spec.OldColumnName.Name, "CHANGE COLUMN")
+			}
+		case ast.AlterTableTruncatePartition:
+			err = checkTableHasMViews(tblInfo, "TRUNCATE PARTITION")
+		case ast.AlterTableDropPartition:
+			err = checkTableHasMViews(tblInfo, "DROP PARTITION")
+		case ast.AlterTableExchangePartition:
+			err = checkTableHasMViews(tblInfo, "EXCHANGE PARTITION")
+		}
+		if err != nil {
+			return err
+		}
+		if !tblInfo.IsMaterializedView() {
+			continue
+		}
+		switch spec.Tp {
+		case ast.AlterTableDropIndex, ast.AlterTableRenameIndex, ast.AlterTableIndexInvisible,
+			ast.AlterTableSetTiFlashReplica:
+		case ast.AlterTableAddConstraint:
+			switch spec.Constraint.Tp {
+			case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			default:
+				return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("this ALTER TABLE on a materialized view")
+			}
+		default:
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("this ALTER TABLE on a materialized view")
+		}
+	}
+	return nil
+}
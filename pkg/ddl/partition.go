@@ -61,6 +61,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/hack"
 	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
 	"github.com/pingcap/tidb/pkg/util/slice"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/tikv/client-go/v2/tikv"
 	kvutil "github.com/tikv/client-go/v2/util"
@@ -4195,24 +4196,62 @@ func bundlesForExchangeTablePartition(t *meta.Mutator, pt *model.TableInfo, newP
 	return bundles, nil
 }
 
+// checkExchangePartitionRecordValidation checks that all the rows of the
+// non-partitioned table belong to the partition, and the rows of the
+// partition satisfy the check constraints of the non-partitioned table. Each
+// check is a `SELECT 1 ... WHERE <mismatch> LIMIT 1` statement, the condition
+// is evaluated by the coprocessor of TiKV, or of TiFlash if only TiFlash
+// supports it, so the rows are filtered by the storage nodes and only the first
+// mismatched row, if any, is returned to TiDB. If the condition can't be pushed
+// down at all, e.g. the partition expression uses TO_DAYS, the exchange is
+// rejected rather than scanning the whole table in TiDB, and WITHOUT VALIDATION
+// should be used instead.
 func checkExchangePartitionRecordValidation(
 	ctx context.Context,
 	w *worker,
 	ptbl, ntbl table.Table,
 	pschemaName, nschemaName, partitionName string,
 ) error {
-	verifyFunc := func(sql string, params ...any) error {
+	verifyFunc := func(schemaName string, tblInfo *model.TableInfo, partitionName, cond string, params ...any) error {
 		sctx, err := w.sessPool.Get()
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer w.sessPool.Put(sctx)
 
+		var buf strings.Builder
+		buf.WriteString("select ")
+		paramList := make([]any, 0, len(params)+4)
+		storeType := exchangePartitionCheckStoreType(sctx, schemaName, tblInfo, cond, params)
+		switch storeType {
+		case kv.TiKV:
+		case kv.TiFlash:
+			buf.WriteString("/*+ read_from_storage(tiflash[%n]) */ ")
+			paramList = append(paramList, tblInfo.Name.L)
+		default:
+			logutil.DDLLogger().Warn("exchange partition validation can't be pushed down",
+				zap.String("table", tblInfo.Name.O), zap.String("condition", cond))
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf(
+				"EXCHANGE PARTITION WITH VALIDATION since checking the rows of table `%s` can't be pushed down to the storage, use WITHOUT VALIDATION instead",
+				tblInfo.Name.O))
+		}
+		buf.WriteString("1 from %n.%n ")
+		paramList = append(paramList, schemaName, tblInfo.Name.L)
+		if partitionName != "" {
+			buf.WriteString("partition(%n) ")
+			paramList = append(paramList, partitionName)
+		}
+		buf.WriteString("where ")
+		buf.WriteString(cond)
+		buf.WriteString(" limit 1")
+		paramList = append(paramList, params...)
+		failpoint.InjectCall("exchangePartitionValidationSQL", buf.String(), paramList, storeType)
+
 		rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(
 			ctx,
 			nil,
-			sql,
-			params...,
+			buf.String(),
+			paramList...,
 		)
 		if err != nil {
 			return errors.Trace(err)
@@ -4248,8 +4287,7 @@ func checkExchangePartitionRecordValidation(
 	}
 
 	var buf strings.Builder
-	buf.WriteString("select 1 from %n.%n where ")
-	paramList := []any{nschemaName, ntbl.Meta().Name.L}
+	var paramList []any
 	checkNt := true
 
 	pi := pt.Partition
@@ -4316,8 +4354,7 @@ func checkExchangePartitionRecordValidation(
 	}
 	// Check non-partition table records.
 	if checkNt {
-		buf.WriteString(" limit 1")
-		err = verifyFunc(buf.String(), paramList...)
+		err = verifyFunc(nschemaName, ntbl.Meta(), "", buf.String(), paramList...)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -4331,11 +4368,7 @@ func checkExchangePartitionRecordValidation(
 		}
 		nCons := ncc.WritableConstraint()
 		if len(nCons) > 0 {
-			buf.Reset()
-			buf.WriteString("select 1 from %n.%n partition(%n) where ")
-			buf.WriteString(genConstraintCondition(nCons))
-			buf.WriteString(" limit 1")
-			err = verifyFunc(buf.String(), pschemaName, pt.Name.L, partitionName)
+			err = verifyFunc(pschemaName, pt, partitionName, genConstraintCondition(nCons))
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -4344,6 +4377,34 @@ func checkExchangePartitionRecordValidation(
 	return nil
 }
 
+// exchangePartitionCheckStoreType returns the storage engine which the
+// validation condition can be pushed down to. TiKV is preferred, TiFlash is
+// used if the condition can only be evaluated by TiFlash and the table has an
+// available TiFlash replica. It returns kv.TiDB if the condition can't be
+// pushed down.
+func exchangePartitionCheckStoreType(sctx sessionctx.Context, schemaName string, tblInfo *model.TableInfo, cond string, params []any) kv.StoreType {
+	condStr, err := sqlescape.EscapeSQL(cond, params...)
+	if err != nil {
+		return kv.TiKV
+	}
+	exprCtx := sctx.GetExprCtx()
+	expr, err := expression.ParseSimpleExpr(exprCtx, condStr, expression.WithTableInfo(schemaName, tblInfo))
+	if err != nil {
+		// Let the planner decide how to execute the check.
+		return kv.TiKV
+	}
+	pushDownCtx := expression.NewPushDownContextFromSessionVars(exprCtx.GetEvalCtx(), sctx.GetSessionVars(), sctx.GetStore().GetClient())
+	exprs := []expression.Expression{expr}
+	if expression.CanExprsPushDown(pushDownCtx, exprs, kv.TiKV) {
+		return kv.TiKV
+	}
+	if tblInfo.TiFlashReplica != nil && tblInfo.TiFlashReplica.Available &&
+		expression.CanExprsPushDown(pushDownCtx, exprs, kv.TiFlash) {
+		return kv.TiFlash
+	}
+	return kv.TiDB
+}
+
 func checkExchangePartitionPlacementPolicy(t *meta.Mutator, ntPPRef, ptPPRef, partPPRef *model.PolicyRefInfo) error {
 	partitionPPRef := partPPRef
 	if partitionPPRef == nil {
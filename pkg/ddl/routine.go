@@ -0,0 +1,522 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/metabuild"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// CreateRoutine implements the DDL interface.
+func (e *executor) CreateRoutine(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.ProcedureName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.ProcedureName.Schema)
+	}
+	routine, err := BuildRoutineInfo(NewMetaBuildContextWithSctx(ctx), ctx, schema, stmt)
+	if err != nil {
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      routine.Name.L,
+		Type:           model.ActionCreateRoutine,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(ctx, job, &model.RoutineArgs{Routine: routine})
+	if meta.ErrRoutineExists.Equal(err) && stmt.IfNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// DropRoutine implements the DDL interface.
+func (e *executor) DropRoutine(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.ProcedureName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.ProcedureName.Schema)
+	}
+	tp := model.RoutineTypeProcedure
+	if stmt.IsFunction {
+		tp = model.RoutineTypeFunction
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      stmt.ProcedureName.Name.L,
+		Type:           model.ActionDropRoutine,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	args := &model.RoutineArgs{Routine: &model.RoutineInfo{Name: stmt.ProcedureName.Name, Type: tp}}
+	err := e.doDDLJob2(ctx, job, args)
+	if meta.ErrRoutineNotExists.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// BuildRoutineInfo builds the stored routine info from the CREATE PROCEDURE or
+// CREATE FUNCTION statement, the routine body is validated as well.
+func BuildRoutineInfo(ctx *metabuild.Context, sctx sessionctx.Context, schema *model.DBInfo, stmt *ast.ProcedureInfo) (*model.RoutineInfo, error) {
+	name := stmt.ProcedureName.Name
+	if err := checkTooLongTable(name); err != nil {
+		return nil, err
+	}
+	routine := &model.RoutineInfo{
+		Name:     name,
+		Type:     model.RoutineTypeProcedure,
+		ParamStr: strings.TrimSpace(stmt.ProcedureParamStr),
+		Definer:  stmt.Definer,
+		Security: ast.SecurityDefiner,
+		SQLMode:  sctx.GetSessionVars().SQLMode,
+	}
+	if stmt.IsFunction {
+		routine.Type = model.RoutineTypeFunction
+	}
+	for _, c := range stmt.Characteristics {
+		switch c.Tp {
+		case ast.ProcedureCharacteristicComment:
+			routine.Comment = c.Comment
+		case ast.ProcedureCharacteristicDeterministic:
+			routine.Deterministic = c.Deterministic
+		case ast.ProcedureCharacteristicSQLSecurity:
+			routine.Security = c.Security
+		case ast.ProcedureCharacteristicDataAccess:
+			routine.DataAccess = c.DataAccess
+		}
+	}
+	// The routines always run with the privileges of the invoker. SQL SECURITY
+	// DEFINER, which is also the default when the clause is omitted, is rejected
+	// rather than ignored, the routines relying on the privileges of the definer
+	// would behave differently.
+	if routine.Security != ast.SecurityInvoker {
+		return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SQL SECURITY DEFINER for stored routines, specify SQL SECURITY INVOKER explicitly")
+	}
+	vars := sctx.GetSessionVars()
+	routine.Charset, _ = vars.GetSystemVar(vardef.CharacterSetClient)
+	routine.Collate, _ = vars.GetSystemVar(vardef.CollationConnection)
+
+	buildType := func(tp *types.FieldType, colName string) error {
+		return buildRoutineVarType(ctx, schema, tp, colName)
+	}
+
+	params := make(map[string]struct{}, len(stmt.ProcedureParam))
+	for _, p := range stmt.ProcedureParam {
+		paramName := ast.NewCIStr(p.ParamName)
+		if _, ok := params[paramName.L]; ok {
+			return nil, dbterror.ErrSpDupParam.GenWithStackByArgs(p.ParamName)
+		}
+		params[paramName.L] = struct{}{}
+		tp := p.ParamType.Clone()
+		if err := buildType(tp, p.ParamName); err != nil {
+			return nil, err
+		}
+		routine.Params = append(routine.Params, &model.RoutineParam{Name: paramName, Mode: p.Paramstatus, Type: tp})
+	}
+	if stmt.IsFunction {
+		routine.ReturnType = stmt.ReturnType.Clone()
+		if err := buildType(routine.ReturnType, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	checker := &routineChecker{isFunction: stmt.IsFunction, buildType: buildType}
+	checker.pushScope()
+	for p := range params {
+		checker.scopes[0].vars[p] = struct{}{}
+	}
+	if err := checker.check(stmt.ProcedureBody); err != nil {
+		return nil, err
+	}
+	if stmt.IsFunction && !checker.hasReturn {
+		return nil, dbterror.ErrSpNoreturn.GenWithStackByArgs(name.O)
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore the body despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := stmt.ProcedureBody.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	routine.Body = sb.String()
+	return routine, nil
+}
+
+// buildRoutineVarType fills the charset, collation and length of the type of a
+// parameter or a local variable, the default charset is the one of the schema.
+func buildRoutineVarType(ctx *metabuild.Context, schema *model.DBInfo, tp *types.FieldType, colName string) error {
+	chs, coll := tp.GetCharset(), tp.GetCollate()
+	if chs == "" {
+		chs, coll = schema.Charset, schema.Collate
+		if chs == "" {
+			chs, coll = charset.GetDefaultCharsetAndCollate()
+		}
+	} else if coll == "" {
+		var err error
+		if coll, err = charset.GetDefaultCollation(chs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return setCharsetCollationFlenDecimal(ctx, tp, colName, chs, coll)
+}
+
+type routineScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+type routineLabel struct {
+	name   string
+	isLoop bool
+}
+
+// routineChecker does the static checks of a stored routine body which MySQL
+// reports at creation time.
+type routineChecker struct {
+	isFunction bool
+	isEvent    bool
+	hasReturn  bool
+	// trigger and tblInfo are set when checking the body of a trigger, the
+	// NEW and OLD rows are checked against the subject table.
+	trigger *model.TriggerInfo
+	tblInfo *model.TableInfo
+	scopes  []*routineScope
+	labels  []routineLabel
+	// buildType fills the charset, collation and length of the local variable
+	// types, so that the restored body keeps them.
+	buildType func(tp *types.FieldType, colName string) error
+}
+
+func (c *routineChecker) pushScope() {
+	c.scopes = append(c.scopes, &routineScope{vars: make(map[string]struct{}), cursors: make(map[string]struct{})})
+}
+
+func (c *routineChecker) popScope() {
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+func (c *routineChecker) hasVar(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range c.scopes {
+		if _, ok := s.vars[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *routineChecker) hasCursor(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range c.scopes {
+		if _, ok := s.cursors[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *routineChecker) pushLabel(name string, isLoop bool) error {
+	for _, l := range c.labels {
+		if strings.EqualFold(l.name, name) {
+			return dbterror.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, routineLabel{name: name, isLoop: isLoop})
+	return nil
+}
+
+func (c *routineChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.check(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *routineChecker) checkBlock(block *ast.ProcedureBlock) error {
+	c.pushScope()
+	defer c.popScope()
+	scope := c.scopes[len(c.scopes)-1]
+	hasCursor, hasHandler := false, false
+	for _, decl := range block.ProcedureVars {
+		if _, ok := decl.(*ast.ProcedureDecl); !ok && c.isFunction {
+			return dbterror.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored functions")
+		}
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			if hasCursor || hasHandler {
+				return dbterror.ErrSpVarcondAfterCurshndlr
+			}
+			if err := c.buildType(x.DeclType, x.DeclNames[0]); err != nil {
+				return err
+			}
+			if err := c.checkTriggerExpr(x.DeclDefault); err != nil {
+				return err
+			}
+			for _, name := range x.DeclNames {
+				lower := strings.ToLower(name)
+				if _, ok := scope.vars[lower]; ok {
+					return dbterror.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[lower] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if hasHandler {
+				return dbterror.ErrSpCursorAfterHandler
+			}
+			hasCursor = true
+			lower := strings.ToLower(x.CurName)
+			if _, ok := scope.cursors[lower]; ok {
+				return dbterror.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			switch sel := x.Selectstring.(type) {
+			case *ast.SelectStmt:
+				if sel.SelectIntoOpt != nil {
+					return dbterror.ErrSpBadCursorSelect
+				}
+			case *ast.SetOprStmt:
+			default:
+				return dbterror.ErrSpBadCursorQuery
+			}
+			if err := c.checkTriggerExpr(x.Selectstring); err != nil {
+				return err
+			}
+			scope.cursors[lower] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			hasHandler = true
+			if err := c.check(x.Operate); err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *routineChecker) checkIf(block *ast.ProcedureIfBlock) error {
+	if err := c.checkTriggerExpr(block.IfExpr); err != nil {
+		return err
+	}
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIf(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *routineChecker) check(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		if x.LabelError {
+			return dbterror.ErrSpLabelMismatch.GenWithStackByArgs(x.LabelEnd)
+		}
+		if err := c.pushLabel(x.LabelName, false); err != nil {
+			return err
+		}
+		defer func() { c.labels = c.labels[:len(c.labels)-1] }()
+		return c.checkBlock(x.Block)
+	case *ast.ProcedureLabelLoop:
+		if x.LabelError {
+			return dbterror.ErrSpLabelMismatch.GenWithStackByArgs(x.LabelEnd)
+		}
+		if err := c.pushLabel(x.LabelName, true); err != nil {
+			return err
+		}
+		defer func() { c.labels = c.labels[:len(c.labels)-1] }()
+		return c.check(x.Block)
+	case *ast.ProcedureJump:
+		tp := "ITERATE"
+		if x.IsLeave {
+			tp = "LEAVE"
+		}
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			if strings.EqualFold(c.labels[i].name, x.Name) {
+				if !x.IsLeave && !c.labels[i].isLoop {
+					break
+				}
+				return nil
+			}
+		}
+		return dbterror.ErrSpLilabelMismatch.GenWithStackByArgs(tp, x.Name)
+	case *ast.ProcedureIfInfo:
+		return c.checkIf(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		if err := c.checkTriggerExpr(x.Condition); err != nil {
+			return err
+		}
+		for _, w := range x.WhenCases {
+			if err := c.checkTriggerExpr(w.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(w.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, w := range x.WhenCases {
+			if err := c.checkTriggerExpr(w.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(w.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		if err := c.checkTriggerExpr(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		if err := c.checkTriggerExpr(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, v := range x.Variables {
+			if !c.hasVar(v) {
+				return dbterror.ErrSpUndeclaredVar.GenWithStackByArgs(v)
+			}
+		}
+		return nil
+	case *ast.ProcedureReturn:
+		if !c.isFunction {
+			return dbterror.ErrSpBadreturn
+		}
+		c.hasReturn = true
+		return nil
+	case *ast.ProcedureInfo:
+		tp := "PROCEDURE"
+		if x.IsFunction {
+			tp = "FUNCTION"
+		}
+		return dbterror.ErrSpNoRecursiveCreate.GenWithStackByArgs(tp)
+	case *ast.CreateEventStmt:
+		if c.isEvent {
+			return dbterror.ErrEventRecursionForbidden
+		}
+	case *ast.AlterEventStmt:
+		if c.isEvent && x.Body != nil {
+			return dbterror.ErrEventRecursionForbidden
+		}
+	case *ast.SetStmt:
+		if c.trigger != nil {
+			return c.checkTriggerStmt(x)
+		}
+		return nil
+	}
+	if c.trigger != nil {
+		return c.checkTriggerStmt(stmt)
+	}
+	if c.isFunction {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("SQL statements other than SET in stored functions")
+	}
+	return nil
+}
+
+func (c *routineChecker) checkCursor(name string) error {
+	if !c.hasCursor(name) {
+		return dbterror.ErrSpCursorMismatch.GenWithStackByArgs(name)
+	}
+	return nil
+}
+
+func onCreateRoutine(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetRoutineArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	routine := args.Routine
+	routine.Created = model.TSConvert2Time(job.StartTS)
+	routine.LastAlter = routine.Created
+	if err = jobCtx.metaMut.CreateRoutine(job.SchemaID, routine); err != nil {
+		if meta.ErrRoutineExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+	return ver, nil
+}
+
+func onDropRoutine(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetRoutineArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	routine := args.Routine
+	if err = jobCtx.metaMut.DropRoutine(job.SchemaID, routine.Type, routine.Name); err != nil {
+		if meta.ErrRoutineNotExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(jobCtx, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	return ver, nil
+}
@@ -473,6 +473,66 @@ func (*Checker) AlterResourceGroup(_ sessionctx.Context, _ *ast.AlterResourceGro
 	return nil
 }
 
+// CreateRoutine implements the DDL interface.
+func (*Checker) CreateRoutine(_ sessionctx.Context, _ *ast.ProcedureInfo) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropRoutine implements the DDL interface.
+func (*Checker) DropRoutine(_ sessionctx.Context, _ *ast.DropProcedureStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// CreateTrigger implements the DDL interface.
+func (*Checker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropTrigger implements the DDL interface.
+func (*Checker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// CreateEvent implements the DDL interface.
+func (*Checker) CreateEvent(_ sessionctx.Context, _ *ast.CreateEventStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// AlterEvent implements the DDL interface.
+func (*Checker) AlterEvent(_ sessionctx.Context, _ *ast.AlterEventStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropEvent implements the DDL interface.
+func (*Checker) DropEvent(_ sessionctx.Context, _ *ast.DropEventStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (*Checker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// DropMaterializedView implements the DDL interface.
+func (*Checker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+// RefreshMaterializedView implements the DDL interface.
+func (*Checker) RefreshMaterializedView(_ sessionctx.Context, _ ast.Ident, _ uint64) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 // CreateSchemaWithInfo implements the DDL interface.
 func (d *Checker) CreateSchemaWithInfo(ctx sessionctx.Context, info *model.DBInfo, onExist ddl.OnExist) error {
 	err := d.realExecutor.CreateSchemaWithInfo(ctx, info, onExist)
@@ -1183,6 +1183,56 @@ func (*SchemaTracker) AlterResourceGroup(_ sessionctx.Context, _ *ast.AlterResou
 	return nil
 }
 
+// CreateRoutine implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateRoutine(_ sessionctx.Context, _ *ast.ProcedureInfo) error {
+	return nil
+}
+
+// DropRoutine implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropRoutine(_ sessionctx.Context, _ *ast.DropProcedureStmt) error {
+	return nil
+}
+
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// CreateEvent implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateEvent(_ sessionctx.Context, _ *ast.CreateEventStmt) error {
+	return nil
+}
+
+// AlterEvent implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) AlterEvent(_ sessionctx.Context, _ *ast.AlterEventStmt) error {
+	return nil
+}
+
+// DropEvent implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropEvent(_ sessionctx.Context, _ *ast.DropEventStmt) error {
+	return nil
+}
+
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
+// RefreshMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) RefreshMaterializedView(_ sessionctx.Context, _ ast.Ident, _ uint64) error {
+	return nil
+}
+
 // BatchCreateTableWithInfo implements the DDL interface, it will call CreateTableWithInfo for each table.
 func (d *SchemaTracker) BatchCreateTableWithInfo(ctx sessionctx.Context, schema ast.CIStr, info []*model.TableInfo, cs ...ddl.CreateTableOption) error {
 	for _, tableInfo := range info {
@@ -79,8 +79,16 @@ func (w *worker) onDropTableOrView(jobCtx *jobContext, job *model.Job) (ver int6
 				return ver, err
 			}
 		}
+		var multiInfos []schemaIDAndTableInfo
+		if job.Type == model.ActionDropMaterializedView {
+			// Changes of the base table aren't logged for the view anymore.
+			multiInfos, err = unlinkMViewFromBaseTable(jobCtx, tblInfo)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
 		tblInfo.State = model.StateWriteOnly
-		ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, originalState != tblInfo.State)
+		ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, originalState != tblInfo.State, multiInfos...)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
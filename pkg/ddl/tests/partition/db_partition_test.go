@@ -3759,3 +3759,71 @@ func TestTruncateNumberOfPhases(t *testing.T) {
 	dom.Reload()
 	require.Equal(t, int64(4), dom.InfoSchema().SchemaMetaVersion()-schemaVersion)
 }
+
+func TestRebuildAndOptimizePartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int primary key nonclustered, b int, index idx(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30))`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21)")
+	getPartitionIDs := func() []int64 {
+		tbl := external.GetTableByName(t, tk, "test", "t")
+		ids := make([]int64, 0, 3)
+		for _, def := range tbl.Meta().Partition.Definitions {
+			ids = append(ids, def.ID)
+		}
+		return ids
+	}
+	oldIDs := getPartitionIDs()
+	tk.MustExec("alter table t rebuild partition p0, p2")
+	newIDs := getPartitionIDs()
+	// The partitions between are rebuilt as well.
+	for i := range oldIDs {
+		require.NotEqual(t, oldIDs[i], newIDs[i])
+	}
+	tk.MustQuery("select * from t partition(p1)").Check(testkit.Rows("11 11"))
+	tk.MustExec("admin check table t")
+	oldIDs = newIDs
+	tk.MustExec("alter table t rebuild partition p1")
+	newIDs = getPartitionIDs()
+	require.Equal(t, oldIDs[0], newIDs[0])
+	require.NotEqual(t, oldIDs[1], newIDs[1])
+	require.Equal(t, oldIDs[2], newIDs[2])
+	tk.MustExec("alter table t rebuild partition all")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  KEY `idx` (`b`),\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20),\n" +
+		" PARTITION `p2` VALUES LESS THAN (30))"))
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "11 11", "21 21"))
+	tk.MustExec("admin check table t")
+	tk.MustGetErrCode("alter table t rebuild partition p3", errno.ErrWrongPartitionName)
+
+	tk.MustExec("create table ht (a int, b int, index idx(b)) partition by hash (a) partitions 3")
+	tk.MustExec("insert into ht values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("alter table ht rebuild partition p1")
+	tk.MustQuery("select * from ht partition(p1)").Check(testkit.Rows("1 1"))
+	tk.MustExec("admin check table ht")
+
+	tk.MustExec("alter table t optimize partition p0, p1")
+	tk.MustExec("alter table t optimize partition all")
+	tk.MustGetErrCode("alter table t optimize partition p3", errno.ErrUnknownPartition)
+	tk.MustExec("create table nt (a int)")
+	tk.MustGetErrCode("alter table nt rebuild partition all", errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table nt optimize partition all", errno.ErrPartitionMgmtOnNonpartitioned)
+
+	// CHECK PARTITION and OPTIMIZE PARTITION can't be combined with other specs.
+	tk.MustGetErrMsg("alter table t add column c int, check partition p0", "[ddl:8200]Unsupported multi schema change for check partition")
+	tk.MustGetErrMsg("alter table t add column c int, optimize partition p0", "[ddl:8200]Unsupported multi schema change for optimize partition")
+	tk.MustGetErrCode("alter table t add column c int, rebuild partition p0", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add column c int, repair partition p0", errno.ErrUnsupportedDDLOperation)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "11 11", "21 21"))
+}
@@ -19,7 +19,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExchangeRangeColumnsPartition(t *testing.T) {
@@ -141,3 +145,73 @@ func TestExchangeRangeColumnsPartition(t *testing.T) {
 	// Clean up
 	tk.MustExec("DROP TABLE t1")
 }
+
+func TestExchangePartitionValidation(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	var checkSQLs []string
+	var checkStoreTypes []kv.StoreType
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/ddl/exchangePartitionValidationSQL", func(sql string, params []any, storeType kv.StoreType) {
+		checkSQLs = append(checkSQLs, sqlescape.MustEscapeSQL(sql, params...))
+		checkStoreTypes = append(checkStoreTypes, storeType)
+	})
+	tk.MustExec(`create table pt (id int, d datetime) partition by range (year(d)) (
+		partition p0 values less than (2025),
+		partition p1 values less than (maxvalue))`)
+	tk.MustExec("create table nt (id int, d datetime)")
+	tk.MustExec("insert into nt values (1, '2024-06-01'), (2, '2025-06-01')")
+	tk.MustContainErrMsg("alter table pt exchange partition p0 with table nt", "[ddl:1737]Found a row that does not match the partition")
+	require.Equal(t, []kv.StoreType{kv.TiKV}, checkStoreTypes)
+	tk.MustContainErrMsg("alter table pt exchange partition p0 with table nt with validation", "[ddl:1737]Found a row that does not match the partition")
+	tk.MustExec("alter table pt exchange partition p0 with table nt without validation")
+	tk.MustQuery("select id from pt partition(p0) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select count(*) from nt").Check(testkit.Rows("0"))
+
+	// WITH VALIDATION and WITHOUT VALIDATION apply to the EXCHANGE PARTITION of the same statement.
+	tk.MustExec("insert into nt values (3, '2024-06-01')")
+	tk.MustContainErrMsg("alter table pt exchange partition p1 with table nt, with validation", "[ddl:1737]Found a row that does not match the partition")
+	tk.MustExec("alter table pt exchange partition p1 with table nt, without validation")
+	tk.MustQuery("select id from pt partition(p1) order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from nt").Check(testkit.Rows("0"))
+
+	// to_days can't be pushed down to TiKV, the exchange is rejected instead of
+	// scanning the whole table in TiDB, even if all the rows match the partition.
+	tk.MustExec(`create table dpt (id int, d datetime) partition by range (to_days(d)) (
+		partition p0 values less than (to_days('2025-01-01')),
+		partition p1 values less than (maxvalue))`)
+	tk.MustExec("create table dnt (id int, d datetime)")
+	tk.MustExec("insert into dnt values (1, '2024-06-01')")
+	unsupportedErr := "[ddl:8200]Unsupported EXCHANGE PARTITION WITH VALIDATION since checking the rows of table `dnt` can't be pushed down to the storage, use WITHOUT VALIDATION instead"
+	tk.MustGetErrMsg("alter table dpt exchange partition p0 with table dnt", unsupportedErr)
+	tk.MustGetErrMsg("alter table dpt exchange partition p0 with table dnt with validation", unsupportedErr)
+	tk.MustQuery("select count(*) from dnt").Check(testkit.Rows("1"))
+	tk.MustExec("alter table dpt exchange partition p0 with table dnt without validation")
+	tk.MustQuery("select id from dpt partition(p0)").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from dnt").Check(testkit.Rows("0"))
+
+	// The pushed down check of a hash partitioned table.
+	tk.MustExec("create table ht (id int) partition by hash (id) partitions 4")
+	tk.MustExec("create table hnt (id int)")
+	tk.MustExec("insert into hnt values (1), (5), (null)")
+	checkSQLs, checkStoreTypes = nil, nil
+	tk.MustContainErrMsg("alter table ht exchange partition p1 with table hnt", "[ddl:1737]Found a row that does not match the partition")
+	require.Equal(t, []kv.StoreType{kv.TiKV}, checkStoreTypes)
+	// the mismatch condition is evaluated by the coprocessor, and only one row is returned to TiDB.
+	plan := tk.MustQuery("explain format = 'brief' " + checkSQLs[0]).Rows()
+	var pushedDown, limitPushedDown bool
+	for _, row := range plan {
+		op, task := fmt.Sprint(row[0]), fmt.Sprint(row[2])
+		pushedDown = pushedDown || (strings.Contains(op, "Selection") && task == "cop[tikv]")
+		limitPushedDown = limitPushedDown || (strings.Contains(op, "Limit") && task == "cop[tikv]")
+	}
+	require.True(t, pushedDown, "%v", plan)
+	require.True(t, limitPushedDown, "%v", plan)
+	tk.MustExec("delete from hnt where id is null")
+	tk.MustExec("alter table ht exchange partition p1 with table hnt")
+	tk.MustQuery("select id from ht order by id").Check(testkit.Rows("1", "5"))
+
+	tk.MustExec("alter table nt with validation")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 ALTER TABLE WITH VALIDATION is currently unsupported"))
+}
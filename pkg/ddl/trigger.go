@@ -0,0 +1,320 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/metabuild"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// CreateTrigger implements the DDL interface.
+func (e *executor) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	if stmt.TriggerName.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema
+	}
+	if util.IsMemOrSysDB(stmt.Table.Schema.L) {
+		return dbterror.ErrNoTriggersOnSystemSchema
+	}
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Table.Schema)
+	}
+	tb, err := is.TableByName(e.ctx, stmt.Table.Schema, stmt.Table.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(stmt.Table.Schema, stmt.Table.Name))
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tblInfo.Name.O)
+	}
+	if _, existing, err := infoschema.FindTriggerTable(e.ctx, is, schema.Name, stmt.TriggerName.Name); err != nil {
+		return errors.Trace(err)
+	} else if existing != nil {
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrTrgAlreadyExists)
+			return nil
+		}
+		return dbterror.ErrTrgAlreadyExists
+	}
+	trigger, err := BuildTriggerInfo(NewMetaBuildContextWithSctx(ctx), ctx, schema, tblInfo, stmt)
+	if err != nil {
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(ctx, job, &model.TriggerArgs{Trigger: trigger, Order: stmt.Order})
+	if dbterror.ErrTrgAlreadyExists.Equal(err) && stmt.IfNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DropTrigger implements the DDL interface.
+func (e *executor) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.TriggerName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.TriggerName.Schema)
+	}
+	tblInfo, _, err := infoschema.FindTriggerTable(e.ctx, is, schema.Name, stmt.TriggerName.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tblInfo == nil {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(dbterror.ErrTrgDoesNotExist)
+			return nil
+		}
+		return dbterror.ErrTrgDoesNotExist
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	args := &model.TriggerArgs{Trigger: &model.TriggerInfo{Name: stmt.TriggerName.Name}}
+	err = e.doDDLJob2(ctx, job, args)
+	if dbterror.ErrTrgDoesNotExist.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// BuildTriggerInfo builds the trigger info from the CREATE TRIGGER statement,
+// the trigger body is validated against the subject table as well.
+func BuildTriggerInfo(ctx *metabuild.Context, sctx sessionctx.Context, schema *model.DBInfo, tblInfo *model.TableInfo, stmt *ast.CreateTriggerStmt) (*model.TriggerInfo, error) {
+	name := stmt.TriggerName.Name
+	if err := checkTooLongTable(name); err != nil {
+		return nil, err
+	}
+	vars := sctx.GetSessionVars()
+	trigger := &model.TriggerInfo{
+		Name:    name,
+		Timing:  stmt.Timing,
+		Event:   stmt.Event,
+		Definer: stmt.Definer,
+		SQLMode: vars.SQLMode,
+	}
+	trigger.Charset, _ = vars.GetSystemVar(vardef.CharacterSetClient)
+	trigger.Collate, _ = vars.GetSystemVar(vardef.CollationConnection)
+
+	checker := &routineChecker{
+		trigger: trigger,
+		tblInfo: tblInfo,
+		buildType: func(tp *types.FieldType, colName string) error {
+			return buildRoutineVarType(ctx, schema, tp, colName)
+		},
+	}
+	checker.pushScope()
+	if err := checker.check(stmt.Body); err != nil {
+		return nil, err
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore the body despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := stmt.Body.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	trigger.Body = sb.String()
+	return trigger, nil
+}
+
+// checkTriggerRow checks the reference to the NEW or OLD row of a trigger.
+func (c *routineChecker) checkTriggerRow(row, colName string, isAssign bool) error {
+	row = strings.ToUpper(row)
+	if (row == "OLD" && c.trigger.Event == ast.TriggerInsert) || (row == "NEW" && c.trigger.Event == ast.TriggerDelete) {
+		return dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(row, c.trigger.Event.String())
+	}
+	col := model.FindColumnInfo(c.tblInfo.Columns, strings.ToLower(colName))
+	if col == nil {
+		return dbterror.ErrBadField.GenWithStackByArgs(colName, row)
+	}
+	if !isAssign {
+		return nil
+	}
+	if row == "OLD" {
+		return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs(row, "")
+	}
+	if c.trigger.Timing == ast.TriggerAfter {
+		return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs(row, "after ")
+	}
+	if col.IsGenerated() {
+		return plannererrors.ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, c.tblInfo.Name.O)
+	}
+	return nil
+}
+
+// checkTriggerStmt checks a simple statement in a trigger body.
+func (c *routineChecker) checkTriggerStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt == nil || x.SelectIntoOpt.Tp != ast.SelectIntoVars {
+			return dbterror.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+	case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return dbterror.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt,
+		*ast.LoadDataStmt, *ast.ImportIntoStmt:
+		return dbterror.ErrCommitNotAllowedInSfOrTrg
+	case *ast.SetStmt:
+		for _, v := range x.Variables {
+			if !v.IsSystem || v.IsGlobal {
+				continue
+			}
+			row, colName, ok := strings.Cut(v.Name, ".")
+			if !ok {
+				continue
+			}
+			if strings.EqualFold(row, "new") || strings.EqualFold(row, "old") {
+				if err := c.checkTriggerRow(row, colName, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return c.checkTriggerExpr(stmt)
+}
+
+// checkTriggerExpr checks the NEW and OLD columns referenced by the node.
+func (c *routineChecker) checkTriggerExpr(node ast.Node) error {
+	if c.trigger == nil || node == nil {
+		return nil
+	}
+	v := &triggerRowVisitor{c: c}
+	node.Accept(v)
+	return v.err
+}
+
+type triggerRowVisitor struct {
+	c   *routineChecker
+	err error
+}
+
+// Enter implements ast.Visitor interface.
+func (v *triggerRowVisitor) Enter(n ast.Node) (ast.Node, bool) {
+	if v.err != nil {
+		return n, true
+	}
+	if x, ok := n.(*ast.ColumnName); ok && x.Schema.L == "" && (x.Table.L == "new" || x.Table.L == "old") {
+		v.err = v.c.checkTriggerRow(x.Table.O, x.Name.O, false)
+	}
+	return n, v.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (v *triggerRowVisitor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, v.err == nil
+}
+
+func onCreateTrigger(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetTriggerArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	trigger := args.Trigger
+	if tblInfo.FindTrigger(trigger.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgAlreadyExists
+	}
+	// The new trigger is activated after the existing ones by default.
+	pos := len(tblInfo.Triggers)
+	if order := args.Order; order != nil {
+		pos = slices.IndexFunc(tblInfo.Triggers, func(t *model.TriggerInfo) bool {
+			return t.Name.L == order.TriggerName.L && t.Timing == trigger.Timing && t.Event == trigger.Event
+		})
+		if pos < 0 {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrTrgDoesNotExist
+		}
+		if order.Follows {
+			pos++
+		}
+	}
+	trigger.Created = model.TSConvert2Time(job.StartTS)
+	tblInfo.Triggers = slices.Insert(tblInfo.Triggers, pos, trigger)
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetTriggerArgs(job)
+	if err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pos := slices.IndexFunc(tblInfo.Triggers, func(t *model.TriggerInfo) bool {
+		return t.Name.L == args.Trigger.Name.L
+	})
+	if pos < 0 {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist
+	}
+	tblInfo.Triggers = slices.Delete(tblInfo.Triggers, pos, pos+1)
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
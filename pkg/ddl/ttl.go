@@ -15,18 +15,26 @@
 package ddl
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschemactx "github.com/pingcap/tidb/pkg/infoschema/context"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 )
 
 func onTTLInfoRemove(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
@@ -51,7 +59,7 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
-	ttlInfo, ttlInfoEnable, ttlInfoJobInterval := args.TTLInfo, args.TTLEnable, args.TTLCronJobSchedule
+	ttlInfo, ttlInfoEnable, ttlInfoJobInterval, ttlAction, ttlCondition := args.TTLInfo, args.TTLEnable, args.TTLCronJobSchedule, args.TTLAction, args.TTLCondition
 
 	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
 	if err != nil {
@@ -66,6 +74,12 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 		if ttlInfoJobInterval == nil && tblInfo.TTLInfo != nil {
 			ttlInfo.JobInterval = tblInfo.TTLInfo.JobInterval
 		}
+		if ttlAction == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Action = tblInfo.TTLInfo.Action
+		}
+		if ttlCondition == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Condition = tblInfo.TTLInfo.Condition
+		}
 		tblInfo.TTLInfo = ttlInfo
 	}
 	if ttlInfoEnable != nil {
@@ -82,6 +96,20 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 
 		tblInfo.TTLInfo.JobInterval = *ttlInfoJobInterval
 	}
+	if ttlAction != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+		}
+
+		tblInfo.TTLInfo.Action = normalizeTTLAction(ttlAction)
+	}
+	if ttlCondition != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_WHERE"))
+		}
+
+		tblInfo.TTLInfo.Condition = *ttlCondition
+	}
 
 	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
 	if err != nil {
@@ -114,7 +142,197 @@ func checkTTLInfoValid(schema ast.CIStr, tblInfo *model.TableInfo, foreignKeyChe
 		}
 	}
 
-	return checkTTLInfoColumnType(tblInfo)
+	if err := checkTTLActionValid(schema, tblInfo, tblInfo.TTLInfo.Action, foreignKeyCheckIs); err != nil {
+		return err
+	}
+
+	if err := checkTTLInfoColumnType(tblInfo); err != nil {
+		return err
+	}
+
+	return checkTTLConditionValid(tblInfo)
+}
+
+// checkTTLConditionValid checks the TTL_WHERE predicate only references the columns of the table, and doesn't contain
+// subqueries, variables, aggregate or non-deterministic functions, so that it can be evaluated on every single row.
+func checkTTLConditionValid(tblInfo *model.TableInfo) error {
+	condition := tblInfo.TTLInfo.Condition
+	if condition == "" {
+		return nil
+	}
+
+	expr, err := generatedexpr.ParseExpression(condition)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var c illegalFunctionChecker
+	expr.Accept(&c)
+	if c.hasIllegalFunc || c.hasAggFunc || c.hasWindowFunc {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("subqueries, variables, aggregate or non-deterministic functions in TTL_WHERE")
+	}
+	if c.otherErr != nil {
+		return errors.Trace(c.otherErr)
+	}
+
+	for _, colName := range FindColumnNamesInExpr(expr) {
+		if findColumnByName(colName.Name.L, tblInfo) == nil {
+			return dbterror.ErrBadField.GenWithStackByArgs(colName.Name.O, "TTL_WHERE")
+		}
+	}
+	return nil
+}
+
+// checkTTLConditionPushDown checks the TTL_WHERE predicate can be pushed down to TiKV. Otherwise, all the expired rows
+// have to be read back to TiDB to evaluate the predicate, which makes the scan tasks much more expensive.
+func checkTTLConditionPushDown(sctx sessionctx.Context, schema ast.CIStr, tblInfo *model.TableInfo) error {
+	if tblInfo.TTLInfo == nil || tblInfo.TTLInfo.Condition == "" {
+		return nil
+	}
+
+	exprCtx := sctx.GetExprCtx()
+	expr, err := expression.ParseSimpleExpr(exprCtx, tblInfo.TTLInfo.Condition, expression.WithTableInfo(schema.L, tblInfo))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pushDownCtx := expression.NewPushDownContextFromSessionVars(exprCtx.GetEvalCtx(), sctx.GetSessionVars(), sctx.GetStore().GetClient())
+	if !expression.CanExprsPushDown(pushDownCtx, []expression.Expression{expr}, kv.TiKV) {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("TTL_WHERE predicate which cannot be pushed down to TiKV")
+	}
+	return nil
+}
+
+// restoreTTLCondition restores the TTL_WHERE predicate to the string stored in `TTLInfo.Condition`.
+func restoreTTLCondition(expr ast.ExprNode) (string, error) {
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName |
+		format.RestoreStringWithoutDefaultCharset
+	if err := expr.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// checkTTLActionValid checks the TTL_ACTION of the table `schema`.`tblName`.
+// If the archive schema is not specified, it'll be filled with `schema`.
+// If `is` is `nil`, the existence of the archive table will not be checked.
+func checkTTLActionValid(schema ast.CIStr, tblInfo *model.TableInfo, action *model.TTLAction, is infoschemactx.MetaOnlyInfoSchema) error {
+	if action == nil {
+		return nil
+	}
+
+	switch action.Tp {
+	case ast.TTLActionDelete:
+	case ast.TTLActionArchive:
+		if action.ArchiveSchema.L == "" {
+			action.ArchiveSchema = schema
+		}
+		if action.ArchiveSchema.L == schema.L && action.ArchiveTable.L == tblInfo.Name.L {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("archiving the expired rows of a TTL table to itself")
+		}
+		if is == nil {
+			return nil
+		}
+		archiveTbl, err := is.TableInfoByName(action.ArchiveSchema, action.ArchiveTable)
+		if err != nil {
+			return infoschema.ErrTableNotExists.GenWithStackByArgs(action.ArchiveSchema, action.ArchiveTable)
+		}
+		if archiveTbl.IsView() || archiveTbl.IsSequence() || archiveTbl.TempTableType != model.TempTableNone {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("archiving the expired rows of a TTL table to a view, sequence or temporary table")
+		}
+		if err := checkTTLArchiveTableColumns(tblInfo, archiveTbl); err != nil {
+			return err
+		}
+	case ast.TTLActionExport:
+		if err := checkTTLExportURI(action.ExportURI); err != nil {
+			return err
+		}
+		switch action.ExportFormat {
+		case ast.TTLExportFormatCSV, ast.TTLExportFormatParquet:
+		default:
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("TTL_ACTION export format " + action.ExportFormat)
+		}
+	default:
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("TTL_ACTION " + action.Tp.String())
+	}
+	return nil
+}
+
+// checkTTLArchiveTableColumns checks that the expired rows of the TTL table can be inserted into the archive table.
+// The columns copied by the TTL jobs, which are the same as `sqlbuilder.ArchiveColumns`, must exist in the archive
+// table with the same type class and not shorter string length, and the other columns of the archive table must
+// have a value when they are not inserted.
+func checkTTLArchiveTableColumns(tblInfo, archiveTbl *model.TableInfo) error {
+	unsupported := func(format string, args ...any) error {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf(
+			"archiving the expired rows of a TTL table to table `%s` "+format, append([]any{archiveTbl.Name.O}, args...)...))
+	}
+	archived := make(map[string]struct{}, len(tblInfo.Columns))
+	for _, col := range tblInfo.Cols() {
+		if col.Hidden || col.IsGenerated() {
+			continue
+		}
+		archived[col.Name.L] = struct{}{}
+		archiveCol := model.FindColumnInfo(archiveTbl.Cols(), col.Name.L)
+		if archiveCol == nil {
+			return unsupported("without column `%s`", col.Name.O)
+		}
+		if archiveCol.IsGenerated() {
+			return unsupported("with generated column `%s`", col.Name.O)
+		}
+		if col.FieldType.EvalType() != archiveCol.FieldType.EvalType() ||
+			(types.IsString(col.GetType()) && archiveCol.GetFlen() != types.UnspecifiedLength && col.GetFlen() > archiveCol.GetFlen()) {
+			return unsupported("with incompatible column `%s` of type %s", col.Name.O, archiveCol.FieldType.CompactStr())
+		}
+	}
+	for _, archiveCol := range archiveTbl.Cols() {
+		if _, ok := archived[archiveCol.Name.L]; ok || archiveCol.IsGenerated() {
+			continue
+		}
+		if mysql.HasNoDefaultValueFlag(archiveCol.GetFlag()) {
+			return unsupported("with not null column `%s` without default value", archiveCol.Name.O)
+		}
+	}
+	return nil
+}
+
+// ttlExportSecretParams are the parameters of the export URI which carry the credentials of the external storage.
+var ttlExportSecretParams = []string{"access-key", "secret-access-key", "session-token", "account-key", "sas-token"}
+
+// checkTTLExportURI checks the external storage of `TTLActionExport`. The URI is persisted in the table info and the
+// DDL job as it is, so the credentials are not allowed in it and should be configured in the environment of the TiDB
+// servers instead. The local storage is not allowed either, because the TTL jobs run on any TiDB server with the
+// privileges of the server.
+func checkTTLExportURI(uri string) error {
+	u, err := storage.ParseRawURL(uri)
+	if err != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("TTL_ACTION export URI '" + ast.RedactURL(uri) + "'")
+	}
+	for k := range u.Query() {
+		// the same normalization with `storage.ExtractQueryParameters`
+		normalizedKey := strings.ToLower(strings.ReplaceAll(k, "_", "-"))
+		if slices.Contains(ttlExportSecretParams, normalizedKey) {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("credentials in the TTL_ACTION export URI, please configure them in the environment of the TiDB servers")
+		}
+	}
+	backend, err := storage.ParseBackendFromURL(u, nil)
+	if err != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("TTL_ACTION export URI '" + ast.RedactURL(uri) + "'")
+	}
+	if backend.GetLocal() != nil || backend.GetNoop() != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("exporting the expired rows of a TTL table to the local storage")
+	}
+	return nil
+}
+
+// normalizeTTLAction returns `nil` for the default `TTLActionDelete`, so the table info keeps compatible with the
+// versions which don't support TTL_ACTION.
+func normalizeTTLAction(action *model.TTLAction) *model.TTLAction {
+	if action == nil || action.Tp == ast.TTLActionDelete {
+		return nil
+	}
+	return action
 }
 
 func checkTTLIntervalExpr(ttlInfo *model.TTLInfo) error {
@@ -136,7 +354,7 @@ func checkTTLInfoColumnType(tblInfo *model.TableInfo) error {
 
 func checkDropColumnWithTTLConfig(tblInfo *model.TableInfo, colName string) error {
 	if tblInfo.TTLInfo != nil {
-		if tblInfo.TTLInfo.ColumnName.L == colName {
+		if tblInfo.TTLInfo.ColumnName.L == colName || isColumnInTTLCondition(tblInfo.TTLInfo, colName) {
 			return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
 		}
 	}
@@ -144,6 +362,29 @@ func checkDropColumnWithTTLConfig(tblInfo *model.TableInfo, colName string) erro
 	return nil
 }
 
+// checkRenameColumnWithTTLConfig checks the renamed column is not referenced by the TTL_WHERE predicate. The TTL column
+// itself can be renamed, because `TTLInfo.ColumnName` is updated together.
+func checkRenameColumnWithTTLConfig(tblInfo *model.TableInfo, oldCol, newCol ast.CIStr) error {
+	if oldCol.L != newCol.L && isColumnInTTLCondition(tblInfo.TTLInfo, oldCol.L) {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("renaming a column referenced by TTL_WHERE")
+	}
+	return nil
+}
+
+// isColumnInTTLCondition returns whether the column is referenced by the TTL_WHERE predicate.
+func isColumnInTTLCondition(ttlInfo *model.TTLInfo, colName string) bool {
+	if ttlInfo == nil || ttlInfo.Condition == "" {
+		return false
+	}
+	expr, err := generatedexpr.ParseExpression(ttlInfo.Condition)
+	if err != nil {
+		// the condition has been checked when it's set, be conservative here
+		return true
+	}
+	_, ok := findDependentColsInExpr(expr)[colName]
+	return ok
+}
+
 // We should forbid creating a TTL table with clustered primary key that contains a column with type float/double.
 // This is because currently we are using SQL to delete expired rows and when the primary key contains float/double column,
 // it is hard to use condition `WHERE PK in (...)` to delete specified rows because some precision will be lost when comparing.
@@ -170,10 +411,12 @@ func checkPrimaryKeyForTTLTable(tblInfo *model.TableInfo) error {
 }
 
 // getTTLInfoInOptions returns the aggregated ttlInfo, the ttlEnable, or an error.
-// if TTL, TTL_ENABLE or TTL_JOB_INTERVAL is not set in the config, the corresponding return value will be nil.
+// if TTL, TTL_ENABLE, TTL_JOB_INTERVAL or TTL_ACTION is not set in the config, the corresponding return value will be nil.
 // if both of TTL and TTL_ENABLE are set, the `ttlInfo.Enable` will be equal with `ttlEnable`.
 // if both of TTL and TTL_JOB_INTERVAL are set, the `ttlInfo.JobInterval` will be equal with `ttlCronJobSchedule`.
-func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, err error) {
+// if both of TTL and TTL_ACTION are set, the `ttlInfo.Action` will be equal with `ttlAction`, or `nil` for the DELETE action.
+// if both of TTL and TTL_WHERE are set, the `ttlInfo.Condition` will be equal with `ttlCondition`, which is empty for `TTL_WHERE = DEFAULT`.
+func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, ttlAction *model.TTLAction, ttlCondition *string, err error) {
 	for _, op := range options {
 		switch op.Tp {
 		case ast.TableOptionTTL:
@@ -182,7 +425,7 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 			restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
 			err := op.Value.Restore(restoreCtx)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, nil, err
 			}
 
 			intervalExpr := sb.String()
@@ -197,6 +440,28 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 			ttlEnable = &op.BoolValue
 		case ast.TableOptionTTLJobInterval:
 			ttlCronJobSchedule = &op.StrValue
+		case ast.TableOptionTTLAction:
+			ttlAction = &model.TTLAction{Tp: ast.TTLActionType(op.UintValue)}
+			switch ttlAction.Tp {
+			case ast.TTLActionArchive:
+				ttlAction.ArchiveSchema = op.TableNames[0].Schema
+				ttlAction.ArchiveTable = op.TableNames[0].Name
+			case ast.TTLActionExport:
+				ttlAction.ExportURI = op.StrValue
+				ttlAction.ExportFormat = ast.TTLExportFormatCSV
+				if op.Value != nil {
+					ttlAction.ExportFormat = op.Value.GetString()
+				}
+			}
+		case ast.TableOptionTTLWhere:
+			condition := ""
+			if !op.Default {
+				condition, err = restoreTTLCondition(op.Expr)
+				if err != nil {
+					return nil, nil, nil, nil, nil, err
+				}
+			}
+			ttlCondition = &condition
 		}
 	}
 
@@ -207,6 +472,10 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 		if ttlCronJobSchedule != nil {
 			ttlInfo.JobInterval = *ttlCronJobSchedule
 		}
+		ttlInfo.Action = normalizeTTLAction(ttlAction)
+		if ttlCondition != nil {
+			ttlInfo.Condition = *ttlCondition
+		}
 	}
-	return ttlInfo, ttlEnable, ttlCronJobSchedule, nil
+	return ttlInfo, ttlEnable, ttlCronJobSchedule, ttlAction, ttlCondition, nil
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,12 +27,16 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 	falseValue := false
 	trueValue := true
 	twentyFiveHours := "25h"
+	deletedCondition := "`deleted` = 1"
+	emptyCondition := ""
 
 	cases := []struct {
 		options            []*ast.TableOption
 		ttlInfo            *model.TTLInfo
 		ttlEnable          *bool
 		ttlCronJobSchedule *string
+		ttlAction          *model.TTLAction
+		ttlCondition       *string
 		err                error
 	}{
 		{
@@ -40,6 +45,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -60,6 +67,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -84,6 +93,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&falseValue,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -112,6 +123,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&trueValue,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -136,15 +149,146 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			&twentyFiveHours,
 			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: ast.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:         ast.TableOptionTTLAction,
+					UintValue:  uint64(ast.TTLActionArchive),
+					TableNames: []*ast.TableName{{Schema: ast.NewCIStr("test"), Name: ast.NewCIStr("t_archive")}},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       ast.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      model.DefaultTTLJobInterval,
+				Action: &model.TTLAction{
+					Tp:            ast.TTLActionArchive,
+					ArchiveSchema: ast.NewCIStr("test"),
+					ArchiveTable:  ast.NewCIStr("t_archive"),
+				},
+			},
+			nil,
+			nil,
+			&model.TTLAction{
+				Tp:            ast.TTLActionArchive,
+				ArchiveSchema: ast.NewCIStr("test"),
+				ArchiveTable:  ast.NewCIStr("t_archive"),
+			},
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: ast.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:        ast.TableOptionTTLAction,
+					UintValue: uint64(ast.TTLActionDelete),
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       ast.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      model.DefaultTTLJobInterval,
+			},
+			nil,
+			nil,
+			&model.TTLAction{Tp: ast.TTLActionDelete},
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:        ast.TableOptionTTLAction,
+					UintValue: uint64(ast.TTLActionExport),
+					StrValue:  "s3://bucket/prefix",
+					Value:     ast.NewValueExpr(ast.TTLExportFormatParquet, "", ""),
+				},
+			},
+			nil,
+			nil,
+			nil,
+			&model.TTLAction{
+				Tp:           ast.TTLActionExport,
+				ExportURI:    "s3://bucket/prefix",
+				ExportFormat: ast.TTLExportFormatParquet,
+			},
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: ast.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp: ast.TableOptionTTLWhere,
+					Expr: &ast.BinaryOperationExpr{
+						Op: opcode.EQ,
+						L:  &ast.ColumnNameExpr{Name: &ast.ColumnName{Table: ast.NewCIStr("t"), Name: ast.NewCIStr("deleted")}},
+						R:  ast.NewValueExpr(1, "", ""),
+					},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       ast.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      model.DefaultTTLJobInterval,
+				Condition:        "`deleted` = 1",
+			},
+			nil,
+			nil,
+			nil,
+			&deletedCondition,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:      ast.TableOptionTTLWhere,
+					Default: true,
+				},
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+			&emptyCondition,
+			nil,
 		},
 	}
 
 	for _, c := range cases {
-		ttlInfo, ttlEnable, ttlCronJobSchedule, err := getTTLInfoInOptions(c.options)
+		ttlInfo, ttlEnable, ttlCronJobSchedule, ttlAction, ttlCondition, err := getTTLInfoInOptions(c.options)
 
 		assert.Equal(t, c.ttlInfo, ttlInfo)
 		assert.Equal(t, c.ttlEnable, ttlEnable)
 		assert.Equal(t, c.ttlCronJobSchedule, ttlCronJobSchedule)
+		assert.Equal(t, c.ttlAction, ttlAction)
+		assert.Equal(t, c.ttlCondition, ttlCondition)
 		assert.Equal(t, c.err, err)
 	}
 }
@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/globalconfigsync"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -214,6 +215,7 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
 	runawayManager           *runaway.Manager
 	resourceGroupsController *rmclient.ResourceGroupsController
 
@@ -1278,6 +1280,10 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.brOwnerMgr != nil {
@@ -3424,6 +3430,14 @@ func (do *Domain) StartTTLJobManager() {
 	ttlJobManager.Start()
 }
 
+// StartEventScheduler creates and starts the scheduler of the scheduled events,
+// the bodies of the events are executed by runner.
+func (do *Domain) StartEventScheduler(runner eventscheduler.Runner) {
+	scheduler := eventscheduler.NewScheduler(do.advancedSysSessionPool, do.store, do.etcdClient, runner, do.ddl.OwnerManager().IsOwner)
+	do.eventScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
 // TTLJobManager returns the ttl job manager on this domain
 func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
@@ -3530,14 +3544,19 @@ func (do *Domain) readTableCostWorker(wbLearningHandle *workloadlearning.Handle,
 	readTableCostTicker := time.NewTicker(vardef.WorkloadBasedLearningInterval.Load())
 	defer func() {
 		readTableCostTicker.Stop()
+		wbLearningHandle.StopCollectingStmtStats()
 		logutil.BgLogger().Info("readTableCostWorker exited.")
 	}()
 	for {
 		select {
 		case <-readTableCostTicker.C:
 			if vardef.EnableWorkloadBasedLearning.Load() && do.statsOwner.IsOwner() {
+				// The Top SQL statement stats are only collected by the owner, they're analyzed from the next round.
+				wbLearningHandle.StartCollectingStmtStats()
 				wbLearningHandle.HandleTableReadCost(do.InfoSchema())
 				wbCacheWorker.UpdateTableReadCostCache()
+			} else {
+				wbLearningHandle.StopCollectingStmtStats()
 			}
 		case <-do.exit:
 			return
@@ -0,0 +1,158 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/session/syssession"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	loopInterval       = time.Second
+	fullSyncInterval   = time.Minute
+	gcInterval         = time.Hour
+	historyGCRetention = "90 DAY"
+)
+
+// Runner runs the body of the scheduled events.
+type Runner interface {
+	// RunEvent executes the body of the event in the database schema as the
+	// definer of the event.
+	RunEvent(ctx context.Context, schema string, event *model.EventInfo) error
+}
+
+// Scheduler schedules the scheduled events with the timer framework. Every
+// event has a timer whose next event time is the next execution time of the
+// event. The timers are only synced and triggered on the DDL owner.
+type Scheduler struct {
+	ctx     context.Context
+	cancel  func()
+	wg      sync.WaitGroup
+	pool    syssession.Pool
+	store   kv.Storage
+	etcd    *clientv3.Client
+	runner  Runner
+	isOwner func() bool
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(pool syssession.Pool, store kv.Storage, etcd *clientv3.Client, runner Runner, isOwner func() bool) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		ctx:     kv.WithInternalSourceType(ctx, kv.InternalTxnEventScheduler),
+		cancel:  cancel,
+		pool:    pool,
+		store:   store,
+		etcd:    etcd,
+		runner:  runner,
+		isOwner: isOwner,
+	}
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the scheduler and waits for the running events to exit.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+	timerStore := tablestore.NewTableTimerStore(1, s.pool, "mysql", "tidb_timers", s.etcd)
+	defer timerStore.Close()
+	rt := newEventTimerRuntime(timerStore, s.pool, s.store, s.runner)
+	defer rt.Pause()
+	syncer := newEventTimersSyncer(s.store, timerStore)
+
+	ticker := time.NewTicker(loopInterval)
+	defer ticker.Stop()
+	gcTicker := time.NewTicker(gcInterval)
+	defer gcTicker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.onTick(rt, syncer, time.Now())
+		case <-gcTicker.C:
+			if s.isOwner() {
+				s.gcHistory()
+			}
+		}
+	}
+}
+
+func (s *Scheduler) onTick(rt *eventTimerRuntime, syncer *eventTimersSyncer, now time.Time) {
+	if !s.isOwner() {
+		rt.Pause()
+		syncer.Reset()
+		return
+	}
+
+	rt.Resume()
+	ver, err := meta.NewReader(s.store.GetSnapshot(kv.MaxVersion)).GetSchemaVersion()
+	if err != nil {
+		logutil.BgLogger().Warn("failed to get schema version for event timers", zap.Error(err))
+		return
+	}
+	lastSyncTime, lastSyncVer := syncer.GetLastSyncInfo()
+	// The events are changed by DDL jobs, so only sync the timers when the
+	// schema version is changed, or it has not been synced for a while.
+	if ver > lastSyncVer || now.Sub(lastSyncTime) > fullSyncInterval {
+		syncer.SyncTimers(s.ctx, ver, now)
+	}
+}
+
+// gcHistory removes the expired execution history of the events.
+func (s *Scheduler) gcHistory() {
+	_, err := executeSQL(s.ctx, s.pool, "DELETE FROM mysql.tidb_event_history WHERE start_time < NOW() - INTERVAL "+historyGCRetention)
+	if err != nil {
+		logutil.BgLogger().Warn("failed to gc the history of events", zap.Error(err))
+	}
+}
+
+func executeSQL(ctx context.Context, pool syssession.Pool, sql string, args ...any) (rows []chunk.Row, _ error) {
+	err := pool.WithSession(func(se *syssession.Session) error {
+		rs, err := se.ExecuteInternal(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		if rs == nil {
+			return nil
+		}
+		defer terror.Call(rs.Close)
+		rows, err = sqlexec.DrainRecordSet(ctx, rs, 8)
+		return err
+	})
+	return rows, err
+}
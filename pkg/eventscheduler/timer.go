@@ -0,0 +1,249 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/session/syssession"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// eventStatusRunning and the following ones are the status of an execution
+	// in mysql.tidb_event_history.
+	eventStatusRunning  = "running"
+	eventStatusFinished = "finished"
+	eventStatusFailed   = "failed"
+)
+
+type eventTimerHook struct {
+	store  *timerapi.TimerStore
+	pool   syssession.Pool
+	kvs    kv.Storage
+	runner Runner
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newEventTimerHook(store *timerapi.TimerStore, pool syssession.Pool, kvs kv.Storage, runner Runner) *eventTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventTimerHook{
+		store:  store,
+		pool:   pool,
+		kvs:    kvs,
+		runner: runner,
+		ctx:    kv.WithInternalSourceType(ctx, kv.InternalTxnEventScheduler),
+		cancel: cancel,
+	}
+}
+
+func (*eventTimerHook) Start() {}
+
+func (h *eventTimerHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventTimerHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !vardef.EnableEventScheduler.Load() {
+		r.Delay = 10 * time.Second
+	}
+	return
+}
+
+func (h *eventTimerHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var data eventTimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid event timer data",
+			zap.String("timerID", timer.ID),
+			zap.String("timerKey", timer.Key),
+			zap.ByteString("data", timer.Data),
+		)
+		return err
+	}
+
+	// The body of the event may run for a long time, so it is executed
+	// asynchronously and the timer event is closed after it finishes.
+	h.wg.Add(1)
+	go h.runEvent(timer, event.EventID(), &data)
+	return nil
+}
+
+func (h *eventTimerHook) runEvent(timer *timerapi.TimerRecord, eventID string, data *eventTimerData) {
+	defer h.wg.Done()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+		zap.Strings("tags", timer.Tags),
+	)
+
+	db, event, err := h.loadEvent(data)
+	if err != nil {
+		logger.Warn("failed to load the event", zap.Error(err))
+		return
+	}
+	if event != nil && event.IsEnabled() {
+		h.execute(logger, timer, eventID, db, event)
+		// Reload the event since it may be altered during the execution.
+		if db, event, err = h.loadEvent(data); err != nil {
+			logger.Warn("failed to load the event", zap.Error(err))
+			return
+		}
+	}
+
+	// Close the timer event and schedule the next execution in one update, so
+	// that the timer is never left with a passed event time.
+	update := &timerapi.TimerUpdate{}
+	update.CheckEventID.Set(eventID)
+	update.EventStatus.Set(timerapi.SchedEventIdle)
+	update.EventID.Set("")
+	update.EventData.Set(nil)
+	update.EventStart.Set(time.Time{})
+	update.EventExtra.Set(timerapi.EventExtra{})
+	update.Watermark.Set(timer.EventStart)
+	next, hasNext := time.Time{}, false
+	if event != nil {
+		next, hasNext = event.NextExecutionTime(timer.EventStart, time.Now())
+		if hasNext {
+			update.SchedPolicyType.Set(timerapi.SchedEventOnce)
+			update.SchedPolicyExpr.Set(formatSchedExpr(next))
+		}
+	}
+	if err = h.store.Update(h.ctx, timer.ID, update); err != nil {
+		logger.Warn("failed to close the event timer", zap.Error(err))
+		return
+	}
+	if event == nil || hasNext {
+		return
+	}
+
+	// The event is completed, it is dropped or disabled according to its
+	// ON COMPLETION clause.
+	sql := "DROP EVENT IF EXISTS %n.%n"
+	if event.Preserve {
+		sql = "ALTER EVENT %n.%n DISABLE"
+	}
+	if _, err = executeSQL(h.ctx, h.pool, sql, db.Name.O, event.Name.O); err != nil {
+		logger.Warn("failed to complete the event", zap.Error(err))
+	}
+}
+
+// execute executes the event and records the execution in the history. The
+// event is executed at most once for every timer event, it is skipped if the
+// timer event has been handled before, e.g. by a previous DDL owner.
+func (h *eventTimerHook) execute(logger *zap.Logger, timer *timerapi.TimerRecord, eventID string, db *model.DBInfo, event *model.EventInfo) {
+	rows, err := executeSQL(h.ctx, h.pool, "SELECT 1 FROM mysql.tidb_event_history WHERE job_id = %?", eventID)
+	if err != nil {
+		logger.Warn("failed to read the history of event", zap.Error(err))
+		return
+	}
+	if len(rows) > 0 {
+		logger.Warn("skip the event since it has been executed")
+		return
+	}
+	_, err = executeSQL(h.ctx, h.pool, "INSERT INTO mysql.tidb_event_history "+
+		"(job_id, event_id, event_schema, event_name, scheduled_time, start_time, status) "+
+		"VALUES (%?, %?, %?, %?, FROM_UNIXTIME(%?), FROM_UNIXTIME(%?), %?)",
+		eventID, event.ID, db.Name.O, event.Name.O, timer.EventStart.Unix(), time.Now().Unix(), eventStatusRunning)
+	if err != nil {
+		logger.Warn("failed to insert the history of event", zap.Error(err))
+		return
+	}
+
+	logger.Info("start to execute the event")
+	status, errMsg := eventStatusFinished, any(nil)
+	if err = h.runner.RunEvent(h.ctx, db.Name.O, event); err != nil {
+		logger.Warn("failed to execute the event", zap.Error(err))
+		status, errMsg = eventStatusFailed, err.Error()
+	}
+	_, err = executeSQL(h.ctx, h.pool, "UPDATE mysql.tidb_event_history "+
+		"SET finish_time = FROM_UNIXTIME(%?), status = %?, error_message = %? WHERE job_id = %?",
+		time.Now().Unix(), status, errMsg, eventID)
+	if err != nil {
+		logger.Warn("failed to update the history of event", zap.Error(err))
+	}
+}
+
+// loadEvent loads the latest info of the event, nil is returned if the event
+// has been dropped.
+func (h *eventTimerHook) loadEvent(data *eventTimerData) (*model.DBInfo, *model.EventInfo, error) {
+	reader := meta.NewReader(h.kvs.GetSnapshot(kv.MaxVersion))
+	db, err := reader.GetDatabase(data.SchemaID)
+	if err != nil || db == nil {
+		return nil, nil, err
+	}
+	events, err := reader.ListEvents(db.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, event := range events {
+		if event.ID == data.EventID {
+			return db, event, nil
+		}
+	}
+	return db, nil, nil
+}
+
+type eventTimerRuntime struct {
+	rt     *timerrt.TimerGroupRuntime
+	store  *timerapi.TimerStore
+	pool   syssession.Pool
+	kvs    kv.Storage
+	runner Runner
+}
+
+func newEventTimerRuntime(store *timerapi.TimerStore, pool syssession.Pool, kvs kv.Storage, runner Runner) *eventTimerRuntime {
+	return &eventTimerRuntime{
+		store:  store,
+		pool:   pool,
+		kvs:    kvs,
+		runner: runner,
+	}
+}
+
+func (r *eventTimerRuntime) Resume() {
+	if r.rt != nil {
+		return
+	}
+
+	r.rt = timerrt.NewTimerRuntimeBuilder("event", r.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(string, timerapi.TimerClient) timerapi.Hook {
+			return newEventTimerHook(r.store, r.pool, r.kvs, r.runner)
+		}).
+		Build()
+	r.rt.Start()
+}
+
+func (r *eventTimerRuntime) Pause() {
+	if rt := r.rt; rt != nil {
+		r.rt = nil
+		rt.Stop()
+	}
+}
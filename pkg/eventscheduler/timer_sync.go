@@ -0,0 +1,187 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event"
+)
+
+// eventTimerData is the data stored in each timer of the events.
+type eventTimerData struct {
+	SchemaID int64 `json:"schema_id"`
+	EventID  int64 `json:"event_id"`
+}
+
+// eventTimerSummary is the summary stored in each timer of the events.
+type eventTimerSummary struct {
+	// LastAltered is used to find out whether the event is changed since the
+	// timer was synced.
+	LastAltered time.Time `json:"last_altered"`
+}
+
+func getTimerKey(event *model.EventInfo) string {
+	return fmt.Sprintf("%s%d", timerKeyPrefix, event.ID)
+}
+
+func getTimerTags(db *model.DBInfo, event *model.EventInfo) []string {
+	return []string{
+		fmt.Sprintf("db=%s", db.Name.O),
+		fmt.Sprintf("event=%s", event.Name.O),
+	}
+}
+
+// formatSchedExpr formats the time as the expression of the ONCE policy.
+func formatSchedExpr(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// eventTimersSyncer syncs the timers with the events.
+type eventTimersSyncer struct {
+	kvs          kv.Storage
+	store        *timerapi.TimerStore
+	cli          timerapi.TimerClient
+	lastSyncTime time.Time
+	lastSyncVer  int64
+}
+
+func newEventTimersSyncer(kvs kv.Storage, store *timerapi.TimerStore) *eventTimersSyncer {
+	return &eventTimersSyncer{
+		kvs:   kvs,
+		store: store,
+		cli:   timerapi.NewDefaultTimerClient(store),
+	}
+}
+
+// Reset resets the syncer's state.
+func (g *eventTimersSyncer) Reset() {
+	g.lastSyncTime = time.Time{}
+	g.lastSyncVer = 0
+}
+
+// GetLastSyncInfo returns the last sync time and schema version.
+func (g *eventTimersSyncer) GetLastSyncInfo() (time.Time, int64) {
+	return g.lastSyncTime, g.lastSyncVer
+}
+
+// SyncTimers creates, updates or deletes the timers according to the events
+// in the schema version ver. The sync info is only updated if all the timers
+// are synced, so that the failed ones are retried in the next round.
+func (g *eventTimersSyncer) SyncTimers(ctx context.Context, ver int64, now time.Time) {
+	timers, err := g.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		logutil.BgLogger().Warn("failed to pull event timers", zap.Error(err))
+		return
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	reader := meta.NewReader(g.kvs.GetSnapshot(kv.MaxVersion))
+	dbs, err := reader.ListDatabases()
+	if err != nil {
+		logutil.BgLogger().Warn("failed to list databases for event timers", zap.Error(err))
+		return
+	}
+	synced := true
+	for _, db := range dbs {
+		events, err := reader.ListEvents(db.ID)
+		if err != nil {
+			logutil.BgLogger().Warn("failed to list events", zap.String("db", db.Name.O), zap.Error(err))
+			return
+		}
+		for _, event := range events {
+			key := getTimerKey(event)
+			if err := g.syncOneTimer(ctx, db, event, key2Timers[key], now); err != nil {
+				logutil.BgLogger().Warn("failed to sync event timer", zap.String("key", key), zap.Error(err))
+				synced = false
+			}
+			delete(key2Timers, key)
+		}
+	}
+
+	// The remaining timers belong to the dropped events.
+	for _, timer := range key2Timers {
+		if _, err := g.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Warn("failed to delete event timer", zap.String("key", timer.Key), zap.Error(err))
+			synced = false
+		}
+	}
+	if synced {
+		g.lastSyncTime, g.lastSyncVer = now, ver
+	}
+}
+
+func (g *eventTimersSyncer) syncOneTimer(ctx context.Context, db *model.DBInfo, event *model.EventInfo, timer *timerapi.TimerRecord, now time.Time) error {
+	tags := getTimerTags(db, event)
+	if timer == nil {
+		data, err := json.Marshal(&eventTimerData{SchemaID: db.ID, EventID: event.ID})
+		if err != nil {
+			return err
+		}
+		next, ok := event.NextExecutionTime(time.Time{}, now)
+		// The summary is filled in the next round of sync.
+		_, err = g.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             getTimerKey(event),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventOnce,
+			SchedPolicyExpr: formatSchedExpr(next),
+			HookClass:       timerHookClass,
+			Enable:          event.IsEnabled() && ok,
+		})
+		return err
+	}
+
+	var summary eventTimerSummary
+	if err := json.Unmarshal(timer.SummaryData, &summary); err == nil && summary.LastAltered.Equal(event.LastAltered) && slices.Equal(timer.Tags, tags) {
+		return nil
+	}
+	// The running timer event reschedules the timer when it is closed, so wait
+	// for it to avoid overwriting its schedule.
+	if timer.EventStatus == timerapi.SchedEventTrigger {
+		return errors.Errorf("the event of timer %s is running", timer.ID)
+	}
+	summaryData, err := json.Marshal(&eventTimerSummary{LastAltered: event.LastAltered})
+	if err != nil {
+		return err
+	}
+	next, ok := event.NextExecutionTime(timer.Watermark, now)
+	update := &timerapi.TimerUpdate{}
+	update.Tags.Set(tags)
+	update.SummaryData.Set(summaryData)
+	update.Enable.Set(event.IsEnabled() && ok)
+	update.SchedPolicyType.Set(timerapi.SchedEventOnce)
+	update.SchedPolicyExpr.Set(formatSchedExpr(next))
+	update.CheckVersion.Set(timer.Version)
+	return g.store.Update(ctx, timer.ID, update)
+}
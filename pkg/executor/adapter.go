@@ -646,11 +646,11 @@ func (a *ExecStmt) inheritContextFromExecuteStmt() {
 func (a *ExecStmt) getSQLForProcessInfo() string {
 	sql := a.Text()
 	if simple, ok := a.Plan.(*plannercore.Simple); ok && simple.Statement != nil {
-		if ss, ok := simple.Statement.(ast.SensitiveStmtNode); ok {
+		if ss, ok := ast.AsSensitiveStmtNode(simple.Statement); ok {
 			// Use SecureText to avoid leak password information.
 			sql = ss.SecureText()
 		}
-	} else if sn, ok2 := a.StmtNode.(ast.SensitiveStmtNode); ok2 {
+	} else if sn, ok2 := ast.AsSensitiveStmtNode(a.StmtNode); ok2 {
 		// such as import into statement
 		sql = sn.SecureText()
 	}
@@ -701,6 +701,38 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 			return err
 		}
 	}
+	if te, ok := e.(WithTrigger); ok {
+		for _, trigger := range te.GetTriggers() {
+			err := a.handleTrigger(ctx, trigger, depth)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// handleTrigger fires the queued AFTER triggers, then handles the foreign key checks, cascades and triggers of the
+// statements executed by the triggers.
+func (a *ExecStmt) handleTrigger(ctx context.Context, t *TriggerExec, depth int) error {
+	err := t.fireAfter(ctx)
+	if err != nil {
+		return err
+	}
+	nested := t.nested
+	t.nested = nil
+	if len(nested) == 0 {
+		return nil
+	}
+	// Call `StmtCommit` to flush the changes of the trigger bodies into txn mem-buffer, so that the later foreign key
+	// cascade executors and triggers can see them.
+	a.Ctx.StmtCommit(ctx)
+	for _, e := range nested {
+		err = a.handleForeignKeyTrigger(ctx, e, depth+1)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -773,7 +805,11 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 // cascade behaviour and this ExecStmt is in transaction.
 func (a *ExecStmt) prepareFKCascadeContext(e exec.Executor) {
 	exec, ok := e.(WithForeignKeyTrigger)
-	if !ok || !exec.HasFKCascades() {
+	if !ok {
+		return
+	}
+	// The statements in trigger bodies are handled like foreign key cascades.
+	if te, ok := e.(WithTrigger); !exec.HasFKCascades() && (!ok || len(te.GetTriggers()) == 0) {
 		return
 	}
 	sessVar := a.Ctx.GetSessionVars()
@@ -2119,7 +2155,7 @@ func (a *ExecStmt) GetTextToLog(keepHint bool) string {
 		} else {
 			sql, _ = sessVars.StmtCtx.SQLDigest()
 		}
-	} else if sensitiveStmt, ok := a.StmtNode.(ast.SensitiveStmtNode); ok {
+	} else if sensitiveStmt, ok := ast.AsSensitiveStmtNode(a.StmtNode); ok {
 		sql = sensitiveStmt.SecureText()
 	} else {
 		sql = redact.String(rmode, sessVars.StmtCtx.OriginalSQL+sessVars.PlanCacheParams.String())
@@ -2135,7 +2171,7 @@ func (a *ExecStmt) getLazyStmtText() (s variable.LazyStmtText) {
 	if rmode == errors.RedactLogEnable {
 		sql, _ := sessVars.StmtCtx.SQLDigest()
 		s.SetText(sql)
-	} else if sensitiveStmt, ok := a.StmtNode.(ast.SensitiveStmtNode); ok {
+	} else if sensitiveStmt, ok := ast.AsSensitiveStmtNode(a.StmtNode); ok {
 		sql := sensitiveStmt.SecureText()
 		s.SetText(sql)
 	} else {
@@ -2156,7 +2192,7 @@ func (a *ExecStmt) updatePrevStmt() {
 	if rmode == errors.RedactLogEnable {
 		sql, _ := sessVars.StmtCtx.SQLDigest()
 		sessVars.PrevStmt.SetText(sql)
-	} else if sensitiveStmt, ok := a.StmtNode.(ast.SensitiveStmtNode); ok {
+	} else if sensitiveStmt, ok := ast.AsSensitiveStmtNode(a.StmtNode); ok {
 		sql := sensitiveStmt.SecureText()
 		sessVars.PrevStmt.SetText(sql)
 	} else {
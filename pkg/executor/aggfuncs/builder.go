@@ -77,7 +77,8 @@ func Build(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordina
 }
 
 // BuildWindowFunctions builds specific window function according to function description and order by columns.
-func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column) AggFunc {
+// ignoreNull and fromLast are the null treatment and the FROM FIRST/LAST clause of the value window functions.
+func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column, ignoreNull, fromLast bool) AggFunc {
 	switch windowFuncDesc.Name {
 	case ast.WindowFuncRank:
 		return buildRank(ordinal, orderByCols, false)
@@ -86,21 +87,21 @@ func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.A
 	case ast.WindowFuncRowNumber:
 		return buildRowNumber(windowFuncDesc, ordinal)
 	case ast.WindowFuncFirstValue:
-		return buildFirstValue(windowFuncDesc, ordinal)
+		return buildFirstValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLastValue:
-		return buildLastValue(windowFuncDesc, ordinal)
+		return buildLastValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncCumeDist:
 		return buildCumeDist(ordinal, orderByCols)
 	case ast.WindowFuncNthValue:
-		return buildNthValue(ctx, windowFuncDesc, ordinal)
+		return buildNthValue(ctx, windowFuncDesc, ordinal, ignoreNull, fromLast)
 	case ast.WindowFuncNtile:
 		return buildNtile(ctx, windowFuncDesc, ordinal)
 	case ast.WindowFuncPercentRank:
 		return buildPercentRank(ordinal, orderByCols)
 	case ast.WindowFuncLead:
-		return buildLead(ctx, windowFuncDesc, ordinal)
+		return buildLead(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLag:
-		return buildLag(ctx, windowFuncDesc, ordinal)
+		return buildLag(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.AggFuncMax:
 		// The max/min aggFunc using in the window function will using the sliding window algo.
 		return buildMaxMinInWindowFunction(ctx, windowFuncDesc, ordinal, true)
@@ -666,20 +667,20 @@ func buildRank(ordinal int, orderByCols []*expression.Column, isDense bool) AggF
 	return r
 }
 
-func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
-func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -690,14 +691,14 @@ func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return r
 }
 
-func buildNthValue(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildNthValue(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull, fromLast bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(ctx.GetEvalCtx(), aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: ignoreNull, fromLast: fromLast}
 }
 
 func buildNtile(ctx AggFuncBuildContext, aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -716,7 +717,7 @@ func buildPercentRank(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return &percentRank{baseAggFunc: base, rowComparer: buildRowComparer(orderByCols)}
 }
 
-func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) baseLeadLag {
+func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) baseLeadLag {
 	offset := uint64(1)
 	if len(aggFuncDesc.Args) >= 2 {
 		offset, _, _ = expression.GetUint64FromConstant(ctx.GetEvalCtx(), aggFuncDesc.Args[1])
@@ -738,13 +739,13 @@ func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNull: ignoreNull}
 }
 
-func buildLead(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLead(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
 
-func buildLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
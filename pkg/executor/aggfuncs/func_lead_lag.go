@@ -15,6 +15,7 @@
 package aggfuncs
 
 import (
+	"sort"
 	"unsafe"
 
 	"github.com/pingcap/tidb/pkg/expression"
@@ -32,11 +33,17 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	// ignoreNull indicates the NULL values are skipped.
+	ignoreNull bool
 }
 
 type partialResult4LeadLag struct {
 	rows   []chunk.Row
 	curIdx uint64
+	// nonNullIdx stores the offsets of the rows whose values are not NULL, it's only
+	// used when the NULL values are skipped.
+	nonNullIdx []uint64
+	resolved   bool
 }
 
 func (*baseLeadLag) AllocPartialResult() (pr PartialResult, memDelta int64) {
@@ -47,6 +54,8 @@ func (*baseLeadLag) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4LeadLag)(pr)
 	p.rows = p.rows[:0]
 	p.curIdx = 0
+	p.nonNullIdx = p.nonNullIdx[:0]
+	p.resolved = false
 }
 
 func (*baseLeadLag) UpdatePartialResult(_ AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -56,15 +65,29 @@ func (*baseLeadLag) UpdatePartialResult(_ AggFuncUpdateContext, rowsInGroup []ch
 	return memDelta, nil
 }
 
-type lead struct {
-	baseLeadLag
+// resolveNonNullRows finds out the rows whose values are not NULL. All the rows in the
+// partition have been consumed before the first result is appended.
+func (v *baseLeadLag) resolveNonNullRows(sctx AggFuncUpdateContext, p *partialResult4LeadLag) error {
+	if p.resolved {
+		return nil
+	}
+	p.resolved = true
+	for i, row := range p.rows {
+		if _, err := v.evaluateRow(sctx, v.args[0], row); err != nil {
+			return err
+		}
+		if !v.isNullResult() {
+			p.nonNullIdx = append(p.nonNullIdx, uint64(i))
+		}
+	}
+	return nil
 }
 
-func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
-	p := (*partialResult4LeadLag)(pr)
+// appendValue appends the value of the row at idx, or the default value if idx is not valid.
+func (v *baseLeadLag) appendValue(sctx AggFuncUpdateContext, p *partialResult4LeadLag, idx uint64, valid bool, chk *chunk.Chunk) error {
 	var err error
-	if p.curIdx+v.offset < uint64(len(p.rows)) {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
+	if valid {
+		_, err = v.evaluateRow(sctx, v.args[0], p.rows[idx])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
 	}
@@ -76,22 +99,43 @@ func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResu
 	return nil
 }
 
+type lead struct {
+	baseLeadLag
+}
+
+func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4LeadLag)(pr)
+	if !v.ignoreNull || v.offset == 0 {
+		return v.appendValue(sctx, p, p.curIdx+v.offset, p.curIdx+v.offset < uint64(len(p.rows)), chk)
+	}
+	if err := v.resolveNonNullRows(sctx, p); err != nil {
+		return err
+	}
+	// Find the offset-th non-NULL row after the current row.
+	i := uint64(sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] > p.curIdx }))
+	remained := uint64(len(p.nonNullIdx)) - i
+	if v.offset > remained {
+		return v.appendValue(sctx, p, 0, false, chk)
+	}
+	return v.appendValue(sctx, p, p.nonNullIdx[i+v.offset-1], true, chk)
+}
+
 type lag struct {
 	baseLeadLag
 }
 
 func (v *lag) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
-	var err error
-	if p.curIdx >= v.offset {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
-	} else {
-		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+	if !v.ignoreNull || v.offset == 0 {
+		return v.appendValue(sctx, p, p.curIdx-v.offset, p.curIdx >= v.offset, chk)
 	}
-	if err != nil {
+	if err := v.resolveNonNullRows(sctx, p); err != nil {
 		return err
 	}
-	v.appendResult(chk, v.ordinal)
-	p.curIdx++
-	return nil
+	// Find the offset-th non-NULL row before the current row.
+	i := uint64(sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] >= p.curIdx }))
+	if v.offset > i {
+		return v.appendValue(sctx, p, 0, false, chk)
+	}
+	return v.appendValue(sctx, p, p.nonNullIdx[i-v.offset], true, chk)
 }
@@ -59,6 +59,8 @@ type valueEvaluator interface {
 	evaluateRow(ctx expression.EvalContext, expr expression.Expression, row chunk.Row) (memDelta int64, err error)
 	// appendResult appends the result to chunk.
 	appendResult(chk *chunk.Chunk, colIdx int)
+	// isNullResult returns whether the evaluated result is NULL.
+	isNullResult() bool
 }
 
 type value4Int struct {
@@ -79,6 +81,10 @@ func (v *value4Int) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Int) isNullResult() bool {
+	return v.isNull
+}
+
 type value4Float32 struct {
 	val    float32
 	isNull bool
@@ -99,6 +105,10 @@ func (v *value4Float32) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Float32) isNullResult() bool {
+	return v.isNull
+}
+
 type value4Decimal struct {
 	val    *types.MyDecimal
 	isNull bool
@@ -117,6 +127,10 @@ func (v *value4Decimal) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Decimal) isNullResult() bool {
+	return v.isNull
+}
+
 type value4Float64 struct {
 	val    float64
 	isNull bool
@@ -135,6 +149,10 @@ func (v *value4Float64) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Float64) isNullResult() bool {
+	return v.isNull
+}
+
 type value4String struct {
 	val    string
 	isNull bool
@@ -154,6 +172,10 @@ func (v *value4String) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4String) isNullResult() bool {
+	return v.isNull
+}
+
 type value4Time struct {
 	val    types.Time
 	isNull bool
@@ -172,6 +194,10 @@ func (v *value4Time) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Time) isNullResult() bool {
+	return v.isNull
+}
+
 type value4Duration struct {
 	val    types.Duration
 	isNull bool
@@ -190,6 +216,10 @@ func (v *value4Duration) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Duration) isNullResult() bool {
+	return v.isNull
+}
+
 type value4JSON struct {
 	val    types.BinaryJSON
 	isNull bool
@@ -210,6 +240,10 @@ func (v *value4JSON) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4JSON) isNullResult() bool {
+	return v.isNull
+}
+
 type value4VectorFloat32 struct {
 	val    types.VectorFloat32
 	isNull bool
@@ -230,6 +264,10 @@ func (v *value4VectorFloat32) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4VectorFloat32) isNullResult() bool {
+	return v.isNull
+}
+
 func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64) {
 	evalType := tp.EvalType()
 	if tp.GetType() == mysql.TypeBit {
@@ -267,6 +305,8 @@ type firstValue struct {
 	baseAggFunc
 
 	tp *types.FieldType
+	// ignoreNull indicates the NULL values are skipped.
+	ignoreNull bool
 }
 
 type partialResult4FirstValue struct {
@@ -290,12 +330,16 @@ func (v *firstValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
-		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
+	for _, row := range rowsInGroup {
+		delta, err := p.evaluator.evaluateRow(sctx, v.args[0], row)
 		if err != nil {
 			return 0, err
 		}
+		memDelta += delta
+		if !v.ignoreNull || !p.evaluator.isNullResult() {
+			p.gotFirstValue = true
+			break
+		}
 	}
 	return memDelta, nil
 }
@@ -314,17 +358,28 @@ type lastValue struct {
 	baseAggFunc
 
 	tp *types.FieldType
+	// ignoreNull indicates the NULL values are skipped.
+	ignoreNull bool
 }
 
 type partialResult4LastValue struct {
 	gotLastValue bool
 	evaluator    valueEvaluator
+	// probe is used to evaluate the rows when the NULL values are skipped, so
+	// the last non-NULL value in evaluator won't be overwritten by NULL.
+	probe valueEvaluator
 }
 
 func (v *lastValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
-	return PartialResult(p), DefPartialResult4LastValueSize + veMemDelta
+	p := &partialResult4LastValue{evaluator: ve}
+	memDelta = DefPartialResult4LastValueSize + veMemDelta
+	if v.ignoreNull {
+		probe, probeMemDelta := buildValueEvaluator(v.tp)
+		p.probe = probe
+		memDelta += probeMemDelta
+	}
+	return PartialResult(p), memDelta
 }
 
 func (*lastValue) ResetPartialResult(pr PartialResult) {
@@ -334,12 +389,27 @@ func (*lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
-		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+	if !v.ignoreNull {
+		if len(rowsInGroup) > 0 {
+			p.gotLastValue = true
+			memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+			if err != nil {
+				return 0, err
+			}
+		}
+		return memDelta, nil
+	}
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		delta, err := p.probe.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 		if err != nil {
 			return 0, err
 		}
+		memDelta += delta
+		if !p.probe.isNullResult() {
+			p.gotLastValue = true
+			p.evaluator, p.probe = p.probe, p.evaluator
+			break
+		}
 	}
 	return memDelta, nil
 }
@@ -359,22 +429,30 @@ type nthValue struct {
 
 	tp  *types.FieldType
 	nth uint64
+	// ignoreNull indicates the NULL values are skipped.
+	ignoreNull bool
+	// fromLast indicates the rows are counted from the end of the frame.
+	fromLast bool
 }
 
 type partialResult4NthValue struct {
 	seenRows  uint64
 	evaluator valueEvaluator
+	// rows keeps the last nth candidate rows when the rows are counted from the end
+	// of the frame, the result is evaluated when it's appended to the chunk.
+	rows []chunk.Row
 }
 
 func (v *nthValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
+	p := &partialResult4NthValue{evaluator: ve}
 	return PartialResult(p), DefPartialResult4NthValueSize + veMemDelta
 }
 
 func (*nthValue) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4NthValue)(pr)
 	p.seenRows = 0
+	p.rows = p.rows[:0]
 }
 
 func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -382,6 +460,25 @@ func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
+	if v.fromLast {
+		return v.updateFromLast(sctx, rowsInGroup, p)
+	}
+	if v.ignoreNull {
+		for _, row := range rowsInGroup {
+			if p.seenRows >= v.nth {
+				break
+			}
+			delta, err := p.evaluator.evaluateRow(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			memDelta += delta
+			if !p.evaluator.isNullResult() {
+				p.seenRows++
+			}
+		}
+		return memDelta, nil
+	}
 	numRows := uint64(len(rowsInGroup))
 	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
 		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
@@ -393,12 +490,41 @@ func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []
 	return memDelta, nil
 }
 
-func (v *nthValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+// updateFromLast keeps the last nth candidate rows, the NULL values are not candidates
+// if they are skipped.
+func (v *nthValue) updateFromLast(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, p *partialResult4NthValue) (memDelta int64, err error) {
+	for _, row := range rowsInGroup {
+		if v.ignoreNull {
+			delta, err := p.evaluator.evaluateRow(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			memDelta += delta
+			if p.evaluator.isNullResult() {
+				continue
+			}
+		}
+		p.rows = append(p.rows, row)
+		memDelta += DefRowSize
+		p.seenRows++
+	}
+	if uint64(len(p.rows)) > v.nth {
+		p.rows = p.rows[uint64(len(p.rows))-v.nth:]
+	}
+	return memDelta, nil
+}
+
+func (v *nthValue) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4NthValue)(pr)
 	if v.nth == 0 || p.seenRows < v.nth {
 		chk.AppendNull(v.ordinal)
-	} else {
-		p.evaluator.appendResult(chk, v.ordinal)
+		return nil
+	}
+	if v.fromLast {
+		if _, err := p.evaluator.evaluateRow(sctx, v.args[0], p.rows[0]); err != nil {
+			return err
+		}
 	}
+	p.evaluator.appendResult(chk, v.ordinal)
 	return nil
 }
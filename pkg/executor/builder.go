@@ -122,6 +122,10 @@ type executorBuilder struct {
 
 	// Used when building MPPGather.
 	encounterUnionScan bool
+
+	// usedTables records the tables modified by the statements which invoke the
+	// trigger being fired, the statements in the trigger body can't modify them.
+	usedTables map[int64]string
 }
 
 // CTEStorages stores resTbl and iterInTbl for CTEExec.
@@ -281,6 +285,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -520,7 +526,8 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 			break
 		}
 	}
-	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndexOrColumnarIndex {
+	// The fast check doesn't support checking specified partitions.
+	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndexOrColumnarIndex && len(v.PartitionNames) == 0 {
 		e := &FastCheckTableExec{
 			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 			dbName:       v.DBName,
@@ -555,6 +562,9 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 		retCh:        make(chan error, len(readerExecs)),
 		checkIndex:   v.CheckIndex,
 	}
+	for _, name := range v.PartitionNames {
+		e.partitionNames = append(e.partitionNames, name.O)
+	}
 	return e
 }
 
@@ -888,6 +898,7 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		CountWarningsOrErrors: v.CountWarningsOrErrors,
 		DBName:                ast.NewCIStr(v.DBName),
 		Table:                 v.Table,
+		Procedure:             v.Procedure,
 		Partition:             v.Partition,
 		Column:                v.Column,
 		IndexName:             v.IndexName,
@@ -1012,6 +1023,19 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	tblID := ivs.Table.Meta().ID
+	triggers, err := b.buildTriggerExecs(map[int64]table.Table{tblID: ivs.Table})
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	ivs.triggers = triggers[tblID]
+	mviewLogs, err := b.buildMViewLogWriters(map[int64]table.Table{tblID: ivs.Table})
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	ivs.mviewLogs = mviewLogs[tblID]
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1305,10 +1329,15 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) exec.Execut
 	if b.err != nil {
 		return nil
 	}
+	colNames := make([]string, 0, len(v.TargetNames))
+	for _, name := range v.TargetNames {
+		colNames = append(colNames, name.ColName.O)
+	}
 	return &SelectIntoExec{
 		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:        v.IntoOpt,
 		LineFieldsInfo: v.LineFieldsInfo,
+		colNames:       colNames,
 	}
 }
 
@@ -2137,6 +2166,31 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	e := &JSONTableExec{
+		BaseExecutorV2: exec.NewBaseExecutorV2(b.ctx.GetSessionVars(), v.Schema(), v.ID()),
+		evalCtx:        b.ctx.GetExprCtx().GetEvalCtx(),
+		typeCtx:        types.DefaultStmtNoWarningContext.WithLocation(b.ctx.GetSessionVars().Location()),
+		jsonExpr:       v.JSONExpr,
+		root:           v.Root,
+		tableName:      v.AsName.O,
+		offsets:        make(map[*logicalop.JSONTableColumn]int, v.Schema().Len()),
+	}
+	var collect func(path *logicalop.JSONTablePath)
+	collect = func(path *logicalop.JSONTablePath) {
+		for _, col := range path.Columns {
+			if offset := v.Schema().ColumnIndex(col.Column); offset >= 0 {
+				e.offsets[col] = offset
+			}
+		}
+		for _, nested := range path.Nested {
+			collect(nested)
+		}
+	}
+	collect(v.Root)
+	return e
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2321,6 +2375,10 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableParameters),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2795,6 +2853,14 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	updateExec.mviewLogs, b.err = b.buildMViewLogWriters(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return updateExec
 }
 
@@ -2848,6 +2914,14 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	deleteExec.mviewLogs, b.err = b.buildMViewLogWriters(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return deleteExec
 }
 
@@ -5124,12 +5198,12 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	exprCtx := b.ctx.GetExprCtx()
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(exprCtx, desc, false)
+		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(exprCtx, desc, desc.HasDistinct)
 		if err != nil {
 			b.err = err
 			return nil
 		}
-		agg := aggfuncs.BuildWindowFunctions(exprCtx, aggDesc, resultColIdx, orderByCols)
+		agg := aggfuncs.BuildWindowFunctions(exprCtx, aggDesc, resultColIdx, orderByCols, desc.IgnoreNull, desc.FromLast)
 		windowFuncs = append(windowFuncs, agg)
 		partialResult, _ := agg.AllocPartialResult()
 		partialResults = append(partialResults, partialResult)
@@ -5163,6 +5237,10 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 		} else {
 			exec.start = v.Frame.Start
 			exec.end = v.Frame.End
+			if v.Frame.Type == ast.Groups {
+				exec.isGroupsFrame = true
+				exec.groups = newPeerGroups(orderByCols)
+			}
 			if v.Frame.Type == ast.Ranges {
 				cmpResult := int64(-1)
 				if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -5196,6 +5274,14 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+	} else if v.Frame.Type == ast.Groups {
+		processor = &groupsFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			groups:         newPeerGroups(orderByCols),
+		}
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -5831,12 +5917,21 @@ func (b *executorBuilder) buildCompactTable(v *plannercore.CompactTable) exec.Ex
 		}
 	}
 
-	return &CompactTableTiFlashExec{
+	tiFlashExec := &CompactTableTiFlashExec{
 		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 		tableInfo:    v.TableInfo,
 		partitionIDs: partitionIDs,
 		tikvStore:    tikvStore,
 	}
+	if v.CompactTiKV {
+		return &CompactTableTiKVExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			tableInfo:    v.TableInfo,
+			partitionIDs: partitionIDs,
+			tiFlashExec:  tiFlashExec,
+		}
+	}
+	return tiFlashExec
 }
 
 func (b *executorBuilder) buildAdminShowBDRRole(v *plannercore.AdminShowBDRRole) exec.Executor {
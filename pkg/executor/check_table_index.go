@@ -61,6 +61,9 @@ type CheckTableExec struct {
 	exitCh     chan struct{}
 	retCh      chan error
 	checkIndex bool
+	// partitionNames are the partitions to check, all the partitions are
+	// checked if it's empty.
+	partitionNames []string
 }
 
 var _ exec.Executor = &CheckTableExec{}
@@ -146,7 +149,7 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		}
 		idxNames = append(idxNames, idx.Name.O)
 	}
-	greater, idxOffset, err := admin.CheckIndicesCount(e.Ctx(), e.dbName, e.table.Meta().Name.O, idxNames)
+	greater, idxOffset, err := admin.CheckIndicesCount(e.Ctx(), e.dbName, e.table.Meta().Name.O, e.partitionNames, idxNames)
 	if err != nil {
 		// For admin check index statement, for speed up and compatibility, doesn't do below checks.
 		if e.checkIndex {
@@ -231,6 +234,11 @@ func (e *CheckTableExec) checkTableRecord(ctx context.Context, idxOffset int) er
 
 	info := e.table.Meta().GetPartitionInfo()
 	for _, def := range info.Definitions {
+		if len(e.partitionNames) > 0 && !slices.ContainsFunc(e.partitionNames, func(name string) bool {
+			return strings.EqualFold(name, def.Name.O)
+		}) {
+			continue
+		}
 		pid := def.ID
 		partition := e.table.(table.PartitionedTable).GetPartition(pid)
 		idx := tables.NewIndex(def.ID, e.table.Meta(), idxInfo)
@@ -20,25 +20,37 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/import_sstpb"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/store/driver/backoff"
+	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	tikverr "github.com/tikv/client-go/v2/error"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
+	tikvutil "github.com/tikv/client-go/v2/util"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
-var _ exec.Executor = &CompactTableTiFlashExec{}
+var (
+	_ exec.Executor = &CompactTableTiFlashExec{}
+	_ exec.Executor = &CompactTableTiKVExec{}
+)
 
 const (
 	compactRequestTimeout         = time.Minute * 60 // A single compact request may take at most 1 hour.
@@ -365,3 +377,119 @@ func (task *storeCompactTask) sendRequestWithRetry(req *tikvrpc.Request) (*kvrpc
 		return resp.Resp.(*kvrpcpb.CompactResponse), nil
 	}
 }
+
+// CompactTableTiKVExec represents an executor for "ALTER TABLE [NAME] OPTIMIZE PARTITION" statement.
+// It compacts the key ranges of the partitions in all the TiKV stores, then compacts the TiFlash replicas.
+type CompactTableTiKVExec struct {
+	exec.BaseExecutor
+
+	tableInfo    *model.TableInfo
+	partitionIDs []int64
+	done         bool
+
+	tiFlashExec *CompactTableTiFlashExec
+}
+
+// Next implements the Executor Next interface.
+func (e *CompactTableTiKVExec) Next(ctx context.Context, chk *chunk.Chunk) error {
+	chk.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	if err := e.doCompact(ctx); err != nil {
+		return err
+	}
+	if e.tableInfo.TiFlashReplica == nil || e.tableInfo.TiFlashReplica.Count == 0 {
+		return nil
+	}
+	return e.tiFlashExec.doCompact(ctx)
+}
+
+func (e *CompactTableTiKVExec) doCompact(execCtx context.Context) error {
+	physicalIDs := e.partitionIDs
+	if len(physicalIDs) == 0 {
+		if pi := e.tableInfo.GetPartitionInfo(); pi != nil {
+			for _, def := range pi.Definitions {
+				physicalIDs = append(physicalIDs, def.ID)
+			}
+		} else {
+			physicalIDs = append(physicalIDs, e.tableInfo.ID)
+		}
+	}
+	ranges := make([]*import_sstpb.Range, 0, len(physicalIDs))
+	for _, id := range physicalIDs {
+		ranges = append(ranges, &import_sstpb.Range{
+			Start: tikvDataKey(tablecodec.EncodeTablePrefix(id)),
+			End:   tikvDataKey(tablecodec.EncodeTablePrefix(id + 1)),
+		})
+	}
+
+	stores, err := infoschema.GetStoreServerInfo(e.Ctx().GetStore())
+	if err != nil {
+		return err
+	}
+	g, ctx := errgroup.WithContext(execCtx)
+	for _, store := range stores {
+		if store.ServerType != kv.TiKV.Name() {
+			continue
+		}
+		g.Go(func() error {
+			if err := compactTiKVRanges(ctx, store.Address, ranges); err != nil {
+				warn := errors.NewNoStackErrorf("compact on store %s failed: %v", store.Address, err)
+				e.Ctx().GetSessionVars().StmtCtx.AppendWarning(warn)
+				log.Warn("Compact table failed",
+					zap.String("table", e.tableInfo.Name.O),
+					zap.Int64("table-id", e.tableInfo.ID),
+					zap.Int64s("partition-id", e.partitionIDs),
+					zap.String("store-address", store.Address),
+					zap.Error(err))
+			}
+			// Errors have been turned into warnings, the other stores are still compacted.
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return nil
+}
+
+// tikvDataKey converts the key to the one stored in the RocksDB of TiKV.
+func tikvDataKey(key kv.Key) []byte {
+	return append([]byte("z"), codec.EncodeBytes(nil, key)...)
+}
+
+// compactTiKVRanges compacts the ranges to the bottommost level in the TiKV store.
+func compactTiKVRanges(ctx context.Context, address string, ranges []*import_sstpb.Range) error {
+	opt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	security := config.GetGlobalConfig().Security
+	if len(security.ClusterSSLCA) != 0 {
+		clusterSecurity := security.ClusterSecurity()
+		tlsConfig, err := clusterSecurity.ToTLSConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		opt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+	conn, err := grpc.Dial(address, opt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer terror.Call(conn.Close)
+
+	client := import_sstpb.NewImportSSTClient(conn)
+	for _, r := range ranges {
+		reqCtx, cancel := context.WithTimeout(ctx, compactRequestTimeout)
+		_, err = client.Compact(reqCtx, &import_sstpb.CompactRequest{
+			Range:       r,
+			OutputLevel: -1,
+			Context: &kvrpcpb.Context{
+				RequestSource: tikvutil.BuildRequestSource(true, kv.InternalTxnOthers, ""),
+			},
+		})
+		cancel()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
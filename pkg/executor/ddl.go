@@ -225,6 +225,26 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeDropResourceGroup(x)
 	case *ast.AlterResourceGroupStmt:
 		err = e.executeAlterResourceGroup(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateRoutine(x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropRoutine(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
+	case *ast.DropMaterializedViewStmt:
+		err = e.executeDropMaterializedView(x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
 	}
 	if err != nil {
 		// If the owner return ErrTableNotExists error when running this DDL, it may be caused by schema changed,
@@ -385,6 +405,41 @@ func (e *DDLExec) executeDropSequence(s *ast.DropSequenceStmt) error {
 	return e.ddlExecutor.DropSequence(e.Ctx(), s)
 }
 
+func (e *DDLExec) executeCreateRoutine(s *ast.ProcedureInfo) error {
+	return e.ddlExecutor.CreateRoutine(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropRoutine(s *ast.DropProcedureStmt) error {
+	return e.ddlExecutor.DropRoutine(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	_, exist, err := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exist {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(s.Table.Name.O)
+	}
+	return e.ddlExecutor.CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return e.ddlExecutor.DropTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateEvent(s *ast.CreateEventStmt) error {
+	return e.ddlExecutor.CreateEvent(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeAlterEvent(s *ast.AlterEventStmt) error {
+	return e.ddlExecutor.AlterEvent(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropEvent(s *ast.DropEventStmt) error {
+	return e.ddlExecutor.DropEvent(e.Ctx(), s)
+}
+
 func (e *DDLExec) dropLocalTemporaryTables(localTempTables []*ast.TableName) error {
 	if len(localTempTables) == 0 {
 		return nil
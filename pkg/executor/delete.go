@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
@@ -50,6 +51,10 @@ type DeleteExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the triggers to fire. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
+	// mviewLogs contains the change logs of the materialized views. the map is tableID -> *mviewLogWriter
+	mviewLogs map[int64]*mviewLogWriter
 
 	ignoreErr bool
 }
@@ -63,7 +68,7 @@ func (e *DeleteExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return e.deleteSingleTableByChunk(ctx)
 }
 
-func (e *DeleteExec) deleteOneRow(tbl table.Table, colInfo *plannercore.TblColPosInfo, isExtraHandle bool, row []types.Datum) error {
+func (e *DeleteExec) deleteOneRow(ctx context.Context, tbl table.Table, colInfo *plannercore.TblColPosInfo, isExtraHandle bool, row []types.Datum) error {
 	end := len(row)
 	if isExtraHandle {
 		end--
@@ -72,7 +77,7 @@ func (e *DeleteExec) deleteOneRow(tbl table.Table, colInfo *plannercore.TblColPo
 	if err != nil {
 		return err
 	}
-	err = e.removeRow(e.Ctx(), tbl, handle, row[:end], colInfo)
+	err = e.removeRow(ctx, tbl, handle, row[:end], colInfo)
 	if err != nil {
 		return err
 	}
@@ -143,7 +148,7 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 					continue
 				}
 			}
-			err = e.deleteOneRow(tbl, colPosInfo, isExtraHandle, datumRow)
+			err = e.deleteOneRow(ctx, tbl, colPosInfo, isExtraHandle, datumRow)
 			if err != nil {
 				return err
 			}
@@ -258,7 +263,7 @@ func (e *DeleteExec) removeRowsInTblRowMap(ctx context.Context, tblRowMap tableR
 				}
 			}
 
-			err = e.removeRow(e.Ctx(), e.tblID2Table[id], h, val.handleVal, val.posInfo)
+			err = e.removeRow(ctx, e.tblID2Table[id], h, val.handleVal, val.posInfo)
 			return err == nil
 		})
 		if err != nil {
@@ -268,22 +273,30 @@ func (e *DeleteExec) removeRowsInTblRowMap(ctx context.Context, tblRowMap tableR
 	return nil
 }
 
-func (e *DeleteExec) removeRow(ctx sessionctx.Context, t table.Table, h kv.Handle, data []types.Datum, posInfo *plannercore.TblColPosInfo) error {
-	txn, err := e.Ctx().Txn(true)
+func (e *DeleteExec) removeRow(ctx context.Context, t table.Table, h kv.Handle, data []types.Datum, posInfo *plannercore.TblColPosInfo) error {
+	sctx := e.Ctx()
+	txn, err := sctx.Txn(true)
 	if err != nil {
 		return err
 	}
 
-	err = t.RemoveRecord(ctx.GetTableCtx(), txn, h, data, posInfo.IndexesRowLayout)
+	tid := t.Meta().ID
+	if err = e.triggers[tid].fireBefore(ctx, ast.TriggerDelete, data, nil); err != nil {
+		return err
+	}
+	err = t.RemoveRecord(sctx.GetTableCtx(), txn, h, data, posInfo.IndexesRowLayout)
 	if err != nil {
 		return err
 	}
-	tid := t.Meta().ID
-	err = onRemoveRowForFK(ctx, data, e.fkChecks[tid], e.fkCascades[tid], e.ignoreErr)
+	err = onRemoveRowForFK(sctx, data, e.fkChecks[tid], e.fkCascades[tid], e.ignoreErr)
 	if err != nil {
 		return err
 	}
-	ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	if err = e.mviewLogs[tid].log(sctx, data); err != nil {
+		return err
+	}
+	e.triggers[tid].queueAfter(ast.TriggerDelete, data, nil)
+	sctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
 	return nil
 }
 
@@ -343,6 +356,11 @@ func (e *DeleteExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
 
+// GetTriggers implements WithTrigger interface.
+func (e *DeleteExec) GetTriggers() []*TriggerExec {
+	return triggerExecsOf(e.triggers)
+}
+
 type handleInfoPair struct {
 	handleVal []types.Datum
 	posInfo   *plannercore.TblColPosInfo
@@ -0,0 +1,57 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// ExecuteEvent executes the body of the scheduled event in the session.
+//
+// The body is interpreted like the body of a stored procedure, every SQL
+// statement inside it is executed by exec as a standalone statement. The caller
+// should have switched the session to the definer, the database and the sql
+// mode of the event.
+func ExecuteEvent(ctx context.Context, sctx sessionctx.Context, exec sqlexec.SQLExecutor, event *model.EventInfo) error {
+	p := parser.New()
+	p.SetSQLMode(event.SQLMode)
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	// Only the body is used, the schedule is kept in the event info.
+	sql := fmt.Sprintf("CREATE EVENT `%s` ON SCHEDULE AT '2000-01-01 00:00:00' DO %s",
+		strings.ReplaceAll(event.Name.O, "`", "``"), event.Body)
+	stmt, err := p.ParseOneStmt(sql, event.Charset, event.Collate)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e := &procedureExec{
+		sctx:     sctx,
+		exec:     exec,
+		active:   make(map[string]int),
+		restored: make(map[ast.Node]string),
+		parser:   p,
+		charset:  event.Charset,
+		collate:  event.Collate,
+	}
+	return e.execStmt(ctx, newProcedureScope(nil), stmt.(*ast.CreateEventStmt).Body)
+}
@@ -25,9 +25,11 @@ import (
 	"github.com/pingcap/tidb/pkg/extension"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/resolve"
 	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
@@ -80,8 +82,18 @@ func (e *GrantExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		dbName = e.Ctx().GetSessionVars().CurrentDB
 	}
 
-	// For table & column level, check whether table exists and privilege is valid
-	if e.Level.Level == ast.GrantLevelTable {
+	// For stored routine level, check whether the routine exists and privilege is valid
+	routineType, isRoutine := routineTypeOfObject(e.ObjectType)
+	if isRoutine && e.Level.Level == ast.GrantLevelTable {
+		if err := checkRoutinePrivs(e.Privs); err != nil {
+			return err
+		}
+		_, _, err := plannercore.LoadRoutine(e.Ctx().GetPlanCtx(), e.is, ast.NewCIStr(dbName), routineType, ast.NewCIStr(e.Level.TableName))
+		if err != nil {
+			return err
+		}
+	} else if e.Level.Level == ast.GrantLevelTable {
+		// For table & column level, check whether table exists and privilege is valid
 		// Return if privilege is invalid, to fail before not existing table, see issue #29302
 		for _, p := range e.Privs {
 			if len(p.Cols) == 0 {
@@ -218,6 +230,13 @@ func (e *GrantExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 				return err
 			}
 		case ast.GrantLevelTable:
+			if isRoutine {
+				err := checkAndInitRoutinePriv(internalSession, dbName, e.Level.TableName, routineType, user.User.Username, user.User.Hostname)
+				if err != nil {
+					return err
+				}
+				break
+			}
 			err := checkAndInitTablePriv(internalSession, dbName, e.Level.TableName, e.is, user.User.Username, user.User.Hostname)
 			if err != nil {
 				return err
@@ -317,6 +336,20 @@ func checkAndInitTablePriv(ctx sessionctx.Context, dbName, tblName string, _ inf
 	return initTablePrivEntry(ctx, user, host, dbName, tblName)
 }
 
+// checkAndInitRoutinePriv checks if stored routine scope privilege entry exists in mysql.procs_priv.
+// If unexists, insert a new one.
+func checkAndInitRoutinePriv(ctx sessionctx.Context, dbName, routineName string, tp model.RoutineType, user string, host string) error {
+	ok, err := routineUserExists(ctx, user, host, dbName, routineName, tp)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	// Entry does not exist for user-host-db-routine. Insert a new entry.
+	return initRoutinePrivEntry(ctx, user, host, dbName, routineName, tp)
+}
+
 // checkAndInitColumnPriv checks if column scope privilege entry exists in mysql.Columns_priv.
 // If unexists, insert a new one.
 func (e *GrantExec) checkAndInitColumnPriv(ctx context.Context, user string, host string, cols []*ast.ColumnName, internalSession sessionctx.Context) error {
@@ -366,6 +399,13 @@ func initTablePrivEntry(sctx sessionctx.Context, user string, host string, db st
 	return err
 }
 
+// initRoutinePrivEntry inserts a new row into mysql.procs_priv with empty privilege.
+func initRoutinePrivEntry(sctx sessionctx.Context, user string, host string, db string, routineName string, tp model.RoutineType) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
+	_, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, `INSERT INTO %n.%n (Host, User, DB, Routine_name, Routine_type, Proc_priv) VALUES (%?, %?, %?, %?, %?, '')`, mysql.SystemDB, mysql.ProcsPrivTable, host, user, db, routineName, tp.String())
+	return err
+}
+
 // initColumnPrivEntry inserts a new row into mysql.Columns_priv with empty privilege.
 func initColumnPrivEntry(sctx sessionctx.Context, user string, host string, db string, tbl string, col string) error {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
@@ -482,6 +522,9 @@ func (e *GrantExec) grantLevelPriv(ctx context.Context, priv *ast.PrivElem, user
 	case ast.GrantLevelDB:
 		return e.grantDBLevel(priv, user, internalSession)
 	case ast.GrantLevelTable:
+		if tp, ok := routineTypeOfObject(e.ObjectType); ok {
+			return e.grantRoutineLevel(priv, user, tp, internalSession)
+		}
 		if len(priv.Cols) == 0 {
 			return e.grantTableLevel(priv, user, internalSession)
 		}
@@ -574,6 +617,34 @@ func (e *GrantExec) grantTableLevel(priv *ast.PrivElem, user *ast.UserSpec, inte
 	return err
 }
 
+// grantRoutineLevel manipulates mysql.procs_priv table.
+func (e *GrantExec) grantRoutineLevel(priv *ast.PrivElem, user *ast.UserSpec, tp model.RoutineType, internalSession sessionctx.Context) error {
+	dbName := e.Level.DBName
+	if len(dbName) == 0 {
+		dbName = e.Ctx().GetSessionVars().CurrentDB
+	}
+	routineName := e.Level.TableName
+
+	currProcPriv, err := getRoutinePriv(internalSession, user.User.Username, user.User.Hostname, dbName, routineName, tp)
+	if err != nil {
+		return err
+	}
+	newProcPriv := SetFromString(currProcPriv)
+	if priv.Priv == mysql.AllPriv {
+		for _, p := range mysql.AllRoutinePrivs {
+			newProcPriv = addToSet(newProcPriv, p.SetString())
+		}
+	} else {
+		newProcPriv = addToSet(newProcPriv, priv.Priv.SetString())
+	}
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
+	_, err = internalSession.GetSQLExecutor().ExecuteInternal(ctx, `UPDATE %n.%n SET Proc_priv=%?, Grantor=%? WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?`,
+		mysql.SystemDB, mysql.ProcsPrivTable, setToString(newProcPriv), e.Ctx().GetSessionVars().User.String(),
+		user.User.Username, user.User.Hostname, dbName, routineName, tp.String())
+	return err
+}
+
 // grantColumnLevel manipulates mysql.tables_priv table.
 func (e *GrantExec) grantColumnLevel(ctx context.Context, priv *ast.PrivElem, user *ast.UserSpec, internalSession sessionctx.Context) error {
 	dbName, tbl, err := getTargetSchemaAndTable(ctx, e.Ctx(), e.Level.DBName, e.Level.TableName, e.is)
@@ -720,6 +791,49 @@ func tableUserExists(ctx sessionctx.Context, name string, host string, db string
 	return recordExists(ctx, `SELECT * FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Table_name=%?;`, mysql.SystemDB, mysql.TablePrivTable, name, host, db, tbl)
 }
 
+// routineUserExists checks if there is an entry with key user-host-db-routine in mysql.procs_priv.
+func routineUserExists(ctx sessionctx.Context, name string, host string, db string, routineName string, tp model.RoutineType) (bool, error) {
+	return recordExists(ctx, `SELECT * FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?;`, mysql.SystemDB, mysql.ProcsPrivTable, name, host, db, routineName, tp.String())
+}
+
+// getRoutinePriv gets current stored routine scope privilege set from mysql.procs_priv.
+func getRoutinePriv(sctx sessionctx.Context, name string, host string, db string, routineName string, tp model.RoutineType) (string, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, `SELECT Proc_priv FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?`, mysql.SystemDB, mysql.ProcsPrivTable, name, host, db, routineName, tp.String())
+	if err != nil {
+		return "", err
+	}
+	rows, _, err := getRowsAndFields(sctx, rs)
+	if err != nil {
+		return "", errors.Errorf("get routine privilege fail for %s %s %s %s: %v", name, host, db, routineName, err)
+	}
+	if len(rows) < 1 {
+		return "", errors.Errorf("get routine privilege fail for %s %s %s %s", name, host, db, routineName)
+	}
+	return rows[0].GetSet(0).Name, nil
+}
+
+// routineTypeOfObject returns the stored routine type of the GRANT or REVOKE object.
+func routineTypeOfObject(tp ast.ObjectTypeType) (model.RoutineType, bool) {
+	switch tp {
+	case ast.ObjectTypeProcedure:
+		return model.RoutineTypeProcedure, true
+	case ast.ObjectTypeFunction:
+		return model.RoutineTypeFunction, true
+	}
+	return 0, false
+}
+
+// checkRoutinePrivs checks whether the privileges can be granted or revoked on a stored routine.
+func checkRoutinePrivs(privs []*ast.PrivElem) error {
+	for _, p := range privs {
+		if len(p.Cols) > 0 || (!mysql.AllRoutinePrivs.Has(p.Priv) && p.Priv != mysql.AllPriv && p.Priv != mysql.UsagePriv && p.Priv != mysql.GrantPriv) {
+			return exeerrors.ErrIllegalGrantForTable
+		}
+	}
+	return nil
+}
+
 // columnPrivEntryExists checks if there is an entry with key user-host-db-tbl-col in mysql.Columns_priv.
 func columnPrivEntryExists(ctx sessionctx.Context, name string, host string, db string, tbl string, col string) (bool, error) {
 	return recordExists(ctx, `SELECT * FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Table_name=%? AND Column_name=%?;`, mysql.SystemDB, mysql.ColumnPrivTable, name, host, db, tbl, col)
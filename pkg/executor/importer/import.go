@@ -76,6 +76,12 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatJSONL represents the data source file of IMPORT INTO is JSON Lines.
+	DataFormatJSONL = "jsonl"
+	// DataFormatNDJSON is an alias of DataFormatJSONL.
+	DataFormatNDJSON = "ndjson"
+	// DataFormatAvro represents the data source file of IMPORT INTO is avro object container file.
+	DataFormatAvro = "avro"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -110,6 +116,9 @@ const (
 	disableTiKVImportModeOption = "disable_tikv_import_mode"
 	cloudStorageURIOption       = "cloud_storage_uri"
 	disablePrecheckOption       = "disable_precheck"
+	// jsonPathsOption maps columns to the paths of the nested values in JSON Lines
+	// files, such as 'a=$.user.id,b=$.tags[0]'.
+	jsonPathsOption = "json_paths"
 	// used for test
 	maxEngineSizeOption  = "__max_engine_size"
 	forceMergeStep       = "__force_merge_step"
@@ -140,6 +149,7 @@ var (
 		manualRecoveryOption:        false,
 		cloudStorageURIOption:       true,
 		disablePrecheckOption:       false,
+		jsonPathsOption:             true,
 	}
 
 	csvOnlyOptions = map[string]struct{}{
@@ -153,6 +163,10 @@ var (
 		splitFileOption:           {},
 	}
 
+	jsonlOnlyOptions = map[string]struct{}{
+		jsonPathsOption: {},
+	}
+
 	allowedOptionsOfImportFromQuery = map[string]struct{}{
 		threadOption:          {},
 		disablePrecheckOption: {},
@@ -163,6 +177,7 @@ var (
 
 	supportedSuffixForServerDisk = []string{
 		".csv", ".sql", ".parquet",
+		".jsonl", ".ndjson", ".avro",
 		".gz", ".gzip",
 		".zstd", ".zst",
 		".snappy",
@@ -195,6 +210,17 @@ type FieldMapping struct {
 	UserVar *ast.VariableExpr
 }
 
+// name returns the name of the column or the user variable.
+func (fm *FieldMapping) name() string {
+	if fm.Column != nil {
+		return fm.Column.Name.O
+	}
+	if fm.UserVar != nil {
+		return fm.UserVar.Name
+	}
+	return ""
+}
+
 // LoadDataReaderInfo provides information for a data reader of LOAD DATA.
 type LoadDataReaderInfo struct {
 	// Opener can be called at needed to get a io.ReadSeekCloser. It will only
@@ -252,6 +278,9 @@ type Plan struct {
 	MaxEngineSize         config.ByteSize
 	CloudStorageURI       string
 	DisablePrecheck       bool
+	// JSONPaths maps column or user variable names to the paths of their values
+	// in JSON Lines files.
+	JSONPaths map[string]string
 
 	// used for checksum in physical mode
 	DistSQLScanConcurrency int
@@ -402,6 +431,9 @@ func NewImportPlan(ctx context.Context, userSctx sessionctx.Context, plan *plann
 	var format string
 	if plan.Format != nil {
 		format = strings.ToLower(*plan.Format)
+		if format == DataFormatNDJSON {
+			format = DataFormatJSONL
+		}
 	} else {
 		// without FORMAT 'xxx' clause, default to CSV
 		format = DataFormatCSV
@@ -508,6 +540,9 @@ func NewLoadDataController(plan *Plan, tbl table.Table, astArgs *ASTArgs) (*Load
 	if err := c.initLoadColumns(columnNames); err != nil {
 		return nil, err
 	}
+	if err := c.checkJSONPaths(); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -526,7 +561,9 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		switch e.Format {
+		case DataFormatCSV, DataFormatParquet, DataFormatSQL, DataFormatJSONL, DataFormatAvro:
+		default:
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -600,6 +637,13 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 			}
 		}
 	}
+	if p.Format != DataFormatJSONL {
+		for k := range jsonlOnlyOptions {
+			if _, ok := specifiedOptions[k]; ok {
+				return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs(k, "non-JSONL format")
+			}
+		}
+	}
 	if p.DataSourceType == DataSourceTypeQuery {
 		for k := range specifiedOptions {
 			if _, ok := allowedOptionsOfImportFromQuery[k]; !ok {
@@ -770,6 +814,15 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 	if _, ok := specifiedOptions[manualRecoveryOption]; ok {
 		p.ManualRecovery = true
 	}
+	if opt, ok := specifiedOptions[jsonPathsOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if p.JSONPaths, err = mydump.ParseJSONLPaths(v); err != nil || len(p.JSONPaths) == 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+	}
 
 	if sv, ok := seCtx.GetSessionVars().GetSystemVar(vardef.TiDBMaxDistTaskNodes); ok {
 		p.MaxNodeCnt = variable.TidbOptInt(sv, 0)
@@ -1000,6 +1053,46 @@ func (e *LoadDataController) GenerateCSVConfig() *config.CSVConfig {
 	return csvConfig
 }
 
+// GenerateJSONLConfig generates a JSON Lines config for parser. The values are
+// mapped to the input fields by name, so the columns are the names of the
+// field mappings. User variables in json_paths can be written with or without
+// the leading '@'.
+func (e *LoadDataController) GenerateJSONLConfig() *mydump.JSONLConfig {
+	columns := make([]string, 0, len(e.FieldMappings))
+	for _, fieldMapping := range e.FieldMappings {
+		columns = append(columns, fieldMapping.name())
+	}
+	var paths map[string]string
+	if len(e.JSONPaths) > 0 {
+		paths = make(map[string]string, len(e.JSONPaths))
+		for name, path := range e.JSONPaths {
+			paths[strings.TrimPrefix(name, "@")] = path
+		}
+	}
+	return &mydump.JSONLConfig{
+		Columns: columns,
+		Paths:   paths,
+	}
+}
+
+// checkJSONPaths checks that each path in json_paths is for an input field.
+func (e *LoadDataController) checkJSONPaths() error {
+	for name := range e.JSONPaths {
+		found := false
+		for _, fieldMapping := range e.FieldMappings {
+			if strings.EqualFold(fieldMapping.name(), strings.TrimPrefix(name, "@")) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(
+				fmt.Sprintf("%s, '%s' is not a column or user variable in the field list", jsonPathsOption, name))
+		}
+	}
+	return nil
+}
+
 // InitDataStore initializes the data store.
 func (e *LoadDataController) InitDataStore(ctx context.Context) error {
 	u, err2 := storage.ParseRawURL(e.Path)
@@ -1191,6 +1284,10 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatJSONL:
+		return mydump.SourceTypeJSONL
+	case DataFormatAvro:
+		return mydump.SourceTypeAvro
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1269,6 +1366,16 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatJSONL:
+		parser, err = mydump.NewJSONLParser(
+			ctx,
+			e.GenerateJSONLConfig(),
+			reader,
+			LoadDataReadBlockSize,
+			nil,
+		)
+	case DataFormatAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
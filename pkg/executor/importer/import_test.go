@@ -177,6 +177,45 @@ func TestInitOptionsPositiveCase(t *testing.T) {
 	require.Equal(t, "", plan.CloudStorageURI, sql4)
 }
 
+func TestInitJSONPathsOption(t *testing.T) {
+	sctx := mock.NewContext()
+	defer sctx.Close()
+	ctx := tikvutil.WithInternalSourceType(context.Background(), tidbkv.InternalImportInto)
+
+	p := parser.New()
+	getOptions := func(sql string) []*plannercore.LoadDataOpt {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err, sql)
+		options := []*plannercore.LoadDataOpt{}
+		for _, opt := range stmt.(*ast.ImportIntoStmt).Options {
+			loadDataOpt := plannercore.LoadDataOpt{Name: opt.Name}
+			if opt.Value != nil {
+				loadDataOpt.Value, err = plannerutil.RewriteAstExprWithPlanCtx(sctx, opt.Value, nil, nil, false)
+				require.NoError(t, err)
+			}
+			options = append(options, &loadDataOpt)
+		}
+		return options
+	}
+
+	options := getOptions("import into t from '/file.jsonl' format 'jsonl' with json_paths='a=$.user.id, b=$.tags[0]'")
+	plan := &Plan{Format: DataFormatJSONL}
+	require.NoError(t, plan.initOptions(ctx, sctx, options))
+	require.Equal(t, map[string]string{"a": "$.user.id", "b": "$.tags[0]"}, plan.JSONPaths)
+
+	plan = &Plan{Format: DataFormatCSV}
+	require.ErrorIs(t, plan.initOptions(ctx, sctx, options), exeerrors.ErrLoadDataUnsupportedOption)
+	plan = &Plan{Format: DataFormatJSONL}
+	options = getOptions("import into t from '/file.jsonl' format 'jsonl' with skip_rows=1")
+	require.ErrorIs(t, plan.initOptions(ctx, sctx, options), exeerrors.ErrLoadDataUnsupportedOption)
+
+	for _, val := range []string{"''", "'a=user.id'", "'a=$.b[x]'", "1"} {
+		options = getOptions("import into t from '/file.jsonl' format 'jsonl' with json_paths=" + val)
+		plan = &Plan{Format: DataFormatJSONL}
+		require.ErrorIs(t, plan.initOptions(ctx, sctx, options), exeerrors.ErrInvalidOptionVal, val)
+	}
+}
+
 func TestAdjustOptions(t *testing.T) {
 	plan := &Plan{
 		DiskQuota:      1,
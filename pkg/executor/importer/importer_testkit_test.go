@@ -369,7 +369,7 @@ func TestProcessChunkWith(t *testing.T) {
 		require.NoError(t, err)
 		checksumMap := checksum.GetInnerChecksums()
 		require.Len(t, checksumMap, 1)
-		require.Equal(t, verify.MakeKVChecksum(111, 3, 17951921359894607752), *checksumMap[verify.DataKVGroupID])
+		require.Equal(t, verify.MakeKVChecksum(111, 3, 17525860725273960722), *checksumMap[verify.DataKVGroupID])
 	})
 }
 
@@ -401,3 +401,49 @@ func TestPopulateChunks(t *testing.T) {
 	require.Len(t, engines[1], 1)
 	require.Len(t, engines[common.IndexEngineID], 0)
 }
+
+func TestGenerateJSONLConfig(t *testing.T) {
+	ctx := context.Background()
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int, name varchar(20), tags json)")
+	do, err := session.GetDomain(store)
+	require.NoError(t, err)
+	dbInfo, ok := do.InfoSchema().SchemaByName(ast.NewCIStr("test"))
+	require.True(t, ok)
+	table, err := do.InfoSchema().TableByName(ctx, ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+
+	newController := func(paths string, astArgs *importer.ASTArgs) (*importer.LoadDataController, error) {
+		format := "ndjson"
+		plan, err := importer.NewImportPlan(ctx, tk.Session(), &plannercore.ImportInto{
+			Path:   "/tmp/test.jsonl",
+			Format: &format,
+			Table: &resolve.TableNameW{
+				TableName: &ast.TableName{Name: table.Meta().Name},
+				DBInfo:    dbInfo,
+			},
+			Options: []*plannercore.LoadDataOpt{{Name: "json_paths", Value: expression.NewStrConst(paths)}},
+		}, table)
+		require.NoError(t, err)
+		require.Equal(t, importer.DataFormatJSONL, plan.Format)
+		return importer.NewLoadDataController(plan, table, astArgs)
+	}
+
+	_, err = newController("nonexist=$.a", &importer.ASTArgs{})
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal)
+
+	controller, err := newController("name=$.user.name, @v=$.user.id", &importer.ASTArgs{
+		ColumnsAndUserVars: []*ast.ColumnNameOrUserVar{
+			{UserVar: &ast.VariableExpr{Name: "v"}},
+			{ColumnName: &ast.ColumnName{Name: ast.NewCIStr("name")}},
+			{ColumnName: &ast.ColumnName{Name: ast.NewCIStr("tags")}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &mydump.JSONLConfig{
+		Columns: []string{"v", "name", "tags"},
+		Paths:   map[string]string{"name": "$.user.name", "v": "$.user.id"},
+	}, controller.GenerateJSONLConfig())
+}
@@ -312,7 +312,7 @@ func (ti *TableImporter) getParser(ctx context.Context, chunk *checkpoints.Chunk
 		}
 		parser.SetRowID(chunk.Chunk.PrevRowIDMax)
 	} else {
-		// if we reached here, the file must be an uncompressed CSV file.
+		// if we reached here, the file must be an uncompressed CSV or JSON Lines file.
 		if err = parser.SetPos(chunk.Chunk.Offset, chunk.Chunk.PrevRowIDMax); err != nil {
 			return nil, err
 		}
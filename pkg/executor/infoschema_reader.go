@@ -163,6 +163,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromIndexes(ctx, sctx)
 		case infoschema.TableViews:
 			err = e.setDataFromViews(ctx, sctx)
+		case infoschema.TableRoutines:
+			err = e.setDataFromRoutines(sctx)
+		case infoschema.TableParameters:
+			err = e.setDataFromParameters(sctx)
+		case infoschema.TableTriggers:
+			err = e.setDataFromTriggers(sctx)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -1615,6 +1623,247 @@ func (e *memtableRetriever) setDataFromViews(ctx context.Context, sctx sessionct
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromRoutines(sctx sessionctx.Context) error {
+	loc := sctx.GetSessionVars().Location()
+	return forEachRoutine(sctx, e.is, func(db *model.DBInfo, routine *model.RoutineInfo) {
+		var (
+			dataType, dtdIdentifier any = "", nil
+			desc                    routineTypeDesc
+		)
+		if routine.IsFunction() {
+			desc = describeRoutineType(routine.ReturnType)
+			dataType, dtdIdentifier = desc.dataType, routine.ReturnType.InfoSchemaStr()
+		}
+		var definition any
+		if canShowRoutineBody(sctx, routine) {
+			definition = routine.Body
+		}
+		isDeterministic := "NO"
+		if routine.Deterministic {
+			isDeterministic = "YES"
+		}
+		record := types.MakeDatums(
+			routine.Name.O,              // SPECIFIC_NAME
+			infoschema.CatalogVal,       // ROUTINE_CATALOG
+			db.Name.O,                   // ROUTINE_SCHEMA
+			routine.Name.O,              // ROUTINE_NAME
+			routine.Type.String(),       // ROUTINE_TYPE
+			dataType,                    // DATA_TYPE
+			desc.charMaxLen,             // CHARACTER_MAXIMUM_LENGTH
+			desc.charOctLen,             // CHARACTER_OCTET_LENGTH
+			desc.numericPrecision,       // NUMERIC_PRECISION
+			desc.numericScale,           // NUMERIC_SCALE
+			desc.datetimePrecision,      // DATETIME_PRECISION
+			desc.charset,                // CHARACTER_SET_NAME
+			desc.collation,              // COLLATION_NAME
+			dtdIdentifier,               // DTD_IDENTIFIER
+			"SQL",                       // ROUTINE_BODY
+			definition,                  // ROUTINE_DEFINITION
+			nil,                         // EXTERNAL_NAME
+			"SQL",                       // EXTERNAL_LANGUAGE
+			"SQL",                       // PARAMETER_STYLE
+			isDeterministic,             // IS_DETERMINISTIC
+			routine.DataAccess.String(), // SQL_DATA_ACCESS
+			nil,                         // SQL_PATH
+			routine.Security.String(),   // SECURITY_TYPE
+			types.NewTime(types.FromGoTime(routine.Created.In(loc)), mysql.TypeDatetime, 0),   // CREATED
+			types.NewTime(types.FromGoTime(routine.LastAlter.In(loc)), mysql.TypeDatetime, 0), // LAST_ALTERED
+			routine.SQLMode.String(),     // SQL_MODE
+			routine.Comment,              // ROUTINE_COMMENT
+			definerName(routine.Definer), // DEFINER
+			routine.Charset,              // CHARACTER_SET_CLIENT
+			routine.Collate,              // COLLATION_CONNECTION
+			db.Collate,                   // DATABASE_COLLATION
+		)
+		e.rows = append(e.rows, record)
+		e.recordMemoryConsume(record)
+	})
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	// The last execution time of the events is read from the execution history.
+	exec := sctx.GetRestrictedSQLExecutor()
+	wrappedCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	chunkRows, _, err := exec.ExecRestrictedSQL(wrappedCtx, nil,
+		"SELECT event_id, UNIX_TIMESTAMP(MAX(start_time)) FROM mysql.tidb_event_history GROUP BY event_id")
+	if err != nil {
+		return err
+	}
+	lastExecuted := make(map[int64]time.Time, len(chunkRows))
+	for _, row := range chunkRows {
+		lastExecuted[row.GetInt64(0)] = time.Unix(row.GetInt64(1), 0)
+	}
+
+	loc := sctx.GetSessionVars().Location()
+	return forEachEvent(sctx, e.is, func(db *model.DBInfo, event *model.EventInfo) {
+		eventLoc := eventLocation(event)
+		tp, executeAt, intervalValue, intervalField, starts, ends := "ONE TIME", any(nil), any(nil), any(nil), any(nil), any(nil)
+		if event.IsRecurring() {
+			tp, intervalValue, intervalField = "RECURRING", event.IntervalValue, event.IntervalField.String()
+			starts = types.NewTime(types.FromGoTime(event.Starts.In(eventLoc)), mysql.TypeDatetime, 0)
+			if !event.Ends.IsZero() {
+				ends = types.NewTime(types.FromGoTime(event.Ends.In(eventLoc)), mysql.TypeDatetime, 0)
+			}
+		} else {
+			executeAt = types.NewTime(types.FromGoTime(event.ExecuteAt.In(eventLoc)), mysql.TypeDatetime, 0)
+		}
+		onCompletion := "NOT PRESERVE"
+		if event.Preserve {
+			onCompletion = "PRESERVE"
+		}
+		var last any
+		if t, ok := lastExecuted[event.ID]; ok {
+			last = types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+		}
+		record := types.MakeDatums(
+			infoschema.CatalogVal,         // EVENT_CATALOG
+			db.Name.O,                     // EVENT_SCHEMA
+			event.Name.O,                  // EVENT_NAME
+			definerName(event.Definer),    // DEFINER
+			event.TimeZone,                // TIME_ZONE
+			"SQL",                         // EVENT_BODY
+			event.Body,                    // EVENT_DEFINITION
+			tp,                            // EVENT_TYPE
+			executeAt,                     // EXECUTE_AT
+			intervalValue,                 // INTERVAL_VALUE
+			intervalField,                 // INTERVAL_FIELD
+			event.SQLMode.String(),        // SQL_MODE
+			starts,                        // STARTS
+			ends,                          // ENDS
+			eventStatusName(event.Status), // STATUS
+			onCompletion,                  // ON_COMPLETION
+			types.NewTime(types.FromGoTime(event.Created.In(loc)), mysql.TypeDatetime, 0),     // CREATED
+			types.NewTime(types.FromGoTime(event.LastAltered.In(loc)), mysql.TypeDatetime, 0), // LAST_ALTERED
+			last,          // LAST_EXECUTED
+			event.Comment, // EVENT_COMMENT
+			0,             // ORIGINATOR
+			event.Charset, // CHARACTER_SET_CLIENT
+			event.Collate, // COLLATION_CONNECTION
+			db.Collate,    // DATABASE_COLLATION
+		)
+		e.rows = append(e.rows, record)
+		e.recordMemoryConsume(record)
+	})
+}
+
+func (e *memtableRetriever) setDataFromTriggers(sctx sessionctx.Context) error {
+	loc := sctx.GetSessionVars().Location()
+	return forEachTrigger(sctx, e.is, e.is.AllSchemas(), func(db *model.DBInfo, tbl *model.TableInfo, trigger *model.TriggerInfo, order int) {
+		record := types.MakeDatums(
+			infoschema.CatalogVal,   // TRIGGER_CATALOG
+			db.Name.O,               // TRIGGER_SCHEMA
+			trigger.Name.O,          // TRIGGER_NAME
+			trigger.Event.String(),  // EVENT_MANIPULATION
+			infoschema.CatalogVal,   // EVENT_OBJECT_CATALOG
+			db.Name.O,               // EVENT_OBJECT_SCHEMA
+			tbl.Name.O,              // EVENT_OBJECT_TABLE
+			order,                   // ACTION_ORDER
+			nil,                     // ACTION_CONDITION
+			trigger.Body,            // ACTION_STATEMENT
+			"ROW",                   // ACTION_ORIENTATION
+			trigger.Timing.String(), // ACTION_TIMING
+			nil,                     // ACTION_REFERENCE_OLD_TABLE
+			nil,                     // ACTION_REFERENCE_NEW_TABLE
+			"OLD",                   // ACTION_REFERENCE_OLD_ROW
+			"NEW",                   // ACTION_REFERENCE_NEW_ROW
+			types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeDatetime, 2), // CREATED
+			trigger.SQLMode.String(),     // SQL_MODE
+			definerName(trigger.Definer), // DEFINER
+			trigger.Charset,              // CHARACTER_SET_CLIENT
+			trigger.Collate,              // COLLATION_CONNECTION
+			db.Collate,                   // DATABASE_COLLATION
+		)
+		e.rows = append(e.rows, record)
+		e.recordMemoryConsume(record)
+	})
+}
+
+func (e *memtableRetriever) setDataFromParameters(sctx sessionctx.Context) error {
+	// The lengths and precisions are VARCHAR columns in PARAMETERS.
+	toString := func(v any) any {
+		if v == nil {
+			return nil
+		}
+		return fmt.Sprint(v)
+	}
+	appendParam := func(db *model.DBInfo, routine *model.RoutineInfo, pos int, mode, name any, tp *types.FieldType) {
+		desc := describeRoutineType(tp)
+		record := types.MakeDatums(
+			infoschema.CatalogVal,            // SPECIFIC_CATALOG
+			db.Name.O,                        // SPECIFIC_SCHEMA
+			routine.Name.O,                   // SPECIFIC_NAME
+			strconv.Itoa(pos),                // ORDINAL_POSITION
+			mode,                             // PARAMETER_MODE
+			name,                             // PARAMETER_NAME
+			desc.dataType,                    // DATA_TYPE
+			toString(desc.charMaxLen),        // CHARACTER_MAXIMUM_LENGTH
+			toString(desc.charOctLen),        // CHARACTER_OCTET_LENGTH
+			toString(desc.numericPrecision),  // NUMERIC_PRECISION
+			toString(desc.numericScale),      // NUMERIC_SCALE
+			toString(desc.datetimePrecision), // DATETIME_PRECISION
+			desc.charset,                     // CHARACTER_SET_NAME
+			desc.collation,                   // COLLATION_NAME
+			tp.InfoSchemaStr(),               // DTD_IDENTIFIER
+			routine.Type.String(),            // ROUTINE_TYPE
+		)
+		e.rows = append(e.rows, record)
+		e.recordMemoryConsume(record)
+	}
+	return forEachRoutine(sctx, e.is, func(db *model.DBInfo, routine *model.RoutineInfo) {
+		// The return value of a stored function is described as the parameter
+		// at position 0 without mode and name.
+		if routine.IsFunction() {
+			appendParam(db, routine, 0, nil, nil, routine.ReturnType)
+		}
+		for i, param := range routine.Params {
+			mode := "IN"
+			switch param.Mode {
+			case ast.MODE_OUT:
+				mode = "OUT"
+			case ast.MODE_INOUT:
+				mode = "INOUT"
+			}
+			appendParam(db, routine, i+1, mode, param.Name.O, param.Type)
+		}
+	})
+}
+
+// routineTypeDesc describes the type of a stored routine parameter or return
+// value in the way of information_schema.
+type routineTypeDesc struct {
+	dataType                              string
+	charMaxLen, charOctLen                any
+	numericPrecision, numericScale        any
+	datetimePrecision, charset, collation any
+}
+
+func describeRoutineType(ft *types.FieldType) routineTypeDesc {
+	desc := routineTypeDesc{dataType: types.TypeToStr(ft.GetType(), ft.GetCharset())}
+	flen, decimal := ft.GetFlen(), ft.GetDecimal()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if flen == types.UnspecifiedLength {
+		flen = defaultFlen
+	}
+	if decimal == types.UnspecifiedLength {
+		decimal = defaultDecimal
+	}
+	switch {
+	case types.IsString(ft.GetType()) || ft.GetType() == mysql.TypeEnum || ft.GetType() == mysql.TypeSet:
+		desc.charMaxLen, desc.charOctLen = flen, calcCharOctLength(flen, ft.GetCharset())
+		if ft.GetCharset() != charset.CharsetBin {
+			desc.charset, desc.collation = ft.GetCharset(), ft.GetCollate()
+		}
+	case types.IsTypeFractionable(ft.GetType()):
+		desc.datetimePrecision = decimal
+	case types.IsTypeNumeric(ft.GetType()):
+		desc.numericPrecision = getNumericPrecision(ft, flen)
+		if decimal != types.UnspecifiedLength {
+			desc.numericScale = decimal
+		}
+	}
+	return desc
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx sessionctx.Context) (err error) {
 	tikvStore, ok := sctx.GetStore().(helper.Storage)
 	if !ok {
@@ -525,7 +525,7 @@ func (e *InsertExec) doDupRowUpdate(
 		handle, oldRow, newData,
 		0, generated, e.evalBuffer4Dup, errorHandler,
 		assignFlag, e.Table,
-		true, e.memTracker, e.fkChecks, e.fkCascades, dupKeyMode, e.ignoreErr)
+		true, e.memTracker, e.fkChecks, e.fkCascades, e.triggers, e.mviewLogs, dupKeyMode, e.ignoreErr)
 
 	if ignored {
 		return nil
@@ -584,3 +584,11 @@ func (e *InsertExec) GetFKCascades() []*FKCascadeExec {
 func (e *InsertExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithTrigger interface.
+func (e *InsertExec) GetTriggers() []*TriggerExec {
+	if e.triggers == nil {
+		return nil
+	}
+	return []*TriggerExec{e.triggers}
+}
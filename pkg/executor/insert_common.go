@@ -95,6 +95,8 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	triggers   *TriggerExec
+	mviewLogs  *mviewLogWriter
 
 	ignoreErr bool
 }
@@ -206,7 +208,8 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 	batchSize := sessVars.DMLBatchSize
 	batchInsert := sessVars.BatchInsert && !sessVars.InTxn() && vardef.EnableBatchDML.Load() && batchSize > 0
 
-	e.lazyFillAutoID = true
+	// The BEFORE INSERT triggers need to see the auto increment value of each row.
+	e.lazyFillAutoID = !e.triggers.hasBefore(ast.TriggerInsert)
 	evalRowFunc := e.fastEvalRow
 	if !e.allAssignmentsAreConstant {
 		evalRowFunc = e.evalRow
@@ -693,6 +696,11 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 		}
 	}
 
+	// The BEFORE INSERT triggers may change the values, generated columns are evaluated after them.
+	if err := e.triggers.fireBefore(ctx, ast.TriggerInsert, nil, row); err != nil {
+		return nil, err
+	}
+
 	// Handle exchange partition
 	tbl := e.Table.Meta()
 	if tbl.ExchangePartitionInfo != nil && tbl.GetPartitionInfo() == nil {
@@ -1364,6 +1372,9 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	if err = e.triggers.fireBefore(ctx, ast.TriggerDelete, oldRow, nil); err != nil {
+		return false, err
+	}
 	if ph, ok := handle.(kv.PartitionHandle); ok {
 		err = e.Table.(table.PartitionedTable).GetPartition(ph.PartitionID).RemoveRecord(e.Ctx().GetTableCtx(), txn, ph.Handle, oldRow)
 	} else {
@@ -1376,6 +1387,10 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	if err = e.mviewLogs.log(e.Ctx(), oldRow); err != nil {
+		return false, err
+	}
+	e.triggers.queueAfter(ast.TriggerDelete, oldRow, nil)
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
@@ -1440,6 +1455,10 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 		// update the TTL metrics if the table is a TTL table
 		vars.TxnCtx.InsertTTLRowsCount++
 	}
+	if err = e.mviewLogs.log(e.Ctx(), row); err != nil {
+		return err
+	}
+	e.triggers.queueAfter(ast.TriggerInsert, nil, row)
 
 	return nil
 }
@@ -0,0 +1,239 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec evaluates the JSON_TABLE() table function. All the rows are generated
+// when it's opened, Apply reopens it for each outer row.
+type JSONTableExec struct {
+	exec.BaseExecutorV2
+
+	evalCtx   expression.EvalContext
+	typeCtx   types.Context
+	jsonExpr  expression.Expression
+	root      *logicalop.JSONTablePath
+	tableName string
+	// offsets maps the columns of JSON_TABLE to their offsets in the schema, the
+	// columns pruned by the optimizer are not in it.
+	offsets map[*logicalop.JSONTableColumn]int
+
+	row    []types.Datum
+	result *chunk.Chunk
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(context.Context) error {
+	if e.result == nil {
+		e.result = exec.NewFirstChunk(e)
+		e.row = make([]types.Datum, e.Schema().Len())
+	}
+	e.result.Reset()
+	e.cursor = 0
+	doc, isNull, err := e.jsonExpr.EvalJSON(e.evalCtx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	_, err = e.generate(e.root, doc)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for ; e.cursor < e.result.NumRows() && !req.IsFull(); e.cursor++ {
+		req.AppendRow(e.result.GetRow(e.cursor))
+	}
+	return nil
+}
+
+// generate generates the rows for each value matched by the path, and returns the
+// number of generated rows.
+func (e *JSONTableExec) generate(path *logicalop.JSONTablePath, doc types.BinaryJSON) (int, error) {
+	count := 0
+	for i, value := range doc.ExtractAll(path.Path) {
+		for _, col := range path.Columns {
+			offset, ok := e.offsets[col]
+			if !ok {
+				continue
+			}
+			d, err := e.evalColumn(col, i+1, value)
+			if err != nil {
+				return 0, err
+			}
+			d.Copy(&e.row[offset])
+		}
+		if len(path.Nested) == 0 {
+			e.appendRow()
+			count++
+			continue
+		}
+		n, err := e.generateNested(path.Nested, value)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// generateNested generates the rows for the sibling NESTED PATH clauses one by one,
+// the columns of the other siblings are NULL. A row with all the nested columns NULL
+// is generated if none of the nested paths matches anything.
+func (e *JSONTableExec) generateNested(nested []*logicalop.JSONTablePath, doc types.BinaryJSON) (int, error) {
+	for _, path := range nested {
+		e.resetColumns(path)
+	}
+	count := 0
+	for _, path := range nested {
+		n, err := e.generate(path, doc)
+		if err != nil {
+			return 0, err
+		}
+		e.resetColumns(path)
+		count += n
+	}
+	if count == 0 {
+		e.appendRow()
+		count = 1
+	}
+	return count, nil
+}
+
+func (e *JSONTableExec) resetColumns(path *logicalop.JSONTablePath) {
+	for _, col := range path.Columns {
+		if offset, ok := e.offsets[col]; ok {
+			e.row[offset].SetNull()
+		}
+	}
+	for _, nested := range path.Nested {
+		e.resetColumns(nested)
+	}
+}
+
+func (e *JSONTableExec) appendRow() {
+	for i := range e.row {
+		e.result.AppendDatum(i, &e.row[i])
+	}
+}
+
+// evalColumn evaluates the column against the value matched by its row path, ordinal
+// is the position of the value in all the matched values.
+func (e *JSONTableExec) evalColumn(col *logicalop.JSONTableColumn, ordinal int, value types.BinaryJSON) (types.Datum, error) {
+	tp := col.Column.RetType
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinal)), nil
+	case ast.JSONTableColumnExistsPath:
+		d := types.NewIntDatum(0)
+		if len(value.ExtractAll(col.Path)) > 0 {
+			d.SetInt64(1)
+		}
+		d, err := d.ConvertTo(e.typeCtx, tp)
+		if err != nil {
+			return jsonTableRespond(col.OnError, err)
+		}
+		return d, nil
+	}
+	values := value.ExtractAll(col.Path)
+	if len(values) == 0 {
+		return jsonTableRespond(col.OnEmpty, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	if len(values) > 1 {
+		return jsonTableRespond(col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.tableName))
+	}
+	if tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(values[0]), nil
+	}
+	var d types.Datum
+	v := values[0]
+	switch v.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return jsonTableRespond(col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.tableName))
+	case types.JSONTypeCodeLiteral:
+		switch v.Value[0] {
+		case types.JSONLiteralNil:
+			return types.Datum{}, nil
+		case types.JSONLiteralTrue:
+			d = jsonBoolDatum(tp, true)
+		default:
+			d = jsonBoolDatum(tp, false)
+		}
+	case types.JSONTypeCodeInt64:
+		d = types.NewIntDatum(v.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d = types.NewUintDatum(v.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d = types.NewFloat64Datum(v.GetFloat64())
+	case types.JSONTypeCodeDate, types.JSONTypeCodeDatetime, types.JSONTypeCodeTimestamp:
+		d = types.NewTimeDatum(v.GetTime())
+	case types.JSONTypeCodeDuration:
+		d = types.NewDurationDatum(v.GetDuration())
+	default:
+		str, err := v.Unquote()
+		if err != nil {
+			return jsonTableRespond(col.OnError, err)
+		}
+		d = types.NewStringDatum(str)
+	}
+	result, err := d.ConvertTo(e.typeCtx, tp)
+	if err != nil {
+		return jsonTableRespond(col.OnError, err)
+	}
+	return result, nil
+}
+
+// jsonTableRespond returns the value of the ON EMPTY / ON ERROR clause, the default behavior
+// is NULL if the clause is not specified.
+func jsonTableRespond(resp *logicalop.JSONTableResponse, err error) (types.Datum, error) {
+	if resp == nil {
+		return types.Datum{}, nil
+	}
+	switch resp.Tp {
+	case ast.JSONTableResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableResponseDefault:
+		return resp.Default, nil
+	default:
+		return types.Datum{}, nil
+	}
+}
+
+func jsonBoolDatum(tp *types.FieldType, b bool) types.Datum {
+	if types.IsString(tp.GetType()) {
+		if b {
+			return types.NewStringDatum("true")
+		}
+		return types.NewStringDatum("false")
+	}
+	if b {
+		return types.NewIntDatum(1)
+	}
+	return types.NewIntDatum(0)
+}
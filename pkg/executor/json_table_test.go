@@ -0,0 +1,92 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestJSONTableBasic(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"b": [1]}]', '$[*]' columns (
+		id for ordinality,
+		a int path '$.a',
+		b varchar(10) path '$.b' default '"none"' on empty default '"bad"' on error,
+		has_a int exists path '$.a')) as jt`).Check(testkit.Rows(
+		"1 1 x 1",
+		"2 2 none 1",
+		"3 <nil> bad 0",
+	))
+	tk.MustQuery(`select jt.a from json_table('{"a": true, "b": null}', '$' columns (a varchar(10) path '$.a', b int path '$.b')) jt`).
+		Check(testkit.Rows("true"))
+	tk.MustQuery(`select * from json_table('{"a": {"b": 1}}', '$' columns (a json path '$.a')) jt`).
+		Check(testkit.Rows(`{"b": 1}`))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) jt`).Check(testkit.Rows())
+	tk.MustQuery(`select count(*) from json_table('[1, 2, 3]', '$[*]' columns (a int path '$')) jt`).Check(testkit.Rows("3"))
+	tk.MustHavePlan(`select * from json_table('[1]', '$[*]' columns (a int path '$')) jt`, "JSONTable")
+
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) jt`, errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) jt`, errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$', a int path '$')) jt`, errno.ErrDupFieldName)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$' default 'x' on empty)) jt`, errno.ErrInvalidDefault)
+	tk.MustGetErrCode(`select * from json_table(b, '$[*]' columns (a int path '$')) jt`, errno.ErrBadField)
+}
+
+func TestJSONTableNested(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [1, 2], "c": ["x"]}, {"a": 2, "b": [], "c": []}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b_id for ordinality, b int path '$'),
+		nested path '$.c[*]' columns (c varchar(10) path '$'))) jt`).Check(testkit.Rows(
+		"1 1 1 <nil>",
+		"1 2 2 <nil>",
+		"1 <nil> <nil> x",
+		"2 <nil> <nil> <nil>",
+	))
+	tk.MustQuery(`select * from json_table('{"a": [{"b": [1, 2]}, {"b": [3]}]}', '$.a[*]' columns (
+		nested path '$.b[*]' columns (b int path '$'))) jt`).Check(testkit.Rows("1", "2", "3"))
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, doc json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[]'), (3, '[3]'), (4, null)`)
+
+	tk.MustQuery(`select t.id, jt.a from t, json_table(t.doc, '$[*]' columns (a int path '$')) as jt order by t.id, jt.a`).
+		Check(testkit.Rows("1 1", "1 2", "3 3"))
+	tk.MustQuery(`select t.id, jt.a from t join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on jt.a > 1 order by t.id, jt.a`).
+		Check(testkit.Rows("1 2", "3 3"))
+	tk.MustQuery(`select t.id, jt.a from t left join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true order by t.id, jt.a`).
+		Check(testkit.Rows("1 1", "1 2", "2 <nil>", "3 3", "4 <nil>"))
+	tk.MustQuery(`select t.id, jt.a from t, json_table(t.doc, '$[*]' columns (a int path '$')) as jt where jt.a = 3`).
+		Check(testkit.Rows("3 3"))
+	tk.MustQuery(`select (select sum(jt.a) from json_table(t.doc, '$[*]' columns (a int path '$')) jt) from t order by t.id`).
+		Check(testkit.Rows("3", "<nil>", "3", "<nil>"))
+	tk.MustHavePlan(`select * from t, json_table(t.doc, '$[*]' columns (a int path '$')) as jt`, "Apply")
+
+	tk.MustGetErrCode(`select * from t right join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true`, errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode(`select * from json_table(t.doc, '$[*]' columns (a int path '$')) as jt, t`, errno.ErrBadField)
+}
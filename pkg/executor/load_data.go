@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -334,6 +335,10 @@ func createInsertValues(e *LoadDataWorker) (insertVal *InsertValues, err error)
 			break
 		}
 	}
+	mviewLogs, err := buildMViewLogWriter(e.UserSctx.GetInfoSchema().(infoschema.InfoSchema), e.table)
+	if err != nil {
+		return nil, err
+	}
 	ret := &InsertValues{
 		BaseExecutor:   exec.NewBaseExecutor(e.UserSctx, nil, e.planInfo.ID),
 		Table:          e.table,
@@ -343,6 +348,7 @@ func createInsertValues(e *LoadDataWorker) (insertVal *InsertValues, err error)
 		insertColumns:  insertColumns,
 		rowLen:         len(insertColumns),
 		hasExtraHandle: hasExtraHandle,
+		mviewLogs:      mviewLogs,
 	}
 	if len(insertColumns) > 0 {
 		ret.initEvalBuffer()
@@ -0,0 +1,473 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// mviewLogTableName is the name of the table logging the groups of the
+// materialized views changed by the DML statements.
+const mviewLogTableName = "tidb_mview_log"
+
+// mviewRefreshBatchSize is the number of the groups refreshed by one
+// statement in the incremental refresh.
+const mviewRefreshBatchSize = 256
+
+// mviewLogTarget is an incrementally refreshed materialized view of a table.
+type mviewLogTarget struct {
+	id int64
+	// offsets are the offsets of the GROUP BY columns in the rows of the base
+	// table.
+	offsets []int
+}
+
+// mviewLogWriter logs the groups changed by a DML statement for the
+// incrementally refreshed materialized views of a table. The log is appended
+// in the same transaction as the changes, so it's consistent with the data of
+// the base table. A group is logged only once for a statement.
+type mviewLogWriter struct {
+	logTbl  table.Table
+	targets []mviewLogTarget
+	logged  map[string]struct{}
+}
+
+// buildMViewLogWriters builds the log writers of the tables modified by the
+// statement, the map is tableID -> *mviewLogWriter. Unlike the triggers, the
+// changes made by the foreign key cascades are logged as well.
+func (b *executorBuilder) buildMViewLogWriters(tblID2Table map[int64]table.Table) (map[int64]*mviewLogWriter, error) {
+	var writers map[int64]*mviewLogWriter
+	for id, tbl := range tblID2Table {
+		w, err := buildMViewLogWriter(b.is, tbl)
+		if err != nil {
+			return nil, err
+		}
+		if w != nil {
+			if writers == nil {
+				writers = make(map[int64]*mviewLogWriter, len(tblID2Table))
+			}
+			writers[id] = w
+		}
+	}
+	return writers, nil
+}
+
+// buildMViewLogWriter builds the log writer of the table, it returns nil if
+// no incrementally refreshed materialized view is defined on the table. The
+// statement fails if the changes can't be logged, otherwise the views would
+// silently miss the changes.
+func buildMViewLogWriter(is infoschema.InfoSchema, tbl table.Table) (*mviewLogWriter, error) {
+	tblInfo := tbl.Meta()
+	if len(tblInfo.MaterializedViews) == 0 {
+		return nil, nil
+	}
+	w := &mviewLogWriter{logged: make(map[string]struct{})}
+	for _, id := range tblInfo.MaterializedViews {
+		// The view may be dropped concurrently.
+		mvInfo, ok := is.TableInfoByID(id)
+		if !ok || !mvInfo.IsMaterializedView() || !mvInfo.MaterializedView.IsIncremental() ||
+			mvInfo.MaterializedView.BaseTableID != tblInfo.ID {
+			continue
+		}
+		target := mviewLogTarget{id: id, offsets: make([]int, 0, len(mvInfo.MaterializedView.GroupBy))}
+		for _, gb := range mvInfo.MaterializedView.GroupBy {
+			col := table.FindCol(tbl.Cols(), gb.BaseColumn.L)
+			if col == nil {
+				return nil, errors.Errorf("the GROUP BY column '%s' of materialized view '%s' is missing in table '%s'",
+					gb.BaseColumn.O, mvInfo.Name.O, tblInfo.Name.O)
+			}
+			target.offsets = append(target.offsets, col.Offset)
+		}
+		w.targets = append(w.targets, target)
+	}
+	if len(w.targets) == 0 {
+		return nil, nil
+	}
+	logTbl, err := is.TableByName(context.Background(), ast.NewCIStr(mysql.SystemDB), ast.NewCIStr(mviewLogTableName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w.logTbl = logTbl
+	return w, nil
+}
+
+// log logs the groups of the rows, the rows are nil if absent.
+func (w *mviewLogWriter) log(sctx sessionctx.Context, rows ...[]types.Datum) error {
+	if w == nil {
+		return nil
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		for _, target := range w.targets {
+			vals := make([]types.Datum, 0, len(target.offsets))
+			for _, offset := range target.offsets {
+				vals = append(vals, row[offset])
+			}
+			key, err := codec.EncodeKey(loc, nil, vals...)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			loggedKey := strconv.FormatInt(target.id, 10) + ":" + string(key)
+			if _, ok := w.logged[loggedKey]; ok {
+				continue
+			}
+			w.logged[loggedKey] = struct{}{}
+			record := []types.Datum{types.NewIntDatum(target.id), types.NewBytesDatum(key)}
+			if _, err = w.logTbl.AddRecord(sctx.GetTableCtx(), txn, record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	if err := e.ddlExecutor.CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	// The view is populated once created.
+	return e.refreshMaterializedView(ctx, s.ViewName, ast.MViewRefreshComplete, true)
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropMaterializedViewStmt) error {
+	return e.ddlExecutor.DropMaterializedView(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	return e.refreshMaterializedView(ctx, s.ViewName, s.Method, false)
+}
+
+// refreshMaterializedView refreshes the result of the materialized view in an
+// internal transaction, then records the start TS of the transaction as the
+// snapshot reflected by the result. The incremental refresh recomputes the
+// groups logged since the last refresh, and removes the logs it has consumed.
+// If onCreate is true, the view is refreshed only if it's never refreshed.
+func (e *DDLExec) refreshMaterializedView(ctx context.Context, name *ast.TableName, method ast.MViewRefreshMethod, onCreate bool) error {
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	tbl, err := is.TableByName(ctx, name.Schema, name.Name)
+	if err != nil {
+		return err
+	}
+	tblInfo := tbl.Meta()
+	if !tblInfo.IsMaterializedView() {
+		// CREATE MATERIALIZED VIEW IF NOT EXISTS may meet another object.
+		if onCreate {
+			return nil
+		}
+		return dbterror.ErrWrongObject.GenWithStackByArgs(name.Schema, name.Name, "MATERIALIZED VIEW")
+	}
+	mv := tblInfo.MaterializedView
+	if onCreate && mv.LastRefreshTS != 0 {
+		return nil
+	}
+	if method == ast.MViewRefreshDefault {
+		method = mv.RefreshMethod
+	}
+	if method == ast.MViewRefreshFast {
+		if !mv.IsIncremental() {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+				"REFRESH FAST on a materialized view which can't be refreshed incrementally")
+		}
+		// The view is refreshed completely for the first time.
+		if mv.LastRefreshTS == 0 {
+			method = ast.MViewRefreshComplete
+		}
+	}
+
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, se)
+	restore, err := setMViewSessionVars(se, mv)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	r := &mviewRefresher{se: se, exec: se.GetSQLExecutor(), name: name, tblInfo: tblInfo}
+	if _, err = r.exec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	txn, err := se.Txn(true)
+	if err != nil {
+		return err
+	}
+	refreshTS := txn.StartTS()
+	if method == ast.MViewRefreshFast {
+		err = r.refreshFast(ctx, is)
+	} else {
+		err = r.refreshComplete(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = r.exec.ExecuteInternal(ctx, "COMMIT"); err != nil {
+		return err
+	}
+	ident := ast.Ident{Schema: name.Schema, Name: name.Name}
+	return e.ddlExecutor.RefreshMaterializedView(e.Ctx(), ident, refreshTS)
+}
+
+// setMViewSessionVars sets the session variables in effect when the view was
+// created, it returns a function to restore the original ones.
+func setMViewSessionVars(se sessionctx.Context, mv *model.MaterializedViewInfo) (func(), error) {
+	vars := se.GetSessionVars()
+	// The definition is restored with backslash escapes.
+	sqlMode := mv.SQLMode &^ mysql.ModeNoBackslashEscapes
+	toSet := map[string]string{
+		vardef.TimeZone:            mv.TimeZone,
+		vardef.SQLModeVar:          sqlMode.String(),
+		vardef.CollationConnection: mv.Collate,
+	}
+	origin := make(map[string]string, len(toSet))
+	restore := func() {
+		for name, val := range origin {
+			_ = vars.SetSystemVar(name, val)
+		}
+	}
+	for name, val := range toSet {
+		if val == "" {
+			continue
+		}
+		old, err := vars.GetSessionOrGlobalSystemVar(context.Background(), name)
+		if err != nil {
+			restore()
+			return nil, err
+		}
+		origin[name] = old
+		if err = vars.SetSystemVar(name, val); err != nil {
+			restore()
+			return nil, err
+		}
+	}
+	return restore, nil
+}
+
+// mviewRefresher refreshes a materialized view in a system session.
+type mviewRefresher struct {
+	se      sessionctx.Context
+	exec    sqlexec.SQLExecutor
+	name    *ast.TableName
+	tblInfo *model.TableInfo
+}
+
+func (r *mviewRefresher) query(ctx context.Context, sql string, args ...any) ([]chunk.Row, error) {
+	rs, err := r.exec.ExecuteInternal(ctx, sql, args...)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rs.Close()
+	}()
+	return sqlexec.DrainRecordSet(ctx, rs, 1024)
+}
+
+func (r *mviewRefresher) refreshComplete(ctx context.Context) error {
+	if _, err := r.query(ctx, "DELETE FROM %n.%n", r.name.Schema.O, r.tblInfo.Name.O); err != nil {
+		return err
+	}
+	if _, err := r.query(ctx, "INSERT INTO %n.%n "+r.tblInfo.MaterializedView.Definition, r.name.Schema.O, r.tblInfo.Name.O); err != nil {
+		return err
+	}
+	return r.removeLogs(ctx)
+}
+
+func (r *mviewRefresher) removeLogs(ctx context.Context) error {
+	_, err := r.query(ctx, "DELETE FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, mviewLogTableName, r.tblInfo.ID)
+	return err
+}
+
+func (r *mviewRefresher) refreshFast(ctx context.Context, is infoschema.InfoSchema) error {
+	mv := r.tblInfo.MaterializedView
+	base, ok := is.TableByID(ctx, mv.BaseTableID)
+	if !ok {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(r.name.Schema, mv.BaseTableID)
+	}
+	fts := make([]*types.FieldType, 0, len(mv.GroupBy))
+	for _, gb := range mv.GroupBy {
+		col := table.FindCol(base.Cols(), gb.BaseColumn.L)
+		if col == nil {
+			return errors.Errorf("the GROUP BY column %s of the materialized view %s doesn't exist", gb.BaseColumn.O, r.tblInfo.Name.O)
+		}
+		fts = append(fts, &col.FieldType)
+	}
+	// The filter on the base table is built on the AST of the definition.
+	p := parser.New()
+	p.SetSQLMode(r.se.GetSessionVars().SQLMode)
+	stmt, err := p.ParseOneStmt(mv.Definition, "", "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return errors.Errorf("the definition of the materialized view %s is not a SELECT statement", r.tblInfo.Name.O)
+	}
+	qualifier, err := mviewBaseQualifier(sel)
+	if err != nil {
+		return err
+	}
+	where := sel.Where
+
+	rows, err := r.query(ctx, "SELECT DISTINCT group_key FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, mviewLogTableName, r.tblInfo.ID)
+	if err != nil {
+		return err
+	}
+	loc := r.se.GetSessionVars().Location()
+	for start := 0; start < len(rows); start += mviewRefreshBatchSize {
+		end := min(start+mviewRefreshBatchSize, len(rows))
+		var viewCond, baseCond strings.Builder
+		for i := start; i < end; i++ {
+			vals, err := codec.Decode(rows[i].GetBytes(0), len(fts))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if i > start {
+				viewCond.WriteString(" OR ")
+				baseCond.WriteString(" OR ")
+			}
+			viewCond.WriteString("(")
+			baseCond.WriteString("(")
+			for j, gb := range mv.GroupBy {
+				val, err := tablecodec.Unflatten(vals[j], fts[j], loc)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				lit, err := mviewLiteral(val)
+				if err != nil {
+					return err
+				}
+				if j > 0 {
+					viewCond.WriteString(" AND ")
+					baseCond.WriteString(" AND ")
+				}
+				viewCond.WriteString(sqlescape.MustEscapeSQL("%n <=> ", gb.ViewColumn.O) + lit)
+				baseCond.WriteString(qualifier + sqlescape.MustEscapeSQL("%n <=> ", gb.BaseColumn.O) + lit)
+			}
+			viewCond.WriteString(")")
+			baseCond.WriteString(")")
+		}
+
+		if _, err = r.query(ctx, "DELETE FROM %n.%n WHERE "+viewCond.String(), r.name.Schema.O, r.tblInfo.Name.O); err != nil {
+			return err
+		}
+		cond, err := p.ParseOneStmt("SELECT 1 FROM DUAL WHERE "+baseCond.String(), "", "")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sel.Where = cond.(*ast.SelectStmt).Where
+		if where != nil {
+			sel.Where = &ast.BinaryOperationExpr{Op: opcode.LogicAnd, L: &ast.ParenthesesExpr{Expr: where}, R: sel.Where}
+		}
+		var sb strings.Builder
+		restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+		if err = sel.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err = r.query(ctx, "INSERT INTO %n.%n "+sb.String(), r.name.Schema.O, r.tblInfo.Name.O); err != nil {
+			return err
+		}
+	}
+	return r.removeLogs(ctx)
+}
+
+// mviewBaseQualifier returns the qualifier of the columns of the base table
+// in the definition of an incrementally refreshed materialized view.
+func mviewBaseQualifier(sel *ast.SelectStmt) (string, error) {
+	if sel.From != nil {
+		if ts, ok := sel.From.TableRefs.Left.(*ast.TableSource); ok {
+			if ts.AsName.L != "" {
+				return sqlescape.MustEscapeSQL("%n.", ts.AsName.O), nil
+			}
+			if tn, ok := ts.Source.(*ast.TableName); ok {
+				return sqlescape.MustEscapeSQL("%n.%n.", tn.Schema.O, tn.Name.O), nil
+			}
+		}
+	}
+	return "", errors.New("the definition of the materialized view doesn't read from a single table")
+}
+
+// mviewLiteral formats the value of a GROUP BY column as a SQL literal, which
+// is compared with the column by <=>.
+func mviewLiteral(d types.Datum) (string, error) {
+	switch d.Kind() {
+	case types.KindNull:
+		return "NULL", nil
+	case types.KindInt64:
+		return strconv.FormatInt(d.GetInt64(), 10), nil
+	case types.KindUint64:
+		return strconv.FormatUint(d.GetUint64(), 10), nil
+	case types.KindFloat32:
+		// The column is compared in double.
+		return strconv.FormatFloat(float64(d.GetFloat32()), 'g', -1, 64), nil
+	case types.KindFloat64:
+		return strconv.FormatFloat(d.GetFloat64(), 'g', -1, 64), nil
+	case types.KindMysqlDecimal:
+		return d.GetMysqlDecimal().String(), nil
+	case types.KindBytes:
+		return sqlescape.EscapeSQL("%?", d.GetBytes())
+	case types.KindString:
+		if d.Collation() == charset.CollationBin {
+			return sqlescape.EscapeSQL("%?", d.GetBytes())
+		}
+		return sqlescape.EscapeSQL("%?", d.GetString())
+	case types.KindMysqlBit, types.KindBinaryLiteral:
+		v, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+		return strconv.FormatUint(v, 10), err
+	case types.KindMysqlEnum:
+		return sqlescape.EscapeSQL("%?", d.GetMysqlEnum().Name)
+	case types.KindMysqlSet:
+		return sqlescape.EscapeSQL("%?", d.GetMysqlSet().Name)
+	case types.KindMysqlJSON:
+		return sqlescape.EscapeSQL("CAST(%? AS JSON)", d.GetMysqlJSON().String())
+	}
+	s, err := d.ToString()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return sqlescape.EscapeSQL("%?", s)
+}
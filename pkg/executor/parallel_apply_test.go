@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/stretchr/testify/require"
 )
@@ -505,3 +506,40 @@ func TestParallelApplyCorrectness(t *testing.T) {
 	tk.MustExec("set tidb_enable_parallel_apply=false")
 	tk.MustQuery(sql).Sort().Check(testkit.Rows("1", "3"))
 }
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table s (a int, c int)")
+	tk.MustExec("insert into t values (1, 10), (2, 20), (3, 30), (null, 40)")
+	tk.MustExec("insert into s values (1, 1), (1, 2), (2, 3), (4, 4)")
+
+	for _, parallel := range []string{"false", "true"} {
+		tk.MustExec(fmt.Sprintf("set tidb_enable_parallel_apply=%s", parallel))
+		tk.MustQuery("select t.a, dt.c from t, lateral (select s.c from s where s.a = t.a) as dt order by t.a, dt.c").
+			Check(testkit.Rows("1 1", "1 2", "2 3"))
+		tk.MustQuery("select t.a, dt.c from t, lateral (select s.c from s where s.a = t.a order by s.c desc limit 1) as dt order by t.a").
+			Check(testkit.Rows("1 2", "2 3"))
+		tk.MustQuery("select t.a, dt.m from t left join lateral (select max(s.c) m, count(*) cnt from s where s.a = t.a having cnt > 0) dt on true order by t.b").
+			Check(testkit.Rows("1 2", "2 3", "3 <nil>", "<nil> <nil>"))
+		tk.MustQuery("select t.a, dt.x from t join lateral (select t.a + t.b as x union all select t.b) as dt on dt.x > 20 order by t.b, dt.x").
+			Check(testkit.Rows("2 22", "3 30", "3 33", "<nil> 40"))
+		tk.MustQuery("select t.a, dt1.c, dt2.d from t, lateral (select s.c from s where s.a = t.a) dt1, lateral (select dt1.c * t.b as d) dt2 order by t.a, dt1.c").
+			Check(testkit.Rows("1 1 10", "1 2 20", "2 3 60"))
+		tk.MustQuery("select t.a, (select sum(dt.c) from lateral (select 1) x, lateral (select s.c from s where s.a = t.a) dt) from t order by t.b").
+			Check(testkit.Rows("1 3", "2 3", "3 <nil>", "<nil> <nil>"))
+	}
+
+	// The correlated derived tables that can be decorrelated are planned as joins.
+	tk.MustNotHavePlan("select * from t, lateral (select s.c from s where s.a = t.a) as dt", "Apply")
+	tk.MustHavePlan("select * from t, lateral (select s.c from s where s.a = t.a order by s.c limit 1) as dt", "Apply")
+	// A LATERAL derived table without outer references is an ordinary derived table.
+	tk.MustNotHavePlan("select * from t, lateral (select * from s) as dt", "Apply")
+	tk.MustQuery("select count(*) from t, lateral (select * from s) as dt").Check(testkit.Rows("16"))
+
+	tk.MustGetErrCode("select * from t right join lateral (select s.c from s where s.a = t.a) dt on true", errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode("select * from lateral (select s.c from s where s.a = t.a) dt, t", errno.ErrBadField)
+	tk.MustGetErrCode("select * from t, (select s.c from s where s.a = t.a) dt", errno.ErrBadField)
+}
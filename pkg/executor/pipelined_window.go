@@ -67,12 +67,15 @@ type PipelinedWindowExec struct {
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
+	// groups records the peer groups of the partition for the GROUPS frame.
+	groups *peerGroups
 
 	// rows keeps rows starting from curStartRow
 	rows                     []chunk.Row
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
+	isGroupsFrame            bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
 }
@@ -88,6 +91,9 @@ func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
 	e.dataIdx, e.curRowIdx, e.dropped, e.rowToConsume, e.accumulated = 0, 0, 0, 0, 0
 	e.lastStartRow, e.lastEndRow, e.stagedStartRow, e.stagedEndRow, e.rowStart, e.rowCnt = 0, 0, 0, 0, 0, 0
 	e.rows, e.data = make([]chunk.Row, 0), make([]dataInfo, 0)
+	if e.groups != nil {
+		e.groups.reset()
+	}
 	return e.BaseExecutor.Open(ctx)
 }
 
@@ -248,6 +254,10 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	if e.start.UnBounded {
 		return 0, nil
 	}
+	if e.isGroupsFrame {
+		e.locatePeerGroup()
+		return e.groups.getStart(e.start, e.rowCnt), nil
+	}
 	if e.isRangeFrame {
 		var start uint64
 		for start = max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
@@ -288,6 +298,10 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	if e.end.UnBounded {
 		return e.rowCnt, nil
 	}
+	if e.isGroupsFrame {
+		e.locatePeerGroup()
+		return e.groups.getEnd(e.end, e.rowCnt), nil
+	}
 	if e.isRangeFrame {
 		var end uint64
 		for end = max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
@@ -324,6 +338,13 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	}
 }
 
+// locatePeerGroup puts the consumed rows into the peer groups and locates the peer group
+// of the current row.
+func (e *PipelinedWindowExec) locatePeerGroup() {
+	e.groups.scan(e.getRow, e.rowCnt)
+	e.groups.locate(e.curRowIdx)
+}
+
 // produce produces rows and append it to chk, return produced means number of rows appended into chunk, available means
 // number of rows processed but not fetched
 func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk, remained uint64) (produced uint64, err error) {
@@ -405,6 +426,10 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		remained--
 	}
 	extend := min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
+	if e.isGroupsFrame && e.groups.scanned > 0 {
+		// The last scanned row is needed to check whether the next row is its peer.
+		extend = min(extend, e.groups.scanned-1)
+	}
 	if extend > e.rowStart {
 		numDrop := extend - e.rowStart
 		e.dropped += numDrop
@@ -447,6 +472,9 @@ func (e *PipelinedWindowExec) reset() {
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
+	if e.groups != nil {
+		e.groups.reset()
+	}
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
@@ -0,0 +1,912 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	contextutil "github.com/pingcap/tidb/pkg/util/context"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// CallProcedure executes the CALL statement.
+//
+// The body of the stored procedure is interpreted here, and every SQL statement
+// inside it is executed by exec as a standalone statement. The statements always
+// run with the privileges of the invoker, a procedure is only created with an
+// explicit SQL SECURITY INVOKER. Only one result set can be returned, a
+// procedure that produces more than one result set fails with ErrSpBadselect.
+func CallProcedure(ctx context.Context, sctx sessionctx.Context, exec sqlexec.SQLExecutor, stmt *ast.CallStmt) (sqlexec.RecordSet, error) {
+	e := &procedureExec{
+		sctx:     sctx,
+		exec:     exec,
+		active:   make(map[string]int),
+		restored: make(map[ast.Node]string),
+	}
+	if err := e.callProcedure(ctx, nil, stmt); err != nil {
+		return nil, err
+	}
+	if e.result == nil {
+		return nil, nil
+	}
+	return e.result, nil
+}
+
+// procedureVar is a parameter or a local variable of a stored procedure.
+type procedureVar struct {
+	tp    *types.FieldType
+	value types.Datum
+}
+
+func (v *procedureVar) valueExpr() ast.ExprNode {
+	ve := &driver.ValueExpr{Datum: *v.value.Clone()}
+	ve.Type = *v.tp
+	ve.SetProjectionOffset(-1)
+	return ve
+}
+
+// procedureCursor is a cursor declared in a stored procedure, the rows are
+// materialized when the cursor is opened.
+type procedureCursor struct {
+	query      ast.StmtNode
+	open       bool
+	fieldTypes []*types.FieldType
+	rows       []chunk.Row
+	pos        int
+}
+
+type procedureHandler struct {
+	isExit bool
+	conds  []ast.ErrNode
+	body   ast.StmtNode
+	// scope is the scope of the block which declares the handler.
+	scope *procedureScope
+}
+
+type procedureScope struct {
+	parent   *procedureScope
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*procedureHandler
+	// activeHandler is the scope which declares the running handler, its
+	// handlers are not visible when the handler body is running.
+	activeHandler *procedureScope
+}
+
+func newProcedureScope(parent *procedureScope) *procedureScope {
+	return &procedureScope{
+		parent:  parent,
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+func (s *procedureScope) lookupVar(name string) *procedureVar {
+	name = strings.ToLower(name)
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *procedureScope) lookupCursor(name string) *procedureCursor {
+	name = strings.ToLower(name)
+	for ; s != nil; s = s.parent {
+		if c, ok := s.cursors[name]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// Handler condition match levels, a more specific match wins.
+const (
+	handlerMatchNone = iota
+	handlerMatchClass
+	handlerMatchState
+	handlerMatchCode
+)
+
+// findHandler finds the handler for the condition, the handlers declared in
+// the innermost block come first.
+func (s *procedureScope) findHandler(cond *mysql.SQLError, isWarning bool) *procedureHandler {
+	var hidden *procedureScope
+	for ; s != nil; s = s.parent {
+		if s.activeHandler != nil {
+			hidden = s.activeHandler
+		}
+		if s == hidden {
+			continue
+		}
+		var (
+			found *procedureHandler
+			level = handlerMatchNone
+		)
+		for _, h := range s.handlers {
+			if l := h.match(cond, isWarning); l > level {
+				found, level = h, l
+			}
+		}
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (h *procedureHandler) match(cond *mysql.SQLError, isWarning bool) int {
+	level := handlerMatchNone
+	for _, c := range h.conds {
+		l := handlerMatchNone
+		switch x := c.(type) {
+		case *ast.ProcedureErrorVal:
+			if x.ErrorNum == uint64(cond.Code) {
+				l = handlerMatchCode
+			}
+		case *ast.ProcedureErrorState:
+			if x.CodeStatus == cond.State {
+				l = handlerMatchState
+			}
+		case *ast.ProcedureErrorCon:
+			class := cond.State[:2]
+			switch x.ErrorCon {
+			case ast.PROCEDUR_SQLWARNING:
+				if class == "01" || isWarning {
+					l = handlerMatchClass
+				}
+			case ast.PROCEDUR_NOT_FOUND:
+				if class == "02" {
+					l = handlerMatchClass
+				}
+			case ast.PROCEDUR_SQLEXCEPTION:
+				if !isWarning && class != "00" && class != "01" && class != "02" {
+					l = handlerMatchClass
+				}
+			}
+		}
+		level = max(level, l)
+	}
+	return level
+}
+
+// procedureJump is the control signal of LEAVE and ITERATE.
+type procedureJump struct {
+	label   string
+	isLeave bool
+}
+
+func (j *procedureJump) Error() string {
+	return fmt.Sprintf("jump to label %s out of the stored procedure", j.label)
+}
+
+// procedureExit is the control signal of an EXIT handler, it leaves the block
+// which declares the handler.
+type procedureExit struct {
+	scope *procedureScope
+}
+
+func (*procedureExit) Error() string {
+	return "exit handler out of the stored procedure"
+}
+
+func isProcedureSignal(err error) bool {
+	switch err.(type) {
+	case *procedureJump, *procedureExit:
+		return true
+	}
+	return false
+}
+
+func toSQLError(err error) *mysql.SQLError {
+	switch x := errors.Cause(err).(type) {
+	case *terror.Error:
+		return terror.ToSQLError(x)
+	case *mysql.SQLError:
+		return x
+	}
+	return mysql.NewErrf(mysql.ErrUnknown, "%s", nil, err.Error())
+}
+
+// stmtExecutor executes the SQL statements of a stored routine or a trigger.
+type stmtExecutor interface {
+	ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlexec.RecordSet, error)
+}
+
+type procedureExec struct {
+	sctx sessionctx.Context
+	exec stmtExecutor
+	// active records how many times each procedure is on the call stack.
+	active map[string]int
+	// restored caches the restored SQL text of the nodes in procedure bodies.
+	restored map[ast.Node]string
+	parser   *parser.Parser
+	charset  string
+	collate  string
+	result   *procedureRecordSet
+	// name is the full name of the running procedure.
+	name string
+}
+
+func (e *procedureExec) callProcedure(ctx context.Context, caller *procedureScope, stmt *ast.CallStmt) error {
+	vars := e.sctx.GetSessionVars()
+	dbName := stmt.Procedure.Schema
+	if dbName.L == "" {
+		if vars.CurrentDB == "" {
+			return plannererrors.ErrNoDB
+		}
+		dbName = ast.NewCIStr(vars.CurrentDB)
+	}
+	name := stmt.Procedure.FnName
+	dbInfo, routine, err := plannercore.LoadRoutine(e.sctx.GetPlanCtx(), domain.GetDomain(e.sctx).InfoSchema(), dbName, model.RoutineTypeProcedure, name)
+	if err != nil {
+		return err
+	}
+	fullName := dbInfo.Name.O + "." + routine.Name.O
+	if pm := privilege.GetPrivilegeManager(e.sctx); pm != nil && vars.User != nil &&
+		!pm.RequestRoutineVerification(vars.ActiveRoles, dbInfo.Name.L, routine.Name.L, model.RoutineTypeProcedure.String(), mysql.ExecutePriv) {
+		return plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, fullName)
+	}
+	if len(stmt.Procedure.Args) != len(routine.Params) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(routine.Params), len(stmt.Procedure.Args))
+	}
+	key := dbInfo.Name.L + "." + routine.Name.L
+	maxDepth := 0
+	if s, ok := vars.GetSystemVar(vardef.MaxSpRecursionDepth); ok {
+		maxDepth, _ = strconv.Atoi(s)
+	}
+	if e.active[key] > maxDepth {
+		return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(maxDepth, routine.Name.O)
+	}
+
+	// Bind the arguments before switching to the context of the callee.
+	scope := newProcedureScope(nil)
+	for i, param := range routine.Params {
+		arg := stmt.Procedure.Args[i]
+		v := &procedureVar{tp: param.Type}
+		if param.Mode != ast.MODE_IN && !isProcedureOutArg(caller, arg) {
+			return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+		}
+		if param.Mode != ast.MODE_OUT {
+			d, err := e.evalExpr(ctx, caller, arg)
+			if err != nil {
+				return err
+			}
+			if v.value, err = d.ConvertTo(vars.StmtCtx.TypeCtx(), v.tp); err != nil {
+				return err
+			}
+		}
+		scope.vars[param.Name.L] = v
+	}
+	body, err := plannercore.ParseRoutineBody(e.sctx.GetPlanCtx(), routine)
+	if err != nil {
+		return err
+	}
+
+	// A stored procedure always runs in its own database with the sql mode it
+	// was created with.
+	origDB, origSQLMode := vars.CurrentDB, vars.SQLMode
+	origParser, origCharset, origCollate, origName := e.parser, e.charset, e.collate, e.name
+	vars.CurrentDB, vars.SQLMode = dbInfo.Name.O, routine.SQLMode
+	e.name = fullName
+	e.parser, e.charset, e.collate = plannercore.NewRoutineParser(e.sctx.GetPlanCtx(), routine), routine.Charset, routine.Collate
+	e.active[key]++
+	err = e.execStmt(ctx, scope, body)
+	e.active[key]--
+	vars.CurrentDB, vars.SQLMode = origDB, origSQLMode
+	e.parser, e.charset, e.collate, e.name = origParser, origCharset, origCollate, origName
+	if err != nil {
+		return err
+	}
+
+	for i, param := range routine.Params {
+		if param.Mode == ast.MODE_IN {
+			continue
+		}
+		v := scope.vars[param.Name.L]
+		switch x := stmt.Procedure.Args[i].(type) {
+		case *ast.VariableExpr:
+			setUserVar(vars, x.Name, v.value, v.tp)
+		case *ast.ColumnNameExpr:
+			if err := e.assignVar(caller, x.Name.Name.L, v.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isProcedureOutArg checks whether the argument can be used as an OUT or INOUT
+// argument, only the user variables and the local variables are allowed.
+func isProcedureOutArg(caller *procedureScope, arg ast.ExprNode) bool {
+	switch x := arg.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem
+	case *ast.ColumnNameExpr:
+		return x.Name.Schema.L == "" && x.Name.Table.L == "" && caller.lookupVar(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+func (e *procedureExec) execStmts(ctx context.Context, scope *procedureScope, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := e.sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+		err := e.execStmt(ctx, scope, stmt)
+		if err == nil || isProcedureSignal(err) {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		h := scope.findHandler(toSQLError(err), false)
+		if h == nil {
+			return err
+		}
+		if err := e.runHandler(ctx, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHandler runs the handler body, a CONTINUE handler returns nil so the
+// execution goes on with the next statement.
+func (e *procedureExec) runHandler(ctx context.Context, h *procedureHandler) error {
+	scope := newProcedureScope(h.scope)
+	scope.activeHandler = h.scope
+	if err := e.execStmt(ctx, scope, h.body); err != nil {
+		return err
+	}
+	if h.isExit {
+		return &procedureExit{scope: h.scope}
+	}
+	return nil
+}
+
+func (e *procedureExec) execStmt(ctx context.Context, scope *procedureScope, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return e.execBlock(ctx, scope, x)
+	case *ast.ProcedureLabelBlock:
+		err := e.execBlock(ctx, scope, x.Block)
+		if j, ok := err.(*procedureJump); ok && j.isLeave && strings.EqualFold(j.label, x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return e.execLoop(ctx, scope, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt:
+		return e.execLoop(ctx, scope, x, "")
+	case *ast.ProcedureJump:
+		return &procedureJump{label: x.Name, isLeave: x.IsLeave}
+	case *ast.ProcedureIfInfo:
+		return e.execIf(ctx, scope, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return e.execSimpleCase(ctx, scope, x)
+	case *ast.SearchCaseStmt:
+		return e.execSearchCase(ctx, scope, x)
+	case *ast.ProcedureOpenCur:
+		return e.openCursor(ctx, scope, x.CurName)
+	case *ast.ProcedureCloseCur:
+		c := scope.lookupCursor(x.CurName)
+		if !c.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		c.open, c.rows, c.pos = false, nil, 0
+		return nil
+	case *ast.ProcedureFetchInto:
+		return e.fetchCursor(scope, x)
+	case *ast.CallStmt:
+		return e.callProcedure(ctx, scope, x)
+	case *ast.SetStmt:
+		return e.execSet(ctx, scope, x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return e.execSelectInto(ctx, scope, x)
+		}
+	}
+	return e.execSQL(ctx, scope, stmt)
+}
+
+func (e *procedureExec) execBlock(ctx context.Context, parent *procedureScope, block *ast.ProcedureBlock) error {
+	scope := newProcedureScope(parent)
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			tp := x.DeclType.Clone()
+			value := types.Datum{}
+			if x.DeclDefault != nil {
+				d, err := e.evalExpr(ctx, scope, x.DeclDefault)
+				if err != nil {
+					return err
+				}
+				if value, err = d.ConvertTo(e.sctx.GetSessionVars().StmtCtx.TypeCtx(), tp); err != nil {
+					return err
+				}
+			}
+			for _, name := range x.DeclNames {
+				scope.vars[strings.ToLower(name)] = &procedureVar{tp: tp, value: *value.Clone()}
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[strings.ToLower(x.CurName)] = &procedureCursor{query: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, &procedureHandler{
+				isExit: x.ControlHandle == ast.PROCEDUR_EXIT,
+				conds:  x.ErrorCon,
+				body:   x.Operate,
+				scope:  scope,
+			})
+		}
+	}
+	err := e.execStmts(ctx, scope, block.ProcedureProcStmts)
+	if exit, ok := err.(*procedureExit); ok && exit.scope == scope {
+		return nil
+	}
+	return err
+}
+
+func (e *procedureExec) execLoop(ctx context.Context, scope *procedureScope, loop ast.StmtNode, label string) error {
+	for {
+		if err := e.sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+		var (
+			err  error
+			done bool
+		)
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			ok, condErr := e.evalCond(ctx, scope, x.Condition)
+			if condErr != nil {
+				return condErr
+			}
+			if !ok {
+				return nil
+			}
+			err = e.execStmts(ctx, scope, x.Body)
+		case *ast.ProcedureRepeatStmt:
+			if err = e.execStmts(ctx, scope, x.Body); err == nil {
+				if done, err = e.evalCond(ctx, scope, x.Condition); err == nil && done {
+					return nil
+				}
+			}
+		}
+		if j, ok := err.(*procedureJump); ok && label != "" && strings.EqualFold(j.label, label) {
+			if j.isLeave {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e *procedureExec) execIf(ctx context.Context, scope *procedureScope, block *ast.ProcedureIfBlock) error {
+	ok, err := e.evalCond(ctx, scope, block.IfExpr)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return e.execStmts(ctx, scope, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return e.execIf(ctx, scope, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return e.execStmts(ctx, scope, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (e *procedureExec) execSimpleCase(ctx context.Context, scope *procedureScope, stmt *ast.SimpleCaseStmt) error {
+	cond, err := e.restore(stmt.Condition)
+	if err != nil {
+		return err
+	}
+	for _, when := range stmt.WhenCases {
+		expr, err := e.restore(when.Expr)
+		if err != nil {
+			return err
+		}
+		d, err := e.evalSQL(ctx, scope, fmt.Sprintf("SELECT (%s) = (%s)", cond, expr))
+		if err != nil {
+			return err
+		}
+		if ok, err := e.datumToBool(d); err != nil || ok {
+			if err != nil {
+				return err
+			}
+			return e.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return e.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+func (e *procedureExec) execSearchCase(ctx context.Context, scope *procedureScope, stmt *ast.SearchCaseStmt) error {
+	for _, when := range stmt.WhenCases {
+		ok, err := e.evalCond(ctx, scope, when.Expr)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return e.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return e.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+func (e *procedureExec) openCursor(ctx context.Context, scope *procedureScope, name string) error {
+	c := scope.lookupCursor(name)
+	if c.open {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	fields, rows, err := e.query(ctx, scope, c.query)
+	if err != nil {
+		return err
+	}
+	c.fieldTypes = make([]*types.FieldType, 0, len(fields))
+	for _, f := range fields {
+		c.fieldTypes = append(c.fieldTypes, &f.Column.FieldType)
+	}
+	c.open, c.rows, c.pos = true, rows, 0
+	return nil
+}
+
+func (e *procedureExec) fetchCursor(scope *procedureScope, stmt *ast.ProcedureFetchInto) error {
+	c := scope.lookupCursor(stmt.CurName)
+	if !c.open {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if len(stmt.Variables) != len(c.fieldTypes) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	if c.pos >= len(c.rows) {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	row := c.rows[c.pos].GetDatumRow(c.fieldTypes)
+	c.pos++
+	for i, name := range stmt.Variables {
+		if err := e.assignVar(scope, name, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) execSet(ctx context.Context, scope *procedureScope, stmt *ast.SetStmt) error {
+	for _, assign := range stmt.Variables {
+		if assign.IsSystem && !assign.IsGlobal && scope.lookupVar(assign.Name) != nil {
+			d, err := e.evalExpr(ctx, scope, assign.Value)
+			if err != nil {
+				return err
+			}
+			if err := e.assignVar(scope, assign.Name, d); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := e.execSQL(ctx, scope, &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) execSelectInto(ctx context.Context, scope *procedureScope, stmt *ast.SelectStmt) error {
+	node, err := e.reparse(stmt)
+	if err != nil {
+		return err
+	}
+	node.(*ast.SelectStmt).SelectIntoOpt = nil
+	fields, rows, err := e.execNode(ctx, scope, node)
+	if err != nil {
+		return err
+	}
+	if len(fields) != len(stmt.SelectIntoOpt.Variables) {
+		return plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	if len(rows) > 1 {
+		return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+	}
+	if len(rows) == 0 {
+		cond := exeerrors.ErrSpFetchNoData.FastGenByArgs()
+		if h := scope.findHandler(toSQLError(cond), true); h != nil {
+			return e.runHandler(ctx, h)
+		}
+		e.sctx.GetSessionVars().StmtCtx.AppendWarning(cond)
+		return nil
+	}
+	vars := e.sctx.GetSessionVars()
+	for i, v := range stmt.SelectIntoOpt.Variables {
+		tp := &fields[i].Column.FieldType
+		d := rows[0].GetDatum(i, tp)
+		if v.IsUserVar {
+			setUserVar(vars, v.Name, d, tp)
+			continue
+		}
+		if err := e.assignVar(scope, v.Name, d); err != nil {
+			return err
+		}
+	}
+	return e.raiseWarnings(ctx, scope)
+}
+
+// execSQL executes a SQL statement of the procedure body, the result set is
+// kept as the result of the CALL statement. Multiple result sets are not
+// supported.
+func (e *procedureExec) execSQL(ctx context.Context, scope *procedureScope, stmt ast.StmtNode) error {
+	node, err := e.reparse(stmt)
+	if err != nil {
+		return err
+	}
+	fields, rows, err := e.execNode(ctx, scope, node)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		if e.result != nil {
+			return exeerrors.ErrSpBadselect.GenWithStackByArgs(e.name)
+		}
+		e.result = &procedureRecordSet{fields: fields, rows: rows, maxChunkSize: e.sctx.GetSessionVars().MaxChunkSize}
+	}
+	return e.raiseWarnings(ctx, scope)
+}
+
+// raiseWarnings activates the handler of the warnings raised by the last
+// statement.
+func (e *procedureExec) raiseWarnings(ctx context.Context, scope *procedureScope) error {
+	for _, w := range e.sctx.GetSessionVars().StmtCtx.GetWarnings() {
+		if w.Level != contextutil.WarnLevelWarning {
+			continue
+		}
+		if h := scope.findHandler(toSQLError(w.Err), true); h != nil {
+			return e.runHandler(ctx, h)
+		}
+	}
+	return nil
+}
+
+// query executes the statement of the procedure body and returns all the rows.
+func (e *procedureExec) query(ctx context.Context, scope *procedureScope, stmt ast.StmtNode) ([]*resolve.ResultField, []chunk.Row, error) {
+	node, err := e.reparse(stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e.execNode(ctx, scope, node)
+}
+
+// execNode executes the freshly parsed statement, the local variables in it
+// are replaced by their values.
+func (e *procedureExec) execNode(ctx context.Context, scope *procedureScope, node ast.StmtNode) ([]*resolve.ResultField, []chunk.Row, error) {
+	if scope != nil {
+		node.Accept(&procedureVarBinder{scope: scope})
+	}
+	rs, err := e.exec.ExecuteStmt(ctx, node)
+	if err != nil || rs == nil {
+		return nil, nil, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, e.sctx.GetSessionVars().MaxChunkSize)
+	fields := rs.Fields()
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return fields, rows, nil
+}
+
+// reparse parses the restored text of the statement, so that every execution
+// works on a fresh AST.
+func (e *procedureExec) reparse(stmt ast.StmtNode) (ast.StmtNode, error) {
+	sql, err := e.restore(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return e.parse(sql)
+}
+
+func (e *procedureExec) parse(sql string) (ast.StmtNode, error) {
+	p := e.parser
+	if p == nil {
+		p = parser.New()
+		p.SetSQLMode(e.sctx.GetSessionVars().SQLMode)
+		p.SetParserConfig(e.sctx.GetSessionVars().BuildParserConfig())
+		e.parser = p
+	}
+	stmt, err := p.ParseOneStmt(sql, e.charset, e.collate)
+	return stmt, errors.Trace(err)
+}
+
+func (e *procedureExec) restore(node ast.Node) (string, error) {
+	if sql, ok := e.restored[node]; ok {
+		return sql, nil
+	}
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	e.restored[node] = sb.String()
+	return sb.String(), nil
+}
+
+func (e *procedureExec) evalExpr(ctx context.Context, scope *procedureScope, expr ast.ExprNode) (types.Datum, error) {
+	sql, err := e.restore(expr)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return e.evalSQL(ctx, scope, "SELECT "+sql)
+}
+
+func (e *procedureExec) evalSQL(ctx context.Context, scope *procedureScope, sql string) (types.Datum, error) {
+	node, err := e.parse(sql)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	fields, rows, err := e.execNode(ctx, scope, node)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	if len(rows) == 0 {
+		return types.Datum{}, nil
+	}
+	return rows[0].GetDatum(0, &fields[0].Column.FieldType), nil
+}
+
+func (e *procedureExec) evalCond(ctx context.Context, scope *procedureScope, expr ast.ExprNode) (bool, error) {
+	d, err := e.evalExpr(ctx, scope, expr)
+	if err != nil {
+		return false, err
+	}
+	return e.datumToBool(d)
+}
+
+func (e *procedureExec) datumToBool(d types.Datum) (bool, error) {
+	if d.IsNull() {
+		return false, nil
+	}
+	v, err := d.ToBool(e.sctx.GetSessionVars().StmtCtx.TypeCtx())
+	return v != 0, err
+}
+
+func (e *procedureExec) assignVar(scope *procedureScope, name string, d types.Datum) error {
+	v := scope.lookupVar(name)
+	if v == nil {
+		return plannererrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+	}
+	value, err := d.ConvertTo(e.sctx.GetSessionVars().StmtCtx.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	v.value = value
+	return nil
+}
+
+// procedureVarBinder replaces the local variables in a statement with their
+// values, a local variable takes precedence over a column with the same name.
+type procedureVarBinder struct {
+	scope *procedureScope
+}
+
+func (b *procedureVarBinder) lookup(n ast.Node) *procedureVar {
+	c, ok := n.(*ast.ColumnNameExpr)
+	if !ok || c.Name.Schema.L != "" {
+		return nil
+	}
+	switch c.Name.Table.L {
+	case "":
+		return b.scope.lookupVar(c.Name.Name.L)
+	case "new", "old":
+		// NEW.col and OLD.col refer to the row which activates the trigger.
+		return b.scope.lookupVar(c.Name.Table.L + "." + c.Name.Name.L)
+	}
+	return nil
+}
+
+// Enter implements the ast.Visitor interface.
+func (b *procedureVarBinder) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.ValuesExpr:
+		// VALUES(col) always refers to a column.
+		return n, true
+	case *ast.SelectField:
+		// Keep the variable name as the column name of the result set.
+		if x.AsName.L == "" && b.lookup(x.Expr) != nil {
+			x.AsName = x.Expr.(*ast.ColumnNameExpr).Name.Name
+		}
+	}
+	return n, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (b *procedureVarBinder) Leave(n ast.Node) (ast.Node, bool) {
+	if v := b.lookup(n); v != nil {
+		return v.valueExpr(), true
+	}
+	return n, true
+}
+
+// procedureRecordSet is the buffered result set of a CALL statement.
+type procedureRecordSet struct {
+	fields       []*resolve.ResultField
+	rows         []chunk.Row
+	idx          int
+	maxChunkSize int
+}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (r *procedureRecordSet) Fields() []*resolve.ResultField {
+	return r.fields
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (r *procedureRecordSet) Next(_ context.Context, chk *chunk.Chunk) error {
+	chk.Reset()
+	if !chk.IsFull() && r.idx < len(r.rows) {
+		numToAppend := min(len(r.rows)-r.idx, chk.RequiredRows()-chk.NumRows())
+		chk.AppendRows(r.rows[r.idx : r.idx+numToAppend])
+		r.idx += numToAppend
+	}
+	return nil
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (r *procedureRecordSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	fieldTypes := make([]*types.FieldType, 0, len(r.fields))
+	for _, f := range r.fields {
+		fieldTypes = append(fieldTypes, &f.Column.FieldType)
+	}
+	if alloc == nil {
+		return chunk.New(fieldTypes, r.maxChunkSize, r.maxChunkSize)
+	}
+	return alloc.Alloc(fieldTypes, r.maxChunkSize, r.maxChunkSize)
+}
+
+// Close implements the sqlexec.RecordSet interface.
+func (*procedureRecordSet) Close() error {
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/errors"
@@ -54,6 +55,12 @@ func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	if e.Action == "show" {
 		return e.showOptions(req)
 	}
+	if e.Action == "explain" {
+		return e.explainHypoIndexes(req)
+	}
+	if e.Action == "drop" {
+		return e.adviseDropIndexes(ctx, req)
+	}
 
 	if e.Action != "run" {
 		return fmt.Errorf("unsupported action: %s", e.Action)
@@ -92,6 +99,38 @@ func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return err
 }
 
+func (e *RecommendIndexExec) explainHypoIndexes(req *chunk.Chunk) error {
+	results, err := indexadvisor.WhatIfHypoIndexes(e.Ctx(), e.SQL)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		req.AppendString(0, r.Database)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.IndexColumns, ","))
+		req.AppendString(4, strconv.FormatFloat(r.OriginalCost, 'f', 2, 64))
+		req.AppendString(5, strconv.FormatFloat(r.HypoCost, 'f', 2, 64))
+		req.AppendString(6, fmt.Sprintf("%v", r.Improvement))
+	}
+	return nil
+}
+
+func (e *RecommendIndexExec) adviseDropIndexes(ctx context.Context, req *chunk.Chunk) error {
+	results, err := indexadvisor.AdviseDropIndexes(ctx, e.Ctx(), e.Options)
+	for _, r := range results {
+		req.AppendString(0, r.Database)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.IndexColumns, ","))
+		req.AppendString(4, fmt.Sprintf("%v", r.IndexSize))
+		req.AppendString(5, fmt.Sprintf("%v", r.WriteReduction))
+		req.AppendString(6, r.Reason)
+		req.AppendString(7, fmt.Sprintf("DROP INDEX %s ON %s.%s;", r.IndexName, r.Database, r.Table))
+	}
+	return err
+}
+
 func (e *RecommendIndexExec) showOptions(req *chunk.Chunk) error {
 	vals, desc, err := indexadvisor.GetOptions(e.Ctx(), indexadvisor.AllOptions...)
 	if err != nil {
@@ -232,3 +232,11 @@ func (e *ReplaceExec) GetFKCascades() []*FKCascadeExec {
 func (e *ReplaceExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithTrigger interface.
+func (e *ReplaceExec) GetTriggers() []*TriggerExec {
+	if e.triggers == nil {
+		return nil
+	}
+	return []*TriggerExec{e.triggers}
+}
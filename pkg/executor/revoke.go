@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
@@ -162,6 +163,8 @@ func (e *RevokeExec) revokeOneUser(ctx context.Context, internalSession sessionc
 	// DB scope:		mysql.DB
 	// Table scope:		mysql.Tables_priv
 	// Column scope:	mysql.Columns_priv
+	// Routine scope:	mysql.procs_priv
+	routineType, isRoutine := routineTypeOfObject(e.ObjectType)
 	switch e.Level.Level {
 	case ast.GrantLevelDB:
 		ok, err := dbUserExists(internalSession, user, host, dbName)
@@ -172,6 +175,19 @@ func (e *RevokeExec) revokeOneUser(ctx context.Context, internalSession sessionc
 			return errors.Errorf("There is no such grant defined for user '%s' on host '%s' on database %s", user, host, dbName)
 		}
 	case ast.GrantLevelTable:
+		if isRoutine {
+			if err := checkRoutinePrivs(e.Privs); err != nil {
+				return err
+			}
+			ok, err := routineUserExists(internalSession, user, host, dbName, e.Level.TableName, routineType)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.Errorf("There is no such grant defined for user '%s' on host '%s' on routine %s.%s", user, host, dbName, e.Level.TableName)
+			}
+			break
+		}
 		ok, err := tableUserExists(internalSession, user, host, dbName, e.Level.TableName)
 		if err != nil {
 			return err
@@ -200,6 +216,9 @@ func (e *RevokeExec) revokePriv(ctx context.Context, internalSession sessionctx.
 	case ast.GrantLevelDB:
 		return e.revokeDBPriv(internalSession, priv, user, host)
 	case ast.GrantLevelTable:
+		if tp, ok := routineTypeOfObject(e.ObjectType); ok {
+			return e.revokeRoutinePriv(ctx, internalSession, priv, tp, user, host)
+		}
 		if len(priv.Cols) == 0 {
 			return e.revokeTablePriv(ctx, internalSession, priv, user, host)
 		}
@@ -304,6 +323,34 @@ func (e *RevokeExec) revokeTablePriv(ctx context.Context, internalSession sessio
 	return err
 }
 
+func (e *RevokeExec) revokeRoutinePriv(ctx context.Context, internalSession sessionctx.Context, priv *ast.PrivElem, tp model.RoutineType, user, host string) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnPrivilege)
+	dbName := e.Level.DBName
+	if len(dbName) == 0 {
+		dbName = e.Ctx().GetSessionVars().CurrentDB
+	}
+	routineName := e.Level.TableName
+
+	var newProcPriv []string
+	if priv.Priv != mysql.AllPriv {
+		currProcPriv, err := getRoutinePriv(internalSession, user, host, dbName, routineName, tp)
+		if err != nil {
+			return err
+		}
+		newProcPriv = deleteFromSet(SetFromString(currProcPriv), priv.Priv.SetString())
+	}
+
+	sql := new(strings.Builder)
+	if len(newProcPriv) == 0 {
+		sqlescape.MustFormatSQL(sql, "DELETE FROM %n.%n", mysql.SystemDB, mysql.ProcsPrivTable)
+	} else {
+		sqlescape.MustFormatSQL(sql, "UPDATE %n.%n SET Proc_priv=%?", mysql.SystemDB, mysql.ProcsPrivTable, setToString(newProcPriv))
+	}
+	sqlescape.MustFormatSQL(sql, " WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?", user, host, dbName, routineName, tp.String())
+	_, err := internalSession.GetSQLExecutor().ExecuteInternal(ctx, sql.String())
+	return err
+}
+
 func (e *RevokeExec) revokeColumnPriv(ctx context.Context, internalSession sessionctx.Context, priv *ast.PrivElem, user, host string) error {
 	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnPrivilege)
 	dbName, tbl, err := getTargetSchemaAndTable(ctx, e.Ctx(), e.Level.DBName, e.Level.TableName, e.is)
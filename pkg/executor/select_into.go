@@ -21,14 +21,19 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 )
 
 // SelectIntoExec represents a SelectInto executor.
@@ -36,6 +41,8 @@ type SelectIntoExec struct {
 	exec.BaseExecutor
 	intoOpt *ast.SelectIntoOption
 	core.LineFieldsInfo
+	// colNames is the names of the result columns, which are used by the parquet format.
+	colNames []string
 
 	lineBuf   []byte
 	realBuf   []byte
@@ -46,23 +53,41 @@ type SelectIntoExec struct {
 	dstFile   *os.File
 	chk       *chunk.Chunk
 	started   bool
+
+	// outfile is used when the OUTFILE is an URI of the external storage.
+	outfile *outfileStorageWriter
 }
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		s.chk = exec.TryNewCacheChunk(s.Children(0))
+		return s.BaseExecutor.Open(ctx)
+	}
+	// only 'select ... into outfile' and 'select ... into var_list' are supported now
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
 
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
-	if err != nil {
-		return errors.Trace(err)
+	if localPath, ok := getLocalOutfilePath(s.intoOpt.FileName); !ok {
+		outfile, err := newOutfileStorageWriter(ctx, s.intoOpt, s.colNames, exec.RetTypes(s.Children(0)))
+		if err != nil {
+			return err
+		}
+		s.outfile = outfile
+	} else {
+		if err := checkLocalOutfileOptions(s.intoOpt); err != nil {
+			return err
+		}
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(localPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.dstFile = f
+		s.writer = bufio.NewWriter(s.dstFile)
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
 	s.chk = exec.TryNewCacheChunk(s.Children(0))
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -72,6 +97,9 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.assignVars(ctx)
+	}
 	for {
 		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
@@ -79,13 +107,55 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		if err := s.dumpToOutfile(ctx); err != nil {
+			return err
+		}
+	}
+	if s.outfile != nil {
+		return s.outfile.finish(ctx)
+	}
+	return nil
+}
+
+// assignVars assigns the only result row to the user variables, a warning is
+// appended if there is no row.
+func (s *SelectIntoExec) assignVars(ctx context.Context) error {
+	var row []types.Datum
+	fieldTypes := exec.RetTypes(s.Children(0))
+	for {
+		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
 		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+		}
+		row = s.chk.GetRow(0).GetDatumRow(fieldTypes)
+	}
+	sessionVars := s.Ctx().GetSessionVars()
+	if row == nil {
+		sessionVars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.FastGenByArgs())
+		return nil
+	}
+	for i, v := range s.intoOpt.Variables {
+		setUserVar(sessionVars, v.Name, row[i], fieldTypes[i])
 	}
 	return nil
 }
 
+// setUserVar sets the user variable like `SET @name = value` does.
+func setUserVar(sessionVars *variable.SessionVars, name string, value types.Datum, tp *types.FieldType) {
+	name = strings.ToLower(name)
+	if value.IsNull() {
+		sessionVars.UnsetUserVar(name)
+		return
+	}
+	sessionVars.SetUserVarVal(name, *value.Clone())
+	sessionVars.SetUserVarType(name, tp)
+}
+
 func (*SelectIntoExec) considerEncloseOpt(et types.EvalType) bool {
 	return et == types.ETString || et == types.ETDuration ||
 		et == types.ETTimestamp || et == types.ETDatetime ||
@@ -121,7 +191,11 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
+	if s.outfile != nil && s.outfile.format == importer.DataFormatParquet {
+		return s.dumpToParquet(ctx)
+	}
+
 	encloseFlag := false
 	var encloseByte byte
 	encloseOpt := false
@@ -149,7 +223,8 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 				s.lineBuf = append(s.lineBuf, nullTerm...)
 				continue
 			}
-			et := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).EvalType()
+			ft := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx())
+			et := ft.EvalType()
 			if (encloseFlag && !encloseOpt) ||
 				(encloseFlag && encloseOpt && s.considerEncloseOpt(et)) {
 				s.lineBuf = append(s.lineBuf, encloseByte)
@@ -157,46 +232,13 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			} else {
 				s.enclosed = false
 			}
-			s.fieldBuf = s.fieldBuf[:0]
-			switch col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetType() {
-			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
-				s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-			case mysql.TypeLonglong:
-				if mysql.HasUnsignedFlag(col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetFlag()) {
-					s.fieldBuf = strconv.AppendUint(s.fieldBuf, row.GetUint64(j), 10)
-				} else {
-					s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-				}
-			case mysql.TypeFloat:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, float64(row.GetFloat32(j)), col.RetType)
-			case mysql.TypeDouble:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, row.GetFloat64(j), col.RetType)
-			case mysql.TypeNewDecimal:
-				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
-			case mysql.TypeBit:
-				// bit value won't be escaped anyway (verified on MySQL, test case added)
-				s.lineBuf = append(s.lineBuf, row.GetBytes(j)...)
-			case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
-				s.fieldBuf = append(s.fieldBuf, row.GetTime(j).String()...)
-			case mysql.TypeDuration:
-				s.fieldBuf = append(s.fieldBuf, row.GetDuration(j, col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetDecimal()).String()...)
-			case mysql.TypeEnum:
-				s.fieldBuf = append(s.fieldBuf, row.GetEnum(j).String()...)
-			case mysql.TypeSet:
-				s.fieldBuf = append(s.fieldBuf, row.GetSet(j).String()...)
-			case mysql.TypeJSON:
-				s.fieldBuf = append(s.fieldBuf, row.GetJSON(j).String()...)
-			case mysql.TypeTiDBVectorFloat32:
-				s.fieldBuf = append(s.fieldBuf, row.GetVectorFloat32(j).String()...)
-			}
+			s.encodeField(row, j, col, ft)
 
-			switch col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).EvalType() {
+			switch et {
 			case types.ETString, types.ETJson:
 				s.lineBuf = append(s.lineBuf, s.escapeField(s.fieldBuf)...)
 			default:
+				// bit value won't be escaped anyway (verified on MySQL, test case added)
 				s.lineBuf = append(s.lineBuf, s.fieldBuf...)
 			}
 			if (encloseFlag && !encloseOpt) ||
@@ -205,6 +247,12 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, s.LinesTerminatedBy...)
+		if s.outfile != nil {
+			if err := s.outfile.writeLine(ctx, s.lineBuf); err != nil {
+				return err
+			}
+			continue
+		}
 		if _, err := s.writer.Write(s.lineBuf); err != nil {
 			return errors.Trace(err)
 		}
@@ -213,11 +261,93 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 	return nil
 }
 
+// dumpToParquet writes the rows to the parquet files. The columns which are not written as INT64 or DOUBLE use the
+// same text format as the CSV files.
+func (s *SelectIntoExec) dumpToParquet(ctx context.Context) error {
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		// the parquet writer buffers the records until a row group is flushed, so they can't be reused.
+		rec := make([]any, len(cols))
+		var size int64
+		for j, col := range cols {
+			if row.IsNull(j) {
+				continue
+			}
+			ft := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx())
+			switch s.outfile.parquetTypes[j] {
+			case outfileParquetInt64:
+				rec[j] = row.GetInt64(j)
+				size += 8
+			case outfileParquetDouble:
+				if ft.GetType() == mysql.TypeFloat {
+					rec[j] = float64(row.GetFloat32(j))
+				} else {
+					rec[j] = row.GetFloat64(j)
+				}
+				size += 8
+			default:
+				s.encodeField(row, j, col, ft)
+				rec[j] = string(s.fieldBuf)
+				size += int64(len(s.fieldBuf))
+			}
+		}
+		if err := s.outfile.writeParquetRow(ctx, rec, size); err != nil {
+			return err
+		}
+	}
+	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
+
+// encodeField encodes the j-th column of the row to s.fieldBuf in the text format.
+func (s *SelectIntoExec) encodeField(row chunk.Row, j int, col *expression.Column, ft *types.FieldType) {
+	s.fieldBuf = s.fieldBuf[:0]
+	switch ft.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
+			s.fieldBuf = strconv.AppendUint(s.fieldBuf, row.GetUint64(j), 10)
+		} else {
+			s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
+		}
+	case mysql.TypeFloat:
+		s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, float64(row.GetFloat32(j)), col.RetType)
+	case mysql.TypeDouble:
+		s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, row.GetFloat64(j), col.RetType)
+	case mysql.TypeNewDecimal:
+		s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeBit:
+		s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		s.fieldBuf = append(s.fieldBuf, row.GetTime(j).String()...)
+	case mysql.TypeDuration:
+		s.fieldBuf = append(s.fieldBuf, row.GetDuration(j, ft.GetDecimal()).String()...)
+	case mysql.TypeEnum:
+		s.fieldBuf = append(s.fieldBuf, row.GetEnum(j).String()...)
+	case mysql.TypeSet:
+		s.fieldBuf = append(s.fieldBuf, row.GetSet(j).String()...)
+	case mysql.TypeJSON:
+		s.fieldBuf = append(s.fieldBuf, row.GetJSON(j).String()...)
+	case mysql.TypeTiDBVectorFloat32:
+		s.fieldBuf = append(s.fieldBuf, row.GetVectorFloat32(j).String()...)
+	}
+}
+
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.BaseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
+	if s.outfile != nil {
+		s.outfile.close()
+		return s.BaseExecutor.Close()
+	}
 	err1 := s.writer.Flush()
 	err2 := s.dstFile.Close()
 	err3 := s.BaseExecutor.Close()
@@ -0,0 +1,425 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/executor/importer"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	// outfileManifestName is the name of the manifest file written after all the data files.
+	outfileManifestName = "manifest.json"
+	// defaultOutfileMaxFileSize is the default max size of each data file written to the external storage.
+	defaultOutfileMaxFileSize = 256 * units.MiB
+	// maxOutfileParquetRowGroupSize is the max row group size of the parquet files, which is the same as the
+	// default value of the parquet writer.
+	maxOutfileParquetRowGroupSize = 128 * units.MiB
+)
+
+// outfileParquetType is the physical type of a column in the parquet files.
+type outfileParquetType int
+
+const (
+	outfileParquetString outfileParquetType = iota
+	outfileParquetBinary
+	outfileParquetInt64
+	outfileParquetDouble
+)
+
+// outfileManifest is the content of the manifest file. It lists the data files in the order they are written.
+type outfileManifest struct {
+	Format      string                `json:"format"`
+	Compression string                `json:"compression,omitempty"`
+	Columns     []string              `json:"columns"`
+	Files       []outfileManifestFile `json:"files"`
+	TotalRows   uint64                `json:"total_rows"`
+}
+
+type outfileManifestFile struct {
+	Name string `json:"name"`
+	Rows uint64 `json:"rows"`
+}
+
+// getLocalOutfilePath returns the local path of the file name of `SELECT ... INTO OUTFILE`, or false if the file name
+// is an URI of the external storage, such as 's3://bucket/prefix/'. The plain file path and the URI of the local
+// storage, such as 'file:///tmp/a.csv', keep the MySQL compatible behavior, which fails if the file already exists.
+func getLocalOutfilePath(fileName string) (string, bool) {
+	u, err := storage.ParseRawURL(fileName)
+	if err != nil || u.Scheme == "" {
+		return fileName, true
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "local", "file":
+		return u.Path, true
+	}
+	return "", false
+}
+
+// checkLocalOutfileOptions checks the options of `SELECT ... INTO OUTFILE` which writes to a local file.
+func checkLocalOutfileOptions(opt *ast.SelectIntoOption) error {
+	if opt.Format != nil && strings.ToLower(*opt.Format) != importer.DataFormatCSV {
+		return exeerrors.ErrLoadDataUnsupportedOption.GenWithStackByArgs("FORMAT", "local OUTFILE")
+	}
+	if opt.Compression != "" {
+		return exeerrors.ErrLoadDataUnsupportedOption.GenWithStackByArgs("COMPRESSION", "local OUTFILE")
+	}
+	if opt.MaxFileSize != "" {
+		return exeerrors.ErrLoadDataUnsupportedOption.GenWithStackByArgs("MAX_FILE_SIZE", "local OUTFILE")
+	}
+	return nil
+}
+
+// outfileStorageWriter writes the result of `SELECT ... INTO OUTFILE` to an external storage. The result is split
+// into multiple files by MAX_FILE_SIZE, and a manifest is written after all the data files are written, so the
+// readers can use the manifest to tell whether the output is complete.
+type outfileStorageWriter struct {
+	store       storage.ExternalStorage
+	fileStore   storage.ExternalStorage
+	format      string
+	compression string
+	suffix      string
+	maxFileSize int64
+
+	parquetSchema []string
+	parquetTypes  []outfileParquetType
+	parquetCodec  parquet.CompressionCodec
+
+	fileWriter    storage.ExternalFileWriter
+	parquetWriter *writer.CSVWriter
+	fileRows      uint64
+	fileSize      int64
+	manifest      outfileManifest
+}
+
+func newOutfileStorageWriter(
+	ctx context.Context,
+	opt *ast.SelectIntoOption,
+	colNames []string,
+	fieldTypes []*types.FieldType,
+) (*outfileStorageWriter, error) {
+	w := &outfileStorageWriter{
+		format:      importer.DataFormatCSV,
+		maxFileSize: defaultOutfileMaxFileSize,
+	}
+	if opt.Format != nil {
+		w.format = strings.ToLower(*opt.Format)
+	}
+	if err := w.initOptions(opt, colNames, fieldTypes); err != nil {
+		return nil, err
+	}
+
+	store, err := storage.NewFromURL(ctx, opt.FileName)
+	if err != nil {
+		return nil, exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs("OUTFILE", err.Error())
+	}
+	exists, err := store.FileExists(ctx, outfileManifestName)
+	if err != nil {
+		store.Close()
+		return nil, errors.Trace(err)
+	}
+	if exists {
+		store.Close()
+		return nil, exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs("OUTFILE",
+			fmt.Sprintf("%s already exists in the target directory", outfileManifestName))
+	}
+	w.store = store
+	w.fileStore = store
+	if w.format == importer.DataFormatCSV {
+		w.fileStore = storage.WithCompression(store, outfileCSVCompressType(w.compression), storage.DecompressConfig{})
+	}
+	return w, nil
+}
+
+func (w *outfileStorageWriter) initOptions(opt *ast.SelectIntoOption, colNames []string, fieldTypes []*types.FieldType) error {
+	if opt.MaxFileSize != "" {
+		size, err := units.RAMInBytes(opt.MaxFileSize)
+		if err != nil || size <= 0 {
+			return exeerrors.ErrInvalidOptionVal.GenWithStackByArgs("MAX_FILE_SIZE")
+		}
+		w.maxFileSize = size
+	}
+
+	compression := strings.ToLower(opt.Compression)
+	switch w.format {
+	case importer.DataFormatCSV:
+		switch compression {
+		case "", "none":
+		case "gzip", "gz":
+			w.compression = "gzip"
+		case "zstd", "zst":
+			w.compression = "zstd"
+		case "snappy":
+			w.compression = "snappy"
+		default:
+			return exeerrors.ErrInvalidOptionVal.GenWithStackByArgs("COMPRESSION")
+		}
+		w.suffix = ".csv" + outfileCSVCompressSuffix(w.compression)
+	case importer.DataFormatParquet:
+		if opt.FieldsInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.GenWithStackByArgs("FIELDS", "parquet format")
+		}
+		if opt.LinesInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.GenWithStackByArgs("LINES", "parquet format")
+		}
+		switch compression {
+		case "none", "uncompressed":
+			w.parquetCodec = parquet.CompressionCodec_UNCOMPRESSED
+		case "", "snappy":
+			// snappy is the default codec of the parquet writer.
+			w.compression = "snappy"
+			w.parquetCodec = parquet.CompressionCodec_SNAPPY
+		case "gzip", "gz":
+			w.compression = "gzip"
+			w.parquetCodec = parquet.CompressionCodec_GZIP
+		case "zstd", "zst":
+			w.compression = "zstd"
+			w.parquetCodec = parquet.CompressionCodec_ZSTD
+		default:
+			return exeerrors.ErrInvalidOptionVal.GenWithStackByArgs("COMPRESSION")
+		}
+		w.suffix = ".parquet"
+		w.initParquetSchema(colNames, fieldTypes)
+	default:
+		return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(w.format)
+	}
+	w.manifest = outfileManifest{
+		Format:      w.format,
+		Compression: w.compression,
+		Columns:     colNames,
+		Files:       []outfileManifestFile{},
+	}
+	return nil
+}
+
+// initParquetSchema builds the schema of the parquet files. The integer and real columns are written as INT64 and
+// DOUBLE, other columns are written in the same text format as the CSV files, so the parquet files can be read back
+// by IMPORT INTO.
+func (w *outfileStorageWriter) initParquetSchema(colNames []string, fieldTypes []*types.FieldType) {
+	w.parquetSchema = make([]string, len(fieldTypes))
+	w.parquetTypes = make([]outfileParquetType, len(fieldTypes))
+	names := make(map[string]struct{}, len(colNames))
+	for i, ft := range fieldTypes {
+		tp := outfileParquetString
+		switch ft.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+			tp = outfileParquetInt64
+		case mysql.TypeLonglong:
+			// the unsigned bigint may overflow INT64, so it's written as a string.
+			if !mysql.HasUnsignedFlag(ft.GetFlag()) {
+				tp = outfileParquetInt64
+			}
+		case mysql.TypeFloat, mysql.TypeDouble:
+			tp = outfileParquetDouble
+		case mysql.TypeBit:
+			tp = outfileParquetBinary
+		default:
+			if ft.GetCharset() == charset.CharsetBin && types.IsString(ft.GetType()) {
+				tp = outfileParquetBinary
+			}
+		}
+		w.parquetTypes[i] = tp
+
+		name := outfileParquetColumnName(colNames[i], i, names)
+		switch tp {
+		case outfileParquetInt64:
+			w.parquetSchema[i] = fmt.Sprintf("name=%s, type=INT64, repetitiontype=OPTIONAL", name)
+		case outfileParquetDouble:
+			w.parquetSchema[i] = fmt.Sprintf("name=%s, type=DOUBLE, repetitiontype=OPTIONAL", name)
+		case outfileParquetBinary:
+			w.parquetSchema[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, repetitiontype=OPTIONAL", name)
+		default:
+			w.parquetSchema[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", name)
+		}
+	}
+}
+
+// outfileParquetColumnName returns a unique parquet column name for the result column. The characters which are
+// not allowed in the schema definition of the parquet writer are replaced with '_'.
+func outfileParquetColumnName(colName string, idx int, names map[string]struct{}) string {
+	var sb strings.Builder
+	for _, r := range colName {
+		if r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	name := sb.String()
+	if name == "" {
+		name = fmt.Sprintf("col_%d", idx)
+	}
+	// the parquet reader of IMPORT INTO matches the columns case-insensitively.
+	for i := 1; ; i++ {
+		if _, ok := names[strings.ToLower(name)]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", sb.String(), i)
+	}
+	names[strings.ToLower(name)] = struct{}{}
+	return name
+}
+
+func outfileCSVCompressType(compression string) storage.CompressType {
+	switch compression {
+	case "gzip":
+		return storage.Gzip
+	case "zstd":
+		return storage.Zstd
+	case "snappy":
+		return storage.Snappy
+	default:
+		return storage.NoCompression
+	}
+}
+
+func outfileCSVCompressSuffix(compression string) string {
+	switch compression {
+	case "gzip":
+		return ".gz"
+	case "zstd":
+		return ".zst"
+	case "snappy":
+		return ".snappy"
+	default:
+		return ""
+	}
+}
+
+// writeLine writes an encoded line to the current CSV file.
+func (w *outfileStorageWriter) writeLine(ctx context.Context, line []byte) error {
+	if err := w.ensureFile(ctx); err != nil {
+		return err
+	}
+	if _, err := w.fileWriter.Write(ctx, line); err != nil {
+		return errors.Trace(err)
+	}
+	return w.afterWriteRow(ctx, int64(len(line)))
+}
+
+// writeParquetRow writes a row to the current parquet file. size is the estimated size of the row.
+func (w *outfileStorageWriter) writeParquetRow(ctx context.Context, rec []any, size int64) error {
+	if err := w.ensureFile(ctx); err != nil {
+		return err
+	}
+	if err := w.parquetWriter.Write(rec); err != nil {
+		return errors.Trace(err)
+	}
+	return w.afterWriteRow(ctx, size)
+}
+
+// afterWriteRow closes the current file if its size reaches MAX_FILE_SIZE. The size is counted before
+// compression, so the compressed files are usually smaller than MAX_FILE_SIZE.
+func (w *outfileStorageWriter) afterWriteRow(ctx context.Context, size int64) error {
+	w.fileRows++
+	w.fileSize += size
+	if w.fileSize >= w.maxFileSize {
+		return w.closeFile(ctx)
+	}
+	return nil
+}
+
+func (w *outfileStorageWriter) ensureFile(ctx context.Context) error {
+	if w.fileWriter != nil {
+		return nil
+	}
+	name := fmt.Sprintf("part-%05d%s", len(w.manifest.Files), w.suffix)
+	fileWriter, err := w.fileStore.Create(ctx, name, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.manifest.Files = append(w.manifest.Files, outfileManifestFile{Name: name})
+	w.fileWriter = fileWriter
+	w.fileRows = 0
+	w.fileSize = 0
+	if w.format != importer.DataFormatParquet {
+		return nil
+	}
+
+	w.parquetWriter, err = writer.NewCSVWriterFromWriter(w.parquetSchema, &outfileWriterAdapter{ctx: ctx, w: fileWriter}, 1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.parquetWriter.CompressionType = w.parquetCodec
+	w.parquetWriter.RowGroupSize = min(w.maxFileSize, maxOutfileParquetRowGroupSize)
+	return nil
+}
+
+func (w *outfileStorageWriter) closeFile(ctx context.Context) error {
+	if w.fileWriter == nil {
+		return nil
+	}
+	if w.parquetWriter != nil {
+		if err := w.parquetWriter.WriteStop(); err != nil {
+			return errors.Trace(err)
+		}
+		w.parquetWriter = nil
+	}
+	fileWriter := w.fileWriter
+	w.fileWriter = nil
+	if err := fileWriter.Close(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	w.manifest.Files[len(w.manifest.Files)-1].Rows = w.fileRows
+	w.manifest.TotalRows += w.fileRows
+	return nil
+}
+
+// finish closes the current file and writes the manifest.
+func (w *outfileStorageWriter) finish(ctx context.Context) error {
+	if err := w.closeFile(ctx); err != nil {
+		return err
+	}
+	data, err := json.Marshal(&w.manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.store.WriteFile(ctx, outfileManifestName, data))
+}
+
+// close releases the resources. The files written so far are kept in the storage, but the manifest is not written
+// if finish is not called.
+func (w *outfileStorageWriter) close() {
+	if w.fileWriter != nil {
+		_ = w.fileWriter.Close(context.Background())
+		w.fileWriter = nil
+	}
+	w.store.Close()
+}
+
+// outfileWriterAdapter adapts storage.ExternalFileWriter to io.Writer.
+type outfileWriterAdapter struct {
+	ctx context.Context
+	w   storage.ExternalFileWriter
+}
+
+// Write implements io.Writer.
+func (a *outfileWriterAdapter) Write(p []byte) (int, error) {
+	return a.w.Write(a.ctx, p)
+}
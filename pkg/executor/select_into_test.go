@@ -15,14 +15,21 @@
 package executor_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
@@ -53,6 +60,11 @@ func TestSelectIntoFileExists(t *testing.T) {
 	require.Error(t, err)
 	require.Truef(t, strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "file exists"), "err: %v", err)
 	require.True(t, strings.Contains(err.Error(), outfile))
+
+	// the URI of the local storage is written to the local file too
+	err = tk.ExecToErr(fmt.Sprintf("select 1 into outfile %q", "file://"+outfile))
+	require.Error(t, err)
+	require.Truef(t, strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "file exists"), "err: %v", err)
 }
 
 func TestSelectIntoOutfilePointGet(t *testing.T) {
@@ -302,3 +314,131 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoExternalStorage(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(20), f double, d decimal(10, 2), u bigint unsigned, dt datetime)")
+	tk.MustExec(`insert into t values (1, 'a,b', 1.5, 1.23, 18446744073709551615, '2026-01-02 03:04:05'),
+		(2, NULL, NULL, NULL, NULL, NULL), (3, 'c', -2.25, -4.56, 0, '2026-10-19 00:00:00')`)
+
+	readManifest := func(dir string) map[string]any {
+		content, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+		require.NoError(t, err)
+		manifest := make(map[string]any)
+		require.NoError(t, json.Unmarshal(content, &manifest))
+		return manifest
+	}
+
+	// the result is split into multiple compressed CSV files.
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(dir)
+	tk.MustExec(fmt.Sprintf("select * from t order by id into outfile '%s' fields terminated by ',' enclosed by '\"' compression 'gzip' max_file_size '1B'", uri))
+	require.Equal(t, uint64(3), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	manifest := readManifest(dir)
+	require.Equal(t, "csv", manifest["format"])
+	require.Equal(t, "gzip", manifest["compression"])
+	require.Equal(t, []any{"id", "v", "f", "d", "u", "dt"}, manifest["columns"])
+	require.EqualValues(t, 3, manifest["total_rows"])
+	require.Len(t, manifest["files"], 3)
+	extStore, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	gzipStore := storage.WithCompression(extStore, storage.Gzip, storage.DecompressConfig{})
+	var sb strings.Builder
+	for i, f := range manifest["files"].([]any) {
+		file := f.(map[string]any)
+		require.Equal(t, fmt.Sprintf("part-%05d.csv.gz", i), file["name"])
+		require.EqualValues(t, 1, file["rows"])
+		content, err := gzipStore.ReadFile(context.Background(), file["name"].(string))
+		require.NoError(t, err)
+		sb.Write(content)
+	}
+	require.Equal(t, `"1","a,b","1.5","1.23","18446744073709551615","2026-01-02 03:04:05"
+"2",\N,\N,\N,\N,\N
+"3","c","-2.25","-4.56","0","2026-10-19 00:00:00"
+`, sb.String())
+
+	// the manifest is used to avoid overwriting the previous output.
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' compression 'gzip'", uri))
+	require.ErrorContains(t, err, "manifest.json already exists")
+
+	// the parquet files can be read by the parquet parser of IMPORT INTO.
+	dir = t.TempDir()
+	uri = "file://" + filepath.ToSlash(dir)
+	tk.MustExec(fmt.Sprintf("select id, v as `v v`, f, d, u, dt, id + 1 as `ID` from t order by id into outfile '%s' format parquet compression 'zstd'", uri))
+	manifest = readManifest(dir)
+	require.Equal(t, "parquet", manifest["format"])
+	require.Equal(t, "zstd", manifest["compression"])
+	require.Len(t, manifest["files"], 1)
+	extStore, err = storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	r, err := extStore.Open(context.Background(), "part-00000.parquet", nil)
+	require.NoError(t, err)
+	parser, err := mydump.NewParquetParser(context.Background(), extStore, r, "part-00000.parquet")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, parser.Close())
+	}()
+	require.Equal(t, []string{"id", "v_v", "f", "d", "u", "dt", "id_1"}, parser.Columns())
+	var rows [][]string
+	for {
+		err := parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		row := make([]string, 0, len(parser.LastRow().Row))
+		for _, d := range parser.LastRow().Row {
+			if d.IsNull() {
+				row = append(row, "<nil>")
+				continue
+			}
+			str, err := d.ToString()
+			require.NoError(t, err)
+			row = append(row, str)
+		}
+		rows = append(rows, row)
+	}
+	require.Equal(t, [][]string{
+		{"1", "a,b", "1.5", "1.23", "18446744073709551615", "2026-01-02 03:04:05", "2"},
+		{"2", "<nil>", "<nil>", "<nil>", "<nil>", "<nil>", "3"},
+		{"3", "c", "-2.25", "-4.56", "0", "2026-10-19 00:00:00", "4"},
+	}, rows)
+	require.Equal(t, types.KindInt64, parser.LastRow().Row[0].Kind())
+	require.Equal(t, types.KindFloat64, parser.LastRow().Row[2].Kind())
+
+	// invalid options
+	uri = "file://" + filepath.ToSlash(t.TempDir())
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' format 'sql'", uri)), "The FORMAT 'sql' is not supported")
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' format parquet compression 'lz4'", uri)), "Invalid option value for COMPRESSION")
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' max_file_size 'abc'", uri)), "Invalid option value for MAX_FILE_SIZE")
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' format parquet fields terminated by ','", uri)), "Unsupported option FIELDS for parquet format")
+	outfile := randomSelectFilePath("TestSelectIntoExternalStorage")
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' compression 'gzip'", outfile)), "Unsupported option COMPRESSION for local OUTFILE")
+	require.ErrorContains(t, tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s' format parquet", outfile)), "Unsupported option FORMAT for local OUTFILE")
+	_, err = os.Stat(outfile)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSelectIntoExternalStoragePrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("create user 'file_user'@'%'")
+	tk.MustExec("grant select on test.* to 'file_user'@'%'")
+	tk.MustExec("grant file on *.* to 'file_user'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "file_user", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	// the external storage without credentials is accessed with the credentials of the server.
+	err := tk1.ExecToErr("select * from t into outfile 'noop://'")
+	require.ErrorContains(t, err, "you need (at least one of) the SUPER privilege(s) for this operation")
+	tk1.MustExec("select * from t into outfile 'noop://?access-key=ak&secret_access_key=sk'")
+	uri := "file://" + filepath.ToSlash(t.TempDir())
+	tk1.MustExec(fmt.Sprintf("select * from t into outfile '%s'", uri))
+	tk.MustExec("select * from t into outfile 'noop://'")
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
@@ -73,6 +74,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/filter"
@@ -85,6 +87,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
 	"github.com/tikv/pd/client/errs"
 	pdHttp "github.com/tikv/pd/client/http"
 	"go.uber.org/zap"
@@ -97,6 +100,7 @@ type ShowExec struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            ast.CIStr
 	Table             *resolve.TableNameW  // Used for showing columns.
+	Procedure         *ast.TableName       // Used for showing create procedure or function.
 	Partition         ast.CIStr            // Used for showing partition
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         ast.CIStr            // Used for show table regions.
@@ -198,6 +202,14 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateView()
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateRoutine(model.RoutineTypeProcedure)
+	case ast.ShowCreateFunction:
+		return e.fetchShowCreateRoutine(model.RoutineTypeFunction)
+	case ast.ShowCreateTrigger:
+		return e.fetchShowCreateTrigger()
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent()
 	case ast.ShowCreatePlacementPolicy:
 		return e.fetchShowCreatePlacementPolicy()
 	case ast.ShowCreateResourceGroup:
@@ -211,7 +223,9 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowRoutineStatus(model.RoutineTypeProcedure)
+	case ast.ShowFunctionStatus:
+		return e.fetchShowRoutineStatus(model.RoutineTypeFunction)
 	case ast.ShowStatus:
 		return e.fetchShowStatus()
 	case ast.ShowTables:
@@ -231,7 +245,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents()
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended(ctx)
 	case ast.ShowStatsMeta:
@@ -1499,6 +1513,47 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 		if err != nil {
 			return err
 		}
+
+		if action := tableInfo.TTLInfo.Action; action != nil {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ACTION")
+				restoreCtx.WritePlain("=")
+				switch action.Tp {
+				case ast.TTLActionArchive:
+					restoreCtx.WriteKeyWord("ARCHIVE TO TABLE ")
+					tableName := ast.TableName{Schema: action.ArchiveSchema, Name: action.ArchiveTable}
+					return tableName.Restore(restoreCtx)
+				case ast.TTLActionExport:
+					restoreCtx.WriteKeyWord("EXPORT TO ")
+					// The URI may contain the credentials of the external storage, so redact it.
+					restoreCtx.WriteString(ast.RedactURL(action.ExportURI))
+					restoreCtx.WriteKeyWord(" FORMAT")
+					restoreCtx.WritePlain("=")
+					restoreCtx.WriteString(action.ExportFormat)
+				default:
+					restoreCtx.WriteKeyWord(action.Tp.String())
+				}
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if condition := tableInfo.TTLInfo.Condition; condition != "" {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_WHERE")
+				restoreCtx.WritePlainf("=(%s)", condition)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -2008,14 +2063,396 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
+func (e *ShowExec) fetchShowTriggers() error {
+	db, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	return forEachTrigger(e.Ctx(), e.is, []*model.DBInfo{db}, func(db *model.DBInfo, tbl *model.TableInfo, trigger *model.TriggerInfo, _ int) {
+		e.appendRow([]any{
+			trigger.Name.O,
+			trigger.Event.String(),
+			tbl.Name.O,
+			trigger.Body,
+			trigger.Timing.String(),
+			types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeDatetime, 2),
+			trigger.SQLMode.String(),
+			definerName(trigger.Definer),
+			trigger.Charset,
+			trigger.Collate,
+			db.Collate,
+		})
+	})
+}
+
+func (e *ShowExec) fetchShowCreateTrigger() error {
+	db, ok := e.is.SchemaByName(e.Procedure.Schema)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.Procedure.Schema)
+	}
+	tbl, trigger, err := infoschema.FindTriggerTable(context.Background(), e.is, db.Name, e.Procedure.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if trigger == nil || !canShowTrigger(e.Ctx(), db, tbl) {
+		return dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	e.appendRow([]any{
+		trigger.Name.O,
+		trigger.SQLMode.String(),
+		constructShowCreateTrigger(e.Ctx(), tbl, trigger),
+		trigger.Charset,
+		trigger.Collate,
+		db.Collate,
+		types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeTimestamp, 2),
+	})
+	return nil
+}
+
+// forEachTrigger calls fn for every trigger in dbs which the current user has
+// the TRIGGER privilege on. order is the 1-based activation order of the
+// trigger among the ones of the same table, timing and event.
+func forEachTrigger(sctx sessionctx.Context, is infoschema.InfoSchema, dbs []*model.DBInfo,
+	fn func(db *model.DBInfo, tbl *model.TableInfo, trigger *model.TriggerInfo, order int)) error {
+	slices.SortFunc(dbs, func(a, b *model.DBInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+	for _, db := range dbs {
+		if util.IsMemDB(db.Name.L) {
+			continue
+		}
+		tbls, err := is.SchemaTableInfos(context.Background(), db.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		slices.SortFunc(tbls, func(a, b *model.TableInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+		for _, tbl := range tbls {
+			if len(tbl.Triggers) == 0 || !canShowTrigger(sctx, db, tbl) {
+				continue
+			}
+			orders := make(map[[2]int]int)
+			for _, trigger := range tbl.Triggers {
+				key := [2]int{int(trigger.Timing), int(trigger.Event)}
+				orders[key]++
+				fn(db, tbl, trigger, orders[key])
+			}
+		}
+	}
+	return nil
+}
+
+// canShowTrigger checks whether the current user has the TRIGGER privilege on the table.
+func canShowTrigger(sctx sessionctx.Context, db *model.DBInfo, tbl *model.TableInfo) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil || sctx.GetSessionVars().User == nil {
+		return true
+	}
+	return checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, db.Name.L, tbl.Name.L, "", mysql.TriggerPriv)
+}
+
+func constructShowCreateTrigger(sctx sessionctx.Context, tbl *model.TableInfo, trigger *model.TriggerInfo) string {
+	sqlMode := sctx.GetSessionVars().SQLMode
+	var buf bytes.Buffer
+	buf.WriteString("CREATE ")
+	writeDefiner(&buf, trigger.Definer, sqlMode)
+	fmt.Fprintf(&buf, "TRIGGER %s %s %s ON %s FOR EACH ROW %s", stringutil.Escape(trigger.Name.O, sqlMode),
+		trigger.Timing, trigger.Event, stringutil.Escape(tbl.Name.O, sqlMode), trigger.Body)
+	return buf.String()
+}
+
+func (e *ShowExec) fetchShowRoutineStatus(tp model.RoutineType) error {
+	loc := e.Ctx().GetSessionVars().Location()
+	return forEachRoutine(e.Ctx(), e.is, func(db *model.DBInfo, routine *model.RoutineInfo) {
+		if routine.Type != tp {
+			return
+		}
+		e.appendRow([]any{
+			db.Name.O,
+			routine.Name.O,
+			routine.Type.String(),
+			definerName(routine.Definer),
+			types.NewTime(types.FromGoTime(routine.LastAlter.In(loc)), mysql.TypeDatetime, 0),
+			types.NewTime(types.FromGoTime(routine.Created.In(loc)), mysql.TypeDatetime, 0),
+			routine.Security.String(),
+			routine.Comment,
+			routine.Charset,
+			routine.Collate,
+			db.Collate,
+		})
+	})
+}
+
+func (e *ShowExec) fetchShowCreateRoutine(tp model.RoutineType) error {
+	db, routine, err := plannercore.LoadRoutine(e.Ctx().GetPlanCtx(), e.is, e.Procedure.Schema, tp, e.Procedure.Name)
+	if err != nil {
+		return err
+	}
+	// The definition is only visible to the definer and the users who can
+	// read all the routines.
+	var createStmt any
+	if canShowRoutineBody(e.Ctx(), routine) {
+		createStmt, err = constructShowCreateRoutine(e.Ctx(), routine)
+		if err != nil {
+			return err
+		}
+	}
+	e.appendRow([]any{routine.Name.O, routine.SQLMode.String(), createStmt, routine.Charset, routine.Collate, db.Collate})
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+// forEachRoutine calls fn for every stored routine in the databases visible
+// to the current user.
+func forEachRoutine(sctx sessionctx.Context, is infoschema.InfoSchema, fn func(db *model.DBInfo, routine *model.RoutineInfo)) error {
+	checker := privilege.GetPrivilegeManager(sctx)
+	reader := meta.NewReader(sctx.GetStore().GetSnapshot(kv.MaxVersion))
+	dbs := is.AllSchemas()
+	slices.SortFunc(dbs, func(a, b *model.DBInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+	for _, db := range dbs {
+		// The memory databases are not persisted, so they have no routines.
+		if util.IsMemDB(db.Name.L) {
+			continue
+		}
+		if checker != nil && !checker.DBIsVisible(sctx.GetSessionVars().ActiveRoles, db.Name.O) {
+			continue
+		}
+		routines, err := reader.ListRoutines(db.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		slices.SortFunc(routines, func(a, b *model.RoutineInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+		for _, routine := range routines {
+			fn(db, routine)
+		}
+	}
 	return nil
 }
 
+func definerName(definer *auth.UserIdentity) string {
+	if definer == nil {
+		return ""
+	}
+	if definer.AuthUsername != "" || definer.AuthHostname != "" {
+		return fmt.Sprintf("%s@%s", definer.AuthUsername, definer.AuthHostname)
+	}
+	return fmt.Sprintf("%s@%s", definer.Username, definer.Hostname)
+}
+
+// writeDefiner writes the `DEFINER=user@host ` clause of the SHOW CREATE statements.
+func writeDefiner(buf *bytes.Buffer, definer *auth.UserIdentity, sqlMode mysql.SQLMode) {
+	if definer == nil {
+		return
+	}
+	user, host := definer.AuthUsername, definer.AuthHostname
+	if user == "" && host == "" {
+		user, host = definer.Username, definer.Hostname
+	}
+	fmt.Fprintf(buf, "DEFINER=%s@%s ", stringutil.Escape(user, sqlMode), stringutil.Escape(host, sqlMode))
+}
+
+// canShowRoutineBody checks whether the current user is the definer of the
+// routine or has the global SELECT privilege.
+func canShowRoutineBody(sctx sessionctx.Context, routine *model.RoutineInfo) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	user := sctx.GetSessionVars().User
+	if checker == nil || user == nil {
+		return true
+	}
+	if routine.Definer != nil && definerName(routine.Definer) == fmt.Sprintf("%s@%s", user.AuthUsername, user.AuthHostname) {
+		return true
+	}
+	return checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, "", "", "", mysql.SelectPriv)
+}
+
+func constructShowCreateRoutine(sctx sessionctx.Context, routine *model.RoutineInfo) (string, error) {
+	sqlMode := sctx.GetSessionVars().SQLMode
+	var buf bytes.Buffer
+	buf.WriteString("CREATE ")
+	writeDefiner(&buf, routine.Definer, sqlMode)
+	fmt.Fprintf(&buf, "%s %s(%s)", routine.Type, stringutil.Escape(routine.Name.O, sqlMode), routine.ParamStr)
+	if routine.IsFunction() {
+		fmt.Fprintf(&buf, " RETURNS %s", routine.ReturnType.CompactStr())
+	}
+	if routine.Deterministic {
+		buf.WriteString("\n    DETERMINISTIC")
+	}
+	if routine.DataAccess != ast.ProcedureContainsSQL {
+		fmt.Fprintf(&buf, "\n    %s", routine.DataAccess)
+	}
+	if routine.Security != ast.SecurityDefiner {
+		fmt.Fprintf(&buf, "\n    SQL SECURITY %s", routine.Security.String())
+	}
+	if routine.Comment != "" {
+		fmt.Fprintf(&buf, "\n    COMMENT '%s'", format.OutputFormat(routine.Comment))
+	}
+	fmt.Fprintf(&buf, "\n%s", routine.Body)
+	return buf.String(), nil
+}
+
+func (e *ShowExec) fetchShowEvents() error {
+	if e.DBName.L == "" {
+		return plannererrors.ErrNoDB
+	}
+	db, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	if !canShowEvent(e.Ctx(), db) {
+		user := e.Ctx().GetSessionVars().User
+		return exeerrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, db.Name.O)
+	}
+	events, err := meta.NewReader(e.Ctx().GetStore().GetSnapshot(kv.MaxVersion)).ListEvents(db.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	slices.SortFunc(events, func(a, b *model.EventInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+	for _, event := range events {
+		loc := eventLocation(event)
+		tp, executeAt, intervalValue, intervalField, starts, ends := "ONE TIME", any(nil), any(nil), any(nil), any(nil), any(nil)
+		if event.IsRecurring() {
+			tp, intervalValue, intervalField = "RECURRING", event.IntervalValue, event.IntervalField.String()
+			starts = types.NewTime(types.FromGoTime(event.Starts.In(loc)), mysql.TypeDatetime, 0)
+			if !event.Ends.IsZero() {
+				ends = types.NewTime(types.FromGoTime(event.Ends.In(loc)), mysql.TypeDatetime, 0)
+			}
+		} else {
+			executeAt = types.NewTime(types.FromGoTime(event.ExecuteAt.In(loc)), mysql.TypeDatetime, 0)
+		}
+		e.appendRow([]any{
+			db.Name.O,
+			event.Name.O,
+			event.TimeZone,
+			definerName(event.Definer),
+			tp,
+			executeAt,
+			intervalValue,
+			intervalField,
+			starts,
+			ends,
+			eventStatusName(event.Status),
+			0,
+			event.Charset,
+			event.Collate,
+			db.Collate,
+		})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateEvent() error {
+	if e.Procedure.Schema.L == "" {
+		return plannererrors.ErrNoDB
+	}
+	db, ok := e.is.SchemaByName(e.Procedure.Schema)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.Procedure.Schema)
+	}
+	if !canShowEvent(e.Ctx(), db) {
+		user := e.Ctx().GetSessionVars().User
+		return exeerrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, db.Name.O)
+	}
+	event, err := meta.NewReader(e.Ctx().GetStore().GetSnapshot(kv.MaxVersion)).GetEvent(db.ID, e.Procedure.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if event == nil {
+		return meta.ErrEventNotExists.GenWithStackByArgs(e.Procedure.Name.O)
+	}
+	e.appendRow([]any{
+		event.Name.O,
+		event.SQLMode.String(),
+		event.TimeZone,
+		constructShowCreateEvent(e.Ctx(), event),
+		event.Charset,
+		event.Collate,
+		db.Collate,
+	})
+	return nil
+}
+
+// forEachEvent calls fn for every scheduled event in the databases which the
+// current user has the EVENT privilege on.
+func forEachEvent(sctx sessionctx.Context, is infoschema.InfoSchema, fn func(db *model.DBInfo, event *model.EventInfo)) error {
+	reader := meta.NewReader(sctx.GetStore().GetSnapshot(kv.MaxVersion))
+	dbs := is.AllSchemas()
+	slices.SortFunc(dbs, func(a, b *model.DBInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+	for _, db := range dbs {
+		// The memory databases are not persisted, so they have no events.
+		if util.IsMemDB(db.Name.L) || !canShowEvent(sctx, db) {
+			continue
+		}
+		events, err := reader.ListEvents(db.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		slices.SortFunc(events, func(a, b *model.EventInfo) int { return strings.Compare(a.Name.L, b.Name.L) })
+		for _, event := range events {
+			fn(db, event)
+		}
+	}
+	return nil
+}
+
+// canShowEvent checks whether the current user has the EVENT privilege on the database.
+func canShowEvent(sctx sessionctx.Context, db *model.DBInfo) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil || sctx.GetSessionVars().User == nil {
+		return true
+	}
+	return checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, db.Name.L, "", "", mysql.EventPriv)
+}
+
+// eventLocation returns the time zone of the event, the schedule of the event
+// is displayed in it.
+func eventLocation(event *model.EventInfo) *time.Location {
+	loc, err := timeutil.ParseTimeZone(event.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func eventStatusName(status ast.EventStatus) string {
+	switch status {
+	case ast.EventStatusDisable:
+		return "DISABLED"
+	case ast.EventStatusDisableOnSlave:
+		return "SLAVESIDE_DISABLED"
+	}
+	return "ENABLED"
+}
+
+func constructShowCreateEvent(sctx sessionctx.Context, event *model.EventInfo) string {
+	sqlMode := sctx.GetSessionVars().SQLMode
+	loc := eventLocation(event)
+	var buf bytes.Buffer
+	buf.WriteString("CREATE ")
+	writeDefiner(&buf, event.Definer, sqlMode)
+	fmt.Fprintf(&buf, "EVENT %s ON SCHEDULE ", stringutil.Escape(event.Name.O, sqlMode))
+	if event.IsRecurring() {
+		value := event.IntervalValue
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			value = "'" + format.OutputFormat(value) + "'"
+		}
+		fmt.Fprintf(&buf, "EVERY %s %s STARTS '%s'", value, event.IntervalField, event.Starts.In(loc).Format(time.DateTime))
+		if !event.Ends.IsZero() {
+			fmt.Fprintf(&buf, " ENDS '%s'", event.Ends.In(loc).Format(time.DateTime))
+		}
+	} else {
+		fmt.Fprintf(&buf, "AT '%s'", event.ExecuteAt.In(loc).Format(time.DateTime))
+	}
+	if event.Preserve {
+		buf.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		buf.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	fmt.Fprintf(&buf, " %s", event.Status)
+	if event.Comment != "" {
+		fmt.Fprintf(&buf, " COMMENT '%s'", format.OutputFormat(event.Comment))
+	}
+	fmt.Fprintf(&buf, " DO %s", event.Body)
+	return buf.String()
+}
+
 func (e *ShowExec) fetchShowPlugins() error {
 	tiPlugins := plugin.GetAll()
 	for _, ps := range tiPlugins {
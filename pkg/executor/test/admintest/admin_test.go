@@ -904,6 +904,46 @@ func TestAdminCheckTableWithMultiValuedIndex(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestAlterTableCheckPartition(t *testing.T) {
+	store, domain := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index idx(b)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (11, 11), (12, 12)")
+	tk.MustExec("alter table t check partition p0, p1")
+	tk.MustExec("alter table t check partition all")
+	tk.MustGetErrCode("alter table t check partition p2", mysql.ErrUnknownPartition)
+	tk.MustExec("create table nt (a int)")
+	tk.MustGetErrCode("alter table nt check partition all", mysql.ErrPartitionMgmtOnNonpartitioned)
+
+	// Remove an index entry of p1.
+	sctx := mock.NewContext()
+	sctx.Store = store
+	tbl, err := domain.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	indexOpr := tables.NewIndex(tblInfo.Partition.Definitions[1].ID, tblInfo, tblInfo.Indices[0])
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	require.NoError(t, indexOpr.Delete(sctx.GetTableCtx(), txn, types.MakeDatums(11), kv.IntHandle(3)))
+	require.NoError(t, txn.Commit(context.Background()))
+
+	tk.MustExec("alter table t check partition p0")
+	err = tk.ExecToErr("alter table t check partition p1")
+	require.True(t, consistency.ErrAdminCheckInconsistent.Equal(err), "%v", err)
+	err = tk.ExecToErr("alter table t check partition all")
+	require.True(t, consistency.ErrAdminCheckInconsistent.Equal(err), "%v", err)
+
+	// REPAIR PARTITION rebuilds the partition, the index entries are regenerated from the rows.
+	tk.MustExec("alter table t repair partition p1")
+	tk.MustExec("alter table t check partition all")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select b from t use index(idx) where b > 10 order by b").Check(testkit.Rows("11", "12"))
+	tk.MustGetErrCode("alter table t repair partition p2", mysql.ErrWrongPartitionName)
+	tk.MustGetErrCode("alter table nt repair partition all", mysql.ErrPartitionMgmtOnNonpartitioned)
+}
+
 func TestAdminCheckPartitionTableFailed(t *testing.T) {
 	store, domain := testkit.CreateMockStoreAndDomain(t)
 
@@ -0,0 +1,168 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create event ev1 on schedule every 1 hour starts '2099-01-01 00:00:00' do insert into t values (1)")
+	tk.MustExec("create event test.ev2 on schedule at '2099-01-01 00:00:00' on completion preserve disable comment 'once' " +
+		"do begin delete from t; insert into t values (2); end")
+	tk.MustExec("create event ev3 on schedule every '1:30' minute_second starts '2099-01-01 00:00:00' ends '2099-02-01 00:00:00' do delete from t")
+	tk.MustGetErrCode("create event ev1 on schedule every 1 day do select 1", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists ev1 on schedule every 1 day do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'ev1' already exists"))
+	tk.MustGetErrCode("create event ev4 on schedule every 0 hour do select 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event ev4 on schedule every -1 day do select 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event ev4 on schedule every 1 day starts '2099-01-02 00:00:00' ends '2099-01-01 00:00:00' do select 1", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event ev4 on schedule every 1 day do create event ev5 on schedule every 1 day do select 1", errno.ErrParse)
+	tk.MustGetErrCode("create event not_exist.ev4 on schedule every 1 day do select 1", errno.ErrBadDB)
+	tk.MustExec("create event ev4 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+
+	tk.MustQuery("show events").Check(testkit.RowsWithSep("|",
+		"test|ev1|+00:00|root@%|RECURRING|<nil>|1|HOUR|2099-01-01 00:00:00|<nil>|ENABLED|0|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+		"test|ev2|+00:00|root@%|ONE TIME|2099-01-01 00:00:00|<nil>|<nil>|<nil>|<nil>|DISABLED|0|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+		"test|ev3|+00:00|root@%|RECURRING|<nil>|1:30|MINUTE_SECOND|2099-01-01 00:00:00|2099-02-01 00:00:00|ENABLED|0|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+	))
+	tk.MustQuery("show events like 'ev_'").CheckAt([]int{1}, testkit.Rows("ev1", "ev2", "ev3"))
+	tk.MustQuery("show events like 'x%'").Check(testkit.Rows())
+	tk.MustQuery("show events from test like 'ev2'").CheckAt([]int{1, 4}, testkit.RowsWithSep("|", "ev2|ONE TIME"))
+	tk.MustQuery("show events where status = 'DISABLED'").CheckAt([]int{1}, testkit.Rows("ev2"))
+	tk.MustQuery("show create event ev2").Check(testkit.RowsWithSep("|",
+		"ev2|ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION|+00:00|"+
+			"CREATE DEFINER=`root`@`%` EVENT `ev2` ON SCHEDULE AT '2099-01-01 00:00:00' ON COMPLETION PRESERVE DISABLE COMMENT 'once' DO BEGIN DELETE FROM `t`;INSERT INTO `t` VALUES (2); END|"+
+			"utf8mb4|utf8mb4_bin|utf8mb4_bin"))
+	tk.MustQuery("show create event ev3").CheckAt([]int{3}, testkit.RowsWithSep("|",
+		"CREATE DEFINER=`root`@`%` EVENT `ev3` ON SCHEDULE EVERY '1:30' MINUTE_SECOND STARTS '2099-01-01 00:00:00' ENDS '2099-02-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO DELETE FROM `t`"))
+	require.ErrorContains(t, tk.QueryToErr("show create event not_exist"), "Unknown event 'not_exist'")
+
+	// The schedule is displayed in the time zone of the event.
+	tk.MustExec("set @@time_zone = '+08:00'")
+	tk.MustExec("create event ev5 on schedule at '2099-01-01 08:00:00' do select 1")
+	tk.MustQuery("show events like 'ev5'").CheckAt([]int{2, 5}, testkit.RowsWithSep("|", "+08:00|2099-01-01 08:00:00"))
+	tk.MustQuery("select execute_at, on_completion from information_schema.events where event_name = 'ev5'").Check(testkit.Rows("2099-01-01 08:00:00 NOT PRESERVE"))
+	tk.MustExec("set @@time_zone = '+00:00'")
+
+	tk.MustExec("alter event ev1 disable")
+	tk.MustExec("alter event ev2 on schedule every 2 day starts '2099-01-01 00:00:00' enable comment ''")
+	tk.MustQuery("select event_name, event_type, interval_value, interval_field, status, event_comment from information_schema.events where event_schema = 'test' order by event_name").Check(testkit.Rows(
+		"ev1 RECURRING 1 HOUR DISABLED ",
+		"ev2 RECURRING 2 DAY ENABLED ",
+		"ev3 RECURRING 1:30 MINUTE_SECOND ENABLED ",
+		"ev5 ONE TIME <nil> <nil> ENABLED ",
+	))
+	tk.MustExec("alter event ev1 rename to ev6 do insert into t values (6)")
+	tk.MustQuery("select event_definition, last_executed from information_schema.events where event_name = 'ev6'").Check(testkit.Rows("INSERT INTO `t` VALUES (6) <nil>"))
+	tk.MustGetErrCode("alter event ev1 enable", errno.ErrEventDoesNotExist)
+	tk.MustGetErrCode("alter event ev6 rename to ev6", errno.ErrEventSameName)
+	tk.MustGetErrCode("alter event ev6 rename to ev2", errno.ErrEventAlreadyExists)
+	tk.MustExec("alter event ev6 on schedule at '2000-01-01 00:00:00'")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1589 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was not changed. Specify a time in the future."))
+
+	tk.MustExec("drop event ev6")
+	tk.MustGetErrCode("drop event ev6", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists ev6")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'ev6'"))
+	tk.MustExec("drop database test")
+	tk.MustExec("create database test")
+	tk.MustQuery("show events from test").Check(testkit.Rows())
+}
+
+func TestEventPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user u1, u2")
+	tk.MustExec("create database db")
+	tk.MustExec("grant event on db.* to u1")
+	tk.MustExec("create event db.ev on schedule every 1 day starts '2099-01-01 00:00:00' do select 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("create event db.ev1 on schedule every 1 day starts '2099-01-01 00:00:00' do select 1")
+	tk1.MustQuery("select event_name, definer from information_schema.events").Check(testkit.Rows("ev @", "ev1 u1@%"))
+	tk1.MustGetErrCode("create definer = 'u2'@'%' event db.ev2 on schedule every 1 day do select 1", errno.ErrSpecificAccessDenied)
+	tk1.MustExec("alter event db.ev disable")
+	tk1.MustExec("drop event db.ev")
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrCode("create event db.ev2 on schedule every 1 day do select 1", errno.ErrDBaccessDenied)
+	tk2.MustGetErrCode("alter event db.ev1 disable", errno.ErrDBaccessDenied)
+	tk2.MustGetErrCode("drop event db.ev1", errno.ErrDBaccessDenied)
+	require.ErrorContains(t, tk2.QueryToErr("show events from db"), "Access denied for user 'u2'@'%' to database 'db'")
+	tk2.MustQuery("select * from information_schema.events").Check(testkit.Rows())
+}
+
+func TestEventExecution(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table t_once (a int)")
+
+	tk.MustExec("create event ev on schedule every 1 second do insert into t values (1)")
+	tk.MustExec("create event ev_once on schedule at now() + interval 1 second do insert into t_once values (1)")
+	tk.MustExec("create event ev_preserve on schedule at now() + interval 1 second on completion preserve do insert into t_once values (2)")
+	tk.MustExec("create event ev_fail on schedule at now() + interval 1 second on completion preserve do insert into not_exist values (1)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t").Rows()) >= 2 &&
+			len(tk.MustQuery("select * from t_once").Rows()) == 2 &&
+			len(tk.MustQuery("show events where status = 'DISABLED'").Rows()) == 2 &&
+			len(tk.MustQuery("show events like 'ev_once'").Rows()) == 0
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select a from t_once order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select event_name, status from information_schema.events order by event_name").Check(testkit.Rows(
+		"ev ENABLED",
+		"ev_fail DISABLED",
+		"ev_preserve DISABLED",
+	))
+	tk.MustQuery("select count(*) from information_schema.events where last_executed is not null").Check(testkit.Rows("3"))
+	tk.MustQuery("select event_name, status, error_message from mysql.tidb_event_history where event_name != 'ev' order by event_name").Check(testkit.Rows(
+		"ev_fail failed [schema:1146]Table 'test.not_exist' doesn't exist",
+		"ev_once finished <nil>",
+		"ev_preserve finished <nil>",
+	))
+
+	// The events are not executed if the event scheduler is off.
+	tk.MustExec("alter event ev disable")
+	tk.MustExec("set global event_scheduler = off")
+	defer tk.MustExec("set global event_scheduler = on")
+	tk.MustExec("delete from t")
+	tk.MustExec("alter event ev enable")
+	time.Sleep(3 * time.Second)
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+	tk.MustExec("set global event_scheduler = on")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t").Rows()) > 0
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustExec("drop event ev")
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestMaterializedViewDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c varchar(10))")
+	tk.MustExec("insert into t values (1, 1, 'x'), (1, 2, 'y'), (2, 3, 'x'), (null, 4, null)")
+
+	tk.MustExec("create materialized view mv (a, cnt, s) refresh fast as select a, count(*), sum(b) from t group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("<nil> 1 4", "1 2 3", "2 1 3"))
+	tk.MustGetErrCode("create materialized view mv as select 1", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+	tk.MustQuery("show create table mv").MultiCheckContain([]string{"`a` int", "`cnt` bigint", "KEY `mview_group` (`a`)"})
+
+	// The complete refresh works for any query.
+	tk.MustExec("create materialized view mv2 as select c, max(b) from t where b > 1 group by c having count(*) > 0")
+	tk.MustQuery("select * from mv2 order by c").Check(testkit.Rows("<nil> 4", "x 3", "y 2"))
+	tk.MustExec("create materialized view mv3 as select t1.a, count(*) from t t1 join t t2 on t1.a = t2.a group by t1.a")
+	tk.MustQuery("select * from mv3 order by a").Check(testkit.Rows("1 4", "2 1"))
+
+	// Only single-table aggregates grouped by plain columns are refreshed incrementally.
+	tk.MustGetErrCode("create materialized view mv4 refresh fast as select t1.a, count(*) from t t1 join t t2 on t1.a = t2.a group by t1.a", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create materialized view mv4 refresh fast as select a + 1, count(*) from t group by a + 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create materialized view mv4 refresh fast as select a, count(*), now() from t group by a", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create materialized view mv4 refresh fast as select count(*) from t", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create materialized view mv4 as select @a", errno.ErrViewSelectVariable)
+	tk.MustGetErrCode("create materialized view mv4 (x) as select a, b from t", errno.ErrViewWrongList)
+	tk.MustGetErrCode("create materialized view mv4 as select a from not_exist", errno.ErrNoSuchTable)
+
+	// The result is only modified by the refresh.
+	tk.MustGetErrCode("insert into mv values (3, 1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("replace into mv values (3, 1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set cnt = 0", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set cnt = 0 where a = 1", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from mv", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete mv from mv", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("truncate table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("drop table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("alter table mv add column d int", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table mv add index idx_cnt (cnt)")
+
+	// The base table of the views can't be dropped or truncated.
+	tk.MustGetErrCode("drop table t", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("truncate table t", errno.ErrUnsupportedDDLOperation)
+	// The GROUP BY columns of the incrementally refreshed views can't be dropped or renamed.
+	tk.MustGetErrMsg("alter table t drop column a", "[ddl:8200]Unsupported DROP COLUMN on column 'a' referenced by the GROUP BY of materialized view 'mv'")
+	tk.MustGetErrCode("alter table t rename column a to a1", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t change a a1 int", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t modify a bigint")
+	tk.MustExec("alter table t rename column c to c1")
+	tk.MustExec("alter table t rename column c1 to c")
+
+	tk.MustGetErrCode("drop materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view mv2 fast", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("drop materialized view mv")
+	tk.MustExec("drop materialized view mv2")
+	tk.MustExec("drop materialized view mv3")
+	tk.MustGetErrCode("drop materialized view mv", errno.ErrBadTable)
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1051 Unknown table 'test.mv'"))
+	tk.MustExec("drop table t")
+}
+
+func TestMaterializedViewRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, s varchar(10), b decimal(10, 2))")
+	tk.MustExec("insert into t values (1, 1, 'x', 1.5), (2, 1, 'x', 2), (3, 2, 'y', 3), (4, null, null, 4)")
+	tk.MustExec("create materialized view mv refresh fast as select a, s, count(*) cnt, sum(b) total, max(id) max_id from t where id < 100 group by a, s")
+	tk.MustExec("create materialized view mv_complete as select a, count(*) cnt from t group by a")
+	tk.MustQuery("select * from mv order by a, s").Check(testkit.Rows("<nil> <nil> 1 4.00 4", "1 x 2 3.50 2", "2 y 1 3.00 3"))
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	// The changed groups are logged with the changes.
+	tk.MustExec("insert into t values (5, 2, 'y', 1), (6, 3, 'z', 1)")
+	tk.MustExec("update t set b = b * 2 where id = 1")
+	tk.MustExec("update t set a = 4, s = 'w' where id = 4")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustExec("insert into t values (100, 1, 'x', 100)")
+	tk.MustExec("insert into t values (3, 0, '', 0) on duplicate key update b = 10")
+	tk.MustExec("replace into t values (6, 3, 'z', 7)")
+	tk.MustExec("begin")
+	tk.MustExec("delete from t where id = 5")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log where mview_id = (select tidb_table_id from information_schema.tables where table_name = 'mv')").
+		Check(testkit.Rows("9"))
+	// The result isn't changed until refreshed.
+	tk.MustQuery("select * from mv order by a, s").Check(testkit.Rows("<nil> <nil> 1 4.00 4", "1 x 2 3.50 2", "2 y 1 3.00 3"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by a, s").Check(testkit.Rows("1 x 1 3.00 1", "2 y 2 11.00 5", "3 z 1 7.00 6", "4 w 1 4.00 4"))
+	tk.MustQuery("select * from mv order by a, s").Check(tk.MustQuery("select a, s, count(*), sum(b), max(id) from t where id < 100 group by a, s order by a, s").Rows())
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	tk.MustExec("delete from t where a = 1")
+	tk.MustExec("refresh materialized view mv fast")
+	tk.MustQuery("select * from mv order by a, s").Check(testkit.Rows("2 y 2 11.00 5", "3 z 1 7.00 6", "4 w 1 4.00 4"))
+
+	// The complete refresh recomputes the whole result.
+	tk.MustQuery("select * from mv_complete order by a").Check(testkit.Rows("<nil> 1", "1 2", "2 1"))
+	tk.MustExec("refresh materialized view mv_complete")
+	tk.MustQuery("select * from mv_complete order by a").Check(testkit.Rows("2 2", "3 1", "4 1"))
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select * from mv order by a, s").Check(testkit.Rows("2 y 2 11.00 5", "3 z 1 7.00 6", "4 w 1 4.00 4"))
+
+	// The groups are matched by the types of the columns.
+	tk.MustExec("create table t2 (d datetime, e enum('a', 'b'), f double, g varbinary(10), v int)")
+	tk.MustExec("insert into t2 values ('2026-01-01 10:00:00', 'a', 0.1, 'x''y', 1), (null, 'b', 2.5, '\\\\', 2)")
+	tk.MustExec("create materialized view mv2 refresh fast as select d, e, f, g, sum(v) from t2 group by d, e, f, g")
+	tk.MustExec("update t2 set v = v + 10")
+	tk.MustExec("insert into t2 values ('2026-01-01 10:00:00', 'a', 0.1, 'x''y', 100)")
+	tk.MustExec("refresh materialized view mv2")
+	tk.MustQuery("select * from mv2 order by e").Check(testkit.Rows("2026-01-01 10:00:00 a 0.1 x'y 111", "<nil> b 2.5 \\ 12"))
+
+	// Dropping the view stops logging.
+	tk.MustExec("drop materialized view mv")
+	tk.MustExec("insert into t values (7, 7, 'q', 1)")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+	tk.MustExec("drop materialized view mv_complete")
+	tk.MustExec("drop table t")
+}
+
+func TestMaterializedViewRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1, 1, 1), (1, 2, 2), (2, 3, 3)")
+	tk.MustExec("create materialized view mv refresh fast as select a, count(*) cnt, sum(b) sb from t where c > 0 group by a")
+
+	hasMView := func(sql string) bool {
+		for _, row := range tk.MustQuery("explain format = 'brief' " + sql).Rows() {
+			for _, col := range row {
+				if s, ok := col.(string); ok && strings.Contains(s, "table:mv") {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	sql := "select a, count(*), sum(b) from t where c > 0 group by a order by a"
+	tk.MustQuery(sql).Check(testkit.Rows("1 2 3", "2 1 3"))
+	if hasMView(sql) {
+		t.Fatal("the rewrite is disabled by default")
+	}
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = on")
+	if !hasMView(sql) {
+		t.Fatal("the query should be rewritten")
+	}
+	// The results are read from the view, which is stale until refreshed.
+	tk.MustExec("insert into t values (3, 1, 1)")
+	tk.MustQuery(sql).Check(testkit.Rows("1 2 3", "2 1 3"))
+	tk.MustQuery("select count(*) as n, a from t t1 where t1.c > 0 group by t1.a having n > 1").Check(testkit.Rows("2 1"))
+	tk.MustQuery("select a, sum(b) + 1 from t where c > 0 group by a order by count(*) desc, a limit 1").Check(testkit.Rows("1 4"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery(sql).Check(testkit.Rows("1 2 3", "2 1 3", "3 1 1"))
+
+	// The queries which can't be answered by the view read the base table.
+	for _, sql := range []string{
+		"select a, count(*) from t group by a",
+		"select a, count(*) from t where c > 1 group by a",
+		"select a, max(b) from t where c > 0 group by a",
+		"select b, count(*) from t where c > 0 group by b",
+		"select a, count(*) from t where c > 0 group by a, b",
+		"select a, count(*) from t where c > 0 group by a having sum(c) > 0",
+		"select a, count(*) from t where c > 0 group by a with rollup",
+	} {
+		if hasMView(sql) {
+			t.Fatalf("the query %s shouldn't be rewritten", sql)
+		}
+	}
+
+	// The view isn't used if it's too stale or the table is modified in the transaction.
+	tk.MustExec("set @@tidb_materialized_view_max_staleness = 0")
+	if hasMView(sql) {
+		t.Fatal("the view is too stale")
+	}
+	tk.MustExec("set @@tidb_materialized_view_max_staleness = 3600")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (3, 1, 1)")
+	tk.MustQuery(sql).Check(testkit.Rows("1 2 3", "2 1 3", "3 2 2"))
+	tk.MustExec("rollback")
+	if !hasMView(sql) {
+		t.Fatal("the query should be rewritten")
+	}
+}
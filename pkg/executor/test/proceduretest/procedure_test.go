@@ -0,0 +1,312 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec(`create procedure fill(in n int, out total int) sql security invoker
+begin
+	declare i int default 0;
+	set total = 0;
+	while i < n do
+		set i = i + 1;
+		if i % 2 = 0 then
+			insert into t values (i, i * 10);
+		elseif i = 3 then
+			insert into t values (i, 0);
+		else
+			iterate_label: begin
+				leave iterate_label;
+			end;
+		end if;
+	end while;
+	select sum(v) into total from t;
+end`)
+	tk.MustExec("call fill(6, @total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("120"))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("2 20", "3 0", "4 40", "6 60"))
+
+	// The result of the last SELECT is returned to the client.
+	tk.MustExec(`create procedure query_t(min_v int) sql security invoker
+begin
+	declare c int;
+	select count(*) into c from t where v >= min_v;
+	select id, c from t where v >= min_v order by id;
+end`)
+	tk.MustQuery("call query_t(40)").Check(testkit.Rows("4 2", "6 2"))
+	tk.MustQuery("call test.query_t(100)").Check(testkit.Rows())
+
+	// Only one result set can be returned.
+	tk.MustExec(`create procedure query_twice() sql security invoker
+begin
+	select 1;
+	select 2;
+end`)
+	require.EqualError(t, tk.ExecToErr("call query_twice()"), "[executor:1312]PROCEDURE test.query_twice can't return a result set in the given context")
+
+	// SQL SECURITY DEFINER is rejected, including the implicit default.
+	definerErr := "[ddl:8200]Unsupported SQL SECURITY DEFINER for stored routines, specify SQL SECURITY INVOKER explicitly"
+	tk.MustGetErrMsg("create procedure p_definer() sql security definer begin end", definerErr)
+	tk.MustGetErrMsg("create function f_definer() returns int sql security definer return 1", definerErr)
+	tk.MustGetErrMsg("create procedure p_default() begin end", definerErr)
+	tk.MustGetErrMsg("create function f_default() returns int deterministic return 1", definerErr)
+	tk.MustGetErrMsg("create procedure p_override() sql security invoker sql security definer begin end", definerErr)
+	tk.MustGetErrCode("call p_default()", errno.ErrSpDoesNotExist)
+	tk.MustExec("create procedure p_invoker() sql security invoker begin end")
+	tk.MustQuery("select security_type from information_schema.routines where routine_name = 'p_invoker'").Check(testkit.Rows("INVOKER"))
+
+	// Nested calls and OUT arguments bound to local variables.
+	tk.MustExec(`create procedure nested_fill(out res int) sql security invoker
+begin
+	declare tmp int;
+	call fill(0, tmp);
+	set res = tmp + 1;
+end`)
+	tk.MustExec("call nested_fill(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("121"))
+
+	tk.MustGetErrCode("call fill(1)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call fill(1, 2)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call not_exist()", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("create procedure fill() sql security invoker begin end", errno.ErrSpAlreadyExists)
+	tk.MustExec("drop procedure nested_fill")
+	tk.MustGetErrCode("call nested_fill(@res)", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists nested_fill")
+}
+
+func TestProcedureCursorAndHandler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, name varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec(`create procedure concat_names(out res varchar(100)) sql security invoker
+begin
+	declare done int default 0;
+	declare n varchar(10);
+	declare cur cursor for select name from t order by id;
+	declare continue handler for not found set done = 1;
+	set res = '';
+	open cur;
+	read_loop: while true do
+		fetch cur into n;
+		if done then
+			leave read_loop;
+		end if;
+		set res = concat(res, n);
+	end while read_loop;
+	close cur;
+end`)
+	tk.MustExec("call concat_names(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("abc"))
+
+	// The EXIT handler leaves the block which declares it.
+	tk.MustExec(`create procedure insert_dup(out status varchar(20)) sql security invoker
+begin
+	set status = 'ok';
+	begin
+		declare exit handler for 1062 set status = 'duplicate';
+		insert into t values (1, 'x');
+		set status = 'unreachable';
+	end;
+end`)
+	tk.MustExec("call insert_dup(@status)")
+	tk.MustQuery("select @status").Check(testkit.Rows("duplicate"))
+
+	// The errors without handlers are returned to the client.
+	tk.MustExec(`create procedure insert_dup_unhandled() sql security invoker
+begin
+	insert into t values (1, 'x');
+end`)
+	tk.MustGetErrCode("call insert_dup_unhandled()", errno.ErrDupEntry)
+
+	tk.MustExec(`create procedure case_not_found(v int) sql security invoker
+begin
+	case v when 1 then select 1; end case;
+end`)
+	tk.MustGetErrCode("call case_not_found(2)", errno.ErrSpCaseNotFound)
+
+	tk.MustExec(`create procedure close_twice() sql security invoker
+begin
+	declare cur cursor for select id from t;
+	open cur;
+	close cur;
+	close cur;
+end`)
+	tk.MustGetErrCode("call close_twice()", errno.ErrSpCursorNotOpen)
+}
+
+func TestStoredFunction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create function fact(n int) returns bigint deterministic sql security invoker
+begin
+	declare res bigint default 1;
+	repeat
+		set res = res * n;
+		set n = n - 1;
+	until n <= 1 end repeat;
+	return res;
+end`)
+	tk.MustExec(`create function grade(score int) returns varchar(10) sql security invoker
+begin
+	case
+		when score >= 90 then return 'A';
+		when score >= 60 then return 'B';
+		else return 'C';
+	end case;
+end`)
+	tk.MustQuery("select fact(5), test.fact(1), grade(95), grade(70), grade(null)").Check(testkit.Rows("120 1 A B C"))
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (3), (10)")
+	tk.MustQuery("select a, fact(a) from t where fact(a) > 1 order by a").Check(testkit.Rows("3 6", "10 3628800"))
+
+	// Stored functions can call each other and set user variables.
+	tk.MustExec(`create function fact_grade(n int) returns varchar(10) sql security invoker
+begin
+	set @last_fact = fact(n);
+	return grade(@last_fact);
+end`)
+	tk.MustQuery("select fact_grade(4)").Check(testkit.Rows("C"))
+	tk.MustQuery("select @last_fact").Check(testkit.Rows("24"))
+
+	tk.MustExec(`create function recursive_fn(n int) returns int sql security invoker
+begin
+	if n <= 0 then
+		return 0;
+	end if;
+	return recursive_fn(n - 1);
+end`)
+	tk.MustGetErrCode("select recursive_fn(3)", errno.ErrSpNoRecursion)
+
+	tk.MustExec(`create function no_return(n int) returns int sql security invoker
+begin
+	if n > 0 then
+		return n;
+	end if;
+end`)
+	tk.MustQuery("select no_return(1)").Check(testkit.Rows("1"))
+	require.EqualError(t, tk.QueryToErr("select no_return(0)"), "[expression:1321]FUNCTION test.no_return ended without RETURN")
+
+	tk.MustGetErrCode("select fact()", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("select test.not_exist()", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("create function no_return_at_all() returns int sql security invoker begin end", errno.ErrSpNoreturn)
+	tk.MustGetErrCode("create function fn_with_query() returns int sql security invoker begin select 1; return 1; end", errno.ErrNotSupportedYet)
+	tk.MustExec("drop function fact_grade")
+	tk.MustGetErrCode("select fact_grade(1)", errno.ErrSpDoesNotExist)
+}
+
+func TestShowRoutines(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p1(in a int, out b varchar(10)) sql security invoker comment 'proc' begin set b = 'x'; end")
+	tk.MustExec("create function f1(a decimal(10, 2)) returns int deterministic sql security invoker return 1")
+
+	tk.MustQuery("show create procedure p1").Check(testkit.Rows(
+		"p1 ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION " +
+			"CREATE DEFINER=`root`@`%` PROCEDURE `p1`(in a int, out b varchar(10))\n    SQL SECURITY INVOKER\n    COMMENT 'proc'\nBEGIN SET @@SESSION.`b`=_UTF8MB4'x'; END " +
+			"utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("show create function test.f1").Check(testkit.Rows(
+		"f1 ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION " +
+			"CREATE DEFINER=`root`@`%` FUNCTION `f1`(a decimal(10, 2)) RETURNS int(11)\n    DETERMINISTIC\n    SQL SECURITY INVOKER\nRETURN 1 " +
+			"utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	require.EqualError(t, tk.QueryToErr("show create procedure f1"), "[planner:1305]PROCEDURE test.f1 does not exist")
+
+	tk.MustQuery("show procedure status where db = 'test'").CheckAt([]int{0, 1, 2, 3, 6, 7}, testkit.Rows("test p1 PROCEDURE root@% INVOKER proc"))
+	tk.MustQuery("show function status like 'f%'").CheckAt([]int{0, 1, 2}, testkit.Rows("test f1 FUNCTION"))
+	tk.MustQuery("show function status like 'p%'").Check(testkit.Rows())
+
+	tk.MustQuery("select routine_schema, routine_name, routine_type, data_type, numeric_precision, dtd_identifier, is_deterministic, routine_definition " +
+		"from information_schema.routines where routine_schema = 'test' order by routine_name").Check(testkit.Rows(
+		"test f1 FUNCTION int 10 int(11) YES RETURN 1",
+		"test p1 PROCEDURE  <nil> <nil> NO BEGIN SET @@SESSION.`b`=_UTF8MB4'x'; END"))
+	tk.MustQuery("select specific_name, ordinal_position, parameter_mode, parameter_name, data_type, character_maximum_length, numeric_precision, numeric_scale, routine_type " +
+		"from information_schema.parameters where specific_schema = 'test' order by specific_name, ordinal_position").Check(testkit.Rows(
+		"f1 0 <nil> <nil> int <nil> 10 0 FUNCTION",
+		"f1 1 IN a decimal <nil> 10 2 FUNCTION",
+		"p1 1 IN a int <nil> 10 0 PROCEDURE",
+		"p1 2 OUT b varchar 10 <nil> <nil> PROCEDURE"))
+
+	// The definition is hidden from the users who are not the definer.
+	tk.MustExec("create user u1")
+	tk.MustExec("grant select, execute on test.* to u1")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustQuery("select routine_name, routine_definition from information_schema.routines where routine_schema = 'test' order by routine_name").Check(testkit.Rows(
+		"f1 <nil>", "p1 <nil>"))
+	tk1.MustQuery("select test.f1(1)").Check(testkit.Rows("1"))
+	tk1.MustGetErrCode("drop procedure test.p1", errno.ErrProcaccessDenied)
+	tk1.MustGetErrCode("create procedure test.p2() sql security invoker begin end", errno.ErrDBaccessDenied)
+}
+
+func TestRoutinePrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p1() sql security invoker begin end")
+	tk.MustExec("create procedure p2() sql security invoker begin end")
+	tk.MustExec("create function f1() returns int sql security invoker return 1")
+	tk.MustExec("create user u1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("call test.p1()", errno.ErrProcaccessDenied)
+	tk1.MustGetErrCode("select test.f1()", errno.ErrProcaccessDenied)
+
+	tk.MustExec("grant execute on procedure test.p1 to u1")
+	tk.MustExec("grant execute, alter routine on function test.f1 to u1 with grant option")
+	tk.MustQuery("select db, routine_name, routine_type, proc_priv from mysql.procs_priv where user = 'u1' order by routine_name").Check(testkit.Rows(
+		"test f1 FUNCTION Execute,Alter Routine,Grant", "test p1 PROCEDURE Execute"))
+	tk.MustQuery("show grants for u1").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u1'@'%'",
+		"GRANT ALL PRIVILEGES ON FUNCTION `test`.`f1` TO 'u1'@'%' WITH GRANT OPTION",
+		"GRANT EXECUTE ON PROCEDURE `test`.`p1` TO 'u1'@'%'"))
+	tk1.MustExec("call test.p1()")
+	tk1.MustGetErrCode("call test.p2()", errno.ErrProcaccessDenied)
+	tk1.MustQuery("select test.f1()").Check(testkit.Rows("1"))
+
+	tk.MustGetErrCode("grant select on procedure test.p1 to u1", errno.ErrIllegalGrantForTable)
+	tk.MustGetErrCode("grant execute on procedure test.not_exist to u1", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("grant execute on function test.p1 to u1", errno.ErrSpDoesNotExist)
+
+	tk.MustExec("revoke execute on procedure test.p1 from u1")
+	tk1.MustGetErrCode("call test.p1()", errno.ErrProcaccessDenied)
+	tk.MustExec("revoke all on function test.f1 from u1")
+	tk.MustQuery("select count(*) from mysql.procs_priv where user = 'u1'").Check(testkit.Rows("0"))
+	tk.MustGetErrMsg("revoke execute on procedure test.p1 from u1", "There is no such grant defined for user 'u1' on host '%' on routine test.p1")
+
+	tk.MustExec("grant execute on procedure test.p1 to u1")
+	tk.MustExec("rename user u1 to u2")
+	tk.MustQuery("select user from mysql.procs_priv").Check(testkit.Rows("u2"))
+	tk.MustExec("drop user u2")
+	tk.MustQuery("select count(*) from mysql.procs_priv").Check(testkit.Rows("0"))
+}
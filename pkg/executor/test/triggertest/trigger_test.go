@@ -0,0 +1,264 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, c int as (a + b))")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(64))")
+	tk.MustExec("create view v as select * from t")
+	tk.MustExec("create temporary table tmp (a int)")
+
+	tk.MustExec("create trigger trg1 before insert on t for each row set new.b = new.b * 2")
+	tk.MustExec("create trigger trg2 after insert on t for each row insert into log(msg) values (concat('ins ', new.a))")
+	tk.MustExec("create trigger trg3 before insert on t for each row precedes trg1 set new.b = new.b + 1")
+	tk.MustExec("create trigger test.trg4 before insert on t for each row follows trg1 set new.b = new.b + 100")
+	tk.MustGetErrCode("create trigger trg1 after delete on t for each row delete from log", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists trg1 after delete on t for each row delete from log")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger trg5 before insert on t for each row follows not_exist set new.b = 1", errno.ErrTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger trg5 before insert on not_exist for each row set new.b = 1", errno.ErrNoSuchTable)
+	tk.MustGetErrCode("create trigger trg5 before insert on v for each row set new.b = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger trg5 before insert on tmp for each row set @a = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger mysql.trg5 before insert on test.t for each row set @a = 1", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger trg5 before insert on mysql.user for each row set @a = 1", errno.ErrNoTriggersOnSystemSchema)
+	tk.MustGetErrCode("create trigger trg5 before insert on t for each row set @a = old.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger trg5 before delete on t for each row set @a = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger trg5 before update on t for each row set old.b = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger trg5 after update on t for each row set new.b = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger trg5 before update on t for each row set new.c = 1", errno.ErrBadGeneratedColumn)
+	tk.MustGetErrCode("create trigger trg5 before update on t for each row set @a = new.x", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger trg5 after update on t for each row select 1", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger trg5 after update on t for each row begin commit; end", errno.ErrCommitNotAllowedInSfOrTrg)
+
+	tk.MustQuery("select trigger_name, action_order, event_manipulation, action_timing, event_object_table from information_schema.triggers where trigger_schema = 'test' order by action_timing, action_order").Check(testkit.Rows(
+		"trg2 1 INSERT AFTER t",
+		"trg3 1 INSERT BEFORE t",
+		"trg1 2 INSERT BEFORE t",
+		"trg4 3 INSERT BEFORE t",
+	))
+	tk.MustQuery("show triggers like 't'").Sort().CheckAt([]int{0, 1, 2, 3, 4}, testkit.RowsWithSep("|",
+		"trg1|INSERT|t|SET @@SESSION.`new.b`=`new`.`b`*2|BEFORE",
+		"trg2|INSERT|t|INSERT INTO `log` (`msg`) VALUES (CONCAT(_UTF8MB4'ins ', `new`.`a`))|AFTER",
+		"trg3|INSERT|t|SET @@SESSION.`new.b`=`new`.`b`+1|BEFORE",
+		"trg4|INSERT|t|SET @@SESSION.`new.b`=`new`.`b`+100|BEFORE",
+	))
+	tk.MustQuery("show create trigger trg1").CheckAt([]int{0, 2}, testkit.RowsWithSep("|",
+		"trg1|CREATE DEFINER=`root`@`%` TRIGGER `trg1` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.b`=`new`.`b`*2",
+	))
+	require.EqualError(t, tk.QueryToErr("show create trigger not_exist"), "[ddl:1360]Trigger does not exist")
+
+	// Triggers are activated in the order of FOLLOWS and PRECEDES.
+	tk.MustExec("insert into t(a, b) values (1, 1)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 104 105"))
+	tk.MustQuery("select msg from log").Check(testkit.Rows("ins 1"))
+
+	tk.MustExec("drop trigger trg3")
+	tk.MustExec("drop trigger test.trg4")
+	tk.MustGetErrCode("drop trigger trg3", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists trg3")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustGetErrCode("rename table t to mysql.t", errno.ErrTrgInWrongSchema)
+
+	// The triggers are dropped with the table.
+	tk.MustExec("drop table t")
+	tk.MustQuery("select count(*) from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("0"))
+}
+
+func TestInsertTriggers(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int auto_increment primary key, a int not null, b varchar(20), c int as (a * 10))")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(64))")
+	tk.MustExec(`create trigger bi before insert on t for each row
+begin
+	if new.a < 0 then
+		set new.a = 0;
+	end if;
+	set new.b = upper(new.b);
+end`)
+	tk.MustExec("create trigger ai after insert on t for each row insert into log(msg) values (concat('ins ', new.id, ' ', new.a, ' ', new.c))")
+	tk.MustExec("create trigger bu before update on t for each row set new.b = concat(old.b, '>', new.b)")
+	tk.MustExec("create trigger au after update on t for each row insert into log(msg) values (concat('upd ', old.a, '->', new.a))")
+	tk.MustExec("create trigger ad after delete on t for each row insert into log(msg) values (concat('del ', old.id))")
+
+	// The generated columns are evaluated with the values set by the BEFORE triggers.
+	tk.MustExec("insert into t(a, b) values (1, 'x'), (-5, 'y')")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 X 10", "2 0 Y 0"))
+	tk.MustQuery("select last_insert_id()").Check(testkit.Rows("1"))
+	tk.MustExec("insert into t(a, b) select a + 1, 'z' from t where id = 1")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("ins 1 1 10", "ins 2 0 0", "ins 3 2 20"))
+
+	// INSERT ... ON DUPLICATE KEY UPDATE fires the BEFORE INSERT triggers and the UPDATE triggers.
+	tk.MustExec("delete from log")
+	tk.MustExec("insert into t(id, a, b) values (1, 7, 'w') on duplicate key update a = values(a), b = 'u'")
+	tk.MustQuery("select * from t where id = 1").Check(testkit.Rows("1 7 X>u 70"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("upd 1->7"))
+
+	// REPLACE fires the DELETE triggers for the replaced rows.
+	tk.MustExec("delete from log")
+	tk.MustExec("replace into t(id, a, b) values (2, 3, 'r')")
+	tk.MustQuery("select * from t where id = 2").Check(testkit.Rows("2 3 R 30"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("del 2", "ins 2 3 30"))
+
+	// The NOT NULL constraint is checked after the BEFORE triggers.
+	tk.MustExec("create trigger bi2 before insert on t for each row follows bi set new.a = if(new.b = 'NULL', null, new.a)")
+	tk.MustGetErrCode("insert into t(a, b) values (1, 'null')", errno.ErrBadNull)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("3"))
+}
+
+func TestUpdateDeleteTriggers(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, k int, key(k))")
+	tk.MustExec("create table t2 (id int primary key, v int)")
+	tk.MustExec("create table audit (tbl varchar(10), id int, old_v int, new_v int)")
+	tk.MustExec("insert into t values (1, 10, 1), (2, 20, 2), (3, 30, 3)")
+	tk.MustExec("insert into t2 values (1, 100), (2, 200)")
+	tk.MustExec(`create trigger bu before update on t for each row
+begin
+	declare diff int default new.v - old.v;
+	if diff > 100 then
+		set new.v = old.v + 100;
+	end if;
+end`)
+	tk.MustExec("create trigger au after update on t for each row insert into audit values ('t', old.id, old.v, new.v)")
+	tk.MustExec("create trigger bd before delete on t for each row insert into audit values ('t', old.id, old.v, null)")
+	tk.MustExec("create trigger ad after delete on t2 for each row insert into audit values ('t2', old.id, old.v, null)")
+
+	tk.MustExec("update t set v = v + 500 where id = 1")
+	tk.MustExec("update t set v = v + 1 where id >= 2")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 110 1", "2 21 2", "3 31 3"))
+	tk.MustQuery("select * from audit order by id").Check(testkit.Rows("t 1 10 110", "t 2 20 21", "t 3 30 31"))
+
+	// An AFTER UPDATE trigger is fired for every matched row even if it isn't changed.
+	tk.MustExec("truncate table audit")
+	tk.MustExec("update t set v = v where id = 3")
+	tk.MustQuery("select * from audit").Check(testkit.Rows("t 3 31 31"))
+
+	// The whole row is visible to the DELETE triggers.
+	tk.MustExec("truncate table audit")
+	tk.MustExec("delete from t where k = 2")
+	tk.MustExec("delete t, t2 from t join t2 on t.id = t2.id")
+	tk.MustQuery("select * from audit order by tbl, id").Check(testkit.Rows("t 1 110 <nil>", "t 2 21 <nil>", "t2 1 100 <nil>"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("3 31 3"))
+	tk.MustQuery("select * from t2").Check(testkit.Rows("2 200"))
+}
+
+func TestTriggerStatementSemantics(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("create table cnt (n int)")
+	tk.MustExec("insert into cnt values (0)")
+	tk.MustExec("create trigger ai after insert on t for each row update cnt set n = n + 1")
+
+	// The AFTER triggers see all the changes of the statement.
+	tk.MustExec("create table seen (id int, total int)")
+	tk.MustExec("create trigger ai2 after insert on t for each row insert into seen select new.id, count(*) from t")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustQuery("select * from seen order by id").Check(testkit.Rows("1 2", "2 2"))
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("2"))
+
+	// A failed trigger rolls back the whole statement.
+	tk.MustExec("create table guard (id int)")
+	tk.MustExec("create trigger bi before insert on t for each row begin if new.v < 0 then insert into guard values ('x'); end if; end")
+	for _, mode := range []string{"optimistic", "pessimistic"} {
+		tk.MustExec("set @@tidb_txn_mode = '" + mode + "'")
+		tk.MustGetErrCode("insert into t values (3, 3), (4, -1)", errno.ErrTruncatedWrongValueForField)
+		tk.MustExec("begin")
+		tk.MustExec("insert into t values (5, 5)")
+		tk.MustGetErrCode("insert into t values (6, 6), (7, -1)", errno.ErrTruncatedWrongValueForField)
+		tk.MustExec("commit")
+		tk.MustQuery("select id from t order by id").Check(testkit.Rows("1", "2", "5"))
+		tk.MustQuery("select n from cnt").Check(testkit.Rows("3"))
+		tk.MustExec("delete from t where id = 5")
+		tk.MustExec("update cnt set n = 2")
+	}
+
+	// A trigger can't modify the table used by the statement which invokes it.
+	tk.MustExec("create trigger bu before update on t for each row delete from t where id = old.id + 1")
+	tk.MustGetErrCode("update t set v = 10", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustExec("drop trigger bu")
+	tk.MustExec("create trigger cu after update on cnt for each row insert into t values (100, 100)")
+	tk.MustGetErrCode("insert into t values (9, 9)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustExec("drop trigger cu")
+	tk.MustQuery("select id, v from t order by id").Check(testkit.Rows("1 1", "2 2"))
+
+	// The trigger body runs with the sql mode it was created with, in the schema of the trigger.
+	tk.MustExec("create database db2")
+	tk.MustExec("create table db2.t (a int)")
+	tk.MustExec("create table db2.cnt (n int)")
+	tk.MustExec("insert into db2.cnt values (0)")
+	tk.MustExec("set sql_mode = ''")
+	tk.MustExec("create trigger db2.ai after insert on db2.t for each row update cnt set n = n + 1")
+	tk.MustExec("set sql_mode = default")
+	tk.MustExec("insert into db2.t values (1)")
+	tk.MustQuery("select n from db2.cnt").Check(testkit.Rows("1"))
+	tk.MustQuery("select n from test.cnt").Check(testkit.Rows("2"))
+	tk.MustQuery("select sql_mode from information_schema.triggers where trigger_schema = 'db2'").Check(testkit.Rows(""))
+}
+
+func TestTriggerForeignKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table parent (id int primary key)")
+	tk.MustExec("create table child (id int primary key, pid int, foreign key (pid) references parent(id) on delete cascade)")
+	tk.MustExec("create table log (msg varchar(64))")
+	tk.MustExec("create trigger ad after delete on child for each row insert into log values (concat('child ', old.id))")
+	tk.MustExec("create trigger ai after insert on parent for each row insert into child values (new.id * 10, new.id)")
+
+	// The foreign key checks of the statements in trigger bodies are done.
+	tk.MustExec("insert into parent values (1), (2)")
+	tk.MustQuery("select * from child order by id").Check(testkit.Rows("10 1", "20 2"))
+	tk.MustExec("create trigger bi before insert on parent for each row insert into child values (new.id * 100, new.id + 1000)")
+	tk.MustGetErrCode("insert into parent values (3)", errno.ErrNoReferencedRow2)
+	tk.MustExec("drop trigger bi")
+	tk.MustQuery("select * from parent order by id").Check(testkit.Rows("1", "2"))
+
+	// The rows deleted by foreign key cascades don't activate the triggers.
+	tk.MustExec("delete from parent where id = 1")
+	tk.MustQuery("select * from child").Check(testkit.Rows("20 2"))
+	tk.MustQuery("select * from log").Check(testkit.Rows())
+	tk.MustExec("delete from child")
+	tk.MustQuery("select * from log").Check(testkit.Rows("child 20"))
+
+	// The foreign key cascades of the statements in trigger bodies are handled.
+	tk.MustExec("insert into child values (21, 2)")
+	tk.MustExec("create table cleanup (id int)")
+	tk.MustExec("create trigger ai2 after insert on cleanup for each row delete from parent where id = new.id")
+	tk.MustExec("insert into cleanup values (2)")
+	tk.MustQuery("select count(*) from parent").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from child").Check(testkit.Rows("0"))
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// WithTrigger indicates the executor fires triggers.
+type WithTrigger interface {
+	GetTriggers() []*TriggerExec
+}
+
+// TriggerExec fires the triggers of a table for the rows changed by a DML
+// statement.
+//
+// The BEFORE triggers are fired when the row is about to be written, and the
+// values assigned to NEW are written back to the row. The AFTER triggers are
+// queued and fired after the foreign key checks and cascades of the statement
+// are done, so that they can see all the changes of the statement.
+//
+// Like the foreign key cascades, the statements in a trigger body are executed
+// as a part of the triggering statement. The foreign key checks, cascades and
+// triggers caused by them are handled after the AFTER triggers are fired. Rows
+// changed by foreign key cascades don't activate triggers, which is the same as
+// MySQL.
+//
+// The changes of the statements in the AFTER trigger bodies are flushed into
+// the txn mem-buffer one by one, so every statement sees the changes made
+// before it. The BEFORE triggers are fired in the middle of the triggering
+// statement, so the statements in them only see the data as it was before the
+// triggering statement started.
+type TriggerExec struct {
+	ctx    sessionctx.Context
+	is     infoschema.InfoSchema
+	tbl    table.Table
+	dbName string
+	before map[ast.TriggerEvent][]*model.TriggerInfo
+	after  map[ast.TriggerEvent][]*model.TriggerInfo
+	// used records the tables modified by the statements which invoke the
+	// trigger, the statements in the trigger body can't modify them.
+	used map[int64]string
+
+	bodies   map[string]*triggerBody
+	restored map[ast.Node]string
+	// pending keeps the rows to fire the AFTER triggers.
+	pending []triggerRow
+	// nested keeps the executors of the statements in the trigger bodies.
+	nested []exec.Executor
+	// inAfter indicates the AFTER triggers are being fired.
+	inAfter bool
+}
+
+type triggerBody struct {
+	stmt   ast.StmtNode
+	parser *parser.Parser
+}
+
+type triggerRow struct {
+	event  ast.TriggerEvent
+	oldRow []types.Datum
+	newRow []types.Datum
+}
+
+// buildTriggerExecs builds the TriggerExec of the tables modified by the
+// statement, the result map is tableID -> *TriggerExec.
+func (b *executorBuilder) buildTriggerExecs(tblID2Table map[int64]table.Table) (map[int64]*TriggerExec, error) {
+	used := maps.Clone(b.usedTables)
+	for id, tbl := range tblID2Table {
+		if _, ok := b.usedTables[id]; ok {
+			return nil, exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tbl.Meta().Name.O)
+		}
+		if used == nil {
+			used = make(map[int64]string, len(tblID2Table))
+		}
+		used[id] = tbl.Meta().Name.O
+	}
+	if b.ctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger {
+		return nil, nil
+	}
+	var triggers map[int64]*TriggerExec
+	for id, tbl := range tblID2Table {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		db, ok := infoschema.SchemaByTable(b.is, tblInfo)
+		if !ok {
+			return nil, errors.Errorf("can not find the schema of table %s", tblInfo.Name.O)
+		}
+		t := &TriggerExec{
+			ctx:      b.ctx,
+			is:       b.is,
+			tbl:      tbl,
+			dbName:   db.Name.O,
+			before:   make(map[ast.TriggerEvent][]*model.TriggerInfo),
+			after:    make(map[ast.TriggerEvent][]*model.TriggerInfo),
+			used:     used,
+			bodies:   make(map[string]*triggerBody),
+			restored: make(map[ast.Node]string),
+		}
+		for _, trigger := range tblInfo.Triggers {
+			if trigger.Timing == ast.TriggerBefore {
+				t.before[trigger.Event] = append(t.before[trigger.Event], trigger)
+			} else {
+				t.after[trigger.Event] = append(t.after[trigger.Event], trigger)
+			}
+		}
+		if triggers == nil {
+			triggers = make(map[int64]*TriggerExec)
+		}
+		triggers[id] = t
+	}
+	return triggers, nil
+}
+
+func (t *TriggerExec) hasBefore(event ast.TriggerEvent) bool {
+	return t != nil && len(t.before[event]) > 0
+}
+
+// fireBefore fires the BEFORE triggers of the event, the values assigned to
+// NEW are written back to newRow.
+func (t *TriggerExec) fireBefore(ctx context.Context, event ast.TriggerEvent, oldRow, newRow []types.Datum) error {
+	if t == nil {
+		return nil
+	}
+	for _, trigger := range t.before[event] {
+		if err := t.fire(ctx, trigger, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueAfter queues the row to fire the AFTER triggers of the event later.
+func (t *TriggerExec) queueAfter(event ast.TriggerEvent, oldRow, newRow []types.Datum) {
+	if t == nil || len(t.after[event]) == 0 {
+		return
+	}
+	r := triggerRow{event: event}
+	n := len(t.tbl.Cols())
+	if oldRow != nil {
+		r.oldRow = types.CloneRow(oldRow[:n])
+	}
+	if newRow != nil {
+		r.newRow = types.CloneRow(newRow[:n])
+	}
+	t.pending = append(t.pending, r)
+}
+
+// fireAfter fires the AFTER triggers of the queued rows.
+func (t *TriggerExec) fireAfter(ctx context.Context) error {
+	pending := t.pending
+	t.pending = nil
+	t.inAfter = true
+	defer func() {
+		t.inAfter = false
+	}()
+	for _, r := range pending {
+		for _, trigger := range t.after[r.event] {
+			if err := t.fire(ctx, trigger, r.oldRow, r.newRow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TriggerExec) fire(ctx context.Context, trigger *model.TriggerInfo, oldRow, newRow []types.Datum) error {
+	body, err := t.loadBody(trigger)
+	if err != nil {
+		return err
+	}
+	// NEW.col and OLD.col are bound as variables of the outermost scope.
+	scope := newProcedureScope(nil)
+	cols := t.tbl.Cols()
+	for i, col := range cols {
+		if oldRow != nil {
+			scope.vars["old."+col.Name.L] = &procedureVar{tp: &col.FieldType, value: oldRow[i]}
+		}
+		if newRow != nil {
+			scope.vars["new."+col.Name.L] = &procedureVar{tp: &col.FieldType, value: newRow[i]}
+		}
+	}
+	e := &procedureExec{
+		sctx:     t.ctx,
+		exec:     t,
+		active:   make(map[string]int),
+		restored: t.restored,
+		parser:   body.parser,
+		charset:  trigger.Charset,
+		collate:  trigger.Collate,
+	}
+
+	// The changes made by the trigger body are not counted in the result of
+	// the triggering statement.
+	vars := t.ctx.GetSessionVars()
+	sc := vars.StmtCtx
+	affectedRows, lastInsertID, lastInsertIDSet := sc.AffectedRows(), sc.LastInsertID, sc.LastInsertIDSet
+	origDB, origSQLMode := vars.CurrentDB, vars.SQLMode
+	vars.CurrentDB, vars.SQLMode = t.dbName, trigger.SQLMode
+	err = e.execStmt(ctx, scope, body.stmt)
+	vars.CurrentDB, vars.SQLMode = origDB, origSQLMode
+	sc.SetAffectedRows(affectedRows)
+	sc.LastInsertID, sc.LastInsertIDSet = lastInsertID, lastInsertIDSet
+	if err != nil {
+		return err
+	}
+
+	if trigger.Timing != ast.TriggerBefore || newRow == nil {
+		return nil
+	}
+	for i, col := range cols {
+		newRow[i] = scope.vars["new."+col.Name.L].value
+		if err := col.HandleBadNull(sc.ErrCtx(), &newRow[i], 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBody parses the body of the trigger with the sql mode it was created
+// with, the parsed body is reused for all the rows.
+func (t *TriggerExec) loadBody(trigger *model.TriggerInfo) (*triggerBody, error) {
+	if body, ok := t.bodies[trigger.Name.L]; ok {
+		return body, nil
+	}
+	p := parser.New()
+	p.SetSQLMode(trigger.SQLMode)
+	p.SetParserConfig(t.ctx.GetSessionVars().BuildParserConfig())
+	sql := fmt.Sprintf("CREATE TRIGGER `%s` %s %s ON `%s` FOR EACH ROW %s",
+		strings.ReplaceAll(trigger.Name.O, "`", "``"), trigger.Timing, trigger.Event,
+		strings.ReplaceAll(t.tbl.Meta().Name.O, "`", "``"), trigger.Body)
+	stmt, err := p.ParseOneStmt(sql, trigger.Charset, trigger.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	body := &triggerBody{stmt: stmt.(*ast.CreateTriggerStmt).Body, parser: p}
+	t.bodies[trigger.Name.L] = body
+	return body, nil
+}
+
+// ExecuteStmt executes a statement of the trigger body, it implements the
+// stmtExecutor interface.
+func (t *TriggerExec) ExecuteStmt(ctx context.Context, node ast.StmtNode) (sqlexec.RecordSet, error) {
+	switch node.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt, *ast.SetStmt, *ast.DoStmt:
+	default:
+		return nil, exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	sctx := t.ctx
+	nodeW := resolve.NewNodeW(node)
+	if err := plannercore.Preprocess(ctx, sctx, nodeW); err != nil {
+		return nil, err
+	}
+	p, names, err := planner.OptimizeForTrigger(ctx, sctx.GetPlanCtx(), nodeW, t.is)
+	if err != nil {
+		return nil, err
+	}
+	b := newExecutorBuilder(sctx, t.is)
+	b.usedTables = t.used
+	e := b.build(p)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := exec.Open(ctx, e); err != nil {
+		terror.Log(exec.Close(e))
+		return nil, err
+	}
+	var rows []chunk.Row
+	for {
+		chk := exec.NewFirstChunk(e)
+		if err = exec.Next(ctx, e, chk); err != nil || chk.NumRows() == 0 {
+			break
+		}
+		for i := range chk.NumRows() {
+			rows = append(rows, chk.GetRow(i))
+		}
+	}
+	if closeErr := exec.Close(e); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := e.(WithForeignKeyTrigger); ok {
+		t.nested = append(t.nested, e)
+		if t.inAfter {
+			// Flush the changes so that the later statements can see them.
+			sctx.StmtCommit(ctx)
+		}
+	}
+	if e.Schema().Len() == 0 {
+		return nil, nil
+	}
+	return &procedureRecordSet{
+		fields:       colNames2ResultFields(e.Schema(), names, sctx.GetSessionVars().CurrentDB),
+		rows:         rows,
+		maxChunkSize: sctx.GetSessionVars().MaxChunkSize,
+	}, nil
+}
+
+func triggerExecsOf(tblID2Triggers map[int64]*TriggerExec) []*TriggerExec {
+	triggers := make([]*TriggerExec, 0, len(tblID2Triggers))
+	for _, t := range tblID2Triggers {
+		triggers = append(triggers, t)
+	}
+	return triggers
+}
@@ -75,6 +75,10 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the triggers to fire. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
+	// mviewLogs contains the change logs of the materialized views. the map is tableID -> *mviewLogWriter
+	mviewLogs map[int64]*mviewLogWriter
 
 	IgnoreError bool
 }
@@ -299,6 +303,8 @@ func (e *UpdateExec) exec(
 			flags, tbl, false, e.memTracker,
 			e.fkChecks[content.TblID],
 			e.fkCascades[content.TblID],
+			e.triggers[content.TblID],
+			e.mviewLogs[content.TblID],
 			dupKeyCheck, e.IgnoreError)
 
 		// Copy data from new row to merge row
@@ -674,6 +680,11 @@ func (e *UpdateExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
 
+// GetTriggers implements WithTrigger interface.
+func (e *UpdateExec) GetTriggers() []*TriggerExec {
+	return triggerExecsOf(e.triggers)
+}
+
 // optimizeDupKeyCheckForUpdate trys to optimize the DupKeyCheckMode for an update statement.
 // If the DupKeyCheckMode of the current statement can be optimized, it will return `DupKeyCheckLazy` to avoid the
 // redundant requests to TiKV, otherwise, `DupKeyCheckInPlace` will be returned.
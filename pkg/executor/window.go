@@ -532,3 +532,216 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
 }
+
+// peerGroups records the peer groups of a partition for the GROUPS frame, the rows
+// in a peer group have the same values on the order by columns. All the rows of the
+// partition are in one peer group if there is no order by column.
+type peerGroups struct {
+	cmpFuncs []chunk.CompareFunc
+	colIdx   []int
+	// starts stores the offset of the first row of each peer group.
+	starts []uint64
+	// scanned is the number of rows that have been put into the peer groups.
+	scanned uint64
+	// cur is the peer group of the current row.
+	cur int
+}
+
+func newPeerGroups(orderByCols []*expression.Column) *peerGroups {
+	g := &peerGroups{
+		cmpFuncs: make([]chunk.CompareFunc, 0, len(orderByCols)),
+		colIdx:   make([]int, 0, len(orderByCols)),
+	}
+	for _, col := range orderByCols {
+		cmpFunc := chunk.GetCompareFunc(col.RetType)
+		if cmpFunc == nil {
+			continue
+		}
+		g.cmpFuncs = append(g.cmpFuncs, cmpFunc)
+		g.colIdx = append(g.colIdx, col.Index)
+	}
+	return g
+}
+
+// scan puts the rows before numRows into the peer groups.
+func (g *peerGroups) scan(getRow func(uint64) chunk.Row, numRows uint64) {
+	for ; g.scanned < numRows; g.scanned++ {
+		if g.scanned == 0 || !g.isPeer(getRow(g.scanned-1), getRow(g.scanned)) {
+			g.starts = append(g.starts, g.scanned)
+		}
+	}
+}
+
+func (g *peerGroups) isPeer(prev, cur chunk.Row) bool {
+	for i, idx := range g.colIdx {
+		if g.cmpFuncs[i](prev, idx, cur, idx) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// locate moves the current peer group to the one containing the row at rowIdx,
+// the rows are located in order.
+func (g *peerGroups) locate(rowIdx uint64) {
+	for g.cur+1 < len(g.starts) && g.starts[g.cur+1] <= rowIdx {
+		g.cur++
+	}
+}
+
+// getStart returns the offset of the first row in the frame, numRows is returned if the
+// start peer group hasn't been scanned.
+func (g *peerGroups) getStart(bound *logicalop.FrameBound, numRows uint64) uint64 {
+	if bound.UnBounded {
+		return 0
+	}
+	cur := uint64(g.cur)
+	switch bound.Type {
+	case ast.Preceding:
+		if cur < bound.Num {
+			return 0
+		}
+		return g.starts[cur-bound.Num]
+	case ast.Following:
+		if bound.Num >= uint64(len(g.starts))-cur {
+			return numRows
+		}
+		return g.starts[cur+bound.Num]
+	default: // ast.CurrentRow
+		return g.starts[cur]
+	}
+}
+
+// getEnd returns the offset after the last row in the frame, numRows is returned if the
+// end peer group may still have more rows.
+func (g *peerGroups) getEnd(bound *logicalop.FrameBound, numRows uint64) uint64 {
+	if bound.UnBounded {
+		return numRows
+	}
+	cur := uint64(g.cur)
+	switch bound.Type {
+	case ast.Preceding:
+		if cur < bound.Num {
+			return 0
+		}
+		if cur-bound.Num+1 >= uint64(len(g.starts)) {
+			return numRows
+		}
+		return g.starts[cur-bound.Num+1]
+	case ast.Following:
+		if bound.Num >= uint64(len(g.starts))-cur-1 {
+			return numRows
+		}
+		return g.starts[cur+bound.Num+1]
+	default: // ast.CurrentRow
+		if cur+1 >= uint64(len(g.starts)) {
+			return numRows
+		}
+		return g.starts[cur+1]
+	}
+}
+
+func (g *peerGroups) reset() {
+	g.starts = g.starts[:0]
+	g.scanned = 0
+	g.cur = 0
+}
+
+type groupsFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *logicalop.FrameBound
+	end            *logicalop.FrameBound
+	curRowIdx      uint64
+	groups         *peerGroups
+}
+
+func (*groupsFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
+	p.groups.scan(func(u uint64) chunk.Row {
+		return rows[u]
+	}, numRows)
+	var (
+		err                      error
+		initializedSlidingWindow bool
+		start                    uint64
+		end                      uint64
+		lastStart                uint64
+		lastEnd                  uint64
+		shiftStart               uint64
+		shiftEnd                 uint64
+	)
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
+	for i, windowFunc := range p.windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	for ; remained > 0; lastStart, lastEnd = start, end {
+		p.groups.locate(p.curRowIdx)
+		start = p.groups.getStart(p.start, numRows)
+		end = p.groups.getEnd(p.end, numRows)
+		p.curRowIdx++
+		remained--
+		shiftStart = start - lastStart
+		shiftEnd = end - lastEnd
+		if start >= end {
+			for i, windowFunc := range p.windowFuncs {
+				slidingWindowAggFunc := slidingWindowAggFuncs[i]
+				if slidingWindowAggFunc != nil && initializedSlidingWindow {
+					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
+						return rows[u]
+					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		for i, windowFunc := range p.windowFuncs {
+			slidingWindowAggFunc := slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
+					return rows[u]
+				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+			} else {
+				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				}
+				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
+			if err != nil {
+				return nil, err
+			}
+			if slidingWindowAggFunc == nil {
+				windowFunc.ResetPartialResult(p.partialResults[i])
+			}
+		}
+		if !initializedSlidingWindow {
+			initializedSlidingWindow = true
+		}
+	}
+	for i, windowFunc := range p.windowFuncs {
+		windowFunc.ResetPartialResult(p.partialResults[i])
+	}
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.groups.reset()
+}
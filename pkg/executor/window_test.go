@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
 )
@@ -479,3 +480,94 @@ func TestVarSampAsAWindowFunction(t *testing.T) {
 	tk.MustExec("select var_samp(c1) from t1")
 	tk.MustExec("select c1, var_samp(c1) over (partition by c1) from t1")
 }
+
+func TestWindowFunctionsNullTreatment(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, p int, v int)")
+	tk.MustExec("insert into t values (1, 1, null), (2, 1, 10), (3, 1, null), (4, 1, 30), (5, 1, null), (6, 2, 5), (7, 2, 6), (8, 2, null)")
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %s", pipelined))
+		for _, chunkSize := range []int{32, 1} {
+			tk.Session().GetSessionVars().MaxChunkSize = chunkSize
+			tk.MustQuery("select id, first_value(v) ignore nulls over w, last_value(v) ignore nulls over w from t window w as (partition by p order by id) order by id").
+				Check(testkit.Rows("1 <nil> <nil>", "2 10 10", "3 10 10", "4 10 30", "5 10 30", "6 5 5", "7 5 6", "8 5 6"))
+			tk.MustQuery("select id, last_value(v) ignore nulls over (partition by p order by id rows between current row and 1 following) from t order by id").
+				Check(testkit.Rows("1 10", "2 10", "3 30", "4 30", "5 <nil>", "6 6", "7 6", "8 <nil>"))
+			tk.MustQuery("select id, nth_value(v, 2) ignore nulls over w, nth_value(v, 1) from last over w, nth_value(v, 1) from last ignore nulls over w " +
+				"from t window w as (partition by p order by id rows between unbounded preceding and unbounded following) order by id").
+				Check(testkit.Rows("1 30 <nil> 30", "2 30 <nil> 30", "3 30 <nil> 30", "4 30 <nil> 30", "5 30 <nil> 30", "6 6 <nil> 6", "7 6 <nil> 6", "8 6 <nil> 6"))
+			tk.MustQuery("select id, nth_value(v, 2) from last ignore nulls over (partition by p order by id) from t order by id").
+				Check(testkit.Rows("1 <nil>", "2 <nil>", "3 <nil>", "4 10", "5 10", "6 <nil>", "7 5", "8 5"))
+			tk.MustQuery("select id, lead(v) ignore nulls over w, lead(v, 2) ignore nulls over w, lag(v, 1, -1) ignore nulls over w from t window w as (partition by p order by id) order by id").
+				Check(testkit.Rows("1 10 30 -1", "2 30 <nil> -1", "3 30 <nil> 10", "4 <nil> <nil> 10", "5 <nil> <nil> 30", "6 6 <nil> -1", "7 <nil> <nil> 5", "8 <nil> <nil> 6"))
+			tk.MustQuery("select id, lead(v, 0) ignore nulls over (order by id) from t where p = 1 order by id").
+				Check(testkit.Rows("1 <nil>", "2 10", "3 <nil>", "4 30", "5 <nil>"))
+		}
+	}
+	tk.MustQuery("explain format = 'brief' select first_value(v) ignore nulls over (order by id), nth_value(v, 2) from last over (order by id) from t").
+		MultiCheckContain([]string{"first_value(test.t.v) ignore nulls", "nth_value(test.t.v, 2) from last"})
+}
+
+func TestWindowFunctionsGroupsFrame(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (o int, v int)")
+	tk.MustExec("insert into t values (3, 8), (1, 1), (5, 32), (1, 2), (2, 4), (3, 16)")
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %s", pipelined))
+		for _, chunkSize := range []int{32, 1} {
+			tk.Session().GetSessionVars().MaxChunkSize = chunkSize
+			tk.MustQuery("select o, v, sum(v) over (order by o groups between 1 preceding and 1 following) from t order by o, v").
+				Check(testkit.Rows("1 1 7", "1 2 7", "2 4 31", "3 8 60", "3 16 60", "5 32 56"))
+			tk.MustQuery("select o, v, sum(v) over (order by o groups current row) from t order by o, v").
+				Check(testkit.Rows("1 1 3", "1 2 3", "2 4 4", "3 8 24", "3 16 24", "5 32 32"))
+			tk.MustQuery("select o, v, count(v) over (order by o groups between 2 preceding and 1 preceding) from t order by o, v").
+				Check(testkit.Rows("1 1 0", "1 2 0", "2 4 2", "3 8 3", "3 16 3", "5 32 3"))
+			tk.MustQuery("select o, v, sum(v) over (order by o groups between 1 following and unbounded following) from t order by o, v").
+				Check(testkit.Rows("1 1 60", "1 2 60", "2 4 56", "3 8 32", "3 16 32", "5 32 <nil>"))
+			tk.MustQuery("select o, v, sum(v) over (order by o desc groups between 1 preceding and current row) from t order by o, v").
+				Check(testkit.Rows("1 1 7", "1 2 7", "2 4 28", "3 8 56", "3 16 56", "5 32 32"))
+			tk.MustQuery("select o, v, max(v) over (order by o groups between 1 preceding and 1 following) from t order by o, v").
+				Check(testkit.Rows("1 1 4", "1 2 4", "2 4 16", "3 8 32", "3 16 32", "5 32 32"))
+			tk.MustQuery("select o, v, sum(v) over (groups between current row and unbounded following) from t order by o, v").
+				Check(testkit.Rows("1 1 63", "1 2 63", "2 4 63", "3 8 63", "3 16 63", "5 32 63"))
+			tk.MustQuery("select o, v, sum(v) over (partition by o > 2 order by o groups 1 preceding) from t order by o, v").
+				Check(testkit.Rows("1 1 3", "1 2 3", "2 4 7", "3 8 24", "3 16 24", "5 32 56"))
+			// the end bound 0 preceding is the current peer group, including the last one.
+			tk.MustQuery("select o, v, sum(v) over (order by o groups between 1 preceding and 0 preceding) from t order by o, v").
+				Check(testkit.Rows("1 1 3", "1 2 3", "2 4 7", "3 8 28", "3 16 28", "5 32 56"))
+			tk.MustQuery("select o, v, sum(v) over (order by o groups between 0 preceding and 0 preceding) from t order by o, v").
+				Check(testkit.Rows("1 1 3", "1 2 3", "2 4 4", "3 8 24", "3 16 24", "5 32 32"))
+		}
+	}
+	tk.MustQuery("explain format = 'brief' select sum(v) over (order by o groups between 1 preceding and 1 following) from t").
+		CheckContain("groups between 1 preceding and 1 following")
+	tk.MustGetErrCode("select sum(v) over (order by o groups between interval 1 day preceding and current row) from t", errno.ErrWindowRowsIntervalUse)
+	tk.MustGetErrCode("select sum(v) over (order by o groups between 1.5 preceding and current row) from t", errno.ErrWindowFrameIllegal)
+}
+
+func TestWindowFunctionsDistinctAndGroupConcat(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (o int, v int)")
+	tk.MustExec("insert into t values (3, 8), (1, 1), (5, 32), (1, 2), (2, 4), (3, 16)")
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %s", pipelined))
+		tk.MustQuery("select o, count(distinct o) over () from t order by o, v").
+			Check(testkit.Rows("1 4", "1 4", "2 4", "3 4", "3 4", "5 4"))
+		tk.MustQuery("select o, sum(distinct o) over (order by o rows between unbounded preceding and current row) from t order by o, v").
+			Check(testkit.Rows("1 1", "1 1", "2 3", "3 6", "3 6", "5 11"))
+		tk.MustQuery("select o, v, group_concat(v order by v desc separator '-') over (partition by o) from t order by o, v").
+			Check(testkit.Rows("1 1 2-1", "1 2 2-1", "2 4 4", "3 8 16-8", "3 16 16-8", "5 32 32"))
+		tk.MustQuery("select o, group_concat(distinct o) over (order by o rows between 1 preceding and 1 following) as r from t order by o, r").
+			Check(testkit.Rows("1 1", "1 1,2", "2 1,2,3", "3 2,3", "3 3,5", "5 3,5"))
+		tk.MustQuery("select o, group_concat(v order by 1) over (order by o groups current row) from t order by o, v").
+			Check(testkit.Rows("1 1,2", "1 1,2", "2 4", "3 8,16", "3 8,16", "5 32"))
+	}
+	tk.MustQuery("explain format = 'brief' select sum(distinct v) over (), group_concat(v order by v desc) over () from t").
+		MultiCheckContain([]string{"sum(distinct cast(test.t.v", "order by test.t.v true)->"})
+}
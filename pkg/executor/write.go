@@ -89,6 +89,8 @@ func updateRecord(
 	_ *memory.Tracker,
 	fkChecks []*FKCheckExec,
 	fkCascades []*FKCascadeExec,
+	triggers *TriggerExec,
+	mviewLogs *mviewLogWriter,
 	dupKeyMode table.DupKeyCheckMode,
 	ignoreErr bool,
 ) (changed bool, ignored bool, retErr error) {
@@ -154,6 +156,18 @@ func updateRecord(
 		return nil
 	}
 
+	// The BEFORE UPDATE triggers may change the non-generated columns.
+	if triggers.hasBefore(ast.TriggerUpdate) {
+		if err := triggers.fireBefore(ctx, ast.TriggerUpdate, oldData, newData); err != nil {
+			return false, false, err
+		}
+		if chunk.Row(evalBuffer).Chunk() != nil {
+			for i := range cols {
+				evalBuffer.SetDatum(i+offset, newData[i])
+			}
+		}
+	}
+
 	// Before do actual update, We need to ensure that all columns are evaluated in the following order:
 	// Step 1: non-generated columns (These columns should be evaluated outside this function).
 	// Step 2: check whether there are some columns changed.
@@ -181,7 +195,11 @@ func updateRecord(
 			keySet |= lockUniqueKeys
 		}
 		_, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet)
-		return false, false, err
+		if err != nil {
+			return false, false, err
+		}
+		triggers.queueAfter(ast.TriggerUpdate, oldData, newData)
+		return false, false, nil
 	}
 
 	// Step 3: fill values into on-update-now fields.
@@ -336,6 +354,10 @@ func updateRecord(
 			return false, false, err
 		}
 	}
+	if err := mviewLogs.log(sctx, oldData, newData); err != nil {
+		return false, false, err
+	}
+	triggers.queueAfter(ast.TriggerUpdate, oldData, newData)
 	if onDup {
 		sc.AddAffectedRows(2)
 	} else {
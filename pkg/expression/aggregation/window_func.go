@@ -15,18 +15,30 @@
 package aggregation
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/cascades/base"
+	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// HasDistinct indicates whether the aggregate function only aggregates the distinct values.
+	HasDistinct bool
+	// IgnoreNull indicates whether the NULL values are skipped, it's only used in
+	// FIRST_VALUE, LAST_VALUE, NTH_VALUE, LEAD and LAG.
+	IgnoreNull bool
+	// FromLast indicates whether NTH_VALUE counts the rows from the end of the frame.
+	FromLast bool
+	// OrderByItems represents the order by clause used in GROUP_CONCAT.
+	OrderByItems []*util.ByItems
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -79,7 +91,7 @@ func NewWindowFuncDesc(ctx expression.BuildContext, name string, args []expressi
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
@@ -119,7 +131,81 @@ func NeedFrame(name string) bool {
 
 // Clone makes a copy of SortItem.
 func (s *WindowFuncDesc) Clone() *WindowFuncDesc {
-	return &WindowFuncDesc{*s.baseFuncDesc.clone()}
+	clone := *s
+	clone.baseFuncDesc = *s.baseFuncDesc.clone()
+	clone.OrderByItems = make([]*util.ByItems, len(s.OrderByItems))
+	for i, byItem := range s.OrderByItems {
+		clone.OrderByItems[i] = byItem.Clone()
+	}
+	return &clone
+}
+
+// StringWithCtx returns the string representation within given ctx.
+func (s *WindowFuncDesc) StringWithCtx(ctx expression.ParamValues, redact string) string {
+	buffer := bytes.NewBufferString(s.Name)
+	buffer.WriteString("(")
+	if s.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
+	for i, arg := range s.Args {
+		buffer.WriteString(arg.StringWithCtx(ctx, redact))
+		if i+1 != len(s.Args) {
+			buffer.WriteString(", ")
+		}
+	}
+	if len(s.OrderByItems) > 0 {
+		buffer.WriteString(" order by ")
+	}
+	for i, arg := range s.OrderByItems {
+		buffer.WriteString(arg.StringWithCtx(ctx, redact))
+		if i+1 != len(s.OrderByItems) {
+			buffer.WriteString(", ")
+		}
+	}
+	buffer.WriteString(")")
+	if s.FromLast {
+		buffer.WriteString(" from last")
+	}
+	if s.IgnoreNull {
+		buffer.WriteString(" ignore nulls")
+	}
+	return buffer.String()
+}
+
+// Hash64 returns the hash64 for the window function signature.
+func (s *WindowFuncDesc) Hash64(h base.Hasher) {
+	s.baseFuncDesc.Hash64(h)
+	h.HashBool(s.HasDistinct)
+	h.HashBool(s.IgnoreNull)
+	h.HashBool(s.FromLast)
+	h.HashInt(len(s.OrderByItems))
+	for _, item := range s.OrderByItems {
+		item.Hash64(h)
+	}
+}
+
+// Equals checks whether two window function signatures are equal.
+func (s *WindowFuncDesc) Equals(other any) bool {
+	s2, ok := other.(*WindowFuncDesc)
+	if !ok {
+		return false
+	}
+	if s == nil {
+		return s2 == nil
+	}
+	if s2 == nil {
+		return false
+	}
+	if s.HasDistinct != s2.HasDistinct || s.IgnoreNull != s2.IgnoreNull || s.FromLast != s2.FromLast ||
+		len(s.OrderByItems) != len(s2.OrderByItems) {
+		return false
+	}
+	for i := range s.OrderByItems {
+		if !s.OrderByItems[i].Equals(s2.OrderByItems[i]) {
+			return false
+		}
+	}
+	return s.baseFuncDesc.Equals(&s2.baseFuncDesc)
 }
 
 // WindowFuncToPBExpr converts aggregate function to pb.
@@ -143,6 +229,9 @@ func WindowFuncToPBExpr(sctx expression.EvalContext, client kv.Client, desc *Win
 
 // CanPushDownToTiFlash control whether a window function desc can be push down to tiflash.
 func (s *WindowFuncDesc) CanPushDownToTiFlash(ctx expression.PushDownContext) bool {
+	if s.HasDistinct || s.IgnoreNull || s.FromLast {
+		return false
+	}
 	// args
 	if !expression.CanExprsPushDown(ctx, s.Args, kv.TiFlash) {
 		return false
@@ -485,9 +485,6 @@ func (c *castAsArrayFunctionClass) getFunction(ctx BuildContext, args []Expressi
 	case mysql.TypeYear, mysql.TypeJSON, mysql.TypeFloat, mysql.TypeNewDecimal:
 		return nil, ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("CAST-ing data to array of %s", arrayType.String()))
 	}
-	if arrayType.EvalType() == types.ETString && arrayType.GetCharset() != charset.CharsetUTF8MB4 && arrayType.GetCharset() != charset.CharsetBin {
-		return nil, ErrNotSupportedYet.GenWithStackByArgs("specifying charset for multi-valued index")
-	}
 	if arrayType.EvalType() == types.ETString && arrayType.GetFlen() == types.UnspecifiedLength {
 		return nil, ErrNotSupportedYet.GenWithStackByArgs("CAST-ing data to array of char/binary BLOBs with unspecified length")
 	}
@@ -569,6 +566,10 @@ func convertJSON2Tp(evalType types.EvalType) func(*stmtctx.StatementContext, typ
 			if item.TypeCode != types.JSONTypeCodeString {
 				return nil, ErrInvalidJSONForFuncIndex
 			}
+			// The string must be representable in the charset of the multi-valued index.
+			if !charset.FindEncodingTakeUTF8AsNoop(tp.GetCharset()).IsValid(item.GetString()) {
+				return nil, ErrInvalidJSONForFuncIndex
+			}
 			return types.ProduceStrWithSpecifiedTp(string(item.GetString()), tp, sc.TypeCtx(), false)
 		}
 	case types.ETInt:
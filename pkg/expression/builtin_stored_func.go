@@ -0,0 +1,362 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/expression/expropt"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// StoredFunction is a compiled stored function. The parameters and the local
+// variables are kept in a frame row, the parameters take the first slots and
+// every expression in the body reads the variables as columns of the frame.
+type StoredFunction struct {
+	// Name is the qualified name of the stored function like `db.func`.
+	Name     ast.CIStr
+	NumParam int
+	VarTypes []*types.FieldType
+	RetType  *types.FieldType
+	Body     []StoredFuncStmt
+}
+
+// StoredFuncStmt is a compiled statement of a stored function body.
+type StoredFuncStmt interface {
+	storedFuncStmt()
+}
+
+// StoredFuncSet assigns the value of Expr to the variable in Slot.
+type StoredFuncSet struct {
+	Slot int
+	Expr Expression
+}
+
+// StoredFuncExpr evaluates Expr for its side effect, like assigning a user variable.
+type StoredFuncExpr struct {
+	Expr Expression
+}
+
+// StoredFuncReturn returns the value of Expr from the stored function.
+type StoredFuncReturn struct {
+	Expr Expression
+}
+
+// StoredFuncIf runs the first branch whose condition is true, it's used by
+// both IF and CASE statements.
+type StoredFuncIf struct {
+	Conds    []Expression
+	Branches [][]StoredFuncStmt
+	Else     []StoredFuncStmt
+	// IsCase indicates it's a CASE statement, which reports an error when no
+	// branch matches and there is no ELSE.
+	IsCase bool
+}
+
+// StoredFuncLoop is a WHILE or REPEAT loop.
+type StoredFuncLoop struct {
+	Label    string
+	Cond     Expression
+	Body     []StoredFuncStmt
+	IsRepeat bool
+}
+
+// StoredFuncBlock is a BEGIN ... END block.
+type StoredFuncBlock struct {
+	Label string
+	Body  []StoredFuncStmt
+}
+
+// StoredFuncJump is a LEAVE or ITERATE statement.
+type StoredFuncJump struct {
+	Label   string
+	IsLeave bool
+}
+
+func (*StoredFuncSet) storedFuncStmt()    {}
+func (*StoredFuncExpr) storedFuncStmt()   {}
+func (*StoredFuncReturn) storedFuncStmt() {}
+func (*StoredFuncIf) storedFuncStmt()     {}
+func (*StoredFuncLoop) storedFuncStmt()   {}
+func (*StoredFuncBlock) storedFuncStmt()  {}
+func (*StoredFuncJump) storedFuncStmt()   {}
+
+// storedFuncReturned is the jump which unwinds the body after RETURN.
+var storedFuncReturned = &StoredFuncJump{}
+
+// NewStoredFunction creates a scalar function which calls the stored function.
+func NewStoredFunction(ctx BuildContext, fn *StoredFunction, args ...Expression) (Expression, error) {
+	funcArgs := make([]Expression, len(args))
+	copy(funcArgs, args)
+	bf, err := newBaseBuiltinFuncWithFieldType(fn.RetType.Clone(), funcArgs)
+	if err != nil {
+		return nil, err
+	}
+	// The result of a stored function has the same coercibility as a column.
+	if bf.tp.EvalType() == types.ETString {
+		bf.SetCoercibility(CoercibilityImplicit)
+	} else {
+		bf.SetCoercibility(CoercibilityNumeric)
+	}
+	bf.SetRepertoire(UNICODE)
+	// The stored function may be dropped or replaced at any time.
+	ctx.SetSkipPlanCache("stored function should not be cached")
+	sig := &builtinStoredFuncSig{baseBuiltinFunc: bf, fn: fn}
+	return &ScalarFunction{
+		FuncName: fn.Name,
+		RetType:  bf.tp,
+		Function: sig,
+	}, nil
+}
+
+type builtinStoredFuncSig struct {
+	baseBuiltinFunc
+	expropt.SessionVarsPropReader
+
+	fn *StoredFunction
+}
+
+func (b *builtinStoredFuncSig) Clone() builtinFunc {
+	newSig := &builtinStoredFuncSig{fn: b.fn}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinStoredFuncSig) RequiredOptionalEvalProps() OptionalEvalPropKeySet {
+	props := b.SessionVarsPropReader.RequiredOptionalEvalProps()
+	var collect func(stmts []StoredFuncStmt)
+	collect = func(stmts []StoredFuncStmt) {
+		for _, stmt := range stmts {
+			switch x := stmt.(type) {
+			case *StoredFuncSet:
+				props |= GetOptionalEvalPropsForExpr(x.Expr)
+			case *StoredFuncExpr:
+				props |= GetOptionalEvalPropsForExpr(x.Expr)
+			case *StoredFuncReturn:
+				props |= GetOptionalEvalPropsForExpr(x.Expr)
+			case *StoredFuncIf:
+				for i, cond := range x.Conds {
+					props |= GetOptionalEvalPropsForExpr(cond)
+					collect(x.Branches[i])
+				}
+				collect(x.Else)
+			case *StoredFuncLoop:
+				props |= GetOptionalEvalPropsForExpr(x.Cond)
+				collect(x.Body)
+			case *StoredFuncBlock:
+				collect(x.Body)
+			}
+		}
+	}
+	collect(b.fn.Body)
+	return props
+}
+
+func (b *builtinStoredFuncSig) evalDatum(ctx EvalContext, row chunk.Row) (types.Datum, error) {
+	frame := chunk.MutRowFromTypes(b.fn.VarTypes)
+	for i, arg := range b.args {
+		d, err := arg.Eval(ctx, row)
+		if err != nil {
+			return types.Datum{}, err
+		}
+		if d, err = d.ConvertTo(typeCtx(ctx), b.fn.VarTypes[i]); err != nil {
+			return types.Datum{}, err
+		}
+		frame.SetDatum(i, d)
+	}
+	e := &storedFuncExec{sig: b, ctx: ctx, frame: frame}
+	jump, err := e.execStmts(b.fn.Body)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	if jump != storedFuncReturned {
+		return types.Datum{}, ErrSpNoreturnend.GenWithStackByArgs(b.fn.Name.O)
+	}
+	return e.ret.ConvertTo(typeCtx(ctx), b.tp)
+}
+
+func (b *builtinStoredFuncSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalDecimal(ctx EvalContext, row chunk.Row) (*types.MyDecimal, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return nil, true, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return "", true, err
+	}
+	return d.GetString(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalTime(ctx EvalContext, row chunk.Row) (types.Time, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.ZeroTime, true, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalDuration(ctx EvalContext, row chunk.Row) (types.Duration, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.Duration{}, true, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	d, err := b.evalDatum(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.BinaryJSON{}, true, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
+
+// storedFuncExec runs the body of a stored function for one call.
+type storedFuncExec struct {
+	sig   *builtinStoredFuncSig
+	ctx   EvalContext
+	frame chunk.MutRow
+	ret   types.Datum
+}
+
+// execStmts runs the statements, a non-nil jump means the execution leaves
+// the statements by LEAVE, ITERATE or RETURN.
+func (e *storedFuncExec) execStmts(stmts []StoredFuncStmt) (*StoredFuncJump, error) {
+	for _, stmt := range stmts {
+		jump, err := e.execStmt(stmt)
+		if err != nil || jump != nil {
+			return jump, err
+		}
+	}
+	return nil, nil
+}
+
+func (e *storedFuncExec) execStmt(stmt StoredFuncStmt) (*StoredFuncJump, error) {
+	switch x := stmt.(type) {
+	case *StoredFuncSet:
+		d, err := x.Expr.Eval(e.ctx, e.frame.ToRow())
+		if err != nil {
+			return nil, err
+		}
+		if d, err = d.ConvertTo(typeCtx(e.ctx), e.sig.fn.VarTypes[x.Slot]); err != nil {
+			return nil, err
+		}
+		e.frame.SetDatum(x.Slot, d)
+	case *StoredFuncExpr:
+		if _, err := x.Expr.Eval(e.ctx, e.frame.ToRow()); err != nil {
+			return nil, err
+		}
+	case *StoredFuncReturn:
+		d, err := x.Expr.Eval(e.ctx, e.frame.ToRow())
+		if err != nil {
+			return nil, err
+		}
+		e.ret = *d.Clone()
+		return storedFuncReturned, nil
+	case *StoredFuncIf:
+		for i, cond := range x.Conds {
+			ok, err := e.evalCond(cond)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return e.execStmts(x.Branches[i])
+			}
+		}
+		if x.IsCase && x.Else == nil {
+			return nil, ErrSpCaseNotFound.GenWithStackByArgs()
+		}
+		return e.execStmts(x.Else)
+	case *StoredFuncLoop:
+		return e.execLoop(x)
+	case *StoredFuncBlock:
+		jump, err := e.execStmts(x.Body)
+		if jump != nil && jump.IsLeave && x.Label != "" && strings.EqualFold(jump.Label, x.Label) {
+			return nil, err
+		}
+		return jump, err
+	case *StoredFuncJump:
+		return x, nil
+	}
+	return nil, nil
+}
+
+func (e *storedFuncExec) execLoop(loop *StoredFuncLoop) (*StoredFuncJump, error) {
+	vars, err := e.sig.GetSessionVars(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if err := vars.SQLKiller.HandleSignal(); err != nil {
+			return nil, err
+		}
+		if !loop.IsRepeat {
+			ok, err := e.evalCond(loop.Cond)
+			if err != nil || !ok {
+				return nil, err
+			}
+		}
+		jump, err := e.execStmts(loop.Body)
+		if err != nil {
+			return nil, err
+		}
+		if jump != nil {
+			if jump == storedFuncReturned || loop.Label == "" || !strings.EqualFold(jump.Label, loop.Label) {
+				return jump, nil
+			}
+			if jump.IsLeave {
+				return nil, nil
+			}
+			continue
+		}
+		if loop.IsRepeat {
+			done, err := e.evalCond(loop.Cond)
+			if err != nil || done {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (e *storedFuncExec) evalCond(cond Expression) (bool, error) {
+	d, err := cond.Eval(e.ctx, e.frame.ToRow())
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	v, err := d.ToBool(typeCtx(e.ctx))
+	return v != 0, err
+}
@@ -40,6 +40,8 @@ var (
 	ErrDataOutOfRangeFuncIndex     = dbterror.ClassExpression.NewStd(mysql.ErrDataOutOfRangeFunctionalIndex)
 	ErrFuncIndexDataIsTooLong      = dbterror.ClassExpression.NewStd(mysql.ErrFunctionalIndexDataIsTooLong)
 	ErrFunctionNotExists           = dbterror.ClassExpression.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpNoreturnend               = dbterror.ClassExpression.NewStd(mysql.ErrSpNoreturnend)
+	ErrSpCaseNotFound              = dbterror.ClassExpression.NewStd(mysql.ErrSpCaseNotFound)
 
 	// All the un-exported errors are defined here:
 	errZlibZData                     = dbterror.ClassExpression.NewStd(mysql.ErrZlibZData)
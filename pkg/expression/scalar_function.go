@@ -414,6 +414,11 @@ func (sf *ScalarFunction) ConstLevel() ConstLevel {
 		return ConstNone
 	}
 
+	if _, ok := sf.Function.(*builtinStoredFuncSig); ok {
+		// the stored function reads the session state and may have a side effect.
+		return ConstNone
+	}
+
 	level := ConstStrict
 	for _, arg := range sf.GetArgs() {
 		argLevel := arg.ConstLevel()
@@ -16,6 +16,9 @@ package multivaluedindex
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/errno"
@@ -264,6 +267,79 @@ func TestWriteMultiValuedIndexComposite(t *testing.T) {
 	}
 }
 
+func TestWriteMultiValuedIndexMultiKeyParts(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(pk int primary key, a json, b json, c int, index idx(c, (cast(a as signed array)), (cast(b as signed array))))")
+	tk.MustExec("insert into t1 values (1, '[1,2,2]', '[3,4]', 1)")
+	tk.MustExec("insert into t1 values (2, '[1]', null, 2)")
+	tk.MustExec("insert into t1 values (3, '[1,2]', '[]', 3)")
+	tk.MustExec("insert into t1 values (4, '[]', '[3]', 4)")
+
+	t1, err := dom.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t1"))
+	require.NoError(t, err)
+	for _, index := range t1.Indices() {
+		if index.Meta().MVIndex {
+			checkCount(t, t1.IndexPrefix(), index, store, 5)
+			checkKey(t, t1.IndexPrefix(), index, store, [][]types.Datum{
+				{types.NewIntDatum(1), types.NewIntDatum(1), types.NewIntDatum(3), types.NewIntDatum(1)},
+				{types.NewIntDatum(1), types.NewIntDatum(1), types.NewIntDatum(4), types.NewIntDatum(1)},
+				{types.NewIntDatum(1), types.NewIntDatum(2), types.NewIntDatum(3), types.NewIntDatum(1)},
+				{types.NewIntDatum(1), types.NewIntDatum(2), types.NewIntDatum(4), types.NewIntDatum(1)},
+				{types.NewIntDatum(2), types.NewIntDatum(1), types.NewDatum(nil), types.NewIntDatum(2)},
+			})
+		}
+	}
+	tk.MustExec("update t1 set b = '[5]' where pk = 3")
+	tk.MustQuery("select pk from t1 where c = 3 and 2 member of (a) and 5 member of (b)").Check(testkit.Rows("3"))
+	tk.MustQuery("select pk from t1 use index(idx) where c = 1 and json_contains(a, '[1, 2]') and json_contains(b, '[3, 4]')").Check(testkit.Rows("1"))
+	tk.MustQuery("select pk from t1 use index(idx) where c = 1 and json_overlaps(a, '[2, 9]') and 4 member of (b)").Check(testkit.Rows("1"))
+	tk.MustExec("admin check table t1")
+	tk.MustExec("delete from t1")
+	for _, index := range t1.Indices() {
+		if index.Meta().MVIndex {
+			checkCount(t, t1.IndexPrefix(), index, store, 0)
+		}
+	}
+
+	// The number of the index values produced by a row is limited.
+	jsonArray := func(n int) string {
+		elems := make([]string, n)
+		for i := range elems {
+			elems[i] = strconv.Itoa(i)
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	}
+	tk.MustExec(fmt.Sprintf("insert into t1 values (5, '%s', '[1]', 5)", jsonArray(256)))
+	tk.MustExec(fmt.Sprintf("insert into t1 values (6, '%s', '%s', 6)", jsonArray(255), jsonArray(256)))
+	require.EqualError(t, tk.ExecToErr(fmt.Sprintf("insert into t1 values (7, '%s', '%s', 7)", jsonArray(256), jsonArray(256))),
+		"[table:3905]Exceeded max number of values per record for multi-valued index 'idx' by 1 value(s).")
+	tk.MustGetErrCode(fmt.Sprintf("update t1 set b = '%s' where pk = 5", jsonArray(256)), errno.ErrExceededMVKeysNum)
+	tk.MustExec("admin check table t1")
+	tk.MustExec("delete from t1")
+
+	// The backfill of ADD INDEX is limited too.
+	tk.MustExec("create table t3(pk int primary key, a json, b json)")
+	tk.MustExec(fmt.Sprintf("insert into t3 values (1, '%s', '%s')", jsonArray(256), jsonArray(256)))
+	tk.MustGetErrCode("alter table t3 add index idx((cast(a as signed array)), (cast(b as signed array)))", errno.ErrExceededMVKeysNum)
+	tk.MustExec("update t3 set b = '[1]'")
+	tk.MustExec("alter table t3 add index idx((cast(a as signed array)), (cast(b as signed array)))")
+	tk.MustExec("admin check table t3")
+
+	tk.MustExec("create table t2(pk int primary key, a json, index idx((cast(a as char(10) charset gbk array))))")
+	tk.MustExec("insert into t2 values (1, '[\"汉字\", \"abc\"]')")
+	tk.MustGetErrCode("insert into t2 values (2, '[\"\U0001F600\"]')", errno.ErrInvalidJSONValueForFuncIndex)
+	tk.MustQuery("select pk from t2 where '汉字' member of (a)").Check(testkit.Rows("1"))
+	tk.MustQuery("show create table t2").Check(testkit.Rows("t2 CREATE TABLE `t2` (\n" +
+		"  `pk` int(11) NOT NULL,\n" +
+		"  `a` json DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`pk`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `idx` ((cast(`a` as char(10) charset gbk array)))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("admin check table t2")
+}
+
 func checkCount(t *testing.T, prefix kv.Key, index table.Index, store kv.Storage, except int) {
 	c := 0
 	checkIndex(t, prefix, index, store, func(it kv.Iterator) {
@@ -101,6 +101,10 @@ func (b *Builder) ApplyDiff(m meta.Reader, diff *model.SchemaDiff) ([]int64, err
 		return applyExchangeTablePartition(b, m, diff)
 	case model.ActionFlashbackCluster:
 		return []int64{-1}, nil
+	case model.ActionCreateRoutine, model.ActionDropRoutine,
+		model.ActionCreateEvent, model.ActionAlterEvent, model.ActionDropEvent:
+		// stored routines and events are not cached in the info schema, they are read from meta directly.
+		return nil, nil
 	default:
 		return applyDefaultAction(b, m, diff)
 	}
@@ -341,7 +345,8 @@ func (b *Builder) getTableIDs(m meta.Reader, diff *model.SchemaDiff) (oldTableID
 		// Since the cluster-index feature also has similar problem, we chose to prevent DDL execution during the upgrade process to avoid this issue.
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
-	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence:
+	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence,
+		model.ActionDropMaterializedView:
 		oldTableID = diff.TableID
 
 		// Still keep the table in infoschema until when the state of table reaches StateNone. This is because
@@ -355,7 +360,7 @@ func (b *Builder) getTableIDs(m meta.Reader, diff *model.SchemaDiff) (oldTableID
 		if tblInfo != nil && tblInfo.State != model.StateNone {
 			newTableID = diff.TableID
 		}
-	case model.ActionTruncateTable, model.ActionCreateView,
+	case model.ActionTruncateTable, model.ActionCreateView, model.ActionCreateMaterializedView,
 		model.ActionExchangeTablePartition, model.ActionAlterTablePartitioning,
 		model.ActionRemovePartitioning:
 		oldTableID = diff.OldTableID
@@ -260,6 +260,22 @@ func TableIsSequence(is InfoSchema, schema, table ast.CIStr) bool {
 	return false
 }
 
+// FindTriggerTable finds the table which the trigger schema.trigger belongs to.
+// The trigger names are unique in a schema, so all the tables of the schema are
+// searched.
+func FindTriggerTable(ctx stdctx.Context, is InfoSchema, schema, trigger ast.CIStr) (*model.TableInfo, *model.TriggerInfo, error) {
+	tblInfos, err := is.SchemaTableInfos(ctx, schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tblInfo := range tblInfos {
+		if trg := tblInfo.FindTrigger(trigger.L); trg != nil {
+			return tblInfo, trg, nil
+		}
+	}
+	return nil, nil, nil
+}
+
 func (is *infoSchema) TableExists(schema, table ast.CIStr) bool {
 	if tbNames, ok := is.schemaMap[schema.L]; ok {
 		if _, ok = tbNames.tables[table.L]; ok {
@@ -226,6 +226,8 @@ const (
 	InternalTimer = "Timer"
 	// InternalDDLNotifier is the type of DDL notifier
 	InternalDDLNotifier = "DDLNotifier"
+	// InternalTxnEventScheduler is the type of the scheduled events usage
+	InternalTxnEventScheduler = "EventScheduler"
 )
 
 // The bitmap:
@@ -0,0 +1,331 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoints
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// WatchTableNameFile is the table name of the files tracked by the watch mode.
+	WatchTableNameFile = "watch_file_v1"
+	// watchSchemaSuffix is appended to the checkpoint schema, the ingested files
+	// are recorded in another schema because the checkpoint schema is dropped
+	// after each batch.
+	watchSchemaSuffix = "_watch"
+	// watchFileSuffix is appended to the checkpoint file name for the same reason.
+	watchFileSuffix = ".watch"
+)
+
+// some frequently used SQL statement templates of MySQLWatchCheckpointsDB.
+const (
+	CreateWatchFileTableTemplate = `
+		CREATE TABLE IF NOT EXISTS %s.%s (
+			path varchar(2048) NOT NULL,
+			file_size bigint NOT NULL,
+			batch_id bigint NOT NULL,
+			finished BOOL NOT NULL DEFAULT FALSE,
+			create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			PRIMARY KEY(path(500)),
+			INDEX(batch_id)
+		);`
+	ReadWatchFileTemplate = `
+		SELECT path, file_size, batch_id, finished FROM %s.%s ORDER BY batch_id, path;`
+	ReplaceWatchFileTemplate = `
+		REPLACE INTO %s.%s (path, file_size, batch_id, finished) VALUES (?, ?, ?, FALSE);`
+	FinishWatchBatchTemplate = `
+		UPDATE %s.%s SET finished = TRUE WHERE batch_id = ?;`
+)
+
+// WatchedFile is a source file tracked by the watch mode.
+type WatchedFile struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	BatchID  int64  `json:"batch-id"`
+	Finished bool   `json:"finished"`
+}
+
+// WatchCheckpointsDB records the source files ingested by the watch mode, so
+// that the files are neither skipped nor imported twice after restarting.
+type WatchCheckpointsDB interface {
+	// Files returns all the tracked files ordered by the batch ID and the path,
+	// the unfinished ones belong to the batch which is being imported.
+	Files(ctx context.Context) ([]WatchedFile, error)
+	// StartBatch records the files of a batch before importing them.
+	StartBatch(ctx context.Context, batchID int64, files []WatchedFile) error
+	// FinishBatch marks the files of the batch as ingested.
+	FinishBatch(ctx context.Context, batchID int64) error
+	Close() error
+}
+
+// OpenWatchCheckpointsDB opens the WatchCheckpointsDB beside the checkpoints DB
+// of the config.
+func OpenWatchCheckpointsDB(ctx context.Context, cfg *config.Config) (WatchCheckpointsDB, error) {
+	if !cfg.Checkpoint.Enable {
+		return nil, errors.New("the watch mode requires the checkpoint to be enabled")
+	}
+
+	switch cfg.Checkpoint.Driver {
+	case config.CheckpointDriverMySQL:
+		var (
+			db  *sql.DB
+			err error
+		)
+		if cfg.Checkpoint.MySQLParam != nil {
+			db, err = cfg.Checkpoint.MySQLParam.Connect()
+		} else {
+			db, err = sql.Open("mysql", cfg.Checkpoint.DSN)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cpdb, err := NewMySQLWatchCheckpointsDB(ctx, db, cfg.Checkpoint.Schema+watchSchemaSuffix)
+		if err != nil {
+			_ = db.Close()
+			return nil, errors.Trace(err)
+		}
+		return cpdb, nil
+
+	case config.CheckpointDriverFile:
+		cpdb, err := NewFileWatchCheckpointsDB(ctx, cfg.Checkpoint.DSN)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return cpdb, nil
+
+	default:
+		return nil, common.ErrUnknownCheckpointDriver.GenWithStackByArgs(cfg.Checkpoint.Driver)
+	}
+}
+
+// MySQLWatchCheckpointsDB is the WatchCheckpointsDB stored in a MySQL compatible database.
+type MySQLWatchCheckpointsDB struct {
+	db     *sql.DB
+	schema string
+}
+
+// NewMySQLWatchCheckpointsDB creates a new MySQLWatchCheckpointsDB.
+func NewMySQLWatchCheckpointsDB(ctx context.Context, db *sql.DB, schemaName string) (*MySQLWatchCheckpointsDB, error) {
+	sql := common.SQLWithRetry{
+		DB:           db,
+		Logger:       log.FromContext(ctx).With(zap.String("schema", schemaName)),
+		HideQueryLog: true,
+	}
+	err := sql.Exec(ctx, "create watch checkpoints database", common.SprintfWithIdentifiers(CreateDBTemplate, schemaName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = sql.Exec(ctx, "create watch files table", common.SprintfWithIdentifiers(CreateWatchFileTableTemplate, schemaName, WatchTableNameFile))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MySQLWatchCheckpointsDB{
+		db:     db,
+		schema: schemaName,
+	}, nil
+}
+
+// Files implements WatchCheckpointsDB.Files.
+func (cpdb *MySQLWatchCheckpointsDB) Files(ctx context.Context) ([]WatchedFile, error) {
+	s := common.SQLWithRetry{
+		DB:     cpdb.db,
+		Logger: log.FromContext(ctx),
+	}
+	query := common.SprintfWithIdentifiers(ReadWatchFileTemplate, cpdb.schema, WatchTableNameFile)
+
+	var files []WatchedFile
+	err := s.Transact(ctx, "read watch files", func(c context.Context, tx *sql.Tx) error {
+		files = files[:0]
+		rows, err := tx.QueryContext(c, query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		//nolint: errcheck
+		defer rows.Close()
+		for rows.Next() {
+			var file WatchedFile
+			if err := rows.Scan(&file.Path, &file.Size, &file.BatchID, &file.Finished); err != nil {
+				return errors.Trace(err)
+			}
+			files = append(files, file)
+		}
+		return errors.Trace(rows.Err())
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return files, nil
+}
+
+// StartBatch implements WatchCheckpointsDB.StartBatch.
+func (cpdb *MySQLWatchCheckpointsDB) StartBatch(ctx context.Context, batchID int64, files []WatchedFile) error {
+	s := common.SQLWithRetry{
+		DB:     cpdb.db,
+		Logger: log.FromContext(ctx).With(zap.Int64("batchID", batchID)),
+	}
+	query := common.SprintfWithIdentifiers(ReplaceWatchFileTemplate, cpdb.schema, WatchTableNameFile)
+
+	return s.Transact(ctx, "start watch batch", func(c context.Context, tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(c, query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		//nolint: errcheck
+		defer stmt.Close()
+		for _, file := range files {
+			if _, err := stmt.ExecContext(c, file.Path, file.Size, batchID); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	})
+}
+
+// FinishBatch implements WatchCheckpointsDB.FinishBatch.
+func (cpdb *MySQLWatchCheckpointsDB) FinishBatch(ctx context.Context, batchID int64) error {
+	s := common.SQLWithRetry{
+		DB:     cpdb.db,
+		Logger: log.FromContext(ctx).With(zap.Int64("batchID", batchID)),
+	}
+	query := common.SprintfWithIdentifiers(FinishWatchBatchTemplate, cpdb.schema, WatchTableNameFile)
+	return s.Exec(ctx, "finish watch batch", query, batchID)
+}
+
+// Close implements WatchCheckpointsDB.Close.
+func (cpdb *MySQLWatchCheckpointsDB) Close() error {
+	return errors.Trace(cpdb.db.Close())
+}
+
+// FileWatchCheckpointsDB is the WatchCheckpointsDB stored as a JSON file beside
+// the checkpoint file.
+type FileWatchCheckpointsDB struct {
+	lock      sync.Mutex
+	files     map[string]WatchedFile
+	fileName  string
+	exStorage storage.ExternalStorage
+}
+
+// NewFileWatchCheckpointsDB creates a new FileWatchCheckpointsDB, the path is
+// the DSN of the checkpoint file.
+func NewFileWatchCheckpointsDB(ctx context.Context, path string) (*FileWatchCheckpointsDB, error) {
+	s, fileName, err := createExstorageByCompletePath(ctx, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if fileName == "" {
+		return nil, errors.Errorf("the checkpoint DSN '%s' must not be a directory", path)
+	}
+	cpdb := &FileWatchCheckpointsDB{
+		files:     make(map[string]WatchedFile),
+		fileName:  fileName + watchFileSuffix,
+		exStorage: s,
+	}
+
+	exist, err := s.FileExists(ctx, cpdb.fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !exist {
+		return cpdb, nil
+	}
+	content, err := s.ReadFile(ctx, cpdb.fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var files []WatchedFile
+	// unlike the checkpoints, a broken file can't be ignored, otherwise all the
+	// ingested files are imported again.
+	if err := json.Unmarshal(content, &files); err != nil {
+		return nil, errors.Annotatef(err, "watch checkpoint file %s is broken", cpdb.fileName)
+	}
+	for _, file := range files {
+		cpdb.files[file.Path] = file
+	}
+	return cpdb, nil
+}
+
+func (cpdb *FileWatchCheckpointsDB) sortedFiles() []WatchedFile {
+	files := make([]WatchedFile, 0, len(cpdb.files))
+	for _, file := range cpdb.files {
+		files = append(files, file)
+	}
+	slices.SortFunc(files, func(a, b WatchedFile) int {
+		if a.BatchID != b.BatchID {
+			if a.BatchID < b.BatchID {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files
+}
+
+func (cpdb *FileWatchCheckpointsDB) save(ctx context.Context) error {
+	content, err := json.Marshal(cpdb.sortedFiles())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return cpdb.exStorage.WriteFile(ctx, cpdb.fileName, content)
+}
+
+// Files implements WatchCheckpointsDB.Files.
+func (cpdb *FileWatchCheckpointsDB) Files(context.Context) ([]WatchedFile, error) {
+	cpdb.lock.Lock()
+	defer cpdb.lock.Unlock()
+	return cpdb.sortedFiles(), nil
+}
+
+// StartBatch implements WatchCheckpointsDB.StartBatch.
+func (cpdb *FileWatchCheckpointsDB) StartBatch(ctx context.Context, batchID int64, files []WatchedFile) error {
+	cpdb.lock.Lock()
+	defer cpdb.lock.Unlock()
+	for _, file := range files {
+		cpdb.files[file.Path] = WatchedFile{Path: file.Path, Size: file.Size, BatchID: batchID}
+	}
+	return cpdb.save(ctx)
+}
+
+// FinishBatch implements WatchCheckpointsDB.FinishBatch.
+func (cpdb *FileWatchCheckpointsDB) FinishBatch(ctx context.Context, batchID int64) error {
+	cpdb.lock.Lock()
+	defer cpdb.lock.Unlock()
+	for path, file := range cpdb.files {
+		if file.BatchID == batchID {
+			file.Finished = true
+			cpdb.files[path] = file
+		}
+	}
+	return cpdb.save(ctx)
+}
+
+// Close implements WatchCheckpointsDB.Close.
+func (cpdb *FileWatchCheckpointsDB) Close() error {
+	cpdb.exStorage.Close()
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoints_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWatchCheckpointsDB(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.Checkpoint.Driver = config.CheckpointDriverFile
+	cfg.Checkpoint.DSN = filepath.Join(t.TempDir(), "cp.pb")
+
+	cpdb, err := checkpoints.OpenWatchCheckpointsDB(ctx, cfg)
+	require.NoError(t, err)
+	files, err := cpdb.Files(ctx)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	require.NoError(t, cpdb.StartBatch(ctx, 1, []checkpoints.WatchedFile{
+		{Path: "db.t.2.csv", Size: 20},
+		{Path: "db.t.1.csv", Size: 10},
+	}))
+	require.NoError(t, cpdb.FinishBatch(ctx, 1))
+	require.NoError(t, cpdb.StartBatch(ctx, 2, []checkpoints.WatchedFile{{Path: "db.t.3.csv", Size: 30}}))
+	require.NoError(t, cpdb.Close())
+
+	// the files are kept after the checkpoint file is removed.
+	require.NoFileExists(t, cfg.Checkpoint.DSN)
+	cpdb, err = checkpoints.OpenWatchCheckpointsDB(ctx, cfg)
+	require.NoError(t, err)
+	defer cpdb.Close()
+	files, err = cpdb.Files(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []checkpoints.WatchedFile{
+		{Path: "db.t.1.csv", Size: 10, BatchID: 1, Finished: true},
+		{Path: "db.t.2.csv", Size: 20, BatchID: 1, Finished: true},
+		{Path: "db.t.3.csv", Size: 30, BatchID: 2},
+	}, files)
+
+	cfg.Checkpoint.Enable = false
+	_, err = checkpoints.OpenWatchCheckpointsDB(ctx, cfg)
+	require.ErrorContains(t, err, "requires the checkpoint to be enabled")
+}
+
+func TestMySQLWatchCheckpointsDB(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `mock-schema_watch`").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS `mock-schema_watch`\\.`watch_file_v\\d+` .+").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	cpdb, err := checkpoints.NewMySQLWatchCheckpointsDB(ctx, db, "mock-schema_watch")
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	stmt := mock.ExpectPrepare("REPLACE INTO `mock-schema_watch`\\.`watch_file_v\\d+`")
+	stmt.ExpectExec().WithArgs("db.t.1.csv", int64(10), int64(3)).WillReturnResult(sqlmock.NewResult(1, 1))
+	stmt.ExpectExec().WithArgs("db.t.2.csv", int64(20), int64(3)).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	require.NoError(t, cpdb.StartBatch(ctx, 3, []checkpoints.WatchedFile{
+		{Path: "db.t.1.csv", Size: 10},
+		{Path: "db.t.2.csv", Size: 20},
+	}))
+
+	mock.ExpectExec("UPDATE `mock-schema_watch`\\.`watch_file_v\\d+` SET finished = TRUE WHERE batch_id = \\?").
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	require.NoError(t, cpdb.FinishBatch(ctx, 3))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT path, file_size, batch_id, finished FROM `mock-schema_watch`\\.`watch_file_v\\d+`").
+		WillReturnRows(sqlmock.NewRows([]string{"path", "file_size", "batch_id", "finished"}).
+			AddRow("db.t.1.csv", 10, 3, true).
+			AddRow("db.t.3.csv", 30, 4, false))
+	mock.ExpectCommit()
+	files, err := cpdb.Files(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []checkpoints.WatchedFile{
+		{Path: "db.t.1.csv", Size: 10, BatchID: 3, Finished: true},
+		{Path: "db.t.3.csv", Size: 30, BatchID: 4},
+	}, files)
+
+	mock.ExpectClose()
+	require.NoError(t, cpdb.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
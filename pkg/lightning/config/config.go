@@ -80,6 +80,9 @@ const (
 	defaultLogicalImportBatchSize     = 96 * units.KiB
 	defaultLogicalImportBatchRows     = 65536
 	defaultLogicalImportPrepStmt      = false
+	defaultWatchPollInterval          = 30 * time.Second
+	defaultWatchBatchFiles            = 1000
+	defaultWatchBatchSize             = 10 * units.GiB
 
 	// defaultMetaSchemaName is the default database name used to store lightning metadata
 	defaultMetaSchemaName           = "lightning_metadata"
@@ -289,6 +292,7 @@ type Config struct {
 	Routes       Routes          `toml:"routes" json:"routes"`
 	Security     Security        `toml:"security" json:"security"`
 	Conflict     Conflict        `toml:"conflict" json:"conflict"`
+	Watch        Watch           `toml:"watch" json:"watch"`
 }
 
 // String implements fmt.Stringer interface.
@@ -892,6 +896,8 @@ type MydumperRuntime struct {
 	StrictFormat     bool             `toml:"strict-format" json:"strict-format"`
 	DefaultFileRules bool             `toml:"default-file-rules" json:"default-file-rules"`
 	IgnoreColumns    AllIgnoreColumns `toml:"ignore-data-columns" json:"ignore-data-columns"`
+	// JSONLPaths maps the columns to the paths of the nested values in the JSON Lines files.
+	JSONLPaths AllJSONLPaths `toml:"jsonl-paths" json:"jsonl-paths"`
 	// DataCharacterSet is the character set of the source file. Only CSV files are supported now. The following options are supported.
 	//   - utf8mb4
 	//   - GB18030
@@ -968,6 +974,14 @@ func (m *MydumperRuntime) adjust() error {
 			ig.Columns = cols
 		}
 	}
+	for _, jp := range m.JSONLPaths {
+		for col, path := range jp.Paths {
+			if !strings.HasPrefix(strings.TrimSpace(path), "$") {
+				return common.ErrInvalidConfig.GenWithStack(
+					"invalid JSON path '%s' of column '%s' in `mydumper.jsonl-paths`, it should start with '$'", path, col)
+			}
+		}
+	}
 	return m.adjustFilePath()
 }
 
@@ -1066,6 +1080,39 @@ func (igCols AllIgnoreColumns) GetIgnoreColumns(db string, table string, caseSen
 	return &IgnoreColumns{Columns: make([]string, 0)}, nil
 }
 
+// AllJSONLPaths is a slice of JSONLPaths.
+type AllJSONLPaths []*JSONLPaths
+
+// JSONLPaths is the config for mapping the columns to the paths of the nested values in the JSON Lines files, such
+// as `$.user.name` or `$.tags[0]`. The column without a path is mapped to the top-level key with the same name.
+type JSONLPaths struct {
+	DB          string            `toml:"db" json:"db"`
+	Table       string            `toml:"table" json:"table"`
+	TableFilter []string          `toml:"table-filter" json:"table-filter"`
+	Paths       map[string]string `toml:"paths" json:"paths"`
+}
+
+// GetJSONLPaths gets JSON Lines paths config by schema name/regex and table name/regex.
+func (jsonlPaths AllJSONLPaths) GetJSONLPaths(db string, table string, caseSensitive bool) (*JSONLPaths, error) {
+	if !caseSensitive {
+		db = strings.ToLower(db)
+		table = strings.ToLower(table)
+	}
+	for i, jp := range jsonlPaths {
+		if jp.DB == db && jp.Table == table {
+			return jsonlPaths[i], nil
+		}
+		f, err := filter.Parse(jp.TableFilter)
+		if err != nil {
+			return nil, common.ErrInvalidConfig.GenWithStack("invalid table filter %s in jsonl paths", strings.Join(jp.TableFilter, ","))
+		}
+		if f.MatchTable(db, table) {
+			return jsonlPaths[i], nil
+		}
+	}
+	return &JSONLPaths{}, nil
+}
+
 // FileRouteRule is the rule for routing files.
 type FileRouteRule struct {
 	Pattern     string `json:"pattern" toml:"pattern" yaml:"pattern"`
@@ -1259,6 +1306,54 @@ type Cron struct {
 	CheckDiskQuota Duration `toml:"check-disk-quota" json:"check-disk-quota"`
 }
 
+// Watch is the config for the watch mode, in which lightning keeps polling the
+// data source and imports the new files in micro-batches.
+type Watch struct {
+	Enable       bool     `toml:"enable" json:"enable"`
+	PollInterval Duration `toml:"poll-interval" json:"poll-interval"`
+	// BatchFiles and BatchSize limit the number of data files and the total size
+	// of them imported in one micro-batch.
+	BatchFiles int      `toml:"batch-files" json:"batch-files"`
+	BatchSize  ByteSize `toml:"batch-size" json:"batch-size"`
+}
+
+// adjust checks the watch config, the input TikvImporter must be adjusted
+// before calling this function.
+func (w *Watch) adjust(i *TikvImporter, c *Checkpoint) error {
+	if !w.Enable {
+		return nil
+	}
+	if w.PollInterval.Duration <= 0 {
+		return common.ErrInvalidConfig.GenWithStack("`watch.poll-interval` got %s, should be larger than 0", w.PollInterval)
+	}
+	if w.BatchFiles <= 0 {
+		return common.ErrInvalidConfig.GenWithStack("`watch.batch-files` got %d, should be larger than 0", w.BatchFiles)
+	}
+	if w.BatchSize <= 0 {
+		return common.ErrInvalidConfig.GenWithStack("`watch.batch-size` got %d, should be larger than 0", w.BatchSize)
+	}
+	// the ingested files are tracked beside the checkpoints, and every batch
+	// must start from clean checkpoints.
+	if !c.Enable {
+		return common.ErrInvalidConfig.GenWithStack("`watch.enable` cannot be used when `checkpoint.enable` is false")
+	}
+	if c.KeepAfterSuccess != CheckpointRemove {
+		return common.ErrInvalidConfig.GenWithStack(
+			"`watch.enable` cannot be used with `checkpoint.keep-after-success` = %q", c.KeepAfterSuccess)
+	}
+	if i.Backend == BackendLocal {
+		// the target tables are not empty since the second batch.
+		if i.AddIndexBySQL {
+			return common.ErrInvalidConfig.GenWithStack("`watch.enable` cannot be used with tikv-importer.add-index-using-ddl")
+		}
+		if !i.ParallelImport {
+			return common.ErrInvalidConfig.GenWithStack(
+				"`watch.enable` requires tikv-importer.parallel-import to be true when using the local backend")
+		}
+	}
+	return nil
+}
+
 // Security is the config for security.
 type Security struct {
 	CAPath   string `toml:"ca-path" json:"ca-path"`
@@ -1479,6 +1574,11 @@ func NewConfig() *Config {
 			LogProgress:    Duration{Duration: 5 * time.Minute},
 			CheckDiskQuota: Duration{Duration: 1 * time.Minute},
 		},
+		Watch: Watch{
+			PollInterval: Duration{Duration: defaultWatchPollInterval},
+			BatchFiles:   defaultWatchBatchFiles,
+			BatchSize:    defaultWatchBatchSize,
+		},
 		Mydumper: MydumperRuntime{
 			ReadBlockSize: ReadBlockSize,
 			CSV: CSVConfig{
@@ -1648,5 +1748,8 @@ func (cfg *Config) Adjust(ctx context.Context) error {
 	if err = cfg.Routes.adjust(&cfg.Mydumper); err != nil {
 		return err
 	}
-	return cfg.Conflict.adjust(&cfg.TikvImporter)
+	if err = cfg.Conflict.adjust(&cfg.TikvImporter); err != nil {
+		return err
+	}
+	return cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint)
 }
@@ -1412,6 +1412,40 @@ func TestAdjustConflict(t *testing.T) {
 	require.EqualValues(t, 0, cfg.Conflict.MaxRecordRows)
 }
 
+func TestAdjustWatch(t *testing.T) {
+	cfg := NewConfig()
+	assignMinimalLegalValue(cfg)
+	require.NoError(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint))
+	require.False(t, cfg.TikvImporter.ParallelImport)
+
+	cfg.Watch.Enable = true
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "requires tikv-importer.parallel-import to be true")
+	cfg.TikvImporter.ParallelImport = true
+	require.NoError(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint))
+	require.Equal(t, 30*time.Second, cfg.Watch.PollInterval.Duration)
+	require.Equal(t, 1000, cfg.Watch.BatchFiles)
+
+	cfg.Checkpoint.KeepAfterSuccess = CheckpointOrigin
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "`checkpoint.keep-after-success` = \"origin\"")
+	cfg.Checkpoint.KeepAfterSuccess = CheckpointRemove
+	cfg.Checkpoint.Enable = false
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "`checkpoint.enable` is false")
+	cfg.Checkpoint.Enable = true
+
+	cfg.TikvImporter.ParallelImport = false
+	cfg.TikvImporter.AddIndexBySQL = true
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "add-index-using-ddl")
+	cfg.TikvImporter.Backend = BackendTiDB
+	require.NoError(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint))
+	require.False(t, cfg.TikvImporter.ParallelImport)
+
+	cfg.Watch.BatchFiles = 0
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "`watch.batch-files` got 0")
+	cfg.Watch.BatchFiles = 10
+	cfg.Watch.PollInterval.Duration = 0
+	require.ErrorContains(t, cfg.Watch.adjust(&cfg.TikvImporter, &cfg.Checkpoint), "`watch.poll-interval` got 0s")
+}
+
 func TestAdjustBlockSize(t *testing.T) {
 	ts, host, port := startMockServer(t, http.StatusOK,
 		`{"port":6666,"advertise-address":"121.212.121.212:5555","path":"34.34.34.34:3434"}`,
@@ -0,0 +1,816 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+const (
+	avroMagic    = "Obj\x01"
+	avroSyncSize = 16
+
+	avroCodecNull      = "null"
+	avroCodecDeflate   = "deflate"
+	avroCodecSnappy    = "snappy"
+	avroCodecZstandard = "zstandard"
+)
+
+// avroSchema is the parsed schema of avro, see https://avro.apache.org/docs/1.11.1/specification/.
+type avroSchema struct {
+	// typ is the primitive or complex type name, such as "long", "record" and "union".
+	typ         string
+	logicalType string
+	scale       int
+	// size is the size of fixed type.
+	size     int
+	fields   []avroField
+	symbols  []string
+	items    *avroSchema
+	values   *avroSchema
+	branches []*avroSchema
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+var avroPrimitiveTypes = map[string]struct{}{
+	"null": {}, "boolean": {}, "int": {}, "long": {}, "float": {}, "double": {}, "bytes": {}, "string": {},
+}
+
+// parseAvroSchema parses the schema in JSON. named holds the defined named types, which can be referenced by
+// their full names or simple names.
+func parseAvroSchema(raw json.RawMessage, named map[string]*avroSchema, namespace string) (*avroSchema, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, errors.New("empty avro schema")
+	}
+	switch raw[0] {
+	case '"':
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := avroPrimitiveTypes[name]; ok {
+			return &avroSchema{typ: name}, nil
+		}
+		if s, ok := named[name]; ok {
+			return s, nil
+		}
+		if s, ok := named[namespace+"."+name]; ok {
+			return s, nil
+		}
+		return nil, errors.Errorf("unknown avro type '%s'", name)
+	case '[':
+		var branches []json.RawMessage
+		if err := json.Unmarshal(raw, &branches); err != nil {
+			return nil, errors.Trace(err)
+		}
+		s := &avroSchema{typ: "union"}
+		for _, b := range branches {
+			branch, err := parseAvroSchema(b, named, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+	case '{':
+	default:
+		return nil, errors.Errorf("invalid avro schema '%s'", raw)
+	}
+
+	var def struct {
+		Type        json.RawMessage   `json:"type"`
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		LogicalType string            `json:"logicalType"`
+		Scale       int               `json:"scale"`
+		Size        int               `json:"size"`
+		Symbols     []string          `json:"symbols"`
+		Items       json.RawMessage   `json:"items"`
+		Values      json.RawMessage   `json:"values"`
+		Fields      []json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &def); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var typ string
+	if err := json.Unmarshal(def.Type, &typ); err != nil {
+		// the type is a nested schema, such as {"type": {"type": "array", ...}}
+		return parseAvroSchema(def.Type, named, namespace)
+	}
+
+	s := &avroSchema{typ: typ, logicalType: def.LogicalType, scale: def.Scale, size: def.Size}
+	switch typ {
+	case "record", "error", "enum", "fixed":
+		if def.Namespace != "" {
+			namespace = def.Namespace
+		}
+		fullName := def.Name
+		if idx := strings.LastIndexByte(def.Name, '.'); idx >= 0 {
+			namespace = def.Name[:idx]
+		} else if namespace != "" {
+			fullName = namespace + "." + def.Name
+		}
+		// register the type before parsing its fields, so the recursive types can be resolved.
+		named[fullName] = s
+		named[def.Name] = s
+	}
+	switch typ {
+	case "record", "error":
+		s.typ = "record"
+		for _, f := range def.Fields {
+			var field struct {
+				Name string          `json:"name"`
+				Type json.RawMessage `json:"type"`
+			}
+			if err := json.Unmarshal(f, &field); err != nil {
+				return nil, errors.Trace(err)
+			}
+			fieldSchema, err := parseAvroSchema(field.Type, named, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.fields = append(s.fields, avroField{name: field.Name, schema: fieldSchema})
+		}
+	case "enum":
+		s.symbols = def.Symbols
+	case "array":
+		items, err := parseAvroSchema(def.Items, named, namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	case "map":
+		values, err := parseAvroSchema(def.Values, named, namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.values = values
+	case "fixed":
+	default:
+		if _, ok := avroPrimitiveTypes[typ]; !ok {
+			if ref, ok := named[typ]; ok {
+				return ref, nil
+			}
+			if ref, ok := named[namespace+"."+typ]; ok {
+				return ref, nil
+			}
+			return nil, errors.Errorf("unknown avro type '%s'", typ)
+		}
+	}
+	return s, nil
+}
+
+// avroDecoder decodes the values in the binary encoding from a decompressed block.
+type avroDecoder struct {
+	buf []byte
+	off int
+}
+
+// avroMaxBlockSize is the max size of a data block before and after it's decompressed, to avoid OOM on corrupt
+// files and compression bombs.
+var avroMaxBlockSize = 256 << 20
+
+var (
+	errAvroShortBuffer   = errors.New("avro data is truncated")
+	errAvroBlockTooLarge = errors.New("avro block is too large after decompression")
+)
+
+func (d *avroDecoder) readLong() (int64, error) {
+	var (
+		u     uint64
+		shift uint
+	)
+	for {
+		if d.off >= len(d.buf) {
+			return 0, errAvroShortBuffer
+		}
+		b := d.buf[d.off]
+		d.off++
+		u |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, errors.New("avro varint overflows")
+		}
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (d *avroDecoder) readFixed(n int) ([]byte, error) {
+	if n < 0 || d.off+n > len(d.buf) {
+		return nil, errAvroShortBuffer
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt32 {
+		return nil, errAvroShortBuffer
+	}
+	return d.readFixed(int(n))
+}
+
+// readBlockCount reads the item count of a block of array or map, 0 means the end of the blocks.
+func (d *avroDecoder) readBlockCount() (int64, error) {
+	count, err := d.readLong()
+	if err != nil {
+		return 0, err
+	}
+	if count < 0 {
+		// a negative count is followed by the size of the block in bytes.
+		count = -count
+		if _, err = d.readLong(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+func (d *avroDecoder) readUnionBranch(s *avroSchema) (*avroSchema, error) {
+	idx, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 || idx >= int64(len(s.branches)) {
+		return nil, errors.Errorf("avro union index %d out of range", idx)
+	}
+	return s.branches[idx], nil
+}
+
+// decodeDatum decodes a value into datum. The complex values, such as records, arrays and maps, are converted to
+// JSON text, so they can be imported into JSON columns.
+func (d *avroDecoder) decodeDatum(s *avroSchema, datum *types.Datum) error {
+	switch s.typ {
+	case "null":
+		datum.SetNull()
+	case "union":
+		branch, err := d.readUnionBranch(s)
+		if err != nil {
+			return err
+		}
+		return d.decodeDatum(branch, datum)
+	case "boolean":
+		b, err := d.readFixed(1)
+		if err != nil {
+			return err
+		}
+		datum.SetInt64(int64(b[0]))
+	case "int", "long":
+		v, err := d.readLong()
+		if err != nil {
+			return err
+		}
+		if str, ok := formatAvroLogicalInt(s, v); ok {
+			datum.SetString(str, "utf8mb4_bin")
+		} else {
+			datum.SetInt64(v)
+		}
+	case "float":
+		b, err := d.readFixed(4)
+		if err != nil {
+			return err
+		}
+		datum.SetFloat64(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+	case "double":
+		b, err := d.readFixed(8)
+		if err != nil {
+			return err
+		}
+		datum.SetFloat64(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+	case "bytes", "fixed":
+		var (
+			b   []byte
+			err error
+		)
+		if s.typ == "fixed" {
+			b, err = d.readFixed(s.size)
+		} else {
+			b, err = d.readBytes()
+		}
+		if err != nil {
+			return err
+		}
+		if s.logicalType == "decimal" {
+			datum.SetString(avroDecimalToString(b, s.scale), "utf8mb4_bin")
+		} else {
+			datum.SetBytes(bytes.Clone(b))
+		}
+	case "string":
+		b, err := d.readBytes()
+		if err != nil {
+			return err
+		}
+		datum.SetString(string(b), "utf8mb4_bin")
+	case "enum":
+		idx, err := d.readLong()
+		if err != nil {
+			return err
+		}
+		if idx < 0 || idx >= int64(len(s.symbols)) {
+			return errors.Errorf("avro enum index %d out of range", idx)
+		}
+		datum.SetString(s.symbols[idx], "utf8mb4_bin")
+	default:
+		v, err := d.decodeValue(s)
+		if err != nil {
+			return err
+		}
+		text, err := json.Marshal(v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		datum.SetString(string(text), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// decodeValue decodes a value into the go value which can be marshaled to JSON.
+func (d *avroDecoder) decodeValue(s *avroSchema) (any, error) {
+	switch s.typ {
+	case "record":
+		obj := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decodeValue(f.schema)
+			if err != nil {
+				return nil, err
+			}
+			obj[f.name] = v
+		}
+		return obj, nil
+	case "array":
+		arr := []any{}
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return arr, nil
+			}
+			for range count {
+				v, err := d.decodeValue(s.items)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+		}
+	case "map":
+		obj := make(map[string]any)
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return obj, nil
+			}
+			for range count {
+				key, err := d.readBytes()
+				if err != nil {
+					return nil, err
+				}
+				v, err := d.decodeValue(s.values)
+				if err != nil {
+					return nil, err
+				}
+				obj[string(key)] = v
+			}
+		}
+	case "union":
+		branch, err := d.readUnionBranch(s)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeValue(branch)
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if str, ok := formatAvroLogicalInt(s, v); ok {
+			return str, nil
+		}
+		return v, nil
+	}
+
+	var datum types.Datum
+	if err := d.decodeDatum(s, &datum); err != nil {
+		return nil, err
+	}
+	switch datum.Kind() {
+	case types.KindFloat64:
+		return datum.GetFloat64(), nil
+	default:
+		return datum.GetString(), nil
+	}
+}
+
+// formatAvroLogicalInt formats the int or long value with the date and time logical types.
+func formatAvroLogicalInt(s *avroSchema, v int64) (string, bool) {
+	switch s.logicalType {
+	case "date":
+		return time.Unix(v*secPerDay, 0).UTC().Format(time.DateOnly), true
+	case "time-millis":
+		return time.UnixMilli(v).UTC().Format("15:04:05.999999"), true
+	case "time-micros":
+		return time.UnixMicro(v).UTC().Format("15:04:05.999999"), true
+	case "timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(utcTimeLayout), true
+	case "timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(utcTimeLayout), true
+	case "local-timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(timeLayout), true
+	case "local-timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(timeLayout), true
+	}
+	return "", false
+}
+
+// avroDecimalToString converts the big-endian two's-complement bytes of the decimal logical type to string.
+func avroDecimalToString(b []byte, scale int) string {
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	return binaryToDecimalStr(bytes.Clone(b), scale)
+}
+
+// AvroParser parses an avro object container file. The file is made up of blocks which may be compressed, so the
+// reported offset is always the beginning of a block, and the row ID is the one before the block. It means the
+// rows of a partially read block are read again with the same row IDs after resuming from a checkpoint.
+// It implements the Parser interface.
+type AvroParser struct {
+	reader ReadSeekCloser
+	buf    *bufio.Reader
+	// offset is the offset of the underlying reader which has been consumed.
+	offset int64
+	// headerEnd is the offset of the first block.
+	headerEnd int64
+
+	schema  *avroSchema
+	columns []string
+	codec   string
+	sync    []byte
+	zstd    *zstd.Decoder
+
+	block       avroDecoder
+	blockRemain int64
+
+	pos      int64
+	posRowID int64
+	lastRow  Row
+	logger   log.Logger
+}
+
+// NewAvroParser creates an avro parser. The ownership of the reader is transferred to the parser.
+func NewAvroParser(
+	ctx context.Context,
+	reader ReadSeekCloser,
+) (*AvroParser, error) {
+	p := &AvroParser{
+		reader: reader,
+		buf:    bufio.NewReader(reader),
+		logger: log.FromContext(ctx),
+	}
+	if err := p.readHeader(); err != nil {
+		return nil, errors.Annotate(err, "failed to read avro header")
+	}
+	return p, nil
+}
+
+func (p *AvroParser) readByte() (byte, error) {
+	b, err := p.buf.ReadByte()
+	if err == nil {
+		p.offset++
+	}
+	return b, err
+}
+
+func (p *AvroParser) readFull(n int64) ([]byte, error) {
+	if n < 0 || n > math.MaxInt32 {
+		return nil, errors.Errorf("invalid avro length %d", n)
+	}
+	b := make([]byte, n)
+	read, err := io.ReadFull(p.buf, b)
+	p.offset += int64(read)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return b, nil
+}
+
+func (p *AvroParser) readLong() (int64, error) {
+	var (
+		u     uint64
+		shift uint
+	)
+	for {
+		b, err := p.readByte()
+		if err != nil {
+			return 0, err
+		}
+		u |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, errors.New("avro varint overflows")
+		}
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (p *AvroParser) readHeader() error {
+	magic, err := p.readFull(int64(len(avroMagic)))
+	if err != nil {
+		return err
+	}
+	if string(magic) != avroMagic {
+		return errors.New("not an avro object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		count, err := p.readLong()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			if _, err = p.readLong(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for range count {
+			keyLen, err := p.readLong()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			key, err := p.readFull(keyLen)
+			if err != nil {
+				return err
+			}
+			valueLen, err := p.readLong()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			value, err := p.readFull(valueLen)
+			if err != nil {
+				return err
+			}
+			meta[string(key)] = value
+		}
+	}
+	if p.sync, err = p.readFull(avroSyncSize); err != nil {
+		return err
+	}
+	p.headerEnd = p.offset
+	p.pos = p.offset
+
+	p.schema, err = parseAvroSchema(meta["avro.schema"], make(map[string]*avroSchema), "")
+	if err != nil {
+		return err
+	}
+	if p.schema.typ != "record" {
+		return errors.Errorf("the schema of avro file should be a record, but got '%s'", p.schema.typ)
+	}
+	p.columns = make([]string, 0, len(p.schema.fields))
+	for _, f := range p.schema.fields {
+		p.columns = append(p.columns, strings.ToLower(f.name))
+	}
+
+	p.codec = string(meta["avro.codec"])
+	switch p.codec {
+	case "", avroCodecNull, avroCodecDeflate, avroCodecSnappy:
+	case avroCodecZstandard:
+		if p.zstd, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(avroMaxBlockSize))); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("unsupported avro codec '%s'", p.codec)
+	}
+	return nil
+}
+
+// readBlock reads the next data block. It returns io.EOF if there is no more block.
+func (p *AvroParser) readBlock() error {
+	count, err := p.readLong()
+	if err != nil {
+		return err
+	}
+	if count < 0 {
+		return errors.Errorf("invalid avro block object count %d at offset %d", count, p.offset)
+	}
+	size, err := p.readLong()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if size < 0 || size > int64(avroMaxBlockSize) {
+		return errors.Errorf("invalid avro block size %d at offset %d", size, p.offset)
+	}
+	data, err := p.readFull(size)
+	if err != nil {
+		return err
+	}
+	sync, err := p.readFull(avroSyncSize)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sync, p.sync) {
+		return errors.Errorf("avro sync marker mismatch at offset %d", p.offset)
+	}
+	if data, err = p.decompress(data); err != nil {
+		return errors.Annotatef(err, "failed to decompress avro block at offset %d", p.offset)
+	}
+	p.block = avroDecoder{buf: data}
+	p.blockRemain = count
+	if count == 0 {
+		p.pos = p.offset
+	}
+	return nil
+}
+
+func (p *AvroParser) decompress(data []byte) ([]byte, error) {
+	switch p.codec {
+	case avroCodecDeflate:
+		r := flate.NewReader(bytes.NewReader(data))
+		//nolint: errcheck
+		defer r.Close()
+		decoded, err := io.ReadAll(io.LimitReader(r, int64(avroMaxBlockSize)+1))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(decoded) > avroMaxBlockSize {
+			return nil, errAvroBlockTooLarge
+		}
+		return decoded, nil
+	case avroCodecSnappy:
+		// the compressed data is followed by the 4-byte, big-endian CRC32 checksum of the uncompressed data.
+		if len(data) < 4 {
+			return nil, errAvroShortBuffer
+		}
+		decodedLen, err := snappy.DecodedLen(data[:len(data)-4])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if decodedLen > avroMaxBlockSize {
+			return nil, errAvroBlockTooLarge
+		}
+		decoded, err := snappy.Decode(nil, data[:len(data)-4])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if crc32.ChecksumIEEE(decoded) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+			return nil, errors.New("avro snappy checksum mismatch")
+		}
+		return decoded, nil
+	case avroCodecZstandard:
+		return p.zstd.DecodeAll(data, nil)
+	default:
+		return data, nil
+	}
+}
+
+// Pos returns the offset of the block which contains the next row, and the row ID before the block.
+func (p *AvroParser) Pos() (pos int64, rowID int64) {
+	return p.pos, p.posRowID
+}
+
+// SetPos sets the offset to the beginning of a block.
+func (p *AvroParser) SetPos(pos int64, rowID int64) error {
+	pos = max(pos, p.headerEnd)
+	if _, err := p.reader.Seek(pos, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	p.buf.Reset(p.reader)
+	p.offset = pos
+	p.pos = pos
+	p.posRowID = rowID
+	p.lastRow.RowID = rowID
+	p.blockRemain = 0
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+func (p *AvroParser) ScannedPos() (int64, error) {
+	return p.reader.Seek(0, io.SeekCurrent)
+}
+
+// Close implements the Parser interface.
+func (p *AvroParser) Close() error {
+	if p.zstd != nil {
+		p.zstd.Close()
+	}
+	return p.reader.Close()
+}
+
+// ReadRow reads a row from the datafile.
+func (p *AvroParser) ReadRow() error {
+	for p.blockRemain == 0 {
+		if err := p.readBlock(); err != nil {
+			return err
+		}
+	}
+
+	row := &p.lastRow
+	row.RowID++
+	row.Row = make([]types.Datum, len(p.schema.fields))
+	start := p.block.off
+	for i, f := range p.schema.fields {
+		if err := p.block.decodeDatum(f.schema, &row.Row[i]); err != nil {
+			return errors.Annotatef(err, "failed to decode avro field '%s' of row %d", f.name, row.RowID)
+		}
+	}
+	row.Length = p.block.off - start
+	p.blockRemain--
+	if p.blockRemain == 0 {
+		if p.block.off != len(p.block.buf) {
+			return errors.Errorf("avro block ending at offset %d has unexpected trailing data", p.offset)
+		}
+		p.pos = p.offset
+		p.posRowID = row.RowID
+	}
+	return nil
+}
+
+// LastRow implements the Parser interface.
+func (p *AvroParser) LastRow() Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*AvroParser) RecycleRow(_ Row) {}
+
+// Columns returns the _lower-case_ field names of the avro schema.
+func (p *AvroParser) Columns() []string {
+	return p.columns
+}
+
+// SetColumns implements the Parser interface.
+func (*AvroParser) SetColumns(_ []string) {
+	// just do nothing
+}
+
+// SetLogger implements the Parser interface.
+func (p *AvroParser) SetLogger(l log.Logger) {
+	p.logger = l
+}
+
+// SetRowID implements the Parser interface.
+func (p *AvroParser) SetRowID(rowID int64) {
+	p.lastRow.RowID = rowID
+	p.posRowID = rowID
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type avroTestWriter struct {
+	bytes.Buffer
+}
+
+func (w *avroTestWriter) long(v int64) *avroTestWriter {
+	u := uint64(v<<1) ^ uint64(v>>63)
+	for u >= 0x80 {
+		w.WriteByte(byte(u) | 0x80)
+		u >>= 7
+	}
+	w.WriteByte(byte(u))
+	return w
+}
+
+func (w *avroTestWriter) bytes(b []byte) *avroTestWriter {
+	w.long(int64(len(b)))
+	w.Write(b)
+	return w
+}
+
+func (w *avroTestWriter) str(s string) *avroTestWriter {
+	return w.bytes([]byte(s))
+}
+
+func (w *avroTestWriter) double(f float64) *avroTestWriter {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	w.Write(b[:])
+	return w
+}
+
+var avroTestSync = []byte("0123456789abcdef")
+
+// makeAvroFile makes an avro object container file, each block is the encoded rows.
+func makeAvroFile(t *testing.T, schema, codec string, blocks [][]byte, counts []int64) []byte {
+	var w avroTestWriter
+	w.WriteString(avroMagic)
+	w.long(2)
+	w.str("avro.schema").str(schema)
+	w.str("avro.codec").str(codec)
+	w.long(0)
+	w.Write(avroTestSync)
+	for i, block := range blocks {
+		data := block
+		switch codec {
+		case avroCodecDeflate:
+			var buf bytes.Buffer
+			fw, err := flate.NewWriter(&buf, flate.BestCompression)
+			require.NoError(t, err)
+			_, err = fw.Write(block)
+			require.NoError(t, err)
+			require.NoError(t, fw.Close())
+			data = buf.Bytes()
+		case avroCodecSnappy:
+			data = snappy.Encode(nil, block)
+			data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(block))
+		case avroCodecZstandard:
+			enc, err := zstd.NewWriter(nil)
+			require.NoError(t, err)
+			data = enc.EncodeAll(block, nil)
+			require.NoError(t, enc.Close())
+		}
+		w.long(counts[i])
+		w.bytes(data)
+		w.Write(avroTestSync)
+	}
+	return w.Bytes()
+}
+
+type avroTestReader struct {
+	*bytes.Reader
+}
+
+func (avroTestReader) Close() error {
+	return nil
+}
+
+const avroTestSchema = `{
+	"type": "record", "name": "Row", "namespace": "test",
+	"fields": [
+		{"name": "ID", "type": "long"},
+		{"name": "name", "type": ["null", "string"]},
+		{"name": "score", "type": "double"},
+		{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+		{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attrs", "type": {"type": "map", "values": "long"}},
+		{"name": "next", "type": ["null", "Row"]}
+	]
+}`
+
+func encodeAvroTestRow(w *avroTestWriter, id int64, name string) {
+	w.long(id)
+	if name == "" {
+		w.long(0)
+	} else {
+		w.long(1).str(name)
+	}
+	w.double(1.5)
+	w.long(19000)
+	w.long(1_700_000_000_123)
+	w.bytes([]byte{0xfe, 0x0c}) // -500
+	w.long(1)
+	w.long(2).str("x").str("y").long(0)
+	w.long(1).str("k").long(7).long(0)
+	// a nested row without next.
+	w.long(1)
+	w.long(id * 10).long(0).double(0).long(0).long(0).bytes(nil).long(0).long(0).long(0).long(0)
+}
+
+func TestAvroParser(t *testing.T) {
+	var block1, block2 avroTestWriter
+	encodeAvroTestRow(&block1, 1, "alice")
+	encodeAvroTestRow(&block1, 2, "")
+	encodeAvroTestRow(&block2, 3, "carol")
+
+	for _, codec := range []string{avroCodecNull, avroCodecDeflate, avroCodecSnappy, avroCodecZstandard} {
+		data := makeAvroFile(t, avroTestSchema, codec, [][]byte{block1.Bytes(), block2.Bytes()}, []int64{2, 1})
+		parser, err := NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+		require.NoError(t, err, codec)
+		require.Equal(t, []string{"id", "name", "score", "day", "ts", "amount", "kind", "tags", "attrs", "next"}, parser.Columns())
+		headerEnd, _ := parser.Pos()
+
+		require.NoError(t, parser.ReadRow())
+		row := parser.LastRow()
+		require.Equal(t, int64(1), row.RowID)
+		require.Equal(t, int64(1), row.Row[0].GetInt64())
+		require.Equal(t, "alice", row.Row[1].GetString())
+		require.Equal(t, 1.5, row.Row[2].GetFloat64())
+		require.Equal(t, "2022-01-08", row.Row[3].GetString())
+		require.Equal(t, "2023-11-14 22:13:20.123Z", row.Row[4].GetString())
+		require.Equal(t, "-5.00", row.Row[5].GetString())
+		require.Equal(t, "B", row.Row[6].GetString())
+		require.Equal(t, `["x","y"]`, row.Row[7].GetString())
+		require.Equal(t, `{"k":7}`, row.Row[8].GetString())
+		require.Contains(t, row.Row[9].GetString(), `"ID":10`)
+		// the block is partially read, so the position is still the beginning of it.
+		pos, rowID := parser.Pos()
+		require.Equal(t, headerEnd, pos)
+		require.Equal(t, int64(0), rowID)
+
+		require.NoError(t, parser.ReadRow())
+		row = parser.LastRow()
+		require.Equal(t, int64(2), row.Row[0].GetInt64())
+		require.True(t, row.Row[1].IsNull())
+		pos, rowID = parser.Pos()
+		require.Greater(t, pos, headerEnd)
+		require.Equal(t, int64(2), rowID)
+
+		// resume from the second block.
+		require.NoError(t, parser.SetPos(pos, rowID))
+		require.NoError(t, parser.ReadRow())
+		row = parser.LastRow()
+		require.Equal(t, int64(3), row.RowID)
+		require.Equal(t, "carol", row.Row[1].GetString())
+		pos, _ = parser.Pos()
+		require.Equal(t, int64(len(data)), pos)
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestAvroParserInvalid(t *testing.T) {
+	_, err := NewAvroParser(context.Background(), avroTestReader{bytes.NewReader([]byte("not avro"))})
+	require.ErrorContains(t, err, "not an avro object container file")
+
+	data := makeAvroFile(t, `"long"`, avroCodecNull, nil, nil)
+	_, err = NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+	require.ErrorContains(t, err, "should be a record")
+
+	data = makeAvroFile(t, avroTestSchema, "bzip2", nil, nil)
+	_, err = NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+	require.ErrorContains(t, err, "unsupported avro codec")
+
+	var block avroTestWriter
+	encodeAvroTestRow(&block, 1, "alice")
+	data = makeAvroFile(t, avroTestSchema, avroCodecNull, [][]byte{block.Bytes()}, []int64{1})
+	// corrupt the sync marker of the block
+	data[len(data)-1] = 'x'
+	parser, err := NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "sync marker mismatch")
+	require.NoError(t, parser.Close())
+
+	// the object count of the block can't be negative
+	data = makeAvroFile(t, avroTestSchema, avroCodecNull, [][]byte{block.Bytes()}, []int64{-1})
+	parser, err = NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "invalid avro block object count -1")
+	require.NoError(t, parser.Close())
+
+	// the size of the block is limited before and after it's decompressed
+	defer func(size int) {
+		avroMaxBlockSize = size
+	}(avroMaxBlockSize)
+	avroMaxBlockSize = 64
+	large := bytes.Repeat([]byte{0}, 65)
+	data = makeAvroFile(t, avroTestSchema, avroCodecNull, [][]byte{large}, []int64{1})
+	parser, err = NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "invalid avro block size 65")
+	require.NoError(t, parser.Close())
+	for _, codec := range []string{avroCodecDeflate, avroCodecSnappy, avroCodecZstandard} {
+		data = makeAvroFile(t, avroTestSchema, codec, [][]byte{large}, []int64{1})
+		parser, err = NewAvroParser(context.Background(), avroTestReader{bytes.NewReader(data)})
+		require.NoError(t, err)
+		require.ErrorContains(t, parser.ReadRow(), "failed to decompress avro block", codec)
+		require.NoError(t, parser.Close())
+	}
+}
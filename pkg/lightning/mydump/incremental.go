@@ -0,0 +1,77 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+)
+
+// IncrementalManifestFile is the manifest written by the incremental dump of Dumpling. An incremental dump contains
+// the rows changed since the previous dump in the data files, and the rows deleted since then in the deletes files.
+const IncrementalManifestFile = "incremental.json"
+
+// GetDeleteStatements gets the DELETE statements in the deletes files of the table, the target table of them is
+// rewritten to the table of the meta, so they can be executed after routing.
+func (m *MDTableMeta) GetDeleteStatements(ctx context.Context, store storage.ExternalStorage, p *parser.Parser) ([]string, error) {
+	var (
+		res []string
+		sb  strings.Builder
+	)
+	restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreStringWithoutCharset, &sb)
+	for _, f := range m.DeleteFiles {
+		data, err := ExportStatement(ctx, store, f, m.charSet)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		stmts, _, err := p.ParseSQL(string(data))
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to parse deletes file %s", f.FileMeta.Path)
+		}
+		for _, stmt := range stmts {
+			tblName := getDeleteTableName(stmt)
+			if tblName == nil {
+				return nil, errors.Errorf("unexpected statement in deletes file %s, only single table DELETE is allowed", f.FileMeta.Path)
+			}
+			tblName.Schema = ast.NewCIStr(m.DB)
+			tblName.Name = ast.NewCIStr(m.Name)
+			sb.Reset()
+			if err = stmt.Restore(restoreCtx); err != nil {
+				return nil, errors.Trace(err)
+			}
+			res = append(res, sb.String())
+		}
+	}
+	return res, nil
+}
+
+func getDeleteTableName(stmt ast.StmtNode) *ast.TableName {
+	del, ok := stmt.(*ast.DeleteStmt)
+	if !ok || del.IsMultiTable || del.TableRefs == nil || del.TableRefs.TableRefs == nil || del.TableRefs.TableRefs.Right != nil {
+		return nil
+	}
+	ts, ok := del.TableRefs.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	tblName, _ := ts.Source.(*ast.TableName)
+	return tblName
+}
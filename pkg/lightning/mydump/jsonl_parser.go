@@ -0,0 +1,405 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/lightning/metric"
+	"github.com/pingcap/tidb/pkg/lightning/worker"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// JSONLConfig is the config of the JSON Lines parser.
+type JSONLConfig struct {
+	// Columns is the names of the values in each row. If it's empty, the columns are the top-level keys of the
+	// first row in the file, and the keys are matched by the order they appear.
+	Columns []string
+	// Paths maps a column to the path of its value in the JSON object, such as `$.user.name` or `$.tags[0]`.
+	// The column without a path is mapped to the top-level key with the same name case-insensitively.
+	Paths map[string]string
+}
+
+// jsonPathLeg is a leg of the path to extract a value from the JSON object. It's either an object key or an
+// array index.
+type jsonPathLeg struct {
+	key   string
+	index int
+}
+
+// ParseJSONLPaths parses the column paths in the form of `col1=$.a.b,col2=$.c[0]`.
+func ParseJSONLPaths(spec string) (map[string]string, error) {
+	paths := make(map[string]string)
+	for _, item := range strings.Split(spec, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		col, path, ok := strings.Cut(item, "=")
+		col = strings.TrimSpace(col)
+		if !ok || col == "" {
+			return nil, errors.Errorf("invalid JSON path mapping '%s', it should be like 'column=$.path'", item)
+		}
+		if _, err := parseJSONLPath(path); err != nil {
+			return nil, err
+		}
+		paths[col] = strings.TrimSpace(path)
+	}
+	return paths, nil
+}
+
+// parseJSONLPath parses the path to extract a value from the JSON object. Only the simple path like `$.a.b[1]`
+// is supported, the wildcards are not supported. `$` means the whole object.
+func parseJSONLPath(path string) ([]jsonPathLeg, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.Errorf("invalid JSON path '%s', it should start with '$'", path)
+	}
+	legs := []jsonPathLeg{}
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			var key string
+			if strings.HasPrefix(rest, `"`) {
+				end := strings.IndexByte(rest[1:], '"')
+				if end < 0 {
+					return nil, errors.Errorf("invalid JSON path '%s', unclosed quote", path)
+				}
+				key, rest = rest[1:end+1], rest[end+2:]
+			} else {
+				end := strings.IndexAny(rest, ".[")
+				if end < 0 {
+					end = len(rest)
+				}
+				key, rest = rest[:end], rest[end:]
+			}
+			if key == "" || key == "*" {
+				return nil, errors.Errorf("invalid JSON path '%s', empty or wildcard key", path)
+			}
+			legs = append(legs, jsonPathLeg{key: key, index: -1})
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, errors.Errorf("invalid JSON path '%s', unclosed bracket", path)
+			}
+			idx, err := strconv.Atoi(strings.TrimSpace(rest[1:end]))
+			if err != nil || idx < 0 {
+				return nil, errors.Errorf("invalid JSON path '%s', bad array index", path)
+			}
+			legs = append(legs, jsonPathLeg{index: idx})
+			rest = rest[end+1:]
+		default:
+			return nil, errors.Errorf("invalid JSON path '%s'", path)
+		}
+	}
+	return legs, nil
+}
+
+// JSONLParser parses a JSON Lines file, each line of which is a JSON object. The offset of the parser is always the
+// beginning of a line, so a large file can be split into multiple chunks at any line terminator.
+// It implements the Parser interface.
+type JSONLParser struct {
+	blockParser
+
+	// paths is the extraction path of each column, nil means the column is mapped to the top-level key with the
+	// same name.
+	paths [][]jsonPathLeg
+	// discoverColumns is true if the columns are not specified, they are read from the first row.
+	discoverColumns bool
+
+	keys   []string
+	values []json.RawMessage
+}
+
+// NewJSONLParser creates a JSON Lines parser. The ownership of the reader is transferred to the parser.
+func NewJSONLParser(
+	ctx context.Context,
+	cfg *JSONLConfig,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+) (*JSONLParser, error) {
+	metrics, _ := metric.FromContext(ctx)
+	parser := &JSONLParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+	}
+	if cfg == nil || len(cfg.Columns) == 0 {
+		parser.discoverColumns = true
+		return parser, nil
+	}
+
+	parser.columns = make([]string, 0, len(cfg.Columns))
+	parser.paths = make([][]jsonPathLeg, 0, len(cfg.Columns))
+	for _, col := range cfg.Columns {
+		var legs []jsonPathLeg
+		for name, path := range cfg.Paths {
+			if !strings.EqualFold(name, col) {
+				continue
+			}
+			var err error
+			if legs, err = parseJSONLPath(path); err != nil {
+				return nil, err
+			}
+			break
+		}
+		parser.columns = append(parser.columns, strings.ToLower(col))
+		parser.paths = append(parser.paths, legs)
+	}
+	return parser, nil
+}
+
+// readLine reads the next line without the line terminator.
+func (parser *JSONLParser) readLine() ([]byte, error) {
+	for {
+		if idx := bytes.IndexByte(parser.buf, '\n'); idx >= 0 {
+			line := parser.buf[:idx]
+			parser.buf = parser.buf[idx+1:]
+			parser.pos += int64(idx + 1)
+			return line, nil
+		}
+		if parser.isLastChunk {
+			if len(parser.buf) == 0 {
+				return nil, io.EOF
+			}
+			line := parser.buf
+			parser.buf = nil
+			parser.pos += int64(len(line))
+			return line, nil
+		}
+		if len(parser.buf) > LargestEntryLimit {
+			return nil, errors.New("size of row cannot exceed the max value of txn-entry-size-limit")
+		}
+		if err := parser.readBlock(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+}
+
+// ReadUntilTerminator skips the bytes until the next line terminator, and returns the offset beyond it.
+// It's used to split a large file into chunks.
+func (parser *JSONLParser) ReadUntilTerminator() (int64, error) {
+	_, err := parser.readLine()
+	return parser.pos, err
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *JSONLParser) ReadRow() error {
+	var line []byte
+	for {
+		var err error
+		line, err = parser.readLine()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			break
+		}
+	}
+
+	if err := parser.decodeObject(line); err != nil {
+		return errors.Annotatef(err, "failed to parse JSON line ending at offset %d", parser.pos)
+	}
+	if parser.discoverColumns && parser.columns == nil {
+		parser.columns = make([]string, 0, len(parser.keys))
+		parser.paths = make([][]jsonPathLeg, len(parser.keys))
+		for _, key := range parser.keys {
+			parser.columns = append(parser.columns, strings.ToLower(key))
+		}
+	}
+
+	row := &parser.lastRow
+	row.RowID++
+	row.Length = len(line)
+	row.Row = parser.acquireDatumSlice()
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	for i, col := range parser.columns {
+		raw, err := parser.extract(line, col, parser.paths[i])
+		if err != nil {
+			return errors.Annotatef(err, "failed to extract column '%s' at offset %d", col, parser.pos)
+		}
+		if err := setDatumByJSON(&row.Row[i], raw); err != nil {
+			return errors.Annotatef(err, "failed to parse column '%s' at offset %d", col, parser.pos)
+		}
+	}
+	return nil
+}
+
+// SetColumns sets the columns, each of which keeps the path configured before, or is mapped to the top-level key
+// with the same name. It's used when resuming from a checkpoint, the columns are the ones read from the first
+// row before.
+func (parser *JSONLParser) SetColumns(columns []string) {
+	paths := make([][]jsonPathLeg, len(columns))
+	for i, col := range columns {
+		for j, c := range parser.columns {
+			if strings.EqualFold(c, col) {
+				paths[i] = parser.paths[j]
+				break
+			}
+		}
+	}
+	parser.columns = columns
+	parser.paths = paths
+	parser.discoverColumns = false
+}
+
+// decodeObject decodes the top-level keys and values of the object, the order of the keys is kept.
+func (parser *JSONLParser) decodeObject(line []byte) error {
+	parser.keys = parser.keys[:0]
+	parser.values = parser.values[:0]
+	dec := json.NewDecoder(bytes.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.New("the line is not a JSON object")
+	}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		//nolint: forcetypeassert
+		key := tok.(string)
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return errors.Trace(err)
+		}
+		parser.keys = append(parser.keys, key)
+		parser.values = append(parser.values, value)
+	}
+	if _, err = dec.Token(); err != nil {
+		return errors.Trace(err)
+	}
+	if dec.More() {
+		return errors.New("unexpected content after the JSON object")
+	}
+	return nil
+}
+
+// lookupKey returns the value of the top-level key, the key is matched exactly first and then case-insensitively.
+// If the key is duplicated, the last one is used. It returns nil if the key doesn't exist.
+func (parser *JSONLParser) lookupKey(col string) json.RawMessage {
+	var found json.RawMessage
+	for i := len(parser.keys) - 1; i >= 0; i-- {
+		if parser.keys[i] == col {
+			return parser.values[i]
+		}
+		if found == nil && strings.EqualFold(parser.keys[i], col) {
+			found = parser.values[i]
+		}
+	}
+	return found
+}
+
+// extract returns the value of the column in the line. It returns nil if the value doesn't exist.
+func (parser *JSONLParser) extract(line []byte, col string, legs []jsonPathLeg) (json.RawMessage, error) {
+	if legs == nil {
+		return parser.lookupKey(col), nil
+	}
+	if len(legs) == 0 {
+		return line, nil
+	}
+	if legs[0].index >= 0 {
+		// the line is an object rather than an array.
+		return nil, nil
+	}
+	raw := parser.lookupKey(legs[0].key)
+	if raw == nil {
+		return nil, nil
+	}
+	return extractJSONPath(raw, legs[1:])
+}
+
+// extractJSONPath extracts the value by the path legs. It returns nil if the path doesn't exist.
+func extractJSONPath(raw json.RawMessage, legs []jsonPathLeg) (json.RawMessage, error) {
+	for _, leg := range legs {
+		if leg.index < 0 {
+			var obj map[string]json.RawMessage
+			if len(raw) == 0 || raw[0] != '{' {
+				return nil, nil
+			}
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return nil, errors.Trace(err)
+			}
+			v, ok := obj[leg.key]
+			if !ok {
+				for k, kv := range obj {
+					if strings.EqualFold(k, leg.key) {
+						v, ok = kv, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil, nil
+			}
+			raw = v
+			continue
+		}
+		var arr []json.RawMessage
+		if len(raw) == 0 || raw[0] != '[' {
+			return nil, nil
+		}
+		if err := json.Unmarshal(raw, &arr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if leg.index >= len(arr) {
+			return nil, nil
+		}
+		raw = arr[leg.index]
+	}
+	return raw, nil
+}
+
+// setDatumByJSON converts a JSON value to datum. The strings are unquoted, the numbers keep their text to avoid
+// losing precision, and the objects and arrays are kept as JSON text, so they can be imported into JSON columns.
+func setDatumByJSON(d *types.Datum, raw json.RawMessage) error {
+	if len(raw) == 0 {
+		d.SetNull()
+		return nil
+	}
+	switch raw[0] {
+	case 'n':
+		d.SetNull()
+	case 't':
+		d.SetInt64(1)
+	case 'f':
+		d.SetInt64(0)
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(s, "utf8mb4_bin")
+	default:
+		// numbers, objects and arrays
+		d.SetString(string(raw), "utf8mb4_bin")
+	}
+	return nil
+}
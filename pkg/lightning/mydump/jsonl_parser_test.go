@@ -0,0 +1,204 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func readAllJSONLRows(t *testing.T, parser Parser) [][]types.Datum {
+	var rows [][]types.Datum
+	for {
+		err := parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, append([]types.Datum{}, parser.LastRow().Row...))
+	}
+}
+
+func TestJSONLParserDiscoverColumns(t *testing.T) {
+	input := `{"ID": 1, "name": "alice", "ok": true, "score": 1.50, "tags": ["a","b"]}
+
+{"name": "bob", "id": 2, "ok": false, "score": null}
+{"id": 3}`
+	parser, err := NewJSONLParser(context.Background(), nil, NewStringReader(input), int64(config.ReadBlockSize), nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, parser.Close())
+	}()
+
+	rows := readAllJSONLRows(t, parser)
+	require.Equal(t, []string{"id", "name", "ok", "score", "tags"}, parser.Columns())
+	require.Len(t, rows, 3)
+	require.Equal(t, "alice", rows[0][1].GetString())
+	require.Equal(t, int64(1), rows[0][2].GetInt64())
+	// numbers keep their text to avoid losing precision.
+	require.Equal(t, "1.50", rows[0][3].GetString())
+	require.Equal(t, `["a","b"]`, rows[0][4].GetString())
+	require.Equal(t, "1", rows[0][0].GetString())
+	// keys are matched by name rather than order.
+	require.Equal(t, "2", rows[1][0].GetString())
+	require.Equal(t, "bob", rows[1][1].GetString())
+	require.Equal(t, int64(0), rows[1][2].GetInt64())
+	require.True(t, rows[1][3].IsNull())
+	require.True(t, rows[1][4].IsNull())
+	require.Equal(t, "3", rows[2][0].GetString())
+	require.True(t, rows[2][1].IsNull())
+
+	pos, rowID := parser.Pos()
+	require.Equal(t, int64(len(input)), pos)
+	require.Equal(t, int64(3), rowID)
+}
+
+func TestJSONLParserPaths(t *testing.T) {
+	paths, err := ParseJSONLPaths(`a=$.user.name, b=$.tags[1], c=$."x.y", d=$`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "$.user.name", "b": "$.tags[1]", "c": `$."x.y"`, "d": "$"}, paths)
+
+	for _, spec := range []string{"a", "a=user", "a=$.", "a=$.*", "a=$[x]", "a=$.b[1", `a=$."b`} {
+		_, err = ParseJSONLPaths(spec)
+		require.Error(t, err, spec)
+	}
+
+	cfg := &JSONLConfig{Columns: []string{"a", "b", "C", "d", "id"}, Paths: paths}
+	input := `{"id": 1, "user": {"Name": "alice"}, "tags": ["x", {"k": 1}], "x.y": "dot"}` + "\n" +
+		`{"id": 2, "user": "bob", "tags": []}` + "\n"
+	parser, err := NewJSONLParser(context.Background(), cfg, NewStringReader(input), int64(config.ReadBlockSize), nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, parser.Close())
+	}()
+	rows := readAllJSONLRows(t, parser)
+	require.Equal(t, []string{"a", "b", "c", "d", "id"}, parser.Columns())
+	require.Len(t, rows, 2)
+	require.Equal(t, "alice", rows[0][0].GetString())
+	require.Equal(t, `{"k": 1}`, rows[0][1].GetString())
+	require.Equal(t, "dot", rows[0][2].GetString())
+	require.Equal(t, `{"id": 1, "user": {"Name": "alice"}, "tags": ["x", {"k": 1}], "x.y": "dot"}`, rows[0][3].GetString())
+	require.Equal(t, "1", rows[0][4].GetString())
+	for i := range 3 {
+		require.True(t, rows[1][i].IsNull(), i)
+	}
+	require.Equal(t, "2", rows[1][4].GetString())
+}
+
+func TestJSONLParserSetPos(t *testing.T) {
+	input := "{\"a\": 1}\n{\"a\": 2}\n{\"a\": 3}\n"
+	parser, err := NewJSONLParser(context.Background(), &JSONLConfig{Columns: []string{"a"}}, NewStringReader(input), 4, nil)
+	require.NoError(t, err)
+	require.NoError(t, parser.SetPos(4, 0))
+	pos, err := parser.ReadUntilTerminator()
+	require.NoError(t, err)
+	require.Equal(t, int64(9), pos)
+	require.NoError(t, parser.Close())
+
+	parser, err = NewJSONLParser(context.Background(), &JSONLConfig{Columns: []string{"a"}}, NewStringReader(input), 4, nil)
+	require.NoError(t, err)
+	require.NoError(t, parser.SetPos(9, 10))
+	rows := readAllJSONLRows(t, parser)
+	require.Len(t, rows, 2)
+	require.Equal(t, "2", rows[0][0].GetString())
+	require.Equal(t, "3", rows[1][0].GetString())
+	require.Equal(t, int64(12), parser.LastRow().RowID)
+	require.NoError(t, parser.Close())
+}
+
+func TestJSONLParserSetColumns(t *testing.T) {
+	input := "{\"user\": {\"name\": \"alice\"}, \"id\": 1}\n"
+	cfg := &JSONLConfig{Columns: []string{"id", "Name"}, Paths: map[string]string{"name": "$.user.name"}}
+	parser, err := NewJSONLParser(context.Background(), cfg, NewStringReader(input), int64(config.ReadBlockSize), nil)
+	require.NoError(t, err)
+	// the columns of the checkpoint keep the configured paths.
+	parser.SetColumns([]string{"name", "id"})
+	rows := readAllJSONLRows(t, parser)
+	require.Len(t, rows, 1)
+	require.Equal(t, "alice", rows[0][0].GetString())
+	require.Equal(t, "1", rows[0][1].GetString())
+	require.NoError(t, parser.Close())
+}
+
+func TestJSONLParserInvalid(t *testing.T) {
+	for _, input := range []string{
+		`[1, 2]`,
+		`{"a": 1`,
+		`{"a": 1} {"a": 2}`,
+		`"abc"`,
+	} {
+		parser, err := NewJSONLParser(context.Background(), nil, NewStringReader(input), int64(config.ReadBlockSize), nil)
+		require.NoError(t, err)
+		require.Error(t, parser.ReadRow(), input)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestSplitLargeJSONL(t *testing.T) {
+	dir := t.TempDir()
+	var sb strings.Builder
+	for i := range 10 {
+		sb.WriteString(`{"a": `)
+		sb.WriteByte(byte('0' + i))
+		sb.WriteString("}\n")
+	}
+	// each line is 9 bytes.
+	content := sb.String()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "t.jsonl"), []byte(content), 0o644))
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	meta := &MDTableMeta{DB: "db", Name: "t"}
+	cfg := &config.Config{Mydumper: config.MydumperRuntime{ReadBlockSize: config.ReadBlockSize}}
+	divideConfig := NewDataDivideConfig(cfg, 1, nil, store, meta)
+	fileInfo := FileInfo{FileMeta: SourceFileMeta{Path: "t.jsonl", Type: SourceTypeJSONL, FileSize: int64(len(content))}}
+	for _, tc := range []struct {
+		maxRegionSize int64
+		offsets       [][]int64
+	}{
+		{20, [][]int64{{0, 27}, {27, 54}, {54, 81}, {81, 90}}},
+		{27, [][]int64{{0, 36}, {36, 72}, {72, 90}}},
+		{100, [][]int64{{0, 90}}},
+	} {
+		divideConfig.MaxChunkSize = tc.maxRegionSize
+		regions, _, err := SplitLargeJSONL(context.Background(), divideConfig, fileInfo)
+		require.NoError(t, err)
+		require.Len(t, regions, len(tc.offsets))
+		var prevRowIDMax int64
+		for i := range tc.offsets {
+			require.Equal(t, tc.offsets[i][0], regions[i].Chunk.Offset)
+			require.Equal(t, tc.offsets[i][1], regions[i].Chunk.EndOffset)
+			require.Equal(t, prevRowIDMax, regions[i].Chunk.PrevRowIDMax)
+			prevRowIDMax = regions[i].Chunk.RowIDMax
+		}
+	}
+
+	// the large file is split by MakeTableRegions even if the format is not strict.
+	meta.DataFiles = []FileInfo{fileInfo}
+	divideConfig.MaxChunkSize = 20
+	regions, err := MakeTableRegions(context.Background(), divideConfig)
+	require.NoError(t, err)
+	require.Len(t, regions, 4)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -86,6 +87,9 @@ type MDTableMeta struct {
 	IndexRatio float64
 	// default to true, and if we do precheck, this var is updated using data sampling result, so it's not accurate.
 	IsRowOrdered bool
+	// DeleteFiles are the deletes files of the incremental dump of Dumpling, which are applied by SQL before importing
+	// the data files.
+	DeleteFiles []FileInfo
 }
 
 // SourceFileMeta contains some analyzed metadata for a source file by MyDumper Loader.
@@ -263,6 +267,7 @@ type mdLoaderSetup struct {
 	tableSchemas  []FileInfo
 	viewSchemas   []FileInfo
 	tableDatas    []FileInfo
+	tableDeletes  []FileInfo
 	dbIndexMap    map[string]int
 	tableIndexMap map[filter.Table]int
 	setupCfg      *MDLoaderSetupConfig
@@ -499,8 +504,10 @@ func (s *mdLoaderSetup) setup(ctx context.Context) error {
 			s.tableSchemas = append(s.tableSchemas, *info)
 		case SourceTypeViewSchema:
 			s.viewSchemas = append(s.viewSchemas, *info)
-		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet:
+		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet, SourceTypeJSONL, SourceTypeAvro:
 			s.tableDatas = append(s.tableDatas, *info)
+		case SourceTypeDeletes:
+			s.tableDeletes = append(s.tableDeletes, *info)
 		}
 	}
 
@@ -545,6 +552,10 @@ func (s *mdLoaderSetup) setup(ctx context.Context) error {
 		tableMeta.DataFiles = append(tableMeta.DataFiles, fileInfo)
 		tableMeta.TotalSize += fileInfo.FileMeta.RealSize
 	}
+	for _, fileInfo := range s.tableDeletes {
+		tableMeta, _, _ := s.insertTable(FileInfo{TableName: fileInfo.TableName})
+		tableMeta.DeleteFiles = append(tableMeta.DeleteFiles, fileInfo)
+	}
 
 	for _, dbMeta := range s.loader.dbs {
 		// Put the small table in the front of the slice which can avoid large table
@@ -620,7 +631,7 @@ func (s *mdLoaderSetup) constructFileInfo(ctx context.Context, f RawFile) (*File
 	}
 
 	switch res.Type {
-	case SourceTypeSQL, SourceTypeCSV:
+	case SourceTypeSQL, SourceTypeCSV, SourceTypeJSONL, SourceTypeAvro:
 		info.FileMeta.RealSize = EstimateRealSizeForFile(ctx, info.FileMeta, s.loader.GetStore())
 	case SourceTypeParquet:
 		var (
@@ -705,7 +716,7 @@ func (s *mdLoaderSetup) route() error {
 		}
 		knownDBNames[info.TableName.Schema].count++
 	}
-	for _, info := range s.tableDatas {
+	for _, info := range slices.Concat(s.tableDatas, s.tableDeletes) {
 		if _, ok := knownDBNames[info.TableName.Schema]; !ok {
 			knownDBNames[info.TableName.Schema] = &dbInfo{
 				fileMeta: info.FileMeta,
@@ -761,6 +772,9 @@ func (s *mdLoaderSetup) route() error {
 	if err := runRoute(s.tableDatas); err != nil {
 		return errors.Trace(err)
 	}
+	if err := runRoute(s.tableDeletes); err != nil {
+		return errors.Trace(err)
+	}
 	// remove all schemas which has been entirely routed away(file count > 0)
 	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
 	remainingSchemas := s.dbSchemas[:0]
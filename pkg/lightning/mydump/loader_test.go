@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/lightning/config"
 	"github.com/pingcap/tidb/pkg/lightning/log"
 	md "github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/parser"
 	filter "github.com/pingcap/tidb/pkg/util/table-filter"
 	router "github.com/pingcap/tidb/pkg/util/table-router"
 	"github.com/stretchr/testify/assert"
@@ -338,6 +339,53 @@ func TestDataWithoutSchema(t *testing.T) {
 	}}, mdl.GetDatabases())
 }
 
+func TestIncrementalDeletes(t *testing.T) {
+	s := newTestMydumpLoaderSuite(t)
+	s.cfg.Mydumper.CharacterSet = "auto"
+	s.cfg.Routes = []*router.TableRule{{
+		SchemaPattern: "db",
+		TargetSchema:  "db2",
+	}, {
+		SchemaPattern: "db",
+		TablePattern:  "tbl",
+		TargetSchema:  "db2",
+		TargetTable:   "tbl2",
+	}}
+	s.touch(t, "db-schema-create.sql")
+	s.touch(t, "db.tbl-schema.sql")
+	s.touch(t, "db.tbl.000000000.sql")
+	require.NoError(t, os.WriteFile(filepath.Join(s.sourceDir, "db.tbl-deletes.sql"), []byte(
+		"/*!40101 SET NAMES binary*/;\n"+
+			"DELETE FROM `tbl` WHERE (`a`,`b`) IN (\n(1,'x'),\n(2,'y''s'));\n"+
+			"DELETE FROM `tbl` WHERE (`a`,`b`) IN (\n(3,'z'));\n"), 0o644))
+
+	mdl, err := md.NewLoader(context.Background(), md.NewLoaderCfg(s.cfg))
+	require.NoError(t, err)
+	dbs := mdl.GetDatabases()
+	require.Len(t, dbs, 1)
+	require.Len(t, dbs[0].Tables, 1)
+	tbl := dbs[0].Tables[0]
+	require.Equal(t, "db2", tbl.DB)
+	require.Equal(t, "tbl2", tbl.Name)
+	require.Len(t, tbl.DataFiles, 1)
+	require.Equal(t, []md.FileInfo{{
+		TableName: filter.Table{Schema: "db2", Name: "tbl2"},
+		FileMeta:  md.SourceFileMeta{Path: "db.tbl-deletes.sql", Type: md.SourceTypeDeletes, FileSize: 139, RealSize: 139},
+	}}, tbl.DeleteFiles)
+
+	stmts, err := tbl.GetDeleteStatements(context.Background(), mdl.GetStore(), parser.New())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"DELETE FROM `db2`.`tbl2` WHERE ROW(`a`,`b`) IN (ROW(1,'x'),ROW(2,'y''s'))",
+		"DELETE FROM `db2`.`tbl2` WHERE ROW(`a`,`b`) IN (ROW(3,'z'))",
+	}, stmts)
+
+	require.NoError(t, os.WriteFile(filepath.Join(s.sourceDir, "db.tbl-deletes.sql"), []byte(
+		"DELETE FROM `tbl` WHERE `a` = 1;\nDROP TABLE `tbl`;\n"), 0o644))
+	_, err = tbl.GetDeleteStatements(context.Background(), mdl.GetStore(), parser.New())
+	require.ErrorContains(t, err, "unexpected statement in deletes file db.tbl-deletes.sql")
+}
+
 func TestTablesWithDots(t *testing.T) {
 	s := newTestMydumpLoaderSuite(t)
 
@@ -249,6 +249,11 @@ func MakeTableRegions(
 				// avoid split a lot of small chunks.
 				// If a csv file is compressed, we can't split it now because we can't get the exact size of a row.
 				regions, sizes, err = SplitLargeCSV(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeJSONL &&
+				info.FileMeta.Compression == CompressionNone &&
+				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
+				// Each line of a JSON Lines file is a complete row, so it can always be split at line terminators.
+				regions, sizes, err = SplitLargeJSONL(egCtx, cfg, info)
 			} else {
 				regions, sizes, err = MakeSourceFileRegion(egCtx, cfg, info)
 			}
@@ -498,3 +503,69 @@ func SplitLargeCSV(
 	}
 	return regions, dataFileSizes, nil
 }
+
+// SplitLargeJSONL splits a large JSON Lines file into multiple regions, the size
+// of each regions is specified by `config.MaxRegionSize`. Unlike CSV, every line
+// of the file is a complete row, so the file doesn't need to be in strict format.
+func SplitLargeJSONL(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) (regions []*TableRegion, dataFileSizes []float64, err error) {
+	maxRegionSize := cfg.MaxChunkSize
+	fileSize := dataFile.FileMeta.FileSize
+	dataFileSizes = make([]float64, 0, fileSize/maxRegionSize+1)
+	startOffset, endOffset := int64(0), min(maxRegionSize, fileSize)
+	var prevRowIdxMax int64
+	divisor := int64(cfg.ColumnCnt) + 2
+	for {
+		curRowsCnt := (endOffset - startOffset) / divisor
+		rowIDMax := prevRowIdxMax + curRowsCnt
+		if endOffset != fileSize {
+			r, err := cfg.Store.Open(ctx, dataFile.FileMeta.Path, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			// the parser only looks for the line terminator, so the columns aren't needed.
+			parser, err := NewJSONLParser(ctx, nil, r, cfg.ReadBlockSize, cfg.IOWorkers)
+			if err != nil {
+				_ = r.Close()
+				return nil, nil, err
+			}
+			if err = parser.SetPos(endOffset, 0); err != nil {
+				_ = parser.Close()
+				return nil, nil, err
+			}
+			pos, err := parser.ReadUntilTerminator()
+			if err != nil {
+				if !errors.ErrorEqual(err, io.EOF) {
+					_ = parser.Close()
+					return nil, nil, err
+				}
+				pos = fileSize
+			}
+			endOffset = min(pos, fileSize)
+			_ = parser.Close()
+		}
+		regions = append(regions,
+			&TableRegion{
+				DB:       cfg.TableMeta.DB,
+				Table:    cfg.TableMeta.Name,
+				FileMeta: dataFile.FileMeta,
+				Chunk: Chunk{
+					Offset:       startOffset,
+					EndOffset:    endOffset,
+					PrevRowIDMax: prevRowIdxMax,
+					RowIDMax:     rowIDMax,
+				},
+			})
+		dataFileSizes = append(dataFileSizes, float64(endOffset-startOffset))
+		prevRowIdxMax = rowIDMax
+		if endOffset == fileSize {
+			break
+		}
+		startOffset = endOffset
+		endOffset = min(endOffset+maxRegionSize, fileSize)
+	}
+	return regions, dataFileSizes, nil
+}
@@ -48,6 +48,13 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeJSONL means this source file is a JSON Lines data file.
+	SourceTypeJSONL
+	// SourceTypeAvro means this source file is an avro object container file.
+	SourceTypeAvro
+	// SourceTypeDeletes means this source file contains the DELETE statements of the rows deleted since the previous
+	// dump, which is written by the incremental dump of Dumpling.
+	SourceTypeDeletes
 )
 
 const (
@@ -63,8 +70,16 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeJSONL is the source type value for JSON Lines data file.
+	TypeJSONL = "jsonl"
+	// TypeNDJSON is an alias of TypeJSONL.
+	TypeNDJSON = "ndjson"
+	// TypeAvro is the source type value for avro data file.
+	TypeAvro = "avro"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
+	// TypeDeletes is the source type value for the deletes file of an incremental dump.
+	TypeDeletes = "deletes"
 )
 
 // Compression specifies the compression type.
@@ -116,10 +131,16 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSONL, TypeNDJSON:
+		return SourceTypeJSONL, nil
+	case TypeAvro:
+		return SourceTypeAvro, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
 		return SourceTypeViewSchema, nil
+	case TypeDeletes:
+		return SourceTypeDeletes, nil
 	default:
 		return SourceTypeIgnore, errors.Errorf("unknown source type '%s'", t)
 	}
@@ -137,8 +158,14 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSONL:
+		return TypeJSONL
+	case SourceTypeAvro:
+		return TypeAvro
 	case SourceTypeViewSchema:
 		return ViewSchema
+	case SourceTypeDeletes:
+		return TypeDeletes
 	default:
 		return TypeIgnore
 	}
@@ -185,7 +212,7 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// ignore *-schema-trigger.sql, *-schema-post.sql files
 	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql(?:\.(\w*?))?$`, Type: "ignore"},
 	// ignore backup files
-	{Pattern: `(?i).*\.(sql|csv|parquet)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
+	{Pattern: `(?i).*\.(sql|csv|parquet|jsonl|ndjson|avro)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
 	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
@@ -195,8 +222,11 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
+	// deletes file pattern of dumpling incremental dump, matches files like '{schema}.{table}-deletes.sql[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-deletes\.sql(?:\.(\w*?))?$`,
+		Schema: "$1", Table: "$2", Type: TypeDeletes, Compression: "$3", Unescape: true},
 	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`,
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|jsonl|ndjson|avro)(?:\.(\w+))?$`,
 		Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
@@ -361,6 +391,9 @@ func (p regexRouterParser) Parse(r *config.FileRouteRule, logger log.Logger) (*R
 			if result.Type == SourceTypeParquet && compression != CompressionNone {
 				return errors.Errorf("can't support whole compressed parquet file, should compress parquet files by choosing correct parquet compress writer, path: %s", r.Path)
 			}
+			if result.Type == SourceTypeAvro && compression != CompressionNone {
+				return errors.Errorf("can't support whole compressed avro file, should compress avro files by choosing correct avro codec, path: %s", r.Path)
+			}
 			result.Compression = compression
 			return nil
 		})
@@ -90,6 +90,8 @@ var (
 	mPolicyPrefix        = "Policy"
 	mResourceGroups      = []byte("ResourceGroups")
 	mResourceGroupPrefix = "RG"
+	mRoutinePrefix       = "Routine"
+	mEventPrefix         = "Event"
 	mPolicyGlobalID      = []byte("PolicyGlobalID")
 	mPolicyMagicByte     = CurrentMagicByteVer
 	mDDLTableVersion     = []byte("DDLTableVersion")
@@ -146,6 +148,14 @@ var (
 	ErrResourceGroupExists = dbterror.ClassMeta.NewStd(errno.ErrResourceGroupExists)
 	// ErrResourceGroupNotExists is the error for resource group not exists.
 	ErrResourceGroupNotExists = dbterror.ClassMeta.NewStd(errno.ErrResourceGroupNotExists)
+	// ErrRoutineExists is the error for stored routine exists.
+	ErrRoutineExists = dbterror.ClassMeta.NewStd(errno.ErrSpAlreadyExists)
+	// ErrRoutineNotExists is the error for stored routine not exists.
+	ErrRoutineNotExists = dbterror.ClassMeta.NewStd(errno.ErrSpDoesNotExist)
+	// ErrEventExists is the error for event exists.
+	ErrEventExists = dbterror.ClassMeta.NewStd(errno.ErrEventAlreadyExists)
+	// ErrEventNotExists is the error for event not exists.
+	ErrEventNotExists = dbterror.ClassMeta.NewStd(errno.ErrEventDoesNotExist)
 	// ErrTableExists is the error for table exists.
 	ErrTableExists = dbterror.ClassMeta.NewStd(mysql.ErrTableExists)
 	// ErrTableNotExists is the error for table not exists.
@@ -1270,6 +1280,208 @@ func (m *Mutator) ListSimpleTables(dbID int64) ([]*model.TableNameInfo, error) {
 	return tables, nil
 }
 
+func (*Mutator) routineKey(tp model.RoutineType, name ast.CIStr) []byte {
+	return []byte(fmt.Sprintf("%s:%d:%s", mRoutinePrefix, tp, name.L))
+}
+
+// CreateRoutine creates a stored routine in database.
+func (m *Mutator) CreateRoutine(dbID int64, routine *model.RoutineInfo) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routine.Type, routine.Name)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrRoutineExists.GenWithStackByArgs(routine.Type, routine.Name)
+	}
+
+	data, err := json.Marshal(routine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, routineKey, data)
+}
+
+// DropRoutine drops a stored routine in database.
+func (m *Mutator) DropRoutine(dbID int64, tp model.RoutineType, name ast.CIStr) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(tp, name)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrRoutineNotExists.GenWithStackByArgs(tp, name)
+	}
+	return errors.Trace(m.txn.HDel(dbKey, routineKey))
+}
+
+// GetRoutine gets the stored routine in database, nil is returned if it doesn't exist.
+func (m *Mutator) GetRoutine(dbID int64, tp model.RoutineType, name ast.CIStr) (*model.RoutineInfo, error) {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	value, err := m.txn.HGet(dbKey, m.routineKey(tp, name))
+	if err != nil || value == nil {
+		return nil, errors.Trace(err)
+	}
+
+	routine := &model.RoutineInfo{}
+	err = json.Unmarshal(value, routine)
+	return routine, errors.Trace(err)
+}
+
+// ListRoutines shows all stored routines in database.
+func (m *Mutator) ListRoutines(dbID int64) ([]*model.RoutineInfo, error) {
+	res, err := m.GetMetasByDBID(dbID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	routines := make([]*model.RoutineInfo, 0)
+	for _, r := range res {
+		// only handle routine meta
+		if !strings.HasPrefix(string(r.Field), mRoutinePrefix+":") {
+			continue
+		}
+
+		routine := &model.RoutineInfo{}
+		if err = json.Unmarshal(r.Value, routine); err != nil {
+			return nil, errors.Trace(err)
+		}
+		routines = append(routines, routine)
+	}
+
+	return routines, nil
+}
+
+func (*Mutator) eventKey(name ast.CIStr) []byte {
+	return []byte(fmt.Sprintf("%s:%s", mEventPrefix, name.L))
+}
+
+// CreateEvent creates a scheduled event in database.
+func (m *Mutator) CreateEvent(dbID int64, event *model.EventInfo) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(event.Name)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrEventExists.GenWithStackByArgs(event.Name)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, eventKey, data)
+}
+
+// UpdateEvent updates a scheduled event in database.
+func (m *Mutator) UpdateEvent(dbID int64, event *model.EventInfo) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(event.Name)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrEventNotExists.GenWithStackByArgs(event.Name)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, eventKey, data)
+}
+
+// DropEvent drops a scheduled event in database.
+func (m *Mutator) DropEvent(dbID int64, name ast.CIStr) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(name)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrEventNotExists.GenWithStackByArgs(name)
+	}
+	return errors.Trace(m.txn.HDel(dbKey, eventKey))
+}
+
+// GetEvent gets the scheduled event in database, nil is returned if it doesn't exist.
+func (m *Mutator) GetEvent(dbID int64, name ast.CIStr) (*model.EventInfo, error) {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	value, err := m.txn.HGet(dbKey, m.eventKey(name))
+	if err != nil || value == nil {
+		return nil, errors.Trace(err)
+	}
+
+	event := &model.EventInfo{}
+	err = json.Unmarshal(value, event)
+	return event, errors.Trace(err)
+}
+
+// ListEvents shows all scheduled events in database.
+func (m *Mutator) ListEvents(dbID int64) ([]*model.EventInfo, error) {
+	res, err := m.GetMetasByDBID(dbID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	events := make([]*model.EventInfo, 0)
+	for _, r := range res {
+		// only handle event meta
+		if !strings.HasPrefix(string(r.Field), mEventPrefix+":") {
+			continue
+		}
+
+		event := &model.EventInfo{}
+		if err = json.Unmarshal(r.Value, event); err != nil {
+			return nil, errors.Trace(err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
 var tableNameInfoFields = []string{"id", "name"}
 
 // FastUnmarshalTableNameInfo is exported for testing.
@@ -162,6 +162,112 @@ func TestResourceGroup(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRoutine(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+
+	m := meta.NewMutator(txn)
+	dbInfo := &model.DBInfo{ID: 1, Name: ast.NewCIStr("a")}
+	require.NoError(t, m.CreateDatabase(dbInfo))
+	require.NoError(t, m.CreateTableOrView(1, &model.TableInfo{ID: 2, Name: ast.NewCIStr("t")}))
+
+	proc := &model.RoutineInfo{Name: ast.NewCIStr("Foo"), Type: model.RoutineTypeProcedure, Body: "SELECT 1"}
+	fn := &model.RoutineInfo{Name: ast.NewCIStr("foo"), Type: model.RoutineTypeFunction, Body: "RETURN 1"}
+	require.NoError(t, m.CreateRoutine(1, proc))
+	require.NoError(t, m.CreateRoutine(1, fn))
+	err = m.CreateRoutine(1, proc)
+	require.True(t, meta.ErrRoutineExists.Equal(err))
+
+	r, err := m.GetRoutine(1, model.RoutineTypeProcedure, ast.NewCIStr("FOO"))
+	require.NoError(t, err)
+	require.Equal(t, proc, r)
+	r, err = m.GetRoutine(1, model.RoutineTypeFunction, ast.NewCIStr("bar"))
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	routines, err := m.ListRoutines(1)
+	require.NoError(t, err)
+	require.Len(t, routines, 2)
+	// routines are not listed as tables.
+	tables, err := m.ListTables(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+
+	require.NoError(t, m.DropRoutine(1, model.RoutineTypeProcedure, ast.NewCIStr("foo")))
+	err = m.DropRoutine(1, model.RoutineTypeProcedure, ast.NewCIStr("foo"))
+	require.True(t, meta.ErrRoutineNotExists.Equal(err))
+	routines, err = m.ListRoutines(1)
+	require.NoError(t, err)
+	require.Len(t, routines, 1)
+	require.Equal(t, fn, routines[0])
+}
+
+func TestEvent(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+
+	m := meta.NewMutator(txn)
+	dbInfo := &model.DBInfo{ID: 1, Name: ast.NewCIStr("a")}
+	require.NoError(t, m.CreateDatabase(dbInfo))
+	require.NoError(t, m.CreateTableOrView(1, &model.TableInfo{ID: 2, Name: ast.NewCIStr("t")}))
+	require.NoError(t, m.CreateRoutine(1, &model.RoutineInfo{Name: ast.NewCIStr("p"), Type: model.RoutineTypeProcedure}))
+
+	ev := &model.EventInfo{Name: ast.NewCIStr("Ev"), Body: "DELETE FROM `t`", Status: ast.EventStatusEnable}
+	require.NoError(t, m.CreateEvent(1, ev))
+	err = m.CreateEvent(1, ev)
+	require.True(t, meta.ErrEventExists.Equal(err))
+
+	e, err := m.GetEvent(1, ast.NewCIStr("EV"))
+	require.NoError(t, err)
+	require.Equal(t, ev, e)
+	e, err = m.GetEvent(1, ast.NewCIStr("ev2"))
+	require.NoError(t, err)
+	require.Nil(t, e)
+
+	ev.Status = ast.EventStatusDisable
+	require.NoError(t, m.UpdateEvent(1, ev))
+	err = m.UpdateEvent(1, &model.EventInfo{Name: ast.NewCIStr("ev2")})
+	require.True(t, meta.ErrEventNotExists.Equal(err))
+
+	events, err := m.ListEvents(1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.EventInfo{ev}, events)
+	// events are not listed as tables or routines.
+	tables, err := m.ListTables(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	routines, err := m.ListRoutines(1)
+	require.NoError(t, err)
+	require.Len(t, routines, 1)
+
+	require.NoError(t, m.DropEvent(1, ast.NewCIStr("ev")))
+	err = m.DropEvent(1, ast.NewCIStr("ev"))
+	require.True(t, meta.ErrEventNotExists.Equal(err))
+	events, err = m.ListEvents(1)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
 func TestMeta(t *testing.T) {
 	store, err := mockstore.NewMockStore(mockstore.WithStoreType(mockstore.EmbedUnistore))
 	require.NoError(t, err)
@@ -104,6 +104,9 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionAddColumnarIndex,
 		ActionModifyEngineAttribute,
 		ActionAlterTableMode,
+		ActionCreateMaterializedView,
+		ActionDropMaterializedView,
+		ActionRefreshMaterializedView,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
@@ -112,6 +115,13 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionCreateResourceGroup,
 		ActionAlterResourceGroup,
 		ActionDropResourceGroup,
+		ActionCreateRoutine,
+		ActionDropRoutine,
+		ActionCreateTrigger,
+		ActionDropTrigger,
+		ActionCreateEvent,
+		ActionAlterEvent,
+		ActionDropEvent,
 	},
 	UnknownDDL: {
 		_DEPRECATEDActionAlterTableAlterPartition,
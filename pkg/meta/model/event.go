@@ -0,0 +1,137 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// EventInterval is the parsed interval of a recurring event. Only one of
+// Months and Duration is set, since no interval unit mixes them.
+type EventInterval struct {
+	Months   int64         `json:"months"`
+	Duration time.Duration `json:"duration"`
+}
+
+// EventInfo provides meta data describing a scheduled event.
+// All the time fields are stored in UTC.
+type EventInfo struct {
+	// ID is kept when the event is altered or renamed.
+	ID      int64              `json:"id"`
+	Name    ast.CIStr          `json:"name"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// ExecuteAt is the execution time of a one-time event.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are the original `EVERY` clause of a
+	// recurring event, Interval is parsed from them.
+	IntervalValue string           `json:"interval_value"`
+	IntervalField ast.TimeUnitType `json:"interval_field"`
+	Interval      EventInterval    `json:"interval"`
+	Starts        time.Time        `json:"starts"`
+	// Ends is zero if the recurring event never ends.
+	Ends     time.Time       `json:"ends"`
+	Status   ast.EventStatus `json:"status"`
+	Preserve bool            `json:"preserve"`
+	Comment  string          `json:"comment"`
+	Body     string          `json:"body"`
+	// TimeZone is the time_zone in effect when the event was created; the
+	// schedule is displayed and the body is executed with it.
+	TimeZone string `json:"time_zone"`
+	// SQLMode is the sql_mode in effect when the event was created; the body is
+	// always parsed and executed with it.
+	SQLMode     mysql.SQLMode `json:"sql_mode"`
+	Charset     string        `json:"charset"`
+	Collate     string        `json:"collate"`
+	Created     time.Time     `json:"created"`
+	LastAltered time.Time     `json:"last_altered"`
+}
+
+// Clone clones EventInfo.
+func (e *EventInfo) Clone() *EventInfo {
+	ne := *e
+	if e.Definer != nil {
+		definer := *e.Definer
+		ne.Definer = &definer
+	}
+	return &ne
+}
+
+// IsRecurring returns whether the event is a recurring event.
+func (e *EventInfo) IsRecurring() bool {
+	return e.ExecuteAt.IsZero()
+}
+
+// IsEnabled returns whether the event is enabled.
+func (e *EventInfo) IsEnabled() bool {
+	return e.Status == ast.EventStatusEnable
+}
+
+// NextExecutionTime returns the next execution time of the event after its
+// last execution at `last`, `last` is zero if the event has never been executed.
+// The executions missed before `now` are merged into the latest one of them.
+// False is returned if the event will not be executed anymore.
+func (e *EventInfo) NextExecutionTime(last, now time.Time) (time.Time, bool) {
+	if !e.IsRecurring() {
+		return e.ExecuteAt, last.Before(e.ExecuteAt)
+	}
+
+	next := e.Starts
+	if !last.Before(e.Starts) {
+		next = e.nthExecutionTime(e.executionsUntil(last) + 1)
+	}
+	if next.Before(now) {
+		next = e.nthExecutionTime(e.executionsUntil(now))
+	}
+	if !e.Ends.IsZero() && next.After(e.Ends) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// executionsUntil returns the max n that the nth execution time is not after t.
+// t should not be before Starts.
+func (e *EventInfo) executionsUntil(t time.Time) int64 {
+	if e.Interval.Months == 0 {
+		return int64(t.Sub(e.Starts) / e.Interval.Duration)
+	}
+	months := int64(t.Year()-e.Starts.Year())*12 + int64(t.Month()-e.Starts.Month())
+	n := months / e.Interval.Months
+	for n > 0 && e.nthExecutionTime(n).After(t) {
+		n--
+	}
+	for !e.nthExecutionTime(n + 1).After(t) {
+		n++
+	}
+	return n
+}
+
+// nthExecutionTime returns Starts + n * Interval. Like DATE_ADD, the day is
+// clipped to the last day of the month when adding months.
+func (e *EventInfo) nthExecutionTime(n int64) time.Time {
+	if e.Interval.Months == 0 {
+		return e.Starts.Add(time.Duration(n) * e.Interval.Duration)
+	}
+	months := int(n * e.Interval.Months)
+	t := e.Starts.AddDate(0, months, 0)
+	if t.Day() != e.Starts.Day() {
+		// overflowed to the next month, go back to the last day of the month.
+		t = t.AddDate(0, 0, -t.Day())
+	}
+	return t
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventNextExecutionTime(t *testing.T) {
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	// one-time event
+	e := &EventInfo{ExecuteAt: date(1, 1, 0)}
+	require.False(t, e.IsRecurring())
+	next, ok := e.NextExecutionTime(time.Time{}, date(2, 1, 0))
+	require.True(t, ok)
+	require.Equal(t, date(1, 1, 0), next)
+	_, ok = e.NextExecutionTime(date(1, 1, 0), date(2, 1, 0))
+	require.False(t, ok)
+
+	// recurring event with a fixed interval
+	e = &EventInfo{
+		Interval: EventInterval{Duration: time.Hour},
+		Starts:   date(1, 1, 0),
+		Ends:     date(1, 2, 0),
+	}
+	require.True(t, e.IsRecurring())
+	for _, c := range []struct {
+		last, now, next time.Time
+		ok              bool
+	}{
+		{time.Time{}, date(1, 1, 0), date(1, 1, 0), true},
+		{time.Time{}, date(1, 1, 0).Add(-time.Minute), date(1, 1, 0), true},
+		{time.Time{}, date(1, 1, 3).Add(time.Minute), date(1, 1, 3), true},
+		{date(1, 1, 0), date(1, 1, 0), date(1, 1, 1), true},
+		{date(1, 1, 1), date(1, 1, 1).Add(time.Minute), date(1, 1, 2), true},
+		// the missed executions are merged
+		{date(1, 1, 1), date(1, 1, 5).Add(time.Minute), date(1, 1, 5), true},
+		{date(1, 1, 23), date(1, 1, 23), date(1, 2, 0), true},
+		{date(1, 2, 0), date(1, 2, 0), time.Time{}, false},
+	} {
+		next, ok = e.NextExecutionTime(c.last, c.now)
+		require.Equal(t, c.ok, ok, "%v %v", c.last, c.now)
+		if ok {
+			require.Equal(t, c.next, next, "%v %v", c.last, c.now)
+		}
+	}
+
+	// recurring event with months interval
+	e = &EventInfo{
+		Interval: EventInterval{Months: 1},
+		Starts:   date(1, 31, 0),
+	}
+	next, ok = e.NextExecutionTime(date(1, 31, 0), date(1, 31, 0))
+	require.True(t, ok)
+	require.Equal(t, date(2, 28, 0), next)
+	next, ok = e.NextExecutionTime(date(2, 28, 0), date(2, 28, 0))
+	require.True(t, ok)
+	require.Equal(t, date(3, 31, 0), next)
+	next, ok = e.NextExecutionTime(date(3, 31, 0), date(6, 1, 0))
+	require.True(t, ok)
+	require.Equal(t, date(5, 31, 0), next)
+	next, ok = e.NextExecutionTime(time.Time{}, date(4, 30, 12))
+	require.True(t, ok)
+	require.Equal(t, date(4, 30, 0), next)
+}
@@ -97,23 +97,33 @@ const (
 	ActionAlterTablePlacement           ActionType = 56
 	ActionAlterCacheTable               ActionType = 57
 	// not used
-	ActionAlterTableStatsOptions ActionType = 58
-	ActionAlterNoCacheTable      ActionType = 59
-	ActionCreateTables           ActionType = 60
-	ActionMultiSchemaChange      ActionType = 61
-	ActionFlashbackCluster       ActionType = 62
-	ActionRecoverSchema          ActionType = 63
-	ActionReorganizePartition    ActionType = 64
-	ActionAlterTTLInfo           ActionType = 65
-	ActionAlterTTLRemove         ActionType = 67
-	ActionCreateResourceGroup    ActionType = 68
-	ActionAlterResourceGroup     ActionType = 69
-	ActionDropResourceGroup      ActionType = 70
-	ActionAlterTablePartitioning ActionType = 71
-	ActionRemovePartitioning     ActionType = 72
-	ActionAddColumnarIndex       ActionType = 73
-	ActionModifyEngineAttribute  ActionType = 74
-	ActionAlterTableMode         ActionType = 75
+	ActionAlterTableStatsOptions  ActionType = 58
+	ActionAlterNoCacheTable       ActionType = 59
+	ActionCreateTables            ActionType = 60
+	ActionMultiSchemaChange       ActionType = 61
+	ActionFlashbackCluster        ActionType = 62
+	ActionRecoverSchema           ActionType = 63
+	ActionReorganizePartition     ActionType = 64
+	ActionAlterTTLInfo            ActionType = 65
+	ActionAlterTTLRemove          ActionType = 67
+	ActionCreateResourceGroup     ActionType = 68
+	ActionAlterResourceGroup      ActionType = 69
+	ActionDropResourceGroup       ActionType = 70
+	ActionAlterTablePartitioning  ActionType = 71
+	ActionRemovePartitioning      ActionType = 72
+	ActionAddColumnarIndex        ActionType = 73
+	ActionModifyEngineAttribute   ActionType = 74
+	ActionAlterTableMode          ActionType = 75
+	ActionCreateRoutine           ActionType = 76
+	ActionDropRoutine             ActionType = 77
+	ActionCreateTrigger           ActionType = 78
+	ActionDropTrigger             ActionType = 79
+	ActionCreateEvent             ActionType = 80
+	ActionAlterEvent              ActionType = 81
+	ActionDropEvent               ActionType = 82
+	ActionCreateMaterializedView  ActionType = 83
+	ActionDropMaterializedView    ActionType = 84
+	ActionRefreshMaterializedView ActionType = 85
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -188,6 +198,16 @@ var ActionMap = map[ActionType]string{
 	ActionAddColumnarIndex:              "add columnar index",
 	ActionModifyEngineAttribute:         "modify engine attribute",
 	ActionAlterTableMode:                "alter table mode",
+	ActionCreateRoutine:                 "create routine",
+	ActionDropRoutine:                   "drop routine",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionCreateEvent:                   "create event",
+	ActionAlterEvent:                    "alter event",
+	ActionDropEvent:                     "drop event",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionDropMaterializedView:          "drop materialized view",
+	ActionRefreshMaterializedView:       "refresh materialized view",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -738,7 +758,7 @@ func (job *Job) IsRollbackable() bool {
 	case ActionAddTablePartition:
 		return job.SchemaState == StateNone || job.SchemaState == StateReplicaOnly
 	case ActionDropColumn, ActionDropSchema, ActionDropTable, ActionDropSequence,
-		ActionDropForeignKey, ActionDropTablePartition:
+		ActionDropForeignKey, ActionDropTablePartition, ActionDropMaterializedView:
 		return job.SchemaState == StatePublic
 	case ActionTruncateTablePartition:
 		return job.SchemaState == StatePublic || job.SchemaState == StateWriteOnly
@@ -205,7 +205,7 @@ func GetModifySchemaArgs(job *Job) (*ModifySchemaArgs, error) {
 	return getOrDecodeArgs[*ModifySchemaArgs](&ModifySchemaArgs{}, job)
 }
 
-// CreateTableArgs is the arguments for create table/view/sequence job.
+// CreateTableArgs is the arguments for create table/view/sequence/materialized view job.
 type CreateTableArgs struct {
 	TableInfo *TableInfo `json:"table_info,omitempty"`
 	// below 2 are used for create view.
@@ -221,7 +221,7 @@ func (a *CreateTableArgs) getArgsV1(job *Job) []any {
 		return []any{a.TableInfo, a.FKCheck}
 	case ActionCreateView:
 		return []any{a.TableInfo, a.OnExistReplace, a.OldViewTblID}
-	case ActionCreateSequence:
+	case ActionCreateSequence, ActionCreateMaterializedView:
 		return []any{a.TableInfo}
 	}
 	return nil
@@ -234,7 +234,7 @@ func (a *CreateTableArgs) decodeV1(job *Job) error {
 		return errors.Trace(job.decodeArgs(a.TableInfo, &a.FKCheck))
 	case ActionCreateView:
 		return errors.Trace(job.decodeArgs(a.TableInfo, &a.OnExistReplace, &a.OldViewTblID))
-	case ActionCreateSequence:
+	case ActionCreateSequence, ActionCreateMaterializedView:
 		return errors.Trace(job.decodeArgs(a.TableInfo))
 	}
 	return nil
@@ -642,6 +642,91 @@ func GetResourceGroupArgs(job *Job) (*ResourceGroupArgs, error) {
 	return getOrDecodeArgs[*ResourceGroupArgs](&ResourceGroupArgs{}, job)
 }
 
+// RoutineArgs is the arguments for create/drop stored routine job.
+type RoutineArgs struct {
+	// for DropRoutine we only use it to store the name and type.
+	Routine *RoutineInfo `json:"routine,omitempty"`
+}
+
+func (a *RoutineArgs) getArgsV1(*Job) []any {
+	return []any{a.Routine}
+}
+
+func (a *RoutineArgs) decodeV1(job *Job) error {
+	a.Routine = &RoutineInfo{}
+	return errors.Trace(job.decodeArgs(a.Routine))
+}
+
+// GetRoutineArgs gets the stored routine args.
+func GetRoutineArgs(job *Job) (*RoutineArgs, error) {
+	return getOrDecodeArgs[*RoutineArgs](&RoutineArgs{}, job)
+}
+
+// TriggerArgs is the arguments for create/drop trigger job.
+type TriggerArgs struct {
+	// for DropTrigger we only use it to store the name.
+	Trigger *TriggerInfo `json:"trigger,omitempty"`
+	// Order is the FOLLOWS or PRECEDES clause of CREATE TRIGGER.
+	Order *ast.TriggerOrder `json:"order,omitempty"`
+}
+
+func (a *TriggerArgs) getArgsV1(*Job) []any {
+	return []any{a.Trigger, a.Order}
+}
+
+func (a *TriggerArgs) decodeV1(job *Job) error {
+	a.Trigger = &TriggerInfo{}
+	return errors.Trace(job.decodeArgs(a.Trigger, &a.Order))
+}
+
+// GetTriggerArgs gets the trigger args.
+func GetTriggerArgs(job *Job) (*TriggerArgs, error) {
+	return getOrDecodeArgs[*TriggerArgs](&TriggerArgs{}, job)
+}
+
+// EventArgs is the arguments for create/alter/drop event job.
+type EventArgs struct {
+	// for DropEvent we only use it to store the name.
+	Event *EventInfo `json:"event,omitempty"`
+	// OldName is the name of the event before AlterEvent, it differs from the
+	// name of Event when the event is renamed.
+	OldName ast.CIStr `json:"old_name,omitempty"`
+}
+
+func (a *EventArgs) getArgsV1(*Job) []any {
+	return []any{a.Event, a.OldName}
+}
+
+func (a *EventArgs) decodeV1(job *Job) error {
+	a.Event = &EventInfo{}
+	return errors.Trace(job.decodeArgs(a.Event, &a.OldName))
+}
+
+// GetEventArgs gets the event args.
+func GetEventArgs(job *Job) (*EventArgs, error) {
+	return getOrDecodeArgs[*EventArgs](&EventArgs{}, job)
+}
+
+// RefreshMaterializedViewArgs is the arguments for refresh materialized view job.
+type RefreshMaterializedViewArgs struct {
+	// RefreshTS is the snapshot TS of the base tables reflected by the refreshed
+	// result.
+	RefreshTS uint64 `json:"refresh_ts,omitempty"`
+}
+
+func (a *RefreshMaterializedViewArgs) getArgsV1(*Job) []any {
+	return []any{a.RefreshTS}
+}
+
+func (a *RefreshMaterializedViewArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(&a.RefreshTS))
+}
+
+// GetRefreshMaterializedViewArgs gets the refresh materialized view args.
+func GetRefreshMaterializedViewArgs(job *Job) (*RefreshMaterializedViewArgs, error) {
+	return getOrDecodeArgs[*RefreshMaterializedViewArgs](&RefreshMaterializedViewArgs{}, job)
+}
+
 // RebaseAutoIDArgs is the arguments for ActionRebaseAutoID DDL.
 // It is also for ActionRebaseAutoRandomBase.
 type RebaseAutoIDArgs struct {
@@ -948,17 +1033,19 @@ func GetShardRowIDArgs(job *Job) (*ShardRowIDArgs, error) {
 
 // AlterTTLInfoArgs is the arguments for alter ttl info job.
 type AlterTTLInfoArgs struct {
-	TTLInfo            *TTLInfo `json:"ttl_info,omitempty"`
-	TTLEnable          *bool    `json:"ttl_enable,omitempty"`
-	TTLCronJobSchedule *string  `json:"ttl_cron_job_schedule,omitempty"`
+	TTLInfo            *TTLInfo   `json:"ttl_info,omitempty"`
+	TTLEnable          *bool      `json:"ttl_enable,omitempty"`
+	TTLCronJobSchedule *string    `json:"ttl_cron_job_schedule,omitempty"`
+	TTLAction          *TTLAction `json:"ttl_action,omitempty"`
+	TTLCondition       *string    `json:"ttl_condition,omitempty"`
 }
 
 func (a *AlterTTLInfoArgs) getArgsV1(*Job) []any {
-	return []any{a.TTLInfo, a.TTLEnable, a.TTLCronJobSchedule}
+	return []any{a.TTLInfo, a.TTLEnable, a.TTLCronJobSchedule, a.TTLAction, a.TTLCondition}
 }
 
 func (a *AlterTTLInfoArgs) decodeV1(job *Job) error {
-	return errors.Trace(job.decodeArgs(&a.TTLInfo, &a.TTLEnable, &a.TTLCronJobSchedule))
+	return errors.Trace(job.decodeArgs(&a.TTLInfo, &a.TTLEnable, &a.TTLCronJobSchedule, &a.TTLAction, &a.TTLCondition))
 }
 
 // GetAlterTTLInfoArgs gets the args for alter ttl info job.
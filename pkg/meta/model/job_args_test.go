@@ -17,6 +17,7 @@ package model
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -177,6 +178,23 @@ func TestCreateTableArgs(t *testing.T) {
 			require.EqualValues(t, inArgs.TableInfo, args.TableInfo)
 		}
 	})
+	t.Run("create materialized view", func(t *testing.T) {
+		inArgs := &CreateTableArgs{
+			TableInfo: &TableInfo{ID: 33, MaterializedView: &MaterializedViewInfo{
+				Definition:    "SELECT `a`,COUNT(1) FROM `test`.`t` GROUP BY `a`",
+				RefreshMethod: ast.MViewRefreshFast,
+				BaseTableID:   32,
+				GroupBy:       []MViewGroupByColumn{{BaseColumn: ast.NewCIStr("a"), ViewColumn: ast.NewCIStr("a")}},
+			}},
+		}
+		for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+			j2 := &Job{}
+			require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionCreateMaterializedView)))
+			args, err := GetCreateTableArgs(j2)
+			require.NoError(t, err)
+			require.EqualValues(t, inArgs.TableInfo, args.TableInfo)
+		}
+	})
 }
 
 func TestBatchCreateTableArgs(t *testing.T) {
@@ -505,6 +523,83 @@ func TestResourceGroupArgs(t *testing.T) {
 	}
 }
 
+func TestRoutineArgs(t *testing.T) {
+	inArgs := &RoutineArgs{
+		Routine: &RoutineInfo{
+			Name:     ast.NewCIStr("proc"),
+			Type:     RoutineTypeProcedure,
+			ParamStr: "in a int",
+			Body:     "SELECT `a`",
+			Params:   []*RoutineParam{{Name: ast.NewCIStr("a"), Mode: ast.MODE_IN}},
+		},
+	}
+	for _, tp := range []ActionType{ActionCreateRoutine, ActionDropRoutine} {
+		for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+			j2 := &Job{}
+			require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, tp)))
+			args, err := GetRoutineArgs(j2)
+			require.NoError(t, err)
+			require.EqualValues(t, inArgs, args)
+		}
+	}
+}
+
+func TestTriggerArgs(t *testing.T) {
+	inArgs := &TriggerArgs{
+		Trigger: &TriggerInfo{
+			Name:   ast.NewCIStr("trg"),
+			Timing: ast.TriggerAfter,
+			Event:  ast.TriggerUpdate,
+			Body:   "INSERT INTO `log` VALUES (`new`.`a`)",
+		},
+		Order: &ast.TriggerOrder{Follows: true, TriggerName: ast.NewCIStr("trg2")},
+	}
+	for _, tp := range []ActionType{ActionCreateTrigger, ActionDropTrigger} {
+		for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+			j2 := &Job{}
+			require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, tp)))
+			args, err := GetTriggerArgs(j2)
+			require.NoError(t, err)
+			require.EqualValues(t, inArgs, args)
+		}
+	}
+}
+
+func TestEventArgs(t *testing.T) {
+	inArgs := &EventArgs{
+		Event: &EventInfo{
+			Name:          ast.NewCIStr("ev2"),
+			IntervalValue: "1",
+			IntervalField: ast.TimeUnitHour,
+			Interval:      EventInterval{Duration: time.Hour},
+			Starts:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Status:        ast.EventStatusEnable,
+			Body:          "DELETE FROM `t`",
+		},
+		OldName: ast.NewCIStr("ev"),
+	}
+	for _, tp := range []ActionType{ActionCreateEvent, ActionAlterEvent, ActionDropEvent} {
+		for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+			j2 := &Job{}
+			require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, tp)))
+			args, err := GetEventArgs(j2)
+			require.NoError(t, err)
+			require.EqualValues(t, inArgs, args)
+		}
+	}
+}
+
+func TestRefreshMaterializedViewArgs(t *testing.T) {
+	inArgs := &RefreshMaterializedViewArgs{RefreshTS: 456}
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionRefreshMaterializedView)))
+		args, err := GetRefreshMaterializedViewArgs(j2)
+		require.NoError(t, err)
+		require.EqualValues(t, inArgs, args)
+	}
+}
+
 func TestGetAlterSequenceArgs(t *testing.T) {
 	inArgs := &AlterSequenceArgs{
 		Ident: ast.Ident{
@@ -637,6 +732,7 @@ func TestGetDropForeignKeyArgs(t *testing.T) {
 func TestGetAlterTTLInfoArgs(t *testing.T) {
 	ttlEanble := true
 	ttlCronJobSchedule := "ttl-schedule"
+	ttlCondition := "`deleted` = 1"
 	inArgs := &AlterTTLInfoArgs{
 		TTLInfo: &TTLInfo{
 			ColumnName:       ast.NewCIStr("column_name"),
@@ -645,6 +741,12 @@ func TestGetAlterTTLInfoArgs(t *testing.T) {
 		},
 		TTLEnable:          &ttlEanble,
 		TTLCronJobSchedule: &ttlCronJobSchedule,
+		TTLAction: &TTLAction{
+			Tp:            ast.TTLActionArchive,
+			ArchiveSchema: ast.NewCIStr("db"),
+			ArchiveTable:  ast.NewCIStr("t_archive"),
+		},
+		TTLCondition: &ttlCondition,
 	}
 	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
 		j2 := &Job{}
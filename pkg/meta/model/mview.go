@@ -0,0 +1,72 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// MaterializedViewInfo provides meta data describing a materialized view, it's
+// stored in the TableInfo of the table which holds the result of the view.
+type MaterializedViewInfo struct {
+	// Definition is the SELECT statement of the view, the table names in it are
+	// qualified with the schema names.
+	Definition    string                 `json:"definition"`
+	RefreshMethod ast.MViewRefreshMethod `json:"refresh_method"`
+	// SQLMode and TimeZone are in effect when the view was created; the
+	// definition is always parsed and evaluated with them.
+	SQLMode  mysql.SQLMode `json:"sql_mode"`
+	TimeZone string        `json:"time_zone"`
+	Charset  string        `json:"charset"`
+	Collate  string        `json:"collate"`
+	// BaseTableID is the ID of the table read by the view if the view is an
+	// aggregation over a single table grouped by plain columns. Only such views
+	// can be refreshed incrementally or used to rewrite queries, it's 0 for the
+	// other views.
+	BaseTableID int64 `json:"base_table_id,omitempty"`
+	// GroupBy maps the GROUP BY columns of the base table to the columns of the
+	// view.
+	GroupBy []MViewGroupByColumn `json:"group_by,omitempty"`
+	// LastRefreshTS is the snapshot TS of the base tables reflected by the
+	// stored result, it's 0 if the view has never been refreshed.
+	LastRefreshTS uint64 `json:"last_refresh_ts,omitempty"`
+}
+
+// MViewGroupByColumn is a GROUP BY column of a materialized view.
+type MViewGroupByColumn struct {
+	BaseColumn ast.CIStr `json:"base_column"`
+	ViewColumn ast.CIStr `json:"view_column"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	nm := *m
+	nm.GroupBy = slices.Clone(m.GroupBy)
+	return &nm
+}
+
+// IsIncremental returns whether the view is refreshed incrementally.
+func (m *MaterializedViewInfo) IsIncremental() bool {
+	return m.RefreshMethod == ast.MViewRefreshFast && m.BaseTableID != 0
+}
+
+// IsMaterializedView returns whether the table holds the result of a
+// materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// RoutineType is the type of a stored routine.
+type RoutineType byte
+
+// List of stored routine types.
+const (
+	RoutineTypeProcedure RoutineType = iota + 1
+	RoutineTypeFunction
+)
+
+// String implements fmt.Stringer interface.
+func (t RoutineType) String() string {
+	switch t {
+	case RoutineTypeProcedure:
+		return "PROCEDURE"
+	case RoutineTypeFunction:
+		return "FUNCTION"
+	}
+	return ""
+}
+
+// RoutineParam is a parameter of a stored routine.
+type RoutineParam struct {
+	Name ast.CIStr `json:"name"`
+	// Mode is one of ast.MODE_IN, ast.MODE_OUT and ast.MODE_INOUT.
+	Mode int              `json:"mode"`
+	Type *types.FieldType `json:"type"`
+}
+
+// RoutineInfo provides meta data describing a stored procedure or function.
+type RoutineInfo struct {
+	Name   ast.CIStr       `json:"name"`
+	Type   RoutineType     `json:"type"`
+	Params []*RoutineParam `json:"params"`
+	// ParamStr is the original text of the parameter list.
+	ParamStr string `json:"param_str"`
+	// ReturnType is only set for stored functions.
+	ReturnType    *types.FieldType        `json:"return_type"`
+	Body          string                  `json:"body"`
+	Definer       *auth.UserIdentity      `json:"definer"`
+	Security      ast.ViewSecurity        `json:"security"`
+	Deterministic bool                    `json:"deterministic"`
+	DataAccess    ast.ProcedureDataAccess `json:"data_access"`
+	Comment       string                  `json:"comment"`
+	// SQLMode is the sql_mode in effect when the routine was created; the body is
+	// always parsed and executed with it.
+	SQLMode   mysql.SQLMode `json:"sql_mode"`
+	Charset   string        `json:"charset"`
+	Collate   string        `json:"collate"`
+	Created   time.Time     `json:"created"`
+	LastAlter time.Time     `json:"last_altered"`
+}
+
+// Clone clones RoutineInfo.
+func (r *RoutineInfo) Clone() *RoutineInfo {
+	nr := *r
+	nr.Params = make([]*RoutineParam, 0, len(r.Params))
+	for _, p := range r.Params {
+		np := *p
+		if p.Type != nil {
+			np.Type = p.Type.Clone()
+		}
+		nr.Params = append(nr.Params, &np)
+	}
+	if r.ReturnType != nil {
+		nr.ReturnType = r.ReturnType.Clone()
+	}
+	if r.Definer != nil {
+		definer := *r.Definer
+		nr.Definer = &definer
+	}
+	return &nr
+}
+
+// IsFunction returns whether the routine is a stored function.
+func (r *RoutineInfo) IsFunction() bool {
+	return r.Type == RoutineTypeFunction
+}
@@ -17,6 +17,7 @@ package model
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -203,6 +204,17 @@ type TableInfo struct {
 	DBID int64 `json:"-"`
 
 	Mode TableMode `json:"mode,omitempty"`
+
+	// Triggers are listed in the order in which they are activated for the
+	// same timing and event.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is not nil if the table holds the result of a
+	// materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MaterializedViews are the IDs of the materialized views which aggregate
+	// this table, see MaterializedViewInfo.BaseTableID.
+	MaterializedViews []int64 `json:"materialized_views,omitempty"`
 }
 
 // Hash64 implement HashEquals interface.
@@ -273,6 +285,18 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
 
+	if len(t.Triggers) > 0 {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	nt.MaterializedViews = slices.Clone(t.MaterializedViews)
+
 	return &nt
 }
 
@@ -1380,14 +1404,42 @@ type TTLInfo struct {
 	// JobInterval is the interval between two TTL scan jobs.
 	// It's suggested to get a duration with `(*TTLInfo).GetJobInterval`
 	JobInterval string `json:"job_interval"`
+	// Action is the action applied to the expired rows. Nil means the expired rows are deleted.
+	Action *TTLAction `json:"action,omitempty"`
+	// Condition is the restored string of the `TTL_WHERE` predicate. Only the expired rows matching the predicate
+	// are processed by TTL. Empty means all the expired rows are processed.
+	Condition string `json:"condition,omitempty"`
 }
 
 // Clone clones TTLInfo
 func (t *TTLInfo) Clone() *TTLInfo {
 	cloned := *t
+	if t.Action != nil {
+		action := *t.Action
+		cloned.Action = &action
+	}
 	return &cloned
 }
 
+// GetActionType returns the action applied to the expired rows.
+func (t *TTLInfo) GetActionType() ast.TTLActionType {
+	if t.Action == nil {
+		return ast.TTLActionDelete
+	}
+	return t.Action.Tp
+}
+
+// TTLAction records what to do with the expired rows before they are deleted.
+type TTLAction struct {
+	Tp ast.TTLActionType `json:"tp"`
+	// ArchiveSchema and ArchiveTable is the target table for `TTLActionArchive`.
+	ArchiveSchema ast.CIStr `json:"archive_schema"`
+	ArchiveTable  ast.CIStr `json:"archive_table"`
+	// ExportURI and ExportFormat is the external storage and the file format for `TTLActionExport`.
+	ExportURI    string `json:"export_uri,omitempty"`
+	ExportFormat string `json:"export_format,omitempty"`
+}
+
 // GetJobInterval parses the job interval and return
 // if the job interval is an empty string, the "1h" will be returned, to keep compatible with 6.5 (in which
 // TTL_JOB_INTERVAL attribute doesn't exist)
@@ -0,0 +1,70 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// TriggerInfo provides meta data describing a row-level trigger, it's stored
+// in the TableInfo of the subject table.
+type TriggerInfo struct {
+	Name    ast.CIStr          `json:"name"`
+	Timing  ast.TriggerTiming  `json:"timing"`
+	Event   ast.TriggerEvent   `json:"event"`
+	Body    string             `json:"body"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// SQLMode is the sql_mode in effect when the trigger was created; the body is
+	// always parsed and executed with it.
+	SQLMode mysql.SQLMode `json:"sql_mode"`
+	Charset string        `json:"charset"`
+	Collate string        `json:"collate"`
+	Created time.Time     `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// FindTrigger finds the trigger by name.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	for _, trigger := range t.Triggers {
+		if trigger.Name.L == name {
+			return trigger
+		}
+	}
+	return nil
+}
+
+// TriggersOf returns the triggers of the timing and event in activation order.
+func (t *TableInfo) TriggersOf(timing ast.TriggerTiming, event ast.TriggerEvent) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, trigger := range t.Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
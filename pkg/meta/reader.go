@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/structure"
 )
 
@@ -30,6 +31,10 @@ type Reader interface {
 	ListTables(ctx context.Context, dbID int64) ([]*model.TableInfo, error)
 	ListSimpleTables(dbID int64) ([]*model.TableNameInfo, error)
 	IterTables(dbID int64, fn func(info *model.TableInfo) error) error
+	GetRoutine(dbID int64, tp model.RoutineType, name ast.CIStr) (*model.RoutineInfo, error)
+	ListRoutines(dbID int64) ([]*model.RoutineInfo, error)
+	GetEvent(dbID int64, name ast.CIStr) (*model.EventInfo, error)
+	ListEvents(dbID int64) ([]*model.EventInfo, error)
 	GetAutoIDAccessors(dbID, tableID int64) AutoIDAccessors
 	GetAllNameToIDAndTheMustLoadedTableInfo(dbID int64) (map[string]int64, []*model.TableInfo, error)
 
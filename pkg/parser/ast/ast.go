@@ -135,6 +135,22 @@ type SensitiveStmtNode interface {
 	SecureText() string
 }
 
+// optionalSensitiveStmtNode is implemented by the statements which contain sensitive information only with some
+// clauses, such as the external storage URI of `SELECT ... INTO OUTFILE`.
+type optionalSensitiveStmtNode interface {
+	SensitiveStmtNode
+	hasSensitiveInfo() bool
+}
+
+// AsSensitiveStmtNode returns the node as a SensitiveStmtNode if it contains sensitive information.
+func AsSensitiveStmtNode(node Node) (SensitiveStmtNode, bool) {
+	if n, ok := node.(optionalSensitiveStmtNode); ok && !n.hasSensitiveInfo() {
+		return nil, false
+	}
+	n, ok := node.(SensitiveStmtNode)
+	return n, ok
+}
+
 // Visitor visits a Node.
 type Visitor interface {
 	// Enter is called before children nodes are visited.
@@ -2007,7 +2007,11 @@ type DropIndexStmt struct {
 
 // Restore implements Node interface.
 func (n *DropIndexStmt) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("DROP INDEX ")
+	if n.IsHypo {
+		ctx.WriteKeyWord("DROP HYPO INDEX ")
+	} else {
+		ctx.WriteKeyWord("DROP INDEX ")
+	}
 	if n.IfExists {
 		_ = ctx.WriteWithSpecialComments("", func() error {
 			ctx.WriteKeyWord("IF EXISTS ")
@@ -2601,6 +2605,8 @@ const (
 	TableOptionTTLJobInterval
 	TableOptionEngineAttribute
 	TableOptionSecondaryEngineAttribute
+	TableOptionTTLAction
+	TableOptionTTLWhere
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2609,6 +2615,39 @@ const (
 	TableOptionStatsSampleRate = TableOptionType(StatsOptionSampleRate)
 )
 
+// TTLActionType is the action applied to the expired rows of a TTL table.
+type TTLActionType int
+
+// TTLActionType values.
+const (
+	// TTLActionDelete deletes the expired rows, which is the default action.
+	TTLActionDelete TTLActionType = iota
+	// TTLActionArchive moves the expired rows to another table in the same cluster.
+	TTLActionArchive
+	// TTLActionExport exports the expired rows to the external storage and then deletes them.
+	TTLActionExport
+)
+
+// String implements fmt.Stringer interface.
+func (t TTLActionType) String() string {
+	switch t {
+	case TTLActionDelete:
+		return "DELETE"
+	case TTLActionArchive:
+		return "ARCHIVE"
+	case TTLActionExport:
+		return "EXPORT"
+	default:
+		return ""
+	}
+}
+
+// The file formats supported by TTLActionExport.
+const (
+	TTLExportFormatCSV     = "csv"
+	TTLExportFormatParquet = "parquet"
+)
+
 // RowFormat types
 const (
 	RowFormatDefault uint64 = iota + 1
@@ -2657,6 +2696,7 @@ type TableOption struct {
 	Value         ValueExpr
 	TableNames    []*TableName
 	ColumnName    *ColumnName
+	Expr          ExprNode
 }
 
 func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
@@ -2970,6 +3010,47 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionTTLAction:
+		return ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ACTION ")
+			ctx.WritePlain("= ")
+			switch TTLActionType(n.UintValue) {
+			case TTLActionDelete:
+				ctx.WriteKeyWord("DELETE")
+			case TTLActionArchive:
+				ctx.WriteKeyWord("ARCHIVE TO TABLE ")
+				if len(n.TableNames) != 1 {
+					return errors.Errorf("invalid archive table for TTL_ACTION: %v", n.TableNames)
+				}
+				return n.TableNames[0].Restore(ctx)
+			case TTLActionExport:
+				ctx.WriteKeyWord("EXPORT TO ")
+				ctx.WriteString(n.StrValue)
+				if n.Value != nil {
+					ctx.WriteKeyWord(" FORMAT ")
+					ctx.WritePlain("= ")
+					ctx.WriteString(n.Value.GetString())
+				}
+			default:
+				return errors.Errorf("invalid TTL_ACTION: %d", n.UintValue)
+			}
+			return nil
+		})
+	case TableOptionTTLWhere:
+		return ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_WHERE ")
+			ctx.WritePlain("= ")
+			if n.Default {
+				ctx.WriteKeyWord("DEFAULT")
+				return nil
+			}
+			ctx.WritePlain("(")
+			if err := n.Expr.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore TableOption.Expr")
+			}
+			ctx.WritePlain(")")
+			return nil
+		})
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
@@ -2997,6 +3078,13 @@ func (n *TableOption) Accept(v Visitor) (Node, bool) {
 		}
 		n.TimeUnitValue = node.(*TimeUnitExpr)
 	}
+	if n.Expr != nil {
+		node, ok := n.Expr.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Expr = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -873,6 +873,10 @@ func TestTableOptionTTLRestore(t *testing.T) {
 	sourceSQL1 := "create table t (created_at datetime) ttl = created_at + INTERVAL 1 YEAR"
 	sourceSQL2 := "alter table t ttl_enable = 'OFF'"
 	sourceSQL3 := "alter table t remove ttl"
+	sourceSQL4 := "alter table t ttl_action = archive to table test.t_archive"
+	sourceSQL5 := "alter table t ttl_action = export to 's3://bucket/prefix' format 'PARQUET'"
+	sourceSQL6 := "alter table t ttl_where = (deleted = 1 and status in ('a', 'b'))"
+	sourceSQL7 := "alter table t ttl_where default"
 	cases := []struct {
 		sourceSQL string
 		flags     format.RestoreFlags
@@ -884,6 +888,14 @@ func TestTableOptionTTLRestore(t *testing.T) {
 		{sourceSQL2, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] TTL_ENABLE = 'OFF' */"},
 		{sourceSQL3, format.DefaultRestoreFlags, "ALTER TABLE `t` REMOVE TTL"},
 		{sourceSQL3, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] REMOVE TTL */"},
+		{sourceSQL4, format.DefaultRestoreFlags, "ALTER TABLE `t` TTL_ACTION = ARCHIVE TO TABLE `test`.`t_archive`"},
+		{sourceSQL4, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] TTL_ACTION = ARCHIVE TO TABLE `test`.`t_archive` */"},
+		{sourceSQL5, format.DefaultRestoreFlags, "ALTER TABLE `t` TTL_ACTION = EXPORT TO 's3://bucket/prefix' FORMAT = 'parquet'"},
+		{sourceSQL5, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] TTL_ACTION = EXPORT TO 's3://bucket/prefix' FORMAT = 'parquet' */"},
+		{sourceSQL6, format.DefaultRestoreFlags, "ALTER TABLE `t` TTL_WHERE = (`deleted`=1 AND `status` IN (_UTF8MB4'a',_UTF8MB4'b'))"},
+		{sourceSQL6, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] TTL_WHERE = (`deleted`=1 AND `status` IN (_UTF8MB4'a',_UTF8MB4'b')) */"},
+		{sourceSQL7, format.DefaultRestoreFlags, "ALTER TABLE `t` TTL_WHERE = DEFAULT"},
+		{sourceSQL7, format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment, "ALTER TABLE `t` /*T![ttl] TTL_WHERE = DEFAULT */"},
 	}
 
 	extractNodeFunc := func(node Node) Node {
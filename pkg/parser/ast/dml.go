@@ -14,12 +14,14 @@
 package ast
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
 var (
@@ -50,6 +52,7 @@ var (
 	_ Node = &TableName{}
 	_ Node = &TableRefsClause{}
 	_ Node = &TableSource{}
+	_ Node = &JSONTable{}
 	_ Node = &SetOprSelectList{}
 	_ Node = &WildCardField{}
 	_ Node = &WindowSpec{}
@@ -528,11 +531,15 @@ type TableSource struct {
 	node
 
 	// Source is the source of the data, can be a TableName,
-	// a SelectStmt, a SetOprStmt, or a JoinNode.
+	// a SelectStmt, a SetOprStmt, a JSONTable or a JoinNode.
 	Source ResultSetNode
 
 	// AsName is the alias name of the table source.
 	AsName CIStr
+
+	// Lateral indicates the derived table is declared with LATERAL, so it can
+	// reference the columns of the preceding tables in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -578,6 +585,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -611,6 +621,167 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTableColumnType is the type of a column in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumnType int
+
+// JSON_TABLE column types.
+const (
+	// JSONTableColumnPath is `name type PATH path [on_empty] [on_error]`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnExistsPath is `name type EXISTS PATH path`.
+	JSONTableColumnExistsPath
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnNested is `NESTED [PATH] path COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableResponseType is the behavior of a JSON_TABLE column when the path
+// matches nothing (ON EMPTY) or the matched value is invalid (ON ERROR).
+type JSONTableResponseType int
+
+// JSON_TABLE ON EMPTY / ON ERROR response types.
+const (
+	JSONTableResponseNull JSONTableResponseType = iota
+	JSONTableResponseError
+	JSONTableResponseDefault
+)
+
+// JSONTableResponse represents `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}`.
+type JSONTableResponse struct {
+	Tp JSONTableResponseType
+	// Default is the json_string of `DEFAULT json_string`.
+	Default string
+}
+
+func (n *JSONTableResponse) restore(ctx *format.RestoreCtx, event string) {
+	switch n.Tp {
+	case JSONTableResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+	ctx.WriteKeyWord(" ON ")
+	ctx.WriteKeyWord(event)
+}
+
+// JSONTableColumn represents a column definition in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp JSONTableColumnType
+	// Name is the column name, it's empty for nested columns.
+	Name CIStr
+	// FieldType is the column type, it's nil for ordinality and nested columns.
+	FieldType *types.FieldType
+	// Path is the JSON path of path, exists path and nested columns.
+	Path    string
+	OnEmpty *JSONTableResponse
+	OnError *JSONTableResponse
+	// Columns is the column list of a nested column.
+	Columns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, n.Columns)
+	}
+	ctx.WriteName(n.Name.O)
+	switch n.Tp {
+	case JSONTableColumnOrdinality:
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	case JSONTableColumnExistsPath:
+		ctx.WritePlain(" ")
+		if err := n.FieldType.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+		}
+		ctx.WriteKeyWord(" EXISTS PATH ")
+		ctx.WriteString(n.Path)
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		n.OnEmpty.restore(ctx, "EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		n.OnError.restore(ctx, "ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return err
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable represents the JSON_TABLE() table function in the FROM clause.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	// Expr is the JSON document the rows are extracted from.
+	Expr ExprNode
+	// Path is the row path.
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Columns")
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
@@ -1460,6 +1631,32 @@ func restoreTables(ctx *format.RestoreCtx, ts []*TableName) error {
 	return nil
 }
 
+var _ SensitiveStmtNode = &SelectStmt{}
+
+// hasSensitiveInfo implements optionalSensitiveStmtNode interface. The URI of `SELECT ... INTO OUTFILE` may contain
+// the credentials of the external storage in its query parameters.
+func (n *SelectStmt) hasSensitiveInfo() bool {
+	if n.SelectIntoOpt == nil || n.SelectIntoOpt.Tp != SelectIntoOutfile {
+		return false
+	}
+	u, err := url.Parse(n.SelectIntoOpt.FileName)
+	return err == nil && u.Scheme != "" && u.RawQuery != ""
+}
+
+// SecureText implements SensitiveStmtNode interface.
+func (n *SelectStmt) SecureText() string {
+	if !n.hasSensitiveInfo() {
+		return n.Text()
+	}
+	redactedIntoOpt := *n.SelectIntoOpt
+	redactedIntoOpt.FileName = RedactURL(n.SelectIntoOpt.FileName)
+	redactedStmt := *n
+	redactedStmt.SelectIntoOpt = &redactedIntoOpt
+	var sb strings.Builder
+	_ = redactedStmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
+
 // Accept implements Node Accept interface.
 func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
@@ -3054,6 +3251,9 @@ const (
 	ShowDistributions
 	ShowPlanForSQL
 	ShowDistributionJobs
+	ShowCreateFunction
+	ShowCreateTrigger
+	ShowCreateEvent
 )
 
 const (
@@ -3077,7 +3277,7 @@ type ShowStmt struct {
 	Tp     ShowStmtType // Databases/Tables/Columns/....
 	DBName string
 	Table  *TableName // Used for showing columns.
-	// Procedure's naming method is consistent with the table name
+	// Procedure's naming method is consistent with the table name, it's also used for functions, triggers and events.
 	Procedure         *TableName
 	Partition         CIStr       // Used for showing partition.
 	Column            *ColumnName // Used for `desc table column`.
@@ -3158,6 +3358,21 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := n.Procedure.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
 		}
+	case ShowCreateFunction:
+		ctx.WriteKeyWord("CREATE FUNCTION ")
+		if err := n.Procedure.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
+		}
+	case ShowCreateTrigger:
+		ctx.WriteKeyWord("CREATE TRIGGER ")
+		if err := n.Procedure.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
+		}
+	case ShowCreateEvent:
+		ctx.WriteKeyWord("CREATE EVENT ")
+		if err := n.Procedure.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
+		}
 	case ShowCreateView:
 		ctx.WriteKeyWord("CREATE VIEW ")
 		if err := n.Table.Restore(ctx); err != nil {
@@ -3647,17 +3862,51 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Variables is the target variables list of `SELECT ... INTO var_list`.
+	Variables []*SelectIntoVar
+	// Format is the file format of `SELECT ... INTO OUTFILE`, nil means the delimited text format.
+	Format *string
+	// Compression is the compression codec of the output files.
+	Compression string
+	// MaxFileSize is the max size of each output file, the result is split into multiple files when
+	// writing to an external storage.
+	MaxFileSize string
+}
+
+// SelectIntoVar is a target variable of `SELECT ... INTO var_list`.
+type SelectIntoVar struct {
+	Name string
+	// IsUserVar indicates the target is a user variable like `@a`,
+	// otherwise it's a local variable of a stored program.
+	IsUserVar bool
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Variables {
+			if i > 0 {
+				ctx.WritePlain(",")
+			}
+			if v.IsUserVar {
+				ctx.WritePlain("@")
+			}
+			ctx.WriteName(v.Name)
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE and INTO var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
 	ctx.WriteKeyWord("INTO OUTFILE ")
 	ctx.WriteString(n.FileName)
+	if n.Format != nil {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(*n.Format)
+	}
 	if n.FieldsInfo != nil {
 		if err := n.FieldsInfo.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.FieldsInfo")
@@ -3668,6 +3917,14 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if n.Compression != "" {
+		ctx.WriteKeyWord(" COMPRESSION ")
+		ctx.WriteString(n.Compression)
+	}
+	if n.MaxFileSize != "" {
+		ctx.WriteKeyWord(" MAX_FILE_SIZE ")
+		ctx.WriteString(n.MaxFileSize)
+	}
 	return nil
 }
 
@@ -3744,6 +4001,8 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ROWS")
 	case Ranges:
 		ctx.WriteKeyWord("RANGE")
+	case Groups:
+		ctx.WriteKeyWord("GROUPS")
 	default:
 		return errors.New("Unsupported window function frame type")
 	}
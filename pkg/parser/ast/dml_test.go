@@ -641,6 +641,43 @@ func TestImportIntoSecureText(t *testing.T) {
 	}
 }
 
+func TestSelectIntoOutfileSecureText(t *testing.T) {
+	testCases := []struct {
+		input     string
+		sensitive bool
+		secured   string
+	}{
+		{
+			input:     "select * from t into outfile 's3://bucket/prefix?access-key=aaaaa&secret-access-key=bbbbb' format 'parquet'",
+			sensitive: true,
+			secured:   "SELECT * FROM `t` INTO OUTFILE 's3://bucket/prefix?access-key=xxxxxx&secret-access-key=xxxxxx' FORMAT 'parquet'",
+		},
+		{
+			input:     "select * from t into outfile 'azure://bucket/prefix?account-name=a&sas-token=bbbbb'",
+			sensitive: true,
+			secured:   "SELECT * FROM `t` INTO OUTFILE 'azure://bucket/prefix?account-name=a&sas-token=xxxxxx'",
+		},
+		{input: "select * from t into outfile 's3://bucket/prefix'"},
+		{input: "select * from t into outfile '/tmp/a.csv'"},
+		{input: "select a from t into @a"},
+		{input: "select * from t where a = 1"},
+	}
+
+	p := parser.New()
+	for _, tc := range testCases {
+		comment := fmt.Sprintf("input = %s", tc.input)
+		node, err := p.ParseOneStmt(tc.input, "", "")
+		require.NoError(t, err, comment)
+		n, ok := AsSensitiveStmtNode(node)
+		require.Equal(t, tc.sensitive, ok, comment)
+		if ok {
+			require.Equal(t, tc.secured, n.SecureText(), comment)
+		} else {
+			require.Equal(t, tc.input, node.(*SelectStmt).SecureText(), comment)
+		}
+	}
+}
+
 func TestImportIntoFromSelectInvalidStmt(t *testing.T) {
 	p := parser.New()
 	_, err := p.ParseOneStmt("IMPORT INTO t1(a, @1) FROM select * from t2;", "", "")
@@ -0,0 +1,130 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent(t *testing.T) {
+	p := parser.New()
+	stmt, _, err := p.Parse("create event ev on schedule every 1 hour do delete from t where a < now()", "", "")
+	require.NoError(t, err)
+	create, ok := stmt[0].(*ast.CreateEventStmt)
+	require.True(t, ok)
+	require.Equal(t, "ev", create.EventName.Name.O)
+	require.Nil(t, create.Schedule.At)
+	require.Equal(t, ast.TimeUnitHour, create.Schedule.Unit)
+	require.Nil(t, create.Schedule.Starts)
+	require.Nil(t, create.Schedule.Ends)
+	require.Equal(t, ast.EventCompletionUnspecified, create.Completion)
+	require.Equal(t, ast.EventStatusUnspecified, create.Status)
+	require.Nil(t, create.Comment)
+	require.Equal(t, "delete from t where a < now()", create.Body.Text())
+
+	stmt, _, err = p.Parse("create definer = 'root'@'%' event if not exists test.ev on schedule at current_timestamp + interval 1 day "+
+		"on completion preserve disable on slave comment 'once' do begin insert into t values (1); end", "", "")
+	require.NoError(t, err)
+	create, ok = stmt[0].(*ast.CreateEventStmt)
+	require.True(t, ok)
+	require.True(t, create.IfNotExists)
+	require.Equal(t, "root", create.Definer.Username)
+	require.NotNil(t, create.Schedule.At)
+	require.Equal(t, ast.EventCompletionPreserve, create.Completion)
+	require.Equal(t, ast.EventStatusDisableOnSlave, create.Status)
+	require.Equal(t, "once", *create.Comment)
+	require.Equal(t, "begin insert into t values (1); end", create.Body.Text())
+
+	stmt, _, err = p.Parse("alter event ev on completion not preserve rename to ev2 enable", "", "")
+	require.NoError(t, err)
+	alter, ok := stmt[0].(*ast.AlterEventStmt)
+	require.True(t, ok)
+	require.Nil(t, alter.Schedule)
+	require.Equal(t, ast.EventCompletionNotPreserve, alter.Completion)
+	require.Equal(t, "ev2", alter.NewName.Name.O)
+	require.Equal(t, ast.EventStatusEnable, alter.Status)
+	require.Nil(t, alter.Body)
+
+	stmt, _, err = p.Parse("alter event ev do set @a = 1", "", "")
+	require.NoError(t, err)
+	alter, ok = stmt[0].(*ast.AlterEventStmt)
+	require.True(t, ok)
+	require.Equal(t, "set @a = 1", alter.Body.Text())
+
+	stmt, _, err = p.Parse("drop event if exists test.ev", "", "")
+	require.NoError(t, err)
+	drop, ok := stmt[0].(*ast.DropEventStmt)
+	require.True(t, ok)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "test", drop.EventName.Schema.O)
+
+	stmt, _, err = p.Parse("show create event ev", "", "")
+	require.NoError(t, err)
+	show, ok := stmt[0].(*ast.ShowStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateEvent), show.Tp)
+
+	for _, sql := range []string{
+		"create or replace event ev on schedule every 1 hour do select 1",
+		"create event ev on schedule every 1 do select 1",
+		"create event ev on schedule at now() starts now() do select 1",
+		"create event ev do select 1",
+		"alter event ev",
+	} {
+		_, _, err = p.Parse(sql, "", "")
+		require.Error(t, err, sql)
+	}
+
+	// The keywords of events are not reserved.
+	_, _, err = p.Parse("create table every (at int, starts int, ends int, completion int)", "", "")
+	require.NoError(t, err)
+}
+
+func TestEventRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"create event ev on schedule every 1 hour do delete from t",
+			"CREATE EVENT `ev` ON SCHEDULE EVERY 1 HOUR DO DELETE FROM `t`",
+		},
+		{
+			"create definer=`u`@`%` event if not exists test.ev on schedule every '1:30' minute_second starts '2026-01-01 00:00:00' ends '2027-01-01 00:00:00' on completion preserve disable comment 'c' do insert into t values (1)",
+			"CREATE DEFINER = `u`@`%` EVENT IF NOT EXISTS `test`.`ev` ON SCHEDULE EVERY _UTF8MB4'1:30' MINUTE_SECOND STARTS _UTF8MB4'2026-01-01 00:00:00' ENDS _UTF8MB4'2027-01-01 00:00:00' ON COMPLETION PRESERVE DISABLE COMMENT 'c' DO INSERT INTO `t` VALUES (1)",
+		},
+		{
+			"create event ev on schedule at now() + interval 1 day on completion not preserve do set @a = 1",
+			"CREATE EVENT `ev` ON SCHEDULE AT DATE_ADD(NOW(), INTERVAL 1 DAY) ON COMPLETION NOT PRESERVE DO SET @`a`=1",
+		},
+		{
+			"alter event test.ev on schedule at now() on completion preserve rename to ev2 disable on slave comment 'c' do set @a = 1",
+			"ALTER EVENT `test`.`ev` ON SCHEDULE AT NOW() ON COMPLETION PRESERVE RENAME TO `ev2` DISABLE ON SLAVE COMMENT 'c' DO SET @`a`=1",
+		},
+		{
+			"alter event ev enable",
+			"ALTER EVENT `ev` ENABLE",
+		},
+		{
+			"drop event if exists test.ev",
+			"DROP EVENT IF EXISTS `test`.`ev`",
+		},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
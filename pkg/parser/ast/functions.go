@@ -912,15 +912,16 @@ type WindowFuncExpr struct {
 	Name string
 	// Args is the function args.
 	Args []ExprNode
-	// Distinct cannot be true for most window functions, except `max` and `min`.
-	// We need to raise error if it is not allowed to be true.
+	// Distinct is only allowed for the aggregate functions.
 	Distinct bool
 	// IgnoreNull indicates how to handle null value.
-	// MySQL only supports `RESPECT NULLS`, so we need to raise error if it is true.
+	// It's only allowed for `first_value`, `last_value`, `nth_value`, `lead` and `lag`.
 	IgnoreNull bool
 	// FromLast indicates the calculation direction of this window function.
-	// MySQL only supports calculation from first, so we need to raise error if it is true.
+	// It's only allowed for `nth_value`.
 	FromLast bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 	// Spec is the specification of this window.
 	Spec WindowSpec
 }
@@ -929,7 +930,13 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.Name)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
+	args := n.Args
+	isGroupConcat := strings.ToLower(n.Name) == AggFuncGroupConcat
+	if isGroupConcat {
+		// The last arg of GROUP_CONCAT is the separator.
+		args = args[:len(args)-1]
+	}
+	for i, v := range args {
 		if i != 0 {
 			ctx.WritePlain(", ")
 		} else if n.Distinct {
@@ -939,6 +946,18 @@ func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
 		}
 	}
+	if isGroupConcat {
+		if n.Order != nil {
+			ctx.WritePlain(" ")
+			if err := n.Order.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Order")
+			}
+		}
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	}
 	ctx.WritePlain(")")
 	if n.FromLast {
 		ctx.WriteKeyWord(" FROM LAST")
@@ -973,6 +992,13 @@ func (n *WindowFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	node, ok := n.Spec.Accept(v)
 	if !ok {
 		return n, false
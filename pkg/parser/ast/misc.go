@@ -2121,6 +2121,24 @@ func (n *RecommendIndexStmt) Restore(ctx *format.RestoreCtx) error {
 				}
 			}
 		}
+	case "explain":
+		ctx.WriteKeyWord(" EXPLAIN FOR ")
+		ctx.WriteString(n.SQL)
+	case "drop":
+		ctx.WriteKeyWord(" DROP")
+		if len(n.Options) > 0 {
+			ctx.WriteKeyWord(" WITH ")
+			for i, opt := range n.Options {
+				if i != 0 {
+					ctx.WritePlain(", ")
+				}
+				ctx.WriteKeyWord(opt.Option)
+				ctx.WritePlain(" = ")
+				if err := opt.Value.Restore(ctx); err != nil {
+					return errors.Annotatef(err, "An error occurred while restore RecommendIndexStmt.Options[%d]", i)
+				}
+			}
+		}
 	case "show":
 		ctx.WriteKeyWord(" SHOW OPTION")
 	case "apply":
@@ -3788,7 +3806,7 @@ func (n *BRIEStmt) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
-// RedactURL redacts the secret tokens in the URL. only S3 url need redaction for now.
+// RedactURL redacts the secret tokens in the URL. only S3 and Azure url need redaction for now.
 // if the url is not a valid url, return the original string.
 func RedactURL(str string) string {
 	// FIXME: this solution is not scalable, and duplicates some logic from BR.
@@ -3812,6 +3830,15 @@ func RedactURL(str string) string {
 			}
 		}
 		u.RawQuery = values.Encode()
+	case "azure", "azblob":
+		values := u.Query()
+		for k := range values {
+			normalizedKey := strings.ToLower(strings.ReplaceAll(k, "_", "-"))
+			if normalizedKey == "account-key" || normalizedKey == "sas-token" {
+				values[k] = []string{"xxxxxx"}
+			}
+		}
+		u.RawQuery = values.Encode()
 	}
 	return u.String()
 }
@@ -356,6 +356,8 @@ func TestRedactURL(t *testing.T) {
 		// underline
 		{args{"s3://bucket/file?access_key=123"}, "s3://bucket/file?access_key=xxxxxx"},
 		{args{"s3://bucket/file?secret_access_key=123"}, "s3://bucket/file?secret_access_key=xxxxxx"},
+		{args{"azure://bucket/file?account-name=a&account-key=123"}, "azure://bucket/file?account-key=xxxxxx&account-name=a"},
+		{args{"azblob://bucket/file?sas_token=123"}, "azblob://bucket/file?sas_token=xxxxxx"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.args.str, func(t *testing.T) {
@@ -0,0 +1,192 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+var (
+	_ DDLNode = &CreateMaterializedViewStmt{}
+	_ DDLNode = &DropMaterializedViewStmt{}
+	_ DDLNode = &RefreshMaterializedViewStmt{}
+)
+
+// MViewRefreshMethod is the way to refresh a materialized view.
+type MViewRefreshMethod int
+
+// MViewRefreshMethod types.
+const (
+	// MViewRefreshDefault is only used by REFRESH MATERIALIZED VIEW, it means
+	// the refresh method of the materialized view is used.
+	MViewRefreshDefault MViewRefreshMethod = iota
+	// MViewRefreshComplete recomputes the whole result of the view.
+	MViewRefreshComplete
+	// MViewRefreshFast only recomputes the groups changed since the last refresh.
+	MViewRefreshFast
+)
+
+// String implements fmt.Stringer interface.
+func (m MViewRefreshMethod) String() string {
+	switch m {
+	case MViewRefreshComplete:
+		return "COMPLETE"
+	case MViewRefreshFast:
+		return "FAST"
+	default:
+		return ""
+	}
+}
+
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+// The result of the SELECT statement is stored in a regular table, which is
+// refreshed by REFRESH MATERIALIZED VIEW.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists   bool
+	ViewName      *TableName
+	Cols          []CIStr
+	RefreshMethod MViewRefreshMethod
+	Select        StmtNode
+
+	// SchemaCols and SchemaTypes are the names and types of the output
+	// columns of Select, they are filled by the planner.
+	SchemaCols  []CIStr
+	SchemaTypes []*types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	ctx.WriteKeyWord(" REFRESH ")
+	ctx.WriteKeyWord(n.RefreshMethod.String())
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropMaterializedViewStmt is a statement to drop a materialized view.
+type DropMaterializedViewStmt struct {
+	ddlNode
+
+	IfExists bool
+	ViewName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropMaterializedViewStmt.ViewName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh the stored result of
+// a materialized view.
+type RefreshMaterializedViewStmt struct {
+	ddlNode
+
+	ViewName *TableName
+	Method   MViewRefreshMethod
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Method != MViewRefreshDefault {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Method.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedView(t *testing.T) {
+	p := parser.New()
+	stmt, _, err := p.Parse("create materialized view mv as select a, count(*) from t group by a", "", "")
+	require.NoError(t, err)
+	create, ok := stmt[0].(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.False(t, create.IfNotExists)
+	require.Equal(t, "mv", create.ViewName.Name.O)
+	require.Nil(t, create.Cols)
+	require.Equal(t, ast.MViewRefreshComplete, create.RefreshMethod)
+	require.Equal(t, "select a, count(*) from t group by a", create.Select.Text())
+
+	stmt, _, err = p.Parse("create materialized view if not exists test.mv (x, y) refresh fast as select a, sum(b) from t group by a", "", "")
+	require.NoError(t, err)
+	create, ok = stmt[0].(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.True(t, create.IfNotExists)
+	require.Equal(t, "test", create.ViewName.Schema.O)
+	require.Equal(t, []ast.CIStr{ast.NewCIStr("x"), ast.NewCIStr("y")}, create.Cols)
+	require.Equal(t, ast.MViewRefreshFast, create.RefreshMethod)
+
+	stmt, _, err = p.Parse("drop materialized view if exists test.mv", "", "")
+	require.NoError(t, err)
+	drop, ok := stmt[0].(*ast.DropMaterializedViewStmt)
+	require.True(t, ok)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "test", drop.ViewName.Schema.O)
+
+	stmt, _, err = p.Parse("refresh materialized view mv", "", "")
+	require.NoError(t, err)
+	refresh, ok := stmt[0].(*ast.RefreshMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.MViewRefreshDefault, refresh.Method)
+
+	stmt, _, err = p.Parse("refresh materialized view mv complete", "", "")
+	require.NoError(t, err)
+	refresh, ok = stmt[0].(*ast.RefreshMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.MViewRefreshComplete, refresh.Method)
+
+	for _, sql := range []string{
+		"create or replace materialized view mv as select 1",
+		"create definer = 'root'@'%' materialized view mv as select 1",
+		"create materialized view mv refresh as select 1",
+		"create materialized view mv as select 1 with check option",
+		"drop materialized view mv1, mv2",
+		"refresh materialized view mv incremental",
+	} {
+		_, _, err = p.Parse(sql, "", "")
+		require.Error(t, err, sql)
+	}
+
+	// The keywords of materialized views are not reserved.
+	_, _, err = p.Parse("create table materialized (refresh int, fast int, complete int)", "", "")
+	require.NoError(t, err)
+}
+
+func TestMaterializedViewRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"create materialized view mv as select a, count(*) from t group by a",
+			"CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`",
+		},
+		{
+			"create materialized view if not exists test.mv (x, y) refresh fast as select a, sum(b) from t where c > 1 group by a",
+			"CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`x`,`y`) REFRESH FAST AS SELECT `a`,SUM(`b`) FROM `t` WHERE `c`>1 GROUP BY `a`",
+		},
+		{
+			"drop materialized view if exists test.mv",
+			"DROP MATERIALIZED VIEW IF EXISTS `test`.`mv`",
+		},
+		{
+			"refresh materialized view mv",
+			"REFRESH MATERIALIZED VIEW `mv`",
+		},
+		{
+			"refresh materialized view test.mv fast",
+			"REFRESH MATERIALIZED VIEW `test`.`mv` FAST",
+		},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
@@ -17,6 +17,7 @@ import (
 	"strconv"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/types"
 )
@@ -24,10 +25,11 @@ import (
 var (
 	_ Node = &StoreParameter{}
 	_ Node = &ProcedureDecl{}
+	_ Node = &ProcedureCharacteristic{}
 
 	_ StmtNode = &ProcedureBlock{}
-	_ StmtNode = &ProcedureInfo{}
-	_ StmtNode = &DropProcedureStmt{}
+	_ DDLNode  = &ProcedureInfo{}
+	_ DDLNode  = &DropProcedureStmt{}
 	_ StmtNode = &ProcedureElseIfBlock{}
 	_ StmtNode = &ProcedureElseBlock{}
 	_ StmtNode = &ProcedureIfBlock{}
@@ -36,6 +38,7 @@ var (
 	_ StmtNode = &ProcedureLabelBlock{}
 	_ StmtNode = &ProcedureLabelLoop{}
 	_ StmtNode = &ProcedureJump{}
+	_ StmtNode = &ProcedureReturn{}
 
 	_ DeclNode = &ProcedureErrorControl{}
 	_ DeclNode = &ProcedureCursor{}
@@ -69,6 +72,43 @@ const (
 	PROCEDUR_END
 )
 
+// ProcedureCharacteristicType is the type of a stored routine characteristic.
+type ProcedureCharacteristicType int
+
+// stored routine characteristic types.
+const (
+	ProcedureCharacteristicComment ProcedureCharacteristicType = iota
+	ProcedureCharacteristicLanguage
+	ProcedureCharacteristicDeterministic
+	ProcedureCharacteristicSQLSecurity
+	ProcedureCharacteristicDataAccess
+)
+
+// ProcedureDataAccess is the SQL data access characteristic of a stored routine.
+type ProcedureDataAccess int
+
+// stored routine SQL data access characteristics.
+const (
+	ProcedureContainsSQL ProcedureDataAccess = iota
+	ProcedureNoSQL
+	ProcedureReadsSQLData
+	ProcedureModifiesSQLData
+)
+
+// String implements fmt.Stringer interface.
+func (a ProcedureDataAccess) String() string {
+	switch a {
+	case ProcedureNoSQL:
+		return "NO SQL"
+	case ProcedureReadsSQLData:
+		return "READS SQL DATA"
+	case ProcedureModifiesSQLData:
+		return "MODIFIES SQL DATA"
+	default:
+		return "CONTAINS SQL"
+	}
+}
+
 // DeclNode expresses procedure block variable interface(include handler\cursor\sp variable)
 type DeclNode interface {
 	Node
@@ -230,18 +270,38 @@ func (n *ProcedureBlock) Accept(v Visitor) (Node, bool) {
 }
 
 // ProcedureInfo stores all procedure information.
+// It's also used for `CREATE FUNCTION`, in which case IsFunction is true.
 type ProcedureInfo struct {
-	stmtNode
+	ddlNode
 	IfNotExists       bool
+	IsFunction        bool
+	Definer           *auth.UserIdentity
 	ProcedureName     *TableName
 	ProcedureParam    []*StoreParameter //procedure param
-	ProcedureBody     StmtNode          //procedure body statement
-	ProcedureParamStr string            //procedure parameter string
+	ReturnType        *types.FieldType  //function return type
+	Characteristics   []*ProcedureCharacteristic
+	ProcedureBody     StmtNode //procedure body statement
+	ProcedureParamStr string   //procedure parameter string
 }
 
 // Restore implements Node interface.
 func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("CREATE PROCEDURE ")
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		ctx.WriteName(n.Definer.Username)
+		if n.Definer.Hostname != "" {
+			ctx.WritePlain("@")
+			ctx.WriteName(n.Definer.Hostname)
+		}
+		ctx.WritePlain(" ")
+	}
+	if n.IsFunction {
+		ctx.WriteKeyWord("FUNCTION ")
+	} else {
+		ctx.WriteKeyWord("PROCEDURE ")
+	}
 	if n.IfNotExists {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
@@ -254,12 +314,32 @@ func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
 		if i > 0 {
 			ctx.WritePlain(",")
 		}
+		if n.IsFunction {
+			// function parameters are always IN parameters and can't be declared with a mode.
+			ctx.WriteName(ProcedureParam.ParamName)
+			ctx.WritePlain(" ")
+			ctx.WriteKeyWord(ProcedureParam.ParamType.CompactStr())
+			continue
+		}
 		err := ProcedureParam.Restore(ctx)
 		if err != nil {
 			return err
 		}
 	}
 	ctx.WritePlain(") ")
+	if n.IsFunction {
+		ctx.WriteKeyWord("RETURNS ")
+		if err := n.ReturnType.Restore(ctx); err != nil {
+			return err
+		}
+		ctx.WritePlain(" ")
+	}
+	for _, c := range n.Characteristics {
+		if err := c.Restore(ctx); err != nil {
+			return err
+		}
+		ctx.WritePlain(" ")
+	}
 	err = (n.ProcedureBody).Restore(ctx)
 	if err != nil {
 		return err
@@ -281,6 +361,13 @@ func (n *ProcedureInfo) Accept(v Visitor) (Node, bool) {
 		}
 		n.ProcedureParam[i] = node.(*StoreParameter)
 	}
+	for i, c := range n.Characteristics {
+		node, ok := c.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Characteristics[i] = node.(*ProcedureCharacteristic)
+	}
 	node, ok := n.ProcedureBody.Accept(v)
 	if !ok {
 		return n, false
@@ -289,17 +376,68 @@ func (n *ProcedureInfo) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
-// DropProcedureStmt represents the ast of `drop procedure`
+// ProcedureCharacteristic is a characteristic of a stored routine,
+// such as `COMMENT 'text'`, `DETERMINISTIC` and `SQL SECURITY INVOKER`.
+type ProcedureCharacteristic struct {
+	node
+
+	Tp            ProcedureCharacteristicType
+	Comment       string
+	Deterministic bool
+	Security      ViewSecurity
+	DataAccess    ProcedureDataAccess
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCharacteristic) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case ProcedureCharacteristicComment:
+		ctx.WriteKeyWord("COMMENT ")
+		ctx.WriteString(n.Comment)
+	case ProcedureCharacteristicLanguage:
+		ctx.WriteKeyWord("LANGUAGE SQL")
+	case ProcedureCharacteristicDeterministic:
+		if !n.Deterministic {
+			ctx.WriteKeyWord("NOT ")
+		}
+		ctx.WriteKeyWord("DETERMINISTIC")
+	case ProcedureCharacteristicSQLSecurity:
+		ctx.WriteKeyWord("SQL SECURITY ")
+		ctx.WriteKeyWord(n.Security.String())
+	case ProcedureCharacteristicDataAccess:
+		ctx.WriteKeyWord(n.DataAccess.String())
+	default:
+		return errors.Errorf("invalid ProcedureCharacteristic: %d", n.Tp)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCharacteristic) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureCharacteristic)
+	return v.Leave(n)
+}
+
+// DropProcedureStmt represents the ast of `drop procedure` and `drop function`.
 type DropProcedureStmt struct {
-	stmtNode
+	ddlNode
 
 	IfExists      bool
+	IsFunction    bool
 	ProcedureName *TableName
 }
 
 // Restore implements DropProcedureStmt interface.
 func (n *DropProcedureStmt) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("DROP PROCEDURE ")
+	if n.IsFunction {
+		ctx.WriteKeyWord("DROP FUNCTION ")
+	} else {
+		ctx.WriteKeyWord("DROP PROCEDURE ")
+	}
 	if n.IfExists {
 		ctx.WriteKeyWord("IF EXISTS ")
 	}
@@ -1161,7 +1299,7 @@ func (n *ProcedureJump) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ITERATE ")
 	}
 
-	ctx.WriteString(n.Name)
+	ctx.WriteName(n.Name)
 	return nil
 }
 
@@ -1174,3 +1312,33 @@ func (n *ProcedureJump) Accept(v Visitor) (Node, bool) {
 	n = newNode.(*ProcedureJump)
 	return v.Leave(n)
 }
+
+// ProcedureReturn stores the `RETURN expr` statement of a stored function.
+type ProcedureReturn struct {
+	stmtNode
+	Expr ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureReturn) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RETURN ")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureReturn.Expr")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureReturn) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureReturn)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
@@ -26,6 +26,7 @@ func TestProcedureVisitorCover(t *testing.T) {
 	stmts := []ast.Node{
 		&ast.StoreParameter{},
 		&ast.ProcedureDecl{},
+		&ast.ProcedureCharacteristic{},
 	}
 	for _, v := range stmts {
 		v.Accept(visitor{})
@@ -35,6 +36,7 @@ func TestProcedureVisitorCover(t *testing.T) {
 		&ast.ProcedureBlock{},
 		&ast.ProcedureInfo{ProcedureBody: &ast.ProcedureBlock{}},
 		&ast.DropProcedureStmt{},
+		&ast.ProcedureReturn{Expr: &ast.ColumnNameExpr{Name: &ast.ColumnName{}}},
 	}
 	for _, v := range stmts2 {
 		v.Accept(visitor{})
@@ -99,6 +101,12 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2() comment 'test' language sql not deterministic contains sql sql security invoker select 1`,
+		`create definer = 'root'@'%' procedure proc_2(out a int) modifies sql data begin select count(*) into a from t1; end`,
+		`create function func_1(a int) returns int deterministic return a + 1`,
+		`create function if not exists func_1(a int, b varchar(10)) returns varchar(20) reads sql data begin declare c varchar(20); set c = concat(b, a); return c; end`,
+		`create function func_1(a int) returns int no sql if a > 1 then return 1; else return 0; end if`,
+		`create procedure proc_2(id int) begin call proc_1(id, @a); call test.proc_3; end`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -121,6 +129,32 @@ func TestShowCreateProcedure(t *testing.T) {
 	require.NoError(t, err)
 	_, ok = stmt[0].(*ast.DropProcedureStmt)
 	require.True(t, ok)
+	stmt, _, err = p.Parse("show create function func_1", "", "")
+	require.NoError(t, err)
+	show, ok := stmt[0].(*ast.ShowStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateFunction), show.Tp)
+	stmt, _, err = p.Parse("drop function if exists func_1", "", "")
+	require.NoError(t, err)
+	drop, ok := stmt[0].(*ast.DropProcedureStmt)
+	require.True(t, ok)
+	require.True(t, drop.IsFunction)
+	require.True(t, drop.IfExists)
+}
+
+func TestInvalidRoutine(t *testing.T) {
+	p := parser.New()
+	testcases := []string{
+		"create or replace procedure proc_2() select 1",
+		"create sql security invoker procedure proc_2() select 1",
+		"create function func_1(out a int) returns int return 1",
+		"create function func_1() return 1",
+		"create function func_1() returns int select 1",
+	}
+	for _, testcase := range testcases {
+		_, _, err := p.Parse(testcase, "", "")
+		require.Error(t, err, testcase)
+	}
 }
 
 func TestProcedureVisitor(t *testing.T) {
@@ -217,6 +251,18 @@ func TestProcedureRestore(t *testing.T) {
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 		},
+		{
+			"CREATE DEFINER = `root`@`%` PROCEDURE `proc_2`( OUT `a` INT(11)) COMMENT 'test' SQL SECURITY INVOKER MODIFIES SQL DATA BEGIN SELECT COUNT(1) FROM `t1` INTO `a`; END",
+			"CREATE DEFINER = `root`@`%` PROCEDURE `proc_2`( OUT `a` INT(11)) COMMENT 'test' SQL SECURITY INVOKER MODIFIES SQL DATA BEGIN SELECT COUNT(1) FROM `t1` INTO `a`; END",
+		},
+		{
+			"CREATE FUNCTION `func_1`(`a` INT(11)) RETURNS INT(11) DETERMINISTIC RETURN `a`+1",
+			"CREATE FUNCTION `func_1`(`a` INT(11)) RETURNS INT(11) DETERMINISTIC RETURN `a`+1",
+		},
+		{
+			"CREATE FUNCTION IF NOT EXISTS `func_1`(`a` INT(11)) RETURNS VARCHAR(20) NOT DETERMINISTIC READS SQL DATA BEGIN DECLARE `c` VARCHAR(20);SET @@SESSION.`c`=CONCAT(`a`);RETURN `c`; END",
+			"CREATE FUNCTION IF NOT EXISTS `func_1`(`a` INT(11)) RETURNS VARCHAR(20) NOT DETERMINISTIC READS SQL DATA BEGIN DECLARE `c` VARCHAR(20);SET @@SESSION.`c`=CONCAT(`a`);RETURN `c`; END",
+		},
 	}
 	extractNodeFunc := func(node ast.Node) ast.Node {
 		return node.(*ast.ProcedureInfo)
@@ -0,0 +1,96 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrigger(t *testing.T) {
+	p := parser.New()
+	stmt, _, err := p.Parse("create trigger trg before insert on t for each row set new.a = new.a + 1", "", "")
+	require.NoError(t, err)
+	create, ok := stmt[0].(*ast.CreateTriggerStmt)
+	require.True(t, ok)
+	require.Equal(t, "trg", create.TriggerName.Name.O)
+	require.Equal(t, ast.TriggerBefore, create.Timing)
+	require.Equal(t, ast.TriggerInsert, create.Event)
+	require.Equal(t, "t", create.Table.Name.O)
+	require.Nil(t, create.Order)
+	require.Equal(t, "set new.a = new.a + 1", create.Body.Text())
+
+	stmt, _, err = p.Parse("create definer = 'root'@'%' trigger if not exists test.trg after delete on test.t for each row follows trg2 begin insert into log values (old.a); end", "", "")
+	require.NoError(t, err)
+	create, ok = stmt[0].(*ast.CreateTriggerStmt)
+	require.True(t, ok)
+	require.True(t, create.IfNotExists)
+	require.Equal(t, "root", create.Definer.Username)
+	require.Equal(t, ast.TriggerAfter, create.Timing)
+	require.Equal(t, ast.TriggerDelete, create.Event)
+	require.True(t, create.Order.Follows)
+	require.Equal(t, "trg2", create.Order.TriggerName.O)
+	require.Equal(t, "begin insert into log values (old.a); end", create.Body.Text())
+
+	stmt, _, err = p.Parse("drop trigger if exists test.trg", "", "")
+	require.NoError(t, err)
+	drop, ok := stmt[0].(*ast.DropTriggerStmt)
+	require.True(t, ok)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "test", drop.TriggerName.Schema.O)
+
+	stmt, _, err = p.Parse("show create trigger trg", "", "")
+	require.NoError(t, err)
+	show, ok := stmt[0].(*ast.ShowStmt)
+	require.True(t, ok)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateTrigger), show.Tp)
+
+	for _, sql := range []string{
+		"create or replace trigger trg before insert on t for each row set new.a = 1",
+		"create trigger trg before select on t for each row set new.a = 1",
+		"create trigger trg before insert on t set new.a = 1",
+	} {
+		_, _, err = p.Parse(sql, "", "")
+		require.Error(t, err, sql)
+	}
+
+	// The keywords of triggers are not reserved.
+	_, _, err = p.Parse("create table each (before int, follows int, precedes int)", "", "")
+	require.NoError(t, err)
+}
+
+func TestTriggerRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"create trigger trg before insert on t for each row set new.a = 1",
+			"CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=1",
+		},
+		{
+			"create definer=`u`@`%` trigger if not exists test.trg after update on test.t for each row precedes trg2 update log set b = new.a where a = old.a",
+			"CREATE DEFINER = `u`@`%` TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW PRECEDES `trg2` UPDATE `log` SET `b`=`new`.`a` WHERE `a`=`old`.`a`",
+		},
+		{
+			"drop trigger if exists test.trg",
+			"DROP TRIGGER IF EXISTS `test`.`trg`",
+		},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
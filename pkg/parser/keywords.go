@@ -134,6 +134,7 @@ var Keywords = []KeywordsType{
 	{"KILL", true, "reserved"},
 	{"LAG", true, "reserved"},
 	{"LAST_VALUE", true, "reserved"},
+	{"LATERAL", true, "reserved"},
 	{"LEAD", true, "reserved"},
 	{"LEADING", true, "reserved"},
 	{"LEAVE", true, "reserved"},
@@ -195,6 +196,7 @@ var Keywords = []KeywordsType{
 	{"REPLACE", true, "reserved"},
 	{"REQUIRE", true, "reserved"},
 	{"RESTRICT", true, "reserved"},
+	{"RETURN", true, "reserved"},
 	{"REVOKE", true, "reserved"},
 	{"RIGHT", true, "reserved"},
 	{"RLIKE", true, "reserved"},
@@ -267,7 +269,9 @@ var Keywords = []KeywordsType{
 	{"ALWAYS", false, "unreserved"},
 	{"ANY", false, "unreserved"},
 	{"APPLY", false, "unreserved"},
+	{"ARCHIVE", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -280,6 +284,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -318,6 +323,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"COMPRESSION_LEVEL", false, "unreserved"},
@@ -327,6 +334,7 @@ var Keywords = []KeywordsType{
 	{"CONNECTION", false, "unreserved"},
 	{"CONSISTENCY", false, "unreserved"},
 	{"CONSISTENT", false, "unreserved"},
+	{"CONTAINS", false, "unreserved"},
 	{"CONTEXT", false, "unreserved"},
 	{"CPU", false, "unreserved"},
 	{"CSV_BACKSLASH_ESCAPE", false, "unreserved"},
@@ -346,6 +354,7 @@ var Keywords = []KeywordsType{
 	{"DECLARE", false, "unreserved"},
 	{"DEFINER", false, "unreserved"},
 	{"DELAY_KEY_WRITE", false, "unreserved"},
+	{"DETERMINISTIC", false, "unreserved"},
 	{"DIGEST", false, "unreserved"},
 	{"DIRECTORY", false, "unreserved"},
 	{"DISABLE", false, "unreserved"},
@@ -355,12 +364,15 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
+	{"EMPTY", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"ENCRYPTION_KEYFILE", false, "unreserved"},
 	{"ENCRYPTION_METHOD", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -371,14 +383,17 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
 	{"EXECUTE", false, "unreserved"},
 	{"EXPANSION", false, "unreserved"},
 	{"EXPIRE", false, "unreserved"},
+	{"EXPORT", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
+	{"FAST", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
 	{"FILE", false, "unreserved"},
@@ -386,6 +401,7 @@ var Keywords = []KeywordsType{
 	{"FIXED", false, "unreserved"},
 	{"FLUSH", false, "unreserved"},
 	{"FOLLOWING", false, "unreserved"},
+	{"FOLLOWS", false, "unreserved"},
 	{"FORMAT", false, "unreserved"},
 	{"FOUND", false, "unreserved"},
 	{"FULL", false, "unreserved"},
@@ -432,7 +448,9 @@ var Keywords = []KeywordsType{
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
+	{"MAX_FILE_SIZE", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
 	{"MAX_QUERIES_PER_HOUR", false, "unreserved"},
@@ -448,11 +466,13 @@ var Keywords = []KeywordsType{
 	{"MINVALUE", false, "unreserved"},
 	{"MIN_ROWS", false, "unreserved"},
 	{"MODE", false, "unreserved"},
+	{"MODIFIES", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
+	{"NESTED", false, "unreserved"},
 	{"NEVER", false, "unreserved"},
 	{"NEXT", false, "unreserved"},
 	{"NEXTVAL", false, "unreserved"},
@@ -477,6 +497,7 @@ var Keywords = []KeywordsType{
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"ORDINALITY", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
@@ -485,6 +506,7 @@ var Keywords = []KeywordsType{
 	{"PARTITIONS", false, "unreserved"},
 	{"PASSWORD", false, "unreserved"},
 	{"PASSWORD_LOCK_TIME", false, "unreserved"},
+	{"PATH", false, "unreserved"},
 	{"PAUSE", false, "unreserved"},
 	{"PERCENT", false, "unreserved"},
 	{"PER_DB", false, "unreserved"},
@@ -492,6 +514,7 @@ var Keywords = []KeywordsType{
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"PRECEDES", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
@@ -508,10 +531,12 @@ var Keywords = []KeywordsType{
 	{"QUERY", false, "unreserved"},
 	{"QUICK", false, "unreserved"},
 	{"RATE_LIMIT", false, "unreserved"},
+	{"READS", false, "unreserved"},
 	{"REBUILD", false, "unreserved"},
 	{"RECOMMEND", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -527,6 +552,7 @@ var Keywords = []KeywordsType{
 	{"RESTORE", false, "unreserved"},
 	{"RESTORES", false, "unreserved"},
 	{"RESUME", false, "unreserved"},
+	{"RETURNS", false, "unreserved"},
 	{"REUSE", false, "unreserved"},
 	{"REVERSE", false, "unreserved"},
 	{"ROLE", false, "unreserved"},
@@ -578,6 +604,7 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -616,8 +643,10 @@ var Keywords = []KeywordsType{
 	{"TRUNCATE", false, "unreserved"},
 	{"TSO", false, "unreserved"},
 	{"TTL", false, "unreserved"},
+	{"TTL_ACTION", false, "unreserved"},
 	{"TTL_ENABLE", false, "unreserved"},
 	{"TTL_JOB_INTERVAL", false, "unreserved"},
+	{"TTL_WHERE", false, "unreserved"},
 	{"TYPE", false, "unreserved"},
 	{"UNBOUNDED", false, "unreserved"},
 	{"UNCOMMITTED", false, "unreserved"},
@@ -36,7 +36,7 @@ func TestKeywords(t *testing.T) {
 }
 
 func TestKeywordsLength(t *testing.T) {
-	require.Equal(t, 661, len(parser.Keywords))
+	require.Equal(t, 690, len(parser.Keywords))
 
 	reservedNr := 0
 	for _, kw := range parser.Keywords {
@@ -44,7 +44,7 @@ func TestKeywordsLength(t *testing.T) {
 			reservedNr += 1
 		}
 	}
-	require.Equal(t, 232, reservedNr)
+	require.Equal(t, 234, reservedNr)
 }
 
 func TestKeywordsSorting(t *testing.T) {
@@ -178,7 +178,9 @@ var tokenMap = map[string]int{
 	"AS":                         as,
 	"ASC":                        asc,
 	"ASCII":                      ascii,
+	"AT":                         at,
 	"APPLY":                      apply,
+	"ARCHIVE":                    archive,
 	"ATTRIBUTE":                  attribute,
 	"ATTRIBUTES":                 attributes,
 	"BATCH":                      batch,
@@ -197,6 +199,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                     backup,
 	"BACKUPS":                    backups,
 	"BDR":                        bdr,
+	"BEFORE":                     before,
 	"BEGIN":                      begin,
 	"BETWEEN":                    between,
 	"BERNOULLI":                  bernoulli,
@@ -263,6 +266,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                     commit,
 	"COMMITTED":                  committed,
 	"COMPACT":                    compact,
+	"COMPLETE":                   complete,
+	"COMPLETION":                 completion,
 	"COMPRESS":                   compress,
 	"COMPRESSED":                 compressed,
 	"COMPRESSION":                compression,
@@ -273,6 +278,7 @@ var tokenMap = map[string]int{
 	"CONSISTENT":                 consistent,
 	"CONSTRAINT":                 constraint,
 	"CONSTRAINTS":                constraints,
+	"CONTAINS":                   contains,
 	"CONTEXT":                    context,
 	"CONTINUE":                   continueKwd,
 	"CONVERT":                    convert,
@@ -329,6 +335,7 @@ var tokenMap = map[string]int{
 	"DEFINED":                    defined,
 	"DEFINER":                    definer,
 	"DELAY_KEY_WRITE":            delayKeyWrite,
+	"DETERMINISTIC":              deterministic,
 	"DELAYED":                    delayed,
 	"DELETE":                     deleteKwd,
 	"DEPENDENCY":                 dependency,
@@ -358,13 +365,16 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                  duplicate,
 	"DURATION":                   timeDuration,
 	"DYNAMIC":                    dynamic,
+	"EACH":                       each,
 	"ELSE":                       elseKwd,
 	"ELSEIF":                     elseIfKwd,
+	"EMPTY":                      emptyKwd,
 	"ENABLE":                     enable,
 	"ENABLED":                    enabled,
 	"ENCLOSED":                   enclosed,
 	"ENCRYPTION":                 encryption,
 	"END":                        end,
+	"ENDS":                       ends,
 	"END_TIME":                   endTime,
 	"ENFORCED":                   enforced,
 	"ENGINE":                     engine,
@@ -378,6 +388,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                    escaped,
 	"EVENT":                      event,
 	"EVENTS":                     events,
+	"EVERY":                      every,
 	"EVOLVE":                     evolve,
 	"EXACT":                      exact,
 	"EXEC_ELAPSED":               execElapsed,
@@ -389,11 +400,13 @@ var tokenMap = map[string]int{
 	"EXIT":                       exit,
 	"EXPANSION":                  expansion,
 	"EXPIRE":                     expire,
+	"EXPORT":                     export,
 	"EXPLAIN":                    explain,
 	"EXPR_PUSHDOWN_BLACKLIST":    exprPushdownBlacklist,
 	"EXTENDED":                   extended,
 	"EXTRACT":                    extract,
 	"FALSE":                      falseKwd,
+	"FAST":                       fast,
 	"FAULTS":                     faultsSym,
 	"FETCH":                      fetch,
 	"FIELDS":                     fields,
@@ -409,6 +422,7 @@ var tokenMap = map[string]int{
 	"FOLLOWERS":                  followers,
 	"FOLLOWER_CONSTRAINTS":       followerConstraints,
 	"FOLLOWING":                  following,
+	"FOLLOWS":                    follows,
 	"FOR":                        forKwd,
 	"FORCE":                      force,
 	"FOREIGN":                    foreign,
@@ -492,6 +506,7 @@ var tokenMap = map[string]int{
 	"JOIN":                       join,
 	"JSON_ARRAYAGG":              jsonArrayagg,
 	"JSON_OBJECTAGG":             jsonObjectAgg,
+	"JSON_TABLE":                 jsonTable,
 	"JSON":                       jsonType,
 	"KEY_BLOCK_SIZE":             keyBlockSize,
 	"KEY":                        key,
@@ -502,6 +517,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":                lastBackup,
 	"LAST":                       last,
 	"LASTVAL":                    lastval,
+	"LATERAL":                    lateral,
 	"LEADER":                     leader,
 	"LEADER_CONSTRAINTS":         leaderConstraints,
 	"LEADING":                    leading,
@@ -531,8 +547,10 @@ var tokenMap = map[string]int{
 	"LONGTEXT":                   longtextType,
 	"LOW_PRIORITY":               lowPriority,
 	"MASTER":                     master,
+	"MATERIALIZED":               materialized,
 	"MATCH":                      match,
 	"MAX_CONNECTIONS_PER_HOUR":   maxConnectionsPerHour,
+	"MAX_FILE_SIZE":              maxFileSize,
 	"MAX_IDXNUM":                 max_idxnum,
 	"MAX_MINUTES":                max_minutes,
 	"MAX_QUERIES_PER_HOUR":       maxQueriesPerHour,
@@ -559,12 +577,14 @@ var tokenMap = map[string]int{
 	"MINVALUE":                   minValue,
 	"MOD":                        mod,
 	"MODE":                       mode,
+	"MODIFIES":                   modifies,
 	"MODIFY":                     modify,
 	"MONTH":                      month,
 	"NAMES":                      names,
 	"NATIONAL":                   national,
 	"NATURAL":                    natural,
 	"NCHAR":                      ncharType,
+	"NESTED":                     nested,
 	"NEVER":                      never,
 	"NEXT_ROW_ID":                next_row_id,
 	"NEXT":                       next,
@@ -607,6 +627,7 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":                 optionally,
 	"OR":                         or,
 	"ORDER":                      order,
+	"ORDINALITY":                 ordinality,
 	"OUT":                        out,
 	"OUTER":                      outer,
 	"OUTFILE":                    outfile,
@@ -618,6 +639,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":               partitioning,
 	"PARTITIONS":                 partitions,
 	"PASSWORD":                   password,
+	"PATH":                       path,
 	"PAUSE":                      pause,
 	"PERCENT":                    percent,
 	"PER_DB":                     per_db,
@@ -632,6 +654,7 @@ var tokenMap = map[string]int{
 	"POSITION":                   position,
 	"PRE_SPLIT_REGIONS":          preSplitRegions,
 	"PRECEDING":                  preceding,
+	"PRECEDES":                   precedes,
 	"PREDICATE":                  predicate,
 	"PRECISION":                  precisionType,
 	"PREPARE":                    prepare,
@@ -654,6 +677,7 @@ var tokenMap = map[string]int{
 	"QUICK":                      quick,
 	"RANGE":                      rangeKwd,
 	"RATE_LIMIT":                 rateLimit,
+	"READS":                      reads,
 	"READ":                       read,
 	"READ_ONLY":                  readOnly,
 	"REAL":                       realType,
@@ -663,6 +687,7 @@ var tokenMap = map[string]int{
 	"RECOVER":                    recover,
 	"RECURSIVE":                  recursive,
 	"REDUNDANT":                  redundant,
+	"REFRESH":                    refresh,
 	"REFERENCES":                 references,
 	"REGEXP":                     regexpKwd,
 	"REGION":                     region,
@@ -693,6 +718,7 @@ var tokenMap = map[string]int{
 	"RESTORES":                   restores,
 	"RESTORED_TS":                restoredTS,
 	"RESTRICT":                   restrict,
+	"RETURN":                     returnKwd,
 	"REVERSE":                    reverse,
 	"REVOKE":                     revoke,
 	"RIGHT":                      right,
@@ -708,6 +734,7 @@ var tokenMap = map[string]int{
 	"RTREE":                      rtree,
 	"HYPO":                       hypo,
 	"RESUME":                     resume,
+	"RETURNS":                    returns,
 	"RUN":                        run,
 	"RUNNING":                    running,
 	"S3":                         s3,
@@ -775,6 +802,7 @@ var tokenMap = map[string]int{
 	"SSL":                        ssl,
 	"STALENESS":                  staleness,
 	"START":                      start,
+	"STARTS":                     starts,
 	"START_TIME":                 startTime,
 	"START_TS":                   startTS,
 	"STARTING":                   starting,
@@ -870,8 +898,10 @@ var tokenMap = map[string]int{
 	"TRUE_CARD_COST":             trueCardCost,
 	"TSO":                        tsoType,
 	"TTL":                        ttl,
+	"TTL_ACTION":                 ttlAction,
 	"TTL_ENABLE":                 ttlEnable,
 	"TTL_JOB_INTERVAL":           ttlJobInterval,
+	"TTL_WHERE":                  ttlWhere,
 	"TYPE":                       tp,
 	"UNBOUNDED":                  unbounded,
 	"UNCOMMITTED":                uncommitted,
@@ -213,6 +213,8 @@ const (
 	TablePrivTable = "Tables_priv"
 	// ColumnPrivTable is the table in system db contains column scope privilege info.
 	ColumnPrivTable = "Columns_priv"
+	// ProcsPrivTable is the table in system db contains stored routine scope privilege info.
+	ProcsPrivTable = "procs_priv"
 	// GlobalVariablesTable is the table contains global system variables.
 	GlobalVariablesTable = "GLOBAL_VARIABLES"
 	// GlobalStatusTable is the table contains global status variables.
@@ -529,6 +531,24 @@ func GetSQLMode(s string) (SQLMode, error) {
 	return sqlMode, nil
 }
 
+// String returns the sql_mode string of the modes, the modes are ordered by their bit positions.
+func (m SQLMode) String() string {
+	var names []string
+	for i := 0; i < 64; i++ {
+		mode := SQLMode(1) << i
+		if m&mode == 0 {
+			continue
+		}
+		for name, v := range Str2SQLMode {
+			if v == mode {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return strings.Join(names, ",")
+}
+
 // Str2SQLMode is the string represent of sql_mode to sql_mode map.
 var Str2SQLMode = map[string]SQLMode{
 	"REAL_AS_FLOAT":              ModeRealAsFloat,
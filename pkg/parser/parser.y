@@ -184,6 +184,7 @@ import (
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -245,6 +246,7 @@ import (
 	replace           "REPLACE"
 	require           "REQUIRE"
 	restrict          "RESTRICT"
+	returnKwd         "RETURN"
 	revoke            "REVOKE"
 	right             "RIGHT"
 	rlike             "RLIKE"
@@ -319,7 +321,9 @@ import (
 	always                   "ALWAYS"
 	any                      "ANY"
 	apply                    "APPLY"
+	archive                  "ARCHIVE"
 	ascii                    "ASCII"
+	at                       "AT"
 	attribute                "ATTRIBUTE"
 	attributes               "ATTRIBUTES"
 	autoIdCache              "AUTO_ID_CACHE"
@@ -332,6 +336,7 @@ import (
 	backup                   "BACKUP"
 	backups                  "BACKUPS"
 	bdr                      "BDR"
+	before                   "BEFORE"
 	begin                    "BEGIN"
 	bernoulli                "BERNOULLI"
 	binding                  "BINDING"
@@ -370,6 +375,8 @@ import (
 	commit                   "COMMIT"
 	committed                "COMMITTED"
 	compact                  "COMPACT"
+	complete                 "COMPLETE"
+	completion               "COMPLETION"
 	compressed               "COMPRESSED"
 	compression              "COMPRESSION"
 	compressionLevel         "COMPRESSION_LEVEL"
@@ -379,6 +386,7 @@ import (
 	connection               "CONNECTION"
 	consistency              "CONSISTENCY"
 	consistent               "CONSISTENT"
+	contains                 "CONTAINS"
 	context                  "CONTEXT"
 	cpu                      "CPU"
 	csvBackslashEscape       "CSV_BACKSLASH_ESCAPE"
@@ -398,6 +406,7 @@ import (
 	declare                  "DECLARE"
 	definer                  "DEFINER"
 	delayKeyWrite            "DELAY_KEY_WRITE"
+	deterministic            "DETERMINISTIC"
 	digest                   "DIGEST"
 	directory                "DIRECTORY"
 	disable                  "DISABLE"
@@ -407,12 +416,15 @@ import (
 	do                       "DO"
 	duplicate                "DUPLICATE"
 	dynamic                  "DYNAMIC"
+	each                     "EACH"
+	emptyKwd                 "EMPTY"
 	enable                   "ENABLE"
 	enabled                  "ENABLED"
 	encryption               "ENCRYPTION"
 	encryptionKeyFile        "ENCRYPTION_KEYFILE"
 	encryptionMethod         "ENCRYPTION_METHOD"
 	end                      "END"
+	ends                     "ENDS"
 	enforced                 "ENFORCED"
 	engine                   "ENGINE"
 	engines                  "ENGINES"
@@ -423,14 +435,17 @@ import (
 	escape                   "ESCAPE"
 	event                    "EVENT"
 	events                   "EVENTS"
+	every                    "EVERY"
 	evolve                   "EVOLVE"
 	exchange                 "EXCHANGE"
 	exclusive                "EXCLUSIVE"
 	execute                  "EXECUTE"
 	expansion                "EXPANSION"
 	expire                   "EXPIRE"
+	export                   "EXPORT"
 	extended                 "EXTENDED"
 	failedLoginAttempts      "FAILED_LOGIN_ATTEMPTS"
+	fast                     "FAST"
 	faultsSym                "FAULTS"
 	fields                   "FIELDS"
 	file                     "FILE"
@@ -438,6 +453,7 @@ import (
 	fixed                    "FIXED"
 	flush                    "FLUSH"
 	following                "FOLLOWING"
+	follows                  "FOLLOWS"
 	format                   "FORMAT"
 	found                    "FOUND"
 	full                     "FULL"
@@ -484,7 +500,9 @@ import (
 	locked                   "LOCKED"
 	logs                     "LOGS"
 	master                   "MASTER"
+	materialized             "MATERIALIZED"
 	maxConnectionsPerHour    "MAX_CONNECTIONS_PER_HOUR"
+	maxFileSize              "MAX_FILE_SIZE"
 	max_idxnum               "MAX_IDXNUM"
 	max_minutes              "MAX_MINUTES"
 	maxQueriesPerHour        "MAX_QUERIES_PER_HOUR"
@@ -500,11 +518,13 @@ import (
 	minValue                 "MINVALUE"
 	minRows                  "MIN_ROWS"
 	mode                     "MODE"
+	modifies                 "MODIFIES"
 	modify                   "MODIFY"
 	month                    "MONTH"
 	names                    "NAMES"
 	national                 "NATIONAL"
 	ncharType                "NCHAR"
+	nested                   "NESTED"
 	never                    "NEVER"
 	next                     "NEXT"
 	nextval                  "NEXTVAL"
@@ -529,6 +549,7 @@ import (
 	onDuplicate              "ON_DUPLICATE"
 	open                     "OPEN"
 	optional                 "OPTIONAL"
+	ordinality               "ORDINALITY"
 	packKeys                 "PACK_KEYS"
 	pageSym                  "PAGE"
 	parser                   "PARSER"
@@ -537,6 +558,7 @@ import (
 	partitions               "PARTITIONS"
 	password                 "PASSWORD"
 	passwordLockTime         "PASSWORD_LOCK_TIME"
+	path                     "PATH"
 	pause                    "PAUSE"
 	percent                  "PERCENT"
 	per_db                   "PER_DB"
@@ -545,6 +567,7 @@ import (
 	plugins                  "PLUGINS"
 	point                    "POINT"
 	policy                   "POLICY"
+	precedes                 "PRECEDES"
 	preceding                "PRECEDING"
 	prepare                  "PREPARE"
 	preserve                 "PRESERVE"
@@ -561,10 +584,12 @@ import (
 	query                    "QUERY"
 	quick                    "QUICK"
 	rateLimit                "RATE_LIMIT"
+	reads                    "READS"
 	rebuild                  "REBUILD"
 	recommend                "RECOMMEND"
 	recover                  "RECOVER"
 	redundant                "REDUNDANT"
+	refresh                  "REFRESH"
 	reload                   "RELOAD"
 	remove                   "REMOVE"
 	reorganize               "REORGANIZE"
@@ -580,6 +605,7 @@ import (
 	restore                  "RESTORE"
 	restores                 "RESTORES"
 	resume                   "RESUME"
+	returns                  "RETURNS"
 	reuse                    "REUSE"
 	reverse                  "REVERSE"
 	role                     "ROLE"
@@ -631,6 +657,7 @@ import (
 	sqlTsiWeek               "SQL_TSI_WEEK"
 	sqlTsiYear               "SQL_TSI_YEAR"
 	start                    "START"
+	starts                   "STARTS"
 	statsAutoRecalc          "STATS_AUTO_RECALC"
 	statsColChoice           "STATS_COL_CHOICE"
 	statsColList             "STATS_COL_LIST"
@@ -669,8 +696,10 @@ import (
 	truncate                 "TRUNCATE"
 	tsoType                  "TSO"
 	ttl                      "TTL"
+	ttlAction                "TTL_ACTION"
 	ttlEnable                "TTL_ENABLE"
 	ttlJobInterval           "TTL_JOB_INTERVAL"
+	ttlWhere                 "TTL_WHERE"
 	tp                       "TYPE"
 	unbounded                "UNBOUNDED"
 	uncommitted              "UNCOMMITTED"
@@ -743,6 +772,7 @@ import (
 	ioWriteBandwidth      "IO_WRITE_BANDWIDTH"
 	jsonArrayagg          "JSON_ARRAYAGG"
 	jsonObjectAgg         "JSON_OBJECTAGG"
+	jsonTable             "JSON_TABLE"
 	leader                "LEADER"
 	leaderConstraints     "LEADER_CONSTRAINTS"
 	learner               "LEARNER"
@@ -976,6 +1006,7 @@ import (
 	AlterPolicyStmt            "Alter Placement Policy statement"
 	AlterResourceGroupStmt     "Alter Resource Group statement"
 	AlterSequenceStmt          "Alter sequence statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AnalyzeTableStmt           "Analyze table statement"
 	BeginTransactionStmt       "BEGIN TRANSACTION statement"
 	BinlogStmt                 "Binlog base64 statement"
@@ -984,6 +1015,7 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
@@ -991,6 +1023,8 @@ import (
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	CreateEventStmt            "CREATE EVENT statement"
 	AddQueryWatchStmt          "ADD QUERY WATCH statement"
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
@@ -999,6 +1033,8 @@ import (
 	DropDatabaseStmt           "DROP DATABASE statement"
 	DropIndexStmt              "DROP INDEX statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	DropEventStmt              "DROP EVENT statement"
 	DropQueryWatchStmt         "DROP QUERY WATCH statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
@@ -1008,6 +1044,7 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -1048,6 +1085,7 @@ import (
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
 	RecoverTableStmt           "recover table statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1101,6 +1139,8 @@ import (
 	ProcedurelabeledLoopStmt   "The loop block with label in procedure"
 	ProcedureIterate           "The iterate statement in procedure, expressed by `iterate ...`"
 	ProcedureLeave             "The leave statement in procedure, expressed by `leave ...`"
+	ProcedureReturn            "The return statement in stored function, expressed by `return ...`"
+	FunctionBodyStmt           "The body statement of stored function"
 
 %type	<item>
 	AdminShowSlow                          "Admin Show Slow statement"
@@ -1227,6 +1267,10 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE optional ON EMPTY and ON ERROR clauses"
+	JSONTableResponse                      "JSON_TABLE ON EMPTY or ON ERROR response"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1330,6 +1374,7 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoClause                       "SELECT statement INTO OUTFILE or INTO var_list clause"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1391,6 +1436,7 @@ import (
 	TrafficCaptureOptList                  "Traffic capture option list"
 	TrafficReplayOpt                       "Traffic replay option"
 	TrafficReplayOptList                   "Traffic replay option list"
+	TTLExportFormatOpt                     "TTL export format option"
 	LockType                               "Table locks type"
 	TransactionChar                        "Transaction characteristic"
 	TransactionChars                       "Transaction characteristic list"
@@ -1418,6 +1464,8 @@ import (
 	ViewDefiner                            "view definer"
 	ViewName                               "view name"
 	ViewFieldList                          "create view statement field list"
+	MViewRefreshOpt                        "Optional materialized view refresh method"
+	MViewRefreshMethodOpt                  "Optional REFRESH MATERIALIZED VIEW method"
 	ViewSQLSecurity                        "view sql security"
 	WhereClause                            "WHERE clause"
 	WhereClauseOptional                    "Optional WHERE clause"
@@ -1546,6 +1594,23 @@ import (
 	ProcedureFetchList                     "Procedure fetch into variables"
 	ProcedureHandlerType                   "Procedure handler operation type"
 	ProcedureHcondList                     "Procedure handler condition value list"
+	ProcedureCharacteristic                "Stored routine characteristic"
+	ProcedureCharacteristicListOpt         "Optional stored routine characteristic list"
+	TriggerTiming                          "Trigger action time"
+	TriggerEvent                           "Trigger event"
+	TriggerOrderOpt                        "Optional trigger order"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Optional event start time"
+	EventEndsOpt                           "Optional event end time"
+	EventCompletion                        "Event ON COMPLETION clause"
+	EventCompletionOpt                     "Optional event ON COMPLETION clause"
+	EventStatusOpt                         "Optional event status"
+	EventCommentOpt                        "Optional event comment"
+	AlterEventScheduleOpt                  "Optional ALTER EVENT schedule and ON COMPLETION clauses"
+	AlterEventRenameOpt                    "Optional ALTER EVENT RENAME TO clause"
+	AlterEventBodyOpt                      "Optional ALTER EVENT body"
+	SelectIntoVarList                      "SELECT ... INTO variable list"
+	SelectIntoVar                          "SELECT ... INTO variable"
 
 %type	<ident>
 	AsOpt             "AS or EmptyString"
@@ -1603,6 +1668,8 @@ import (
 	ConfigItemName                  "A config item like aa or aa.bb or aa.bb-cc.dd"
 	AuthString                      "Password string value"
 	AuthPlugin                      "Authentication plugin name"
+	SelectIntoCompressionOpt        "SELECT ... INTO OUTFILE compression option"
+	SelectIntoMaxFileSizeOpt        "SELECT ... INTO OUTFILE max file size option"
 	CharsetName                     "Character set name"
 	CollationName                   "Collation name"
 	ColumnFormat                    "Column format"
@@ -1638,6 +1705,8 @@ import (
 %precedence next
 %precedence lowerThanValueKeyword
 %precedence value
+%precedence lowerThanInto
+%precedence into
 %precedence lowerThanWith
 %precedence with
 %precedence lowerThanStringLitToken
@@ -4311,6 +4380,24 @@ CreateIndexStmt:
 			LockAlg:                 indexLockAndAlgorithm,
 		}
 	}
+|	"CREATE" IndexKeyTypeOpt "HYPO" "INDEX" IfNotExists Identifier "ON" TableName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		var indexOption *ast.IndexOption
+		if $12 != nil {
+			indexOption = $12.(*ast.IndexOption)
+		} else {
+			indexOption = &ast.IndexOption{}
+		}
+		indexOption.Tp = ast.IndexTypeHypo
+		$$ = &ast.CreateIndexStmt{
+			IfNotExists:             $5.(bool),
+			IndexName:               $6,
+			Table:                   $8.(*ast.TableName),
+			IndexPartSpecifications: $10.([]*ast.IndexPartSpecification),
+			IndexOption:             indexOption,
+			KeyType:                 $2.(ast.IndexKeyType),
+		}
+	}
 
 IndexPartSpecificationListOpt:
 	{
@@ -5147,6 +5234,97 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW [IF NOT EXISTS] mv_name [(column_list)]
+ *      [REFRESH {COMPLETE | FAST}]
+ *      AS select_statement
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList MViewRefreshOpt "AS" CreateViewSelectOpt
+	{
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined || !$4.(*auth.UserIdentity).CurrentUser || $5.(ast.ViewSecurity) != ast.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $13.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists:   $8.(bool),
+			ViewName:      $9.(*ast.TableName),
+			RefreshMethod: $11.(ast.MViewRefreshMethod),
+			Select:        selStmt,
+		}
+		if $10 != nil {
+			x.Cols = $10.([]ast.CIStr)
+		}
+		$$ = x
+	}
+
+MViewRefreshOpt:
+	{
+		$$ = ast.MViewRefreshComplete
+	}
+|	"REFRESH" "COMPLETE"
+	{
+		$$ = ast.MViewRefreshComplete
+	}
+|	"REFRESH" "FAST"
+	{
+		$$ = ast.MViewRefreshFast
+	}
+
+/*******************************************************************
+ *
+ *  Drop Materialized View Statement
+ *
+ *  Example:
+ *      DROP MATERIALIZED VIEW [IF EXISTS] mv_name
+ *******************************************************************/
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{
+			IfExists: $4.(bool),
+			ViewName: $5.(*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Refresh Materialized View Statement
+ *
+ *  Example:
+ *      REFRESH MATERIALIZED VIEW mv_name [COMPLETE | FAST]
+ *
+ *  The refresh method of the materialized view is used if it's not specified.
+ *******************************************************************/
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName MViewRefreshMethodOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Method:   $5.(ast.MViewRefreshMethod),
+		}
+	}
+
+MViewRefreshMethodOpt:
+	{
+		$$ = ast.MViewRefreshDefault
+	}
+|	"COMPLETE"
+	{
+		$$ = ast.MViewRefreshComplete
+	}
+|	"FAST"
+	{
+		$$ = ast.MViewRefreshFast
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -6566,6 +6744,7 @@ GroupByClause:
 	}
 
 HavingClause:
+	%prec lowerThanInto
 	{
 		$$ = nil
 	}
@@ -6831,6 +7010,7 @@ UnReservedKeyword:
 |	"ADVISE"
 |	"ASCII"
 |	"APPLY"
+|	"ARCHIVE"
 |	"ATTRIBUTE"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
@@ -6844,6 +7024,16 @@ UnReservedKeyword:
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
+|	"BEFORE"
+|	"AT"
+|	"COMPLETION"
+|	"ENDS"
+|	"EVERY"
+|	"STARTS"
+|	"COMPLETE"
+|	"FAST"
+|	"MATERIALIZED"
+|	"REFRESH"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6864,6 +7054,7 @@ UnReservedKeyword:
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
+|	"CONTAINS"
 |	"CURRENT"
 |	"DATA"
 |	"DATE" %prec lowerThanStringLitToken
@@ -6873,6 +7064,7 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EACH"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -6893,6 +7085,7 @@ UnReservedKeyword:
 |	"FIXED"
 |	"FLUSH"
 |	"FOLLOWING"
+|	"FOLLOWS"
 |	"FORMAT"
 |	"FULL"
 |	"GENERAL"
@@ -6952,6 +7145,7 @@ UnReservedKeyword:
 |	"WARNINGS"
 |	"YEAR"
 |	"MODE"
+|	"MODIFIES"
 |	"WEEK"
 |	"WEIGHT_STRING"
 |	"ANY"
@@ -6975,6 +7169,7 @@ UnReservedKeyword:
 |	"GRANTS"
 |	"TRIGGERS"
 |	"DELAY_KEY_WRITE"
+|	"DETERMINISTIC"
 |	"ISOLATION"
 |	"JSON"
 |	"REPEATABLE"
@@ -7021,6 +7216,7 @@ UnReservedKeyword:
 |	"MINUTE"
 |	"PLUGINS"
 |	"PRECEDING"
+|	"PRECEDES"
 |	"QUERY"
 |	"QUERIES"
 |	"SAVEPOINT"
@@ -7030,6 +7226,7 @@ UnReservedKeyword:
 |	"SHARED"
 |	"SLOW"
 |	"MAX_CONNECTIONS_PER_HOUR"
+|	"MAX_FILE_SIZE"
 |	"MAX_QUERIES_PER_HOUR"
 |	"MAX_UPDATES_PER_HOUR"
 |	"MAX_USER_CONNECTIONS"
@@ -7055,6 +7252,7 @@ UnReservedKeyword:
 |	"X509"
 |	"NEVER"
 |	"EXPIRE"
+|	"EXPORT"
 |	"ACCOUNT"
 |	"INCREMENTAL"
 |	"CPU"
@@ -7147,6 +7345,7 @@ UnReservedKeyword:
 |	"MB"
 |	"ONLINE"
 |	"RATE_LIMIT"
+|	"READS"
 |	"RESTORE"
 |	"RESTORES"
 |	"SEND_CREDENTIALS_TO_TIKV"
@@ -7173,6 +7372,7 @@ UnReservedKeyword:
 |	"PERCENT"
 |	"PAUSE"
 |	"RESUME"
+|	"RETURNS"
 |	"OFF"
 |	"OPTIONAL"
 |	"REQUIRED"
@@ -7185,8 +7385,10 @@ UnReservedKeyword:
 |	"PRESERVE"
 |	"TOKEN_ISSUER"
 |	"TTL"
+|	"TTL_ACTION"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"TTL_WHERE"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -7212,6 +7414,10 @@ UnReservedKeyword:
 |	"COMPRESSION_TYPE"
 |	"ENCRYPTION_METHOD"
 |	"ENCRYPTION_KEYFILE"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
 
 TiDBKeyword:
 	"ADMIN"
@@ -7389,6 +7595,7 @@ NotKeywordToken:
 |	"UNLIMITED"
 |	"MODERATED"
 |	"UTILIZATION_LIMIT"
+|	"JSON_TABLE"
 
 /************************************************************************************
  *
@@ -8584,9 +8791,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{Name: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -8618,7 +8829,11 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
-			$$ = &ast.WindowFuncExpr{Name: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			wf := &ast.WindowFuncExpr{Name: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			if $5 != nil {
+				wf.Order = $5.(*ast.OrderByClause)
+			}
+			$$ = wf
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
 			if $5 != nil {
@@ -9350,6 +9565,23 @@ SelectStmtBasic:
 		}
 		$$ = st
 	}
+|	"SELECT" SelectStmtOpts SelectStmtFieldList SelectIntoClause HavingClause
+	{
+		st := &ast.SelectStmt{
+			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
+			Distinct:       $2.(*ast.SelectStmtOpts).Distinct,
+			Fields:         $3.(*ast.FieldList),
+			Kind:           ast.SelectStmtKindSelect,
+			SelectIntoOpt:  $4.(*ast.SelectIntoOption),
+		}
+		if st.SelectStmtOpts.TableHints != nil {
+			st.TableHints = st.SelectStmtOpts.TableHints
+		}
+		if $5 != nil {
+			st.Having = $5.(*ast.HavingClause)
+		}
+		$$ = st
+	}
 
 SelectStmtFromDualTable:
 	SelectStmtBasic FromDual WhereClauseOptional
@@ -9985,12 +10217,98 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(ast.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsName
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(ast.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(ast.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: ast.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      ast.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExistsPath,
+			Name:      ast.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, Columns: $6.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, Columns: $5.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	{
+		$$ = []*ast.JSONTableResponse{nil, nil}
+	}
+|	JSONTableResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableResponse{$1.(*ast.JSONTableResponse), nil}
+	}
+|	JSONTableResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableResponse{nil, $1.(*ast.JSONTableResponse)}
+	}
+|	JSONTableResponse "ON" "EMPTY" JSONTableResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableResponse{$1.(*ast.JSONTableResponse), $4.(*ast.JSONTableResponse)}
+	}
+
+JSONTableResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10391,21 +10709,74 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectIntoClause
+
+SelectIntoClause:
+	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines SelectIntoCompressionOpt SelectIntoMaxFileSizeOpt
 	{
 		x := &ast.SelectIntoOption{
-			Tp:       ast.SelectIntoOutfile,
-			FileName: $3,
+			Tp:          ast.SelectIntoOutfile,
+			FileName:    $3,
+			Compression: $7,
+			MaxFileSize: $8,
 		}
 		if $4 != nil {
-			x.FieldsInfo = $4.(*ast.FieldsClause)
+			x.Format = $4.(*string)
 		}
 		if $5 != nil {
-			x.LinesInfo = $5.(*ast.LinesClause)
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
 		}
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]*ast.SelectIntoVar),
+		}
+	}
+
+SelectIntoCompressionOpt:
+	{
+		$$ = ""
+	}
+|	"COMPRESSION" EqOpt stringLit
+	{
+		$$ = $3
+	}
+
+SelectIntoMaxFileSizeOpt:
+	{
+		$$ = ""
+	}
+|	"MAX_FILE_SIZE" EqOpt stringLit
+	{
+		$$ = $3
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []*ast.SelectIntoVar{$1.(*ast.SelectIntoVar)}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]*ast.SelectIntoVar), $3.(*ast.SelectIntoVar))
+	}
+
+SelectIntoVar:
+	Identifier
+	{
+		$$ = &ast.SelectIntoVar{Name: strings.ToLower($1)}
+	}
+|	singleAtIdentifier
+	{
+		$$ = &ast.SelectIntoVar{Name: strings.TrimPrefix($1, "@"), IsUserVar: true}
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -11782,6 +12153,27 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "FUNCTION" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateFunction,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "TRIGGER" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateTrigger,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateEvent,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
 |	"SHOW" "TABLE" TableName PartitionNameListOpt "DISTRIBUTIONS" WhereClauseOptional
 	{
 		stmt := &ast.ShowStmt{
@@ -12340,6 +12732,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterRangeStmt
 |	AlterSequenceStmt
+|	AlterEventStmt
 |	AlterPolicyStmt
 |	AlterResourceGroupStmt
 |	AnalyzeTableStmt
@@ -12356,11 +12749,14 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
 |	CreatePolicyStmt
 |	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12371,9 +12767,12 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropTriggerStmt
+|	DropEventStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropMaterializedViewStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12403,6 +12802,7 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RefreshMaterializedViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12842,6 +13242,41 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"TTL_ACTION" EqOpt "DELETE"
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLAction, UintValue: uint64(ast.TTLActionDelete)}
+	}
+|	"TTL_ACTION" EqOpt "ARCHIVE" "TO" "TABLE" TableName
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLAction, UintValue: uint64(ast.TTLActionArchive), TableNames: []*ast.TableName{$6.(*ast.TableName)}}
+	}
+|	"TTL_ACTION" EqOpt "EXPORT" "TO" stringLit TTLExportFormatOpt
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLAction, UintValue: uint64(ast.TTLActionExport), StrValue: $5, Value: $6.(ast.ValueExpr)}
+	}
+|	"TTL_WHERE" EqOpt '(' Expression ')'
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLWhere, Expr: $4}
+	}
+|	"TTL_WHERE" EqOpt "DEFAULT"
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLWhere, Default: true}
+	}
+
+TTLExportFormatOpt:
+	/* empty */
+	{
+		$$ = ast.NewValueExpr(ast.TTLExportFormatCSV, "", "")
+	}
+|	"FORMAT" EqOpt stringLit
+	{
+		exportFormat := strings.ToLower($3)
+		if exportFormat != ast.TTLExportFormatCSV && exportFormat != ast.TTLExportFormatParquet {
+			yylex.AppendError(yylex.Errorf("The TTL_ACTION export format has to be 'CSV' or 'PARQUET'"))
+			return 1
+		}
+		$$ = ast.NewValueExpr(exportFormat, "", "")
+	}
 
 ForceOpt:
 	/* empty */
@@ -14430,6 +14865,24 @@ RecommendIndexStmt:
 			Options: $4.([]ast.RecommendIndexOption),
 		}
 
+		$$ = x
+	}
+|	"RECOMMEND" "INDEX" "EXPLAIN" "FOR" stringLit
+	{
+		x := &ast.RecommendIndexStmt{
+			Action: "explain",
+			SQL:    $5,
+		}
+
+		$$ = x
+	}
+|	"RECOMMEND" "INDEX" "DROP" RecommendIndexOptionListOpt
+	{
+		x := &ast.RecommendIndexStmt{
+			Action:  "drop",
+			Options: $4.([]ast.RecommendIndexOption),
+		}
+
 		$$ = x
 	}
 |	"RECOMMEND" "INDEX" "SHOW" "OPTION"
@@ -14942,6 +15395,11 @@ FormatOpt:
 		str := $2
 		$$ = &str
 	}
+|	"FORMAT" Identifier
+	{
+		str := strings.ToLower($2)
+		$$ = &str
+	}
 
 IgnoreLines:
 	{
@@ -16096,6 +16554,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -16543,6 +17002,14 @@ ProcedureLeave:
 		}
 	}
 
+ProcedureReturn:
+	"RETURN" Expression
+	{
+		$$ = &ast.ProcedureReturn{
+			Expr: $2,
+		}
+	}
+
 ProcedureProcStmt:
 	ProcedureStatementStmt
 |	ProcedureUnlabeledBlock
@@ -16556,6 +17023,7 @@ ProcedureProcStmt:
 |	ProcedurelabeledLoopStmt
 |	ProcedureIterate
 |	ProcedureLeave
+|	ProcedureReturn
 
 /********************************************************************************************
  *
@@ -16576,28 +17044,130 @@ ProcedureProcStmt:
  *  Valid SQL routine statement
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureCharacteristicListOpt ProcedureProcStmt
 	{
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined || $5.(ast.ViewSecurity) != ast.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
 		x := &ast.ProcedureInfo{
-			IfNotExists:    $3.(bool),
-			ProcedureName:  $4.(*ast.TableName),
-			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+			IfNotExists:     $7.(bool),
+			Definer:         $4.(*auth.UserIdentity),
+			ProcedureName:   $8.(*ast.TableName),
+			ProcedureParam:  $10.([]*ast.StoreParameter),
+			Characteristics: $12.([]*ast.ProcedureCharacteristic),
+			ProcedureBody:   $13,
 		}
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $13
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
+		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
+		$$ = x
+	}
+|	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "FUNCTION" IfNotExists TableName '(' OptSpPdparams ')' "RETURNS" Type ProcedureCharacteristicListOpt FunctionBodyStmt
+	{
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined || $5.(ast.ViewSecurity) != ast.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		params := $10.([]*ast.StoreParameter)
+		for _, param := range params {
+			if param.Paramstatus != ast.MODE_IN {
+				yylex.AppendError(ErrSyntax)
+				return 1
+			}
+		}
+		x := &ast.ProcedureInfo{
+			IfNotExists:     $7.(bool),
+			IsFunction:      true,
+			Definer:         $4.(*auth.UserIdentity),
+			ProcedureName:   $8.(*ast.TableName),
+			ProcedureParam:  params,
+			ReturnType:      $13.(*types.FieldType),
+			Characteristics: $14.([]*ast.ProcedureCharacteristic),
+			ProcedureBody:   $15,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $15
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		startOffset = parser.startOffset(&yyS[yypt-6])
+		if parser.src[startOffset] == '(' {
+			startOffset++
+		}
+		endOffset := parser.startOffset(&yyS[yypt-4])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
 
+// FunctionBodyStmt excludes the statements starting with '(' or a label from ProcedureProcStmt,
+// otherwise they are ambiguous with the field length of the RETURNS type.
+FunctionBodyStmt:
+	ProcedureUnlabeledBlock
+|	ProcedureIfstmt
+|	ProcedureCaseStmt
+|	ProcedureUnlabelLoopBlock
+|	ProcedureReturn
+
+ProcedureCharacteristicListOpt:
+	/* Empty */
+	{
+		$$ = []*ast.ProcedureCharacteristic{}
+	}
+|	ProcedureCharacteristicListOpt ProcedureCharacteristic
+	{
+		$$ = append($1.([]*ast.ProcedureCharacteristic), $2.(*ast.ProcedureCharacteristic))
+	}
+
+ProcedureCharacteristic:
+	"COMMENT" stringLit
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicComment, Comment: $2}
+	}
+|	"LANGUAGE" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicLanguage}
+	}
+|	"DETERMINISTIC"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDeterministic, Deterministic: true}
+	}
+|	"NOT" "DETERMINISTIC"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDeterministic, Deterministic: false}
+	}
+|	"SQL" "SECURITY" "DEFINER"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicSQLSecurity, Security: ast.SecurityDefiner}
+	}
+|	"SQL" "SECURITY" "INVOKER"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicSQLSecurity, Security: ast.SecurityInvoker}
+	}
+|	"CONTAINS" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDataAccess, DataAccess: ast.ProcedureContainsSQL}
+	}
+|	"NO" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDataAccess, DataAccess: ast.ProcedureNoSQL}
+	}
+|	"READS" "SQL" "DATA"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDataAccess, DataAccess: ast.ProcedureReadsSQLData}
+	}
+|	"MODIFIES" "SQL" "DATA"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureCharacteristicDataAccess, DataAccess: ast.ProcedureModifiesSQLData}
+	}
+
 /********************************************************************************************
 *  DROP PROCEDURE  [IF EXISTS] sp_name
+*  DROP FUNCTION  [IF EXISTS] sp_name
 ********************************************************************************************/
 DropProcedureStmt:
 	"DROP" "PROCEDURE" IfExists TableName
@@ -16607,6 +17177,306 @@ DropProcedureStmt:
 			ProcedureName: $4.(*ast.TableName),
 		}
 	}
+|	"DROP" "FUNCTION" IfExists TableName
+	{
+		$$ = &ast.DropProcedureStmt{
+			IfExists:      $3.(bool),
+			IsFunction:    true,
+			ProcedureName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *  CREATE
+ *  [DEFINER = user]
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  [trigger_order]
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ *  trigger_order: { FOLLOWS | PRECEDES } other_trigger_name
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrderOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined || $5.(ast.ViewSecurity) != ast.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $7.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			TriggerName: $8.(*ast.TableName),
+			Timing:      $9.(ast.TriggerTiming),
+			Event:       $10.(ast.TriggerEvent),
+			Table:       $12.(*ast.TableName),
+			Body:        $17,
+		}
+		if $16 != nil {
+			x.Order = $16.(*ast.TriggerOrder)
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $17
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = ast.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = ast.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = ast.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = ast.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = ast.TriggerDelete
+	}
+
+TriggerOrderOpt:
+	{
+		$$ = nil
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.TriggerOrder{Follows: true, TriggerName: ast.NewCIStr($2)}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.TriggerOrder{TriggerName: ast.NewCIStr($2)}
+	}
+
+/********************************************************************************************
+ *  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+ ********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *  CREATE
+ *  [DEFINER = user]
+ *  EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: {
+ *      AT timestamp [+ INTERVAL interval] ...
+ *    | EVERY interval
+ *      [STARTS timestamp [+ INTERVAL interval] ...]
+ *      [ENDS timestamp [+ INTERVAL interval] ...]
+ *  }
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined || $5.(ast.ViewSecurity) != ast.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		x := &ast.CreateEventStmt{
+			IfNotExists: $7.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			EventName:   $8.(*ast.TableName),
+			Schedule:    $11.(*ast.EventSchedule),
+			Completion:  $12.(ast.EventCompletion),
+			Status:      $13.(ast.EventStatus),
+			Body:        $16,
+		}
+		if $14 != nil {
+			x.Comment = $14.(*string)
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $16
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletion:
+	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionUnspecified
+	}
+|	EventCompletion
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusDisableOnSlave
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = &comment
+	}
+
+/********************************************************************************************
+ *  ALTER
+ *  [DEFINER = user]
+ *  EVENT event_name
+ *  [ON SCHEDULE schedule]
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [RENAME TO new_event_name]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	"ALTER" ViewDefiner "EVENT" TableName AlterEventScheduleOpt AlterEventRenameOpt EventStatusOpt EventCommentOpt AlterEventBodyOpt
+	{
+		x := $5.(*ast.AlterEventStmt)
+		x.Definer = $2.(*auth.UserIdentity)
+		x.EventName = $4.(*ast.TableName)
+		if $6 != nil {
+			x.NewName = $6.(*ast.TableName)
+		}
+		x.Status = $7.(ast.EventStatus)
+		if $8 != nil {
+			x.Comment = $8.(*string)
+		}
+		if $9 != nil {
+			x.Body = $9.(ast.StmtNode)
+		}
+		if x.Definer.CurrentUser && x.Schedule == nil && x.Completion == ast.EventCompletionUnspecified &&
+			x.NewName == nil && x.Status == ast.EventStatusUnspecified && x.Comment == nil && x.Body == nil {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	{
+		$$ = &ast.AlterEventStmt{}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:   $3.(*ast.EventSchedule),
+			Completion: $4.(ast.EventCompletion),
+		}
+	}
+|	EventCompletion
+	{
+		$$ = &ast.AlterEventStmt{Completion: $1.(ast.EventCompletion)}
+	}
+
+AlterEventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+AlterEventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $2
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = $2
+	}
+
+/********************************************************************************************
+ *  DROP EVENT [IF EXISTS] event_name
+ ********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
 
 /********************************************************************
  *
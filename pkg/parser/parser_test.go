@@ -474,6 +474,11 @@ func TestRecommendIndex(t *testing.T) {
 			"RECOMMEND INDEX RUN FOR 'select * from t where a=1' WITH A = 1"},
 		{"recommend index run for 'select * from t where a=1' with A = 1, B = 2", true,
 			"RECOMMEND INDEX RUN FOR 'select * from t where a=1' WITH A = 1, B = 2"},
+		{"recommend index explain for 'select * from t where a=1'", true,
+			"RECOMMEND INDEX EXPLAIN FOR 'select * from t where a=1'"},
+		{"recommend index explain", false, ""},
+		{"recommend index drop", true, "RECOMMEND INDEX DROP"},
+		{"recommend index drop with A = 1", true, "RECOMMEND INDEX DROP WITH A = 1"},
 		{"recommend index show option", true, "RECOMMEND INDEX SHOW OPTION"},
 		{"recommend index apply 1", true, "RECOMMEND INDEX APPLY 1"},
 		{"recommend index ignore 1", true, "RECOMMEND INDEX IGNORE 1"},
@@ -823,6 +828,16 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select * from t into outfile 's3://bucket/prefix/' format parquet compression 'zstd'", true, "SELECT * FROM `t` INTO OUTFILE 's3://bucket/prefix/' FORMAT 'parquet' COMPRESSION 'zstd'"},
+		{"select * from t into outfile 's3://bucket/prefix/' format 'csv' fields terminated by ',' compression = 'gzip' max_file_size = '64MiB'", true, "SELECT * FROM `t` INTO OUTFILE 's3://bucket/prefix/' FORMAT 'csv' FIELDS TERMINATED BY ',' COMPRESSION 'gzip' MAX_FILE_SIZE '64MiB'"},
+		{"select * from t into outfile '/tmp/result.txt' max_file_size '1GiB'", true, "SELECT * FROM `t` INTO OUTFILE '/tmp/result.txt' MAX_FILE_SIZE '1GiB'"},
+		{"select * from t into outfile 's3://bucket/prefix/' compression 'zstd' format parquet", false, ""},
+
+		// select into variables
+		{"select a, b from t into @x, y", true, "SELECT `a`,`b` FROM `t` INTO @`x`,`y`"},
+		{"select a into @x from t where a > 1", true, "SELECT `a` FROM `t` WHERE `a`>1 INTO @`x`"},
+		{"select 1 into @x", true, "SELECT 1 INTO @`x`"},
+		{"select a from t into @x, outfile '/tmp/a.txt'", false, ""},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -3415,6 +3430,9 @@ func TestDDL(t *testing.T) {
 		{"CREATE FULLTEXT INDEX idx ON t (a) WITH PARSER ident comment 'string' lock default", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`) WITH PARSER `ident` COMMENT 'string'"},
 		{"CREATE INDEX idx ON t (a) USING HASH", true, "CREATE INDEX `idx` ON `t` (`a`) USING HASH"},
 		{"CREATE INDEX idx ON t (a) COMMENT 'foo'", true, "CREATE INDEX `idx` ON `t` (`a`) COMMENT 'foo'"},
+		{"CREATE HYPO INDEX idx ON t (a, b)", true, "CREATE INDEX `idx` ON `t` (`a`, `b`) USING HYPO"},
+		{"CREATE UNIQUE HYPO INDEX IF NOT EXISTS idx ON t (a) COMMENT 'foo'", true, "CREATE UNIQUE INDEX IF NOT EXISTS `idx` ON `t` (`a`) USING HYPO COMMENT 'foo'"},
+		{"DROP HYPO INDEX idx ON t", true, "DROP HYPO INDEX `idx` ON `t`"},
 		{"CREATE INDEX idx ON t (a) USING HASH COMMENT 'foo'", true, "CREATE INDEX `idx` ON `t` (`a`) USING HASH COMMENT 'foo'"},
 		{"CREATE INDEX idx ON t (a) LOCK=NONE", true, "CREATE INDEX `idx` ON `t` (`a`) LOCK = NONE"},
 		{"CREATE INDEX idx USING BTREE ON t (a) USING HASH COMMENT 'foo'", true, "CREATE INDEX `idx` ON `t` (`a`) USING HASH COMMENT 'foo'"},
@@ -6256,6 +6274,44 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' default '1' on empty)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '1' ON EMPTY)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a json path '$.a' null on empty default '{}' on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` JSON PATH '$.a' NULL ON EMPTY DEFAULT '{}' ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c[*]' columns (c int path '$'))) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c[*]' COLUMNS (`c` INT PATH '$'))) AS `jt`"},
+		{`select t.id, jt.* from t, json_table(t.doc, '$.items[*]' columns (nested int path '$.n', path varchar(5) path '$.p')) as jt`, true, "SELECT `t`.`id`,`jt`.* FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$.items[*]' COLUMNS (`nested` INT PATH '$.n', `path` VARCHAR(5) PATH '$.p')) AS `jt`"},
+		{`select * from t left join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{`select json_table from json_table`, true, "SELECT `json_table` FROM `json_table`"},
+
+		// negative test cases
+		{`select * from json_table('[]', '$[*]' columns (a int path '$'))`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns ()) jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$' error on error null on empty)) jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int)) jt`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select * from t, lateral (select * from t1 where t1.a = t.a) as dt`, true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt`"},
+		{`select * from t left join lateral (select max(b) m from t1 where t1.a = t.a) dt on true`, true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT MAX(`b`) AS `m` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{`select * from t cross join lateral (select t.a union select t.b) as dt`, true, "SELECT * FROM `t` JOIN LATERAL (SELECT `t`.`a` UNION SELECT `t`.`b`) AS `dt`"},
+
+		// negative test cases
+		{`select * from t, lateral (select t.a)`, false, ""},
+		{`select * from t, lateral t1`, false, ""},
+		{`select lateral from t`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -6644,6 +6700,11 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT AVG(val) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL '2:30' MINUTE_SECOND FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL _UTF8MB4'2:30' MINUTE_SECOND FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
+		{`SELECT AVG(val) OVER (ORDER BY time GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (ORDER BY `time` GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM `t`"},
+		{`SELECT SUM(DISTINCT val) OVER (PARTITION BY subject) FROM t;`, true, "SELECT SUM(DISTINCT `val`) OVER (PARTITION BY `subject`) FROM `t`"},
+		{`SELECT COUNT(DISTINCT val, subject) OVER () FROM t;`, true, "SELECT COUNT(DISTINCT `val`, `subject`) OVER () FROM `t`"},
+		{`SELECT GROUP_CONCAT(val) OVER (PARTITION BY subject) FROM t;`, true, "SELECT GROUP_CONCAT(`val` SEPARATOR ',') OVER (PARTITION BY `subject`) FROM `t`"},
+		{`SELECT GROUP_CONCAT(DISTINCT val, time ORDER BY time DESC SEPARATOR ';') OVER (PARTITION BY subject) FROM t;`, true, "SELECT GROUP_CONCAT(DISTINCT `val`, `time` ORDER BY `time` DESC SEPARATOR ';') OVER (PARTITION BY `subject`) FROM `t`"},
 
 		// For named windows.
 		// See https://dev.mysql.com/doc/refman/8.0/en/window-functions-named-windows.html
@@ -7794,6 +7855,13 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '@monthly'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10hourxx'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10.10.255h'", false, ""},
+
+		// TTL_WHERE settings
+		{"create table t (created_at datetime, deleted int) TTL = created_at + INTERVAL 1 YEAR TTL_WHERE = (deleted = 1)", true, "CREATE TABLE `t` (`created_at` DATETIME,`deleted` INT) TTL = `created_at` + INTERVAL 1 YEAR TTL_WHERE = (`deleted`=1)"},
+		{"create table t (created_at datetime, deleted int) /*T![ttl] TTL = created_at + INTERVAL 1 YEAR TTL_WHERE (deleted is not null) */", true, "CREATE TABLE `t` (`created_at` DATETIME,`deleted` INT) TTL = `created_at` + INTERVAL 1 YEAR TTL_WHERE = (`deleted` IS NOT NULL)"},
+		{"alter table t TTL_WHERE = (deleted = 1 or status = 'x') TTL_ENABLE = 'ON'", true, "ALTER TABLE `t` TTL_WHERE = (`deleted`=1 OR `status`=_UTF8MB4'x') TTL_ENABLE = 'ON'"},
+		{"alter table t TTL_WHERE = DEFAULT", true, "ALTER TABLE `t` TTL_WHERE = DEFAULT"},
+		{"alter table t TTL_WHERE = deleted = 1", false, ""},
 	}
 
 	RunTest(t, table, false)
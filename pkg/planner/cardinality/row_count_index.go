@@ -23,6 +23,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/planner/planctx"
 	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
@@ -91,6 +92,20 @@ func GetRowCountByIndexRanges(sctx planctx.PlanContext, coll *statistics.HistCol
 	return result, corrResult, errors.Trace(err)
 }
 
+// GetRowCountByHypoIndexAccessConds estimates the row count accessed by a hypothetical index.
+// Hypothetical indexes have no statistics of their own, so the row count is derived from the
+// selectivity of the access conditions over the statistics of the indexed columns.
+func GetRowCountByHypoIndexAccessConds(sctx planctx.PlanContext, coll *statistics.HistColl, accessConds []expression.Expression) (float64, error) {
+	if len(accessConds) == 0 {
+		return float64(coll.RealtimeCount), nil
+	}
+	selectivity, _, err := Selectivity(sctx, coll, accessConds, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return selectivity * float64(coll.RealtimeCount), nil
+}
+
 func getIndexRowCountForStatsV1(sctx planctx.PlanContext, coll *statistics.HistColl, idxID int64, indexRanges []*ranger.Range) (float64, error) {
 	sc := sctx.GetSessionVars().StmtCtx
 	debugTrace := sc.EnableOptimizerDebugTrace
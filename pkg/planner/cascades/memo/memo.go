@@ -16,6 +16,7 @@ package memo
 
 import (
 	"container/list"
+	"slices"
 	"unsafe"
 
 	"github.com/bits-and-blooms/bitset"
@@ -358,6 +359,8 @@ type IteratorLP struct {
 	traceID int
 	// hasher is for compute the subtree's IDs' hash64 rooted from current logical operator.
 	hasher base2.Hasher
+	// path is the groups stepped in from the root group to the current one.
+	path []*Group
 }
 
 // NewIterator new a logical plan iterator from current memo based on its root group.
@@ -385,6 +388,7 @@ func (it *IteratorLP) Next() (logic base.LogicalPlan) {
 	for {
 		// when non-first time loop here, we should reset traceID back to -1.
 		it.traceID = -1
+		it.path = it.path[:0]
 		it.hasher.Reset()
 		if len(it.stackInfo) != 0 {
 			// when state stack is not empty, we need to pick the next group expression from the top of stack .
@@ -408,6 +412,18 @@ func (it *IteratorLP) dfs(target *Group) base.LogicalPlan {
 	if ge == nil {
 		return nil
 	}
+	it.path = append(it.path, target)
+	defer func() {
+		it.path = it.path[:len(it.path)-1]
+	}()
+	// group merge may make a group expression refer to a group on the current path, which forms a
+	// cycle. Skip it by treating it as a failed one, the next iteration will pick the next one here.
+	for _, childGroup := range ge.Inputs {
+		if slices.Contains(it.path, childGroup) {
+			it.stackInfo = it.stackInfo[:it.traceID+1]
+			return nil
+		}
+	}
 	lp := ge.LogicalPlan
 	// clean the children to avoid pollution.
 	children := lp.Children()[:0]
@@ -0,0 +1,91 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agg
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
+)
+
+var _ rule.Rule = &XFEliminateAggOnUniqueKey{}
+
+// XFEliminateAggOnUniqueKey converts the aggregation to a projection when the group
+// by columns cover a unique key of its child, since each group has only one row.
+// It's the cascades version of the classic rule AggregationEliminator.
+type XFEliminateAggOnUniqueKey struct {
+	*rule.BaseRule
+}
+
+// NewXFEliminateAggOnUniqueKey creates a new XFEliminateAggOnUniqueKey rule.
+func NewXFEliminateAggOnUniqueKey() *XFEliminateAggOnUniqueKey {
+	pa := pattern.NewPattern(pattern.OperandAggregation, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly))
+	return &XFEliminateAggOnUniqueKey{
+		BaseRule: rule.NewBaseRule(rule.XFEliminateAggOnUniqueKey, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFEliminateAggOnUniqueKey) ID() uint {
+	return uint(rule.XFEliminateAggOnUniqueKey)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFEliminateAggOnUniqueKey) PreCheck(aggGE base.LogicalPlan) bool {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	for _, af := range agg.AggFuncs {
+		// GROUP_CONCAT should truncate the result according to `group_concat_max_len`,
+		// see tryToEliminateAggregation in the classic rule for the details.
+		if af.Name == ast.AggFuncGroupConcat {
+			return false
+		}
+	}
+	groupByCols := agg.GetGroupByCols()
+	if len(groupByCols) == 0 {
+		return false
+	}
+	schemaByGroupby := expression.NewSchema(groupByCols...)
+	for _, key := range aggGE.Children()[0].Schema().PKOrUK {
+		if schemaByGroupby.ColumnsIndices(key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// XForm implements the Rule interface.
+func (*XFEliminateAggOnUniqueKey) XForm(aggGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	exprCtx := agg.SCtx().GetExprCtx()
+	proj := logicalop.LogicalProjection{
+		Exprs: make([]expression.Expression, 0, len(agg.AggFuncs)),
+	}.Init(agg.SCtx(), agg.QueryBlockOffset())
+	for _, fun := range agg.AggFuncs {
+		ok, expr := ruleutil.RewriteAggFuncToExpr(exprCtx, fun)
+		if !ok {
+			return nil, false, nil
+		}
+		proj.Exprs = append(proj.Exprs, expr)
+	}
+	proj.SetSchema(agg.Schema().Clone())
+	proj.SetOutputNames(agg.OutputNames())
+	proj.SetChildren(aggGE.Children()[0])
+	return []base.LogicalPlan{proj}, false, nil
+}
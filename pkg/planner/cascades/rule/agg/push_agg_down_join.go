@@ -0,0 +1,226 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agg
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushAggDownJoin{}
+
+// XFPushAggDownJoin pushes the aggregation down to one side of the inner join, it
+// transforms `aggregation -> join(x, y)` to `aggregation' -> join(partial -> x, y)`.
+// The partial aggregation is grouped by the columns of x used by the group by items
+// and the join conditions, it computes the aggregate functions whose arguments come
+// from x, and aggregation' merges its results in final mode. It's the cascades version
+// of the classic rule AggregationPushDownSolver for the inner join, and both sides are
+// explored as the alternatives.
+type XFPushAggDownJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFPushAggDownJoin creates a new XFPushAggDownJoin rule.
+func NewXFPushAggDownJoin() *XFPushAggDownJoin {
+	pa := pattern.NewPattern(pattern.OperandAggregation, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushAggDownJoin{
+		BaseRule: rule.NewBaseRule(rule.XFPushAggDownJoin, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushAggDownJoin) ID() uint {
+	return uint(rule.XFPushAggDownJoin)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushAggDownJoin) PreCheck(aggGE base.LogicalPlan) bool {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	join := aggGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	if !agg.SCtx().GetSessionVars().AllowAggPushDown || join.JoinType != logicalop.InnerJoin || len(join.NAEQConditions) > 0 {
+		return false
+	}
+	// the aggregation merging the pushed results is in final mode, it can't be pushed again.
+	return len(agg.AggFuncs) > 0 && !slices.ContainsFunc(agg.AggFuncs, func(fun *aggregation.AggFuncDesc) bool {
+		return fun.Mode != aggregation.CompleteMode || !ruleutil.IsDecomposableWithJoin(fun)
+	})
+}
+
+// XForm implements the Rule interface.
+func (*XFPushAggDownJoin) XForm(aggGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	joinGE := aggGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	children := joinGE.Children()
+	result := make([]base.LogicalPlan, 0, len(children))
+	for idx := range children {
+		newAgg, err := pushAggDownJoinSide(agg, join, children, idx)
+		if err != nil {
+			return nil, false, err
+		}
+		if newAgg != nil {
+			result = append(result, newAgg)
+		}
+	}
+	return result, false, nil
+}
+
+// pushAggDownJoinSide pushes the aggregation down to the idx-th child of the join,
+// it returns nil if the aggregation can't be pushed to this side.
+func pushAggDownJoinSide(agg *logicalop.LogicalAggregation, join *logicalop.LogicalJoin,
+	children []base.LogicalPlan, idx int) (base.LogicalPlan, error) {
+	sideSchema, otherSchema := children[idx].Schema(), children[1-idx].Schema()
+	pushed := make([]int, 0, len(agg.AggFuncs))
+	for i, fun := range agg.AggFuncs {
+		var fromSide, fromOther bool
+		for _, col := range expression.ExtractColumnsFromExpressions(nil, fun.Args, nil) {
+			if sideSchema.Contains(col) {
+				fromSide = true
+			} else if otherSchema.Contains(col) {
+				fromOther = true
+			}
+		}
+		switch {
+		case fromSide && fromOther:
+			return nil, nil
+		case fromOther:
+			// the rows of the other side are joined with the groups instead of the rows of
+			// this side after pushing, so count and sum on the other side are changed.
+			if fun.Name == ast.AggFuncSum || fun.Name == ast.AggFuncCount {
+				return nil, nil
+			}
+		default:
+			// the functions with constant arguments, e.g. count(*), are computed on this side.
+			pushed = append(pushed, i)
+		}
+	}
+	pushedFuncs := make([]*aggregation.AggFuncDesc, 0, len(pushed))
+	for _, i := range pushed {
+		pushedFuncs = append(pushedFuncs, agg.AggFuncs[i])
+	}
+	if len(pushedFuncs) == 0 || aggregation.IsAllFirstRow(pushedFuncs) {
+		return nil, nil
+	}
+	gbyCols := collectJoinSideGbyCols(agg, join, sideSchema, idx)
+	gbySchema := expression.NewSchema(gbyCols...)
+	for _, key := range sideSchema.PKOrUK {
+		// each group has only one row, pushing the aggregation is useless.
+		if gbySchema.ColumnsIndices(key) != nil {
+			return nil, nil
+		}
+	}
+
+	ctx := agg.SCtx()
+	partialAgg := logicalop.LogicalAggregation{
+		GroupByItems:   expression.Column2Exprs(gbyCols),
+		PreferAggType:  agg.PreferAggType,
+		PreferAggToCop: agg.PreferAggToCop,
+	}.Init(ctx, agg.QueryBlockOffset())
+	partialFuncs := make([]*aggregation.AggFuncDesc, 0, len(pushedFuncs)+len(gbyCols))
+	partialSchema := expression.NewSchema(make([]*expression.Column, 0, len(pushedFuncs)+len(gbyCols))...)
+	finalFuncs := make([]*aggregation.AggFuncDesc, 0, len(agg.AggFuncs))
+	for _, fun := range agg.AggFuncs {
+		finalFuncs = append(finalFuncs, fun.Clone())
+	}
+	for _, i := range pushed {
+		partialFuncs = append(partialFuncs, agg.AggFuncs[i].Clone())
+		col := &expression.Column{
+			UniqueID: ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  agg.AggFuncs[i].RetTp,
+		}
+		partialSchema.Append(col)
+		finalFuncs[i].Args = []expression.Expression{col}
+		finalFuncs[i].Mode = aggregation.FinalMode
+	}
+	for _, gbyCol := range gbyCols {
+		firstRow, err := aggregation.NewAggFuncDesc(ctx.GetExprCtx(), ast.AggFuncFirstRow, []expression.Expression{gbyCol}, false)
+		if err != nil {
+			return nil, err
+		}
+		newCol, _ := gbyCol.Clone().(*expression.Column)
+		newCol.RetType = firstRow.RetTp
+		partialFuncs = append(partialFuncs, firstRow)
+		partialSchema.Append(newCol)
+	}
+	// If agg has no group-by item, it will return a default value, so a constant group-by item is added.
+	if len(partialAgg.GroupByItems) == 0 {
+		partialAgg.GroupByItems = []expression.Expression{&expression.Constant{
+			Value:   types.NewDatum(0),
+			RetType: types.NewFieldType(mysql.TypeLong)}}
+	}
+	partialAgg.AggFuncs = partialFuncs
+	partialAgg.SetSchema(partialSchema)
+	partialAgg.SetChildren(children[idx])
+
+	newChildren := slices.Clone(children)
+	newChildren[idx] = partialAgg
+	newJoin := join.LogicalJoinShallowRef()
+	// ReAlloc4Cascades is to re-alloc the plan factors for cascades.
+	newJoin.ReAlloc4Cascades(plancodec.TypeJoin, newJoin)
+	newJoin.LeftProperties, newJoin.RightProperties = nil, nil
+	newJoin.SetChildren(newChildren...)
+	newJoin.SetSchema(expression.MergeSchema(newChildren[0].Schema(), newChildren[1].Schema()))
+	newJoin.SetOutputNames(nil)
+
+	newAgg := agg.LogicalAggregationShallowRef()
+	newAgg.ReAlloc4Cascades(plancodec.TypeAgg, newAgg)
+	newAgg.AggFuncs = finalFuncs
+	// the possible properties are derived from the original child, they are prepared again in physical optimization.
+	newAgg.PossibleProperties = nil
+	newAgg.SetChildren(newJoin)
+	return newAgg, nil
+}
+
+// collectJoinSideGbyCols collects the columns of the idx-th join child used by the group
+// by items and the join conditions, they are the group by columns of the pushed aggregation.
+func collectJoinSideGbyCols(agg *logicalop.LogicalAggregation, join *logicalop.LogicalJoin,
+	sideSchema *expression.Schema, idx int) []*expression.Column {
+	evalCtx := agg.SCtx().GetExprCtx().GetEvalCtx()
+	gbyCols := make([]*expression.Column, 0, len(agg.GroupByItems)+len(join.EqualConditions))
+	addCols := func(cols ...*expression.Column) {
+		for _, col := range cols {
+			if !sideSchema.Contains(col) || slices.ContainsFunc(gbyCols, func(gbyCol *expression.Column) bool {
+				return col.Equal(evalCtx, gbyCol)
+			}) {
+				continue
+			}
+			gbyCols = append(gbyCols, col)
+		}
+	}
+	addCols(expression.ExtractColumnsFromExpressions(nil, agg.GroupByItems, nil)...)
+	for _, eqCond := range join.EqualConditions {
+		addCols(eqCond.GetArgs()[idx].(*expression.Column))
+	}
+	sideConds := join.LeftConditions
+	if idx == 1 {
+		sideConds = join.RightConditions
+	}
+	addCols(expression.ExtractColumnsFromExpressions(nil, sideConds, nil)...)
+	addCols(expression.ExtractColumnsFromExpressions(nil, join.OtherConditions, nil)...)
+	return gbyCols
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agg
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushAggDownProjection{}
+
+// XFPushAggDownProjection merges the projection into the aggregation above it, it
+// transforms `aggregation -> projection -> x` to `aggregation -> x`. The group by
+// items and the arguments of the aggregate functions are rewritten by the projection
+// expressions.
+type XFPushAggDownProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFPushAggDownProjection creates a new XFPushAggDownProjection rule.
+func NewXFPushAggDownProjection() *XFPushAggDownProjection {
+	pa := pattern.NewPattern(pattern.OperandAggregation, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandProjection, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushAggDownProjection{
+		BaseRule: rule.NewBaseRule(rule.XFPushAggDownProjection, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushAggDownProjection) ID() uint {
+	return uint(rule.XFPushAggDownProjection)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushAggDownProjection) PreCheck(aggGE base.LogicalPlan) bool {
+	proj := aggGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	return !proj.Proj4Expand && !slices.ContainsFunc(proj.Exprs, func(expr expression.Expression) bool {
+		return expression.HasAssignSetVarFunc(expr) || expression.IsMutableEffectsExpr(expr)
+	})
+}
+
+// XForm implements the Rule interface.
+func (*XFPushAggDownProjection) XForm(aggGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	projGE := aggGE.Children()[0]
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	exprCtx := agg.SCtx().GetExprCtx()
+	evalCtx := exprCtx.GetEvalCtx()
+	// substitute rewrites the expression by the projection, it fails when the eval
+	// type is changed, since the aggregate functions are built by the original type.
+	substitute := func(expr expression.Expression) (expression.Expression, bool) {
+		_, failed, newExpr := expression.ColumnSubstituteImpl(exprCtx, expr, proj.Schema(), proj.Exprs, true)
+		if failed || expression.ExprHasSetVarOrSleep(newExpr) ||
+			newExpr.GetType(evalCtx).EvalType() != expr.GetType(evalCtx).EvalType() {
+			return nil, false
+		}
+		return newExpr, true
+	}
+
+	newGbyItems := make([]expression.Expression, 0, len(agg.GroupByItems))
+	for _, gbyItem := range agg.GroupByItems {
+		newItem, ok := substitute(gbyItem)
+		if !ok {
+			return nil, false, nil
+		}
+		newGbyItems = append(newGbyItems, newItem)
+	}
+	newAggFuncs := make([]*aggregation.AggFuncDesc, 0, len(agg.AggFuncs))
+	for _, aggFunc := range agg.AggFuncs {
+		newAggFunc := aggFunc.Clone()
+		for i, arg := range aggFunc.Args {
+			newArg, ok := substitute(arg)
+			if !ok {
+				return nil, false, nil
+			}
+			newAggFunc.Args[i] = newArg
+		}
+		for i, by := range aggFunc.OrderByItems {
+			newBy, ok := substitute(by.Expr)
+			if !ok {
+				return nil, false, nil
+			}
+			newAggFunc.OrderByItems[i] = &util.ByItems{Expr: newBy, Desc: by.Desc}
+		}
+		newAggFuncs = append(newAggFuncs, newAggFunc)
+	}
+
+	newAgg := agg.LogicalAggregationShallowRef()
+	newAgg.ReAlloc4Cascades(plancodec.TypeAgg, newAgg)
+	newAgg.GroupByItems = newGbyItems
+	newAgg.AggFuncs = newAggFuncs
+	// the possible properties are derived from the original child, they are prepared again in physical optimization.
+	newAgg.PossibleProperties = nil
+	newAgg.SetChildren(projGE.Children()[0])
+	return []base.LogicalPlan{newAgg}, false, nil
+}
@@ -256,8 +256,14 @@ func (b *Binder) dfsMatch(p *pattern.Pattern, parentHolder base.LogicalPlan) boo
 		// we ensure that pattern len is equal to input child groups len.
 		childGroup := gE.Inputs[i]
 		b.traceIn(childPattern, childGroup)
-		// rebound the dynamic placeholder no matter whether it is CHANGED or NOT or NIL.
-		parentHolder.SetChild(i, b.pickGroupExpression(childPattern, childGroup))
+		childGE := b.pickGroupExpression(childPattern, childGroup)
+		if childGE == nil {
+			// the child group is exhausted, don't rebound the placeholder with a nil group expression,
+			// the wrapped logical plan may still refer to its child, say, deriving schema from it.
+			return false
+		}
+		// rebound the dynamic placeholder no matter whether it is CHANGED or NOT.
+		parentHolder.SetChild(i, childGE)
 		// we can sure that childPattern and element in Subs[i] is match when arrive here, recursive for child.
 		if !b.dfsMatch(childPattern, parentHolder.Children()[i]) {
 			return false
@@ -0,0 +1,238 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFJoinLeftToRightAssoc{}
+var _ rule.Rule = &XFJoinRightToLeftAssoc{}
+
+// XFJoinLeftToRightAssoc rotates the left deep inner join (A⋈B)⋈C to A⋈(B⋈C).
+// Together with XFJoinRightToLeftAssoc, it explores all the bushy join trees of
+// the join order decided by the normalization phase. Both rules keep the output
+// column order, so no projection is needed above the rotated join.
+type XFJoinLeftToRightAssoc struct {
+	*rule.BaseRule
+}
+
+// NewXFJoinLeftToRightAssoc creates a new XFJoinLeftToRightAssoc rule.
+func NewXFJoinLeftToRightAssoc() *XFJoinLeftToRightAssoc {
+	pa := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)),
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly))
+	return &XFJoinLeftToRightAssoc{
+		BaseRule: rule.NewBaseRule(rule.XFJoinLeftToRightAssoc, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFJoinLeftToRightAssoc) ID() uint {
+	return uint(rule.XFJoinLeftToRightAssoc)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFJoinLeftToRightAssoc) PreCheck(joinGE base.LogicalPlan) bool {
+	top := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	bottom := joinGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	return canBeAssociated(top) && canBeAssociated(bottom)
+}
+
+// XForm implements the Rule interface.
+func (*XFJoinLeftToRightAssoc) XForm(joinGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	top := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	bottomGE := joinGE.Children()[0]
+	bottom := bottomGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	newJoin := associate(top, bottom, []base.LogicalPlan{bottomGE.Children()[0], bottomGE.Children()[1], joinGE.Children()[1]}, true)
+	if newJoin == nil {
+		return nil, false, nil
+	}
+	return []base.LogicalPlan{newJoin}, false, nil
+}
+
+// XFJoinRightToLeftAssoc rotates the right deep inner join A⋈(B⋈C) to (A⋈B)⋈C.
+type XFJoinRightToLeftAssoc struct {
+	*rule.BaseRule
+}
+
+// NewXFJoinRightToLeftAssoc creates a new XFJoinRightToLeftAssoc rule.
+func NewXFJoinRightToLeftAssoc() *XFJoinRightToLeftAssoc {
+	pa := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly),
+		pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+			pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFJoinRightToLeftAssoc{
+		BaseRule: rule.NewBaseRule(rule.XFJoinRightToLeftAssoc, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFJoinRightToLeftAssoc) ID() uint {
+	return uint(rule.XFJoinRightToLeftAssoc)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFJoinRightToLeftAssoc) PreCheck(joinGE base.LogicalPlan) bool {
+	top := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	bottom := joinGE.Children()[1].GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	return canBeAssociated(top) && canBeAssociated(bottom)
+}
+
+// XForm implements the Rule interface.
+func (*XFJoinRightToLeftAssoc) XForm(joinGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	top := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	bottomGE := joinGE.Children()[1]
+	bottom := bottomGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	newJoin := associate(top, bottom, []base.LogicalPlan{joinGE.Children()[0], bottomGE.Children()[0], bottomGE.Children()[1]}, false)
+	if newJoin == nil {
+		return nil, false, nil
+	}
+	return []base.LogicalPlan{newJoin}, false, nil
+}
+
+// canBeAssociated checks whether the join can take part in the rotation. The join
+// with hints is kept as it is, since the hints are bound to the written join order.
+func canBeAssociated(join *logicalop.LogicalJoin) bool {
+	return join.JoinType == logicalop.InnerJoin && !join.StraightJoin && !join.PreferJoinOrder &&
+		join.PreferJoinType == 0 && join.LeftPreferJoinType == 0 && join.RightPreferJoinType == 0 &&
+		len(join.NAEQConditions) == 0
+}
+
+// associate builds the rotated join of the three inputs. When bottomOnRight is true,
+// the result is inputs[0]⋈(inputs[1]⋈inputs[2]), otherwise it's (inputs[0]⋈inputs[1])⋈inputs[2].
+// The conditions of the two joins are redistributed, the one only referring to the
+// columns of the new bottom join is attached to it. It returns nil if any of the new
+// joins becomes a cartesian product.
+func associate(top, bottom *logicalop.LogicalJoin, inputs []base.LogicalPlan, bottomOnRight bool) *logicalop.LogicalJoin {
+	bottomInputs, topInput := inputs[:2], inputs[0]
+	if bottomOnRight {
+		bottomInputs = inputs[1:]
+	} else {
+		topInput = inputs[2]
+	}
+	bottomSchema := expression.MergeSchema(bottomInputs[0].Schema(), bottomInputs[1].Schema())
+
+	conds := append(collectJoinConds(top), collectJoinConds(bottom)...)
+	bottomConds := make([]expression.Expression, 0, len(conds))
+	topConds := make([]expression.Expression, 0, len(conds))
+	for _, cond := range conds {
+		cols := expression.ExtractColumns(cond)
+		// constant conditions should have been folded in the normalization phase, leave it unchanged.
+		if len(cols) == 0 {
+			return nil
+		}
+		if bottomSchema.ColumnsIndices(cols) != nil {
+			bottomConds = append(bottomConds, cond)
+		} else {
+			topConds = append(topConds, cond)
+		}
+	}
+
+	newBottom := newInnerJoin(bottom)
+	newBottom.SetChildren(bottomInputs...)
+	if !attachConds(newBottom, bottomConds, bottomInputs[0].Schema(), bottomInputs[1].Schema()) {
+		return nil
+	}
+	// the new bottom join only outputs the columns used by the top join.
+	usedCols := expression.ExtractColumnsFromExpressions(slices.Clone(top.Schema().Columns), topConds, nil)
+	usedSchema := expression.NewSchema(usedCols...)
+	bottomNames := append(slices.Clone(bottomInputs[0].OutputNames()), bottomInputs[1].OutputNames()...)
+	namesAligned := len(bottomNames) == bottomSchema.Len()
+	newSchema := expression.NewSchema()
+	newNames := make(types.NameSlice, 0, bottomSchema.Len())
+	for i, col := range bottomSchema.Columns {
+		if usedSchema.Contains(col) {
+			newSchema.Append(col)
+			if namesAligned {
+				newNames = append(newNames, bottomNames[i])
+			}
+		}
+	}
+	if !namesAligned {
+		newNames = nil
+	}
+	newBottom.SetSchema(newSchema)
+	newBottom.SetOutputNames(newNames)
+
+	newTop := newInnerJoin(top)
+	if bottomOnRight {
+		newTop.SetChildren(topInput, newBottom)
+		if !attachConds(newTop, topConds, topInput.Schema(), newBottom.Schema()) {
+			return nil
+		}
+	} else {
+		newTop.SetChildren(newBottom, topInput)
+		if !attachConds(newTop, topConds, newBottom.Schema(), topInput.Schema()) {
+			return nil
+		}
+	}
+	return newTop
+}
+
+// newInnerJoin clones the join without the conditions and children for cascades.
+func newInnerJoin(join *logicalop.LogicalJoin) *logicalop.LogicalJoin {
+	newJoin := join.LogicalJoinShallowRef()
+	// ReAlloc4Cascades is to re-alloc the plan factors for cascades.
+	newJoin.ReAlloc4Cascades(plancodec.TypeJoin, newJoin)
+	newJoin.EqualConditions, newJoin.NAEQConditions = nil, nil
+	newJoin.LeftConditions, newJoin.RightConditions, newJoin.OtherConditions = nil, nil, nil
+	newJoin.LeftProperties, newJoin.RightProperties = nil, nil
+	newJoin.FullSchema, newJoin.FullNames = nil, nil
+	newJoin.EqualCondOutCnt = 0
+	newJoin.CartesianJoin = false
+	return newJoin
+}
+
+// attachConds classifies the conditions by the schema of the join inputs and attaches
+// them to the join. The conditions are sorted, so that the joins rotated from different
+// shapes can be recognized as the same group expression. It returns false if there is
+// no equal condition.
+func attachConds(join *logicalop.LogicalJoin, conds []expression.Expression, leftSchema, rightSchema *expression.Schema) bool {
+	eq, left, right, other := join.ExtractOnCondition(conds, leftSchema, rightSchema, false, false)
+	if len(eq) == 0 {
+		return false
+	}
+	slices.SortFunc(eq, func(a, b *expression.ScalarFunction) int {
+		return bytes.Compare(a.HashCode(), b.HashCode())
+	})
+	for _, exprs := range [][]expression.Expression{left, right, other} {
+		slices.SortFunc(exprs, func(a, b expression.Expression) int {
+			return bytes.Compare(a.HashCode(), b.HashCode())
+		})
+	}
+	join.AppendJoinConds(eq, left, right, other)
+	return true
+}
+
+func collectJoinConds(join *logicalop.LogicalJoin) []expression.Expression {
+	conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.LeftConditions)+len(join.RightConditions)+len(join.OtherConditions))
+	for _, cond := range join.EqualConditions {
+		conds = append(conds, cond)
+	}
+	conds = append(conds, join.LeftConditions...)
+	conds = append(conds, join.RightConditions...)
+	return append(conds, join.OtherConditions...)
+}
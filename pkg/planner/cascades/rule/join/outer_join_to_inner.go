@@ -0,0 +1,83 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFOuterJoinToInnerJoin{}
+
+// XFOuterJoinToInnerJoin converts the outer join to inner join when the selection
+// above it rejects the null rows supplemented from the inner side, it's the cascades
+// version of the classic rule ConvertOuterToInnerJoin.
+type XFOuterJoinToInnerJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFOuterJoinToInnerJoin creates a new XFOuterJoinToInnerJoin rule.
+func NewXFOuterJoinToInnerJoin() *XFOuterJoinToInnerJoin {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFOuterJoinToInnerJoin{
+		BaseRule: rule.NewBaseRule(rule.XFOuterJoinToInnerJoin, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFOuterJoinToInnerJoin) ID() uint {
+	return uint(rule.XFOuterJoinToInnerJoin)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFOuterJoinToInnerJoin) PreCheck(selGE base.LogicalPlan) bool {
+	join := selGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	return join.JoinType == logicalop.LeftOuterJoin || join.JoinType == logicalop.RightOuterJoin
+}
+
+// XForm implements the Rule interface.
+func (*XFOuterJoinToInnerJoin) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	joinGE := selGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	innerSchema := joinGE.Children()[1].Schema()
+	if join.JoinType == logicalop.RightOuterJoin {
+		innerSchema = joinGE.Children()[0].Schema()
+	}
+	nullRejected := false
+	for _, cond := range sel.Conditions {
+		if util.IsNullRejected(sel.SCtx(), innerSchema, cond, true) {
+			nullRejected = true
+			break
+		}
+	}
+	if !nullRejected {
+		return nil, false, nil
+	}
+	innerJoin := join.LogicalJoinShallowRef()
+	innerJoin.ReAlloc4Cascades(plancodec.TypeJoin, innerJoin)
+	innerJoin.JoinType = logicalop.InnerJoin
+	innerJoin.DefaultValues = nil
+	innerJoin.SetChildren(joinGE.Children()[0], joinGE.Children()[1])
+	newSel := logicalop.LogicalSelection{Conditions: sel.Conditions}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(innerJoin)
+	return []base.LogicalPlan{newSel}, false, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFMergeAdjacentProjection{}
+
+// XFMergeAdjacentProjection merges the adjacent projections, it transforms
+// `projection -> projection -> x` to `projection -> x`. The expressions of the
+// upper projection are rewritten by the lower one.
+type XFMergeAdjacentProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFMergeAdjacentProjection creates a new XFMergeAdjacentProjection rule.
+func NewXFMergeAdjacentProjection() *XFMergeAdjacentProjection {
+	pa := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandProjection, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFMergeAdjacentProjection{
+		BaseRule: rule.NewBaseRule(rule.XFMergeAdjacentProjection, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFMergeAdjacentProjection) ID() uint {
+	return uint(rule.XFMergeAdjacentProjection)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFMergeAdjacentProjection) PreCheck(projGE base.LogicalPlan) bool {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	child := projGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	return !proj.Proj4Expand && !child.Proj4Expand && !expression.ExprsHasSideEffects(child.Exprs)
+}
+
+// XForm implements the Rule interface.
+func (*XFMergeAdjacentProjection) XForm(projGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	childGE := projGE.Children()[0]
+	child := childGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	exprCtx := proj.SCtx().GetExprCtx()
+	evalCtx := exprCtx.GetEvalCtx()
+	newExprs := make([]expression.Expression, 0, len(proj.Exprs))
+	for _, expr := range proj.Exprs {
+		// ReplaceColumnOfExpr modifies the expression in place, clone it since it's shared with the original projection.
+		replaced := ruleutil.ReplaceColumnOfExpr(expr.Clone(), child.Exprs, child.Schema())
+		folded := expression.FoldConstant(exprCtx, replaced)
+		// the folded expr should have the same null flag with the original expr, especially for the
+		// projection under union. The type may be shared with the lower projection, so give up here
+		// instead of forcing the flag like the classic rule.
+		if mysql.HasNotNullFlag(folded.GetType(evalCtx).GetFlag()) != mysql.HasNotNullFlag(expr.GetType(evalCtx).GetFlag()) {
+			return nil, false, nil
+		}
+		newExprs = append(newExprs, folded)
+	}
+	newProj := proj.LogicalProjectionShallowRef()
+	newProj.ReAlloc4Cascades(plancodec.TypeProj, newProj)
+	newProj.Exprs = newExprs
+	newProj.SetChildren(childGE.Children()[0])
+	return []base.LogicalPlan{newProj}, false, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPruneJoinColumns{}
+
+// XFPruneJoinColumns prunes the output columns of the join which are not used by
+// the projection above, it transforms `projection -> join` to `projection -> join'`
+// where join' only outputs the columns referred by the projection. The joins
+// produced by the other rules, e.g. the rotated or the aggregation pushed down
+// ones, may output more columns than needed, so they are pruned here.
+type XFPruneJoinColumns struct {
+	*rule.BaseRule
+}
+
+// NewXFPruneJoinColumns creates a new XFPruneJoinColumns rule.
+func NewXFPruneJoinColumns() *XFPruneJoinColumns {
+	pa := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPruneJoinColumns{
+		BaseRule: rule.NewBaseRule(rule.XFPruneJoinColumns, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPruneJoinColumns) ID() uint {
+	return uint(rule.XFPruneJoinColumns)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPruneJoinColumns) PreCheck(projGE base.LogicalPlan) bool {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	join := projGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	// the schema of semi join is decided by its left child, and the aux column of
+	// the outer semi join is always needed, so only the inner and outer joins are pruned.
+	switch join.JoinType {
+	case logicalop.InnerJoin, logicalop.LeftOuterJoin, logicalop.RightOuterJoin:
+	default:
+		return false
+	}
+	if proj.Proj4Expand {
+		return false
+	}
+	return len(usedJoinColumns(proj, join)) < join.Schema().Len()
+}
+
+// XForm implements the Rule interface.
+func (*XFPruneJoinColumns) XForm(projGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	joinGE := projGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	names := join.OutputNames()
+	namesAligned := len(names) == join.Schema().Len()
+	used := usedJoinColumns(proj, join)
+	newSchema := expression.NewSchema()
+	newNames := make(types.NameSlice, 0, len(used))
+	for _, i := range used {
+		newSchema.Append(join.Schema().Columns[i])
+		if namesAligned {
+			newNames = append(newNames, names[i])
+		}
+	}
+	if !namesAligned {
+		newNames = nil
+	}
+	newJoin := join.LogicalJoinShallowRef()
+	// ReAlloc4Cascades is to re-alloc the plan factors for cascades.
+	newJoin.ReAlloc4Cascades(plancodec.TypeJoin, newJoin)
+	newJoin.LeftProperties, newJoin.RightProperties = nil, nil
+	newJoin.SetChildren(joinGE.Children()...)
+	newJoin.SetSchema(newSchema)
+	newJoin.SetOutputNames(newNames)
+
+	newProj := proj.LogicalProjectionShallowRef()
+	newProj.ReAlloc4Cascades(plancodec.TypeProj, newProj)
+	newProj.SetChildren(newJoin)
+	return []base.LogicalPlan{newProj}, false, nil
+}
+
+// usedJoinColumns returns the offsets of the join output columns referred by the projection.
+func usedJoinColumns(proj *logicalop.LogicalProjection, join *logicalop.LogicalJoin) []int {
+	usedSchema := expression.NewSchema(expression.ExtractColumnsFromExpressions(nil, proj.Exprs, nil)...)
+	used := make([]int, 0, join.Schema().Len())
+	for i, col := range join.Schema().Columns {
+		if usedSchema.Contains(col) {
+			used = append(used, i)
+		}
+	}
+	if len(used) == 0 {
+		// keep one column at least, the join can't output nothing.
+		used = append(used, 0)
+	}
+	return used
+}
@@ -41,6 +41,30 @@ const (
 	XFPullCorrPredFromAgg1
 	// XFPullCorrPredFromAgg2 try to pull correlated expression from agg<selection> from inner child of an apply.
 	XFPullCorrPredFromAgg2
+	// XFJoinLeftToRightAssoc rotates the left deep inner join (A⋈B)⋈C to A⋈(B⋈C).
+	XFJoinLeftToRightAssoc
+	// XFJoinRightToLeftAssoc rotates the right deep inner join A⋈(B⋈C) to (A⋈B)⋈C.
+	XFJoinRightToLeftAssoc
+	// XFOuterJoinToInnerJoin converts the outer join to inner join when the selection above rejects the null rows.
+	XFOuterJoinToInnerJoin
+	// XFPushSelDownProjection pushes the selection down through the projection.
+	XFPushSelDownProjection
+	// XFPushSelDownJoin pushes the selection down through the join.
+	XFPushSelDownJoin
+	// XFPushTopNDownProjection pushes the topN down through the projection.
+	XFPushTopNDownProjection
+	// XFPushTopNDownOuterJoin pushes the topN down to the outer side of the outer join.
+	XFPushTopNDownOuterJoin
+	// XFPushAggDownProjection pushes the aggregation down through the projection.
+	XFPushAggDownProjection
+	// XFEliminateAggOnUniqueKey converts the aggregation grouped by the unique key of its child to projection.
+	XFEliminateAggOnUniqueKey
+	// XFPushAggDownJoin pushes the aggregation down to one side of the inner join.
+	XFPushAggDownJoin
+	// XFMergeAdjacentProjection merges the adjacent projections.
+	XFMergeAdjacentProjection
+	// XFPruneJoinColumns prunes the output columns of the join which are not used by the projection above.
+	XFPruneJoinColumns
 	// XFMaximumRuleLength is the maximum rule length.
 	XFMaximumRuleLength
 )
@@ -50,6 +74,32 @@ func (tp *Type) String() string {
 	switch *tp {
 	case XFJoinToApply:
 		return "join_to_apply"
+	case XFDeCorrelateSimpleApply:
+		return "decorrelate_simple_apply"
+	case XFJoinLeftToRightAssoc:
+		return "join_left_to_right_assoc"
+	case XFJoinRightToLeftAssoc:
+		return "join_right_to_left_assoc"
+	case XFOuterJoinToInnerJoin:
+		return "outer_join_to_inner_join"
+	case XFPushSelDownProjection:
+		return "push_selection_down_projection"
+	case XFPushSelDownJoin:
+		return "push_selection_down_join"
+	case XFPushTopNDownProjection:
+		return "push_topn_down_projection"
+	case XFPushTopNDownOuterJoin:
+		return "push_topn_down_outer_join"
+	case XFPushAggDownProjection:
+		return "push_agg_down_projection"
+	case XFEliminateAggOnUniqueKey:
+		return "eliminate_agg_on_unique_key"
+	case XFPushAggDownJoin:
+		return "push_agg_down_join"
+	case XFMergeAdjacentProjection:
+		return "merge_adjacent_projection"
+	case XFPruneJoinColumns:
+		return "prune_join_columns"
 	default:
 		return "default_none"
 	}
@@ -19,7 +19,12 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
 	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
 	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/agg"
 	"github.com/pingcap/tidb/pkg/planner/cascades/rule/apply/decorrelateapply"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/join"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/projection"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/selection"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/topn"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 )
 
@@ -35,7 +40,12 @@ const (
 
 // DefaultRuleSets indicates the all rule set.
 var DefaultRuleSets = map[pattern.Operand]*OperandRules{
-	pattern.OperandApply: OperandApplyRules,
+	pattern.OperandApply:       OperandApplyRules,
+	pattern.OperandSelection:   OperandSelectionRules,
+	pattern.OperandJoin:        OperandJoinRules,
+	pattern.OperandTopN:        OperandTopNRules,
+	pattern.OperandAggregation: OperandAggregationRules,
+	pattern.OperandProjection:  OperandProjectionRules,
 }
 
 // OperandRules wrapper all the rules rooted from one specified operator.
@@ -82,3 +92,50 @@ var OperandApplyRulesMap = map[SetType][]rule.Rule{
 var OperandApplyRulesList = []rule.Rule{
 	decorrelateapply.NewXFDeCorrelateSimpleApply(),
 }
+
+// OperandSelectionRules is the rules rooted from a selection operand.
+var OperandSelectionRules = &OperandRules{nil, OperandSelectionRulesList}
+
+// OperandSelectionRulesList is the rules rooted from a selection operand, organized as list.
+var OperandSelectionRulesList = []rule.Rule{
+	join.NewXFOuterJoinToInnerJoin(),
+	selection.NewXFPushSelDownProjection(),
+	selection.NewXFPushSelDownJoin(),
+}
+
+// OperandJoinRules is the rules rooted from a join operand.
+var OperandJoinRules = &OperandRules{nil, OperandJoinRulesList}
+
+// OperandJoinRulesList is the rules rooted from a join operand, organized as list.
+var OperandJoinRulesList = []rule.Rule{
+	join.NewXFJoinLeftToRightAssoc(),
+	join.NewXFJoinRightToLeftAssoc(),
+}
+
+// OperandTopNRules is the rules rooted from a topN operand.
+var OperandTopNRules = &OperandRules{nil, OperandTopNRulesList}
+
+// OperandTopNRulesList is the rules rooted from a topN operand, organized as list.
+var OperandTopNRulesList = []rule.Rule{
+	topn.NewXFPushTopNDownProjection(),
+	topn.NewXFPushTopNDownOuterJoin(),
+}
+
+// OperandAggregationRules is the rules rooted from an aggregation operand.
+var OperandAggregationRules = &OperandRules{nil, OperandAggregationRulesList}
+
+// OperandAggregationRulesList is the rules rooted from an aggregation operand, organized as list.
+var OperandAggregationRulesList = []rule.Rule{
+	agg.NewXFPushAggDownProjection(),
+	agg.NewXFEliminateAggOnUniqueKey(),
+	agg.NewXFPushAggDownJoin(),
+}
+
+// OperandProjectionRules is the rules rooted from a projection operand.
+var OperandProjectionRules = &OperandRules{nil, OperandProjectionRulesList}
+
+// OperandProjectionRulesList is the rules rooted from a projection operand, organized as list.
+var OperandProjectionRulesList = []rule.Rule{
+	projection.NewXFMergeAdjacentProjection(),
+	projection.NewXFPruneJoinColumns(),
+}
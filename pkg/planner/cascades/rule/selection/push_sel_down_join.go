@@ -0,0 +1,127 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushSelDownJoin{}
+
+// XFPushSelDownJoin pushes the selection down through the join. For inner join,
+// the conditions become the join conditions or the selections above the join
+// children. For the other join types, only the conditions referring to the
+// preserved side are pushed down to it.
+type XFPushSelDownJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFPushSelDownJoin creates a new XFPushSelDownJoin rule.
+func NewXFPushSelDownJoin() *XFPushSelDownJoin {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushSelDownJoin{
+		BaseRule: rule.NewBaseRule(rule.XFPushSelDownJoin, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushSelDownJoin) ID() uint {
+	return uint(rule.XFPushSelDownJoin)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushSelDownJoin) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	joinGE := selGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	leftGE, rightGE := joinGE.Children()[0], joinGE.Children()[1]
+
+	var conds, remained []expression.Expression
+	for _, cond := range sel.Conditions {
+		if expression.HasGetSetVarFunc(cond) {
+			remained = append(remained, cond)
+		} else {
+			conds = append(conds, cond)
+		}
+	}
+	var (
+		eq                    []*expression.ScalarFunction
+		leftConds, rightConds []expression.Expression
+		otherConds            []expression.Expression
+	)
+	switch join.JoinType {
+	case logicalop.InnerJoin:
+		// the join type is inner join, so ExtractOnCondition won't modify the join itself.
+		eq, leftConds, rightConds, otherConds = join.ExtractOnCondition(conds, leftGE.Schema(), rightGE.Schema(), false, false)
+	case logicalop.LeftOuterJoin, logicalop.SemiJoin, logicalop.AntiSemiJoin, logicalop.LeftOuterSemiJoin, logicalop.AntiLeftOuterSemiJoin:
+		leftConds, remained = splitByPreservedSchema(conds, leftGE.Schema(), remained)
+	case logicalop.RightOuterJoin:
+		rightConds, remained = splitByPreservedSchema(conds, rightGE.Schema(), remained)
+	default:
+		return nil, false, nil
+	}
+	if len(eq) == 0 && len(leftConds) == 0 && len(rightConds) == 0 && len(otherConds) == 0 {
+		return nil, false, nil
+	}
+
+	newJoin := join.LogicalJoinShallowRef()
+	newJoin.ReAlloc4Cascades(plancodec.TypeJoin, newJoin)
+	var newLeft, newRight base.LogicalPlan = leftGE, rightGE
+	if len(leftConds) > 0 {
+		newLeft = logicalop.LogicalSelection{Conditions: leftConds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+		newLeft.SetChildren(leftGE)
+	}
+	if len(rightConds) > 0 {
+		newRight = logicalop.LogicalSelection{Conditions: rightConds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+		newRight.SetChildren(rightGE)
+	}
+	newJoin.SetChildren(newLeft, newRight)
+	if join.JoinType == logicalop.InnerJoin {
+		newJoin.EqualConditions = append(slices.Clone(join.EqualConditions), eq...)
+		newJoin.OtherConditions = append(slices.Clone(join.OtherConditions), otherConds...)
+		newJoin.CartesianJoin = newJoin.CartesianJoin && len(newJoin.EqualConditions) == 0
+	}
+	if len(remained) == 0 {
+		return []base.LogicalPlan{newJoin}, false, nil
+	}
+	topSel := logicalop.LogicalSelection{Conditions: remained}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	topSel.SetChildren(newJoin)
+	return []base.LogicalPlan{topSel}, false, nil
+}
+
+// splitByPreservedSchema splits the conditions into the ones only referring to the
+// preserved side of the join and the others, the latter are appended to remained.
+func splitByPreservedSchema(conds []expression.Expression, preservedSchema *expression.Schema,
+	remained []expression.Expression) ([]expression.Expression, []expression.Expression) {
+	var pushed []expression.Expression
+	for _, cond := range conds {
+		cols := expression.ExtractColumns(cond)
+		if len(cols) > 0 && preservedSchema.ColumnsIndices(cols) != nil {
+			pushed = append(pushed, cond)
+		} else {
+			remained = append(remained, cond)
+		}
+	}
+	return pushed, remained
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushSelDownProjection{}
+
+// XFPushSelDownProjection pushes the selection down through the projection, the
+// conditions are rewritten by the projection expressions. It transforms
+// `selection -> projection -> x` to
+// 1. `projection -> selection -> x` or
+// 2. `selection -> projection -> selection -> x` if some conditions can't be pushed.
+type XFPushSelDownProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFPushSelDownProjection creates a new XFPushSelDownProjection rule.
+func NewXFPushSelDownProjection() *XFPushSelDownProjection {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandProjection, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushSelDownProjection{
+		BaseRule: rule.NewBaseRule(rule.XFPushSelDownProjection, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushSelDownProjection) ID() uint {
+	return uint(rule.XFPushSelDownProjection)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushSelDownProjection) PreCheck(selGE base.LogicalPlan) bool {
+	proj := selGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	return !slices.ContainsFunc(proj.Exprs, expression.HasAssignSetVarFunc)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushSelDownProjection) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	projGE := selGE.Children()[0]
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	exprCtx := sel.SCtx().GetExprCtx()
+	canBePushed := make([]expression.Expression, 0, len(sel.Conditions))
+	canNotBePushed := make([]expression.Expression, 0, len(sel.Conditions))
+	for _, cond := range sel.Conditions {
+		substituted, hasFailed, newCond := expression.ColumnSubstituteImpl(exprCtx, cond, proj.Schema(), proj.Exprs, true)
+		if substituted && !hasFailed && !expression.HasGetSetVarFunc(newCond) {
+			canBePushed = append(canBePushed, newCond)
+		} else {
+			canNotBePushed = append(canNotBePushed, cond)
+		}
+	}
+	if len(canBePushed) == 0 {
+		return nil, false, nil
+	}
+	newSel := logicalop.LogicalSelection{Conditions: canBePushed}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(projGE.Children()[0])
+	newProj := proj.LogicalProjectionShallowRef()
+	newProj.ReAlloc4Cascades(plancodec.TypeProj, newProj)
+	newProj.SetChildren(newSel)
+	if len(canNotBePushed) == 0 {
+		return []base.LogicalPlan{newProj}, false, nil
+	}
+	topSel := logicalop.LogicalSelection{Conditions: canNotBePushed}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	topSel.SetChildren(newProj)
+	return []base.LogicalPlan{topSel}, false, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topn
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushTopNDownOuterJoin{}
+
+// XFPushTopNDownOuterJoin pushes the topN down to the outer side of the outer join
+// when all the order by items come from the outer side. It transforms
+// `topN -> join(x, y)` to `topN -> join(topN' -> x, y)`, where topN' keeps the first
+// count+offset rows of x. The top topN is kept, since the inner side may produce
+// more than one row for each outer row.
+type XFPushTopNDownOuterJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFPushTopNDownOuterJoin creates a new XFPushTopNDownOuterJoin rule.
+func NewXFPushTopNDownOuterJoin() *XFPushTopNDownOuterJoin {
+	pa := pattern.NewPattern(pattern.OperandTopN, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandJoin, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly), pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushTopNDownOuterJoin{
+		BaseRule: rule.NewBaseRule(rule.XFPushTopNDownOuterJoin, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushTopNDownOuterJoin) ID() uint {
+	return uint(rule.XFPushTopNDownOuterJoin)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushTopNDownOuterJoin) PreCheck(topNGE base.LogicalPlan) bool {
+	topN := topNGE.GetWrappedLogicalPlan().(*logicalop.LogicalTopN)
+	if len(topN.ByItems) == 0 || len(topN.PartitionBy) > 0 {
+		return false
+	}
+	joinGE := topNGE.Children()[0]
+	outerIdx := outerChildIdx(joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin))
+	if outerIdx < 0 {
+		return false
+	}
+	// the topN has been pushed to the outer side already, pushing it again only
+	// stacks another same topN upon it.
+	return pattern.GetOperand(joinGE.Children()[outerIdx].GetWrappedLogicalPlan()) != pattern.OperandTopN
+}
+
+// XForm implements the Rule interface.
+func (*XFPushTopNDownOuterJoin) XForm(topNGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	topN := topNGE.GetWrappedLogicalPlan().(*logicalop.LogicalTopN)
+	joinGE := topNGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	outerIdx := outerChildIdx(join)
+	outerGE := joinGE.Children()[outerIdx]
+	for _, by := range topN.ByItems {
+		for _, col := range expression.ExtractColumns(by.Expr) {
+			if !outerGE.Schema().Contains(col) {
+				return nil, false, nil
+			}
+		}
+	}
+	pushedTopN := logicalop.LogicalTopN{
+		ByItems:          topN.ByItems,
+		Offset:           0,
+		Count:            topN.Count + topN.Offset,
+		PreferLimitToCop: topN.PreferLimitToCop,
+	}.Init(topN.SCtx(), topN.QueryBlockOffset())
+	pushedTopN.SetChildren(outerGE)
+	pushedTopN.SetSchema(outerGE.Schema().Clone())
+
+	newJoin := join.LogicalJoinShallowRef()
+	newJoin.ReAlloc4Cascades(plancodec.TypeJoin, newJoin)
+	if outerIdx == 0 {
+		newJoin.SetChildren(pushedTopN, joinGE.Children()[1])
+	} else {
+		newJoin.SetChildren(joinGE.Children()[0], pushedTopN)
+	}
+
+	newTopN := logicalop.LogicalTopN{
+		ByItems:          topN.ByItems,
+		Offset:           topN.Offset,
+		Count:            topN.Count,
+		PreferLimitToCop: topN.PreferLimitToCop,
+	}.Init(topN.SCtx(), topN.QueryBlockOffset())
+	newTopN.SetChildren(newJoin)
+	newTopN.SetSchema(topN.Schema().Clone())
+	return []base.LogicalPlan{newTopN}, false, nil
+}
+
+// outerChildIdx returns the index of the outer child of the join, or -1 if the
+// join doesn't preserve all the rows of one side.
+func outerChildIdx(join *logicalop.LogicalJoin) int {
+	switch join.JoinType {
+	case logicalop.LeftOuterJoin, logicalop.LeftOuterSemiJoin, logicalop.AntiLeftOuterSemiJoin:
+		return 0
+	case logicalop.RightOuterJoin:
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topn
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+var _ rule.Rule = &XFPushTopNDownProjection{}
+
+// XFPushTopNDownProjection pushes the topN down through the projection, it transforms
+// `topN -> projection -> x` to `projection -> topN -> x`. The order by items are
+// rewritten by the projection expressions.
+type XFPushTopNDownProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFPushTopNDownProjection creates a new XFPushTopNDownProjection rule.
+func NewXFPushTopNDownProjection() *XFPushTopNDownProjection {
+	pa := pattern.NewPattern(pattern.OperandTopN, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.BuildPattern(pattern.OperandProjection, pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly)))
+	return &XFPushTopNDownProjection{
+		BaseRule: rule.NewBaseRule(rule.XFPushTopNDownProjection, pa),
+	}
+}
+
+// ID implement the Rule interface.
+func (*XFPushTopNDownProjection) ID() uint {
+	return uint(rule.XFPushTopNDownProjection)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushTopNDownProjection) PreCheck(topNGE base.LogicalPlan) bool {
+	topN := topNGE.GetWrappedLogicalPlan().(*logicalop.LogicalTopN)
+	proj := topNGE.Children()[0].GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	return len(topN.ByItems) > 0 && len(topN.PartitionBy) == 0 &&
+		!slices.ContainsFunc(proj.Exprs, expression.HasAssignSetVarFunc)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushTopNDownProjection) XForm(topNGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	topN := topNGE.GetWrappedLogicalPlan().(*logicalop.LogicalTopN)
+	projGE := topNGE.Children()[0]
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	childGE := projGE.Children()[0]
+	exprCtx := topN.SCtx().GetExprCtx()
+	byItems := make([]*util.ByItems, 0, len(topN.ByItems))
+	for _, by := range topN.ByItems {
+		substituted := expression.FoldConstant(exprCtx, expression.ColumnSubstitute(exprCtx, by.Expr, proj.Schema(), proj.Exprs))
+		if !expression.IsImmutableFunc(substituted) {
+			// after substituting, if the order-by expression is un-deterministic like 'order by rand()', stop pushing down.
+			return nil, false, nil
+		}
+		switch substituted.(type) {
+		case *expression.Constant, *expression.CorrelatedColumn:
+			// remove meaningless constant sort items.
+			continue
+		}
+		for _, col := range expression.ExtractColumns(substituted) {
+			if !childGE.Schema().Contains(col) {
+				return nil, false, nil
+			}
+		}
+		byItems = append(byItems, &util.ByItems{Expr: substituted, Desc: by.Desc})
+	}
+	if len(byItems) == 0 {
+		// the topN degenerates to a limit, leave it to the normalization phase.
+		return nil, false, nil
+	}
+	newTopN := logicalop.LogicalTopN{
+		ByItems:          byItems,
+		Offset:           topN.Offset,
+		Count:            topN.Count,
+		PreferLimitToCop: topN.PreferLimitToCop,
+	}.Init(topN.SCtx(), topN.QueryBlockOffset())
+	newTopN.SetChildren(childGE)
+	newTopN.SetSchema(childGE.Schema().Clone())
+	newProj := proj.LogicalProjectionShallowRef()
+	newProj.ReAlloc4Cascades(plancodec.TypeProj, newProj)
+	newProj.SetChildren(newTopN)
+	return []base.LogicalPlan{newProj}, false, nil
+}
@@ -0,0 +1,402 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/funcdep"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+)
+
+// errMemoNotImplementable means the memo can't be implemented group by group, the logical alternatives should be
+// costed one by one instead.
+var errMemoNotImplementable = errors.New("memo can't be implemented group by group")
+
+// memoImplementor implements the memo into the physical plan group by group. The implementation rules of a group
+// expression are the physical plans enumerated by ExhaustPhysicalPlans of its logical operator, and the child tasks
+// are the best tasks of its input groups under the properties required by the physical plan. So all the logical
+// alternatives in memo are costed, while the sub-plans shared by them are only implemented once. The cheapest
+// alternative is then implemented by physicalOptimize, so that its operators derive their stats from their own
+// children instead of the ones of the group.
+type memoImplementor struct {
+	opt *optimizetrace.PhysicalOptimizeOp
+	// reps is the representative plan of each prepared group, which is nil if all the group expressions of the
+	// group are unusable.
+	reps map[*memo.Group]base.LogicalPlan
+	// usable records the group expressions which can be implemented. A group expression referring to a group on
+	// its own path, which is a cycle formed by group merge, is unusable.
+	usable map[*memo.GroupExpression]struct{}
+	// possibleProps is the possible properties of the prepared logical plans.
+	possibleProps map[base.LogicalPlan][][]*expression.Column
+	// reloaded records whether the stats of the prepared logical plans are reloaded, the parents should reload
+	// their stats too, like RecursiveDeriveStats.
+	reloaded map[base.LogicalPlan]bool
+	// bestTasks caches the best task of the groups for each required property.
+	bestTasks map[*memo.Group]map[string]base.Task
+	// bestGEs records the group expression of the best task of the groups for each required property.
+	bestGEs map[*memo.Group]map[string]*memo.GroupExpression
+	// bestChildProps records the properties required on the input groups by the best task of the group
+	// expressions for each required property.
+	bestChildProps map[*memo.GroupExpression]map[string][]*property.PhysicalProperty
+}
+
+func newMemoImplementor(opt *optimizetrace.PhysicalOptimizeOp) *memoImplementor {
+	return &memoImplementor{
+		opt:            opt,
+		reps:           make(map[*memo.Group]base.LogicalPlan),
+		usable:         make(map[*memo.GroupExpression]struct{}),
+		possibleProps:  make(map[base.LogicalPlan][][]*expression.Column),
+		reloaded:       make(map[base.LogicalPlan]bool),
+		bestTasks:      make(map[*memo.Group]map[string]base.Task),
+		bestGEs:        make(map[*memo.Group]map[string]*memo.GroupExpression),
+		bestChildProps: make(map[*memo.GroupExpression]map[string][]*property.PhysicalProperty),
+	}
+}
+
+// implementMemo finds the best physical plan of the memo, it returns errMemoNotImplementable if the memo has the
+// operators whose children can't be implemented separately.
+func implementMemo(mm *memo.Memo) (plan base.PhysicalPlan, cost float64, err error) {
+	root := mm.GetRootGroup()
+	m := newMemoImplementor(optimizetrace.DefaultPhysicalOptimizeOption())
+	rootPlan, err := m.prepare(root, make(map[*memo.Group]struct{}))
+	if err != nil {
+		return nil, 0, err
+	}
+	if rootPlan == nil {
+		return nil, 0, errMemoNotImplementable
+	}
+	sessVars := rootPlan.SCtx().GetSessionVars()
+	sessVars.StmtCtx.TaskMapBakTS = 0
+	// the physical plans built in the search are dropped, the plan and column ids are restored to build the final plan.
+	savedPlanID, savedPlanColumnID := sessVars.PlanID.Load(), sessVars.PlanColumnID.Load()
+	prop := &property.PhysicalProperty{
+		TaskTp:      property.RootTaskType,
+		ExpectedCnt: math.MaxFloat64,
+	}
+	t, err := m.implementGroup(root, prop)
+	if err != nil {
+		return nil, 0, err
+	}
+	if t.Invalid() {
+		return nil, 0, errMemoNotImplementable
+	}
+	planCounter := base.PlanCounterTp(-1)
+	lp, _, err := m.bestLogicalPlan(root, prop)
+	if err != nil {
+		return nil, 0, err
+	}
+	sessVars.PlanID.Store(savedPlanID)
+	sessVars.PlanColumnID.Store(savedPlanColumnID)
+	return physicalOptimize(lp, &planCounter)
+}
+
+// prepare sets the children of the usable group expressions in the group to the representative plans of their
+// input groups, then derives their stats and possible properties, which are needed by ExhaustPhysicalPlans.
+// It returns the representative plan of the group.
+func (m *memoImplementor) prepare(g *memo.Group, path map[*memo.Group]struct{}) (base.LogicalPlan, error) {
+	if rep, ok := m.reps[g]; ok {
+		return rep, nil
+	}
+	path[g] = struct{}{}
+	defer delete(path, g)
+	var (
+		rep base.LogicalPlan
+		err error
+	)
+	g.ForEachGE(func(ge *memo.GroupExpression) bool {
+		lp := ge.GetWrappedLogicalPlan()
+		if _, ok := lp.(*logicalop.LogicalSequence); ok {
+			// the children of sequence are implemented one by one, which depends on the former ones.
+			err = errMemoNotImplementable
+			return false
+		}
+		children := make([]base.LogicalPlan, 0, len(ge.Inputs))
+		for _, input := range ge.Inputs {
+			if _, ok := path[input]; ok {
+				return true
+			}
+			var child base.LogicalPlan
+			if child, err = m.prepare(input, path); err != nil {
+				return false
+			}
+			if child == nil {
+				return true
+			}
+			children = append(children, child)
+		}
+		childStats := make([]*property.StatsInfo, 0, len(children))
+		childSchema := make([]*expression.Schema, 0, len(children))
+		childProps := make([][][]*expression.Column, 0, len(children))
+		reloads := make([]bool, 0, len(children))
+		for _, child := range children {
+			childStats = append(childStats, child.StatsInfo())
+			childSchema = append(childSchema, child.Schema())
+			childProps = append(childProps, m.possibleProps[child])
+			reloads = append(reloads, m.reloaded[child])
+		}
+		if len(children) > 0 {
+			lp.SetChildren(children...)
+		}
+		var reload bool
+		if _, reload, err = lp.DeriveStats(childStats, lp.Schema(), childSchema, reloads); err != nil {
+			return false
+		}
+		m.reloaded[lp] = reload
+		m.possibleProps[lp] = lp.PreparePossibleProperties(lp.Schema(), childProps...)
+		m.usable[ge] = struct{}{}
+		if rep == nil {
+			rep = lp
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.reps[g] = rep
+	return rep, nil
+}
+
+// implementGroup returns the best task of the group under the required property.
+func (m *memoImplementor) implementGroup(g *memo.Group, prop *property.PhysicalProperty) (base.Task, error) {
+	key := string(prop.HashCode())
+	if t, ok := m.bestTasks[g][key]; ok {
+		return t, nil
+	}
+	bestTask := base.InvalidTask
+	var (
+		bestGE *memo.GroupExpression
+		err    error
+	)
+	g.ForEachGE(func(ge *memo.GroupExpression) bool {
+		if _, ok := m.usable[ge]; !ok {
+			return true
+		}
+		var (
+			curTask       base.Task
+			curChildProps []*property.PhysicalProperty
+			curIsBetter   bool
+		)
+		if curTask, curChildProps, err = m.implementGroupExpression(ge, prop); err != nil {
+			return false
+		}
+		if curIsBetter, err = compareTaskCost(curTask, bestTask, m.opt); err != nil {
+			return false
+		}
+		if curIsBetter {
+			bestTask, bestGE = curTask, ge
+			if m.bestChildProps[ge] == nil {
+				m.bestChildProps[ge] = make(map[string][]*property.PhysicalProperty)
+			}
+			m.bestChildProps[ge][key] = curChildProps
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m.bestTasks[g] == nil {
+		m.bestTasks[g] = make(map[string]base.Task)
+		m.bestGEs[g] = make(map[string]*memo.GroupExpression)
+	}
+	m.bestTasks[g][key] = bestTask
+	m.bestGEs[g][key] = bestGE
+	return bestTask, nil
+}
+
+// bestLogicalPlan builds the logical alternative of the best task of the group under the required property. The
+// stats of the operators are derived from the representative plans of their input groups in prepare, so they are
+// derived again when the children are different, it returns whether the stats are derived again.
+func (m *memoImplementor) bestLogicalPlan(g *memo.Group, prop *property.PhysicalProperty) (base.LogicalPlan, bool, error) {
+	key := string(prop.HashCode())
+	ge := m.bestGEs[g][key]
+	lp := ge.GetWrappedLogicalPlan()
+	if len(ge.Inputs) == 0 {
+		return lp, false, nil
+	}
+	childProps := m.bestChildProps[ge][key]
+	children := make([]base.LogicalPlan, 0, len(ge.Inputs))
+	reload := false
+	for j, input := range ge.Inputs {
+		if childProps[j] == nil {
+			// the inner child of index join is built from the representative plan by the join itself.
+			children = append(children, m.reps[input])
+			continue
+		}
+		child, childReload, err := m.bestLogicalPlan(input, childProps[j])
+		if err != nil {
+			return nil, false, err
+		}
+		reload = reload || childReload || child != m.reps[input]
+		children = append(children, child)
+	}
+	lp.SetChildren(children...)
+	if !reload {
+		return lp, false, nil
+	}
+	childStats := make([]*property.StatsInfo, 0, len(children))
+	childSchema := make([]*expression.Schema, 0, len(children))
+	reloads := make([]bool, 0, len(children))
+	for _, child := range children {
+		childStats = append(childStats, child.StatsInfo())
+		childSchema = append(childSchema, child.Schema())
+		reloads = append(reloads, true)
+	}
+	if _, _, err := lp.DeriveStats(childStats, lp.Schema(), childSchema, reloads); err != nil {
+		return nil, false, err
+	}
+	return lp, true, nil
+}
+
+// implementGroupExpression returns the best task of the group expression under the required property and the
+// properties it requires on the input groups, it follows findBestTask except that the child tasks come from the
+// input groups.
+func (m *memoImplementor) implementGroupExpression(ge *memo.GroupExpression,
+	prop *property.PhysicalProperty) (base.Task, []*property.PhysicalProperty, error) {
+	lp := ge.GetWrappedLogicalPlan()
+	if len(ge.Inputs) == 0 {
+		// the leaves are implemented by themselves, e.g. the access paths of data source.
+		t, _, err := lp.FindBestTask(prop, &PlanCounterDisabled, m.opt)
+		return t, nil, err
+	}
+	p := lp.GetBaseLogicalPlan().(*logicalop.BaseLogicalPlan)
+	if prop.IndexJoinProp != nil && !admitIndexJoinInnerChildPattern(lp) {
+		return base.InvalidTask, nil, nil
+	}
+	if prop.TaskTp != property.RootTaskType && !prop.IsFlashProp() {
+		return base.InvalidTask, nil, nil
+	}
+	canAddEnforcer := prop.CanAddEnforcer
+	newProp := prop.CloneEssentialFields()
+	newProp.IndexJoinProp = prop.IndexJoinProp
+	plansFitsProp, hintWorksWithProp, err := p.Self().ExhaustPhysicalPlans(newProp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hintWorksWithProp && !newProp.IsSortItemEmpty() {
+		canAddEnforcer = true
+	}
+	var plansNeedEnforce []base.PhysicalPlan
+	if canAddEnforcer {
+		newProp.SortItems = []property.SortItem{}
+		newProp.SortItemsForPartition = []property.SortItem{}
+		newProp.ExpectedCnt = math.MaxFloat64
+		newProp.MPPPartitionCols = nil
+		newProp.MPPPartitionTp = property.AnyType
+		var hintCanWork bool
+		plansNeedEnforce, hintCanWork, err = p.Self().ExhaustPhysicalPlans(newProp)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hintCanWork && !hintWorksWithProp {
+			plansFitsProp = nil
+		}
+		if !hintCanWork && !hintWorksWithProp && !prop.CanAddEnforcer {
+			plansNeedEnforce = nil
+		}
+		newProp = prop
+	}
+	bestTask, bestChildProps, err := m.enumeratePhysicalPlans(ge, p, plansFitsProp, newProp, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	curTask, curChildProps, err := m.enumeratePhysicalPlans(ge, p, plansNeedEnforce, newProp, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if curIsBetter, err := compareTaskCost(curTask, bestTask, m.opt); err != nil {
+		return nil, nil, err
+	} else if curIsBetter {
+		bestTask, bestChildProps = curTask, curChildProps
+	}
+	return bestTask, bestChildProps, nil
+}
+
+// enumeratePhysicalPlans follows enumeratePhysicalPlans4Task except that the child tasks come from the input groups.
+func (m *memoImplementor) enumeratePhysicalPlans(ge *memo.GroupExpression, p *logicalop.BaseLogicalPlan,
+	physicalPlans []base.PhysicalPlan, prop *property.PhysicalProperty, addEnforcer bool) (base.Task, []*property.PhysicalProperty, error) {
+	bestTask, preferTask := base.InvalidTask, base.InvalidTask
+	var bestChildProps, preferChildProps []*property.PhysicalProperty
+	var fd *funcdep.FDSet
+	if addEnforcer {
+		switch logicalPlan := p.Self().(type) {
+		case *logicalop.LogicalJoin, *logicalop.LogicalAggregation:
+			fd = logicalPlan.ExtractFD()
+		}
+	}
+	childTasks := make([]base.Task, 0, len(ge.Inputs))
+	for _, pp := range physicalPlans {
+		childTasks = childTasks[:0]
+		for j, input := range ge.Inputs {
+			childProp := pp.GetChildReqProps(j)
+			if childProp == nil {
+				// the inner child of index join is built by the join itself.
+				childTasks = append(childTasks, nil)
+				continue
+			}
+			childTask, err := m.implementGroup(input, childProp)
+			if err != nil {
+				return nil, nil, err
+			}
+			if childTask.Invalid() {
+				break
+			}
+			childTasks = append(childTasks, childTask)
+		}
+		if len(childTasks) != len(ge.Inputs) {
+			continue
+		}
+		curTask := pp.Attach2Task(childTasks...)
+		if curTask.Invalid() {
+			continue
+		}
+		if _, ok := curTask.(*RootTask); !ok && prop.TaskTp == property.RootTaskType {
+			curTask = curTask.ConvertToRootTask(p.SCtx())
+		}
+		if addEnforcer {
+			curTask = enforceProperty(prop, curTask, p.SCtx(), fd)
+		}
+		if _, isMpp := curTask.(*MppTask); !isMpp && prop.IsSortItemEmpty() {
+			curTask = optimizeByShuffle(curTask, p.SCtx())
+		}
+		appendCandidate4PhysicalOptimizeOp(m.opt, p, curTask.Plan(), prop)
+		childProps := make([]*property.PhysicalProperty, 0, len(ge.Inputs))
+		for j := range ge.Inputs {
+			childProps = append(childProps, pp.GetChildReqProps(j))
+		}
+		if curIsBetter, err := compareTaskCost(curTask, bestTask, m.opt); err != nil {
+			return nil, nil, err
+		} else if curIsBetter {
+			bestTask, bestChildProps = curTask, childProps
+		}
+		if suitLogicalJoinHint(p.Self(), curTask.Plan()) {
+			if curIsBetter, err := compareTaskCost(curTask, preferTask, m.opt); err != nil {
+				return nil, nil, err
+			} else if curIsBetter {
+				preferTask, preferChildProps = curTask, childProps
+			}
+		}
+	}
+	if !preferTask.Invalid() {
+		return preferTask, preferChildProps, nil
+	}
+	return bestTask, bestChildProps, nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cascades
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/planner/cascades"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/planner/core/rule"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	"github.com/stretchr/testify/require"
+)
+
+// exploreAlternatives builds the logical plan of sql with only a few normalization rules, so that
+// the patterns are kept for the cascades rules, and returns all the logical alternatives in memo.
+func exploreAlternatives(t *testing.T, tk *testkit.TestKit, sql string, withTopN bool) []string {
+	var alternatives []string
+	exploreMemo(t, tk, sql, rule.FlagPruneColumns|rule.FlagBuildKeyInfo|rule.FlagCollectPredicateColumnsPoint, withTopN, func(oneLogic base.LogicalPlan) {
+		alternatives = append(alternatives, plannercore.ToString(oneLogic))
+	})
+	return alternatives
+}
+
+// exploreMemo builds the logical plan of sql with the normalization rules in flag, explores it
+// by cascades and calls f on each logical alternative in memo.
+func exploreMemo(t *testing.T, tk *testkit.TestKit, sql string, flag uint64, withTopN bool, f func(base.LogicalPlan)) {
+	ctx := context.Background()
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	require.NoError(t, err, sql)
+	ret := &plannercore.PreprocessorReturn{}
+	nodeW := resolve.NewNodeW(stmt)
+	err = plannercore.Preprocess(ctx, tk.Session(), nodeW, plannercore.WithPreprocessorReturn(ret))
+	require.NoError(t, err, sql)
+	builder, _ := plannercore.NewPlanBuilder().Init(tk.Session().GetPlanCtx(), ret.InfoSchema, hint.NewQBHintHandler(nil))
+	p, err := builder.Build(ctx, nodeW)
+	require.NoError(t, err, sql)
+	lp, err := plannercore.LogicalOptimizeTest(ctx, flag, p.(base.LogicalPlan))
+	require.NoError(t, err, sql)
+	if withTopN {
+		// the topN is always pushed down in normalization, build it upon the plan manually.
+		topN := logicalop.LogicalTopN{ByItems: []*util.ByItems{{Expr: lp.Schema().Columns[0]}}, Count: 2}.Init(lp.SCtx(), lp.QueryBlockOffset())
+		topN.SetChildren(lp)
+		topN.SetSchema(lp.Schema().Clone())
+		lp = topN
+	}
+	lp.ExtractFD()
+	cas, err := cascades.NewOptimizer(lp)
+	require.NoError(t, err, sql)
+	defer cas.Destroy()
+	require.NoError(t, cas.Execute(), sql)
+	cas.GetMemo().NewIterator().Each(func(oneLogic base.LogicalPlan) bool {
+		f(oneLogic)
+		return true
+	})
+}
+
+func TestXFormRulesExploration(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t2(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t3(a int primary key, b int, c int, key(b))")
+	tk.MustExec("set @@tidb_opt_agg_push_down = 1")
+
+	testCases := []struct {
+		sql      string
+		withTopN bool
+		expected string
+	}{
+		// join associativity.
+		{
+			sql:      "select * from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b",
+			expected: "Join{DataScan(t1)->Join{DataScan(t2)->DataScan(t3)}(test.t2.b,test.t3.b)}(test.t1.a,test.t2.a)->Projection",
+		},
+		// outer join to inner join, then push the selection down to the join.
+		{
+			sql:      "select * from t1 left join t2 on t1.a=t2.a where t2.c > 0",
+			expected: "Join{DataScan(t1)->DataScan(t2)->Sel([gt(test.t2.c, 0)])}(test.t1.a,test.t2.a)->Projection",
+		},
+		// push selection down through projection, then merge the adjacent projections.
+		{
+			sql:      "select * from (select a+1 x, b from t1) t where x > 1",
+			expected: "DataScan(t1)->Sel([gt(plus(test.t1.a, 1), 1)])->Projection",
+		},
+		// push aggregation down through projection.
+		{
+			sql:      "select x, count(b) from (select a+1 x, b from t1) t group by x",
+			expected: "DataScan(t1)->Aggr(count(test.t1.b),firstrow(plus(test.t1.a, 1)))->Projection",
+		},
+		// eliminate aggregation grouped by unique key.
+		{
+			sql:      "select a, sum(b) from t1 group by a",
+			expected: "DataScan(t1)->Projection",
+		},
+		// push topN down through projection.
+		{
+			sql:      "select t1.c+1, t1.b from t1",
+			withTopN: true,
+			expected: "DataScan(t1)->TopN([plus(test.t1.c, 1)],0,2)->Projection",
+		},
+		// push topN down to the outer side of outer join.
+		{
+			sql:      "select t1.c, t2.a from t1 left join t2 on t1.b=t2.b",
+			withTopN: true,
+			expected: "Join{DataScan(t1)->TopN([test.t1.c],0,2)->DataScan(t2)}(test.t1.b,test.t2.b)->TopN([test.t1.c],0,2)->Projection",
+		},
+	}
+	for _, tc := range testCases {
+		alternatives := exploreAlternatives(t, tk, tc.sql, tc.withTopN)
+		require.Contains(t, alternatives, tc.expected, tc.sql)
+	}
+
+	// push aggregation down to the join side its arguments come from, the final aggregation
+	// refers to the new columns of the partial one, so only the partial one is checked.
+	aggCases := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			sql:      "select t1.b, sum(t2.c) from t1 join t2 on t1.a=t2.b group by t1.b",
+			expected: "Join{DataScan(t1)->DataScan(t2)->Aggr(sum(test.t2.c),firstrow(test.t2.b))}(test.t1.a,test.t2.b)",
+		},
+		{
+			sql:      "select count(*), max(t2.c) from t1 join t2 on t1.b=t2.b",
+			expected: "Join{DataScan(t1)->Aggr(count(1),firstrow(test.t1.b))->DataScan(t2)}(test.t1.b,test.t2.b)",
+		},
+	}
+	for _, tc := range aggCases {
+		alternatives := exploreAlternatives(t, tk, tc.sql, false)
+		require.True(t, slices.ContainsFunc(alternatives, func(alternative string) bool {
+			return strings.Contains(alternative, tc.expected)
+		}), "%s: %v", tc.sql, alternatives)
+	}
+	// the aggregation pushed to the side grouped by its primary key is useless.
+	for _, alternative := range exploreAlternatives(t, tk, "select t1.b, sum(t2.c) from t1 join t2 on t1.b=t2.a group by t1.b", false) {
+		require.NotContains(t, alternative, "DataScan(t2)->Aggr(")
+	}
+}
+
+func TestXFormRulePruneJoinColumns(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t2(a int primary key, b int, c int, key(b))")
+
+	// the columns are not pruned in normalization, so the join outputs all the columns of both sides.
+	var joinSchemaLens []int
+	exploreMemo(t, tk, "select t1.c from t1 join t2 on t1.a=t2.a",
+		rule.FlagPredicatePushDown|rule.FlagBuildKeyInfo|rule.FlagCollectPredicateColumnsPoint, false,
+		func(oneLogic base.LogicalPlan) {
+			proj, ok := oneLogic.(*logicalop.LogicalProjection)
+			require.True(t, ok)
+			join, ok := proj.Children()[0].(*logicalop.LogicalJoin)
+			require.True(t, ok)
+			joinSchemaLens = append(joinSchemaLens, join.Schema().Len())
+		})
+	require.ElementsMatch(t, []int{6, 1}, joinSchemaLens)
+}
+
+func TestXFormRulesKeepResults(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t2(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t3(a int primary key, b int, c int, key(b))")
+	tk.MustExec("insert into t1 values(1,1,1),(2,2,2),(3,null,3),(4,4,null)")
+	tk.MustExec("insert into t2 values(1,1,1),(2,3,2),(4,4,null),(5,null,5)")
+	tk.MustExec("insert into t3 values(1,2,1),(2,3,2),(5,4,null),(6,1,6)")
+	tk.MustExec("set @@tidb_opt_agg_push_down = 1")
+
+	sqls := []string{
+		"select * from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b",
+		"select * from t1 join t2 on t1.b=t2.b join t3 on t1.c=t3.a where t2.c > 1",
+		"select * from t1, t2, t3 where t1.a=t2.a and t2.a=t3.a and t1.b+t3.b > 2",
+		"select * from t1 left join t2 on t1.a=t2.a where t2.c > 0",
+		"select * from t1 left join t2 on t1.a=t2.a where t2.c is null",
+		"select * from t1 right join t2 on t1.b=t2.b where t1.c > 1 or t2.c > 1",
+		"select * from (select a+1 x, b from t1) t where x > 2",
+		"select x, count(b) from (select a+1 x, b from t1) t group by x",
+		"select a, sum(b), count(c), max(c) from t1 group by a",
+		"select t1.b, count(*) from t1 left join t2 on t1.a=t2.a where t2.c > 0 group by t1.b",
+		"select * from t1 left join t2 on t1.b=t2.b order by t1.c, t1.a limit 2",
+		"select * from (select a*2 x, c from t1) t order by x desc limit 3",
+		"select t1.b, sum(t2.c) from t1 join t2 on t1.a=t2.b group by t1.b",
+		"select count(*), max(t2.c) from t1 join t2 on t1.b=t2.b",
+		"select t2.c, count(t1.c), min(t2.b) from t1 join t2 on t1.a=t2.a and t1.c > t2.c group by t2.c",
+		"select t3.c, sum(t1.c) from t1 join t2 on t1.b=t2.b join t3 on t2.a=t3.a group by t3.c",
+		"select t1.c from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b",
+	}
+	for _, sql := range sqls {
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+		expected := tk.MustQuery(sql).Sort().Rows()
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(true)
+		tk.MustQuery(sql).Sort().Check(expected)
+	}
+}
+
+func TestCascadesImplementation(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t2(a int primary key, b int, c int, key(b))")
+	tk.MustExec("create table t3(a int primary key, b int, c int, key(b))")
+
+	// the plans without alternatives are implemented the same as the classic planner.
+	sqls := []string{
+		"select * from t1 where a = 1",
+		"select * from t1 where b > 1",
+		"select b, count(*) from t1 group by b",
+		"select * from t1 where c > 1 order by b limit 2",
+	}
+	for _, sql := range sqls {
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+		expected := tk.MustQuery("explain format = 'brief' " + sql).Rows()
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(true)
+		tk.MustQuery("explain format = 'brief' " + sql).Check(expected)
+	}
+
+	// nth_plan costs the logical alternatives one by one, which is limited by tidb_opt_cascades_max_alternatives.
+	sql := "select * from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b"
+	tk.MustExec(sql)
+	require.Empty(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings())
+	tk.MustExec("set @@tidb_opt_cascades_max_alternatives = 1")
+	tk.MustExec(sql)
+	require.Empty(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings())
+	tk.MustExec("select /*+ nth_plan(1) */ * from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1105 Only 1 logical alternatives are costed, raise tidb_opt_cascades_max_alternatives to cost more of them"))
+	tk.MustExec("set @@tidb_opt_cascades_max_alternatives = 0")
+	tk.MustExec("select /*+ nth_plan(1) */ * from t1 join t2 on t1.a=t2.a join t3 on t2.b=t3.b")
+	require.Empty(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings())
+}
@@ -91,6 +91,9 @@ type CheckTable struct {
 	IndexInfos         []*model.IndexInfo
 	IndexLookUpReaders []*PhysicalIndexLookUpReader
 	CheckIndex         bool
+	// PartitionNames are the partitions to check, all the partitions are
+	// checked if it's empty.
+	PartitionNames []ast.CIStr
 }
 
 // RecoverIndex is used for backfilling corrupted index data.
@@ -743,6 +746,9 @@ type CompactTable struct {
 	ReplicaKind    ast.CompactReplicaKind
 	TableInfo      *model.TableInfo
 	PartitionNames []ast.CIStr
+	// CompactTiKV indicates whether the key ranges of the table are compacted
+	// in the TiKV stores too, it's set by OPTIMIZE PARTITION.
+	CompactTiKV bool
 }
 
 // DDL represents a DDL statement plan.
@@ -757,7 +763,9 @@ type SelectInto struct {
 	baseSchemaProducer
 
 	TargetPlan base.Plan
-	IntoOpt    *ast.SelectIntoOption
+	// TargetNames is the output names of the TargetPlan, because the physical plan doesn't keep them.
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
 	LineFieldsInfo
 }
 
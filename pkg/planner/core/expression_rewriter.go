@@ -2389,6 +2389,13 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 
 	var function expression.Expression
 	er.ctxStackPop(len(v.Args))
+	if er.planCtx != nil {
+		function, er.err = er.rewriteStoredFuncCall(er.planCtx, v, args)
+		if er.err != nil || function != nil {
+			er.ctxStackAppend(function, types.EmptyName)
+			return
+		}
+	}
 	if ok := expression.IsDeferredFunctions(er.sctx, v.FnName.L); er.useCache() && ok {
 		// When the expression is unix_timestamp and the number of argument is not zero,
 		// we deal with it as normal expression.
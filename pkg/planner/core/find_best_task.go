@@ -115,6 +115,28 @@ func findBestTask4LogicalTableDual(lp base.LogicalPlan, prop *property.PhysicalP
 	return rt, 1, nil
 }
 
+func findBestTask4LogicalJSONTable(lp base.LogicalPlan, prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	if prop.IndexJoinProp != nil {
+		// even enforce hint can not work with this.
+		return base.InvalidTask, 0, nil
+	}
+	p := lp.(*logicalop.LogicalJSONTable)
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return base.InvalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		JSONExpr: p.JSONExpr,
+		Root:     p.Root,
+		AsName:   p.AsName,
+	}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.Schema())
+	planCounter.Dec(1)
+	appendCandidate4PhysicalOptimizeOp(opt, p, jt, prop)
+	rt := &RootTask{}
+	rt.SetPlan(jt)
+	return rt, 1, nil
+}
+
 func findBestTask4LogicalShow(lp base.LogicalPlan, prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, _ *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
 	if prop.IndexJoinProp != nil {
 		// even enforce hint can not work with this.
@@ -828,20 +828,19 @@ func buildPartialPaths4MVIndex(
 ) {
 	evalCtx := sctx.GetExprCtx().GetEvalCtx()
 
-	var virColID = -1
+	var virColIDs []int
 	for i := range idxCols {
 		// index column may contain other virtual column.
 		if idxCols[i].VirtualExpr != nil && idxCols[i].VirtualExpr.GetType(evalCtx).IsArray() {
-			virColID = i
-			break
+			virColIDs = append(virColIDs, i)
 		}
 	}
-	if virColID == -1 { // unexpected, no vir-col on this MVIndex
+	if len(virColIDs) == 0 { // unexpected, no vir-col on this MVIndex
 		return nil, false, false, nil
 	}
-	if len(accessFilters) <= virColID {
-		// No filter related to the vir-col, cannot build a path for multi-valued index. Scanning on a multi-valued
-		// index will only produce the rows whose corresponding array is not empty.
+	if len(accessFilters) <= virColIDs[len(virColIDs)-1] {
+		// No filter related to some vir-col, cannot build a path for multi-valued index. Scanning on a multi-valued
+		// index will only produce the rows whose corresponding arrays are all not empty.
 		return nil, false, false, nil
 	}
 	// If the condition is related with the array column, all following condition assumes that the array is not empty:
@@ -851,24 +850,82 @@ func buildPartialPaths4MVIndex(
 	// Only when the condition implies that the array is not empty, it'd be safe to scan on multi-valued index without
 	// worrying whether the row with empty array will be lost in the result.
 
-	virCol := idxCols[virColID]
-	jsonType := virCol.GetType(evalCtx).ArrayType()
+	// For an index with more than one multi-valued key part, like idx(x, cast(a as array), cast(b as array)), the
+	// index entries are the cartesian product of the arrays. So `(1 member of a) and json_contains(b, '[2, 3]')`
+	// is an intersection of (a=1, b=2) and (a=1, b=3). But `json_contains(a, '[1, 2]') and json_overlaps(b, '[3, 4]')`
+	// can't be expressed by a single intersection or union, so it is not supported.
+	newAccessFilters := [][]expression.Expression{accessFilters}
+	var hasMultiValues bool
+	for _, virColID := range virColIDs {
+		virCol := idxCols[virColID]
+		virColVals, intersection, ok := extractValues4MVIndexCol(sctx, accessFilters[virColID], virCol)
+		if !ok {
+			return nil, false, false, nil
+		}
+		if len(virColVals) > 1 {
+			if hasMultiValues && intersection != isIntersection {
+				return nil, false, false, nil
+			}
+			hasMultiValues = true
+			isIntersection = intersection
+		} else if !hasMultiValues {
+			isIntersection = isIntersection || intersection
+		}
+
+		for _, v := range virColVals {
+			if !isSafeTypeConversion4MVIndexRange(v.GetType(evalCtx), virCol.GetType(evalCtx)) {
+				return nil, false, false, nil
+			}
+		}
+
+		mutations := make([][]expression.Expression, 0, len(newAccessFilters)*len(virColVals))
+		for _, filters := range newAccessFilters {
+			for _, v := range virColVals {
+				// rewrite json functions to EQ to calculate range, `(1 member of j)` -> `j=1`.
+				eq, err := expression.NewFunction(sctx.GetExprCtx(), ast.EQ, types.NewFieldType(mysql.TypeTiny), virCol, v)
+				if err != nil {
+					return nil, false, false, err
+				}
+				mutation := make([]expression.Expression, len(filters))
+				copy(mutation, filters)
+				mutation[virColID] = eq
+				mutations = append(mutations, mutation)
+			}
+		}
+		newAccessFilters = mutations
+	}
+
+	for _, filters := range newAccessFilters {
+		partialPath, ok, err := buildPartialPath4MVIndex(sctx, filters, idxCols, mvIndex, histColl)
+		if !ok || err != nil {
+			return nil, false, ok, err
+		}
+		partialPaths = append(partialPaths, partialPath)
+	}
+	return partialPaths, isIntersection, true, nil
+}
+
+// extractValues4MVIndexCol extracts values related to this vir-col from the access filter, for example, extract
+// [1, 2] from `json_contains(j, '[1, 2]')`. isIntersection indicates whether all the values should be satisfied.
+func extractValues4MVIndexCol(
+	sctx planctx.PlanContext,
+	accessFilter expression.Expression,
+	virCol *expression.Column,
+) (virColVals []expression.Expression, isIntersection bool, ok bool) {
+	jsonType := virCol.GetType(sctx.GetExprCtx().GetEvalCtx()).ArrayType()
 	targetJSONPath, ok := unwrapJSONCast(virCol.VirtualExpr)
 	if !ok {
-		return nil, false, false, nil
+		return nil, false, false
 	}
-
-	// extract values related to this vir-col, for example, extract [1, 2] from `json_contains(j, '[1, 2]')`
-	var virColVals []expression.Expression
-	sf, ok := accessFilters[virColID].(*expression.ScalarFunction)
+	sf, ok := accessFilter.(*expression.ScalarFunction)
 	if !ok {
-		return nil, false, false, nil
+		return nil, false, false
 	}
 	switch sf.FuncName.L {
 	case ast.JSONMemberOf: // (1 member of a->'$.zip')
 		v, ok := unwrapJSONCast(sf.GetArgs()[0]) // cast(1 as json) --> 1
 		if !ok {
-			return nil, false, false, nil
+			return nil, false, false
 		}
 		virColVals = append(virColVals, v)
 	case ast.JSONContains: // (json_contains(a->'$.zip', '[1, 2, 3]')
@@ -883,7 +940,7 @@ func buildPartialPaths4MVIndex(
 		if !ok || len(virColVals) == 0 {
 			// json_contains(JSON, '[]') is TRUE. If the row has an empty array, it'll not exist on multi-valued index,
 			// but the `json_contains(array, '[]')` is still true, so also don't try to scan on the index.
-			return nil, false, false, nil
+			return nil, false, false
 		}
 	case ast.JSONOverlaps: // (json_overlaps(a->'$.zip', '[1, 2, 3]')
 		var jsonPathIdx int
@@ -892,9 +949,8 @@ func buildPartialPaths4MVIndex(
 		} else if sf.GetArgs()[1].Equal(sctx.GetExprCtx().GetEvalCtx(), targetJSONPath) {
 			jsonPathIdx = 1 // (json_overlaps('[1, 2, 3]', a->'$.zip')
 		} else {
-			return nil, false, false, nil
+			return nil, false, false
 		}
-		var ok bool
 		virColVals, ok = jsonArrayExpr2Exprs(
 			sctx.GetExprCtx(),
 			ast.JSONOverlaps,
@@ -903,35 +959,12 @@ func buildPartialPaths4MVIndex(
 			true,
 		)
 		if !ok || len(virColVals) == 0 { // forbid empty array for safety
-			return nil, false, false, nil
+			return nil, false, false
 		}
 	default:
-		return nil, false, false, nil
-	}
-
-	for _, v := range virColVals {
-		if !isSafeTypeConversion4MVIndexRange(v.GetType(evalCtx), virCol.GetType(evalCtx)) {
-			return nil, false, false, nil
-		}
-	}
-
-	for _, v := range virColVals {
-		// rewrite json functions to EQ to calculate range, `(1 member of j)` -> `j=1`.
-		eq, err := expression.NewFunction(sctx.GetExprCtx(), ast.EQ, types.NewFieldType(mysql.TypeTiny), virCol, v)
-		if err != nil {
-			return nil, false, false, err
-		}
-		newAccessFilters := make([]expression.Expression, len(accessFilters))
-		copy(newAccessFilters, accessFilters)
-		newAccessFilters[virColID] = eq
-
-		partialPath, ok, err := buildPartialPath4MVIndex(sctx, newAccessFilters, idxCols, mvIndex, histColl)
-		if !ok || err != nil {
-			return nil, false, ok, err
-		}
-		partialPaths = append(partialPaths, partialPath)
+		return nil, false, false
 	}
-	return partialPaths, isIntersection, true, nil
+	return virColVals, isIntersection, true
 }
 
 // isSafeTypeConversion4MVIndexRange checks whether it is safe to convert valType to mvIndexType when building ranges for MVIndexes.
@@ -976,16 +1009,16 @@ func buildPartialPath4MVIndex(
 
 // PrepareIdxColsAndUnwrapArrayType collects columns for an index and returns them as []*expression.Column.
 // If any column of them is an array type, we will use it's underlying FieldType in the returned Column.RetType.
-// If checkOnly1ArrayTypeCol is true, we will check if this index contains only one array type column. If not, it will
+// If checkArrayTypeCol is true, we will check if this index contains at least one array type column. If not, it will
 // return (nil, false). This check works as a sanity check for an MV index.
 // Though this function is introduced for MV index, it can also be used for normal index if you pass false to
-// checkOnly1ArrayTypeCol.
+// checkArrayTypeCol.
 // This function is exported for test.
 func PrepareIdxColsAndUnwrapArrayType(
 	tableInfo *model.TableInfo,
 	idxInfo *model.IndexInfo,
 	tblCols []*expression.Column,
-	checkOnly1ArrayTypeCol bool,
+	checkArrayTypeCol bool,
 ) (idxCols []*expression.Column, ok bool) {
 	var virColNum = 0
 	for i := range idxInfo.Columns {
@@ -1010,7 +1043,7 @@ func PrepareIdxColsAndUnwrapArrayType(
 		}
 		idxCols = append(idxCols, col)
 	}
-	if checkOnly1ArrayTypeCol && virColNum != 1 { // assume only one vir-col in the MVIndex
+	if checkArrayTypeCol && virColNum == 0 { // assume at least one vir-col in the MVIndex
 		return nil, false
 	}
 	return idxCols, true
@@ -1036,8 +1069,10 @@ func collectFilters4MVIndex(
 				accessFilters = append(accessFilters, f)
 				usedAsAccess[i] = true
 				found = true
-				// access filter type on mv col overrides normal col for the return value of this function
-				if accessTp == unspecifiedFilterTp || accessTp == eqOnNonMVColTp {
+				// access filter type on mv col overrides normal col for the return value of this function,
+				// and the multi-values type on mv col overrides the single-value type on another mv col.
+				if accessTp == unspecifiedFilterTp || accessTp == eqOnNonMVColTp ||
+					(accessTp == singleValueOnMVColTp && tp != eqOnNonMVColTp) {
 					accessTp = tp
 				}
 				break
@@ -1108,6 +1143,16 @@ func CollectFilters4MVIndexMutations(sctx base.PlanContext, filters []expression
 			}
 			if ok, _ := checkAccessFilter4IdxCol(sctx, f, col); ok {
 				if col.VirtualExpr != nil && col.VirtualExpr.GetType(sctx.GetExprCtx().GetEvalCtx()).IsArray() {
+					if mvColOffset != -1 && mvColOffset != z {
+						// only the filters on the first mv json col are mutated, for the other mv json cols,
+						// just use the first found filter like the normal col.
+						if !found {
+							accessFilters = append(accessFilters, f)
+							usedAsAccess[i] = true
+							found = true
+						}
+						continue
+					}
 					// assert jsonColOffset should always be the same.
 					// if the filter is from virtual expression, it means it is about the mv json col.
 					mvFilterMutations = append(mvFilterMutations, f)
@@ -185,6 +185,63 @@ func TestMultiMVIndexRandom(t *testing.T) {
 		}
 	}
 }
+func TestMVIndexMultiKeyPartsRandom(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	for _, testCase := range []struct {
+		indexType     string
+		insertValOpts randMVIndexValOpts
+		queryValsOpts randMVIndexValOpts
+	}{
+		{"signed", randMVIndexValOpts{"signed", 0, 3}, randMVIndexValOpts{"signed", 0, 3}},
+		{"char(3)", randMVIndexValOpts{"string", 3, 3}, randMVIndexValOpts{"string", 3, 3}},
+		{"char(3) charset utf8mb4", randMVIndexValOpts{"string", 3, 3}, randMVIndexValOpts{"string", 1, 3}},
+		{"date", randMVIndexValOpts{"date", 0, 3}, randMVIndexValOpts{"date", 0, 3}},
+	} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec(fmt.Sprintf(`create table t(a int, j1 json, j2 json, index kj(a, (cast(j1 as %v array)), (cast(j2 as %v array))))`, testCase.indexType, testCase.indexType))
+		nRows := 20
+		rows := make([]string, 0, nRows)
+		for i := 0; i < nRows; i++ {
+			va, v11, v12, v21, v22 := rand.Intn(testCase.insertValOpts.distinct), randMVIndexValue(testCase.insertValOpts), randMVIndexValue(testCase.insertValOpts), randMVIndexValue(testCase.insertValOpts), randMVIndexValue(testCase.insertValOpts)
+			if testCase.indexType == "date" {
+				rows = append(rows, fmt.Sprintf(`(%v, json_array(cast(%v as date), cast(%v as date)), json_array(cast(%v as date), cast(%v as date)))`, va, v11, v12, v21, v22))
+			} else {
+				rows = append(rows, fmt.Sprintf(`(%v, '[%v, %v]', '[%v, %v]')`, va, v11, v12, v21, v22))
+			}
+		}
+		tk.MustExec(fmt.Sprintf("insert into t values %v", strings.Join(rows, ", ")))
+		randJColName := func() string {
+			if rand.Intn(2) < 1 {
+				return "j1"
+			}
+			return "j2"
+		}
+		randNColName := func() string {
+			return "a"
+		}
+		nQueries := 20
+		for i := 0; i < nQueries; i++ {
+			conds, _, _ := randMVIndexConds(rand.Intn(4)+1, testCase.queryValsOpts, randJColName, randNColName)
+			r1 := tk.MustQuery("select /*+ ignore_index(t, kj) */ * from t where " + conds).Sort()
+			tk.MustQuery("select /*+ use_index_merge(t, kj) */ * from t where " + conds).Sort().Check(r1.Rows())
+		}
+		tk.MustExec("admin check table t")
+	}
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, j1 json, j2 json, index kj(a, (cast(j1 as signed array)), (cast(j2 as signed array))))")
+	tk.MustExec("insert into t values (1, '[1, 2]', '[3, 4]'), (1, '[1]', '[]'), (2, '[]', '[3]')")
+	tk.MustUseIndex("select /*+ use_index_merge(t, kj) */ * from t where a = 1 and 1 member of (j1) and json_contains(j2, '[3, 4]')", "kj")
+	tk.MustQuery("select /*+ use_index_merge(t, kj) */ * from t where a = 1 and 1 member of (j1) and json_contains(j2, '[3, 4]')").Check(testkit.Rows("1 [1, 2] [3, 4]"))
+	// The index can't be used if there is no filter on some multi-valued key part.
+	tk.MustNoIndexUsed("select /*+ use_index_merge(t, kj) */ * from t where a = 1 and 1 member of (j1)")
+	// json_contains and json_overlaps on different multi-valued key parts can't be combined.
+	tk.MustNoIndexUsed("select /*+ use_index_merge(t, kj) */ * from t where a = 1 and json_contains(j1, '[1, 2]') and json_overlaps(j2, '[3, 4]')")
+	tk.MustQuery("select /*+ use_index_merge(t, kj) */ * from t where a = 1 and json_contains(j1, '[1, 2]') and json_overlaps(j2, '[3, 4]')").Check(testkit.Rows("1 [1, 2] [3, 4]"))
+}
+
 func TestMVIndexRandom(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -43,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/opcode"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	ptypes "github.com/pingcap/tidb/pkg/parser/types"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	core_metrics "github.com/pingcap/tidb/pkg/planner/core/metrics"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
@@ -452,6 +453,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			isTableName = true
 		default:
 			err = plannererrors.ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -467,7 +471,7 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 				name.TblName = x.AsName
 			}
 		}
-		// `TableName` is not a select block, so we do not need to handle it.
+		// `TableName` and `JSONTable` are not select blocks, so we do not need to handle them.
 		var plannerSelectBlockAsName []ast.HintTable
 		if p := b.ctx.GetSessionVars().PlannerSelectBlockAsName.Load(); p != nil {
 			plannerSelectBlockAsName = *p
@@ -568,10 +572,25 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
+	// The LATERAL derived table or the table function on the right side can reference
+	// the columns of the left side.
+	isLateral := isLateralResultSetNode(joinNode.Right)
+	if isLateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if isLateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	isLateral = isLateral && len(coreusage.ExtractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
+	if isLateral && joinNode.Tp == ast.RightJoin {
+		return nil, plannererrors.ErrTFForbiddenJoinType.GenWithStackByArgs(rightPlan.OutputNames()[0].TblName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*logicalop.LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -682,7 +701,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == logicalop.InnerJoin {
 			sel := logicalop.LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(b.buildLateralApply(joinPlan, isLateral))
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -692,7 +711,189 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.CartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return b.buildLateralApply(joinPlan, isLateral), nil
+}
+
+// isLateralResultSetNode checks whether the node can reference the columns of the
+// preceding tables in the FROM clause, it's true for the LATERAL derived tables and
+// the table functions.
+func isLateralResultSetNode(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
+}
+
+// buildLateralApply converts the join to an Apply if its inner side references the
+// columns of its outer side, so that the inner side is evaluated for each outer row.
+func (b *PlanBuilder) buildLateralApply(join *logicalop.LogicalJoin, isLateral bool) base.LogicalPlan {
+	if !isLateral {
+		return join
+	}
+	b.optFlag = b.optFlag | rule.FlagBuildKeyInfo | rule.FlagDecorrelate
+	join.CartesianJoin = false
+	setIsInApplyForCTE(join.Children()[1], join.Schema())
+	ap := &logicalop.LogicalApply{LogicalJoin: *join}
+	ap.SetTP(plancodec.TypeApply)
+	ap.SetSelf(ap)
+	return ap
+}
+
+// buildJSONTable builds the LogicalJSONTable for the JSON_TABLE() table function. The
+// JSON document is rewritten with the preceding tables of the FROM clause as the outer
+// schemas, so that it can reference their columns as correlated columns.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName ast.CIStr) (base.LogicalPlan, error) {
+	b.handleHelper.pushMap(nil)
+	dual := logicalop.LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	oldClause := b.curClause
+	b.curClause = tableFunctionArgument
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	b.curClause = oldClause
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subquery in the argument of JSON_TABLE")
+	}
+	if expr.GetType(b.ctx.GetExprCtx().GetEvalCtx()).EvalType() != types.ETJson {
+		expr = expression.BuildCastFunction(b.ctx.GetExprCtx(), expr, types.NewFieldType(mysql.TypeJSON))
+	}
+
+	p := logicalop.LogicalJSONTable{JSONExpr: expr, AsName: asName}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Root, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.SetOutputNames(names)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, asName ast.CIStr,
+	schema *expression.Schema, names *types.NameSlice) (*logicalop.JSONTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &logicalop.JSONTablePath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.Columns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			result.Nested = append(result.Nested, nested)
+			continue
+		}
+		c := &logicalop.JSONTableColumn{Name: col.Name, Tp: col.Tp}
+		var tp *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			tp = types.NewFieldType(mysql.TypeLong)
+			tp.AddFlag(mysql.UnsignedFlag)
+			tp.SetFlen(mysql.MaxIntWidth)
+			types.SetBinChsClnFlag(tp)
+		} else {
+			if c.Path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			if tp, err = b.buildJSONTableColumnType(col.FieldType); err != nil {
+				return nil, err
+			}
+			if c.OnEmpty, err = b.buildJSONTableResponse(col.OnEmpty, col.Name, tp); err != nil {
+				return nil, err
+			}
+			if c.OnError, err = b.buildJSONTableResponse(col.OnError, col.Name, tp); err != nil {
+				return nil, err
+			}
+		}
+		c.Column = &expression.Column{
+			RetType:  tp,
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			OrigName: col.Name.O,
+		}
+		schema.Append(c.Column)
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			OrigTblName: asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+		result.Columns = append(result.Columns, c)
+	}
+	return result, nil
+}
+
+// buildJSONTableColumnType fills the charset, collation and length of the column type
+// declared in JSON_TABLE, as CREATE TABLE does for a column definition.
+func (b *PlanBuilder) buildJSONTableColumnType(ft *types.FieldType) (*types.FieldType, error) {
+	tp := ft.Clone()
+	if ptypes.HasCharset(tp) {
+		switch {
+		case tp.GetCharset() == "" && tp.GetCollate() == "":
+			tp.SetCharset(charset.CharsetUTF8MB4)
+			tp.SetCollate(b.ctx.GetExprCtx().GetDefaultCollationForUTF8MB4())
+		case tp.GetCollate() == "":
+			collation, err := charset.GetDefaultCollation(tp.GetCharset())
+			if err != nil {
+				return nil, err
+			}
+			tp.SetCollate(collation)
+		case tp.GetCharset() == "":
+			coll, err := collate.GetCollationByName(tp.GetCollate())
+			if err != nil {
+				return nil, err
+			}
+			tp.SetCharset(coll.CharsetName)
+		}
+	} else if tp.GetCharset() == "" {
+		types.SetBinChsClnFlag(tp)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return tp, nil
+}
+
+// buildJSONTableResponse converts the json_string of `DEFAULT json_string ON {EMPTY | ERROR}`
+// to the column type in advance.
+func (b *PlanBuilder) buildJSONTableResponse(resp *ast.JSONTableResponse, colName ast.CIStr, tp *types.FieldType) (*logicalop.JSONTableResponse, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	result := &logicalop.JSONTableResponse{Tp: resp.Tp}
+	if resp.Tp != ast.JSONTableResponseDefault {
+		return result, nil
+	}
+	bj, err := types.ParseBinaryJSONFromString(resp.Default)
+	if err != nil {
+		return nil, types.ErrInvalidDefault.GenWithStackByArgs(colName.O)
+	}
+	if tp.GetType() == mysql.TypeJSON {
+		result.Default = types.NewJSONDatum(bj)
+		return result, nil
+	}
+	str, err := bj.Unquote()
+	if err != nil {
+		return nil, types.ErrInvalidDefault.GenWithStackByArgs(colName.O)
+	}
+	typeCtx := types.DefaultStmtNoWarningContext.WithLocation(b.ctx.GetSessionVars().Location())
+	d := types.NewStringDatum(str)
+	result.Default, err = d.ConvertTo(typeCtx, tp)
+	if err != nil {
+		return nil, types.ErrInvalidDefault.GenWithStackByArgs(colName.O)
+	}
+	return result, nil
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -5731,6 +5932,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				if isCTE(tlW) || tlW.TableInfo.IsView() || tlW.TableInfo.IsSequence() {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
+				if err := checkMViewWritable(b.ctx, tlW.TableInfo, "UPDATE"); err != nil {
+					return nil, nil, false, err
+				}
 				foundListItem = true
 			}
 		}
@@ -6030,6 +6234,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if err := checkMViewWritable(b.ctx, tableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6050,6 +6257,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tblW.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if err := checkMViewWritable(b.ctx, tblW.TableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -6088,8 +6298,14 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 		return nil, err
 	}
 
+	// The triggers and the logs of the materialized views need the whole row,
+	// so the columns can't be pruned either.
+	noPrune := len(del.FKCascades) > 0 || len(del.FKChecks) > 0
+	for _, tbl := range tblID2table {
+		noPrune = noPrune || len(tbl.Meta().Triggers) > 0 || len(tbl.Meta().MaterializedViews) > 0
+	}
 	var nonPruned *bitset.BitSet
-	del.TblColPosInfos, nonPruned, err = pruneAndBuildColPositionInfoForDelete(preProjNames, tblID2Handle, tblID2table, len(del.FKCascades) > 0 || len(del.FKChecks) > 0)
+	del.TblColPosInfos, nonPruned, err = pruneAndBuildColPositionInfoForDelete(preProjNames, tblID2Handle, tblID2table, noPrune)
 	if err != nil {
 		return nil, err
 	}
@@ -6329,7 +6545,7 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -6433,9 +6649,6 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p base.LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	checker := &expression.ParamMarkerInPrepareChecker{}
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.Name) == ast.AggFuncGroupConcat {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
-		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
 			return err
@@ -6524,6 +6737,12 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p base.LogicalPl
 		spec, funcs := window.spec, window.funcs
 		for _, windowFunc := range funcs {
 			args = append(args, windowFunc.Args...)
+			// The order by items of GROUP_CONCAT are projected together with the arguments.
+			orderByExprs, err := resolveWindowFuncOrderBy(b.ctx, windowFunc)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, orderByExprs...)
 		}
 		np, partitionBy, orderBy, args, err := b.buildProjectionForWindow(ctx, p, spec, args, aggMap)
 		if err != nil {
@@ -6571,6 +6790,16 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p base.LogicalPl
 				return nil, nil, plannererrors.ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.Name))
 			}
 			preArgs += len(windowFunc.Args)
+			if windowFunc.Order != nil {
+				desc.OrderByItems = make([]*util.ByItems, 0, len(windowFunc.Order.Items))
+				for _, byItem := range windowFunc.Order.Items {
+					desc.OrderByItems = append(desc.OrderByItems, &util.ByItems{Expr: args[preArgs], Desc: byItem.Desc})
+					preArgs++
+				}
+			}
+			desc.HasDistinct = windowFunc.Distinct
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
 			desc.WrapCastForAggArgs(b.ctx.GetExprCtx())
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -6588,19 +6817,33 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p base.LogicalPl
 	return p, windowMap, nil
 }
 
+// resolveWindowFuncOrderBy resolves the order by items of GROUP_CONCAT used as a window function,
+// the positions in the items refer to the arguments of GROUP_CONCAT.
+func resolveWindowFuncOrderBy(ctx base.PlanContext, windowFunc *ast.WindowFuncExpr) ([]ast.ExprNode, error) {
+	if windowFunc.Order == nil {
+		return nil, nil
+	}
+	resolver := &aggOrderByResolver{
+		ctx:  ctx,
+		args: windowFunc.Args[:len(windowFunc.Args)-1], // the last argument is SEPARATOR, remove it.
+	}
+	exprs := make([]ast.ExprNode, 0, len(windowFunc.Order.Items))
+	for _, byItem := range windowFunc.Order.Items {
+		resolver.exprDepth = 0
+		resolver.err = nil
+		retExpr, _ := byItem.Expr.Accept(resolver)
+		if resolver.err != nil {
+			return nil, errors.Trace(resolver.err)
+		}
+		exprs = append(exprs, retExpr.(ast.ExprNode))
+	}
+	return exprs, nil
+}
+
 // checkOriginWindowFuncs checks the validity for original window specifications for a group of functions.
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
-		}
-		if f.Distinct {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
-		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -6617,9 +6860,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return plannererrors.ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -6651,7 +6891,8 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	// The bounds of GROUPS frame are the number of peer groups, which are checked as ROWS frame.
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return plannererrors.ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
@@ -0,0 +1,488 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/table"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// checkMViewWritable returns an error if the statement modifies the result of
+// a materialized view, which is only modified by REFRESH MATERIALIZED VIEW in
+// the internal sessions.
+func checkMViewWritable(sctx base.PlanContext, tblInfo *model.TableInfo, op string) error {
+	if tblInfo.IsMaterializedView() && !sctx.GetSessionVars().InRestrictedSQL {
+		return plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tblInfo.Name.O, op)
+	}
+	return nil
+}
+
+// TryRewriteWithMaterializedView tries to rewrite an aggregate query over a
+// single table to read a materialized view with the same filter and GROUP BY
+// columns, it returns nil if the query can't be rewritten. The view is used
+// only if it's refreshed within tidb_materialized_view_max_staleness and the
+// table isn't modified in the current transaction, so the result may lag
+// behind the table by the staleness at most.
+func TryRewriteWithMaterializedView(ctx context.Context, sctx sessionctx.Context, node *resolve.NodeW, is infoschema.InfoSchema) *resolve.NodeW {
+	vars := sctx.GetSessionVars()
+	if !vars.EnableMViewRewrite || vars.InRestrictedSQL || vars.StmtCtx.IsStaleness || vars.SnapshotTS != 0 {
+		return nil
+	}
+	var sel *ast.SelectStmt
+	explain, isExplain := node.Node.(*ast.ExplainStmt)
+	if isExplain {
+		sel, _ = explain.Stmt.(*ast.SelectStmt)
+	} else {
+		sel, _ = node.Node.(*ast.SelectStmt)
+	}
+	if sel == nil {
+		return nil
+	}
+	newSel := rewriteWithMaterializedView(ctx, sctx, sel, is)
+	if newSel == nil {
+		return nil
+	}
+	var newNode ast.StmtNode = newSel
+	if isExplain {
+		newExplain := *explain
+		newExplain.Stmt = newSel
+		newNode = &newExplain
+	}
+	nodeW := resolve.NewNodeW(newNode)
+	if err := Preprocess(ctx, sctx, nodeW, WithPreprocessorReturn(&PreprocessorReturn{InfoSchema: is})); err != nil {
+		return nil
+	}
+	return nodeW
+}
+
+// mviewRewriteQuery is the query to be rewritten.
+type mviewRewriteQuery struct {
+	sel *ast.SelectStmt
+	// qualifier is the alias or the name of the table read by the query.
+	qualifier ast.CIStr
+	schema    ast.CIStr
+	tbl       table.Table
+}
+
+func rewriteWithMaterializedView(ctx context.Context, sctx sessionctx.Context, sel *ast.SelectStmt, is infoschema.InfoSchema) *ast.SelectStmt {
+	q := checkMViewRewriteQuery(ctx, sel, is)
+	if q == nil {
+		return nil
+	}
+	vars := sctx.GetSessionVars()
+	tblInfo := q.tbl.Meta()
+	if modifiedInTxn(sctx, tblInfo) {
+		return nil
+	}
+	pm := privilege.GetPrivilegeManager(sctx)
+	if pm != nil && !pm.RequestVerification(vars.ActiveRoles, q.schema.L, tblInfo.Name.L, "", mysql.SelectPriv) {
+		return nil
+	}
+	for _, id := range tblInfo.MaterializedViews {
+		mvInfo, ok := is.TableInfoByID(id)
+		if !ok || !mvInfo.IsMaterializedView() || mvInfo.MaterializedView.BaseTableID != tblInfo.ID {
+			continue
+		}
+		mv := mvInfo.MaterializedView
+		if mv.LastRefreshTS == 0 || time.Since(oracle.GetTimeFromTS(mv.LastRefreshTS)) > vars.MViewMaxStaleness {
+			continue
+		}
+		// The expressions are evaluated in the same way only if the variables
+		// are the same as the ones the view was created with.
+		if mv.SQLMode != vars.SQLMode || !sysVarEquals(vars, vardef.TimeZone, mv.TimeZone) ||
+			!sysVarEquals(vars, vardef.CollationConnection, mv.Collate) {
+			continue
+		}
+		mvSchema, ok := infoschema.SchemaByTable(is, mvInfo)
+		if !ok {
+			continue
+		}
+		if pm != nil && !pm.RequestVerification(vars.ActiveRoles, mvSchema.Name.L, mvInfo.Name.L, "", mysql.SelectPriv) {
+			continue
+		}
+		if newSel := rewriteWithMView(sctx, q, mvSchema.Name, mvInfo); newSel != nil {
+			return newSel
+		}
+	}
+	return nil
+}
+
+// checkMViewRewriteQuery checks whether the query is an aggregation over a
+// single table which may be answered by a materialized view.
+func checkMViewRewriteQuery(ctx context.Context, sel *ast.SelectStmt, is infoschema.InfoSchema) *mviewRewriteQuery {
+	if sel.Kind != ast.SelectStmtKindSelect || sel.With != nil || sel.Distinct || sel.SelectIntoOpt != nil ||
+		sel.GroupBy == nil || sel.GroupBy.Rollup || len(sel.WindowSpecs) > 0 ||
+		(sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) ||
+		sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.AsOf != nil || len(tn.PartitionNames) > 0 || len(tn.IndexHints) > 0 || tn.Schema.L == "" {
+		return nil
+	}
+	tbl, err := is.TableByName(ctx, tn.Schema, tn.Name)
+	if err != nil || len(tbl.Meta().MaterializedViews) == 0 {
+		return nil
+	}
+	q := &mviewRewriteQuery{sel: sel, qualifier: tn.Name, schema: tn.Schema, tbl: tbl}
+	if ts.AsName.L != "" {
+		q.qualifier = ts.AsName
+	}
+	return q
+}
+
+func modifiedInTxn(sctx sessionctx.Context, tblInfo *model.TableInfo) bool {
+	deltas := sctx.GetSessionVars().TxnCtx.TableDeltaMap
+	if _, ok := deltas[tblInfo.ID]; ok {
+		return true
+	}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			if _, ok := deltas[def.ID]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sysVarEquals(vars *variable.SessionVars, name, val string) bool {
+	cur, _ := vars.GetSystemVar(name)
+	return strings.EqualFold(cur, val)
+}
+
+// rewriteWithMView rewrites the query to read the materialized view, it
+// returns nil if the query can't be answered by the view. The query is
+// rewritten on a copy, so the original statement is kept if it fails.
+func rewriteWithMView(sctx sessionctx.Context, q *mviewRewriteQuery, mvSchema ast.CIStr, mvInfo *model.TableInfo) *ast.SelectStmt {
+	mv := mvInfo.MaterializedView
+	p := parser.New()
+	p.SetSQLMode(mv.SQLMode)
+	stmt, err := p.ParseOneStmt(mv.Definition, "", "")
+	if err != nil {
+		return nil
+	}
+	def, ok := stmt.(*ast.SelectStmt)
+	if !ok || len(def.Fields.Fields) != len(mvInfo.Columns) {
+		return nil
+	}
+	defQualifier, ok := mviewDefinitionQualifier(def)
+	if !ok {
+		return nil
+	}
+
+	// Copy the query, the copy is parsed with the mode of the view which is
+	// the same as the session.
+	var sb strings.Builder
+	if err = q.sel.Restore(format.NewRestoreCtx(mviewRestoreFlags, &sb)); err != nil {
+		return nil
+	}
+	stmt, err = p.ParseOneStmt(sb.String(), "", "")
+	if err != nil {
+		return nil
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || len(sel.Fields.Fields) != len(q.sel.Fields.Fields) {
+		return nil
+	}
+
+	// The filters and the GROUP BY columns must be the same.
+	if !mviewExprEquals(sel.Where, q.qualifier, def.Where, defQualifier) {
+		return nil
+	}
+	groupBy := make(map[string]struct{}, len(sel.GroupBy.Items))
+	for _, item := range sel.GroupBy.Items {
+		key, ok := normalizeMViewExpr(item.Expr, q.qualifier)
+		if !ok {
+			return nil
+		}
+		groupBy[key] = struct{}{}
+	}
+	if len(groupBy) != len(mv.GroupBy) {
+		return nil
+	}
+	for _, gb := range mv.GroupBy {
+		key, _ := normalizeMViewExpr(&ast.ColumnNameExpr{Name: &ast.ColumnName{Name: gb.BaseColumn}}, ast.CIStr{})
+		if _, ok := groupBy[key]; !ok {
+			return nil
+		}
+	}
+
+	// Map the expressions of the view to the columns of the view.
+	mvCols := make(map[string]*ast.ColumnNameExpr, len(def.Fields.Fields))
+	for i, field := range def.Fields.Fields {
+		key, ok := normalizeMViewExpr(field.Expr, defQualifier)
+		if !ok {
+			return nil
+		}
+		mvCols[key] = &ast.ColumnNameExpr{Name: &ast.ColumnName{Schema: mvSchema, Table: mvInfo.Name, Name: mvInfo.Columns[i].Name}}
+	}
+	replacer := &mviewExprReplacer{qualifier: q.qualifier, mvCols: mvCols}
+	aliases := make(map[string]ast.ExprNode, len(sel.Fields.Fields))
+	for i, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			return nil
+		}
+		origin := q.sel.Fields.Fields[i]
+		field.AsName = origin.AsName
+		if field.AsName.L == "" {
+			// Keep the names of the output columns.
+			switch x := getInnerFromParenthesesAndUnaryPlus(origin.Expr).(type) {
+			case *ast.ColumnNameExpr:
+				col := table.FindCol(q.tbl.Cols(), x.Name.Name.L)
+				if col == nil {
+					return nil
+				}
+				field.AsName = col.Name
+			case *driver.ValueExpr:
+			default:
+				field.AsName = ast.NewCIStr(parser.SpecFieldPattern.ReplaceAllStringFunc(origin.Text(), parser.TrimComment))
+			}
+		}
+		node, _ := field.Expr.Accept(replacer)
+		field.Expr = node.(ast.ExprNode)
+		if field.AsName.L != "" {
+			aliases[field.AsName.L] = field.Expr
+		}
+	}
+	checker := &mviewRewriteChecker{mvSchema: mvSchema, mvName: mvInfo.Name}
+	for _, field := range sel.Fields.Fields {
+		if field.Expr.Accept(checker); checker.failed {
+			return nil
+		}
+	}
+	// HAVING is converted to WHERE on the view, the aliases in it are
+	// replaced by the expressions of the fields.
+	var where ast.ExprNode
+	if sel.Having != nil {
+		node, _ := sel.Having.Expr.Accept(replacer)
+		node, _ = node.Accept(&mviewAliasReplacer{aliases: aliases})
+		where = node.(ast.ExprNode)
+		if where.Accept(checker); checker.failed {
+			return nil
+		}
+	}
+	if sel.OrderBy != nil {
+		checker.aliases = aliases
+		for _, item := range sel.OrderBy.Items {
+			node, _ := item.Expr.Accept(replacer)
+			item.Expr = node.(ast.ExprNode)
+			if item.Expr.Accept(checker); checker.failed {
+				return nil
+			}
+		}
+	}
+
+	sel.TableHints = nil
+	sel.From = &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+		Source: &ast.TableName{Schema: mvSchema, Name: mvInfo.Name},
+	}}}
+	sel.Where = where
+	sel.GroupBy = nil
+	sel.Having = nil
+	sctx.GetSessionVars().StmtCtx.SetSkipPlanCache("the query is rewritten to read a materialized view")
+	return sel
+}
+
+const mviewRestoreFlags = format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase |
+	format.RestoreNameBackQuotes | format.RestoreNameLowercase
+
+// mviewDefinitionQualifier returns the qualifier of the table read by the
+// definition of a materialized view.
+func mviewDefinitionQualifier(def *ast.SelectStmt) (ast.CIStr, bool) {
+	if def.From == nil || def.From.TableRefs == nil || def.From.TableRefs.Right != nil {
+		return ast.CIStr{}, false
+	}
+	ts, ok := def.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return ast.CIStr{}, false
+	}
+	if ts.AsName.L != "" {
+		return ts.AsName, true
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		return ast.CIStr{}, false
+	}
+	return tn.Name, true
+}
+
+// normalizeMViewExpr restores the expression over the table with the
+// qualifier, the qualifiers of the columns are removed so the expressions of
+// the query and the view can be compared. It returns false if the expression
+// refers to the other tables.
+func normalizeMViewExpr(expr ast.ExprNode, qualifier ast.CIStr) (string, bool) {
+	if expr == nil {
+		return "", true
+	}
+	stripper := &mviewQualifierStripper{qualifier: qualifier}
+	expr.Accept(stripper)
+	defer stripper.restore()
+	if stripper.failed {
+		return "", false
+	}
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(mviewRestoreFlags, &sb)); err != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+func mviewExprEquals(a ast.ExprNode, aQualifier ast.CIStr, b ast.ExprNode, bQualifier ast.CIStr) bool {
+	aKey, ok := normalizeMViewExpr(a, aQualifier)
+	if !ok {
+		return false
+	}
+	bKey, ok := normalizeMViewExpr(b, bQualifier)
+	return ok && aKey == bKey
+}
+
+// mviewQualifierStripper removes the qualifiers of the columns temporarily.
+type mviewQualifierStripper struct {
+	qualifier ast.CIStr
+	stripped  []*ast.ColumnName
+	origins   []ast.ColumnName
+	failed    bool
+}
+
+func (s *mviewQualifierStripper) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.WindowFuncExpr, *ast.VariableExpr, *ast.DefaultExpr:
+		s.failed = true
+		return in, true
+	case *ast.ColumnNameExpr:
+		if x.Name.Table.L != "" {
+			if x.Name.Table.L != s.qualifier.L {
+				s.failed = true
+				return in, true
+			}
+			s.stripped = append(s.stripped, x.Name)
+			s.origins = append(s.origins, *x.Name)
+			x.Name.Schema, x.Name.Table = ast.CIStr{}, ast.CIStr{}
+		}
+	}
+	return in, false
+}
+
+func (*mviewQualifierStripper) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (s *mviewQualifierStripper) restore() {
+	for i, name := range s.stripped {
+		*name = s.origins[i]
+	}
+}
+
+// mviewExprReplacer replaces the expressions computed by the view with the
+// columns of the view.
+type mviewExprReplacer struct {
+	qualifier ast.CIStr
+	mvCols    map[string]*ast.ColumnNameExpr
+}
+
+func (r *mviewExprReplacer) Enter(in ast.Node) (ast.Node, bool) {
+	expr, ok := in.(ast.ExprNode)
+	if !ok {
+		return in, false
+	}
+	if _, ok := expr.(*driver.ValueExpr); ok {
+		return in, true
+	}
+	key, ok := normalizeMViewExpr(expr, r.qualifier)
+	if !ok {
+		return in, false
+	}
+	if col, ok := r.mvCols[key]; ok {
+		return &ast.ColumnNameExpr{Name: &ast.ColumnName{Schema: col.Name.Schema, Table: col.Name.Table, Name: col.Name.Name}}, true
+	}
+	return in, false
+}
+
+func (*mviewExprReplacer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// mviewAliasReplacer replaces the references to the aliases of the fields.
+type mviewAliasReplacer struct {
+	aliases map[string]ast.ExprNode
+}
+
+func (r *mviewAliasReplacer) Enter(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.ColumnNameExpr); ok && x.Name.Table.L == "" {
+		if expr, ok := r.aliases[x.Name.Name.L]; ok {
+			return expr, true
+		}
+	}
+	return in, false
+}
+
+func (*mviewAliasReplacer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// mviewRewriteChecker checks that the rewritten expression only refers to the
+// columns of the view, or the aliases of the fields in ORDER BY.
+type mviewRewriteChecker struct {
+	mvSchema ast.CIStr
+	mvName   ast.CIStr
+	aliases  map[string]ast.ExprNode
+	failed   bool
+}
+
+func (c *mviewRewriteChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.AggregateFuncExpr, *ast.WindowFuncExpr, *ast.SubqueryExpr, *ast.ExistsSubqueryExpr,
+		*ast.VariableExpr, *ast.DefaultExpr, *ast.ValuesExpr:
+		c.failed = true
+		return in, true
+	case *ast.ColumnNameExpr:
+		if x.Name.Table.L == c.mvName.L && x.Name.Schema.L == c.mvSchema.L {
+			return in, true
+		}
+		if _, ok := c.aliases[x.Name.Name.L]; ok && x.Name.Table.L == "" {
+			return in, true
+		}
+		c.failed = true
+		return in, true
+	}
+	return in, false
+}
+
+func (*mviewRewriteChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
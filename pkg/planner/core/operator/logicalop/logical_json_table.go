@@ -0,0 +1,179 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logicalop
+
+import (
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace/logicaltrace"
+	"github.com/pingcap/tidb/pkg/planner/util/utilfuncp"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+// JSONTableRowCountEstimation is the estimated row count of a JSON_TABLE, since
+// we know nothing about the JSON document before evaluating it.
+const JSONTableRowCountEstimation = 10
+
+// JSONTableResponse is the ON EMPTY / ON ERROR behavior of a JSON_TABLE column.
+type JSONTableResponse struct {
+	Tp ast.JSONTableResponseType
+	// Default is the value of `DEFAULT json_string`, already converted to the column type.
+	Default types.Datum
+}
+
+// JSONTableColumn is an output column of JSON_TABLE.
+type JSONTableColumn struct {
+	Name    ast.CIStr
+	Tp      ast.JSONTableColumnType
+	Path    types.JSONPathExpression
+	OnEmpty *JSONTableResponse
+	OnError *JSONTableResponse
+	// Column is the column in the schema of JSON_TABLE, it may be pruned from the schema.
+	Column *expression.Column
+}
+
+// JSONTablePath is a row path of JSON_TABLE together with the columns evaluated against
+// each value it matches. The NESTED PATH clauses of the path are stored in Nested.
+type JSONTablePath struct {
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// LogicalJSONTable represents the JSON_TABLE() table function.
+type LogicalJSONTable struct {
+	LogicalSchemaProducer
+
+	// JSONExpr is the JSON document, it usually references columns of the outer plan
+	// and is evaluated once for each outer row by Apply.
+	JSONExpr expression.Expression
+	Root     *JSONTablePath
+	// AsName is the alias of JSON_TABLE.
+	AsName ast.CIStr
+}
+
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx base.PlanContext, offset int) *LogicalJSONTable {
+	p.BaseLogicalPlan = NewBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// *************************** start implementation of Plan interface ***************************
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return p.JSONExpr.StringWithCtx(p.SCtx().GetExprCtx().GetEvalCtx(), errors.RedactLogDisable)
+}
+
+// *************************** end implementation of Plan interface ***************************
+
+// *************************** start implementation of logicalPlan interface ***************************
+
+// HashCode inherits BaseLogicalPlan.LogicalPlan.<0th> implementation.
+
+// PredicatePushDown inherits BaseLogicalPlan.LogicalPlan.<1st> implementation.
+
+// PruneColumns implements base.LogicalPlan.<2nd> interface.
+func (p *LogicalJSONTable) PruneColumns(parentUsedCols []*expression.Column, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, error) {
+	used := expression.GetUsedList(p.SCtx().GetExprCtx().GetEvalCtx(), parentUsedCols, p.Schema())
+	prunedColumns := make([]*expression.Column, 0)
+	for i := len(used) - 1; i >= 0; i-- {
+		// Keep at least one column so that the number of rows is still visible to the parent.
+		if !used[i] && p.Schema().Len() > 1 {
+			prunedColumns = append(prunedColumns, p.Schema().Columns[i])
+			p.Schema().Columns = slices.Delete(p.Schema().Columns, i, i+1)
+			p.SetOutputNames(slices.Delete(p.OutputNames(), i, i+1))
+		}
+	}
+	logicaltrace.AppendColumnPruneTraceStep(p, prunedColumns, opt)
+	return p, nil
+}
+
+// FindBestTask implements the base.LogicalPlan.<3rd> interface.
+func (p *LogicalJSONTable) FindBestTask(prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	return utilfuncp.FindBestTask4LogicalJSONTable(p, prop, planCounter, opt)
+}
+
+// BuildKeyInfo inherits BaseLogicalPlan.LogicalPlan.<4th> implementation.
+
+// PushDownTopN inherits BaseLogicalPlan.LogicalPlan.<5th> implementation.
+
+// DeriveTopN inherits BaseLogicalPlan.LogicalPlan.<6th> implementation.
+
+// PredicateSimplification inherits BaseLogicalPlan.LogicalPlan.<7th> implementation.
+
+// ConstantPropagation inherits BaseLogicalPlan.LogicalPlan.<8th> implementation.
+
+// PullUpConstantPredicates inherits BaseLogicalPlan.LogicalPlan.<9th> implementation.
+
+// RecursiveDeriveStats inherits BaseLogicalPlan.LogicalPlan.<10th> implementation.
+
+// DeriveStats implement base.LogicalPlan.<11th> interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, reloads []bool) (*property.StatsInfo, bool, error) {
+	var reload bool
+	if len(reloads) == 1 {
+		reload = reloads[0]
+	}
+	if !reload && p.StatsInfo() != nil {
+		return p.StatsInfo(), false, nil
+	}
+	profile := &property.StatsInfo{
+		RowCount: JSONTableRowCountEstimation,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		profile.ColNDVs[col.UniqueID] = JSONTableRowCountEstimation
+	}
+	p.SetStats(profile)
+	return p.StatsInfo(), true, nil
+}
+
+// ExtractColGroups inherits BaseLogicalPlan.LogicalPlan.<12th> implementation.
+
+// PreparePossibleProperties inherits BaseLogicalPlan.LogicalPlan.<13th> implementation.
+
+// ExhaustPhysicalPlans inherits BaseLogicalPlan.LogicalPlan.<14th> implementation.
+
+// ExtractCorrelatedCols implements base.LogicalPlan.<15th> interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
+// MaxOneRow inherits BaseLogicalPlan.LogicalPlan.<16th> implementation.
+
+// Children inherits BaseLogicalPlan.LogicalPlan.<17th> implementation.
+
+// SetChildren inherits BaseLogicalPlan.LogicalPlan.<18th> implementation.
+
+// SetChild inherits BaseLogicalPlan.LogicalPlan.<19th> implementation.
+
+// RollBackTaskMap inherits BaseLogicalPlan.LogicalPlan.<20th> implementation.
+
+// CanPushToCop inherits BaseLogicalPlan.LogicalPlan.<21st> implementation.
+
+// ExtractFD inherits BaseLogicalPlan.LogicalPlan.<22nd> implementation.
+
+// GetBaseLogicalPlan inherits BaseLogicalPlan.LogicalPlan.<23rd> implementation.
+
+// ConvertOuterToInnerJoin inherits BaseLogicalPlan.LogicalPlan.<24th> implementation.
+
+// *************************** end implementation of logicalPlan interface ***************************
@@ -38,6 +38,7 @@ var (
 	_ base.LogicalPlan = &LogicalApply{}
 	_ base.LogicalPlan = &LogicalMaxOneRow{}
 	_ base.LogicalPlan = &LogicalTableDual{}
+	_ base.LogicalPlan = &LogicalJSONTable{}
 	_ base.LogicalPlan = &DataSource{}
 	_ base.LogicalPlan = &TiKVSingleGather{}
 	_ base.LogicalPlan = &LogicalTableScan{}
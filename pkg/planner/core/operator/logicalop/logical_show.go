@@ -42,6 +42,7 @@ type ShowContents struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            string
 	Table             *resolve.TableNameW // Used for showing columns.
+	Procedure         *ast.TableName      // Used for showing create procedure or function.
 	Partition         ast.CIStr           // Use for showing partition
 	Column            *ast.ColumnName     // Used for `desc table column`.
 	IndexName         ast.CIStr
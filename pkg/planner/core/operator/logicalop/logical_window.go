@@ -290,6 +290,9 @@ func (p *LogicalWindow) ReplaceExprColumns(replace map[string]*expression.Column
 		for _, arg := range desc.Args {
 			ruleutil.ResolveExprAndReplace(arg, replace)
 		}
+		for _, item := range desc.OrderByItems {
+			ruleutil.ResolveExprAndReplace(item.Expr, replace)
+		}
 	}
 	for _, item := range p.PartitionBy {
 		ruleutil.ResolveColumnAndReplace(item.Col, replace)
@@ -438,6 +441,9 @@ func (p *LogicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, item := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(item.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
@@ -579,6 +585,9 @@ func (p *LogicalWindow) extractUsedCols(parentUsedCols []*expression.Column) []*
 		for _, arg := range desc.Args {
 			parentUsedCols = append(parentUsedCols, expression.ExtractColumns(arg)...)
 		}
+		for _, item := range desc.OrderByItems {
+			parentUsedCols = append(parentUsedCols, expression.ExtractColumns(item.Expr)...)
+		}
 	}
 	for _, by := range p.PartitionBy {
 		parentUsedCols = append(parentUsedCols, by.Col)
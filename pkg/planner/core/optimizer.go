@@ -38,6 +38,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/cascades"
+	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/physicalop"
@@ -305,11 +306,46 @@ func CascadesOptimize(ctx context.Context, sctx base.PlanContext, flag uint64, l
 	}
 	var (
 		physical base.PhysicalPlan
-		cost     = math.MaxFloat64
+		cost     float64
 	)
-	// At current phase, cascades just iterate every logic plan out for feeding physicalOptimize.
-	// TODO: In the near future, physicalOptimize will be refactored as receiving *Group as param directly.
-	cas.GetMemo().NewIterator().Each(func(oneLogic base.LogicalPlan) bool {
+	if sessVars.StmtCtx.StmtHints.ForceNthPlan > 0 {
+		// nth_plan counts the physical plans of one logical plan, so the logical alternatives are costed one by one.
+		err = errMemoNotImplementable
+	} else {
+		physical, cost, err = implementMemo(cas.GetMemo())
+	}
+	if err == errMemoNotImplementable {
+		physical, cost, err = physicalOptimizeAlternatives(sctx, cas.GetMemo())
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	finalPlan := postOptimize(ctx, sctx, physical)
+	if sessVars.StmtCtx.EnableOptimizerCETrace {
+		refineCETrace(sctx)
+	}
+	if sessVars.StmtCtx.EnableOptimizeTrace {
+		sessVars.StmtCtx.OptimizeTracer.RecordFinalPlan(finalPlan.BuildPlanTrace())
+	}
+	return logic, finalPlan, cost, nil
+}
+
+// physicalOptimizeAlternatives feeds the logical alternatives in memo to physicalOptimize one by one, and returns
+// the cheapest physical plan. At most tidb_opt_cascades_max_alternatives alternatives are costed, the first of
+// which is always the normalized plan itself.
+func physicalOptimizeAlternatives(sctx base.PlanContext, mm *memo.Memo) (physical base.PhysicalPlan, cost float64, err error) {
+	sessVars := sctx.GetSessionVars()
+	cost = math.MaxFloat64
+	alternatives := 0
+	mm.NewIterator().Each(func(oneLogic base.LogicalPlan) bool {
+		if sessVars.CascadesMaxAlternatives > 0 && alternatives >= sessVars.CascadesMaxAlternatives {
+			sessVars.StmtCtx.AppendWarning(errors.NewNoStackErrorf(
+				"Only %d logical alternatives are costed, raise %s to cost more of them",
+				alternatives, vardef.TiDBOptCascadesMaxAlternatives))
+			return false
+		}
+		alternatives++
 		planCounter := base.PlanCounterTp(sessVars.StmtCtx.StmtHints.ForceNthPlan)
 		if planCounter == 0 {
 			planCounter = -1
@@ -325,18 +361,7 @@ func CascadesOptimize(ctx context.Context, sctx base.PlanContext, flag uint64, l
 		}
 		return true
 	})
-	if err != nil {
-		return nil, nil, 0, err
-	}
-
-	finalPlan := postOptimize(ctx, sctx, physical)
-	if sessVars.StmtCtx.EnableOptimizerCETrace {
-		refineCETrace(sctx)
-	}
-	if sessVars.StmtCtx.EnableOptimizeTrace {
-		sessVars.StmtCtx.OptimizeTracer.RecordFinalPlan(finalPlan.BuildPlanTrace())
-	}
-	return logic, finalPlan, cost, nil
+	return physical, cost, err
 }
 
 // VolcanoOptimize includes: logicalOptimize, physicalOptimize
@@ -62,6 +62,7 @@ var (
 	_ base.PhysicalPlan = &PhysicalTopN{}
 	_ base.PhysicalPlan = &PhysicalMaxOneRow{}
 	_ base.PhysicalPlan = &PhysicalTableDual{}
+	_ base.PhysicalPlan = &PhysicalJSONTable{}
 	_ base.PhysicalPlan = &PhysicalUnionAll{}
 	_ base.PhysicalPlan = &PhysicalSort{}
 	_ base.PhysicalPlan = &NominalSort{}
@@ -2534,6 +2535,33 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of the JSON_TABLE() table function.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	JSONExpr expression.Expression
+	Root     *logicalop.JSONTablePath
+	AsName   ast.CIStr
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInterface + size.SizeOfPointer
+	if p.JSONExpr != nil {
+		sum += p.JSONExpr.MemoryUsage()
+	}
+	return
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
@@ -2554,6 +2582,9 @@ func (p *PhysicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, item := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(item.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
@@ -577,6 +577,8 @@ func isPhysicalPlanCacheable(sctx base.PlanContext, p base.PhysicalPlan, paramNu
 		return false, "get a Shuffle plan"
 	case *PhysicalMemTable:
 		return false, "PhysicalMemTable plan is un-cacheable"
+	case *PhysicalJSONTable:
+		return false, "PhysicalJSONTable plan is un-cacheable"
 	case *PhysicalIndexMergeReader:
 		if x.AccessMVIndex && !enablePlanCacheForGeneratedCols(sctx) {
 			return false, "the plan with IndexMerge accessing Multi-Valued Index is un-cacheable"
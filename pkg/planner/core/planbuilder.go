@@ -117,21 +117,23 @@ const (
 	expressionClause
 	windowOrderByClause
 	partitionByClause
+	tableFunctionArgument
 )
 
 var clauseMsg = map[clauseCode]string{
-	unknowClause:        "",
-	fieldList:           "field list",
-	havingClause:        "having clause",
-	onClause:            "on clause",
-	orderByClause:       "order clause",
-	whereClause:         "where clause",
-	groupByClause:       "group statement",
-	showStatement:       "show statement",
-	globalOrderByClause: "global ORDER clause",
-	expressionClause:    "expression",
-	windowOrderByClause: "window order by",
-	partitionByClause:   "window partition by",
+	unknowClause:          "",
+	fieldList:             "field list",
+	havingClause:          "having clause",
+	onClause:              "on clause",
+	orderByClause:         "order clause",
+	whereClause:           "where clause",
+	groupByClause:         "group statement",
+	showStatement:         "show statement",
+	globalOrderByClause:   "global ORDER clause",
+	expressionClause:      "expression",
+	windowOrderByClause:   "window order by",
+	partitionByClause:     "window partition by",
+	tableFunctionArgument: "a table function argument",
 }
 
 type capFlagType = uint64
@@ -296,6 +298,9 @@ type PlanBuilder struct {
 
 	// allowBuildCastArray indicates whether allow cast(... as ... array).
 	allowBuildCastArray bool
+	// storedFuncStack holds the stored functions being compiled, it's used to
+	// detect the recursive stored functions.
+	storedFuncStack []string
 	// resolveCtx is set when calling Build, it's only effective in the current Build call.
 	resolveCtx *resolve.Context
 }
@@ -559,7 +564,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node *resolve.NodeW) (base.Plan
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt, *ast.DropProcedureStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
 		return b.buildSimple(ctx, node.Node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1769,7 +1774,7 @@ func tryGetPkHandleCol(tblInfo *model.TableInfo, allColSchema *expression.Schema
 	return nil, nil, false
 }
 
-func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName ast.CIStr, tbl table.Table, indices []table.Index) ([]base.Plan, []*model.IndexInfo, error) {
+func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName ast.CIStr, tbl table.Table, indices []table.Index, partitionNames []ast.CIStr) ([]base.Plan, []*model.IndexInfo, error) {
 	tblInfo := tbl.Meta()
 	// get index information
 	indexInfos := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
@@ -1817,6 +1822,11 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 		// For partition tables except global index.
 		if pi := tbl.Meta().GetPartitionInfo(); pi != nil && !idxInfo.Global {
 			for _, def := range pi.Definitions {
+				if len(partitionNames) > 0 && !slices.ContainsFunc(partitionNames, func(name ast.CIStr) bool {
+					return name.L == def.Name.L
+				}) {
+					continue
+				}
 				t := tbl.(table.PartitionedTable).GetPartition(def.ID)
 				reader, err := b.buildPhysicalIndexLookUpReader(ctx, dbName, t, idxInfo)
 				if err != nil {
@@ -1871,9 +1881,9 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 			return nil, errors.Errorf("index %s state %s isn't public", as.Index, idx.Meta().State)
 		}
 		p.CheckIndex = true
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx})
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx}, nil)
 	} else {
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices())
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices(), nil)
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1887,6 +1897,74 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 	return p, nil
 }
 
+// buildCheckPartitions builds a plan for the "ALTER TABLE ... CHECK PARTITION"
+// statement, it checks the records and the local indexes of the partitions
+// like ADMIN CHECK TABLE. The global indexes are not checked since they cover
+// all the partitions.
+func (b *PlanBuilder) buildCheckPartitions(ctx context.Context, tn *ast.TableName, spec *ast.AlterTableSpec) (base.Plan, error) {
+	tnW := b.resolveCtx.GetTableName(tn)
+	tbl, ok := b.is.TableByID(ctx, tnW.TableInfo.ID)
+	if !ok {
+		return nil, infoschema.ErrTableNotExists.FastGenByArgs(tnW.DBInfo.Name.O, tnW.TableInfo.Name.O)
+	}
+	pi := tbl.Meta().GetPartitionInfo()
+	if pi == nil {
+		return nil, dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	partitionNames := spec.PartitionNames
+	if spec.OnAllPartitions {
+		partitionNames = make([]ast.CIStr, 0, len(pi.Definitions))
+		for _, def := range pi.Definitions {
+			partitionNames = append(partitionNames, def.Name)
+		}
+	}
+	for _, name := range partitionNames {
+		if pi.FindPartitionDefinitionByName(name.L) == -1 {
+			return nil, table.ErrUnknownPartition.GenWithStackByArgs(name.O, tbl.Meta().Name.O)
+		}
+	}
+	indices := make([]table.Index, 0, len(tbl.Indices()))
+	for _, idx := range tbl.Indices() {
+		if !idx.Meta().Global {
+			indices = append(indices, idx)
+		}
+	}
+	readerPlans, indexInfos, err := b.buildPhysicalIndexLookUpReaders(ctx, tnW.DBInfo.Name, tbl, indices, partitionNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	readers := make([]*PhysicalIndexLookUpReader, 0, len(readerPlans))
+	for _, plan := range readerPlans {
+		readers = append(readers, plan.(*PhysicalIndexLookUpReader))
+	}
+	return &CheckTable{
+		DBName:             tnW.DBInfo.Name.O,
+		Table:              tbl,
+		IndexInfos:         indexInfos,
+		IndexLookUpReaders: readers,
+		PartitionNames:     partitionNames,
+	}, nil
+}
+
+// buildOptimizePartitions builds a plan for the "ALTER TABLE ... OPTIMIZE
+// PARTITION" statement, the key ranges of the partitions are compacted in both
+// the TiKV and TiFlash stores.
+func (b *PlanBuilder) buildOptimizePartitions(tn *ast.TableName, spec *ast.AlterTableSpec) (base.Plan, error) {
+	tblInfo := b.resolveCtx.GetTableName(tn).TableInfo
+	if tblInfo.GetPartitionInfo() == nil {
+		return nil, dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	p := &CompactTable{
+		ReplicaKind: ast.CompactReplicaKindAll,
+		TableInfo:   tblInfo,
+		CompactTiKV: true,
+	}
+	if !spec.OnAllPartitions {
+		p.PartitionNames = spec.PartitionNames
+	}
+	return p, nil
+}
+
 func (b *PlanBuilder) buildCheckIndexSchema(tn *ast.TableName, indexName string) (*expression.Schema, types.NameSlice, error) {
 	schema := expression.NewSchema()
 	var names types.NameSlice
@@ -3454,6 +3532,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			CountWarningsOrErrors: show.CountWarningsOrErrors,
 			DBName:                show.DBName,
 			Table:                 tnW,
+			Procedure:             show.Procedure,
 			Partition:             show.Partition,
 			Column:                show.Column,
 			IndexName:             show.IndexName,
@@ -3583,8 +3662,17 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		switch show.Tp {
+		case ast.ShowProcedureStatus, ast.ShowFunctionStatus, ast.ShowEvents:
+			// The pattern matches the name of the routine or event rather than the database.
+			patternCol = p.OutputNames()[1].ColName
+		case ast.ShowTriggers:
+			// The pattern matches the name of the table rather than the trigger.
+			patternCol = p.OutputNames()[2].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3752,13 +3840,6 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN or RESOURCE_GROUP_USER")
 			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN", "RESOURCE_GROUP_USER"}, false, err)
 		}
-	case *ast.DropProcedureStmt:
-		procName := fmt.Sprintf("%s.%s", ast.NewCIStr(b.ctx.GetSessionVars().CurrentDB).O, raw.ProcedureName.Name.O)
-		err := plannererrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", procName)
-		if !raw.IfExists {
-			return nil, err
-		}
-		b.ctx.GetSessionVars().StmtCtx.AppendNote(err)
 	}
 	return p, nil
 }
@@ -3776,6 +3857,11 @@ func collectVisitInfoFromRevokeStmt(ctx context.Context, sctx base.PlanContext,
 		}
 		dbName = sctx.GetSessionVars().CurrentDB
 	}
+	// The privileges on a stored routine are checked at the database level.
+	isRoutine := stmt.ObjectType == ast.ObjectTypeProcedure || stmt.ObjectType == ast.ObjectTypeFunction
+	if isRoutine {
+		tableName = ""
+	}
 	var nonDynamicPrivilege bool
 	var allPrivs []mysql.PrivilegeType
 	for _, item := range stmt.Privs {
@@ -3792,6 +3878,9 @@ func collectVisitInfoFromRevokeStmt(ctx context.Context, sctx base.PlanContext,
 				allPrivs = mysql.AllDBPrivs
 			case ast.GrantLevelTable:
 				allPrivs = mysql.AllTablePrivs
+				if isRoutine {
+					allPrivs = mysql.AllRoutinePrivs
+				}
 			}
 			break
 		}
@@ -3840,6 +3929,11 @@ func collectVisitInfoFromGrantStmt(sctx base.PlanContext, vi []visitInfo, stmt *
 		}
 		dbName = sctx.GetSessionVars().CurrentDB
 	}
+	// The privileges on a stored routine are checked at the database level.
+	isRoutine := stmt.ObjectType == ast.ObjectTypeProcedure || stmt.ObjectType == ast.ObjectTypeFunction
+	if isRoutine {
+		tableName = ""
+	}
 	var nonDynamicPrivilege bool
 	var allPrivs []mysql.PrivilegeType
 	authErr := genAuthErrForGrantStmt(sctx, dbName)
@@ -3869,6 +3963,9 @@ func collectVisitInfoFromGrantStmt(sctx base.PlanContext, vi []visitInfo, stmt *
 				allPrivs = mysql.AllDBPrivs
 			case ast.GrantLevelTable:
 				allPrivs = mysql.AllTablePrivs
+				if isRoutine {
+					allPrivs = mysql.AllRoutinePrivs
+				}
 			}
 			break
 		}
@@ -3986,6 +4083,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	mviewOp := "INSERT"
+	if insert.IsReplace {
+		mviewOp = "REPLACE"
+	}
+	if err := checkMViewWritable(b.ctx, tableInfo, mviewOp); err != nil {
+		return nil, err
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -4451,6 +4555,9 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 		options = append(options, &loadDataOpt)
 	}
 	tnW := b.resolveCtx.GetTableName(ld.Table)
+	if err = checkMViewWritable(b.ctx, tnW.TableInfo, "LOAD"); err != nil {
+		return nil, err
+	}
 	p := LoadData{
 		FileLocRef:         ld.FileLocRef,
 		OnDuplicate:        ld.OnDuplicate,
@@ -4658,6 +4765,8 @@ func (b *PlanBuilder) buildImportInto(ctx context.Context, ld *ast.ImportIntoStm
 		return nil, errors.Errorf("IMPORT INTO does not support temporary table")
 	} else if tnW.TableInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return nil, errors.Errorf("IMPORT INTO does not support cached table")
+	} else if tnW.TableInfo.IsMaterializedView() || len(tnW.TableInfo.MaterializedViews) > 0 {
+		return nil, errors.Errorf("IMPORT INTO does not support materialized view or its base table")
 	}
 	p := ImportInto{
 		Path:               ld.Path,
@@ -5075,6 +5184,27 @@ func checkForUserVariables(in ast.Node) error {
 	return nil
 }
 
+// appendVisitInfo4TTLAction requires the INSERT privilege on the archive table of `TTL_ACTION`, because the
+// expired rows will be moved into it.
+func (b *PlanBuilder) appendVisitInfo4TTLAction(options []*ast.TableOption, dbName string) {
+	for _, op := range options {
+		if op.Tp != ast.TableOptionTTLAction || ast.TTLActionType(op.UintValue) != ast.TTLActionArchive || len(op.TableNames) == 0 {
+			continue
+		}
+		archiveTbl := op.TableNames[0]
+		archiveDB := archiveTbl.Schema.L
+		if archiveDB == "" {
+			archiveDB = dbName
+		}
+		var authErr error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername,
+				user.AuthHostname, archiveTbl.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, archiveDB, archiveTbl.Name.L, "", authErr)
+	}
+}
+
 func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan, error) {
 	var authErr error
 	switch v := node.(type) {
@@ -5161,6 +5291,27 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 					b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ReferencesPriv, spec.Constraint.Refer.Table.Schema.L,
 						spec.Constraint.Refer.Table.Name.L, "", authErr)
 				}
+			} else if spec.Tp == ast.AlterTableOption {
+				b.appendVisitInfo4TTLAction(spec.Options, dbName)
+			}
+		}
+		// CHECK PARTITION and OPTIMIZE PARTITION don't change the schema, they are
+		// executed like ADMIN CHECK TABLE and ALTER TABLE ... COMPACT, so they
+		// can't be combined with other specs.
+		if len(v.Specs) == 1 {
+			switch spec := v.Specs[0]; spec.Tp {
+			case ast.AlterTableCheckPartitions:
+				return b.buildCheckPartitions(ctx, v.Table, spec)
+			case ast.AlterTableOptimizePartition:
+				return b.buildOptimizePartitions(v.Table, spec)
+			}
+		}
+		for _, spec := range v.Specs {
+			switch spec.Tp {
+			case ast.AlterTableCheckPartitions:
+				return nil, dbterror.ErrRunMultiSchemaChanges.FastGenByArgs("check partition")
+			case ast.AlterTableOptimizePartition:
+				return nil, dbterror.ErrRunMultiSchemaChanges.FastGenByArgs("optimize partition")
 			}
 		}
 	case *ast.AlterSequenceStmt:
@@ -5221,6 +5372,7 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, v.ReferTable.Schema.L,
 				v.ReferTable.Name.L, "", authErr)
 		}
+		b.appendVisitInfo4TTLAction(v.Options, v.Table.Schema.L)
 	case *ast.CreateViewStmt:
 		err := checkForUserVariables(v.Select)
 		if err != nil {
@@ -5279,6 +5431,149 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		err := checkForUserVariables(v.Select)
+		if err != nil {
+			return nil, err
+		}
+		b.capFlag |= canExpandAST
+		defer func() {
+			b.capFlag &= ^canExpandAST
+		}()
+
+		nodeW := resolve.NewNodeWWithCtx(v.Select, b.resolveCtx)
+		plan, err := b.Build(ctx, nodeW)
+		if err != nil {
+			return nil, err
+		}
+		schema := plan.Schema()
+		v.SchemaCols = v.Cols
+		if v.SchemaCols == nil {
+			adjustOverlongViewColname(plan.(base.LogicalPlan))
+			v.SchemaCols = make([]ast.CIStr, 0, schema.Len())
+			for _, name := range plan.OutputNames() {
+				v.SchemaCols = append(v.SchemaCols, name.ColName)
+			}
+		}
+		if len(v.SchemaCols) != schema.Len() {
+			return nil, dbterror.ErrViewWrongList
+		}
+		v.SchemaTypes = make([]*types.FieldType, 0, schema.Len())
+		for _, col := range schema.Columns {
+			v.SchemaTypes = append(v.SchemaTypes, col.RetType.Clone())
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.DropMaterializedViewStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.RefreshMaterializedViewStmt:
+		// The result of the view is rewritten, so both INSERT and DELETE are required.
+		for _, priv := range []struct {
+			name string
+			priv mysql.PrivilegeType
+		}{{"INSERT", mysql.InsertPriv}, {"DELETE", mysql.DeletePriv}} {
+			if b.ctx.GetSessionVars().User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs(priv.name, b.ctx.GetSessionVars().User.AuthUsername,
+					b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, priv.priv, v.ViewName.Schema.L,
+				v.ViewName.Name.L, "", authErr)
+		}
+	case *ast.ProcedureInfo:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ProcedureName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+		if (v.Definer == nil || v.Definer.CurrentUser) && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropProcedureStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrProcaccessDenied.GenWithStackByArgs("alter routine", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ProcedureName.Schema.L+"."+v.ProcedureName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+	case *ast.CreateEventStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.EventName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, v.EventName.Schema.L,
+			"", "", authErr)
+		if (v.Definer == nil || v.Definer.CurrentUser) && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.AlterEventStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.EventName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, v.EventName.Schema.L,
+			"", "", authErr)
+		if v.Definer != nil && v.Definer.CurrentUser && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if v.Definer != nil && b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropEventStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.EventName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, v.EventName.Schema.L,
+			"", "", authErr)
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+		if (v.Definer == nil || v.Definer.CurrentUser) && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropTriggerStmt:
+		// The privilege is checked on the table of the trigger, the DDL reports
+		// the error if the trigger doesn't exist.
+		tblInfo, _, err := infoschema.FindTriggerTable(ctx, b.is, v.TriggerName.Schema, v.TriggerName.Name)
+		if err == nil && tblInfo != nil {
+			if b.ctx.GetSessionVars().User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+					b.ctx.GetSessionVars().User.AuthHostname, tblInfo.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L,
+				tblInfo.Name.L, "", authErr)
+		}
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5558,24 +5853,65 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 	return b.buildExplainPlan(targetPlan, explain.Format, nil, explain.Analyze, explain.Stmt, nil)
 }
 
+// outfileCredentialParams are the parameters of the external storage URI which carry the credentials.
+var outfileCredentialParams = []string{"access-key", "secret-access-key", "session-token", "account-key", "sas-token"}
+
+// outfileUsesServerCredentials returns whether `SELECT ... INTO OUTFILE` writes to an external storage with the
+// credentials of the TiDB server, that is the URI is not a local path and doesn't carry the credentials.
+func outfileUsesServerCredentials(fileName string) bool {
+	u, err := storage.ParseRawURL(fileName)
+	if err != nil || storage.IsLocal(u) {
+		// the invalid URIs fail when the statement is executed.
+		return false
+	}
+	for k := range u.Query() {
+		// the same normalization with `storage.ExtractQueryParameters`
+		if slices.Contains(outfileCredentialParams, strings.ToLower(strings.ReplaceAll(k, "_", "-"))) {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (base.Plan, error) {
-	if sem.IsEnabled() {
+	selectIntoInfo := sel.SelectIntoOpt
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		// Local variables are resolved by the stored routine executor, so only
+		// user variables can be found here.
+		for _, v := range selectIntoInfo.Variables {
+			if !v.IsUserVar {
+				return nil, plannererrors.ErrSpUndeclaredVar.GenWithStackByArgs(v.Name)
+			}
+		}
+	} else if sem.IsEnabled() {
 		return nil, plannererrors.ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
-	selectIntoInfo := sel.SelectIntoOpt
-	sel.SelectIntoOpt = nil
 	sctx, err := AsSctx(b.ctx)
 	if err != nil {
 		return nil, err
 	}
+	// The select-into option is removed to optimize the inner query, and set back so that the statement can still be
+	// redacted by `SecureText` when it's logged.
+	sel.SelectIntoOpt = nil
 	nodeW := resolve.NewNodeWWithCtx(sel, b.resolveCtx)
-	targetPlan, _, err := OptimizeAstNode(ctx, sctx, nodeW, b.is)
+	targetPlan, targetNames, err := OptimizeAstNode(ctx, sctx, nodeW, b.is)
+	sel.SelectIntoOpt = selectIntoInfo
 	if err != nil {
 		return nil, err
 	}
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		if targetPlan.Schema().Len() != len(selectIntoInfo.Variables) {
+			return nil, plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+		}
+		return &SelectInto{TargetPlan: targetPlan, IntoOpt: selectIntoInfo}, nil
+	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	if outfileUsesServerCredentials(selectIntoInfo.FileName) {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
+	}
 	return &SelectInto{
 		TargetPlan:     targetPlan,
+		TargetNames:    targetNames,
 		IntoOpt:        selectIntoInfo,
 		LineFieldsInfo: NewLineFieldsInfo(selectIntoInfo.FieldsInfo, selectIntoInfo.LinesInfo),
 	}, nil
@@ -5735,6 +6071,15 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateFunction:
+		names = []string{"Function", "sql_mode", "Create Function", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateTrigger:
+		names = []string{"Trigger", "sql_mode", "SQL Original Statement", "character_set_client", "collation_connection", "Database Collation", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTimestamp}
 	case ast.ShowGrants:
 		if s.User != nil {
 			names = []string{fmt.Sprintf("Grants for %s", s.User)}
@@ -6026,6 +6371,27 @@ func (*PlanBuilder) buildRecommendIndex(v *ast.RecommendIndexStmt) (base.Plan, e
 		schema.Append(buildColumnWithName("", "top_impacted_query", mysql.TypeBlob, -1))
 		schema.Append(buildColumnWithName("", "create_index_statement", mysql.TypeBlob, -1))
 		p.setSchemaAndNames(schema.col2Schema(), schema.names)
+	case "explain":
+		schema := newColumnsWithNames(7)
+		schema.Append(buildColumnWithName("", "database", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "table", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_name", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_columns", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "original_cost", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "hypo_cost", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "improvement", mysql.TypeVarchar, 64))
+		p.setSchemaAndNames(schema.col2Schema(), schema.names)
+	case "drop":
+		schema := newColumnsWithNames(8)
+		schema.Append(buildColumnWithName("", "database", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "table", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_name", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_columns", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "est_index_size", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "est_write_reduction", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "reason", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "drop_index_statement", mysql.TypeBlob, -1))
+		p.setSchemaAndNames(schema.col2Schema(), schema.names)
 	case "set":
 		if len(p.Options) == 0 {
 			return nil, fmt.Errorf("option is empty")
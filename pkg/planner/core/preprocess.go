@@ -291,6 +291,14 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
 		p.checkDropTableGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.stmtTp = TypeDrop
+		p.flag |= inCreateOrDropTable
+		p.checkDropTableNames([]*ast.TableName{node.ViewName})
 	case *ast.RenameTableStmt:
 		p.stmtTp = TypeRename
 		p.flag |= inCreateOrDropTable
@@ -330,6 +338,49 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	case *ast.DeleteTableList:
 		p.stmtTp = TypeDelete
 		return in, true
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.ProcedureName)
+		// The routine body is resolved when the routine is executed, the objects
+		// referenced by it may not exist yet.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.ProcedureName)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		// The trigger is created in the schema of its table by default.
+		if node.TriggerName.Schema.L == "" {
+			node.TriggerName.Schema = node.Table.Schema
+		}
+		p.resolveRoutineName(node.TriggerName)
+		if node.Table.Schema.L == "" {
+			node.Table.Schema = node.TriggerName.Schema
+		}
+		// The trigger body is resolved when the trigger is activated.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.TriggerName)
+		return in, true
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.EventName)
+		// The schedule is evaluated by the DDL and the event body is resolved
+		// when the event is executed.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.resolveRoutineName(node.EventName)
+		if node.NewName != nil && node.NewName.Schema.L == "" {
+			node.NewName.Schema = node.EventName.Schema
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.EventName)
+		return in, true
 	case *ast.Join:
 		p.checkNonUniqTableAlias(node)
 	case *ast.CreateBindingStmt:
@@ -388,10 +439,6 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if node.FnName.L == ast.NextVal || node.FnName.L == ast.LastVal || node.FnName.L == ast.SetVal {
 			p.flag |= inSequenceFunction
 		}
-		// not support procedure right now.
-		if node.Schema.L != "" {
-			p.err = expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", node.Schema.L+"."+node.FnName.L)
-		}
 	case *ast.BRIEStmt:
 		if node.Kind == ast.BRIEKindRestore {
 			p.flag |= inCreateOrDropTable
@@ -618,7 +665,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -1049,6 +1096,26 @@ func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	switch sel := stmt.Select.(type) {
+	case *ast.SelectStmt:
+		p.checkCreateViewWithSelect(sel)
+	case *ast.SetOprStmt:
+		p.checkCreateViewWithSelect(sel.SelectList)
+	}
+}
+
 func (p *preprocessor) checkDropSequenceGrammar(stmt *ast.DropSequenceStmt) {
 	p.checkDropTableNames(stmt.Sequences)
 }
@@ -1826,6 +1893,9 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	} else if node.Table != nil && node.Table.Schema.L == "" {
 		node.Table.Schema = ast.NewCIStr(node.DBName)
 	}
+	if node.Procedure != nil && node.Procedure.Schema.L == "" {
+		node.Procedure.Schema = ast.NewCIStr(node.DBName)
+	}
 	if node.User != nil && node.User.CurrentUser {
 		// Fill the Username and Hostname with the current user.
 		currentUser := p.sctx.GetSessionVars().User
@@ -1838,6 +1908,18 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	}
 }
 
+func (p *preprocessor) resolveRoutineName(tn *ast.TableName) {
+	if tn.Schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(plannererrors.ErrNoDB)
+		return
+	}
+	tn.Schema = ast.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) resolveExecuteStmt(node *ast.ExecuteStmt) {
 	prepared, err := GetPreparedStmt(node, p.sctx.GetSessionVars())
 	if err != nil {
@@ -665,6 +665,12 @@ func (p *PhysicalWindow) ResolveIndices() (err error) {
 				return err
 			}
 		}
+		for _, item := range desc.OrderByItems {
+			item.Expr, err = item.Expr.ResolveIndices(p.Children()[0].Schema())
+			if err != nil {
+				return err
+			}
+		}
 	}
 	if p.Frame != nil {
 		for i := range p.Frame.Start.CalcFuncs {
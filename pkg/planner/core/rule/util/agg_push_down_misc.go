@@ -0,0 +1,44 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+)
+
+// IsDecomposableWithJoin checks if an aggregate function is decomposable. An aggregation function $F$ is decomposable
+// if there exist aggregation functions F_1 and F_2 such that F(S_1 union all S_2) = F_2(F_1(S_1),F_1(S_2)),
+// where S_1 and S_2 are two sets of values. We call S_1 and S_2 partial groups.
+// For example, Max(S_1 union S_2) = Max(Max(S_1) union Max(S_2)), thus we think Max is decomposable.
+// It's easy to see that max, min, first row is decomposable, no matter whether it's distinct, but sum(distinct) and
+// count(distinct) is not.
+// Currently we don't support avg and concat.
+func IsDecomposableWithJoin(fun *aggregation.AggFuncDesc) bool {
+	if len(fun.OrderByItems) > 0 {
+		return false
+	}
+	switch fun.Name {
+	case ast.AggFuncAvg, ast.AggFuncGroupConcat, ast.AggFuncVarPop, ast.AggFuncJsonArrayagg, ast.AggFuncJsonObjectAgg, ast.AggFuncStddevPop, ast.AggFuncVarSamp, ast.AggFuncApproxPercentile, ast.AggFuncStddevSamp:
+		// TODO: Support avg push down.
+		return false
+	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
+		return true
+	case ast.AggFuncSum, ast.AggFuncCount:
+		return !fun.HasDistinct
+	default:
+		return false
+	}
+}
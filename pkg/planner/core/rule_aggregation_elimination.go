@@ -17,16 +17,14 @@ package core
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/expression/aggregation"
 	"github.com/pingcap/tidb/pkg/parser/ast"
-	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
 	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
-	"github.com/pingcap/tidb/pkg/types"
 )
 
 // AggregationEliminator is used to eliminate aggregation grouped by unique key.
@@ -188,7 +186,7 @@ func ConvertAggToProj(agg *logicalop.LogicalAggregation, schema *expression.Sche
 		Exprs: make([]expression.Expression, 0, len(agg.AggFuncs)),
 	}.Init(agg.SCtx(), agg.QueryBlockOffset())
 	for _, fun := range agg.AggFuncs {
-		ok, expr := rewriteExpr(agg.SCtx().GetExprCtx(), fun)
+		ok, expr := ruleutil.RewriteAggFuncToExpr(agg.SCtx().GetExprCtx(), fun)
 		if !ok {
 			return false, nil
 		}
@@ -198,65 +196,6 @@ func ConvertAggToProj(agg *logicalop.LogicalAggregation, schema *expression.Sche
 	return true, proj
 }
 
-// rewriteExpr will rewrite the aggregate function to expression doesn't contain aggregate function.
-func rewriteExpr(ctx expression.BuildContext, aggFunc *aggregation.AggFuncDesc) (bool, expression.Expression) {
-	switch aggFunc.Name {
-	case ast.AggFuncCount:
-		if aggFunc.Mode == aggregation.FinalMode &&
-			len(aggFunc.Args) == 1 &&
-			mysql.HasNotNullFlag(aggFunc.Args[0].GetType(ctx.GetEvalCtx()).GetFlag()) {
-			return true, wrapCastFunction(ctx, aggFunc.Args[0], aggFunc.RetTp)
-		}
-		return true, rewriteCount(ctx, aggFunc.Args, aggFunc.RetTp)
-	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncGroupConcat:
-		return true, wrapCastFunction(ctx, aggFunc.Args[0], aggFunc.RetTp)
-	case ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor:
-		return true, rewriteBitFunc(ctx, aggFunc.Name, aggFunc.Args[0], aggFunc.RetTp)
-	default:
-		return false, nil
-	}
-}
-
-func rewriteCount(ctx expression.BuildContext, exprs []expression.Expression, targetTp *types.FieldType) expression.Expression {
-	// If is count(expr), we will change it to if(isnull(expr), 0, 1).
-	// If is count(distinct x, y, z), we will change it to if(isnull(x) or isnull(y) or isnull(z), 0, 1).
-	// If is count(expr not null), we will change it to constant 1.
-	isNullExprs := make([]expression.Expression, 0, len(exprs))
-	for _, expr := range exprs {
-		if mysql.HasNotNullFlag(expr.GetType(ctx.GetEvalCtx()).GetFlag()) {
-			isNullExprs = append(isNullExprs, expression.NewZero())
-		} else {
-			isNullExpr := expression.NewFunctionInternal(ctx, ast.IsNull, types.NewFieldType(mysql.TypeTiny), expr)
-			isNullExprs = append(isNullExprs, isNullExpr)
-		}
-	}
-
-	innerExpr := expression.ComposeDNFCondition(ctx, isNullExprs...)
-	newExpr := expression.NewFunctionInternal(ctx, ast.If, targetTp, innerExpr, expression.NewZero(), expression.NewOne())
-	return newExpr
-}
-
-func rewriteBitFunc(ctx expression.BuildContext, funcType string, arg expression.Expression, targetTp *types.FieldType) expression.Expression {
-	// For not integer type. We need to cast(cast(arg as signed) as unsigned) to make the bit function work.
-	innerCast := expression.WrapWithCastAsInt(ctx, arg, nil)
-	outerCast := wrapCastFunction(ctx, innerCast, targetTp)
-	var finalExpr expression.Expression
-	if funcType != ast.AggFuncBitAnd {
-		finalExpr = expression.NewFunctionInternal(ctx, ast.Ifnull, targetTp, outerCast, expression.NewZero())
-	} else {
-		finalExpr = expression.NewFunctionInternal(ctx, ast.Ifnull, outerCast.GetType(ctx.GetEvalCtx()), outerCast, &expression.Constant{Value: types.NewUintDatum(math.MaxUint64), RetType: targetTp})
-	}
-	return finalExpr
-}
-
-// wrapCastFunction will wrap a cast if the targetTp is not equal to the arg's.
-func wrapCastFunction(ctx expression.BuildContext, arg expression.Expression, targetTp *types.FieldType) expression.Expression {
-	if arg.GetType(ctx.GetEvalCtx()).Equal(targetTp) {
-		return arg
-	}
-	return expression.BuildCastFunction(ctx, arg, targetTp)
-}
-
 // Optimize implements the base.LogicalOptRule.<0th> interface.
 func (a *AggregationEliminator) Optimize(ctx context.Context, p base.LogicalPlan, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, bool, error) {
 	planChanged := false
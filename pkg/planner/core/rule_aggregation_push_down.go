@@ -37,30 +37,6 @@ type AggregationPushDownSolver struct {
 	aggregationEliminateChecker
 }
 
-// isDecomposable checks if an aggregate function is decomposable. An aggregation function $F$ is decomposable
-// if there exist aggregation functions F_1 and F_2 such that F(S_1 union all S_2) = F_2(F_1(S_1),F_1(S_2)),
-// where S_1 and S_2 are two sets of values. We call S_1 and S_2 partial groups.
-// For example, Max(S_1 union S_2) = Max(Max(S_1) union Max(S_2)), thus we think Max is decomposable.
-// It's easy to see that max, min, first row is decomposable, no matter whether it's distinct, but sum(distinct) and
-// count(distinct) is not.
-// Currently we don't support avg and concat.
-func (*AggregationPushDownSolver) isDecomposableWithJoin(fun *aggregation.AggFuncDesc) bool {
-	if len(fun.OrderByItems) > 0 {
-		return false
-	}
-	switch fun.Name {
-	case ast.AggFuncAvg, ast.AggFuncGroupConcat, ast.AggFuncVarPop, ast.AggFuncJsonArrayagg, ast.AggFuncJsonObjectAgg, ast.AggFuncStddevPop, ast.AggFuncVarSamp, ast.AggFuncApproxPercentile, ast.AggFuncStddevSamp:
-		// TODO: Support avg push down.
-		return false
-	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
-		return true
-	case ast.AggFuncSum, ast.AggFuncCount:
-		return !fun.HasDistinct
-	default:
-		return false
-	}
-}
-
 func (*AggregationPushDownSolver) isDecomposableWithUnion(fun *aggregation.AggFuncDesc) bool {
 	if len(fun.OrderByItems) > 0 {
 		return false
@@ -109,7 +85,7 @@ func (a *AggregationPushDownSolver) collectAggFuncs(agg *logicalop.LogicalAggreg
 	leftChild := join.Children()[0]
 	rightChild := join.Children()[1]
 	for _, aggFunc := range agg.AggFuncs {
-		if !a.isDecomposableWithJoin(aggFunc) {
+		if !ruleutil.IsDecomposableWithJoin(aggFunc) {
 			return false, nil, nil
 		}
 		index := a.getAggFuncChildIdx(aggFunc, leftChild.Schema(), rightChild.Schema())
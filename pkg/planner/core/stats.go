@@ -412,7 +412,12 @@ func detachCondAndBuildRangeForPath(
 			path.ConstCols[i] = res.ColumnValues[i] != nil
 		}
 	}
-	path.CountAfterAccess, path.CorrCountAfterAccess, err = cardinality.GetRowCountByIndexRanges(sctx, histColl, path.Index.ID, path.Ranges)
+	if path.Index.Tp == ast.IndexTypeHypo && sctx.GetSessionVars().StmtCtx.InWhatIfHypoIndex {
+		// hypo-indexes have no stats, estimate them from the stats of the indexed columns.
+		path.CountAfterAccess, err = cardinality.GetRowCountByHypoIndexAccessConds(sctx, histColl, path.AccessConds)
+	} else {
+		path.CountAfterAccess, path.CorrCountAfterAccess, err = cardinality.GetRowCountByIndexRanges(sctx, histColl, path.Index.ID, path.Ranges)
+	}
 	if path.CorrCountAfterAccess == 0 {
 		path.CorrCountAfterAccess = path.CountAfterAccess
 	}
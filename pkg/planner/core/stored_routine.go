@@ -0,0 +1,445 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	infoschemactx "github.com/pingcap/tidb/pkg/infoschema/context"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/hint"
+)
+
+// LoadRoutine loads the stored routine from the latest meta snapshot, stored
+// routines are not cached in the info schema.
+func LoadRoutine(sctx base.PlanContext, is infoschemactx.MetaOnlyInfoSchema, dbName ast.CIStr, tp model.RoutineType, name ast.CIStr) (*model.DBInfo, *model.RoutineInfo, error) {
+	notExists := plannererrors.ErrSpDoesNotExist.GenWithStackByArgs(tp.String(), dbName.O+"."+name.O)
+	db, ok := is.SchemaByName(dbName)
+	if !ok {
+		return nil, nil, notExists
+	}
+	routine, err := meta.NewReader(sctx.GetStore().GetSnapshot(kv.MaxVersion)).GetRoutine(db.ID, tp, name)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if routine == nil {
+		return nil, nil, notExists
+	}
+	return db, routine, nil
+}
+
+// ParseRoutineBody parses the body of the stored routine with the sql mode it
+// was created with.
+func ParseRoutineBody(sctx base.PlanContext, routine *model.RoutineInfo) (ast.StmtNode, error) {
+	sql := fmt.Sprintf("CREATE %s `%s`(%s)", routine.Type, strings.ReplaceAll(routine.Name.O, "`", "``"), routine.ParamStr)
+	if routine.IsFunction() {
+		// Only the body is used, the return type is kept in the routine info.
+		sql += " RETURNS " + routine.ReturnType.CompactStr()
+	}
+	sql += " " + routine.Body
+	p := NewRoutineParser(sctx, routine)
+	stmt, err := p.ParseOneStmt(sql, routine.Charset, routine.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stmt.(*ast.ProcedureInfo).ProcedureBody, nil
+}
+
+// NewRoutineParser creates a parser to parse the statements of the stored routine.
+func NewRoutineParser(sctx base.PlanContext, routine *model.RoutineInfo) *parser.Parser {
+	p := parser.New()
+	p.SetSQLMode(routine.SQLMode)
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	return p
+}
+
+// rewriteStoredFuncCall rewrites the call of a stored function. It returns nil
+// if the function is not a stored function.
+func (er *expressionRewriter) rewriteStoredFuncCall(planCtx *exprRewriterPlanCtx, v *ast.FuncCallExpr, args []expression.Expression) (expression.Expression, error) {
+	b := planCtx.builder
+	dbName := v.Schema
+	if dbName.L == "" {
+		if expression.IsFunctionSupported(v.FnName.L) {
+			return nil, nil
+		}
+		currentDB := b.ctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			return nil, nil
+		}
+		dbName = ast.NewCIStr(currentDB)
+	}
+	db, routine, err := LoadRoutine(b.ctx, b.is, dbName, model.RoutineTypeFunction, v.FnName)
+	if err != nil {
+		if plannererrors.ErrSpDoesNotExist.Equal(err) {
+			if v.Schema.L == "" {
+				return nil, nil
+			}
+			return nil, expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", v.Schema.O+"."+v.FnName.O)
+		}
+		return nil, err
+	}
+	fullName := db.Name.O + "." + routine.Name.O
+	vars := b.ctx.GetSessionVars()
+	if pm := privilege.GetPrivilegeManager(b.ctx); pm != nil && vars.User != nil &&
+		!pm.RequestRoutineVerification(vars.ActiveRoles, db.Name.L, routine.Name.L, model.RoutineTypeFunction.String(), mysql.ExecutePriv) {
+		return nil, plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, fullName)
+	}
+	if len(args) != len(routine.Params) {
+		return nil, plannererrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("FUNCTION", fullName, len(routine.Params), len(args))
+	}
+	key := db.Name.L + "." + routine.Name.L
+	if slices.Contains(b.storedFuncStack, key) {
+		return nil, plannererrors.ErrSpNoRecursion.GenWithStackByArgs()
+	}
+	fn, err := compileStoredFunction(er.ctx, b, db, routine, key)
+	if err != nil {
+		return nil, err
+	}
+	return expression.NewStoredFunction(er.sctx, fn, args...)
+}
+
+// storedFuncCompiler compiles the body of a stored function into the
+// expression.StoredFunction. Every variable takes a slot of the frame, and the
+// expressions in the body refer to the variables as the columns of the frame.
+type storedFuncCompiler struct {
+	ctx     context.Context
+	b       *PlanBuilder
+	routine *model.RoutineInfo
+	fn      *expression.StoredFunction
+	schema  *expression.Schema
+	// varNames is the name of the variable in each slot.
+	varNames []string
+	// scopes maps the visible variable names to their slots.
+	scopes []map[string]int
+}
+
+func compileStoredFunction(ctx context.Context, outer *PlanBuilder, db *model.DBInfo, routine *model.RoutineInfo, key string) (*expression.StoredFunction, error) {
+	sctx := outer.ctx
+	body, err := ParseRoutineBody(sctx, routine)
+	if err != nil {
+		return nil, err
+	}
+	b, savedBlockNames := NewPlanBuilder().Init(sctx, outer.is, hint.NewQBHintHandler(nil))
+	defer sctx.GetSessionVars().PlannerSelectBlockAsName.Store(&savedBlockNames)
+	b.storedFuncStack = append(slices.Clone(outer.storedFuncStack), key)
+	b.curClause = expressionClause
+
+	// The names in the body are resolved in the database of the stored function.
+	vars := sctx.GetSessionVars()
+	origDB := vars.CurrentDB
+	vars.CurrentDB = db.Name.O
+	defer func() { vars.CurrentDB = origDB }()
+
+	c := &storedFuncCompiler{
+		ctx:     ctx,
+		b:       b,
+		routine: routine,
+		fn: &expression.StoredFunction{
+			Name:     ast.NewCIStr(db.Name.O + "." + routine.Name.O),
+			NumParam: len(routine.Params),
+			RetType:  routine.ReturnType,
+		},
+		schema: expression.NewSchema(),
+		scopes: []map[string]int{{}},
+	}
+	for _, param := range routine.Params {
+		c.declare(param.Name.O, param.Type)
+	}
+	if c.fn.Body, err = c.compileStmts([]ast.StmtNode{body}); err != nil {
+		return nil, err
+	}
+	return c.fn, nil
+}
+
+func (c *storedFuncCompiler) declare(name string, tp *types.FieldType) int {
+	tp = tp.Clone()
+	if tp.EvalType() == types.ETString && tp.GetCharset() == "" {
+		tp.SetCharset(c.routine.Charset)
+		tp.SetCollate(c.routine.Collate)
+	}
+	slot := len(c.fn.VarTypes)
+	c.fn.VarTypes = append(c.fn.VarTypes, tp)
+	c.varNames = append(c.varNames, strings.ToLower(name))
+	c.schema.Append(&expression.Column{
+		UniqueID: c.b.ctx.GetSessionVars().AllocPlanColumnID(),
+		Index:    slot,
+		RetType:  tp,
+	})
+	c.scopes[len(c.scopes)-1][strings.ToLower(name)] = slot
+	return slot
+}
+
+func (c *storedFuncCompiler) lookup(name string) (int, bool) {
+	name = strings.ToLower(name)
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if slot, ok := c.scopes[i][name]; ok {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+func (c *storedFuncCompiler) compileExpr(expr ast.ExprNode) (expression.Expression, error) {
+	checker := &subqueryChecker{}
+	expr.Accept(checker)
+	if checker.found {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in stored functions")
+	}
+	// Only the innermost variable of the same name is visible.
+	names := make(types.NameSlice, len(c.varNames))
+	for i, name := range c.varNames {
+		slot, ok := c.lookup(name)
+		names[i] = &types.FieldName{ColName: ast.NewCIStr(name), NotExplicitUsable: !ok || slot != i}
+	}
+	p := logicalop.LogicalTableDual{}.Init(c.b.ctx, 0)
+	p.SetSchema(c.schema)
+	p.SetOutputNames(names)
+	newExpr, _, err := c.b.rewrite(c.ctx, expr, p, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return newExpr.ResolveIndices(c.schema)
+}
+
+func (c *storedFuncCompiler) compileStmts(stmts []ast.StmtNode) ([]expression.StoredFuncStmt, error) {
+	result := make([]expression.StoredFuncStmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		compiled, err := c.compileStmt(stmt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, compiled...)
+	}
+	return result, nil
+}
+
+func (c *storedFuncCompiler) compileStmt(stmt ast.StmtNode) ([]expression.StoredFuncStmt, error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		block, err := c.compileBlock(x, "")
+		if err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{block}, nil
+	case *ast.ProcedureLabelBlock:
+		block, err := c.compileBlock(x.Block, x.LabelName)
+		if err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{block}, nil
+	case *ast.ProcedureLabelLoop:
+		loop, err := c.compileLoop(x.Block, x.LabelName)
+		if err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{loop}, nil
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt:
+		loop, err := c.compileLoop(x, "")
+		if err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{loop}, nil
+	case *ast.ProcedureJump:
+		return []expression.StoredFuncStmt{&expression.StoredFuncJump{Label: x.Name, IsLeave: x.IsLeave}}, nil
+	case *ast.ProcedureIfInfo:
+		ifStmt := &expression.StoredFuncIf{}
+		if err := c.compileIf(ifStmt, x.IfBody); err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{ifStmt}, nil
+	case *ast.SimpleCaseStmt:
+		ifStmt := &expression.StoredFuncIf{IsCase: true}
+		for _, when := range x.WhenCases {
+			cond := &ast.BinaryOperationExpr{Op: opcode.EQ, L: x.Condition, R: when.Expr}
+			if err := c.compileBranch(ifStmt, cond, when.ProcedureStmts); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.compileElse(ifStmt, x.ElseCases); err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{ifStmt}, nil
+	case *ast.SearchCaseStmt:
+		ifStmt := &expression.StoredFuncIf{IsCase: true}
+		for _, when := range x.WhenCases {
+			if err := c.compileBranch(ifStmt, when.Expr, when.ProcedureStmts); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.compileElse(ifStmt, x.ElseCases); err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{ifStmt}, nil
+	case *ast.ProcedureReturn:
+		expr, err := c.compileExpr(x.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return []expression.StoredFuncStmt{&expression.StoredFuncReturn{Expr: expr}}, nil
+	case *ast.SetStmt:
+		return c.compileSet(x)
+	}
+	return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements other than SET in stored functions")
+}
+
+func (c *storedFuncCompiler) compileBlock(block *ast.ProcedureBlock, label string) (*expression.StoredFuncBlock, error) {
+	c.scopes = append(c.scopes, map[string]int{})
+	defer func() { c.scopes = c.scopes[:len(c.scopes)-1] }()
+	result := &expression.StoredFuncBlock{Label: label}
+	for _, decl := range block.ProcedureVars {
+		x, ok := decl.(*ast.ProcedureDecl)
+		if !ok {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored functions")
+		}
+		// The default value is evaluated before the variables are visible, and
+		// the variables are reset every time the block is entered.
+		var value expression.Expression = &expression.Constant{Value: types.NewDatum(nil), RetType: x.DeclType}
+		if x.DeclDefault != nil {
+			var err error
+			if value, err = c.compileExpr(x.DeclDefault); err != nil {
+				return nil, err
+			}
+		}
+		for _, name := range x.DeclNames {
+			slot := c.declare(name, x.DeclType)
+			result.Body = append(result.Body, &expression.StoredFuncSet{Slot: slot, Expr: value})
+		}
+	}
+	body, err := c.compileStmts(block.ProcedureProcStmts)
+	if err != nil {
+		return nil, err
+	}
+	result.Body = append(result.Body, body...)
+	return result, nil
+}
+
+func (c *storedFuncCompiler) compileLoop(stmt ast.StmtNode, label string) (*expression.StoredFuncLoop, error) {
+	var (
+		cond ast.ExprNode
+		body []ast.StmtNode
+		loop = &expression.StoredFuncLoop{Label: label}
+	)
+	switch x := stmt.(type) {
+	case *ast.ProcedureWhileStmt:
+		cond, body = x.Condition, x.Body
+	case *ast.ProcedureRepeatStmt:
+		cond, body, loop.IsRepeat = x.Condition, x.Body, true
+	}
+	var err error
+	if loop.Cond, err = c.compileExpr(cond); err != nil {
+		return nil, err
+	}
+	if loop.Body, err = c.compileStmts(body); err != nil {
+		return nil, err
+	}
+	return loop, nil
+}
+
+func (c *storedFuncCompiler) compileIf(ifStmt *expression.StoredFuncIf, block *ast.ProcedureIfBlock) error {
+	if err := c.compileBranch(ifStmt, block.IfExpr, block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.compileIf(ifStmt, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.compileElse(ifStmt, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *storedFuncCompiler) compileBranch(ifStmt *expression.StoredFuncIf, cond ast.ExprNode, stmts []ast.StmtNode) error {
+	expr, err := c.compileExpr(cond)
+	if err != nil {
+		return err
+	}
+	body, err := c.compileStmts(stmts)
+	if err != nil {
+		return err
+	}
+	ifStmt.Conds = append(ifStmt.Conds, expr)
+	ifStmt.Branches = append(ifStmt.Branches, body)
+	return nil
+}
+
+func (c *storedFuncCompiler) compileElse(ifStmt *expression.StoredFuncIf, stmts []ast.StmtNode) error {
+	if stmts == nil {
+		return nil
+	}
+	// The compiled ELSE is never nil, so that CASE can tell it from a missing ELSE.
+	var err error
+	ifStmt.Else, err = c.compileStmts(stmts)
+	return err
+}
+
+func (c *storedFuncCompiler) compileSet(stmt *ast.SetStmt) ([]expression.StoredFuncStmt, error) {
+	result := make([]expression.StoredFuncStmt, 0, len(stmt.Variables))
+	for _, assign := range stmt.Variables {
+		// `SET v = ...` is restored as a session variable assignment.
+		if assign.IsSystem && !assign.IsGlobal {
+			if slot, ok := c.lookup(assign.Name); ok {
+				expr, err := c.compileExpr(assign.Value)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, &expression.StoredFuncSet{Slot: slot, Expr: expr})
+				continue
+			}
+		}
+		if assign.IsSystem || assign.Value == nil {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("setting system variables in stored functions")
+		}
+		expr, err := c.compileExpr(&ast.VariableExpr{Name: assign.Name, Value: assign.Value})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &expression.StoredFuncExpr{Expr: expr})
+	}
+	return result, nil
+}
+
+// subqueryChecker checks whether an expression contains subqueries.
+type subqueryChecker struct {
+	found bool
+}
+
+func (s *subqueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
+		s.found = true
+		return in, true
+	}
+	return in, false
+}
+
+func (*subqueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
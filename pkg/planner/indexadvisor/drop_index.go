@@ -0,0 +1,335 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	s "github.com/pingcap/tidb/pkg/util/set"
+	"go.uber.org/zap"
+)
+
+// AdviseDropIndexes is the entry point for recommending existing indexes to drop.
+// An index is recommended to drop if it's not used in the observation window,
+// or if it's a duplicate or a prefix of another index on the same table.
+func AdviseDropIndexes(ctx context.Context, sctx sessionctx.Context,
+	userOptions []ast.RecommendIndexOption) (results []*DropRecommendation, err error) {
+	if ctx == nil || sctx == nil {
+		return nil, errors.New("nil input")
+	}
+	option := new(Option)
+	if err := fillOption(sctx, option, userOptions); err != nil {
+		advisorLogger().Error("fill index advisor option failed", zap.Error(err))
+		return nil, err
+	}
+	advisorLogger().Info("start to advise indexes to drop", zap.Any("option", option))
+
+	usages, err := prepareIndexUsages(ctx, sctx)
+	if err != nil {
+		advisorLogger().Error("prepare index usages failed", zap.Error(err))
+		return nil, err
+	}
+	referencedIndexes, err := collectReferencedIndexes(ctx, sctx, option)
+	if err != nil {
+		advisorLogger().Error("collect indexes referenced by bindings or hints failed", zap.Error(err))
+		return nil, err
+	}
+
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	opt := NewOptimizer(sctx)
+	for _, schema := range is.AllSchemaNames() {
+		if isSystemSchema(schema.L) {
+			continue
+		}
+		tbls, err := is.SchemaTableInfos(ctx, schema)
+		if err != nil {
+			return nil, err
+		}
+		for _, tbl := range tbls {
+			if tbl.IsView() || tbl.IsSequence() || tbl.TempTableType != model.TempTableNone {
+				continue
+			}
+			tblResults, err := adviseDropIndexesForTable(sctx, is, opt, schema.L, tbl, usages, referencedIndexes, option)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, tblResults...)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { // to make the result stable
+		if results[i].Database != results[j].Database {
+			return results[i].Database < results[j].Database
+		}
+		if results[i].Table != results[j].Table {
+			return results[i].Table < results[j].Table
+		}
+		return results[i].IndexName < results[j].IndexName
+	})
+	advisorLogger().Info("finish advising indexes to drop", zap.Int("num-index", len(results)))
+	return results, nil
+}
+
+func adviseDropIndexesForTable(sctx sessionctx.Context, is infoschema.InfoSchema, opt Optimizer,
+	schemaName string, tbl *model.TableInfo, usages map[string]IndexUsage,
+	referencedIndexes s.StringSet, option *Option) ([]*DropRecommendation, error) {
+	// the row itself and each secondary index cost one KV write for a row change.
+	numKVWrites := 1
+	candidates := make([]*model.IndexInfo, 0, len(tbl.Indices))
+	for _, idx := range tbl.Indices {
+		if idx.State != model.StatePublic || (idx.Primary && tbl.HasClusteredIndex()) {
+			continue
+		}
+		numKVWrites++
+		if idx.Primary || idx.IsColumnarIndex() || idx.Tp == ast.IndexTypeHypo {
+			continue
+		}
+		candidates = append(candidates, idx)
+	}
+
+	// indexes not used in the observation window, unique ones are kept since they are constraints.
+	unused := make(map[int64]string, len(candidates))
+	now := time.Now()
+	for _, idx := range candidates {
+		if idx.Unique {
+			continue
+		}
+		usage, ok := usages[fmt.Sprintf("%v.%v.%v", schemaName, tbl.Name.L, idx.Name.L)]
+		if !ok || usage.LastUsedAt.IsZero() {
+			unused[idx.ID] = "Index is never used"
+		} else if now.Sub(usage.LastUsedAt) > option.UnusedIndexWindow {
+			unused[idx.ID] = fmt.Sprintf("Index is not used in the last %v, last used at %v",
+				option.UnusedIndexWindow, usage.LastUsedAt.Format(time.DateTime))
+		}
+	}
+
+	results := make([]*DropRecommendation, 0, 2)
+	dropped := make(map[int64]struct{}, len(candidates))
+	for _, idx := range candidates {
+		// duplicated or prefix indexes, the covering index must be kept. It's preferred to
+		// the unused reason since it tells which index serves the queries instead.
+		var keeper *model.IndexInfo
+		for _, other := range candidates {
+			if _, dropped := unused[other.ID]; dropped || !coverIndex(other, idx) {
+				continue
+			}
+			if keeper == nil || coverIndex(other, keeper) {
+				keeper = other
+			}
+		}
+		reason, ok := unused[idx.ID]
+		if keeper != nil && len(keeper.Columns) == len(idx.Columns) {
+			reason = fmt.Sprintf("Index is a duplicate of index %v", keeper.Name.O)
+		} else if keeper != nil {
+			reason = fmt.Sprintf("Index is a prefix of index %v", keeper.Name.O)
+		} else if !ok {
+			continue
+		}
+
+		if referencedIndexes.Exist(tbl.Name.L+"."+idx.Name.L) || referencedIndexes.Exist("."+idx.Name.L) {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(fmt.Errorf(
+				"index %v.%v.%v is referenced by bindings or hints, skip it", schemaName, tbl.Name.O, idx.Name.O))
+			continue
+		}
+		if neededByForeignKey(is, schemaName, tbl, idx, dropped) {
+			continue
+		}
+		dropped[idx.ID] = struct{}{}
+
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.Name.L)
+		}
+		indexSize, err := opt.EstIndexSize(schemaName, tbl.Name.L, cols...)
+		if err != nil {
+			advisorLogger().Info("show index stats failed", zap.Error(err))
+			return nil, err
+		}
+		results = append(results, &DropRecommendation{
+			Database:       schemaName,
+			Table:          tbl.Name.L,
+			IndexName:      idx.Name.O,
+			IndexColumns:   cols,
+			Reason:         reason,
+			IndexSize:      uint64(indexSize),
+			WriteReduction: round(1/float64(numKVWrites), 6),
+		})
+	}
+	return results, nil
+}
+
+// coverIndex returns whether idx can be dropped in favor of keeper, which requires that
+// idx is a prefix of keeper and keeper is as strict as idx on uniqueness.
+// It's a strict order to make sure that at least one of the duplicates is kept.
+func coverIndex(keeper, idx *model.IndexInfo) bool {
+	if keeper.ID == idx.ID || len(keeper.Columns) < len(idx.Columns) || keeper.MVIndex || idx.MVIndex {
+		return false
+	}
+	for i, col := range idx.Columns {
+		if keeper.Columns[i].Name.L != col.Name.L || keeper.Columns[i].Length != col.Length {
+			return false
+		}
+	}
+	if len(keeper.Columns) > len(idx.Columns) {
+		return !idx.Unique
+	}
+	if keeper.Unique != idx.Unique {
+		return keeper.Unique
+	}
+	return keeper.ID < idx.ID
+}
+
+// neededByForeignKey returns whether idx is the only index supporting a foreign key
+// after dropping the indexes in dropped.
+func neededByForeignKey(is infoschema.InfoSchema, schemaName string, tbl *model.TableInfo,
+	idx *model.IndexInfo, dropped map[int64]struct{}) bool {
+	fkCols := make([][]ast.CIStr, 0, len(tbl.ForeignKeys))
+	for _, fk := range tbl.ForeignKeys {
+		fkCols = append(fkCols, fk.Cols)
+	}
+	for _, referredFK := range is.GetTableReferredForeignKeys(schemaName, tbl.Name.L) {
+		fkCols = append(fkCols, referredFK.Cols)
+	}
+	for _, cols := range fkCols {
+		if !model.IsIndexPrefixCovered(tbl, idx, cols...) {
+			continue
+		}
+		covered := false
+		for _, other := range tbl.Indices {
+			if _, ok := dropped[other.ID]; ok || other.ID == idx.ID {
+				continue
+			}
+			if model.IsIndexPrefixCovered(tbl, other, cols...) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return true
+		}
+	}
+	return false
+}
+
+func isSystemSchema(schema string) bool {
+	switch strings.ToLower(schema) {
+	case "mysql", "sys", "information_schema", "metrics_schema", "performance_schema":
+		return true
+	}
+	return false
+}
+
+// prepareIndexUsages loads the usage of all indexes from all TiDB instances.
+func prepareIndexUsages(ctx context.Context, sctx sessionctx.Context) (map[string]IndexUsage, error) {
+	var usageList []IndexUsage
+	if intest.InTest && ctx.Value(TestKey("index_usage")) != nil {
+		usageList = ctx.Value(TestKey("index_usage")).([]IndexUsage)
+	} else {
+		template := `SELECT lower(table_schema), lower(table_name), lower(index_name),
+				cast(sum(query_total) as unsigned), max(last_access_time)
+			FROM information_schema.cluster_tidb_index_usage
+			WHERE lower(table_schema) not in ('sys', 'mysql', 'information_schema', 'metrics_schema', 'performance_schema')
+			GROUP BY table_schema, table_name, index_name`
+		rows, err := exec(sctx, template)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			usage := IndexUsage{
+				SchemaName: r.GetString(0),
+				TableName:  r.GetString(1),
+				IndexName:  r.GetString(2),
+			}
+			if !r.IsNull(3) {
+				usage.QueryTotal = r.GetUint64(3)
+			}
+			if !r.IsNull(4) {
+				usage.LastUsedAt, err = r.GetTime(4).GoTime(sctx.GetSessionVars().Location())
+				if err != nil {
+					return nil, err
+				}
+			}
+			usageList = append(usageList, usage)
+		}
+	}
+
+	usages := make(map[string]IndexUsage, len(usageList))
+	for _, u := range usageList {
+		usages[u.Key()] = u
+	}
+	return usages, nil
+}
+
+// collectReferencedIndexes collects the indexes referenced by global bindings and
+// by hints in recent queries, which are unsafe to drop.
+// The key is "table.index", or ".index" if the table is unknown, e.g. an alias in hints.
+func collectReferencedIndexes(ctx context.Context, sctx sessionctx.Context, option *Option) (s.StringSet, error) {
+	var sqls []string
+	if intest.InTest && ctx.Value(TestKey("referenced_sqls")) != nil {
+		sqls = ctx.Value(TestKey("referenced_sqls")).([]string)
+	} else {
+		rows, err := exec(sctx, `SELECT bind_sql FROM mysql.bind_info WHERE status != 'deleted'`)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			sqls = append(sqls, r.GetString(0))
+		}
+		template := `SELECT DISTINCT query_sample_text
+			FROM information_schema.statements_summary_history
+			WHERE summary_begin_time >= date_sub(now(), interval %? second) AND
+				lower(query_sample_text) like '%%index%%'`
+		rows, err = exec(sctx, template, int64(option.UnusedIndexWindow/time.Second))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			sqls = append(sqls, r.GetString(0))
+		}
+	}
+
+	referenced := s.NewStringSet()
+	for _, sql := range sqls {
+		stmt, err := ParseOneSQL(sql)
+		if err != nil { // ignore invalid SQLs like the pseudo binding for locking
+			continue
+		}
+		visitNode(stmt, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.TableName:
+				for _, hint := range x.IndexHints {
+					for _, idx := range hint.IndexNames {
+						referenced.Insert(x.Name.L + "." + idx.L)
+					}
+				}
+			case *ast.TableOptimizerHint:
+				for _, idx := range x.Indexes {
+					referenced.Insert("." + idx.L)
+				}
+			}
+			return false
+		}, nil)
+	}
+	return referenced, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func checkDropIndexes(ctx context.Context, t *testing.T, tk *testkit.TestKit, expected ...string) {
+	r, err := indexadvisor.AdviseDropIndexes(ctx, tk.Session(), nil)
+	require.NoError(t, err)
+	results := make([]string, 0, len(r))
+	for _, result := range r {
+		results = append(results, fmt.Sprintf("%v.%v.%v(%v): %v", result.Database, result.Table,
+			result.IndexName, strings.Join(result.IndexColumns, ","), result.Reason))
+	}
+	require.Equal(t, strings.Join(expected, "\n"), strings.Join(results, "\n"))
+}
+
+func usageCtx(usages []indexadvisor.IndexUsage, referencedSQLs ...string) context.Context {
+	ctx := context.WithValue(context.Background(), indexadvisor.TestKey("index_usage"), usages)
+	if len(referencedSQLs) > 0 {
+		ctx = context.WithValue(ctx, indexadvisor.TestKey("referenced_sqls"), referencedSQLs)
+	}
+	return ctx
+}
+
+func TestAdviseDropIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int, d int primary key,
+		key ia(a), key iab(a, b), key iab2(a, b), unique key uc(c), key ic(c), key ib(b))`)
+
+	now := time.Now()
+	usages := []indexadvisor.IndexUsage{
+		{SchemaName: "test", TableName: "t", IndexName: "ia", QueryTotal: 10, LastUsedAt: now},
+		{SchemaName: "test", TableName: "t", IndexName: "iab", QueryTotal: 10, LastUsedAt: now},
+		{SchemaName: "test", TableName: "t", IndexName: "iab2", QueryTotal: 10, LastUsedAt: now},
+		{SchemaName: "test", TableName: "t", IndexName: "ic", QueryTotal: 10, LastUsedAt: now},
+	}
+	checkDropIndexes(usageCtx(usages), t, tk,
+		"test.t.ia(a): Index is a prefix of index iab",
+		"test.t.iab2(a,b): Index is a duplicate of index iab",
+		"test.t.ib(b): Index is never used",
+		"test.t.ic(c): Index is a duplicate of index uc")
+
+	// the unused index can't be the covering index of others.
+	usages[1].LastUsedAt = now.Add(-200 * time.Hour)
+	checkDropIndexes(usageCtx(usages), t, tk,
+		"test.t.ia(a): Index is a prefix of index iab2",
+		"test.t.iab(a,b): Index is not used in the last 168h0m0s, last used at "+usages[1].LastUsedAt.Format(time.DateTime),
+		"test.t.ib(b): Index is never used",
+		"test.t.ic(c): Index is a duplicate of index uc")
+
+	tk.MustExec(`recommend index set unused_index_window='300h'`)
+	checkDropIndexes(usageCtx(usages), t, tk,
+		"test.t.ia(a): Index is a prefix of index iab",
+		"test.t.iab2(a,b): Index is a duplicate of index iab",
+		"test.t.ib(b): Index is never used",
+		"test.t.ic(c): Index is a duplicate of index uc")
+}
+
+func TestAdviseDropIndexesSafetyCheck(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int, key ia(a), key ib(b), key ic(c), unique key ua(a))`)
+	tk.MustExec(`create table child (id int primary key, pa int, key ipa(pa), foreign key fk(pa) references t(a))`)
+
+	// indexes referenced by bindings or hints are kept.
+	checkDropIndexes(usageCtx(nil,
+		"select /*+ use_index(t1, ib) */ * from t t1 where b = 1",
+		"select * from t use index(ic) where c = 1"), t, tk,
+		"test.t.ia(a): Index is a duplicate of index ua")
+
+	// ipa is the only index for the foreign key, and one of ipa and ipa2 is kept.
+	tk.MustExec(`create global binding for select * from t where c = 1 using select * from t use index(ic) where c = 1`)
+	checkDropIndexes(usageCtx(nil), t, tk,
+		"test.t.ia(a): Index is a duplicate of index ua",
+		"test.t.ib(b): Index is never used")
+	tk.MustExec(`alter table child add index ipa2(pa, id)`)
+	checkDropIndexes(usageCtx(nil), t, tk,
+		"test.child.ipa(pa): Index is never used",
+		"test.t.ia(a): Index is a duplicate of index ua",
+		"test.t.ib(b): Index is never used")
+}
+
+func TestAdviseDropUnusedCoveredIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int, key ia(a), key iab(a, b), key ic(c), key icb(c, b))`)
+
+	// the unused index covered by a used index is reported as a duplicate or prefix of it,
+	// and the covering index is kept.
+	now := time.Now()
+	usages := []indexadvisor.IndexUsage{
+		{SchemaName: "test", TableName: "t", IndexName: "iab", QueryTotal: 10, LastUsedAt: now},
+	}
+	checkDropIndexes(usageCtx(usages), t, tk,
+		"test.t.ia(a): Index is a prefix of index iab",
+		"test.t.ic(c): Index is never used",
+		"test.t.icb(c,b): Index is never used")
+
+	// the unused index is reported as unused if its covering index is unused too.
+	usages[0].LastUsedAt = now.Add(-200 * time.Hour)
+	checkDropIndexes(usageCtx(usages), t, tk,
+		"test.t.ia(a): Index is never used",
+		"test.t.iab(a,b): Index is not used in the last 168h0m0s, last used at "+usages[0].LastUsedAt.Format(time.DateTime),
+		"test.t.ic(c): Index is never used",
+		"test.t.icb(c,b): Index is never used")
+}
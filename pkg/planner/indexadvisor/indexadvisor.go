@@ -43,6 +43,8 @@ type Option struct {
 	MaxNumQuery   int
 	Timeout       time.Duration
 	SpecifiedSQLs []string
+
+	UnusedIndexWindow time.Duration
 }
 
 // AdviseIndexes is the entry point for the index advisor.
@@ -17,6 +17,7 @@ package indexadvisor_test
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -92,3 +93,44 @@ func TestIndexAdvisorEmptyResult(t *testing.T) {
 	tk.MustQuery(`recommend index run for 'select * from t where a=1 and b=1 and c=1'`).Check(testkit.Rows())
 	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105  Considered 3 indexable columns(test.t.a, test.t.b, test.t.c), 3 or more index candidates(test.t(a), test.t(b), test.t(c)), no sufficiently beneficial indexes were found."))
 }
+
+func TestIndexAdvisorExplainHypoIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int)`)
+	tk.MustExec(`create table t2 (a int)`)
+	tk.MustQuery(`recommend index explain for 'select * from t where a=1'`).Check(testkit.Rows())
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 no hypo index on the tables accessed by this query"))
+
+	tk.MustExec(`create hypo index ha on t (a)`)
+	tk.MustExec(`create hypo index hc on t (c)`)
+	tk.MustExec(`create hypo index h2 on t2 (a)`)
+	rows := tk.MustQuery(`recommend index explain for 'select * from t where a=1'`).Rows()
+	require.Len(t, rows, 2) // h2 is on another table
+	require.Equal(t, []any{"test", "t", "ha", "a"}, rows[0][:4])
+	require.Equal(t, []any{"test", "t", "hc", "c"}, rows[1][:4])
+	require.Equal(t, rows[0][4], rows[1][4]) // the same original cost
+	require.NotEqual(t, "0", rows[0][6])     // ha makes the query cheaper
+	require.Equal(t, "0", rows[1][6])        // hc is useless for this query
+
+	// hypo-indexes are still visible to EXPLAIN after the what-if costing
+	tk.MustQuery(`explain select * from t where a=1`).CheckContain("index:ha(a)")
+	tk.MustExec(`drop hypo index ha on t`)
+	rows = tk.MustQuery(`recommend index explain for 'select * from t where a=1'`).Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "hc", rows[0][2])
+
+	// the unique hypo-index is costed as a unique index
+	tk.MustExec(`drop hypo index hc on t`)
+	tk.MustExec(`create hypo index hb on t (b)`)
+	tk.MustExec(`create unique hypo index hub on t (b)`)
+	rows = tk.MustQuery(`recommend index explain for 'select * from t where b=1'`).Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []any{"hb", "hub"}, []any{rows[0][2], rows[1][2]})
+	hbCost, err := strconv.ParseFloat(rows[0][5].(string), 64)
+	require.NoError(t, err)
+	hubCost, err := strconv.ParseFloat(rows[1][5].(string), 64)
+	require.NoError(t, err)
+	require.Less(t, hubCost, hbCost)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 )
 
 // Query represents a Query statement.
@@ -66,6 +67,7 @@ type Index struct {
 	TableName  string
 	IndexName  string
 	Columns    []Column
+	Unique     bool // only used by the hypo-indexes created by CREATE HYPO INDEX
 }
 
 // NewIndex creates a new index.
@@ -162,3 +164,28 @@ type Recommendation struct {
 	WorkloadImpact     *WorkloadImpact
 	TopImpactedQueries []*ImpactedQuery
 }
+
+// IndexUsage represents the usage of an existing index.
+type IndexUsage struct {
+	SchemaName string
+	TableName  string
+	IndexName  string
+	QueryTotal uint64
+	LastUsedAt time.Time // zero if the index is never used
+}
+
+// Key returns the key of the index usage.
+func (u IndexUsage) Key() string {
+	return fmt.Sprintf("%v.%v.%v", u.SchemaName, u.TableName, u.IndexName)
+}
+
+// DropRecommendation represents an existing index which is recommended to be dropped.
+type DropRecommendation struct {
+	Database       string
+	Table          string
+	IndexName      string
+	IndexColumns   []string
+	Reason         string  // why recommend dropping this index
+	IndexSize      uint64  // byte
+	WriteReduction float64 // the estimated ratio of KV writes on this table saved by dropping this index
+}
@@ -197,6 +197,7 @@ func (opt *optimizerImpl) addHypoIndex(hypoIndexes ...Index) error {
 		idxInfo := &model.IndexInfo{
 			Name:    ast.NewCIStr(h.IndexName),
 			Columns: cols,
+			Unique:  h.Unique,
 			State:   model.StatePublic,
 			Tp:      ast.IndexTypeHypo,
 		}
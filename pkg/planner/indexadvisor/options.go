@@ -35,11 +35,13 @@ const (
 	OptMaxNumQuery = "max_num_query"
 	// OptTimeout is the option name for the timeout of index advisor.
 	OptTimeout = "timeout"
+	// OptUnusedIndexWindow is the option name for the observation window of unused indexes.
+	OptUnusedIndexWindow = "unused_index_window"
 )
 
 var (
 	// AllOptions is the list of all options.
-	AllOptions = []string{OptMaxNumIndex, OptMaxIndexColumns, OptMaxNumQuery, OptTimeout}
+	AllOptions = []string{OptMaxNumIndex, OptMaxIndexColumns, OptMaxNumQuery, OptTimeout, OptUnusedIndexWindow}
 )
 
 func fillOption(sctx sessionctx.Context, opt *Option, userOptions []ast.RecommendIndexOption) error {
@@ -73,6 +75,13 @@ func fillOption(sctx sessionctx.Context, opt *Option, userOptions []ast.Recommen
 		}
 		opt.Timeout = i
 	}
+	if opt.UnusedIndexWindow == 0 {
+		i, err := time.ParseDuration(vals[OptUnusedIndexWindow])
+		if err != nil {
+			return err
+		}
+		opt.UnusedIndexWindow = i
+	}
 	return nil
 }
 
@@ -107,6 +116,15 @@ func optionVal(opt string, val ast.ValueExpr) (string, error) {
 		if d < 0 {
 			return "", errors.Errorf("invalid value %v for %s", d, opt)
 		}
+	case OptUnusedIndexWindow:
+		v = val.GetValue().(string)
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return "", err
+		}
+		if d <= 0 {
+			return "", errors.Errorf("invalid value %v for %s", d, opt)
+		}
 	default:
 		return "", errors.Errorf("unknown option %s", opt)
 	}
@@ -167,6 +185,8 @@ func description(opt string) string {
 		return "The maximum number of queries to recommend indexes."
 	case OptTimeout:
 		return "The timeout of index advisor."
+	case OptUnusedIndexWindow:
+		return "The observation window of unused indexes to recommend dropping."
 	}
 	return ""
 }
@@ -181,6 +201,8 @@ func defaultVal(opt string) string {
 		return "1000"
 	case OptTimeout:
 		return "30s"
+	case OptUnusedIndexWindow:
+		return "168h"
 	}
 	return ""
 }
@@ -0,0 +1,127 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"go.uber.org/zap"
+)
+
+// WhatIfResult represents the what-if cost of a query with a hypo-index.
+type WhatIfResult struct {
+	Database     string
+	Table        string
+	IndexName    string
+	IndexColumns []string
+	OriginalCost float64 // the plan cost without any hypo-index
+	HypoCost     float64 // the plan cost with this hypo-index
+	Improvement  float64
+}
+
+// WhatIfHypoIndexes estimates the plan cost of the query with each session-level hypo-index
+// on the tables it accesses, which are created by CREATE HYPO INDEX.
+func WhatIfHypoIndexes(sctx sessionctx.Context, sql string) ([]*WhatIfResult, error) {
+	if sctx == nil || strings.TrimSpace(sql) == "" {
+		return nil, errors.New("empty SQL")
+	}
+	defaultDB := sctx.GetSessionVars().CurrentDB
+	tableNames, err := CollectTableNamesFromQuery(defaultDB, sql)
+	if err != nil {
+		return nil, err
+	}
+	hypoIndexes := sessionHypoIndexes(sctx, tableNames)
+	if len(hypoIndexes) == 0 {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("no hypo index on the tables accessed by this query"))
+		return nil, nil
+	}
+
+	sctx.GetSessionVars().StmtCtx.InWhatIfHypoIndex = true
+	defer func() {
+		sctx.GetSessionVars().StmtCtx.InWhatIfHypoIndex = false
+	}()
+	opt := NewOptimizer(sctx)
+	originalCost, err := opt.QueryPlanCost(sql)
+	if err != nil {
+		advisorLogger().Info("failed to get query plan cost", zap.Error(err))
+		return nil, err
+	}
+	results := make([]*WhatIfResult, 0, len(hypoIndexes))
+	for _, idx := range hypoIndexes {
+		hypoCost, err := opt.QueryPlanCost(sql, idx)
+		if err != nil {
+			advisorLogger().Info("failed to get query plan cost", zap.Error(err))
+			return nil, err
+		}
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.ColumnName)
+		}
+		improvement := 0.0
+		if originalCost > 0 {
+			improvement = round((originalCost-hypoCost)/originalCost, 6)
+		}
+		results = append(results, &WhatIfResult{
+			Database:     idx.SchemaName,
+			Table:        idx.TableName,
+			IndexName:    idx.IndexName,
+			IndexColumns: cols,
+			OriginalCost: originalCost,
+			HypoCost:     hypoCost,
+			Improvement:  improvement,
+		})
+	}
+	return results, nil
+}
+
+// sessionHypoIndexes returns the session-level hypo-indexes on the specified tables.
+func sessionHypoIndexes(sctx sessionctx.Context, tableNames []string) []Index {
+	hypoIndexes := sctx.GetSessionVars().HypoIndexes
+	if hypoIndexes == nil {
+		return nil
+	}
+	visited := make(map[string]struct{}, len(tableNames))
+	indexes := make([]Index, 0, 4)
+	for _, name := range tableNames {
+		name = strings.ToLower(name)
+		if _, ok := visited[name]; ok {
+			continue
+		}
+		visited[name] = struct{}{}
+		schemaName, tableName, ok := strings.Cut(name, ".")
+		if !ok || hypoIndexes[schemaName] == nil {
+			continue
+		}
+		for _, idxInfo := range hypoIndexes[schemaName][tableName] {
+			cols := make([]string, 0, len(idxInfo.Columns))
+			for _, col := range idxInfo.Columns {
+				cols = append(cols, col.Name.L)
+			}
+			idx := NewIndex(schemaName, tableName, idxInfo.Name.L, cols...)
+			idx.Unique = idxInfo.Unique
+			indexes = append(indexes, idx)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool { // to make the result stable
+		if indexes[i].Key() != indexes[j].Key() {
+			return indexes[i].Key() < indexes[j].Key()
+		}
+		return indexes[i].IndexName < indexes[j].IndexName
+	})
+	return indexes
+}
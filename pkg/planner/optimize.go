@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/topsql"
 	"github.com/pingcap/tidb/pkg/util/tracing"
 	"go.uber.org/zap"
@@ -227,6 +228,10 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node *resolve.NodeW,
 		sessVars.StmtCtx.SetSkipPlanCache("SET_VAR is used in the SQL")
 	}
 
+	if rewritten := core.TryRewriteWithMaterializedView(ctx, sctx, node, is); rewritten != nil {
+		node = rewritten
+	}
+
 	if _, isolationReadContainTiKV := sessVars.IsolationReadEngines[kv.TiKV]; isolationReadContainTiKV {
 		var fp base.Plan
 		if fpv, ok := sctx.Value(core.PointPlanKey).(core.PointPlanVal); ok {
@@ -386,6 +391,40 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx planctx.PlanContext,
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization and creates a Plan for a statement in a
+// trigger body. Like OptimizeForForeignKeyCascade, it doesn't consider plan
+// cache and plan binding, and the plan IDs are not reset since the plan is a
+// part of the running statement. The privileges of the invoker are checked.
+func OptimizeForTrigger(ctx context.Context, sctx planctx.PlanContext, node *resolve.NodeW, is infoschema.InfoSchema) (base.Plan, types.NameSlice, error) {
+	hintProcessor := hint.NewQBHintHandler(sctx.GetSessionVars().StmtCtx)
+	node.Node.Accept(hintProcessor)
+	defer hintProcessor.HandleUnusedViewHints()
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	builder.Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		visitInfo := core.VisitInfo4PrivCheck(ctx, is, node.Node, builder.GetVisitInfo())
+		if err := core.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, visitInfo); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, nil, err
+	}
+	names := p.OutputNames()
+	logic, isLogicalPlan := p.(base.LogicalPlan)
+	if !isLogicalPlan {
+		return p, names, nil
+	}
+	core.RecheckCTE(logic)
+	finalPlan, _, err := core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+	return finalPlan, names, err
+}
+
 func allowInReadOnlyMode(sctx planctx.PlanContext, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -621,6 +660,46 @@ func planDigestFunc(sctx sessionctx.Context, stmt ast.StmtNode) (planDigest stri
 	return digest.String(), nil
 }
 
+func genPlanFunc(sctx sessionctx.Context, stmt ast.StmtNode) (planDigest, plan, planHints string, err error) {
+	ret := &core.PreprocessorReturn{}
+	nodeW := resolve.NewNodeW(stmt)
+	err = core.Preprocess(
+		context.Background(),
+		sctx,
+		nodeW,
+		core.WithPreprocessorReturn(ret),
+		core.InitTxnContextProvider,
+	)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	sessVars := sctx.GetSessionVars()
+	defer func() {
+		// restore the variables modified by the SET_VAR hints, since the statement is not executed.
+		for name, val := range sessVars.StmtCtx.SetVarHintRestore {
+			if err := sessVars.SetSystemVar(name, val); err != nil {
+				logutil.BgLogger().Warn("failed to restore the variable after SET_VAR hint",
+					zap.String("variable name", name), zap.String("expected value", val))
+			}
+		}
+		sessVars.StmtCtx.SetVarHintRestore = nil
+	}()
+
+	p, _, err := Optimize(context.Background(), sctx, nodeW, sctx.GetDomainInfoSchema().(infoschema.InfoSchema))
+	if err != nil {
+		return "", "", "", err
+	}
+	flat := core.FlattenPhysicalPlan(p, false)
+	_, digest := core.NormalizeFlatPlan(flat)
+	plan, err = plancodec.DecodePlan(core.EncodeFlatPlan(flat))
+	if err != nil {
+		return "", "", "", err
+	}
+	planHints = hint.RestoreOptimizerHints(core.GenHintsFromFlatPlan(flat))
+	return digest.String(), plan, planHints, nil
+}
+
 func init() {
 	core.OptimizeAstNode = Optimize
 	core.IsReadOnly = IsReadOnly
@@ -629,4 +708,5 @@ func init() {
 		return domain.GetDomain(sctx).BindingHandle()
 	}
 	bindinfo.PlanDigestFunc = planDigestFunc
+	bindinfo.GenPlanFunc = genPlanFunc
 }
@@ -55,6 +55,10 @@ type Manager interface {
 	// this means any privilege would be OK.
 	RequestVerification(activeRole []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool
 
+	// RequestRoutineVerification verifies user privilege on a stored routine, the privileges
+	// in global and db scope are checked as well. routineType is PROCEDURE or FUNCTION.
+	RequestRoutineVerification(activeRole []*auth.RoleIdentity, db, routine, routineType string, priv mysql.PrivilegeType) bool
+
 	// RequestVerificationWithUser verifies specific user privilege for the request.
 	RequestVerificationWithUser(ctx context.Context, db, table, column string, priv mysql.PrivilegeType, user *auth.UserIdentity) bool
 
@@ -54,6 +54,7 @@ var (
 	userTablePrivilegeMask = computePrivMask(mysql.AllGlobalPrivs)
 	dbTablePrivilegeMask   = computePrivMask(mysql.AllDBPrivs)
 	tablePrivMask          = computePrivMask(mysql.AllTablePrivs)
+	routinePrivMask        = computePrivMask(mysql.AllRoutinePrivs)
 )
 
 const globalDBVisible = mysql.CreatePriv | mysql.SelectPriv | mysql.InsertPriv | mysql.UpdatePriv | mysql.DeletePriv | mysql.ShowDBPriv | mysql.DropPriv | mysql.AlterPriv | mysql.IndexPriv | mysql.CreateViewPriv | mysql.ShowViewPriv | mysql.GrantPriv | mysql.TriggerPriv | mysql.ReferencesPriv | mysql.ExecutePriv | mysql.CreateTMPTablePriv
@@ -65,6 +66,7 @@ const (
 	sqlLoadTablePrivTable   = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Grantor,Timestamp,Table_priv,Column_priv FROM mysql.tables_priv"
 	sqlLoadColumnsPrivTable = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Column_name,Timestamp,Column_priv FROM mysql.columns_priv"
 	sqlLoadDefaultRoles     = "SELECT HIGH_PRIORITY HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER FROM mysql.default_roles"
+	sqlLoadProcsPrivTable   = "SELECT HIGH_PRIORITY Host,DB,User,Routine_name,Routine_type,Proc_priv FROM mysql.procs_priv"
 	// list of privileges from mysql.Priv2UserCol
 	sqlLoadUserTable = `SELECT HIGH_PRIORITY Host,User,authentication_string,
 	Create_priv, Select_priv, Insert_priv, Update_priv, Delete_priv, Show_db_priv, Super_priv,
@@ -231,6 +233,15 @@ type tablesPrivRecord struct {
 	ColumnPriv mysql.PrivilegeType
 }
 
+type procsPrivRecord struct {
+	baseRecord
+
+	DB          string
+	RoutineName string
+	RoutineType string
+	ProcPriv    mysql.PrivilegeType
+}
+
 type columnsPrivRecord struct {
 	baseRecord
 
@@ -298,6 +309,15 @@ func compareItemTablesPriv(a, b itemTablesPriv) bool {
 	return a.username < b.username
 }
 
+type itemProcsPriv struct {
+	username string
+	data     []procsPrivRecord
+}
+
+func compareItemProcsPriv(a, b itemProcsPriv) bool {
+	return a.username < b.username
+}
+
 type itemColumnsPriv struct {
 	username string
 	data     []columnsPrivRecord
@@ -364,6 +384,7 @@ type MySQLPrivilege struct {
 	db           bTree[itemDB]
 	tablesPriv   bTree[itemTablesPriv]
 	columnsPriv  bTree[itemColumnsPriv]
+	procsPriv    bTree[itemProcsPriv]
 	defaultRoles bTree[itemDefaultRole]
 
 	globalPriv  bTree[itemGlobalPriv]
@@ -377,6 +398,7 @@ func newMySQLPrivilege() *MySQLPrivilege {
 	p.db = bTree[itemDB]{BTreeG: btree.NewG(8, compareItemDB)}
 	p.tablesPriv = bTree[itemTablesPriv]{BTreeG: btree.NewG(8, compareItemTablesPriv)}
 	p.columnsPriv = bTree[itemColumnsPriv]{BTreeG: btree.NewG(8, compareItemColumnsPriv)}
+	p.procsPriv = bTree[itemProcsPriv]{BTreeG: btree.NewG(8, compareItemProcsPriv)}
 	p.defaultRoles = bTree[itemDefaultRole]{BTreeG: btree.NewG(8, compareItemDefaultRole)}
 	p.globalPriv = bTree[itemGlobalPriv]{BTreeG: btree.NewG(8, compareItemGlobalPriv)}
 	p.dynamicPriv = bTree[itemDynamicPriv]{BTreeG: btree.NewG(8, compareItemDynamicPriv)}
@@ -498,6 +520,15 @@ func (p *MySQLPrivilege) LoadAll(ctx sqlexec.SQLExecutor) error {
 		logutil.BgLogger().Warn("mysql.columns_priv missing")
 	}
 
+	err = p.LoadProcsPrivTable(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.procs_priv", zap.Error(err))
+			return errLoadPrivilege.FastGen("mysql.procs_priv")
+		}
+		logutil.BgLogger().Warn("mysql.procs_priv missing")
+	}
+
 	err = p.LoadRoleGraph(ctx)
 	if err != nil {
 		if !noSuchTable(err) {
@@ -574,6 +605,12 @@ func (p *MySQLPrivilege) loadSomeUsers(ctx sqlexec.SQLExecutor, userList map[str
 		return errors.Trace(err)
 	}
 
+	// mysql.procs_priv may not exist before the bootstrap upgrade is finished.
+	err = loadTable(ctx, addUserFilterCondition(sqlLoadProcsPrivTable, userList), p.decodeProcsPrivTableRow(userList))
+	if err != nil && !noSuchTable(err) {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 
@@ -628,6 +665,18 @@ func (p *MySQLPrivilege) merge(diff *MySQLPrivilege, userList map[string]struct{
 	}
 	ret.columnsPriv.BTreeG = columnsPriv
 
+	procsPriv := p.procsPriv.Clone()
+	for u := range userList {
+		itm, ok := diff.procsPriv.Get(itemProcsPriv{username: u})
+		if !ok {
+			procsPriv.Delete(itemProcsPriv{username: u})
+		} else {
+			slices.SortFunc(itm.data, compareProcsPrivRecord)
+			procsPriv.ReplaceOrInsert(itm)
+		}
+	}
+	ret.procsPriv.BTreeG = procsPriv
+
 	defaultRoles := p.defaultRoles.Clone()
 	for u := range userList {
 		itm, ok := diff.defaultRoles.Get(itemDefaultRole{username: u})
@@ -879,6 +928,30 @@ func (p *MySQLPrivilege) LoadColumnsPrivTable(exec sqlexec.SQLExecutor) error {
 	return loadTable(exec, sqlLoadColumnsPrivTable, p.decodeColumnsPrivTableRow(nil))
 }
 
+func compareProcsPrivRecord(x, y procsPrivRecord) int {
+	ret := compareBaseRecord(&x.baseRecord, &y.baseRecord)
+	if ret != 0 {
+		return ret
+	}
+
+	ret = strings.Compare(x.DB, y.DB)
+	if ret != 0 {
+		return ret
+	}
+
+	ret = strings.Compare(x.RoutineName, y.RoutineName)
+	if ret != 0 {
+		return ret
+	}
+
+	return strings.Compare(x.RoutineType, y.RoutineType)
+}
+
+// LoadProcsPrivTable loads the mysql.procs_priv table from database.
+func (p *MySQLPrivilege) LoadProcsPrivTable(exec sqlexec.SQLExecutor) error {
+	return loadTable(exec, sqlLoadProcsPrivTable, p.decodeProcsPrivTableRow(nil))
+}
+
 // LoadDefaultRoles loads the mysql.columns_priv table from database.
 func (p *MySQLPrivilege) LoadDefaultRoles(exec sqlexec.SQLExecutor) error {
 	return loadTable(exec, sqlLoadDefaultRoles, p.decodeDefaultRoleTableRow(nil))
@@ -1333,6 +1406,39 @@ func (p *MySQLPrivilege) decodeColumnsPrivTableRow(userList map[string]struct{})
 	}
 }
 
+func (p *MySQLPrivilege) decodeProcsPrivTableRow(userList map[string]struct{}) func(chunk.Row, []*resolve.ResultField) error {
+	return func(row chunk.Row, fs []*resolve.ResultField) error {
+		var value procsPrivRecord
+		for i, f := range fs {
+			switch f.ColumnAsName.L {
+			case "db":
+				value.DB = strings.Clone(row.GetString(i))
+			case "routine_name":
+				value.RoutineName = strings.Clone(row.GetString(i))
+			case "routine_type":
+				value.RoutineType = strings.Clone(row.GetEnum(i).String())
+			case "proc_priv":
+				value.ProcPriv = decodeSetToPrivilege(row.GetSet(i))
+			default:
+				value.assignUserOrHost(row, i, f)
+			}
+		}
+		if userList != nil {
+			if _, ok := userList[value.User]; !ok {
+				return nil
+			}
+		}
+
+		old, ok := p.procsPriv.Get(itemProcsPriv{username: value.User})
+		if !ok {
+			old.username = value.User
+		}
+		old.data = append(old.data, value)
+		p.procsPriv.ReplaceOrInsert(old)
+		return nil
+	}
+}
+
 func decodeSetToPrivilege(s types.Set) mysql.PrivilegeType {
 	var ret mysql.PrivilegeType
 	if s.Name == "" {
@@ -1388,6 +1494,13 @@ func (record *tablesPrivRecord) match(user, host, db, table string) bool {
 		strings.EqualFold(record.TableName, table)
 }
 
+func (record *procsPrivRecord) match(user, host, db, routine, routineType string) bool {
+	return record.baseRecord.match(user, host) &&
+		strings.EqualFold(record.DB, db) &&
+		strings.EqualFold(record.RoutineName, routine) &&
+		strings.EqualFold(record.RoutineType, routineType)
+}
+
 func (record *columnsPrivRecord) match(user, host, db, table, col string) bool {
 	return record.baseRecord.match(user, host) &&
 		strings.EqualFold(record.DB, db) &&
@@ -1527,6 +1640,19 @@ func (p *MySQLPrivilege) matchColumns(user, host, db, table, column string) *col
 	return nil
 }
 
+func (p *MySQLPrivilege) matchProcs(user, host, db, routine, routineType string) *procsPrivRecord {
+	item, exists := p.procsPriv.Get(itemProcsPriv{username: user})
+	if exists {
+		for i := 0; i < len(item.data); i++ {
+			record := &item.data[i]
+			if record.match(user, host, db, routine, routineType) {
+				return record
+			}
+		}
+	}
+	return nil
+}
+
 // HasExplicitlyGrantedDynamicPrivilege checks if a user has a DYNAMIC privilege
 // without accepting SUPER privilege as a fallback.
 func (p *MySQLPrivilege) HasExplicitlyGrantedDynamicPrivilege(activeRoles []*auth.RoleIdentity, user, host, privName string, withGrant bool) bool {
@@ -1634,6 +1760,24 @@ func (p *MySQLPrivilege) RequestVerification(activeRoles []*auth.RoleIdentity, u
 	return priv == 0
 }
 
+// RequestRoutineVerification checks whether the user have sufficient privileges on the stored
+// routine, the privileges in global and db scope are checked before the ones of the routine.
+func (p *MySQLPrivilege) RequestRoutineVerification(activeRoles []*auth.RoleIdentity, user, host, db, routine, routineType string, priv mysql.PrivilegeType) bool {
+	if p.RequestVerification(activeRoles, user, host, db, "", "", priv) {
+		return true
+	}
+
+	roleList := p.FindAllUserEffectiveRoles(user, host, activeRoles)
+	roleList = append(roleList, &auth.RoleIdentity{Username: user, Hostname: host})
+	var procPriv mysql.PrivilegeType
+	for _, r := range roleList {
+		if record := p.matchProcs(r.Username, r.Hostname, db, routine, routineType); record != nil {
+			procPriv |= record.ProcPriv
+		}
+	}
+	return procPriv&priv > 0
+}
+
 // DBIsVisible checks whether the user can see the db.
 func (p *MySQLPrivilege) DBIsVisible(user, host, db string) bool {
 	if record := p.matchUser(user, host); record != nil {
@@ -1815,6 +1959,40 @@ func (p *MySQLPrivilege) showGrants(ctx sessionctx.Context, user, host string, r
 	}
 	slices.Sort(gs[sortFromIdx:])
 
+	// Show stored routine scope grants.
+	sortFromIdx = len(gs)
+	routinePrivTable := make(map[string]mysql.PrivilegeType)
+	p.procsPriv.Ascend(func(itm itemProcsPriv) bool {
+		for _, record := range itm.data {
+			recordKey := record.RoutineType + " " + stringutil.Escape(record.DB, sqlMode) + "." + stringutil.Escape(record.RoutineName, sqlMode)
+			if user == record.User && host == record.Host {
+				routinePrivTable[recordKey] |= record.ProcPriv
+			} else {
+				for _, r := range allRoles {
+					if record.baseRecord.match(r.Username, r.Hostname) {
+						routinePrivTable[recordKey] |= record.ProcPriv
+					}
+				}
+			}
+		}
+		return true
+	})
+	for k, priv := range routinePrivTable {
+		g := routinePrivToString(priv)
+		if len(g) == 0 {
+			if (priv & mysql.GrantPriv) == 0 {
+				continue
+			}
+			g = "USAGE"
+		}
+		if (priv & mysql.GrantPriv) > 0 {
+			gs = append(gs, fmt.Sprintf(`GRANT %s ON %s TO '%s'@'%s' WITH GRANT OPTION`, g, k, user, host))
+		} else {
+			gs = append(gs, fmt.Sprintf(`GRANT %s ON %s TO '%s'@'%s'`, g, k, user, host))
+		}
+	}
+	slices.Sort(gs[sortFromIdx:])
+
 	// Show column scope grants, column and table are combined.
 	// A map of "DB.Table" => Priv(col1, col2 ...)
 	sortFromIdx = len(gs)
@@ -1983,6 +2161,13 @@ func tablePrivToString(privs mysql.PrivilegeType) string {
 	return PrivToString(privs, mysql.AllTablePrivs, mysql.Priv2Str)
 }
 
+func routinePrivToString(privs mysql.PrivilegeType) string {
+	if (privs & ^mysql.GrantPriv) == routinePrivMask {
+		return mysql.AllPrivilegeLiteral
+	}
+	return PrivToString(privs, mysql.AllRoutinePrivs, mysql.Priv2Str)
+}
+
 // PrivToString converts the privileges to string.
 func PrivToString(priv mysql.PrivilegeType, allPrivs []mysql.PrivilegeType, allPrivNames map[mysql.PrivilegeType]string) string {
 	pstrs := make([]string, 0, 20)
@@ -208,6 +208,20 @@ func (p *UserPrivileges) RequestVerification(activeRoles []*auth.RoleIdentity, d
 	return p.authPluginRequestVerification == nil || p.authPluginRequestVerification(p.user, p.host, activeRoles, db, table, column, priv)
 }
 
+// RequestRoutineVerification implements the Manager interface.
+func (p *UserPrivileges) RequestRoutineVerification(activeRoles []*auth.RoleIdentity, db, routine, routineType string, priv mysql.PrivilegeType) bool {
+	if SkipWithGrant {
+		return true
+	}
+
+	if p.user == "" && p.host == "" {
+		return true
+	}
+
+	mysqlPriv := p.Handle.Get()
+	return mysqlPriv.RequestRoutineVerification(activeRoles, p.user, p.host, db, routine, routineType, priv)
+}
+
 // RequestVerificationWithUser implements the Manager interface.
 func (p *UserPrivileges) RequestVerificationWithUser(ctx context.Context, db, table, column string, priv mysql.PrivilegeType, user *auth.UserIdentity) bool {
 	if SkipWithGrant {
@@ -181,6 +181,18 @@ const (
 		Column_priv	SET('Select','Insert','Update','References'),
 		PRIMARY KEY (Host, DB, User, Table_name),
 		KEY i_user (User));`
+	// CreateProcsPrivTable is the SQL statement creates stored routine scope privilege table in system db.
+	CreateProcsPrivTable = `CREATE TABLE IF NOT EXISTS mysql.procs_priv (
+		Host			CHAR(255),
+		DB				CHAR(64) CHARSET utf8mb4 COLLATE utf8mb4_general_ci,
+		User			CHAR(32),
+		Routine_name	CHAR(64) CHARSET utf8mb4 COLLATE utf8mb4_general_ci,
+		Routine_type	ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		Grantor			CHAR(77),
+		Proc_priv		SET('Execute','Alter Routine','Grant'),
+		Timestamp		TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (Host, DB, User, Routine_name, Routine_type),
+		KEY i_user (User));`
 	// CreateColumnPrivTable is the SQL statement creates column scope privilege table in system db.
 	CreateColumnPrivTable = `CREATE TABLE IF NOT EXISTS mysql.columns_priv(
 		Host		CHAR(255),
@@ -788,6 +800,31 @@ const (
 		value json NOT NULL,
 		index idx_version_category_type (version, category, type),
 		index idx_table_id (table_id));`
+
+	// CreateEventHistory is a table to store the execution history of the scheduled events.
+	CreateEventHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_event_history (
+		job_id varchar(64) PRIMARY KEY,
+		event_id bigint(64) NOT NULL,
+		event_schema varchar(64) NOT NULL,
+		event_name varchar(64) NOT NULL,
+		scheduled_time timestamp NOT NULL,
+		start_time timestamp NOT NULL,
+		finish_time timestamp NULL DEFAULT NULL,
+		status varchar(64) NOT NULL,
+		error_message text,
+		key(event_id, start_time),
+		key(event_schema, event_name, start_time),
+		key(start_time)
+	);`
+
+	// CreateMViewLog is a table to log the groups of the materialized views
+	// changed by the DML on their base tables, it's consumed by the incremental
+	// refresh.
+	CreateMViewLog = `CREATE TABLE IF NOT EXISTS mysql.tidb_mview_log (
+		mview_id bigint(64) NOT NULL,
+		group_key blob NOT NULL,
+		key idx_mview_id (mview_id)
+	);`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1284,11 +1321,23 @@ const (
 	// version 247
 	// Add last_stats_histograms_version to mysql.stats_meta.
 	version247 = 247
+
+	// version 248
+	// Add mysql.tidb_event_history to store the execution history of the scheduled events.
+	version248 = 248
+
+	// version 249
+	// Add mysql.tidb_mview_log to log the changed groups of the materialized views.
+	version249 = 249
+
+	// version 250
+	// Add mysql.procs_priv to store the privileges of the stored routines.
+	version250 = 250
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version247
+var currentBootstrapVersion int64 = version250
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1471,6 +1520,9 @@ var (
 		upgradeToVer245,
 		upgradeToVer246,
 		upgradeToVer247,
+		upgradeToVer248,
+		upgradeToVer249,
+		upgradeToVer250,
 	}
 )
 
@@ -3477,6 +3529,27 @@ func upgradeToVer247(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.stats_meta ADD COLUMN last_stats_histograms_version bigint unsigned DEFAULT NULL", infoschema.ErrColumnExists)
 }
 
+func upgradeToVer248(s sessiontypes.Session, ver int64) {
+	if ver >= version248 {
+		return
+	}
+	mustExecute(s, CreateEventHistory)
+}
+
+func upgradeToVer249(s sessiontypes.Session, ver int64) {
+	if ver >= version249 {
+		return
+	}
+	mustExecute(s, CreateMViewLog)
+}
+
+func upgradeToVer250(s sessiontypes.Session, ver int64) {
+	if ver >= version250 {
+		return
+	}
+	mustExecute(s, CreateProcsPrivTable)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3633,6 +3706,12 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateKernelOptionsTable)
 	// create mysql.tidb_workload_values
 	mustExecute(s, CreateTiDBWorkloadValuesTable)
+	// create mysql.tidb_event_history
+	mustExecute(s, CreateEventHistory)
+	// create mysql.tidb_mview_log
+	mustExecute(s, CreateMViewLog)
+	// create mysql.procs_priv
+	mustExecute(s, CreateProcsPrivTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -239,6 +239,12 @@ func TestBootstrapWithError(t *testing.T) {
 	MustExec(t, se, "SELECT * from mysql.tidb_ttl_table_status")
 	// Check mysql.tidb_workload_values table
 	MustExec(t, se, "SELECT * from mysql.tidb_workload_values")
+	// Check mysql.tidb_event_history table
+	MustExec(t, se, "SELECT * from mysql.tidb_event_history")
+	// Check mysql.tidb_mview_log table
+	MustExec(t, se, "SELECT * from mysql.tidb_mview_log")
+	// Check mysql.procs_priv table
+	MustExec(t, se, "SELECT * from mysql.procs_priv")
 }
 
 func TestDDLTableCreateBackfillTable(t *testing.T) {
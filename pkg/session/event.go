@@ -0,0 +1,61 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+)
+
+// eventRunner runs the body of the scheduled events in new sessions, it
+// implements the eventscheduler.Runner interface.
+type eventRunner struct {
+	store kv.Storage
+}
+
+// RunEvent implements the eventscheduler.Runner interface.
+func (r *eventRunner) RunEvent(ctx context.Context, schema string, event *model.EventInfo) error {
+	se, err := CreateSession(r.store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+
+	// The body is executed with the privileges of the definer.
+	if definer := event.Definer; definer != nil {
+		user := &auth.UserIdentity{Username: definer.AuthUsername, Hostname: definer.AuthHostname}
+		if user.Username == "" && user.Hostname == "" {
+			user.Username, user.Hostname = definer.Username, definer.Hostname
+		}
+		if !se.AuthWithoutVerification(ctx, user) {
+			return errors.Errorf("the definer %s of the event does not exist", user)
+		}
+	}
+	vars := se.GetSessionVars()
+	vars.CurrentDB = schema
+	if err := vars.SetSystemVar(vardef.TimeZone, event.TimeZone); err != nil {
+		return err
+	}
+	if err := vars.SetSystemVar(vardef.SQLModeVar, event.SQLMode.String()); err != nil {
+		return err
+	}
+	return executor.ExecuteEvent(ctx, se, se, event)
+}
@@ -2037,6 +2037,11 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
 		return nil, err
 	}
+	if call, ok := stmtNode.(*ast.CallStmt); ok {
+		// The stored procedure is interpreted outside of the planner, the
+		// statements in its body are executed by this session one by one.
+		return executor.CallProcedure(ctx, s, s, call)
+	}
 	if execStmt, ok := stmtNode.(*ast.ExecuteStmt); ok {
 		if binParam, ok := execStmt.BinaryArgs.([]param.BinaryParam); ok {
 			args, err := expression.ExecBinaryParam(s.GetSessionVars().StmtCtx.TypeCtx(), binParam)
@@ -3655,6 +3660,7 @@ func bootstrapSessionImpl(ctx context.Context, store kv.Storage, createSessionsI
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(&eventRunner{store: store})
 
 	dom.LoadSigningCertLoop(cfg.Security.SessionTokenSigningCert, cfg.Security.SessionTokenSigningKey)
 
@@ -4114,7 +4120,7 @@ func logStmt(execStmt *executor.ExecStmt, s *session) {
 	if isCrucial {
 		user := vars.User
 		schemaVersion := s.GetInfoSchema().SchemaMetaVersion()
-		if ss, ok := execStmt.StmtNode.(ast.SensitiveStmtNode); ok {
+		if ss, ok := ast.AsSensitiveStmtNode(execStmt.StmtNode); ok {
 			logutil.BgLogger().Info("CRUCIAL OPERATION",
 				zap.Uint64("conn", vars.ConnectionID),
 				zap.Int64("schemaVersion", schemaVersion),
@@ -259,6 +259,9 @@ type StatementContext struct {
 	InSetSessionStatesStmt bool
 	InPreparedPlanBuilding bool
 	InShowWarning          bool
+	// InWhatIfHypoIndex is set when RECOMMEND INDEX EXPLAIN costs the query with the hypo-indexes,
+	// the row counts of the hypo-indexes are estimated from the stats of the indexed columns then.
+	InWhatIfHypoIndex bool
 
 	contextutil.PlanCacheTracker
 	contextutil.RangeFallbackHandler
@@ -116,6 +116,8 @@ const (
 	ErrorCount = "error_count"
 	// DefaultPasswordLifetime is the name for 'default_password_lifetime' system variable.
 	DefaultPasswordLifetime = "default_password_lifetime"
+	// EventScheduler is the name for 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// DisconnectOnExpiredPassword is the name for 'disconnect_on_expired_password' system variable.
 	DisconnectOnExpiredPassword = "disconnect_on_expired_password"
 	// SQLSelectLimit is the name for 'sql_select_limit' system variable.
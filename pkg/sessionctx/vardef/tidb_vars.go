@@ -477,6 +477,11 @@ const (
 	// TiDBEnableCascadesPlanner is used to control whether to enable the cascades planner.
 	TiDBEnableCascadesPlanner = "tidb_enable_cascades_planner"
 
+	// TiDBOptCascadesMaxAlternatives limits the number of logical alternatives in memo costed one by one by the
+	// cascades planner, which only happens when the memo can't be implemented group by group, e.g. the nth_plan hint
+	// is used. 0 means no limit.
+	TiDBOptCascadesMaxAlternatives = "tidb_opt_cascades_max_alternatives"
+
 	// TiDBSkipUTF8Check skips the UTF8 validate process, validate UTF8 has performance cost, if we can make sure
 	// the input string values are valid, we can skip the check.
 	TiDBSkipUTF8Check = "tidb_skip_utf8_check"
@@ -1020,6 +1025,13 @@ const (
 
 	// TiDBAccelerateUserCreationUpdate decides whether tidb will load & update the whole user's data in-memory.
 	TiDBAccelerateUserCreationUpdate = "tidb_accelerate_user_creation_update"
+
+	// TiDBEnableMaterializedViewRewrite indicates whether the optimizer rewrites the aggregate queries to read
+	// the materialized views.
+	TiDBEnableMaterializedViewRewrite = "tidb_enable_materialized_view_rewrite"
+	// TiDBMaterializedViewMaxStaleness is the max seconds since the last refresh of a materialized view which can
+	// be used to rewrite the queries.
+	TiDBMaterializedViewMaxStaleness = "tidb_materialized_view_max_staleness"
 )
 
 // TiDB vars that have only global scope
@@ -1417,6 +1429,7 @@ const (
 	DefEnableStrictDoubleTypeCheck          = true
 	DefEnableVectorizedExpression           = true
 	DefTiDBOptJoinReorderThreshold          = 0
+	DefTiDBOptCascadesMaxAlternatives       = 64
 	DefTiDBDDLSlowOprThreshold              = 300
 	DefTiDBUseFastAnalyze                   = false
 	DefTiDBSkipIsolationLevelCheck          = false
@@ -1434,6 +1447,7 @@ const (
 	DefTiDBEvolvePlanTaskStartTime          = "00:00 +0000"
 	DefTiDBEvolvePlanTaskEndTime            = "23:59 +0000"
 	DefInnodbLockWaitTimeout                = 50 // 50s
+	DefEventScheduler                       = true
 	DefTiDBStoreLimit                       = 0
 	DefTiDBMetricSchemaStep                 = 60 // 60s
 	DefTiDBMetricSchemaRangeDuration        = 60 // 60s
@@ -1653,6 +1667,8 @@ const (
 	DefTiDBTSOClientRPCMode                           = TSOClientRPCModeDefault
 	DefTiDBCircuitBreakerPDMetaErrorRatePct           = 0
 	DefTiDBAccelerateUserCreationUpdate               = false
+	DefTiDBEnableMaterializedViewRewrite              = false
+	DefTiDBMaterializedViewMaxStaleness               = 300
 )
 
 // Process global variables.
@@ -1739,10 +1755,12 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
-	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
-	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
-	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
-	TTLJobScheduleWindowStartTime      = atomic.NewTime(
+	// EnableEventScheduler indicates whether the scheduled events are executed.
+	EnableEventScheduler          = atomic.NewBool(DefEventScheduler)
+	TTLScanBatchSize              = atomic.NewInt64(DefTiDBTTLScanBatchSize)
+	TTLDeleteBatchSize            = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
+	TTLDeleteRateLimit            = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
+	TTLJobScheduleWindowStartTime = atomic.NewTime(
 		mustParseTime(
 			FullDayTimeFormat,
 			DefTiDBTTLJobScheduleWindowStartTime,
@@ -337,7 +337,6 @@ var noopSysVars = []*SysVar{
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: "ndb_force_send", Value: ""},
 	{Scope: vardef.ScopeNone, Name: "skip_show_database", Value: "0"},
 	{Scope: vardef.ScopeGlobal, Name: "log_timestamps", Value: ""},
-	{Scope: vardef.ScopeGlobal, Name: "event_scheduler", Value: vardef.Off},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: "ndb_deferred_constraints", Value: ""},
 	{Scope: vardef.ScopeGlobal, Name: "log_syslog_include_pid", Value: ""},
 	{Scope: vardef.ScopeNone, Name: "innodb_ft_cache_size", Value: "8000000"},
@@ -1118,6 +1118,10 @@ type SessionVars struct {
 	// EnableCascadesPlanner enables the cascades planner.
 	EnableCascadesPlanner bool
 
+	// CascadesMaxAlternatives limits the number of logical alternatives costed one by one by the cascades planner,
+	// 0 means no limit.
+	CascadesMaxAlternatives int
+
 	// EnableWindowFunction enables the window function.
 	EnableWindowFunction bool
 
@@ -1615,6 +1619,12 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMViewRewrite indicates whether the aggregate queries are rewritten to read the materialized views.
+	EnableMViewRewrite bool
+
+	// MViewMaxStaleness is the max duration since the last refresh of a materialized view used by the rewrite.
+	MViewMaxStaleness time.Duration
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -2187,6 +2197,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		EnableVectorizedExpression:    vardef.DefEnableVectorizedExpression,
 		CommandValue:                  uint32(mysql.ComSleep),
 		TiDBOptJoinReorderThreshold:   vardef.DefTiDBOptJoinReorderThreshold,
+		CascadesMaxAlternatives:       vardef.DefTiDBOptCascadesMaxAlternatives,
 		SlowQueryFile:                 config.GetGlobalConfig().Log.SlowQueryFile,
 		WaitSplitRegionFinish:         vardef.DefTiDBWaitSplitRegionFinish,
 		WaitSplitRegionTimeout:        vardef.DefWaitSplitRegionTimeout,
@@ -2241,6 +2252,8 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		mppExchangeCompressionMode:    vardef.DefaultExchangeCompressionMode,
 		mppVersion:                    kv.MppVersionUnspecified,
 		EnableLateMaterialization:     vardef.DefTiDBOptEnableLateMaterialization,
+		EnableMViewRewrite:            vardef.DefTiDBEnableMaterializedViewRewrite,
+		MViewMaxStaleness:             vardef.DefTiDBMaterializedViewMaxStaleness * time.Second,
 		TiFlashComputeDispatchPolicy:  tiflashcompute.DispatchPolicyConsistentHash,
 		ResourceGroupName:             resourcegroup.DefaultResourceGroupName,
 		DefaultCollationForUTF8MB4:    mysql.DefaultCollationName,
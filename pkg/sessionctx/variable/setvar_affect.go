@@ -86,6 +86,7 @@ var isHintUpdatableVerified = map[string]struct{}{
 	"tidb_min_paging_size":                            {},
 	"tidb_max_paging_size":                            {},
 	"tidb_enable_cascades_planner":                    {},
+	"tidb_opt_cascades_max_alternatives":              {},
 	"tidb_merge_join_concurrency":                     {},
 	"tidb_index_merge_intersection_concurrency":       {},
 	"tidb_opt_projection_push_down":                   {},
@@ -267,6 +267,14 @@ func TestVarsutil(t *testing.T) {
 	require.Equal(t, "5", val)
 	require.Equal(t, 5, v.TiDBOptJoinReorderThreshold)
 
+	require.Equal(t, vardef.DefTiDBOptCascadesMaxAlternatives, v.CascadesMaxAlternatives)
+	err = v.SetSystemVar(vardef.TiDBOptCascadesMaxAlternatives, "0")
+	require.NoError(t, err)
+	val, err = v.GetSessionOrGlobalSystemVar(context.Background(), vardef.TiDBOptCascadesMaxAlternatives)
+	require.NoError(t, err)
+	require.Equal(t, "0", val)
+	require.Equal(t, 0, v.CascadesMaxAlternatives)
+
 	err = v.SetSystemVar(vardef.TiDBLowResolutionTSO, "1")
 	require.NoError(t, err)
 	val, err = v.GetSessionOrGlobalSystemVar(context.Background(), vardef.TiDBLowResolutionTSO)
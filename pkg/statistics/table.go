@@ -1082,5 +1082,5 @@ var PrepareCols4MVIndex func(
 	tableInfo *model.TableInfo,
 	mvIndex *model.IndexInfo,
 	tblCols []*expression.Column,
-	checkOnly1ArrayTypeCol bool,
+	checkArrayTypeCol bool,
 ) (idxCols []*expression.Column, ok bool)
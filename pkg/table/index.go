@@ -120,6 +120,8 @@ type IndexKVGenerator struct {
 	i          int
 	// Only used by non multi-value index.
 	idxVals []types.Datum
+	// err is returned by Next if the index values can't be generated.
+	err error
 }
 
 // NewMultiValueIndexKVGenerator creates a new IndexKVGenerator for multi-value indexes.
@@ -143,6 +145,12 @@ func NewMultiValueIndexKVGenerator(
 	}
 }
 
+// NewErrIndexKVGenerator creates a new IndexKVGenerator which returns the error
+// when generating the index kv.
+func NewErrIndexKVGenerator(err error) IndexKVGenerator {
+	return IndexKVGenerator{err: err}
+}
+
 // NewPlainIndexKVGenerator creates a new IndexKVGenerator for non multi-value indexes.
 func NewPlainIndexKVGenerator(
 	index Index,
@@ -166,6 +174,10 @@ func NewPlainIndexKVGenerator(
 // Next returns the next index key and value.
 // For non multi-value indexes, there is only one index kv.
 func (iter *IndexKVGenerator) Next(keyBuf, valBuf []byte) ([]byte, []byte, bool, error) {
+	if iter.err != nil {
+		iter.i++
+		return nil, nil, false, iter.err
+	}
 	var val []types.Datum
 	if iter.isMultiValue {
 		val = iter.allIdxVals[iter.i]
@@ -186,7 +198,7 @@ func (iter *IndexKVGenerator) Next(keyBuf, valBuf []byte) ([]byte, []byte, bool,
 
 // Valid returns true if the generator is not exhausted.
 func (iter *IndexKVGenerator) Valid() bool {
-	if iter.isMultiValue {
+	if iter.isMultiValue && iter.err == nil {
 		return iter.i < len(iter.allIdxVals)
 	}
 	return iter.i == 0
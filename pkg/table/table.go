@@ -115,6 +115,8 @@ var (
 	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
 	// ErrCheckConstraintViolated return when check constraint is violated.
 	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrExceededMVKeysNum returns when a row produces too many values for a multi-valued index.
+	ErrExceededMVKeysNum = dbterror.ClassTable.NewStd(mysql.ErrExceededMVKeysNum)
 )
 
 // RecordIterFunc is used for low-level record iteration.
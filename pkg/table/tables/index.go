@@ -16,6 +16,7 @@ package tables
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
@@ -33,6 +34,11 @@ import (
 	"github.com/pingcap/tidb/pkg/util/tracing"
 )
 
+// maxMVIndexValuesPerRow is the max number of the values a row can write for a
+// multi-valued index, the cartesian product grows quickly with multiple
+// multi-valued key parts.
+const maxMVIndexValuesPerRow = 65535
+
 // index is the data structure for index data in the KV store.
 type index struct {
 	idxInfo  *model.IndexInfo
@@ -109,51 +115,76 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
-func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+// 5. (i1, [m1,m2], [n1,n2], ...) ==> [(i1, m1, n1, ...), (i1, m1, n2, ...), (i1, m2, n1, ...), (i1, m2, n2, ...)]
+//
+// If limit is positive, an error is returned when the number of the values exceeds
+// it, which is checked before the cartesian product is generated.
+func (c *index) getIndexedValue(indexedValues []types.Datum, limit int) ([][]types.Datum, error) {
 	if !c.idxInfo.MVIndex {
-		return [][]types.Datum{indexedValues}
+		return [][]types.Datum{indexedValues}, nil
 	}
 
-	vals := make([][]types.Datum, 0, 16)
-	jsonIdx := 0
-	jsonIsNull := false
-	existsVals := make(map[string]struct{})
+	// Collect the distinct elements of the multi-valued key parts first, a nil
+	// slice means the key part is not multi-valued.
+	partElems := make([][]types.Datum, len(indexedValues))
+	total := 1
 	var buf []byte
-	for !jsonIsNull {
-		val := make([]types.Datum, 0, len(indexedValues))
-		for i, v := range indexedValues {
-			if !c.tblInfo.Columns[c.idxInfo.Columns[i].Offset].FieldType.IsArray() {
-				val = append(val, v)
-			} else {
-				// if the datum type is not JSON, it must come from cleanup index.
-				if v.IsNull() || v.Kind() != types.KindMysqlJSON {
-					val = append(val, v)
-					jsonIsNull = true
-					continue
-				}
-				elemCount := v.GetMysqlJSON().GetElemCount()
-				for {
-					// JSON cannot be indexed, if the value is JSON type, it must be multi-valued index.
-					if jsonIdx >= elemCount {
-						goto out
-					}
-					binaryJSON := v.GetMysqlJSON().ArrayGetElem(jsonIdx)
-					jsonIdx++
-					buf = buf[:0]
-					key := string(binaryJSON.HashValue(buf))
-					if _, exists := existsVals[key]; exists {
-						continue
-					}
-					existsVals[key] = struct{}{}
-					val = append(val, types.NewDatum(binaryJSON.GetValue()))
-					break
-				}
+	for i, v := range indexedValues {
+		// if the datum type is not JSON, it must come from cleanup index.
+		if !c.tblInfo.Columns[c.idxInfo.Columns[i].Offset].FieldType.IsArray() ||
+			v.IsNull() || v.Kind() != types.KindMysqlJSON {
+			continue
+		}
+		// JSON cannot be indexed, if the value is JSON type, it must be multi-valued index.
+		bj := v.GetMysqlJSON()
+		elemCount := bj.GetElemCount()
+		elems := make([]types.Datum, 0, elemCount)
+		existsVals := make(map[string]struct{}, elemCount)
+		for jsonIdx := range elemCount {
+			binaryJSON := bj.ArrayGetElem(jsonIdx)
+			buf = binaryJSON.HashValue(buf[:0])
+			key := string(buf)
+			if _, exists := existsVals[key]; exists {
+				continue
 			}
+			existsVals[key] = struct{}{}
+			elems = append(elems, types.NewDatum(binaryJSON.GetValue()))
+		}
+		if len(elems) == 0 {
+			return nil, nil
+		}
+		partElems[i] = elems
+		if total > math.MaxInt/len(elems) {
+			total = math.MaxInt
+		} else {
+			total *= len(elems)
 		}
-		vals = append(vals, val)
 	}
-out:
-	return vals
+	if limit > 0 && total > limit {
+		return nil, table.ErrExceededMVKeysNum.GenWithStackByArgs(c.idxInfo.Name.O, total-limit)
+	}
+
+	// Generate the cartesian product of the values of all multi-valued key parts.
+	vals := make([][]types.Datum, 1, total)
+	vals[0] = make([]types.Datum, 0, len(indexedValues))
+	for i, v := range indexedValues {
+		if partElems[i] == nil {
+			for j := range vals {
+				vals[j] = append(vals[j], v)
+			}
+			continue
+		}
+		newVals := make([][]types.Datum, 0, len(vals)*len(partElems[i]))
+		for _, val := range vals {
+			for _, elem := range partElems[i] {
+				newVal := make([]types.Datum, len(val), len(indexedValues))
+				copy(newVal, val)
+				newVals = append(newVals, append(newVal, elem))
+			}
+		}
+		vals = newVals
+	}
+	return vals, nil
 }
 
 // Create creates a new entry in the kvIndex data.
@@ -168,7 +199,12 @@ func (c *index) create(sctx table.MutateContext, txn kv.Transaction, indexedValu
 	if c.Meta().Unique {
 		txn.CacheTableInfo(c.phyTblID, c.tblInfo)
 	}
-	indexedValues := c.getIndexedValue(indexedValue)
+	// The number of the values is only limited when writing, so the existing
+	// rows which exceed the limit can still be deleted and checked.
+	indexedValues, err := c.getIndexedValue(indexedValue, maxMVIndexValuesPerRow)
+	if err != nil {
+		return nil, err
+	}
 	ctx := opt.Ctx()
 	if ctx != nil {
 		var r tracing.Region
@@ -412,7 +448,10 @@ func (c *index) create(sctx table.MutateContext, txn kv.Transaction, indexedValu
 
 // Delete removes the entry for handle h and indexedValues from KV index.
 func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue []types.Datum, h kv.Handle) error {
-	indexedValues := c.getIndexedValue(indexedValue)
+	indexedValues, err := c.getIndexedValue(indexedValue, 0)
+	if err != nil {
+		return err
+	}
 	evalCtx := ctx.GetExprCtx().GetEvalCtx()
 	loc, ec := evalCtx.Location(), evalCtx.ErrCtx()
 	for _, value := range indexedValues {
@@ -501,9 +540,12 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
-	var mvIndexValues [][]types.Datum
 	if c.Meta().MVIndex {
-		mvIndexValues = c.getIndexedValue(indexedValue)
+		// the index entries are written by the callers, e.g. the backfill of ADD INDEX.
+		mvIndexValues, err := c.getIndexedValue(indexedValue, maxMVIndexValuesPerRow)
+		if err != nil {
+			return table.NewErrIndexKVGenerator(err)
+		}
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
 	return table.NewPlainIndexKVGenerator(c, ec, loc, h, handleRestoreData, indexedValue)
@@ -535,7 +577,10 @@ func GenTempIdxKeyByState(indexInfo *model.IndexInfo, indexKey kv.Key) (key, tem
 }
 
 func (c *index) Exist(ec errctx.Context, loc *time.Location, txn kv.Transaction, indexedValue []types.Datum, h kv.Handle) (bool, kv.Handle, error) {
-	indexedValues := c.getIndexedValue(indexedValue)
+	indexedValues, err := c.getIndexedValue(indexedValue, 0)
+	if err != nil {
+		return false, nil, err
+	}
 	for _, val := range indexedValues {
 		key, distinct, err := c.GenIndexKey(ec, loc, val, h, nil)
 		if err != nil {
@@ -352,3 +352,60 @@ func TestTableOperationsInDDLDropIndexWriteOnly(t *testing.T) {
 	// commit should success without any assertion fail.
 	tk2.MustExec("commit")
 }
+
+func TestMultiValuedIndexValuesLimit(t *testing.T) {
+	tblInfo := buildTableInfo(t, "create table t (a json, index idx((cast(a as signed array))))")
+	idx := tables.NewIndex(tblInfo.ID, tblInfo, tblInfo.Indices[0])
+	elems := make([]any, 65536)
+	for i := range elems {
+		elems[i] = int64(i)
+	}
+	vals := []types.Datum{types.NewJSONDatum(types.CreateBinaryJSON(elems))}
+	h := kv.IntHandle(1)
+
+	store := testkit.CreateMockStore(t)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+	mockCtx := mock.NewContext()
+	sc := mockCtx.GetSessionVars().StmtCtx
+	_, err = idx.Create(mockCtx.GetTableCtx(), txn, vals, h, nil)
+	require.True(t, table.ErrExceededMVKeysNum.Equal(err), "%v", err)
+
+	// The index kv of the rows which exceed the limit can't be generated by the
+	// iterator either, e.g. for the backfill of ADD INDEX.
+	iter := idx.GenIndexKVIter(sc.ErrCtx(), sc.TimeZone(), vals, h, nil)
+	require.True(t, iter.Valid())
+	_, _, _, err = iter.Next(nil, nil)
+	require.True(t, table.ErrExceededMVKeysNum.Equal(err), "%v", err)
+	require.False(t, iter.Valid())
+
+	// The existing rows which exceed the limit can still be checked and deleted.
+	for _, elem := range elems {
+		idxVals := []types.Datum{types.NewIntDatum(elem.(int64))}
+		key, _, err := idx.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), idxVals, h, nil)
+		require.NoError(t, err)
+		val, err := idx.GenIndexValue(sc.ErrCtx(), sc.TimeZone(), false, idxVals, h, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, txn.Set(key, val))
+	}
+	exist, _, err := idx.Exist(sc.ErrCtx(), sc.TimeZone(), txn, vals, h)
+	require.NoError(t, err)
+	require.True(t, exist)
+	require.NoError(t, idx.Delete(mockCtx.GetTableCtx(), txn, vals, h))
+	exist, _, err = idx.Exist(sc.ErrCtx(), sc.TimeZone(), txn, vals, h)
+	require.NoError(t, err)
+	require.False(t, exist)
+
+	// The cartesian product of multiple multi-valued key parts is checked before it's generated.
+	tblInfo = buildTableInfo(t, "create table t (a json, b json, index idx((cast(a as signed array)), (cast(b as signed array))))")
+	idx = tables.NewIndex(tblInfo.ID, tblInfo, tblInfo.Indices[0])
+	vals = []types.Datum{types.NewJSONDatum(types.CreateBinaryJSON(elems[:300])), types.NewJSONDatum(types.CreateBinaryJSON(elems[:300]))}
+	_, err = idx.Create(mockCtx.GetTableCtx(), txn, vals, h, nil)
+	require.EqualError(t, err, "[table:3905]Exceeded max number of values per record for multi-valued index 'idx' by 24465 value(s).")
+	iter = idx.GenIndexKVIter(sc.ErrCtx(), sc.TimeZone(), vals, h, nil)
+	_, _, _, err = iter.Next(nil, nil)
+	require.True(t, table.ErrExceededMVKeysNum.Equal(err), "%v", err)
+}
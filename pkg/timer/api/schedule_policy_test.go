@@ -185,3 +185,25 @@ func TestCronPolicy(t *testing.T) {
 		require.Equal(t, !next.IsZero(), ok)
 	}
 }
+
+func TestOncePolicy(t *testing.T) {
+	tm := time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC)
+	p, err := CreateSchedEventPolicy(SchedEventOnce, tm.Format(time.RFC3339Nano))
+	require.NoError(t, err)
+	require.IsType(t, &OncePolicy{}, p)
+
+	next, ok := p.NextEventTime(time.Time{})
+	require.True(t, ok)
+	require.True(t, tm.Equal(next))
+	next, ok = p.NextEventTime(tm.Add(-time.Second))
+	require.True(t, ok)
+	require.True(t, tm.Equal(next))
+	_, ok = p.NextEventTime(tm)
+	require.False(t, ok)
+	_, ok = p.NextEventTime(tm.Add(time.Hour))
+	require.False(t, ok)
+
+	p, err = CreateSchedEventPolicy(SchedEventOnce, "2026-10-18 08:30:00")
+	require.Nil(t, p)
+	require.ErrorContains(t, err, "invalid schedule event expr '2026-10-18 08:30:00'")
+}
@@ -32,6 +32,8 @@ const (
 	SchedEventInterval SchedPolicyType = "INTERVAL"
 	// SchedEventCron indicates to schedule events by cron expression.
 	SchedEventCron SchedPolicyType = "CRON"
+	// SchedEventOnce indicates to schedule only one event at a specified time.
+	SchedEventOnce SchedPolicyType = "ONCE"
 )
 
 // SchedEventPolicy is an interface to tell the runtime how to schedule a timer's events.
@@ -95,6 +97,33 @@ func (p *CronPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
 	return next, !next.IsZero()
 }
 
+// OncePolicy implements SchedEventPolicy, it is the policy of type `SchedEventOnce`.
+// The expression is the time of the event in RFC3339 format.
+type OncePolicy struct {
+	tm time.Time
+}
+
+// NewOncePolicy creates a new OncePolicy.
+func NewOncePolicy(expr string) (*OncePolicy, error) {
+	tm, err := time.Parse(time.RFC3339Nano, expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule event expr '%s'", expr)
+	}
+
+	return &OncePolicy{
+		tm: tm,
+	}, nil
+}
+
+// NextEventTime returns the next time of the timer event.
+// The event is only scheduled when the watermark is before the specified time.
+func (p *OncePolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
+	if watermark.IsZero() || watermark.Before(p.tm) {
+		return p.tm, true
+	}
+	return time.Time{}, false
+}
+
 // ManualRequest is the request info to trigger timer manually.
 type ManualRequest struct {
 	// ManualRequestID is the id of manual request.
@@ -201,6 +230,8 @@ func CreateSchedEventPolicy(tp SchedPolicyType, expr string) (SchedEventPolicy,
 		return NewSchedIntervalPolicy(expr)
 	case SchedEventCron:
 		return NewCronPolicy(expr)
+	case SchedEventOnce:
+		return NewOncePolicy(expr)
 	default:
 		return nil, errors.Errorf("invalid schedule event type: '%s'", tp)
 	}
@@ -111,11 +111,16 @@ func (b *SQLBuilder) Build() (string, error) {
 
 // WriteSelect writes a select statement to select key columns without any condition
 func (b *SQLBuilder) WriteSelect() error {
+	return b.WriteSelectColumns(b.tbl.KeyColumns)
+}
+
+// WriteSelectColumns writes a select statement to select the specified columns without any condition
+func (b *SQLBuilder) WriteSelectColumns(cols []*model.ColumnInfo) error {
 	if b.state != writeBegin {
 		return errors.Errorf("invalid state: %v", b.state)
 	}
 	b.restoreCtx.WritePlain("SELECT LOW_PRIORITY SQL_NO_CACHE ")
-	b.writeColNames(b.tbl.KeyColumns, false)
+	b.writeColNames(cols, false)
 	b.restoreCtx.WritePlain(" FROM ")
 	if err := b.writeTblName(); err != nil {
 		return err
@@ -148,6 +153,33 @@ func (b *SQLBuilder) WriteDelete() error {
 	return nil
 }
 
+// WriteInsertSelect writes an insert statement to copy the specified columns into the target table without any condition
+func (b *SQLBuilder) WriteInsertSelect(targetSchema, targetTable ast.CIStr, cols []*model.ColumnInfo) error {
+	if b.state != writeBegin {
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+	b.restoreCtx.WritePlain("INSERT LOW_PRIORITY INTO ")
+	target := ast.TableName{Schema: targetSchema, Name: targetTable}
+	if err := target.Restore(b.restoreCtx); err != nil {
+		return err
+	}
+	b.restoreCtx.WritePlain(" ")
+	b.writeColNames(cols, true)
+	b.restoreCtx.WritePlain(" SELECT ")
+	b.writeColNames(cols, false)
+	b.restoreCtx.WritePlain(" FROM ")
+	if err := b.writeTblName(); err != nil {
+		return err
+	}
+	if par := b.tbl.PartitionDef; par != nil {
+		b.restoreCtx.WritePlain(" PARTITION(")
+		b.restoreCtx.WriteName(par.Name.O)
+		b.restoreCtx.WritePlain(")")
+	}
+	b.state = writeSelOrDel
+	return nil
+}
+
 // WriteCommonCondition writes a new condition
 func (b *SQLBuilder) WriteCommonCondition(cols []*model.ColumnInfo, op string, dp []types.Datum) error {
 	switch b.state {
@@ -167,7 +199,8 @@ func (b *SQLBuilder) WriteCommonCondition(cols []*model.ColumnInfo, op string, d
 	return b.writeDataPoint(cols, dp)
 }
 
-// WriteExpireCondition writes a condition with the time column
+// WriteExpireCondition writes a condition with the time column. If the table has a `TTL_WHERE` predicate, it's also
+// written to make sure only the rows matching it are considered as expired.
 func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	switch b.state {
 	case writeSelOrDel:
@@ -184,6 +217,12 @@ func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	b.restoreCtx.WritePlain("FROM_UNIXTIME(")
 	b.restoreCtx.WritePlain(strconv.FormatInt(expire.Unix(), 10))
 	b.restoreCtx.WritePlain(")")
+	if ttlInfo := b.tbl.TTLInfo; ttlInfo != nil && ttlInfo.Condition != "" {
+		// The condition is restored from the parsed predicate in DDL, so it's safe to be written directly.
+		b.restoreCtx.WritePlain(" AND (")
+		b.restoreCtx.WritePlain(ttlInfo.Condition)
+		b.restoreCtx.WritePlain(")")
+	}
 	b.hasWriteExpireCond = true
 	return nil
 }
@@ -480,3 +519,68 @@ func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.
 
 	return b.Build()
 }
+
+// ArchiveColumns returns the columns which are copied to the archive table or exported to the external storage.
+// The generated columns are skipped because they cannot be inserted and can be computed again from the others.
+func ArchiveColumns(tbl *cache.PhysicalTable) []*model.ColumnInfo {
+	cols := make([]*model.ColumnInfo, 0, len(tbl.TableInfo.Columns))
+	for _, col := range tbl.TableInfo.Cols() {
+		if col.Hidden || col.IsGenerated() {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// BuildArchiveSQL builds an insert SQL to copy the rows to the archive table
+func BuildArchiveSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.Time, archiveSchema, archiveTable ast.CIStr) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteInsertSelect(archiveSchema, archiveTable, ArchiveColumns(tbl)); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteLimit(len(rows)); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
+
+// BuildExportSQL builds a select SQL to read the rows which will be exported to the external storage
+func BuildExportSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build export SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteSelectColumns(ArchiveColumns(tbl)); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteLimit(len(rows)); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
@@ -930,6 +930,92 @@ func TestBuildDeleteSQL(t *testing.T) {
 	}
 }
 
+func TestBuildArchiveAndExportSQL(t *testing.T) {
+	id := &model.ColumnInfo{Name: ast.NewCIStr("id"), Offset: 0, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	tm := &model.ColumnInfo{Name: ast.NewCIStr("time"), Offset: 1, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	v := &model.ColumnInfo{Name: ast.NewCIStr("v"), Offset: 2, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeVarchar)}
+	g := &model.ColumnInfo{Name: ast.NewCIStr("g"), Offset: 3, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24), GeneratedExprString: "`id` + 1"}
+	h := &model.ColumnInfo{Name: ast.NewCIStr("h"), Offset: 4, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24), Hidden: true}
+	w := &model.ColumnInfo{Name: ast.NewCIStr("w"), Offset: 5, State: model.StateWriteOnly, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	tblInfo := &model.TableInfo{
+		Name:    ast.NewCIStr("t1"),
+		Columns: []*model.ColumnInfo{id, tm, v, g, h, w},
+	}
+	t1 := &cache.PhysicalTable{
+		Schema:     ast.NewCIStr("test"),
+		TableInfo:  tblInfo,
+		KeyColumns: []*model.ColumnInfo{id},
+		TimeColumn: tm,
+	}
+	p1 := &cache.PhysicalTable{
+		Schema:       ast.NewCIStr("test"),
+		TableInfo:    tblInfo,
+		KeyColumns:   []*model.ColumnInfo{id},
+		TimeColumn:   tm,
+		PartitionDef: &model.PartitionDefinition{Name: ast.NewCIStr("p1")},
+	}
+	require.Equal(t, []*model.ColumnInfo{id, tm, v}, sqlbuilder.ArchiveColumns(t1))
+
+	expire := time.UnixMilli(0).In(time.UTC)
+	sql, err := sqlbuilder.BuildArchiveSQL(t1, [][]types.Datum{d(1), d(2)}, expire, ast.NewCIStr("test2"), ast.NewCIStr("t1_archive"))
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `test2`.`t1_archive` (`id`, `time`, `v`) SELECT `id`, `time`, `v` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSQL(p1, [][]types.Datum{d(1)}, expire, ast.NewCIStr("test"), ast.NewCIStr("t1_archive"))
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `test`.`t1_archive` (`id`, `time`, `v`) SELECT `id`, `time`, `v` FROM `test`.`t1` PARTITION(`p1`) WHERE `id` IN (1) AND `time` < FROM_UNIXTIME(0) LIMIT 1", sql)
+
+	sql, err = sqlbuilder.BuildExportSQL(t1, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `time`, `v` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) LIMIT 2", sql)
+
+	_, err = sqlbuilder.BuildArchiveSQL(t1, nil, expire, ast.NewCIStr("test"), ast.NewCIStr("t1_archive"))
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildExportSQL(t1, nil, expire)
+	require.Error(t, err)
+
+	// the archive SQL must have the expire condition
+	b := sqlbuilder.NewSQLBuilder(t1)
+	require.NoError(t, b.WriteInsertSelect(ast.NewCIStr("test"), ast.NewCIStr("t1_archive"), sqlbuilder.ArchiveColumns(t1)))
+	require.NoError(t, b.WriteInCondition(t1.KeyColumns, d(1)))
+	_, err = b.Build()
+	require.EqualError(t, err, "expire condition not write")
+}
+
+func TestBuildSQLWithTTLCondition(t *testing.T) {
+	id := &model.ColumnInfo{Name: ast.NewCIStr("id"), Offset: 0, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	tm := &model.ColumnInfo{Name: ast.NewCIStr("time"), Offset: 1, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	deleted := &model.ColumnInfo{Name: ast.NewCIStr("deleted"), Offset: 2, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeTiny)}
+	t1 := &cache.PhysicalTable{
+		Schema: ast.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name:    ast.NewCIStr("t1"),
+			Columns: []*model.ColumnInfo{id, tm, deleted},
+			TTLInfo: &model.TTLInfo{
+				ColumnName: ast.NewCIStr("time"),
+				Condition:  "`deleted` = 1 or `deleted` is null",
+			},
+		},
+		KeyColumns: []*model.ColumnInfo{id},
+		TimeColumn: tm,
+	}
+
+	expire := time.UnixMilli(0).In(time.UTC)
+	g, err := sqlbuilder.NewScanQueryGenerator(t1, expire, nil, nil)
+	require.NoError(t, err)
+	sql, err := g.NextSQL(nil, 32)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id` FROM `test`.`t1` WHERE `time` < FROM_UNIXTIME(0) AND (`deleted` = 1 or `deleted` is null) ORDER BY `id` ASC LIMIT 32", sql)
+
+	sql, err = sqlbuilder.BuildDeleteSQL(t1, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) AND (`deleted` = 1 or `deleted` is null) LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSQL(t1, [][]types.Datum{d(1)}, expire, ast.NewCIStr("test"), ast.NewCIStr("t1_archive"))
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `test`.`t1_archive` (`id`, `time`, `deleted`) SELECT `id`, `time`, `deleted` FROM `test`.`t1` WHERE `id` IN (1) AND `time` < FROM_UNIXTIME(0) AND (`deleted` = 1 or `deleted` is null) LIMIT 1", sql)
+}
+
 func d(vs ...any) []types.Datum {
 	datums := make([]types.Datum, len(vs))
 	for i, v := range vs {
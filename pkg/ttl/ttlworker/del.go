@@ -22,6 +22,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/session/syssession"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
@@ -29,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ttl/session"
 	"github.com/pingcap/tidb/pkg/ttl/sqlbuilder"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
@@ -114,6 +117,8 @@ type ttlDeleteTask struct {
 	expire     time.Time
 	rows       [][]types.Datum
 	statistics *ttlStatistics
+	// exporter is created lazily when the TTL action of the table is export.
+	exporter *ttlExporter
 }
 
 func (t *ttlDeleteTask) taskLogger(l *zap.Logger) *zap.Logger {
@@ -125,6 +130,77 @@ func (t *ttlDeleteTask) taskLogger(l *zap.Logger) *zap.Logger {
 	)
 }
 
+// buildDeleteSQLs builds the SQLs to delete the rows according to the TTL action of the table. They are executed in
+// one transaction, so the rows are deleted only if they have been copied to the archive table, or exported to the
+// external storage by the returned `onResult` callback.
+func (t *ttlDeleteTask) buildDeleteSQLs(ctx context.Context, rows [][]types.Datum) ([]string, func(int, []chunk.Row) error, error) {
+	delSQL, err := sqlbuilder.BuildDeleteSQL(t.tbl, rows, t.expire)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch t.tbl.TTLInfo.GetActionType() {
+	case ast.TTLActionArchive:
+		action := t.tbl.TTLInfo.Action
+		archiveSQL, err := sqlbuilder.BuildArchiveSQL(t.tbl, rows, t.expire, action.ArchiveSchema, action.ArchiveTable)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{archiveSQL, delSQL}, nil, nil
+	case ast.TTLActionExport:
+		exportSQL, err := sqlbuilder.BuildExportSQL(t.tbl, rows, t.expire)
+		if err != nil {
+			return nil, nil, err
+		}
+		if t.exporter == nil {
+			if t.exporter, err = newTTLExporter(ctx, t.tbl); err != nil {
+				return nil, nil, err
+			}
+		}
+		return []string{exportSQL, delSQL}, func(idx int, result []chunk.Row) error {
+			if idx != 0 || len(result) == 0 {
+				return nil
+			}
+			return t.exporter.export(ctx, t.jobID, t.scanID, result)
+		}, nil
+	default:
+		return []string{delSQL}, nil, nil
+	}
+}
+
+// settleExportedFile finalizes the file exported in the transaction if the transaction is committed, or discards it
+// if the transaction is rolled back. The file is kept with the staging suffix if the result of the transaction is
+// undetermined, or it fails to be finalized. Its name contains the job and scan task IDs, so that it can be checked
+// and cleaned manually.
+func (t *ttlDeleteTask) settleExportedFile(ctx context.Context, txnErr error) {
+	staged := t.exporter.staged
+	if staged == "" {
+		return
+	}
+	var err error
+	switch {
+	case txnErr == nil:
+		err = t.exporter.finalize(ctx)
+	case terror.ErrResultUndetermined.Equal(txnErr):
+		t.taskLogger(logutil.Logger(ctx)).Warn(
+			"the result of the TTL delete transaction is undetermined, the exported file is kept as staging",
+			zap.String("file", staged),
+		)
+		t.exporter.staged = ""
+		return
+	default:
+		err = t.exporter.discard(ctx)
+	}
+	if err != nil {
+		t.taskLogger(logutil.Logger(ctx)).Warn(
+			"settle the exported file in TTL failed, the file is kept as staging",
+			zap.String("file", staged),
+			zap.Bool("deleted", txnErr == nil),
+			zap.Error(err),
+		)
+	}
+}
+
 func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (retryRows [][]types.Datum) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
@@ -138,6 +214,12 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 	}()
 
 	se := newTableSession(rawSe, t.tbl, t.expire)
+	defer func() {
+		if t.exporter != nil {
+			t.exporter.close()
+			t.exporter = nil
+		}
+	}()
 	for len(leftRows) > 0 && ctx.Err() == nil {
 		maxBatch := vardef.TTLDeleteBatchSize.Load()
 		var delBatch [][]types.Datum
@@ -149,7 +231,7 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 			leftRows = leftRows[maxBatch:]
 		}
 
-		sql, err := sqlbuilder.BuildDeleteSQL(t.tbl, delBatch, t.expire)
+		sqls, onResult, err := t.buildDeleteSQLs(ctx, delBatch)
 		if err != nil {
 			t.statistics.IncErrorRows(len(delBatch))
 			t.taskLogger(logutil.Logger(ctx)).Warn(
@@ -173,14 +255,17 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 		tracer.EnterPhase(metrics.PhaseOther)
 
 		sqlStart := time.Now()
-		_, needRetry, err := se.ExecuteSQLWithCheck(ctx, sql)
+		needRetry, err := se.ExecuteSQLsWithCheck(ctx, sqls, onResult)
 		sqlInterval := time.Since(sqlStart)
+		if t.exporter != nil {
+			t.settleExportedFile(ctx, err)
+		}
 		if err != nil {
 			metrics.DeleteErrorDuration.Observe(sqlInterval.Seconds())
 			t.taskLogger(logutil.Logger(ctx)).Warn(
 				"delete SQL in TTL failed",
 				zap.Error(err),
-				zap.String("SQL", sql),
+				zap.Strings("SQL", sqls),
 				zap.Bool("needRetry", needRetry),
 			)
 
@@ -0,0 +1,260 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/session"
+	"github.com/pingcap/tidb/pkg/ttl/ttlworker"
+	"github.com/stretchr/testify/require"
+)
+
+// runTTLScanAndDelete scans the expired rows of the table and deletes them with the TTL action of the table.
+func runTTLScanAndDelete(t *testing.T, dom *domain.Domain, tblName string) {
+	tbl, err := dom.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr(tblName))
+	require.NoError(t, err)
+	physicalTbl, err := cache.NewPhysicalTable(ast.NewCIStr("test"), tbl.Meta(), ast.NewCIStr(""))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	scanTask := ttlworker.NewTTLScanTask(ctx, physicalTbl, &cache.TTLTask{
+		JobID:            "test",
+		TableID:          physicalTbl.ID,
+		ScanID:           1,
+		ExpireTime:       time.Now().Add(-time.Hour),
+		OwnerID:          "test",
+		OwnerAddr:        "test",
+		OwnerHBTime:      time.Now(),
+		Status:           cache.TaskStatusRunning,
+		StatusUpdateTime: time.Now(),
+		State:            &cache.TTLTaskState{},
+		CreatedTime:      time.Now(),
+	})
+
+	delCh := make(chan *ttlworker.TTLDeleteTask)
+	var delTasks []*ttlworker.TTLDeleteTask
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for task := range delCh {
+			delTasks = append(delTasks, task)
+		}
+	}()
+	result := scanTask.DoScan(ctx, delCh, dom.AdvancedSysSessionPool())
+	close(delCh)
+	wg.Wait()
+	require.NoError(t, result.GetError())
+
+	for _, task := range delTasks {
+		require.NoError(t, ttlworker.WithSessionForTest(dom.AdvancedSysSessionPool(), func(se session.Session) error {
+			// the failed rows will be retried by the next job, it's fine to ignore them here
+			task.DoDelete(ctx, se)
+			return nil
+		}))
+	}
+}
+
+func TestTTLDeleteWithArchiveAction(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime) TTL = created_at + interval 1 hour TTL_ACTION = ARCHIVE TO TABLE t_archive",
+		"[schema:1146]Table 'test.t_archive' doesn't exist")
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime) TTL_ACTION = ARCHIVE TO TABLE t_archive",
+		"[ddl:8150]Cannot set TTL_ACTION on a table without TTL config")
+	tk.MustExec("create table t_archive (id int primary key, created_at datetime, v varchar(16))")
+	tk.MustExec("create table t (id int primary key, created_at datetime, v varchar(16), g int as (id + 1)) " +
+		"TTL = created_at + interval 1 hour TTL_ACTION = ARCHIVE TO TABLE t_archive")
+	tk.MustGetErrMsg("alter table t TTL_ACTION = ARCHIVE TO TABLE t",
+		"[ddl:8200]Unsupported archiving the expired rows of a TTL table to itself")
+	// the archive table must be able to store the archived columns
+	tk.MustExec("create table t_archive2 (id int primary key, created_at datetime)")
+	tk.MustGetErrMsg("alter table t TTL_ACTION = ARCHIVE TO TABLE t_archive2",
+		"[ddl:8200]Unsupported archiving the expired rows of a TTL table to table `t_archive2` without column `v`")
+	tk.MustExec("create table t_archive3 (id int primary key, created_at datetime, v varchar(8))")
+	tk.MustGetErrMsg("alter table t TTL_ACTION = ARCHIVE TO TABLE t_archive3",
+		"[ddl:8200]Unsupported archiving the expired rows of a TTL table to table `t_archive3` with incompatible column `v` of type varchar(8)")
+	tk.MustExec("create table t_archive4 (id int primary key, created_at datetime, v varchar(16), note varchar(16) not null)")
+	tk.MustGetErrMsg("alter table t TTL_ACTION = ARCHIVE TO TABLE t_archive4",
+		"[ddl:8200]Unsupported archiving the expired rows of a TTL table to table `t_archive4` with not null column `note` without default value")
+	tk.MustExec("create table t_archive5 (id bigint primary key, created_at datetime, v text, archived_at timestamp not null default current_timestamp)")
+	tk.MustExec("alter table t TTL_ACTION = ARCHIVE TO TABLE t_archive5")
+	tk.MustExec("alter table t TTL_ACTION = ARCHIVE TO TABLE t_archive")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL,\n" +
+		"  `v` varchar(16) DEFAULT NULL,\n" +
+		"  `g` int(11) GENERATED ALWAYS AS (`id` + 1) VIRTUAL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin " +
+		"/*T![ttl] TTL=`created_at` + INTERVAL 1 HOUR */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='24h' */ " +
+		"/*T![ttl] TTL_ACTION=ARCHIVE TO TABLE `test`.`t_archive` */"))
+
+	tk.MustExec("insert into t(id, created_at, v) values (1, NOW() - INTERVAL 1 DAY, 'a'), (2, NOW() - INTERVAL 1 DAY, NULL), (3, NOW(), 'c')")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id, v from t").Check(testkit.Rows("3 c"))
+	tk.MustQuery("select id, v from t_archive order by id").Check(testkit.Rows("1 a", "2 <nil>"))
+
+	// the rows are not deleted if they cannot be archived
+	tk.MustExec("insert into t(id, created_at, v) values (4, NOW() - INTERVAL 1 DAY, 'd')")
+	tk.MustExec("insert into t_archive values (4, NOW(), 'conflict')")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select id, v from t_archive order by id").Check(testkit.Rows("1 a", "2 <nil>", "4 conflict"))
+
+	// set the action back to DELETE
+	tk.MustExec("alter table t TTL_ACTION = DELETE")
+	tk.MustQuery("show create table t").CheckNotContain("TTL_ACTION")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from t_archive").Check(testkit.Rows("3"))
+}
+
+func TestTTLDeleteWithExportAction(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	backend := s3mem.New()
+	ts := httptest.NewServer(gofakes3.New(backend).Server())
+	defer ts.Close()
+	require.NoError(t, backend.CreateBucket("test-bucket"))
+	// the credentials are not allowed in the URI, they are read from the environment of the TiDB server
+	t.Setenv("AWS_ACCESS_KEY_ID", "xxxxxx")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "xxxxxx")
+	uri := fmt.Sprintf("s3://test-bucket/prefix?endpoint=%s&force-path-style=true&region=us-east-1", url.QueryEscape(ts.URL))
+	extStore, err := storage.NewFromURL(context.Background(), uri)
+	require.NoError(t, err)
+	readExportedFiles := func(suffix string) []string {
+		var contents []string
+		err := extStore.WalkDir(context.Background(), &storage.WalkOption{SubDir: "test.t"}, func(name string, _ int64) error {
+			if !strings.HasSuffix(name, suffix) {
+				return nil
+			}
+			content, err := extStore.ReadFile(context.Background(), name)
+			contents = append(contents, string(content))
+			return err
+		})
+		require.NoError(t, err)
+		return contents
+	}
+
+	tk.MustExec(fmt.Sprintf("create table t (id int primary key, created_at datetime, v varchar(16)) "+
+		"TTL = created_at + interval 1 hour TTL_ACTION = EXPORT TO '%s'", uri))
+	tk.MustQuery("show create table t").CheckContain(fmt.Sprintf("/*T![ttl] TTL_ACTION=EXPORT TO '%s' FORMAT='csv' */", uri))
+
+	tk.MustExec("insert into t values (1, '2020-01-01 00:00:00', 'a,b'), (2, '2020-01-01 00:00:00', NULL), (3, NOW(), 'c'), " +
+		"(5, '2020-01-01 00:00:00', '\\\\N')")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t").Check(testkit.Rows("3"))
+
+	// the backslashes are escaped, so that the string `\N` can be told from NULL.
+	contents := readExportedFiles(".csv")
+	require.Equal(t, []string{"id,created_at,v\n1,2020-01-01 00:00:00,\"a,b\"\n2,2020-01-01 00:00:00,\\N\n5,2020-01-01 00:00:00,\\\\N\n"}, contents)
+	// the files are renamed after the rows are deleted.
+	require.Empty(t, readExportedFiles(".staging"))
+
+	tk.MustExec(fmt.Sprintf("alter table t TTL_ACTION = EXPORT TO '%s' FORMAT = 'parquet'", uri))
+	tk.MustExec("insert into t values (4, '2020-01-01 00:00:00', 'd')")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t").Check(testkit.Rows("3"))
+
+	contents = readExportedFiles(".parquet")
+	require.Len(t, contents, 1)
+	require.True(t, strings.HasPrefix(contents[0], "PAR1"))
+
+	tk.MustGetErrMsg("alter table t TTL_ACTION = EXPORT TO 'unknown://bucket/prefix'",
+		"[ddl:8200]Unsupported TTL_ACTION export URI 'unknown://bucket/prefix'")
+	// the local storage and the credentials in the URI are not allowed
+	for _, localURI := range []string{"file:///tmp/ttl", "local:///tmp/ttl", "/tmp/ttl", "noop://"} {
+		tk.MustGetErrMsg(fmt.Sprintf("alter table t TTL_ACTION = EXPORT TO '%s'", localURI),
+			"[ddl:8200]Unsupported exporting the expired rows of a TTL table to the local storage")
+	}
+	tk.MustGetErrMsg("alter table t TTL_ACTION = EXPORT TO 's3://bucket/prefix?access-key=ak&secret-access-key=sk'",
+		"[ddl:8200]Unsupported credentials in the TTL_ACTION export URI, please configure them in the environment of the TiDB servers")
+	tk.MustGetErrMsg("alter table t TTL_ACTION = EXPORT TO 'azure://bucket/prefix?account-name=a&sas_token=sas'",
+		"[ddl:8200]Unsupported credentials in the TTL_ACTION export URI, please configure them in the environment of the TiDB servers")
+	tk.MustQuery("show create table t").CheckContain(fmt.Sprintf("/*T![ttl] TTL_ACTION=EXPORT TO '%s' FORMAT='parquet' */", uri))
+}
+
+func TestTTLDeleteWithCondition(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime, deleted tinyint) TTL_WHERE = (deleted = 1)",
+		"[ddl:8150]Cannot set TTL_WHERE on a table without TTL config")
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime, deleted tinyint) TTL = created_at + interval 1 hour TTL_WHERE = (unknown = 1)",
+		"[ddl:1054]Unknown column 'unknown' in 'TTL_WHERE'")
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime, deleted tinyint) TTL = created_at + interval 1 hour TTL_WHERE = (deleted = rand())",
+		"[ddl:8200]Unsupported subqueries, variables, aggregate or non-deterministic functions in TTL_WHERE")
+	tk.MustGetErrMsg("create table t (id int primary key, created_at datetime, deleted tinyint) TTL = created_at + interval 1 hour TTL_WHERE = (deleted in (select 1))",
+		"[ddl:8200]Unsupported subqueries, variables, aggregate or non-deterministic functions in TTL_WHERE")
+	tk.MustExec("create table t (id int primary key, created_at datetime, deleted tinyint, status varchar(16)) " +
+		"TTL = created_at + interval 1 hour TTL_WHERE = (deleted = 1 and status <> 'keep')")
+	tk.MustQuery("show create table t").CheckContain("/*T![ttl] TTL_WHERE=(`deleted` = 1 and `status` != 'keep') */")
+
+	tk.MustExec("insert into t values " +
+		"(1, NOW() - INTERVAL 1 DAY, 1, 'a'), " +
+		"(2, NOW() - INTERVAL 1 DAY, 0, 'b'), " +
+		"(3, NOW() - INTERVAL 1 DAY, 1, 'keep'), " +
+		"(4, NOW(), 1, 'd'), " +
+		"(5, NOW() - INTERVAL 1 DAY, NULL, 'e')")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("2", "3", "4", "5"))
+
+	// the columns referenced by the condition cannot be dropped or renamed
+	tk.MustGetErrMsg("alter table t drop column deleted", "[ddl:8149]Cannot drop column 'deleted': needed in TTL config")
+	tk.MustGetErrMsg("alter table t rename column status to status2", "[ddl:8200]Unsupported renaming a column referenced by TTL_WHERE")
+	tk.MustGetErrMsg("alter table t change column status status2 varchar(16)", "[ddl:8200]Unsupported renaming a column referenced by TTL_WHERE")
+	tk.MustExec("alter table t rename column created_at to created_at2")
+
+	// the condition is kept when the TTL is changed
+	tk.MustExec("alter table t TTL = created_at2 + interval 1 hour TTL_JOB_INTERVAL = '2h'")
+	tk.MustQuery("show create table t").CheckContain("TTL_WHERE=(`deleted` = 1 and `status` != 'keep')")
+
+	tk.MustExec("alter table t TTL_WHERE = (deleted is null)")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("2", "3", "4"))
+
+	// remove the condition, all the expired rows are deleted
+	tk.MustExec("alter table t TTL_WHERE = DEFAULT")
+	tk.MustQuery("show create table t").CheckNotContain("TTL_WHERE")
+	tk.MustExec("alter table t drop column deleted")
+	runTTLScanAndDelete(t, dom, "t")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("4"))
+
+	tk.MustExec("drop table t")
+	tk.MustGetErrMsg("alter table t TTL_WHERE = (id > 0)", "[schema:1146]Table 'test.t' doesn't exist")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustGetErrMsg("alter table t TTL_WHERE = (v > 0)", "[ddl:8150]Cannot set TTL_WHERE on a table without TTL config")
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/sqlbuilder"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// csvNullValue is the representation of NULL in the exported CSV files, which is the same with the default
+// `FIELDS ESCAPED BY '\\'` of `LOAD DATA` and `IMPORT INTO`. The backslashes in the values are escaped, so that a
+// string `\N` is not read as NULL.
+const csvNullValue = `\N`
+
+// exportStagingSuffix is the suffix of the exported files whose rows haven't been deleted yet. The files are renamed
+// to remove the suffix after the transaction deleting the rows is committed.
+const exportStagingSuffix = ".staging"
+
+// ttlExporter writes the expired rows of a table to the external storage before they are deleted.
+type ttlExporter struct {
+	tbl    *cache.PhysicalTable
+	store  storage.ExternalStorage
+	format string
+	cols   []*model.ColumnInfo
+	// staged is the staging file written in the current transaction.
+	staged string
+}
+
+func newTTLExporter(ctx context.Context, tbl *cache.PhysicalTable) (*ttlExporter, error) {
+	action := tbl.TTLInfo.Action
+	if action == nil || action.Tp != ast.TTLActionExport {
+		return nil, errors.Errorf("TTL action of table '%s' is not export", tbl.FullName())
+	}
+
+	// The URI is checked by the DDL, but the table meta may also come from other paths such as BR restore, so check
+	// the local storage again here and never put the URI in the error without redacting it.
+	backend, err := storage.ParseBackend(action.ExportURI, nil)
+	if err != nil {
+		return nil, errors.Errorf("invalid TTL export URI '%s' of table '%s'", ast.RedactURL(action.ExportURI), tbl.FullName())
+	}
+	if backend.GetLocal() != nil || backend.GetNoop() != nil {
+		return nil, errors.Errorf("TTL export URI of table '%s' should not be a local storage", tbl.FullName())
+	}
+	store, err := storage.New(ctx, backend, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ttlExporter{
+		tbl:    tbl,
+		store:  store,
+		format: action.ExportFormat,
+		cols:   sqlbuilder.ArchiveColumns(tbl),
+	}, nil
+}
+
+// export writes the rows to a new staging file named by the job and scan task. The rows should be selected by the
+// SQL built by `sqlbuilder.BuildExportSQL`, so that they have the same columns with `e.cols`. The file should be
+// finalized after the rows are deleted, or discarded if the deletion fails.
+func (e *ttlExporter) export(ctx context.Context, jobID string, scanID int64, rows []chunk.Row) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch e.format {
+	case ast.TTLExportFormatCSV:
+		data, err = e.encodeCSV(rows)
+	case ast.TTLExportFormatParquet:
+		data, err = e.encodeParquet(rows)
+	default:
+		err = errors.Errorf("unsupported TTL export format '%s'", e.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = e.discard(ctx); err != nil {
+		return err
+	}
+	// A scan task deletes the rows in many batches, so a random suffix is used to avoid overwriting the files.
+	name := path.Join(
+		fmt.Sprintf("%s.%s", e.tbl.Schema.O, e.tbl.Name.O),
+		fmt.Sprintf("%d.%s.%d.%s.%s%s", e.tbl.ID, jobID, scanID, uuid.NewString(), e.format, exportStagingSuffix),
+	)
+	if err = e.store.WriteFile(ctx, name, data); err != nil {
+		return err
+	}
+	e.staged = name
+	return nil
+}
+
+// finalize removes the staging suffix of the file written in the current transaction, it should be called after the
+// transaction is committed.
+func (e *ttlExporter) finalize(ctx context.Context) error {
+	if e.staged == "" {
+		return nil
+	}
+	name := e.staged
+	e.staged = ""
+	return e.store.Rename(ctx, name, strings.TrimSuffix(name, exportStagingSuffix))
+}
+
+// discard removes the file written in the current transaction, it should be called if the transaction is rolled
+// back, so that the rows which are not deleted are not exported.
+func (e *ttlExporter) discard(ctx context.Context) error {
+	if e.staged == "" {
+		return nil
+	}
+	name := e.staged
+	e.staged = ""
+	return e.store.DeleteFile(ctx, name)
+}
+
+func (e *ttlExporter) encodeCSV(rows []chunk.Row) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	record := make([]string, len(e.cols))
+	for i, col := range e.cols {
+		record[i] = col.Name.O
+	}
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for i, col := range e.cols {
+			if row.IsNull(i) {
+				record[i] = csvNullValue
+				continue
+			}
+			d := row.GetDatum(i, &col.FieldType)
+			str, err := d.ToString()
+			if err != nil {
+				return nil, err
+			}
+			record[i] = strings.ReplaceAll(str, `\`, `\\`)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (e *ttlExporter) encodeParquet(rows []chunk.Row) ([]byte, error) {
+	// All the columns are exported as optional strings, which keeps the same text representation with the CSV files.
+	md := make([]string, len(e.cols))
+	for i, col := range e.cols {
+		md[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", col.Name.O)
+	}
+
+	var buf bytes.Buffer
+	w, err := writer.NewCSVWriterFromWriter(md, &buf, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		record := make([]*string, len(e.cols))
+		for i, col := range e.cols {
+			if row.IsNull(i) {
+				continue
+			}
+			d := row.GetDatum(i, &col.FieldType)
+			str, err := d.ToString()
+			if err != nil {
+				return nil, err
+			}
+			record[i] = &str
+		}
+		if err = w.WriteString(record); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = w.WriteStop(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *ttlExporter) close() {
+	e.store.Close()
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/pkg/session/syssession"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/session"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -571,5 +572,15 @@ func (t *ttlScanTask) DoScan(ctx context.Context, delCh chan<- *TTLDeleteTask, s
 	return t.doScan(ctx, delCh, sessPool)
 }
 
+// GetError returns the error of the scan result for test.
+func (r *ttlScanTaskExecResult) GetError() error {
+	return r.err
+}
+
 // TTLDeleteTask is an exported version of `ttlDeleteTask` for test.
 type TTLDeleteTask = ttlDeleteTask
+
+// DoDelete is an exported version of `doDelete` for test.
+func (t *ttlDeleteTask) DoDelete(ctx context.Context, rawSe session.Session) (retryRows [][]types.Datum) {
+	return t.doDelete(ctx, rawSe)
+}
@@ -259,45 +259,67 @@ type ttlTableSession struct {
 }
 
 func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) ([]chunk.Row, bool, error) {
+	var result []chunk.Row
+	shouldRetry, err := s.ExecuteSQLsWithCheck(ctx, []string{sql}, func(_ int, rows []chunk.Row) error {
+		result = rows
+		return nil
+	})
+	if err != nil {
+		return nil, shouldRetry, err
+	}
+	return result, false, nil
+}
+
+// ExecuteSQLsWithCheck executes the SQLs in one transaction and checks the TTL meta after the first SQL is executed.
+// The `onResult` is called with the result of each SQL before the next one is executed, and the transaction
+// is rolled back if it returns an error.
+func (s *ttlTableSession) ExecuteSQLsWithCheck(ctx context.Context, sqls []string, onResult func(idx int, rows []chunk.Row) error) (bool, error) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
 
 	tracer.EnterPhase(metrics.PhaseOther)
 	if !vardef.EnableTTLJob.Load() {
-		return nil, false, errors.New("global TTL job is disabled")
+		return false, errors.New("global TTL job is disabled")
 	}
 
 	if err := s.ResetWithGlobalTimeZone(ctx); err != nil {
-		return nil, false, err
+		return false, err
 	}
 
-	var result []chunk.Row
 	shouldRetry := true
 	err := s.RunInTxn(ctx, func() error {
-		tracer.EnterPhase(metrics.PhaseQuery)
-		defer tracer.EnterPhase(tracer.Phase())
-		rows, err := s.ExecuteSQL(ctx, sql)
-		tracer.EnterPhase(metrics.PhaseCheckTTL)
-		// We must check the configuration after ExecuteSQL because of MDL and the meta the current transaction used
-		// can only be determined after executed one query.
-		if validateErr := validateTTLWork(ctx, s.Session, s.tbl, s.expire); validateErr != nil {
-			shouldRetry = false
-			return errors.Annotatef(validateErr, "table '%s.%s' meta changed, should abort current job", s.tbl.Schema, s.tbl.Name)
-		}
+		for i, sql := range sqls {
+			tracer.EnterPhase(metrics.PhaseQuery)
+			rows, err := s.ExecuteSQL(ctx, sql)
+			tracer.EnterPhase(metrics.PhaseCheckTTL)
+			// We must check the configuration after ExecuteSQL because of MDL and the meta the current transaction used
+			// can only be determined after executed one query.
+			if i == 0 {
+				if validateErr := validateTTLWork(ctx, s.Session, s.tbl, s.expire); validateErr != nil {
+					shouldRetry = false
+					return errors.Annotatef(validateErr, "table '%s.%s' meta changed, should abort current job", s.tbl.Schema, s.tbl.Name)
+				}
+			}
+			tracer.EnterPhase(metrics.PhaseOther)
 
-		if err != nil {
-			return err
-		}
+			if err != nil {
+				return err
+			}
 
-		result = rows
+			if onResult != nil {
+				if err = onResult(i, rows); err != nil {
+					return err
+				}
+			}
+		}
 		return nil
 	}, session.TxnModeOptimistic)
 
 	if err != nil {
-		return nil, shouldRetry, err
+		return shouldRetry, err
 	}
 
-	return result, false, nil
+	return false, nil
 }
 
 func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.PhysicalTable, expire time.Time) error {
@@ -337,6 +359,17 @@ func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.Physical
 		return errors.New("time column name changed")
 	}
 
+	if newTblInfo.TTLInfo.Condition != tbl.TTLInfo.Condition {
+		// the rows matched by the old condition may be deleted unexpectedly if the condition is narrowed
+		return errors.New("ttl condition changed")
+	}
+
+	oldAction, newAction := tbl.TTLInfo.Action, newTblInfo.TTLInfo.Action
+	if (oldAction == nil) != (newAction == nil) || (oldAction != nil && *oldAction != *newAction) {
+		// the expired rows must not be deleted without archiving or exporting them to the new target
+		return errors.New("ttl action changed")
+	}
+
 	if newTblInfo.TTLInfo.IntervalExprStr != tbl.TTLInfo.IntervalExprStr ||
 		newTblInfo.TTLInfo.IntervalTimeUnit != tbl.TTLInfo.IntervalTimeUnit {
 		newExpireTime, err := newTTLTbl.EvalExpireTime(ctx, s, s.Now())
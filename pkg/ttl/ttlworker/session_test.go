@@ -360,6 +360,29 @@ func TestValidateTTLWork(t *testing.T) {
 	err = validateTTLWork(ctx, s, tbl, expire)
 	require.EqualError(t, err, "time column name changed")
 
+	// test ttl condition changed
+	tbl2 = tbl.TableInfo.Clone()
+	tbl2.TTLInfo.Condition = "`deleted` = 1"
+	s.sessionInfoSchema = newMockInfoSchema(tbl2)
+	err = validateTTLWork(ctx, s, tbl, expire)
+	require.EqualError(t, err, "ttl condition changed")
+
+	// test ttl action changed
+	tbl2 = tbl.TableInfo.Clone()
+	tbl2.TTLInfo.Action = &model.TTLAction{Tp: ast.TTLActionArchive,
+		ArchiveSchema: ast.NewCIStr("test"), ArchiveTable: ast.NewCIStr("t1_archive")}
+	s.sessionInfoSchema = newMockInfoSchema(tbl2)
+	err = validateTTLWork(ctx, s, tbl, expire)
+	require.EqualError(t, err, "ttl action changed")
+
+	archiveTbl, err := cache.NewPhysicalTable(tbl.Schema, tbl2, ast.NewCIStr(""))
+	require.NoError(t, err)
+	tbl3 := tbl2.Clone()
+	tbl3.TTLInfo.Action.ArchiveTable = ast.NewCIStr("t1_archive2")
+	s.sessionInfoSchema = newMockInfoSchema(tbl3)
+	err = validateTTLWork(ctx, s, archiveTbl, expire)
+	require.EqualError(t, err, "ttl action changed")
+
 	// test interval changed and expire time before previous
 	tbl2 = tbl.TableInfo.Clone()
 	tbl2.TTLInfo.IntervalExprStr = "10"
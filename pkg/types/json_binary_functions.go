@@ -258,6 +258,13 @@ func (bj BinaryJSON) Extract(pathExprList []JSONPathExpression) (ret BinaryJSON,
 	return
 }
 
+// ExtractAll returns all the values matched by the path expression in document order.
+// Unlike Extract, the matched values are not wrapped into an array, which is needed
+// by JSON_TABLE to produce one row for each of them.
+func (bj BinaryJSON) ExtractAll(pathExpr JSONPathExpression) []BinaryJSON {
+	return bj.extractTo(make([]BinaryJSON, 0, 1), pathExpr, make(map[*byte]struct{}), false)
+}
+
 func (bj BinaryJSON) extractOne(pathExpr JSONPathExpression) []BinaryJSON {
 	result := make([]BinaryJSON, 0, 1)
 	return bj.extractTo(result, pathExpr, nil, true)
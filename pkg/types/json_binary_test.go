@@ -542,6 +542,32 @@ func TestFunctions(t *testing.T) {
 	require.EqualError(t, err, "Cant peek from empty bytes")
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, "2", {"aa": "bb"}, [3, 4]], "b": true}`)
+
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$", []string{`{"a": [1, "2", {"aa": "bb"}, [3, 4]], "b": true}`}},
+		{"$.a[*]", []string{`1`, `"2"`, `{"aa": "bb"}`, `[3, 4]`}},
+		{"$.a[3]", []string{`[3, 4]`}},
+		{"$.a[*].aa", []string{`"bb"`}},
+		{"$.b[0]", []string{`true`}},
+		{"$.c", nil},
+		{"$.b[*]", nil},
+	}
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pe)
+		require.Len(t, result, len(test.expected), test.pathExpr)
+		for i, expected := range test.expected {
+			require.Equal(t, 0, CompareBinaryJSON(mustParseBinaryFromString(t, expected), result[i]), test.pathExpr)
+		}
+	}
+}
+
 func TestBinaryJSONExtractCallback(t *testing.T) {
 	bj1 := mustParseBinaryFromString(t, `{"\"hello\"": "world", "a": [1, "2", {"aa": "bb"}, 4.0, {"aa": "cc"}], "b": true, "c": ["d"]}`)
 	bj2 := mustParseBinaryFromString(t, `[{"a": 1, "b": true}, 3, 3.5, "hello, world", null, true]`)
@@ -68,7 +68,8 @@ const (
 // It returns the count greater type, the index offset and an error.
 // It returns nil if the count from the index is equal to the count from the table columns,
 // otherwise it returns an error and the corresponding index's offset.
-func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices []string) (byte, int, error) {
+// Only the specified partitions are counted if partitions is not empty.
+func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, partitions []string, indices []string) (byte, int, error) {
 	// Here we need check all indexes, includes invisible index
 	originOptUseInvisibleIdx := ctx.GetSessionVars().OptimizerUseInvisibleIndexes
 	ctx.GetSessionVars().OptimizerUseInvisibleIndexes = true
@@ -90,12 +91,25 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 
 	// Add `` for some names like `table name`.
 	exec := ctx.GetRestrictedSQLExecutor()
-	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX()", dbName, tableName)
+	var partitionClause strings.Builder
+	args := []any{dbName, tableName}
+	if len(partitions) > 0 {
+		partitionClause.WriteString(" PARTITION(")
+		for i, partition := range partitions {
+			if i > 0 {
+				partitionClause.WriteString(", ")
+			}
+			partitionClause.WriteString("%n")
+			args = append(args, partition)
+		}
+		partitionClause.WriteString(")")
+	}
+	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n"+partitionClause.String()+" USE INDEX()", args...)
 	if err != nil {
 		return 0, 0, errors.Trace(err)
 	}
 	for i, idx := range indices {
-		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX(%n)", dbName, tableName, idx)
+		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n"+partitionClause.String()+" USE INDEX(%n)", append(args, idx)...)
 		if err != nil {
 			return 0, i, errors.Trace(err)
 		}
@@ -512,6 +512,71 @@ var (
 	ErrEngineAttributeInvalidFormat = ClassDDL.NewStd(mysql.ErrEngineAttributeInvalidFormat)
 	// ErrStorageClassInvalidSpec is reserved for future use.
 	ErrStorageClassInvalidSpec = ClassDDL.NewStd(mysql.ErrStorageClassInvalidSpec)
+
+	// ErrSpNoRecursiveCreate is returned when creating a stored routine inside another one.
+	ErrSpNoRecursiveCreate = ClassDDL.NewStd(mysql.ErrSpNoRecursiveCreate)
+	// ErrSpDupParam is returned when a stored routine has duplicate parameters.
+	ErrSpDupParam = ClassDDL.NewStd(mysql.ErrSpDupParam)
+	// ErrSpDupVar is returned when a variable is declared twice in the same block.
+	ErrSpDupVar = ClassDDL.NewStd(mysql.ErrSpDupVar)
+	// ErrSpDupCurs is returned when a cursor is declared twice in the same block.
+	ErrSpDupCurs = ClassDDL.NewStd(mysql.ErrSpDupCurs)
+	// ErrSpBadreturn is returned when a stored procedure contains RETURN.
+	ErrSpBadreturn = ClassDDL.NewStd(mysql.ErrSpBadreturn)
+	// ErrSpNoreturn is returned when a stored function contains no RETURN.
+	ErrSpNoreturn = ClassDDL.NewStd(mysql.ErrSpNoreturn)
+	// ErrSpLabelMismatch is returned when the end label doesn't match the begin label.
+	ErrSpLabelMismatch = ClassDDL.NewStd(mysql.ErrSpLabelMismatch)
+	// ErrSpLabelRedefine is returned when a label is redefined in nested blocks.
+	ErrSpLabelRedefine = ClassDDL.NewStd(mysql.ErrSpLabelRedefine)
+	// ErrSpLilabelMismatch is returned when LEAVE or ITERATE refers to an unknown label.
+	ErrSpLilabelMismatch = ClassDDL.NewStd(mysql.ErrSpLilabelMismatch)
+	// ErrSpCursorMismatch is returned when a cursor is used without being declared.
+	ErrSpCursorMismatch = ClassDDL.NewStd(mysql.ErrSpCursorMismatch)
+	// ErrSpUndeclaredVar is returned when a variable is used without being declared.
+	ErrSpUndeclaredVar = ClassDDL.NewStd(mysql.ErrSpUndeclaredVar)
+	// ErrSpVarcondAfterCurshndlr is returned when a variable is declared after a cursor or handler.
+	ErrSpVarcondAfterCurshndlr = ClassDDL.NewStd(mysql.ErrSpVarcondAfterCurshndlr)
+	// ErrSpCursorAfterHandler is returned when a cursor is declared after a handler.
+	ErrSpCursorAfterHandler = ClassDDL.NewStd(mysql.ErrSpCursorAfterHandler)
+	// ErrSpBadCursorQuery is returned when a cursor is not declared for a SELECT.
+	ErrSpBadCursorQuery = ClassDDL.NewStd(mysql.ErrSpBadCursorQuery)
+	// ErrSpBadCursorSelect is returned when the cursor SELECT has an INTO clause.
+	ErrSpBadCursorSelect = ClassDDL.NewStd(mysql.ErrSpBadCursorSelect)
+
+	// ErrTrgAlreadyExists is returned when the trigger already exists in the schema.
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist is returned when the trigger doesn't exist.
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgCantChangeRow is returned when a trigger assigns a row which can't be changed.
+	ErrTrgCantChangeRow = ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg is returned when a trigger refers to a row which doesn't exist for its event.
+	ErrTrgNoSuchRowInTrg = ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	// ErrTrgInWrongSchema is returned when the trigger and its table are in different schemas.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema is returned when creating a trigger on a system table.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrSpNoRetset is returned when a trigger returns a result set.
+	ErrSpNoRetset = ClassDDL.NewStd(mysql.ErrSpNoRetset)
+	// ErrCommitNotAllowedInSfOrTrg is returned when a trigger contains a statement which commits implicitly or explicitly.
+	ErrCommitNotAllowedInSfOrTrg = ClassDDL.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+
+	// ErrEventIntervalNotPositiveOrTooBig is returned when the interval of an event is not positive or too big.
+	ErrEventIntervalNotPositiveOrTooBig = ClassDDL.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	// ErrEventEndsBeforeStarts is returned when the ENDS of an event is before its STARTS.
+	ErrEventEndsBeforeStarts = ClassDDL.NewStd(mysql.ErrEventEndsBeforeStarts)
+	// ErrEventExecTimeInThePast is returned as a note when an event is disabled because its execution time is in the past.
+	ErrEventExecTimeInThePast = ClassDDL.NewStd(mysql.ErrEventExecTimeInThePast)
+	// ErrEventCannotCreateInThePast is returned as a note when an event is not created because its execution time is in the past.
+	ErrEventCannotCreateInThePast = ClassDDL.NewStd(mysql.ErrEventCannotCreateInThePast)
+	// ErrEventCannotAlterInThePast is returned as a note when an event is not altered because its execution time is in the past.
+	ErrEventCannotAlterInThePast = ClassDDL.NewStd(mysql.ErrEventCannotAlterInThePast)
+	// ErrEventSameName is returned when an event is renamed to its own name.
+	ErrEventSameName = ClassDDL.NewStd(mysql.ErrEventSameName)
+	// ErrEventRecursionForbidden is returned when the body of an event creates or alters an event with a body.
+	ErrEventRecursionForbidden = ClassDDL.NewStd(mysql.ErrEventRecursionForbidden)
 )
 
 // ReorgRetryableErrCodes are the error codes that are retryable for reorganization.
@@ -97,6 +97,7 @@ var (
 	ErrCTERecursiveForbidsAggregation        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbidsAggregation)
 	ErrCTERecursiveForbiddenJoinOrder        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbiddenJoinOrder)
 	ErrInvalidRequiresSingleReference        = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidRequiresSingleReference)
+	ErrTFForbiddenJoinType                   = dbterror.ClassOptimizer.NewStd(mysql.ErrTFForbiddenJoinType)
 	ErrSQLInReadOnlyMode                     = dbterror.ClassOptimizer.NewStd(mysql.ErrReadOnlyMode)
 	ErrDeleteNotFoundColumn                  = dbterror.ClassOptimizer.NewStd(mysql.ErrDeleteNotFoundColumn)
 	// Since we cannot know if user logged in with a password, use message of ErrAccessDeniedNoPassword instead
@@ -121,4 +122,8 @@ var (
 	ErrRowIsReferenced2 = dbterror.ClassOptimizer.NewStd(mysql.ErrRowIsReferenced2)
 	ErrNoReferencedRow2 = dbterror.ClassOptimizer.NewStd(mysql.ErrNoReferencedRow2)
 	ErrSpDoesNotExist   = dbterror.ClassOptimizer.NewStd(mysql.ErrSpDoesNotExist)
+	ErrProcaccessDenied = dbterror.ClassOptimizer.NewStd(mysql.ErrProcaccessDenied)
+	ErrSpUndeclaredVar  = dbterror.ClassOptimizer.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpNoRecursion    = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoRecursion)
+	ErrSpWrongNoOfArgs  = dbterror.ClassOptimizer.NewStd(mysql.ErrSpWrongNoOfArgs)
 )
@@ -23,18 +23,25 @@ package workloadlearning
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/topsql/stmtstats"
 	"go.uber.org/zap"
 )
 
@@ -48,15 +55,120 @@ const (
 	tableReadCost = "TableReadCost"
 )
 
+// maxLearnedPlanDigests limits the number of plan digests whose tables are remembered from statement summary.
+const maxLearnedPlanDigests = 10000
+
+// stmtStatsBucketDuration is the time range of a bucket of the Top SQL statement stats, the buckets are aligned to it.
+// The statement summary windows are aligned to the refresh interval, so a bucket doesn't cross the boundaries of the
+// windows as long as the refresh interval is a multiple of it, which is true by default.
+const stmtStatsBucketDuration = time.Minute
+
+// maxStmtStatsBuckets limits the buckets waiting for the statement summary windows covering them to end, the oldest
+// ones are dropped if the windows don't end in time, e.g. the statement summary is disabled.
+const maxStmtStatsBuckets = 24 * 60
+
 // Handle The entry point for all workload-based learning related tasks
 type Handle struct {
 	sysSessionPool util.DestroyableSessionPool
+
+	mu struct {
+		sync.Mutex
+		// collecting is whether the handle is registered to collect the Top SQL statement stats.
+		collecting bool
+		// stmtStats are the buckets of the Top SQL statement stats which haven't been analyzed, ordered by time.
+		// A bucket is analyzed after the statement summary windows covering it end, so that a statement is only
+		// counted by either of them.
+		stmtStats []*stmtStatsBucket
+		// summaryEndTime is the latest end time of the statement summary windows which have been analyzed.
+		summaryEndTime types.Time
+		// planTables records the tables read by each plan digest in statement summary, it's used to attribute the
+		// Top SQL statement stats, which don't contain the table names, to tables.
+		planTables map[string][]tableName
+	}
+}
+
+// stmtStatsBucket is the Top SQL statement stats collected in [begin, begin+stmtStatsBucketDuration).
+type stmtStatsBucket struct {
+	begin time.Time
+	stats stmtstats.StatementStatsMap
+}
+
+type tableName struct {
+	db    ast.CIStr
+	table ast.CIStr
 }
 
 // NewWorkloadLearningHandle Create a new WorkloadLearningHandle
 // WorkloadLearningHandle is Singleton pattern
 func NewWorkloadLearningHandle(pool util.DestroyableSessionPool) *Handle {
-	return &Handle{pool}
+	handle := &Handle{sysSessionPool: pool}
+	handle.mu.planTables = make(map[string][]tableName)
+	return handle
+}
+
+// StartCollectingStmtStats registers the handle to collect the Top SQL statement stats, which are only collected
+// when Top SQL is enabled. It does nothing if the handle has been registered.
+func (handle *Handle) StartCollectingStmtStats() {
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+	if handle.mu.collecting {
+		return
+	}
+	handle.mu.collecting = true
+	handle.mu.stmtStats = nil
+	stmtstats.RegisterCollector(handle)
+}
+
+// StopCollectingStmtStats unregisters the handle from Top SQL and drops the statement stats collected, so they're
+// not accumulated on the nodes which don't analyze them.
+func (handle *Handle) StopCollectingStmtStats() {
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+	if !handle.mu.collecting {
+		return
+	}
+	handle.mu.collecting = false
+	handle.mu.stmtStats = nil
+	stmtstats.UnregisterCollector(handle)
+}
+
+// CollectStmtStatsMap implements stmtstats.Collector.
+// The Top SQL statement stats are accumulated into the bucket of the collecting time until they're analyzed.
+func (handle *Handle) CollectStmtStatsMap(data stmtstats.StatementStatsMap) {
+	now := time.Now()
+	failpoint.Inject("mockStmtStatsCollectTime", func(val failpoint.Value) {
+		// mockStmtStatsCollectTime takes string of Unix timestamp
+		if unixTime, err := strconv.ParseInt(val.(string), 10, 64); err == nil {
+			now = time.Unix(unixTime, 0)
+		}
+	})
+	begin := now.Truncate(stmtStatsBucketDuration)
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+	// the stats may be reported after the handle is unregistered.
+	if !handle.mu.collecting {
+		return
+	}
+	var bucket *stmtStatsBucket
+	for i := len(handle.mu.stmtStats) - 1; i >= 0; i-- {
+		if handle.mu.stmtStats[i].begin.Equal(begin) {
+			bucket = handle.mu.stmtStats[i]
+			break
+		}
+	}
+	if bucket == nil {
+		if len(handle.mu.stmtStats) >= maxStmtStatsBuckets {
+			logutil.BgLogger().Info("drop the oldest Top SQL statement stats not covered by statement summary",
+				zap.Time("begin", handle.mu.stmtStats[0].begin))
+			handle.mu.stmtStats = handle.mu.stmtStats[1:]
+		}
+		bucket = &stmtStatsBucket{begin: begin, stats: stmtstats.StatementStatsMap{}}
+		handle.mu.stmtStats = append(handle.mu.stmtStats, bucket)
+		slices.SortFunc(handle.mu.stmtStats, func(a, b *stmtStatsBucket) int {
+			return a.begin.Compare(b.begin)
+		})
+	}
+	bucket.stats.Merge(data)
 }
 
 // HandleTableReadCost Start a new round of analysis of all historical table read queries.
@@ -73,51 +185,204 @@ func NewWorkloadLearningHandle(pool util.DestroyableSessionPool) *Handle {
 // 4. Calculate table cost for each table, table cost = table scan time / total scan time + table mem usage / total mem usage
 // 5. Save all table cost metrics[per table](scan time, table cost, etc) to table "mysql.tidb_workload_values"
 func (handle *Handle) HandleTableReadCost(infoSchema infoschema.InfoSchema) {
-	// step1: abstract middle table cost metrics from every record in statement_summary
-	middleMetrics, startTime, endTime := handle.analyzeBasedOnStatementStats()
+	// step1: abstract middle table cost metrics from every record in statement_summary and statement_stats,
+	// the statement_stats are only analyzed when the ended statement summary windows cover them, and the ones of the
+	// plans found in these windows are skipped to avoid double counting.
+	summaryMetrics, summaryPlans, startTime := handle.analyzeBasedOnStatementSummary()
+	statsMetrics, statsStartTime, endTime := handle.analyzeBasedOnStatementStats(summaryPlans)
+	middleMetrics := append(summaryMetrics, statsMetrics...)
 	if len(middleMetrics) == 0 {
 		return
 	}
+	if statsStartTime.Before(startTime) {
+		startTime = statsStartTime
+	}
 	// step2: group by tablename, sum(table-scan-time), sum(table-mem-usage), sum(read-frequency)
 	// step3: calculate the total scan time and total memory usage
 	tableNameToMetrics := make(map[ast.CIStr]*TableReadCostMetrics)
 	totalScanTime := 0.0
 	totalMemUsage := 0.0
 	for _, middleMetric := range middleMetrics {
-		metric, ok := tableNameToMetrics[middleMetric.TableName]
+		// tables with the same name may exist in different databases.
+		key := ast.NewCIStr(middleMetric.DbName.O + "." + middleMetric.TableName.O)
+		metric, ok := tableNameToMetrics[key]
 		if !ok {
-			tableNameToMetrics[middleMetric.TableName] = middleMetric
+			tableNameToMetrics[key] = middleMetric
 		} else {
-			metric.TableScanTime += middleMetric.TableScanTime * float64(middleMetric.ReadFrequency)
-			metric.TableMemUsage += middleMetric.TableMemUsage * float64(middleMetric.ReadFrequency)
+			metric.TableScanTime += middleMetric.TableScanTime
+			metric.TableMemUsage += middleMetric.TableMemUsage
 			metric.ReadFrequency += middleMetric.ReadFrequency
 		}
 		totalScanTime += middleMetric.TableScanTime
 		totalMemUsage += middleMetric.TableMemUsage
 	}
-	if totalScanTime == 0 || totalMemUsage == 0 {
+	if totalScanTime == 0 && totalMemUsage == 0 {
 		return
 	}
 	// step4: calculate the percentage of scan time and memory usage for each table
 	for _, metric := range tableNameToMetrics {
-		metric.TableReadCost = metric.TableScanTime/totalScanTime + metric.TableMemUsage/totalMemUsage
+		metric.TableReadCost = 0
+		// the memory usage is unknown if all metrics come from statement_stats.
+		if totalScanTime > 0 {
+			metric.TableReadCost += metric.TableScanTime / totalScanTime
+		}
+		if totalMemUsage > 0 {
+			metric.TableReadCost += metric.TableMemUsage / totalMemUsage
+		}
 	}
 	// step5: save the table cost metrics to table "mysql.tidb_workload_values"
 	handle.SaveTableReadCostMetrics(tableNameToMetrics, startTime, endTime, infoSchema)
 }
 
-func (*Handle) analyzeBasedOnStatementSummary() []*TableReadCostMetrics {
-	// step1: get all record from statement_summary
+// analyzeBasedOnStatementSummary abstracts the table cost metrics from the read statements in the statement summary
+// history of the whole cluster, it also returns the plan digests of these statements.
+// Only the windows ended since the last round are analyzed, the current windows are still accumulating and would be
+// counted twice otherwise.
+// The costs of a statement are split evenly to the tables it reads, since they are not recorded per table.
+func (handle *Handle) analyzeBasedOnStatementSummary() (middleMetrics []*TableReadCostMetrics,
+	planDigests map[string]struct{}, startTime time.Time) {
+	startTime = time.Now()
+	planDigests = make(map[string]struct{})
+	se, err := handle.sysSessionPool.Get()
+	if err != nil {
+		logutil.BgLogger().Warn("get system session failed when reading statement summary", zap.Error(err))
+		return nil, planDigests, startTime
+	}
+	defer func() {
+		if err == nil { // only recycle when no error
+			handle.sysSessionPool.Put(se)
+		} else {
+			// Note: Otherwise, the session will be leaked.
+			handle.sysSessionPool.Destroy(se)
+		}
+	}()
+	sctx := se.(sessionctx.Context)
+	exec := sctx.GetRestrictedSQLExecutor()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnWorkloadLearning)
+
+	handle.mu.Lock()
+	summaryEndTime := handle.mu.summaryEndTime
+	handle.mu.Unlock()
+	// step1: get the records of the ended windows from statement_summary
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "select summary_begin_time, summary_end_time, plan_digest, table_names, exec_count, "+
+		"avg_process_time, avg_mem from information_schema.cluster_statements_summary_history "+
+		"where stmt_type = 'Select' and exec_count > 0 and summary_end_time <= now()")
+	if !summaryEndTime.IsZero() {
+		sqlescape.MustFormatSQL(sql, " and summary_end_time > %?", summaryEndTime.String())
+	}
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql.String())
+	if err != nil {
+		logutil.BgLogger().Warn("read statement summary failed when analyzing table cost", zap.Error(err))
+		return nil, planDigests, startTime
+	}
+
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
 	// step2: abstract table cost metrics from each record
-	return nil
+	for _, row := range rows {
+		if beginTime, err := row.GetTime(0).GoTime(time.Local); err == nil && beginTime.Before(startTime) {
+			startTime = beginTime
+		}
+		if endTime := row.GetTime(1); endTime.Compare(handle.mu.summaryEndTime) > 0 {
+			handle.mu.summaryEndTime = endTime
+		}
+		planDigest := row.GetString(2)
+		tables := parseTableNames(row.GetString(3))
+		if len(tables) == 0 {
+			continue
+		}
+		planDigests[planDigest] = struct{}{}
+		if _, ok := handle.mu.planTables[planDigest]; !ok {
+			if len(handle.mu.planTables) >= maxLearnedPlanDigests {
+				clear(handle.mu.planTables)
+			}
+			handle.mu.planTables[planDigest] = tables
+		}
+		execCount := row.GetUint64(4)
+		sumProcessTime := time.Duration(row.GetUint64(5) * execCount)
+		sumMem := row.GetUint64(6) * execCount
+		for _, tbl := range tables {
+			middleMetrics = append(middleMetrics, &TableReadCostMetrics{
+				DbName:        tbl.db,
+				TableName:     tbl.table,
+				TableScanTime: sumProcessTime.Seconds() / float64(len(tables)),
+				TableMemUsage: float64(sumMem) / float64(len(tables)),
+				ReadFrequency: int64(execCount),
+			})
+		}
+	}
+	return middleMetrics, planDigests, startTime
 }
 
-// TODO
-func (*Handle) analyzeBasedOnStatementStats() (middleMetrics []*TableReadCostMetrics, startTime, endTime time.Time) {
-	// step1: get all record from statement_stats
-	// step2: abstract table cost metrics from each record
-	// TODO change the mock value
-	return nil, time.Now(), time.Now()
+// analyzeBasedOnStatementStats abstracts the table cost metrics from the Top SQL statement stats covered by the
+// statement summary windows which have ended, the later ones are kept for the next rounds.
+// The covered stats overlap with the statement summary windows analyzed in this round, so the ones of the plan digests
+// found in these windows are skipped, and only the statements missing from statement_summary, e.g. evicted ones,
+// are counted. The stats don't contain the table names, so they are attributed to tables by the plan digests learned
+// from statement_summary.
+// The execution duration is taken as the scan time, and the memory usage is unknown.
+func (handle *Handle) analyzeBasedOnStatementStats(skippedPlanDigests map[string]struct{}) (
+	middleMetrics []*TableReadCostMetrics, startTime, endTime time.Time) {
+	startTime, endTime = time.Now(), time.Now()
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+	if handle.mu.summaryEndTime.IsZero() {
+		return nil, startTime, endTime
+	}
+	coveredEnd, err := handle.mu.summaryEndTime.GoTime(time.Local)
+	if err != nil {
+		logutil.BgLogger().Warn("invalid end time of statement summary", zap.Error(err))
+		return nil, startTime, endTime
+	}
+	// step1: get the records covered by statement_summary from statement_stats
+	var pending []*stmtStatsBucket
+	for _, bucket := range handle.mu.stmtStats {
+		if bucket.begin.Add(stmtStatsBucketDuration).After(coveredEnd) {
+			pending = append(pending, bucket)
+			continue
+		}
+		if bucket.begin.Before(startTime) {
+			startTime = bucket.begin
+		}
+		// step2: abstract table cost metrics from each record
+		for digest, item := range bucket.stats {
+			if item.ExecCount == 0 {
+				continue
+			}
+			planDigest := hex.EncodeToString([]byte(digest.PlanDigest))
+			if _, ok := skippedPlanDigests[planDigest]; ok {
+				continue
+			}
+			tables, ok := handle.mu.planTables[planDigest]
+			if !ok {
+				continue
+			}
+			for _, tbl := range tables {
+				middleMetrics = append(middleMetrics, &TableReadCostMetrics{
+					DbName:        tbl.db,
+					TableName:     tbl.table,
+					TableScanTime: time.Duration(item.SumDurationNs).Seconds() / float64(len(tables)),
+					ReadFrequency: int64(item.ExecCount),
+				})
+			}
+		}
+	}
+	handle.mu.stmtStats = pending
+	return middleMetrics, startTime, endTime
+}
+
+// parseTableNames parses the table names of statement_summary, which are like "db1.t1,db2.t2".
+func parseTableNames(tableNames string) []tableName {
+	var tables []tableName
+	for _, name := range strings.Split(tableNames, ",") {
+		db, table, ok := strings.Cut(name, ".")
+		if !ok || table == "" {
+			continue
+		}
+		tables = append(tables, tableName{db: ast.NewCIStr(db), table: ast.NewCIStr(table)})
+	}
+	return tables
 }
 
 // SaveTableReadCostMetrics table cost metrics, workload-based start and end time, version,
@@ -15,13 +15,27 @@
 package workloadlearning_test
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/server"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/stmtsummary"
+	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
+	"github.com/pingcap/tidb/pkg/util/topsql/stmtstats"
 	"github.com/pingcap/tidb/pkg/workloadlearning"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestSaveReadTableCostMetrics(t *testing.T) {
@@ -48,3 +62,133 @@ func TestSaveReadTableCostMetrics(t *testing.T) {
 	result := tk.MustQuery("select * from mysql.tidb_workload_values").Rows()
 	require.Equal(t, 1, len(result))
 }
+
+// createRPCServer starts the RPC service of TiDB, which serves the cluster tables such as
+// cluster_statements_summary_history.
+func createRPCServer(t *testing.T, dom *domain.Domain) *grpc.Server {
+	sm := &testkit.MockSessionManager{}
+	sm.PS = append(sm.PS, &util.ProcessInfo{
+		ID:      1,
+		User:    "root",
+		Host:    "127.0.0.1",
+		Command: mysql.ComQuery,
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := server.NewRPCServer(config.GetGlobalConfig(), dom, sm)
+	port := lis.Addr().(*net.TCPAddr).Port
+	go func() {
+		err = srv.Serve(lis)
+		require.NoError(t, err)
+	}()
+
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Status.StatusPort = uint(port)
+		conf.AdvertiseAddress = "127.0.0.1"
+	})
+	return srv
+}
+
+func TestHandleTableReadCost(t *testing.T) {
+	defer config.RestoreFunc()()
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := createRPCServer(t, dom)
+	defer srv.Stop()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec("create table t1 (a int, b int, index idx(a))")
+	tk.MustExec("create table t2 (a int, b int)")
+
+	// the windows of statement summary are aligned to the refresh interval, which is 30 minutes by default,
+	// window1 and window2 are two successive windows which have ended.
+	window1 := (time.Now().Unix()/1800 - 3) * 1800
+	window2 := window1 + 1800
+	handle := workloadlearning.NewWorkloadLearningHandle(dom.SysSessionPool())
+	handle.StartCollectingStmtStats()
+	defer handle.StopCollectingStmtStats()
+	addRecord := func(digest, planDigest string, tables ...string) {
+		info := stmtsummaryv2.GenerateStmtExecInfo4Test(digest)
+		info.PlanDigest = planDigest
+		info.StmtCtx.Tables = nil
+		for _, tbl := range tables {
+			info.StmtCtx.Tables = append(info.StmtCtx.Tables, stmtctx.TableEntry{DB: "test", Table: tbl})
+		}
+		stmtsummary.StmtSummaryByDigestMap.AddStatement(info)
+	}
+	collectStmtStats := func(unixTime int64, stats stmtstats.StatementStatsMap) {
+		testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/workloadlearning/mockStmtStatsCollectTime",
+			fmt.Sprintf(`return("%v")`, unixTime))
+		handle.CollectStmtStatsMap(stats)
+		testfailpoint.Disable(t, "github.com/pingcap/tidb/pkg/workloadlearning/mockStmtStatsCollectTime")
+	}
+	// the records are added to window1, the statements executed afterwards go to the current window which hasn't
+	// ended, so they're not analyzed.
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	defer stmtsummary.StmtSummaryByDigestMap.Clear()
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/util/stmtsummary/mockTimeForStatementsSummary",
+		fmt.Sprintf(`return("%v")`, window1))
+	// each record has 500ns process time and 10000 bytes memory usage.
+	addRecord("digest1", "0101", "t1")
+	addRecord("digest1", "0101", "t1")
+	addRecord("digest2", "0202", "t1", "t2")
+	testfailpoint.Disable(t, "github.com/pingcap/tidb/pkg/util/stmtsummary/mockTimeForStatementsSummary")
+	// the statement stats of window1 are ignored because their plans are counted by statement summary.
+	collectStmtStats(window1+60, stmtstats.StatementStatsMap{
+		{SQLDigest: "digest1", PlanDigest: "\x01\x01"}: &stmtstats.StatementStatsItem{ExecCount: 100, SumDurationNs: 100000},
+	})
+	handle.HandleTableReadCost(dom.InfoSchema())
+
+	worker := workloadlearning.NewWLCacheWorker(dom.SysSessionPool())
+	worker.UpdateTableReadCostCache()
+	tbl1, err := dom.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t1"))
+	require.NoError(t, err)
+	tbl2, err := dom.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t2"))
+	require.NoError(t, err)
+	metrics := worker.GetTableReadCostMetrics(tbl1.Meta().ID)
+	require.NotNil(t, metrics)
+	require.Equal(t, int64(3), metrics.ReadFrequency)
+	require.InDelta(t, 1250e-9, metrics.TableScanTime, 1e-12)
+	require.InDelta(t, 25000.0, metrics.TableMemUsage, 1e-9)
+	require.InDelta(t, 1.666, metrics.TableReadCost, 0.001)
+	metrics = worker.GetTableReadCostMetrics(tbl2.Meta().ID)
+	require.NotNil(t, metrics)
+	require.Equal(t, int64(1), metrics.ReadFrequency)
+	require.InDelta(t, 0.333, metrics.TableReadCost, 0.001)
+
+	// the statement stats of window2 are kept until window2 ends.
+	collectStmtStats(window2+60, stmtstats.StatementStatsMap{
+		{SQLDigest: "digest1", PlanDigest: "\x01\x01"}: &stmtstats.StatementStatsItem{ExecCount: 5, SumDurationNs: 5000},
+		{SQLDigest: "digest2", PlanDigest: "\x02\x02"}: &stmtstats.StatementStatsItem{ExecCount: 4, SumDurationNs: 3000},
+		{SQLDigest: "digest3", PlanDigest: "\x03\x03"}: &stmtstats.StatementStatsItem{ExecCount: 5, SumDurationNs: 5000},
+	})
+	handle.HandleTableReadCost(dom.InfoSchema())
+	tk.MustQuery("select count(distinct version) from mysql.tidb_workload_values").Check(testkit.Rows("1"))
+
+	// window2 ends with only digest2 in statement summary, e.g. digest1 is evicted, so the statement stats of
+	// digest1 are counted, while the ones of digest2 are skipped, and the ones of digest3 can't be attributed.
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/util/stmtsummary/mockTimeForStatementsSummary",
+		fmt.Sprintf(`return("%v")`, window2))
+	addRecord("digest2", "0202", "t1", "t2")
+	testfailpoint.Disable(t, "github.com/pingcap/tidb/pkg/util/stmtsummary/mockTimeForStatementsSummary")
+	handle.HandleTableReadCost(dom.InfoSchema())
+	tk.MustQuery("select count(distinct version) from mysql.tidb_workload_values").Check(testkit.Rows("2"))
+	worker.UpdateTableReadCostCache()
+	metrics = worker.GetTableReadCostMetrics(tbl1.Meta().ID)
+	require.NotNil(t, metrics)
+	require.Equal(t, int64(6), metrics.ReadFrequency)
+	require.InDelta(t, 5250e-9, metrics.TableScanTime, 1e-12)
+	require.InDelta(t, 5000.0, metrics.TableMemUsage, 1e-9)
+	require.InDelta(t, 1.4545, metrics.TableReadCost, 0.001)
+	metrics = worker.GetTableReadCostMetrics(tbl2.Meta().ID)
+	require.NotNil(t, metrics)
+	require.Equal(t, int64(1), metrics.ReadFrequency)
+	require.InDelta(t, 250e-9, metrics.TableScanTime, 1e-12)
+	require.InDelta(t, 0.5454, metrics.TableReadCost, 0.001)
+
+	// nothing is saved if there is no new workload.
+	handle.HandleTableReadCost(dom.InfoSchema())
+	tk.MustQuery("select count(distinct version) from mysql.tidb_workload_values").Check(testkit.Rows("2"))
+}